@@ -0,0 +1,72 @@
+package analyze
+
+import (
+	"os"
+
+	"kctl/cmd"
+	"kctl/internal/console"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbPath    string
+	rulesFile string
+	failOn    string
+)
+
+// AnalyzeCmd 是 analyze 子命令
+var AnalyzeCmd = &cobra.Command{
+	Use:   "analyze <pods.json>",
+	Short: "离线分析预先获取的 Kubelet /pods JSON",
+	Long: `离线分析预先获取的 Kubelet /pods 响应（或 kubectl get pods -o json 的输出），不访问网络
+
+适用于只能在短暂的访问窗口内获取原始 JSON 的场景：
+  - 标记特权、hostPath、hostPID、hostNetwork、Secret 挂载等风险
+  - 按 Pod 规格评估 SA 风险（同 sa scan --passive，不读取 Token、不检查 RBAC）
+  - 检查容器环境变量中的明文凭据
+  - 识别 EKS / GKE / AKS 并检查云身份配置
+
+示例：
+  # 在访问窗口内获取原始 JSON
+  curl -sk -H "Authorization: Bearer $TOKEN" https://10.0.0.1:10250/pods > pods.json
+
+  # 离线分析
+  kctl analyze pods.json
+
+  # 保存结果到数据库，之后用控制台浏览
+  kctl analyze pods.json --db field.db
+  kctl console --viewer --db field.db
+
+  # 在 CI 中使用：存在 CRITICAL 及以上的问题时以退出码 3 退出
+  kctl analyze pods.json --fail-on critical`,
+	Args: cobra.ExactArgs(1),
+	Run:  runAnalyze,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(AnalyzeCmd)
+
+	AnalyzeCmd.Flags().StringVar(&dbPath, "db", "", "将结果写入数据库文件或 postgres:// 连接串（默认使用内存数据库）")
+	AnalyzeCmd.Flags().StringVar(&rulesFile, "rules", "", "自定义权限检查/风险规则文件 (YAML/JSON)")
+	AnalyzeCmd.Flags().StringVar(&failOn, "fail-on", "", "存在不低于该风险等级的问题时以退出码 3 退出 [admin|critical|high|medium|low]")
+}
+
+func runAnalyze(cmd *cobra.Command, args []string) {
+	console.RegisterCommands()
+
+	c, err := console.NewWithOptions(console.Options{
+		RulesFile: rulesFile,
+		DBPath:    dbPath,
+		FailOn:    failOn,
+	})
+	if err != nil {
+		log.Errorf("创建控制台失败: %v", err)
+		os.Exit(1)
+	}
+
+	code := c.RunOffline([]string{"import", "pods", args[0]})
+	c.Close()
+	os.Exit(code)
+}
@@ -1,6 +1,8 @@
 package console
 
 import (
+	"os"
+
 	"kctl/cmd"
 	"kctl/internal/console"
 
@@ -12,11 +14,25 @@ var (
 	// 命令行参数
 	target    string
 	port      int
+	profile   string
 	tokenFile string
 	tokenStr  string
+	certFile  string
+	keyFile   string
+	kubeCfg   string
+	kubeCtx   string
 	proxy     string
 	apiServer string
 	apiPort   int
+	execLine  string
+	rulesFile string
+	dbPath    string
+	viewer    bool
+	bridge    bool
+	rawDump   string
+	failOn    string
+	restore   bool
+	readOnly  bool
 )
 
 // ConsoleCmd 是 console 子命令
@@ -27,7 +43,7 @@ var ConsoleCmd = &cobra.Command{
 	Long: `进入交互式控制台，支持扫描、查询、执行等操作
 
 特点：
-  - 无文件落地：所有数据缓存在内存中，退出时自动清除
+  - 无文件落地：默认所有数据缓存在内存中，退出时自动清除（--db 时写入文件）
   - 一次扫描，多次查询：扫描结果缓存，避免重复扫描
   - 交互式操作：类似 MSF 的命令行界面，支持自动补全
   - 自动连接：进入时自动使用当前环境信息连接
@@ -51,8 +67,43 @@ var ConsoleCmd = &cobra.Command{
   # 使用 token 文件
   kctl console -t 10.0.0.1 --token-file /path/to/token
 
+  # 使用窃取的 kubeconfig（命令行参数优先于 kubeconfig 中的值）
+  kctl console -t 10.0.0.1 --kubeconfig ./admin.conf --context kubernetes-admin@kubernetes
+
+  # 使用收集到的 kubelet 客户端证书认证
+  kctl console -t 10.0.0.1 --client-cert kubelet-client-current.pem --api-server 10.0.0.1 --api-port 6443
+
+  # 执行单条命令后退出（退出码与远程命令一致）
+  kctl console -t 10.0.0.1 -x "exec nginx -- id"
+
+  # CI 中定期检查：存在 HIGH 及以上的问题时以退出码 3 退出
+  kctl console -t 10.0.0.1 -x "scan" --fail-on high
+
+  # 将扫描结果写入数据库文件，供队友以只读方式同时浏览
+  # 退出时会话状态一并保存，下次以同一 --db 启动时询问是否恢复（--restore 直接恢复）
+  kctl console -t 10.0.0.1 --db /tmp/kctl.db
+  kctl console --viewer --db /tmp/kctl.db
+
+  # 审计时以安全模式运行：禁止 exec、deploy、端口转发等修改或执行操作，sa scan 改为被动扫描
+  kctl console -t 10.0.0.1 --read-only
+
+  # 将 Kubelet 原始响应（/pods、/configz、/stats 等）按原样保存，作为报告证据
+  kctl console -t 10.0.0.1 --raw-dump ./evidence
+
+  # 以换行分隔的 JSON 在标准输入/输出上提供命令（供 C2、自动化脚本调用）
+  echo '{"id":1,"command":"sa list --risky"}' | kctl console --bridge -t 10.0.0.1
+
+  # 使用配置文件中的 profile（~/.kctl/config.yaml）
+  kctl console --profile lab
+
   # 在控制台中
-  kctl [kube-system/cluster-admin ADMIN]> exec -- whoami`,
+  kctl [kube-system/cluster-admin ADMIN]> exec -- whoami
+
+环境变量（未指定对应参数时使用，优先于 profile）：
+  KCTL_TARGET, KCTL_PORT, KCTL_TOKEN, KCTL_TOKEN_FILE, KCTL_CLIENT_CERT, KCTL_CLIENT_KEY,
+  KCTL_KUBECONFIG, KCTL_CONTEXT, KCTL_PROXY, KCTL_API_SERVER, KCTL_API_PORT, KCTL_CONCURRENCY,
+  KCTL_RULES, KCTL_DB, KCTL_RAW_DUMP, KCTL_NOTIFY_URL, KCTL_PROFILE, KCTL_CONFIG (配置文件路径),
+  KCTL_HISTORY (命令历史文件，off 不保存)`,
 	Run: runConsole,
 }
 
@@ -64,31 +115,87 @@ func init() {
 	ConsoleCmd.Flags().IntVarP(&port, "port", "p", 10250, "Kubelet 端口")
 	ConsoleCmd.Flags().StringVar(&tokenFile, "token-file", "", "Token 文件路径")
 	ConsoleCmd.Flags().StringVar(&tokenStr, "token", "", "Token 字符串")
-	ConsoleCmd.Flags().StringVar(&proxy, "proxy", "", "SOCKS5 代理地址")
+	ConsoleCmd.Flags().StringVar(&certFile, "client-cert", "", "客户端证书文件 (PEM，可包含私钥)")
+	ConsoleCmd.Flags().StringVar(&keyFile, "client-key", "", "客户端私钥文件 (PEM)")
+	ConsoleCmd.Flags().StringVar(&kubeCfg, "kubeconfig", "", "从 kubeconfig 加载 API Server、CA 和凭据")
+	ConsoleCmd.Flags().StringVar(&kubeCtx, "context", "", "kubeconfig 上下文（默认 current-context）")
+	ConsoleCmd.Flags().StringVar(&proxy, "proxy", "", "代理地址 (socks5://、http://、https://，可附带 user:pass@)")
 	ConsoleCmd.Flags().StringVar(&apiServer, "api-server", "", "API Server 地址")
 	ConsoleCmd.Flags().IntVar(&apiPort, "api-port", 443, "API Server 端口")
+	ConsoleCmd.Flags().StringVarP(&execLine, "exec", "x", "", "执行单条控制台命令后退出")
+	ConsoleCmd.Flags().StringVar(&rulesFile, "rules", "", "自定义权限检查/风险规则文件 (YAML/JSON)")
+	ConsoleCmd.Flags().StringVar(&profile, "profile", "", "使用配置文件中的 profile（默认 default-profile）")
+	ConsoleCmd.Flags().StringVar(&dbPath, "db", "", "数据库文件路径或 postgres:// 连接串（默认使用内存数据库）")
+	ConsoleCmd.Flags().BoolVar(&bridge, "bridge", false, "以换行分隔的 JSON 在 stdio 上提供命令（机器接口）")
+	ConsoleCmd.Flags().StringVar(&rawDump, "raw-dump", "", "将 Kubelet 原始响应按原样保存到目录（含 SHA256 索引）")
+	ConsoleCmd.Flags().BoolVar(&restore, "restore", false, "不询问，直接恢复 --db 中保存的上次会话（目标、凭据、当前 SA、扫描状态）")
+	ConsoleCmd.Flags().BoolVar(&viewer, "viewer", false, "以只读方式打开 --db 指定的数据库，禁用所有访问集群的命令")
+	ConsoleCmd.Flags().BoolVar(&readOnly, "read-only", false, "安全模式：禁止 exec、deploy 等对集群的修改或执行操作，且不能在控制台中关闭")
+	ConsoleCmd.Flags().StringVar(&failOn, "fail-on", "", "与 -x 一起使用：存在不低于该风险等级的问题时以退出码 3 退出 [admin|critical|high|medium|low]")
 }
 
 func runConsole(cmd *cobra.Command, args []string) {
 	// 注册所有命令
 	console.RegisterCommands()
 
+	if failOn != "" && execLine == "" {
+		log.Errorf("--fail-on 只能与 -x 一起使用")
+		os.Exit(1)
+	}
+
+	// 未显式指定 --port / --api-port 时保留默认值、profile 或 kubeconfig 中的端口
+	if !cmd.Flags().Changed("port") {
+		port = 0
+	}
+	if !cmd.Flags().Changed("api-port") {
+		apiPort = 0
+	}
+
 	// 创建控制台，传入命令行参数
 	opts := console.Options{
-		Target:    target,
-		Port:      port,
-		TokenFile: tokenFile,
-		Token:     tokenStr,
-		Proxy:     proxy,
-		APIServer: apiServer,
-		APIPort:   apiPort,
+		Target:      target,
+		Port:        port,
+		Profile:     profile,
+		TokenFile:   tokenFile,
+		Token:       tokenStr,
+		CertFile:    certFile,
+		KeyFile:     keyFile,
+		Kubeconfig:  kubeCfg,
+		KubeContext: kubeCtx,
+		Proxy:       proxy,
+		APIServer:   apiServer,
+		APIPort:     apiPort,
+		RulesFile:   rulesFile,
+		DBPath:      dbPath,
+		Viewer:      viewer,
+		RawDump:     rawDump,
+		FailOn:      failOn,
+		Restore:     restore,
+		ReadOnly:    readOnly,
 	}
 
 	c, err := console.NewWithOptions(opts)
 	if err != nil {
 		log.Errorf("创建控制台失败: %v", err)
+		os.Exit(1)
+	}
+	// 桥接模式
+	if bridge {
+		err := c.ServeBridge(os.Stdin, os.Stdout)
+		c.Close()
+		if err != nil {
+			log.Errorf("桥接失败: %v", err)
+			os.Exit(1)
+		}
 		return
 	}
+
+	// 单条命令模式
+	if execLine != "" {
+		code := c.RunOnce(execLine)
+		c.Close()
+		os.Exit(code)
+	}
 	defer c.Close()
 
 	// 运行控制台
@@ -3,6 +3,9 @@ package cmd
 import (
 	cc "github.com/ivanpirog/coloredcobra"
 	"github.com/spf13/cobra"
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
+	"kctl/internal/output"
 	"kctl/utils/log"
 	"os"
 )
@@ -13,17 +16,28 @@ var RootCmd = &cobra.Command{
 	Long: `
 `,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		output.SetPlain(noColor || output.PlainFromEnv())
+		_ = i18n.SetLang(i18n.FromEnv())
 		log.Init(logLevel)
+		if verbose {
+			_ = logging.SetLevel(logging.LevelDebug)
+		}
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 
 	},
 }
 
-var logLevel string
+var (
+	logLevel string
+	noColor  bool
+	verbose  bool
+)
 
 func init() {
 	RootCmd.PersistentFlags().StringVar(&logLevel, "logLevel", "info", "设置日志等级 (Set log level) [trace|debug|info|warn|error|fatal|panic]")
+	RootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "不输出颜色，Unicode 符号和框线替换为 ASCII（也可设置 NO_COLOR 环境变量）")
+	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "输出调试日志，包含每个 HTTP/WebSocket 请求（同控制台 set log-level debug）")
 	RootCmd.CompletionOptions.DisableDefaultCmd = true
 }
 
@@ -0,0 +1,150 @@
+package run
+
+import (
+	"os"
+	"strings"
+
+	"kctl/cmd"
+	"kctl/internal/console"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	target      string
+	port        int
+	profile     string
+	tokenFile   string
+	tokenStr    string
+	certFile    string
+	keyFile     string
+	kubeCfg     string
+	kubeCtx     string
+	proxy       string
+	apiServer   string
+	apiPort     int
+	rulesFile   string
+	dbPath      string
+	viewer      bool
+	restore     bool
+	readOnly    bool
+	failOn      string
+	stopOnError bool
+	vars        []string
+)
+
+// RunCmd 是 run 子命令
+var RunCmd = &cobra.Command{
+	Use:   "run <script.kctl> [args...]",
+	Short: "逐行执行脚本文件中的控制台命令",
+	Long: `自动连接后逐行执行脚本文件中的控制台命令，用于将侦察流程固化为可在每次渗透测试中重放的剧本
+脚本文件为 - 时从标准输入读取；控制台中可用 source 执行同样的脚本
+
+脚本语法：
+  # 注释                空行和 # 开头的行忽略，行尾 \ 续行
+  NS=kube-system        定义变量，之后以 ${NS} 引用
+  ${1} ${2} ${@}        位置参数；未定义的变量依次查找环境变量，都没有时该行报错
+  $$                    字面的 $（exec 等远程命令中的 $VAR 不展开，原样保留）
+  -sa use ${NS}/x       行首 - 表示忽略该行的失败（即使使用 -e）
+  exit [code]           结束脚本
+
+退出码：全部成功为 0；有命令失败时为 1（exit <code> 或远程命令的非零退出码原样返回）；
+--fail-on 命中时为 3
+
+示例：
+  # recon.kctl
+  #   sa scan
+  #   sa list --risky
+  #   export sarif ${1}.sarif
+  kctl run recon.kctl -t 10.0.0.1 --token-file ./token -- prod
+
+  # 任一命令失败时停止，结果写入数据库
+  kctl run -e loot.kctl --var NS=kube-system -t 10.0.0.1 --db loot.db
+
+  # CI 中检查
+  echo scan | kctl run - -t 10.0.0.1 --fail-on high`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runScript,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(RunCmd)
+
+	RunCmd.Flags().StringVarP(&target, "target", "t", "", "Kubelet IP 地址")
+	RunCmd.Flags().IntVarP(&port, "port", "p", 10250, "Kubelet 端口")
+	RunCmd.Flags().StringVar(&tokenFile, "token-file", "", "Token 文件路径")
+	RunCmd.Flags().StringVar(&tokenStr, "token", "", "Token 字符串")
+	RunCmd.Flags().StringVar(&certFile, "client-cert", "", "客户端证书文件 (PEM，可包含私钥)")
+	RunCmd.Flags().StringVar(&keyFile, "client-key", "", "客户端私钥文件 (PEM)")
+	RunCmd.Flags().StringVar(&kubeCfg, "kubeconfig", "", "从 kubeconfig 加载 API Server、CA 和凭据")
+	RunCmd.Flags().StringVar(&kubeCtx, "context", "", "kubeconfig 上下文（默认 current-context）")
+	RunCmd.Flags().StringVar(&proxy, "proxy", "", "代理地址 (socks5://、http://、https://，可附带 user:pass@)")
+	RunCmd.Flags().StringVar(&apiServer, "api-server", "", "API Server 地址")
+	RunCmd.Flags().IntVar(&apiPort, "api-port", 443, "API Server 端口")
+	RunCmd.Flags().StringVar(&rulesFile, "rules", "", "自定义权限检查/风险规则文件 (YAML/JSON)")
+	RunCmd.Flags().StringVar(&profile, "profile", "", "使用配置文件中的 profile（默认 default-profile）")
+	RunCmd.Flags().StringVar(&dbPath, "db", "", "数据库文件路径或 postgres:// 连接串（默认使用内存数据库）")
+	RunCmd.Flags().BoolVar(&viewer, "viewer", false, "以只读方式打开 --db 指定的数据库，只允许只读命令")
+	RunCmd.Flags().BoolVar(&readOnly, "read-only", false, "安全模式：禁止 exec、deploy 等对集群的修改或执行操作，脚本中不能关闭")
+	RunCmd.Flags().BoolVar(&restore, "restore", false, "恢复 --db 中保存的上次会话")
+	RunCmd.Flags().StringVar(&failOn, "fail-on", "", "脚本执行完后存在不低于该风险等级的问题时以退出码 3 退出 [admin|critical|high|medium|low]")
+	RunCmd.Flags().BoolVarP(&stopOnError, "stop-on-error", "e", false, "任一命令失败时停止")
+	RunCmd.Flags().StringArrayVar(&vars, "var", nil, "定义脚本变量 KEY=VAL（可重复）")
+}
+
+func runScript(cmd *cobra.Command, args []string) {
+	console.RegisterCommands()
+
+	// 未显式指定 --port / --api-port 时保留默认值、profile 或 kubeconfig 中的端口
+	if !cmd.Flags().Changed("port") {
+		port = 0
+	}
+	if !cmd.Flags().Changed("api-port") {
+		apiPort = 0
+	}
+
+	scriptVars := make(map[string]string, len(vars))
+	for _, v := range vars {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			log.Errorf("无效的变量: %s (需要 KEY=VAL)", v)
+			os.Exit(1)
+		}
+		scriptVars[key] = value
+	}
+
+	c, err := console.NewWithOptions(console.Options{
+		Target:      target,
+		Port:        port,
+		Profile:     profile,
+		TokenFile:   tokenFile,
+		Token:       tokenStr,
+		CertFile:    certFile,
+		KeyFile:     keyFile,
+		Kubeconfig:  kubeCfg,
+		KubeContext: kubeCtx,
+		Proxy:       proxy,
+		APIServer:   apiServer,
+		APIPort:     apiPort,
+		RulesFile:   rulesFile,
+		DBPath:      dbPath,
+		Viewer:      viewer,
+		Restore:     restore,
+		FailOn:      failOn,
+		ReadOnly:    readOnly,
+	})
+	if err != nil {
+		log.Errorf("创建控制台失败: %v", err)
+		os.Exit(1)
+	}
+
+	code := c.RunScript(console.ScriptOptions{
+		Path:        args[0],
+		Args:        args[1:],
+		Vars:        scriptVars,
+		StopOnError: stopOnError,
+	})
+	c.Close()
+	os.Exit(code)
+}
@@ -0,0 +1,135 @@
+package serve
+
+import (
+	"os"
+
+	"kctl/cmd"
+	"kctl/internal/console"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	target    string
+	port      int
+	profile   string
+	tokenFile string
+	tokenStr  string
+	certFile  string
+	keyFile   string
+	kubeCfg   string
+	kubeCtx   string
+	proxy     string
+	apiServer string
+	apiPort   int
+	rulesFile string
+	dbPath    string
+	viewer    bool
+	readOnly  bool
+	listen    string
+	apiToken  string
+	tlsCert   string
+	tlsKey    string
+)
+
+// ServeCmd 是 serve 子命令
+var ServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "以 REST API 提供扫描数据",
+	Long: `以带认证的 REST API 提供会话中的 Pod、ServiceAccount、检查发现和扫描历史，并允许远程触发扫描，
+供仪表盘或团队服务器使用
+
+所有请求需要携带 Authorization: Bearer <token>；未指定 --api-token 时启动时随机生成并输出
+
+接口（前缀 /api/v1）：
+  GET  /status                          会话状态和数据统计
+  GET  /pods[?namespace=]               Pod 缓存
+  GET  /serviceaccounts[?risky=true]    ServiceAccount（Token 只在 ?tokens=true 时返回）
+  GET  /findings[?source=&severity=]    检查发现
+  GET  /scans                           扫描历史
+  GET  /scans/{id}                      扫描记录及结果快照
+  POST /scans  {"passive": false}       同步执行 sa scan，已有扫描在执行时返回 409
+
+示例：
+  kctl serve -t 10.0.0.1 --token-file ./token --listen 127.0.0.1:8080
+  curl -H "Authorization: Bearer $KCTL_TOKEN" http://127.0.0.1:8080/api/v1/serviceaccounts?risky=true
+  curl -X POST -H "Authorization: Bearer $KCTL_TOKEN" http://127.0.0.1:8080/api/v1/scans
+
+  # 以只读方式提供已有数据库，每次请求前同步最新结果
+  kctl serve --viewer --db /tmp/kctl.db --listen :8080 --api-token "$KCTL_TOKEN" --tls-cert server.pem --tls-key server-key.pem`,
+	Args: cobra.NoArgs,
+	Run:  runServe,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(ServeCmd)
+
+	ServeCmd.Flags().StringVarP(&target, "target", "t", "", "Kubelet IP 地址")
+	ServeCmd.Flags().IntVarP(&port, "port", "p", 10250, "Kubelet 端口")
+	ServeCmd.Flags().StringVar(&tokenFile, "token-file", "", "Token 文件路径")
+	ServeCmd.Flags().StringVar(&tokenStr, "token", "", "Token 字符串")
+	ServeCmd.Flags().StringVar(&certFile, "client-cert", "", "客户端证书文件 (PEM，可包含私钥)")
+	ServeCmd.Flags().StringVar(&keyFile, "client-key", "", "客户端私钥文件 (PEM)")
+	ServeCmd.Flags().StringVar(&kubeCfg, "kubeconfig", "", "从 kubeconfig 加载 API Server、CA 和凭据")
+	ServeCmd.Flags().StringVar(&kubeCtx, "context", "", "kubeconfig 上下文（默认 current-context）")
+	ServeCmd.Flags().StringVar(&proxy, "proxy", "", "代理地址 (socks5://、http://、https://，可附带 user:pass@)")
+	ServeCmd.Flags().StringVar(&apiServer, "api-server", "", "API Server 地址")
+	ServeCmd.Flags().IntVar(&apiPort, "api-port", 443, "API Server 端口")
+	ServeCmd.Flags().StringVar(&rulesFile, "rules", "", "自定义权限检查/风险规则文件 (YAML/JSON)")
+	ServeCmd.Flags().StringVar(&profile, "profile", "", "使用配置文件中的 profile（默认 default-profile）")
+	ServeCmd.Flags().StringVar(&dbPath, "db", "", "数据库文件路径或 postgres:// 连接串（默认使用内存数据库）")
+	ServeCmd.Flags().BoolVar(&viewer, "viewer", false, "以只读方式打开 --db 指定的数据库，禁止触发扫描")
+	ServeCmd.Flags().BoolVar(&readOnly, "read-only", false, "安全模式：禁止 exec 等对集群的修改或执行操作，POST /scans 只执行被动扫描")
+	ServeCmd.Flags().StringVar(&listen, "listen", "127.0.0.1:8080", "监听地址")
+	ServeCmd.Flags().StringVar(&apiToken, "api-token", "", "API 令牌（默认随机生成）")
+	ServeCmd.Flags().StringVar(&tlsCert, "tls-cert", "", "HTTPS 证书文件 (PEM)")
+	ServeCmd.Flags().StringVar(&tlsKey, "tls-key", "", "HTTPS 私钥文件 (PEM)")
+}
+
+func runServe(cmd *cobra.Command, args []string) {
+	console.RegisterCommands()
+
+	// 未显式指定 --port / --api-port 时保留默认值、profile 或 kubeconfig 中的端口
+	if !cmd.Flags().Changed("port") {
+		port = 0
+	}
+	if !cmd.Flags().Changed("api-port") {
+		apiPort = 0
+	}
+
+	c, err := console.NewWithOptions(console.Options{
+		Target:      target,
+		Port:        port,
+		Profile:     profile,
+		TokenFile:   tokenFile,
+		Token:       tokenStr,
+		CertFile:    certFile,
+		KeyFile:     keyFile,
+		Kubeconfig:  kubeCfg,
+		KubeContext: kubeCtx,
+		Proxy:       proxy,
+		APIServer:   apiServer,
+		APIPort:     apiPort,
+		RulesFile:   rulesFile,
+		DBPath:      dbPath,
+		Viewer:      viewer,
+		ReadOnly:    readOnly,
+	})
+	if err != nil {
+		log.Errorf("创建控制台失败: %v", err)
+		os.Exit(1)
+	}
+
+	err = c.Serve(console.ServeOptions{
+		Listen:  listen,
+		Token:   apiToken,
+		TLSCert: tlsCert,
+		TLSKey:  tlsKey,
+	})
+	c.Close()
+	if err != nil {
+		log.Errorf("%v", err)
+		os.Exit(1)
+	}
+}
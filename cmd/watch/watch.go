@@ -0,0 +1,125 @@
+package watch
+
+import (
+	"os"
+	"time"
+
+	"kctl/cmd"
+	"kctl/internal/console"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	targets   []string
+	port      int
+	profile   string
+	tokenFile string
+	tokenStr  string
+	certFile  string
+	keyFile   string
+	kubeCfg   string
+	kubeCtx   string
+	proxy     string
+	apiServer string
+	apiPort   int
+	rulesFile string
+	dbPath    string
+	notifyURL string
+	interval  time.Duration
+	count     int
+	passive   bool
+)
+
+// WatchCmd 是 watch 子命令
+var WatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "持续监控：定期扫描并报告风险变化",
+	Long: `按间隔重复执行 sa scan，每轮与同一目标的上一次扫描比较，报告新增的风险：
+  - 新出现的 cluster-admin / CRITICAL / HIGH ServiceAccount
+  - 风险升级的 ServiceAccount（如获得 cluster-admin）
+  - 新出现特权、HostPath、HostPID 等高危配置的 Pod（按命名空间和 SA 比较，滚动更新不会误报）
+
+每次扫描都记录到扫描历史；使用 --db 时可在重启后继续与上一次扫描比较，
+也可以随时用 kctl console --viewer --db 浏览。变化同时输出到标准输出，
+并在设置 --notify-url 时推送到 Slack / 通用 Webhook（事件 posture.changed）
+
+示例：
+  # 每小时扫描一次
+  kctl watch -t 10.0.0.1 --token-file ./token --interval 1h
+
+  # 监控多个节点，结果写入数据库，变化推送到 Slack
+  kctl watch -t 10.0.0.1,10.0.0.2 --token-file ./token --interval 30m \
+    --db posture.db --notify-url https://hooks.slack.com/services/T000/B000/XXXX
+
+  # 只根据 Pod 规格评估，不读取 Token
+  kctl watch -t 10.0.0.1 --client-cert kubelet.pem --interval 1h --passive`,
+	Args: cobra.NoArgs,
+	Run:  runWatch,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(WatchCmd)
+
+	WatchCmd.Flags().StringSliceVarP(&targets, "target", "t", nil, "Kubelet IP 地址（逗号分隔或重复指定多个）")
+	WatchCmd.Flags().IntVarP(&port, "port", "p", 10250, "Kubelet 端口")
+	WatchCmd.Flags().StringVar(&tokenFile, "token-file", "", "Token 文件路径")
+	WatchCmd.Flags().StringVar(&tokenStr, "token", "", "Token 字符串")
+	WatchCmd.Flags().StringVar(&certFile, "client-cert", "", "客户端证书文件 (PEM，可包含私钥)")
+	WatchCmd.Flags().StringVar(&keyFile, "client-key", "", "客户端私钥文件 (PEM)")
+	WatchCmd.Flags().StringVar(&kubeCfg, "kubeconfig", "", "从 kubeconfig 加载 API Server、CA 和凭据")
+	WatchCmd.Flags().StringVar(&kubeCtx, "context", "", "kubeconfig 上下文（默认 current-context）")
+	WatchCmd.Flags().StringVar(&proxy, "proxy", "", "代理地址 (socks5://、http://、https://，可附带 user:pass@)")
+	WatchCmd.Flags().StringVar(&apiServer, "api-server", "", "API Server 地址")
+	WatchCmd.Flags().IntVar(&apiPort, "api-port", 443, "API Server 端口")
+	WatchCmd.Flags().StringVar(&rulesFile, "rules", "", "自定义权限检查/风险规则文件 (YAML/JSON)")
+	WatchCmd.Flags().StringVar(&profile, "profile", "", "使用配置文件中的 profile（默认 default-profile）")
+	WatchCmd.Flags().StringVar(&dbPath, "db", "", "数据库文件路径或 postgres:// 连接串（默认使用内存数据库）")
+	WatchCmd.Flags().StringVar(&notifyURL, "notify-url", "", "发现新增风险时推送的 Slack / 通用 Webhook 地址")
+	WatchCmd.Flags().DurationVar(&interval, "interval", time.Hour, "两轮扫描之间的间隔")
+	WatchCmd.Flags().IntVar(&count, "count", 0, "扫描轮数后退出（默认持续运行直到 Ctrl+C）")
+	WatchCmd.Flags().BoolVar(&passive, "passive", false, "只根据 Pod 规格评估（sa scan --passive），不读取 Token、不检查权限")
+}
+
+func runWatch(cmd *cobra.Command, args []string) {
+	console.RegisterCommands()
+
+	// 未显式指定 --port / --api-port 时保留默认值、profile 或 kubeconfig 中的端口
+	if !cmd.Flags().Changed("port") {
+		port = 0
+	}
+	if !cmd.Flags().Changed("api-port") {
+		apiPort = 0
+	}
+
+	c, err := console.NewWithOptions(console.Options{
+		Port:        port,
+		Profile:     profile,
+		TokenFile:   tokenFile,
+		Token:       tokenStr,
+		CertFile:    certFile,
+		KeyFile:     keyFile,
+		Kubeconfig:  kubeCfg,
+		KubeContext: kubeCtx,
+		Proxy:       proxy,
+		APIServer:   apiServer,
+		APIPort:     apiPort,
+		RulesFile:   rulesFile,
+		DBPath:      dbPath,
+		NotifyURL:   notifyURL,
+	})
+	if err != nil {
+		log.Errorf("创建控制台失败: %v", err)
+		os.Exit(1)
+	}
+
+	code := c.Watch(console.WatchOptions{
+		Targets:  targets,
+		Interval: interval,
+		Count:    count,
+		Passive:  passive,
+	})
+	c.Close()
+	os.Exit(code)
+}
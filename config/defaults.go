@@ -8,9 +8,15 @@ const (
 	// DefaultKubeletPort Kubelet 默认端口
 	DefaultKubeletPort = 10250
 
+	// DefaultKubeletReadOnlyPort Kubelet 只读端口（不认证，--read-only-port）
+	DefaultKubeletReadOnlyPort = 10255
+
 	// DefaultTokenPath ServiceAccount Token 默认路径
 	DefaultTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 
+	// DefaultCAPath ServiceAccount CA 证书默认路径
+	DefaultCAPath = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+
 	// DefaultK8sAPIServer K8s API Server 默认地址
 	DefaultK8sAPIServer = "https://kubernetes.default.svc"
 )
@@ -29,6 +35,15 @@ const (
 
 	// DefaultWebSocketTimeout WebSocket 握手超时
 	DefaultWebSocketTimeout = 30 * time.Second
+
+	// DefaultMaxRetries HTTP 请求遇到临时错误时的默认最大重试次数
+	DefaultMaxRetries = 3
+
+	// DefaultRetryInterval 首次重试前的等待时间，之后每次加倍
+	DefaultRetryInterval = time.Second
+
+	// DefaultKeepaliveInterval 交互式 shell 发送 WebSocket ping 的默认间隔
+	DefaultKeepaliveInterval = 30 * time.Second
 )
 
 // ==================== 数据库配置 ====================
@@ -44,8 +59,101 @@ const (
 	// DefaultScanConcurrency 默认扫描并发数
 	DefaultScanConcurrency = 3
 
-	// DefaultMaxRetries 默认最大重试次数
-	DefaultMaxRetries = 3
+	// DefaultWorkers 所有批量命令共享的工作池大小（同时进行的请求总数）
+	DefaultWorkers = 64
+
+	// DefaultTargetConcurrency 同一 Kubelet 上同时进行的最大请求数
+	DefaultTargetConcurrency = 16
+)
+
+// ==================== Pod 内端口扫描配置（pscan） ====================
+
+const (
+	// DefaultPodScanTimeout 单个连接默认超时（秒）
+	DefaultPodScanTimeout = 1
+
+	// DefaultPodScanConcurrency 每批默认并发连接数
+	DefaultPodScanConcurrency = 64
+
+	// MaxPodScanTargets 单次扫描的最大 IP 数（相当于 /20）
+	MaxPodScanTargets = 4096
+
+	// PodScanChunkSize 每次 exec 扫描的 IP 数
+	PodScanChunkSize = 64
+)
+
+// DefaultPodScanPorts 默认扫描端口：SSH、HTTP、Kubernetes 组件、Docker、常见数据库和管理界面
+var DefaultPodScanPorts = []int{
+	22, 80, 443, 2375, 2379, 3000, 3306, 5432, 6379, 6443,
+	8080, 8443, 9090, 9200, 10250, 10255, 11211, 27017, 44134,
+}
+
+// ==================== Pod 出口 SOCKS5 代理配置（pivot） ====================
+
+const (
+	// DefaultPivotPort 本地 SOCKS5 代理默认端口
+	DefaultPivotPort = 1080
+)
+
+// ==================== API Server 检查配置 ====================
+
+const (
+	// DefaultAPIServerInsecurePort kube-apiserver 旧版不安全端口（--insecure-port，1.20 起移除）
+	DefaultAPIServerInsecurePort = 8080
+)
+
+// ==================== etcd 检查配置 ====================
+
+const (
+	// EtcdClientPort etcd 客户端端口
+	EtcdClientPort = 2379
+
+	// EtcdPeerPort etcd 集群节点间通信端口
+	EtcdPeerPort = 2380
+
+	// EtcdSecretsPrefix kube-apiserver 在 etcd 中保存 Secret 的键前缀
+	EtcdSecretsPrefix = "/registry/secrets/"
+
+	// DefaultEtcdKeyLimit 默认列出的 Secret 键数量
+	DefaultEtcdKeyLimit = 20
+)
+
+// ==================== exec 通道配置 ====================
+
+const (
+	// ExecViaAuto 自动协商：依次尝试 Kubelet WebSocket、SPDY、API Server pods/exec、nodes/proxy、Kubelet /run
+	ExecViaAuto = "auto"
+
+	// ExecViaWebSocket 只通过 Kubelet /exec（WebSocket）执行
+	ExecViaWebSocket = "websocket"
+
+	// ExecViaKubelet websocket 的别名（兼容旧配置）
+	ExecViaKubelet = "kubelet"
+
+	// ExecViaSPDY 只通过 Kubelet /exec（SPDY/3.1）执行
+	ExecViaSPDY = "spdy"
+
+	// ExecViaAPI 只通过 API Server pods/exec 子资源执行
+	ExecViaAPI = "api"
+
+	// ExecViaNodeProxy 只通过 API Server nodes/proxy 转发到 Kubelet /exec
+	ExecViaNodeProxy = "nodes-proxy"
+
+	// ExecViaRun 只通过旧版 Kubelet /run 执行（不支持交互式）
+	ExecViaRun = "run"
+)
+
+// ExecViaOptions 可用的执行通道（set exec-via）
+var ExecViaOptions = []string{ExecViaAuto, ExecViaWebSocket, ExecViaSPDY, ExecViaAPI, ExecViaNodeProxy, ExecViaRun}
+
+// ==================== 时间显示配置 ====================
+
+const (
+	// TimeZoneLocal 按本地时区显示时间
+	TimeZoneLocal = "local"
+
+	// TimeZoneUTC 按 UTC 显示时间
+	TimeZoneUTC = "utc"
 )
 
 // ==================== 路由表配置 ====================
@@ -1,8 +1,11 @@
 package config
 
+import "strings"
+
 // ==================== 权限检查定义 ====================
 
 // PermissionDef 权限定义
+// Resource 以 / 开头时表示非资源 URL（nonResourceURLs），此时 Group、Subresource 为空
 type PermissionDef struct {
 	Resource    string
 	Verb        string
@@ -85,4 +88,16 @@ var PermissionsToCheck = []PermissionDef{
 	{"persistentvolumes", "create", "", ""},
 	{"persistentvolumeclaims", "list", "", ""},
 	{"persistentvolumeclaims", "create", "", ""},
+
+	// ==================== 非资源 URL 权限 ====================
+	{"/*", "get", "", ""},       // nonResourceURLs 通配（"*" 或 "/*"）
+	{"/logs", "get", "", ""},    // API Server 所在节点的 /var/log
+	{"/metrics", "get", "", ""}, // API Server 指标
+	{"/api", "get", "", ""},
+	{"/healthz", "get", "", ""},
+}
+
+// IsNonResourceURL 资源名是否为非资源 URL（如 /logs、/metrics）
+func IsNonResourceURL(resource string) bool {
+	return strings.HasPrefix(resource, "/")
 }
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// ==================== 风险策略匹配 ====================
+//
+// 风险查找表（CriticalPermissions/HighPermissions/MediumPermissions）的键格式:
+//
+//	resource                 任意 API Group 下的资源，如 secrets
+//	resource/subresource     子资源，如 pods/exec
+//	group:resource           指定 API Group，支持通配，如 *.argoproj.io:workflows
+//	core:resource            core API Group（空 group）
+//	/path                    非资源 URL，如 /logs，按字面匹配（/* 表示 nonResourceURLs 通配）
+//
+// 资源与 API Group 支持 glob 通配（*、?、[...]）。
+// 未指定 group 时单独的 "*" 仅匹配 RBAC 通配权限本身（集群管理员），
+// 指定 group 时 "*" 匹配该 group 下任意资源（适用于 CRD）。
+
+// RiskTableEntry 风险查找表中的一条有效规则
+type RiskTableEntry struct {
+	Level    RiskLevel
+	Group    string // 空表示任意 group
+	Resource string // resource 或 resource/subresource
+	Verbs    []string
+}
+
+// MatchPattern 检查值是否匹配模式，"*" 匹配任意值，其余按 glob 匹配
+func MatchPattern(pattern, value string) bool {
+	if pattern == "*" || pattern == value {
+		return true
+	}
+	if !strings.ContainsAny(pattern, "*?[") {
+		return false
+	}
+	ok, err := path.Match(pattern, value)
+	return err == nil && ok
+}
+
+// ValidatePattern 校验通配模式语法
+func ValidatePattern(pattern string) error {
+	_, err := path.Match(pattern, "")
+	return err
+}
+
+// parseRiskKey 解析风险查找表键，返回 group 模式、资源模式及是否指定了 group
+func parseRiskKey(key string) (group, resource string, hasGroup bool) {
+	group, resource, hasGroup = strings.Cut(key, ":")
+	if !hasGroup {
+		return "", key, false
+	}
+	if group == "core" {
+		group = ""
+	}
+	return group, resource, true
+}
+
+// matchRiskKey 检查权限是否匹配风险查找表键
+func matchRiskKey(key, group, resource string) bool {
+	g, r, hasGroup := parseRiskKey(key)
+	if IsNonResourceURL(resource) {
+		return !hasGroup && r == resource
+	}
+	if !hasGroup {
+		// 兼容内置表："*" 仅表示 RBAC 通配权限
+		if r == "*" {
+			return resource == "*"
+		}
+		return MatchPattern(r, resource)
+	}
+	return MatchPattern(g, group) && MatchPattern(r, resource)
+}
+
+// matchVerbs 检查操作是否在列表中
+func matchVerbs(verbs []string, verb string) bool {
+	for _, v := range verbs {
+		if v == "*" || v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// riskTables 按优先级排列的风险查找表
+func riskTables() []struct {
+	level RiskLevel
+	table map[string][]string
+} {
+	return []struct {
+		level RiskLevel
+		table map[string][]string
+	}{
+		{RiskCritical, CriticalPermissions},
+		{RiskHigh, HighPermissions},
+		{RiskMedium, MediumPermissions},
+	}
+}
+
+// joinResource 拼接资源与子资源
+func joinResource(resource, subresource string) string {
+	if subresource == "" {
+		return resource
+	}
+	return resource + "/" + subresource
+}
+
+// PermissionRiskLevel 根据风险查找表判断单个权限的风险等级
+// 未命中任何条目时返回 RiskNone
+func PermissionRiskLevel(group, resource, subresource, verb string) RiskLevel {
+	full := joinResource(resource, subresource)
+	for _, t := range riskTables() {
+		for key, verbs := range t.table {
+			if matchRiskKey(key, group, full) && matchVerbs(verbs, verb) {
+				return t.level
+			}
+		}
+	}
+	return RiskNone
+}
+
+// MatchRiskRule 返回权限命中的风险查找表键和等级，同一张表内按键排序取第一个以保证结果稳定
+// 未命中任何条目时 ok 为 false
+func MatchRiskRule(group, resource, subresource, verb string) (key string, level RiskLevel, ok bool) {
+	full := joinResource(resource, subresource)
+	for _, t := range riskTables() {
+		keys := make([]string, 0, len(t.table))
+		for k := range t.table {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if matchRiskKey(k, group, full) && matchVerbs(t.table[k], verb) {
+				return k, t.level, true
+			}
+		}
+	}
+	return "", RiskNone, false
+}
+
+// ResourceRiskLevel 根据风险查找表判断资源的风险等级（忽略操作）
+func ResourceRiskLevel(group, resource, subresource string) RiskLevel {
+	full := joinResource(resource, subresource)
+	for _, t := range riskTables() {
+		for key := range t.table {
+			if matchRiskKey(key, group, full) {
+				return t.level
+			}
+		}
+	}
+	return RiskNone
+}
+
+// EffectiveRiskTable 返回当前生效的风险查找表（按等级、group、资源排序）
+func EffectiveRiskTable() []RiskTableEntry {
+	var entries []RiskTableEntry
+	for _, t := range riskTables() {
+		for key, verbs := range t.table {
+			g, r, hasGroup := parseRiskKey(key)
+			if hasGroup && g == "" {
+				g = "core"
+			}
+			entries = append(entries, RiskTableEntry{
+				Level:    t.level,
+				Group:    g,
+				Resource: r,
+				Verbs:    verbs,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Level != entries[j].Level {
+			return RiskLevelOrder[entries[i].Level] < RiskLevelOrder[entries[j].Level]
+		}
+		if entries[i].Group != entries[j].Group {
+			return entries[i].Group < entries[j].Group
+		}
+		return entries[i].Resource < entries[j].Resource
+	})
+	return entries
+}
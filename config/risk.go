@@ -113,7 +113,14 @@ var PermissionRiskRules = []PermissionRiskRule{
 	{"validatingwebhookconfigurations", "create", "admissionregistration.k8s.io", "", PermLevelDangerous, "可创建验证 Webhook"},
 	{"validatingwebhookconfigurations", "update", "admissionregistration.k8s.io", "", PermLevelDangerous, "可修改验证 Webhook"},
 
+	// 非资源 URL 通配 - 可访问 API Server 上任意非资源路径
+	{"/*", "get", "", "", PermLevelDangerous, "可访问任意非资源 URL"},
+
 	// ==================== SENSITIVE 级别 ====================
+	// 非资源 URL - API Server 节点日志与指标
+	{"/logs", "get", "", "", PermLevelSensitive, "可读取 API Server 节点日志"},
+	{"/metrics", "get", "", "", PermLevelSensitive, "可读取 API Server 指标"},
+
 	// Secrets - 可能包含凭据、密钥等
 	{"secrets", "get", "", "", PermLevelSensitive, "可获取 Secret 内容"},
 	{"secrets", "list", "", "", PermLevelSensitive, "可列出 Secrets"},
@@ -196,6 +203,8 @@ var HighPermissions = map[string][]string{
 	"persistentvolumeclaims": {"create", "*"},
 	"persistentvolumes":      {"create", "*"},
 	"serviceaccounts/token":  {"create", "*"},
+	"/*":                     {"get", "*"},
+	"/logs":                  {"get", "*"},
 }
 
 // MediumPermissions 中危权限定义
@@ -204,6 +213,7 @@ var MediumPermissions = map[string][]string{
 	"endpoints":       {"create", "update", "*"},
 	"ingresses":       {"create", "update", "*"},
 	"networkpolicies": {"create", "update", "delete", "*"},
+	"/metrics":        {"get", "*"},
 }
 
 // PrivilegeEquivalentPermissions 等同于特权的权限
@@ -234,24 +244,10 @@ func IsPrivilegeEquivalent(resource, verb string) bool {
 
 // IsCriticalPermission 检查是否是高危权限
 func IsCriticalPermission(resource, verb string) bool {
-	if verbs, ok := CriticalPermissions[resource]; ok {
-		for _, v := range verbs {
-			if v == "*" || v == verb {
-				return true
-			}
-		}
-	}
-	return false
+	return PermissionRiskLevel("", resource, "", verb) == RiskCritical
 }
 
 // IsHighPermission 检查是否是高危权限
 func IsHighPermission(resource, verb string) bool {
-	if verbs, ok := HighPermissions[resource]; ok {
-		for _, v := range verbs {
-			if v == "*" || v == verb {
-				return true
-			}
-		}
-	}
-	return false
+	return PermissionRiskLevel("", resource, "", verb) == RiskHigh
 }
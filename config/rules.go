@@ -0,0 +1,312 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"kctl/internal/i18n"
+)
+
+// ==================== 用户自定义规则文件 ====================
+
+// RulesFile 用户自定义规则文件结构（YAML 或 JSON）
+//
+// 示例:
+//
+//	replace: false
+//	permissions:
+//	  - {resource: widgets, verb: create, group: example.com}
+//	risk:
+//	  critical:
+//	    - {resource: widgets, verbs: [create, "*"]}
+//	    - {group: "*.argoproj.io", resource: "*", verbs: [create]}
+//	  high:
+//	    - {resource: /debug/pprof, verbs: [get]}
+//	rules:
+//	  - {resource: widgets, verb: create, group: example.com, level: dangerous, description: 可创建 Widget}
+type RulesFile struct {
+	// Replace 为 true 时替换内置列表，否则与内置列表合并
+	Replace     bool              `yaml:"replace" json:"replace"`
+	Permissions []RulesPermission `yaml:"permissions" json:"permissions"`
+	Risk        RulesRisk         `yaml:"risk" json:"risk"`
+	Rules       []RulesRiskRule   `yaml:"rules" json:"rules"`
+}
+
+// RulesPermission 需要检查的权限
+type RulesPermission struct {
+	Resource    string `yaml:"resource" json:"resource"`
+	Verb        string `yaml:"verb" json:"verb"`
+	Group       string `yaml:"group" json:"group"`
+	Subresource string `yaml:"subresource" json:"subresource"`
+}
+
+// RulesRisk 风险等级快速查找表
+type RulesRisk struct {
+	Critical []RulesRiskEntry `yaml:"critical" json:"critical"`
+	High     []RulesRiskEntry `yaml:"high" json:"high"`
+	Medium   []RulesRiskEntry `yaml:"medium" json:"medium"`
+}
+
+// RulesRiskEntry 风险等级条目，resource 可为 resource/subresource 形式
+// group 可选，支持通配（如 *.argoproj.io），core 表示核心 API Group
+type RulesRiskEntry struct {
+	Group    string   `yaml:"group" json:"group"`
+	Resource string   `yaml:"resource" json:"resource"`
+	Verbs    []string `yaml:"verbs" json:"verbs"`
+}
+
+// key 返回风险查找表键
+func (e RulesRiskEntry) key() string {
+	if e.Group == "" {
+		return e.Resource
+	}
+	return e.Group + ":" + e.Resource
+}
+
+// RulesRiskRule 权限风险规则
+type RulesRiskRule struct {
+	Resource    string `yaml:"resource" json:"resource"`
+	Verb        string `yaml:"verb" json:"verb"`
+	Group       string `yaml:"group" json:"group"`
+	Subresource string `yaml:"subresource" json:"subresource"`
+	Level       string `yaml:"level" json:"level"` // admin, dangerous, sensitive, normal
+	Description string `yaml:"description" json:"description"`
+}
+
+// RulesSummary 规则加载结果统计
+type RulesSummary struct {
+	Permissions int
+	RiskEntries int
+	Rules       int
+	Replaced    bool
+}
+
+// permissionLevelByName 规则文件中的级别名称
+var permissionLevelByName = map[string]PermissionLevel{
+	"admin":     PermLevelAdmin,
+	"dangerous": PermLevelDangerous,
+	"sensitive": PermLevelSensitive,
+	"normal":    PermLevelNormal,
+}
+
+// 内置规则快照，用于重置和重复加载
+var (
+	defaultsOnce        sync.Once
+	defaultPermissions  []PermissionDef
+	defaultRiskRules    []PermissionRiskRule
+	defaultCriticalPerm map[string][]string
+	defaultHighPerm     map[string][]string
+	defaultMediumPerm   map[string][]string
+)
+
+// snapshotDefaults 保存内置规则快照
+func snapshotDefaults() {
+	defaultsOnce.Do(func() {
+		defaultPermissions = append([]PermissionDef(nil), PermissionsToCheck...)
+		defaultRiskRules = append([]PermissionRiskRule(nil), PermissionRiskRules...)
+		defaultCriticalPerm = copyVerbMap(CriticalPermissions)
+		defaultHighPerm = copyVerbMap(HighPermissions)
+		defaultMediumPerm = copyVerbMap(MediumPermissions)
+	})
+}
+
+// ResetRules 恢复内置的权限检查列表和风险规则
+func ResetRules() {
+	snapshotDefaults()
+	PermissionsToCheck = append([]PermissionDef(nil), defaultPermissions...)
+	PermissionRiskRules = append([]PermissionRiskRule(nil), defaultRiskRules...)
+	CriticalPermissions = copyVerbMap(defaultCriticalPerm)
+	HighPermissions = copyVerbMap(defaultHighPerm)
+	MediumPermissions = copyVerbMap(defaultMediumPerm)
+}
+
+// LoadRulesFile 加载用户规则文件并应用到全局规则
+// 每次加载都基于内置规则重新计算，重复加载不会累加
+func LoadRulesFile(path string) (*RulesSummary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取规则文件失败: %w", err)
+	}
+
+	// YAML 是 JSON 的超集，统一使用 YAML 解析
+	var rf RulesFile
+	if err := yaml.Unmarshal(data, &rf); err != nil {
+		return nil, i18n.Errorf("解析规则文件失败: %w", err)
+	}
+
+	if err := rf.Validate(); err != nil {
+		return nil, err
+	}
+
+	ResetRules()
+	rf.apply()
+
+	return &RulesSummary{
+		Permissions: len(rf.Permissions),
+		RiskEntries: len(rf.Risk.Critical) + len(rf.Risk.High) + len(rf.Risk.Medium),
+		Rules:       len(rf.Rules),
+		Replaced:    rf.Replace,
+	}, nil
+}
+
+// Validate 校验规则文件内容
+func (rf *RulesFile) Validate() error {
+	for i, p := range rf.Permissions {
+		if err := validateResourceVerb(p.Resource, p.Verb); err != nil {
+			return fmt.Errorf("permissions[%d]: %w", i, err)
+		}
+		if err := validateNonResourceURL(p.Resource, p.Group, p.Subresource); err != nil {
+			return fmt.Errorf("permissions[%d]: %w", i, err)
+		}
+	}
+
+	for name, entries := range map[string][]RulesRiskEntry{
+		"critical": rf.Risk.Critical,
+		"high":     rf.Risk.High,
+		"medium":   rf.Risk.Medium,
+	} {
+		for i, e := range entries {
+			if strings.TrimSpace(e.Resource) == "" {
+				return i18n.Errorf("risk.%s[%d]: resource 不能为空", name, i)
+			}
+			if len(e.Verbs) == 0 {
+				return i18n.Errorf("risk.%s[%d]: verbs 不能为空", name, i)
+			}
+			if strings.Contains(e.Group+e.Resource, ":") {
+				return i18n.Errorf("risk.%s[%d]: group/resource 不能包含 ':'", name, i)
+			}
+			if err := validatePatterns(e.Group, e.Resource); err != nil {
+				return fmt.Errorf("risk.%s[%d]: %w", name, i, err)
+			}
+			if err := validateNonResourceURL(e.Resource, e.Group, ""); err != nil {
+				return fmt.Errorf("risk.%s[%d]: %w", name, i, err)
+			}
+		}
+	}
+
+	for i, r := range rf.Rules {
+		if err := validateResourceVerb(r.Resource, r.Verb); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		if err := validatePatterns(r.Group, r.Resource, r.Subresource); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		if err := validateNonResourceURL(r.Resource, r.Group, r.Subresource); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		if _, ok := permissionLevelByName[strings.ToLower(r.Level)]; !ok {
+			return i18n.Errorf("rules[%d]: 无效的 level: %q (可用: admin, dangerous, sensitive, normal)", i, r.Level)
+		}
+	}
+
+	return nil
+}
+
+// apply 将规则合并或替换到全局规则
+func (rf *RulesFile) apply() {
+	if rf.Replace {
+		PermissionsToCheck = nil
+		PermissionRiskRules = nil
+		CriticalPermissions = map[string][]string{}
+		HighPermissions = map[string][]string{}
+		MediumPermissions = map[string][]string{}
+	}
+
+	// 权限检查列表：追加并去重
+	seen := make(map[PermissionDef]bool)
+	for _, p := range PermissionsToCheck {
+		seen[p] = true
+	}
+	for _, p := range rf.Permissions {
+		def := PermissionDef{Resource: p.Resource, Verb: p.Verb, Group: p.Group, Subresource: p.Subresource}
+		if !seen[def] {
+			seen[def] = true
+			PermissionsToCheck = append(PermissionsToCheck, def)
+		}
+	}
+
+	// 风险查找表：合并 verbs
+	mergeVerbMap(CriticalPermissions, rf.Risk.Critical)
+	mergeVerbMap(HighPermissions, rf.Risk.High)
+	mergeVerbMap(MediumPermissions, rf.Risk.Medium)
+
+	// 风险规则：用户规则优先匹配（按先匹配先返回）
+	var custom []PermissionRiskRule
+	for _, r := range rf.Rules {
+		custom = append(custom, PermissionRiskRule{
+			Resource:    r.Resource,
+			Verb:        r.Verb,
+			Group:       r.Group,
+			Subresource: r.Subresource,
+			Level:       permissionLevelByName[strings.ToLower(r.Level)],
+			Description: r.Description,
+		})
+	}
+	PermissionRiskRules = append(custom, PermissionRiskRules...)
+}
+
+// validateResourceVerb 校验资源和操作
+func validateResourceVerb(resource, verb string) error {
+	if strings.TrimSpace(resource) == "" {
+		return i18n.Errorf("resource 不能为空")
+	}
+	if strings.TrimSpace(verb) == "" {
+		return i18n.Errorf("verb 不能为空")
+	}
+	if strings.ContainsAny(resource+verb, " \t") {
+		return i18n.Errorf("resource/verb 不能包含空白字符")
+	}
+	return nil
+}
+
+// validateNonResourceURL 非资源 URL（以 / 开头）不能指定 group 和子资源
+func validateNonResourceURL(resource, group, subresource string) error {
+	if IsNonResourceURL(resource) && (group != "" || subresource != "") {
+		return i18n.Errorf("非资源 URL %s 不能指定 group 或 subresource", resource)
+	}
+	return nil
+}
+
+// validatePatterns 校验通配模式语法
+func validatePatterns(patterns ...string) error {
+	for _, p := range patterns {
+		if err := ValidatePattern(p); err != nil {
+			return i18n.Errorf("无效的通配模式 %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// mergeVerbMap 合并风险条目到查找表
+func mergeVerbMap(dst map[string][]string, entries []RulesRiskEntry) {
+	for _, e := range entries {
+		key := e.key()
+		existing := dst[key]
+		for _, v := range e.Verbs {
+			found := false
+			for _, ev := range existing {
+				if ev == v {
+					found = true
+					break
+				}
+			}
+			if !found {
+				existing = append(existing, v)
+			}
+		}
+		dst[key] = existing
+	}
+}
+
+// copyVerbMap 深拷贝查找表
+func copyVerbMap(src map[string][]string) map[string][]string {
+	dst := make(map[string][]string, len(src))
+	for k, v := range src {
+		dst[k] = append([]string(nil), v...)
+	}
+	return dst
+}
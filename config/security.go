@@ -1,5 +1,7 @@
 package config
 
+import "strings"
+
 // ==================== 敏感路径规则 ====================
 
 // SensitivePaths 敏感路径关键词列表
@@ -47,6 +49,91 @@ var DangerousHostPaths = []string{
 	"/dev",                 // 设备
 }
 
+// DangerousCapabilities 高危 Linux capabilities
+// 添加这些 capability 可能导致容器逃逸、劫持主机网络或读取主机文件
+var DangerousCapabilities = []string{
+	"ALL",             // 全部 capabilities
+	"SYS_ADMIN",       // mount、cgroup release_agent 逃逸
+	"SYS_PTRACE",      // 注入主机进程（配合 hostPID）
+	"SYS_MODULE",      // 加载内核模块
+	"DAC_READ_SEARCH", // open_by_handle_at 读取主机文件
+	"DAC_OVERRIDE",    // 绕过文件权限检查
+	"NET_ADMIN",       // 修改网络配置（配合 hostNetwork）
+	"NET_RAW",         // ARP / DNS 欺骗
+	"SYS_RAWIO",       // 访问 /dev/mem 等原始 I/O
+	"BPF",             // 加载 eBPF 程序
+	"SYS_BOOT",        // 重启主机
+}
+
+// IsDangerousCapability 检查 capability 是否高危（忽略大小写和 CAP_ 前缀）
+func IsDangerousCapability(capability string) bool {
+	capability = strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+	for _, dangerous := range DangerousCapabilities {
+		if capability == dangerous {
+			return true
+		}
+	}
+	return false
+}
+
+// ==================== 敏感服务规则 ====================
+
+// InterestingService 值得关注的集群内服务（管理界面、包管理、数据库等横向移动目标）
+type InterestingService struct {
+	Label    string    // 显示名称，如 kubernetes-dashboard
+	Keywords []string  // 服务名或命名空间包含任一关键词即匹配
+	Ports    []int     // 服务端口为其中之一即匹配
+	Level    RiskLevel // 作为横向移动目标的价值
+	Note     string    // 利用提示
+}
+
+// InterestingServices 敏感服务列表，按顺序匹配，关键词优先于端口
+var InterestingServices = []InterestingService{
+	{Label: "kubernetes-dashboard", Keywords: []string{"kubernetes-dashboard", "k8s-dashboard"}, Level: RiskCritical, Note: "skip 登录或高权限 SA 时可直接管理集群"},
+	{Label: "tiller", Keywords: []string{"tiller"}, Ports: []int{44134}, Level: RiskCritical, Note: "Helm v2 Tiller gRPC 默认无认证，通常为 cluster-admin"},
+	{Label: "argocd", Keywords: []string{"argocd", "argo-cd"}, Level: RiskHigh, Note: "默认 admin 密码为 argocd-initial-admin-secret，可部署任意应用"},
+	{Label: "rancher", Keywords: []string{"rancher"}, Level: RiskHigh, Note: "多集群管理平台"},
+	{Label: "kubeflow", Keywords: []string{"kubeflow", "ml-pipeline"}, Level: RiskHigh, Note: "Notebook / Pipeline 可运行任意代码"},
+	{Label: "jenkins", Keywords: []string{"jenkins"}, Level: RiskHigh, Note: "Script Console 可执行任意代码"},
+	{Label: "vault", Keywords: []string{"vault"}, Ports: []int{8200}, Level: RiskHigh, Note: "集中存放凭据"},
+	{Label: "etcd", Keywords: []string{"etcd"}, Ports: []int{2379, 2380}, Level: RiskCritical, Note: "可读取 /registry/secrets"},
+	{Label: "docker", Ports: []int{2375, 2376}, Level: RiskCritical, Note: "Docker API 可创建特权容器"},
+	{Label: "prometheus", Keywords: []string{"prometheus"}, Ports: []int{9090}, Level: RiskMedium, Note: "/api/v1/targets 暴露内部服务拓扑"},
+	{Label: "alertmanager", Keywords: []string{"alertmanager"}, Ports: []int{9093}, Level: RiskLow, Note: "告警配置可能包含 Webhook 凭据"},
+	{Label: "grafana", Keywords: []string{"grafana"}, Level: RiskMedium, Note: "默认 admin/admin，数据源配置包含凭据"},
+	{Label: "kibana", Keywords: []string{"kibana"}, Ports: []int{5601}, Level: RiskMedium, Note: "日志中可能包含凭据"},
+	{Label: "elasticsearch", Keywords: []string{"elasticsearch"}, Ports: []int{9200}, Level: RiskMedium, Note: "旧版本默认无认证"},
+	{Label: "consul", Keywords: []string{"consul"}, Ports: []int{8500}, Level: RiskMedium, Note: "KV 存储可能包含凭据"},
+	{Label: "redis", Keywords: []string{"redis"}, Ports: []int{6379}, Level: RiskMedium, Note: "常见无密码部署"},
+	{Label: "memcached", Keywords: []string{"memcached"}, Ports: []int{11211}, Level: RiskLow, Note: "无认证"},
+	{Label: "mysql", Keywords: []string{"mysql", "mariadb"}, Ports: []int{3306}, Level: RiskMedium, Note: "数据库"},
+	{Label: "postgres", Keywords: []string{"postgres"}, Ports: []int{5432}, Level: RiskMedium, Note: "数据库"},
+	{Label: "mongodb", Keywords: []string{"mongo"}, Ports: []int{27017}, Level: RiskMedium, Note: "旧版本默认无认证"},
+	{Label: "minio", Keywords: []string{"minio"}, Level: RiskMedium, Note: "默认 minioadmin/minioadmin"},
+	{Label: "kafka", Keywords: []string{"kafka"}, Ports: []int{9092}, Level: RiskLow, Note: "消息队列"},
+	{Label: "rabbitmq", Keywords: []string{"rabbitmq"}, Ports: []int{5672, 15672}, Level: RiskLow, Note: "默认 guest/guest（仅本地）"},
+}
+
+// WellKnownPorts 常见端口对应的服务（InterestingServices 之外）
+var WellKnownPorts = map[int]string{
+	22:    "ssh",
+	53:    "dns",
+	80:    "http",
+	443:   "https",
+	2381:  "etcd-metrics",
+	3000:  "grafana",
+	6443:  "kube-apiserver",
+	8080:  "http-alt",
+	8443:  "https-alt",
+	9100:  "node-exporter",
+	10249: "kube-proxy",
+	10250: "kubelet",
+	10255: "kubelet-readonly",
+	10256: "kube-proxy-health",
+	10257: "kube-controller-manager",
+	10259: "kube-scheduler",
+}
+
 // ==================== 安全上下文检测规则 ====================
 
 // SecurityContextRule 安全上下文检测规则
@@ -150,6 +237,21 @@ var SecurityFlagConfigs = map[string]SecurityFlagConfig{
 		Description: "主机 PID",
 		Level:       "HIGH",
 	},
+	"HostIPC": {
+		Abbrev:      "HIPC",
+		Description: "主机 IPC",
+		Level:       "MEDIUM",
+	},
+	"Capabilities": {
+		Abbrev:      "CAP",
+		Description: "添加高危 capabilities",
+		Level:       "HIGH",
+	},
+	"NoSeccomp": {
+		Abbrev:      "NOSC",
+		Description: "未配置 seccomp",
+		Level:       "LOW",
+	},
 	"SATokenMount": {
 		Abbrev:      "SA",
 		Description: "SA Token 挂载",
@@ -231,6 +231,24 @@ var SecurityFlagDisplayConfig = map[string]SecurityFlagDisplay{
 		Color:       ColorYellow,
 		Description: "主机 PID",
 	},
+	"HostIPC": {
+		Abbrev:      "HIPC",
+		Symbol:      "★",
+		Color:       ColorYellow,
+		Description: "主机 IPC",
+	},
+	"Capabilities": {
+		Abbrev:      "CAP",
+		Symbol:      "★",
+		Color:       ColorRed,
+		Description: "添加高危 capabilities",
+	},
+	"NoSeccomp": {
+		Abbrev:      "NOSC",
+		Symbol:      "★",
+		Color:       ColorGray,
+		Description: "未配置 seccomp",
+	},
 }
 
 // ==================== 表格样式配置 ====================
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"kctl/internal/i18n"
+)
+
+// ==================== 用户配置文件 ====================
+
+// UserConfigEnv 覆盖用户配置文件路径的环境变量
+const UserConfigEnv = "KCTL_CONFIG"
+
+// UserConfig 用户配置文件结构（默认 ~/.kctl/config.yaml）
+//
+// 示例:
+//
+//	filters:
+//	  prod-risky: namespace~"^prod" && risk>=HIGH
+//	default-profile: lab
+//	profiles:
+//	  lab:
+//	    target: 10.0.0.1
+//	    token-file: ~/engagements/lab/token
+//	    api-server: 10.0.0.1
+//	    api-port: 6443
+//	    proxy: socks5://127.0.0.1:1080
+//	    concurrency: 5
+//	    time-format: absolute
+type UserConfig struct {
+	// Filters 命名过滤表达式
+	Filters map[string]string `yaml:"filters,omitempty"`
+
+	// DefaultProfile 未指定 --profile 时使用的 profile，为空时不加载
+	DefaultProfile string `yaml:"default-profile,omitempty"`
+
+	// Profiles 命名的会话默认配置（--profile 或 profile use 选择）
+	Profiles map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+// Profile 会话默认配置，字段与 set 的配置项同名；路径支持 ~/ 开头
+type Profile struct {
+	Target      string            `yaml:"target,omitempty"`
+	Port        int               `yaml:"port,omitempty"`
+	Token       string            `yaml:"token,omitempty"`
+	TokenFile   string            `yaml:"token-file,omitempty"`
+	ClientCert  string            `yaml:"client-cert,omitempty"`
+	ClientKey   string            `yaml:"client-key,omitempty"`
+	Kubeconfig  string            `yaml:"kubeconfig,omitempty"`
+	Context     string            `yaml:"context,omitempty"`
+	APIServer   string            `yaml:"api-server,omitempty"`
+	APIPort     int               `yaml:"api-port,omitempty"`
+	Proxy       string            `yaml:"proxy,omitempty"`
+	RawDump     string            `yaml:"raw-dump,omitempty"`
+	Concurrency int               `yaml:"concurrency,omitempty"`
+	RulesFile   string            `yaml:"rules-file,omitempty"`
+	Env         map[string]string `yaml:"env,omitempty"`
+	ExecVia     string            `yaml:"exec-via,omitempty"`
+	TimeFormat  string            `yaml:"time-format,omitempty"` // relative, absolute
+	TimeZone    string            `yaml:"timezone,omitempty"`    // local, utc
+	Pager       string            `yaml:"pager,omitempty"`       // on, off
+	NotifyURL   string            `yaml:"notify-url,omitempty"`
+	SyncServer  string            `yaml:"sync-server,omitempty"`
+	SyncToken   string            `yaml:"sync-token,omitempty"`
+}
+
+// Profile 按名称查找 profile
+func (c *UserConfig) Profile(name string) (*Profile, error) {
+	profile, ok := c.Profiles[name]
+	if !ok || profile == nil {
+		return nil, i18n.Errorf("profile 不存在: %s", name)
+	}
+	return profile, nil
+}
+
+// ExpandHome 展开 ~/ 开头的路径
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// UserConfigPath 返回用户配置文件路径
+func UserConfigPath() (string, error) {
+	if path := os.Getenv(UserConfigEnv); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", i18n.Errorf("无法确定用户目录: %w", err)
+	}
+	return filepath.Join(home, ".kctl", "config.yaml"), nil
+}
+
+// LoadUserConfig 读取用户配置文件，文件不存在时返回空配置
+func LoadUserConfig() (*UserConfig, error) {
+	cfg := &UserConfig{}
+
+	path, err := UserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取配置文件失败: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, i18n.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// SaveUserConfig 写入用户配置文件
+// 文件已存在时在原有 YAML 节点上合并修改，保留注释及 kctl 不识别的键
+func SaveUserConfig(cfg *UserConfig) error {
+	path, err := UserConfigPath()
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := node.Encode(cfg); err != nil {
+		return i18n.Errorf("序列化配置失败: %w", err)
+	}
+
+	var doc yaml.Node
+	if data, err := os.ReadFile(path); err == nil {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return i18n.Errorf("解析配置文件 %s 失败: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return i18n.Errorf("读取配置文件失败: %w", err)
+	}
+	if len(doc.Content) == 1 && doc.Content[0].Kind == yaml.MappingNode {
+		mergeNode(doc.Content[0], &node, reflect.TypeOf(cfg))
+	} else {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&node}}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return i18n.Errorf("序列化配置失败: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return i18n.Errorf("序列化配置失败: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return i18n.Errorf("创建配置目录失败: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return i18n.Errorf("写入配置文件失败: %w", err)
+	}
+	return nil
+}
+
+// mergeNode 将 src 合并到 dst，t 为节点对应的 Go 类型
+// 结构体中 src 缺少的已知字段从 dst 删除，不识别的键保留；map 以 src 为准
+// 标量等其他节点直接替换，保留 dst 上的注释
+func mergeNode(dst, src *yaml.Node, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode ||
+		(t.Kind() != reflect.Struct && t.Kind() != reflect.Map) {
+		head, line, foot := dst.HeadComment, dst.LineComment, dst.FootComment
+		*dst = *src
+		dst.HeadComment, dst.LineComment, dst.FootComment = head, line, foot
+		return
+	}
+
+	fields := make(map[string]reflect.Type)
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+			if name != "" && name != "-" {
+				fields[name] = t.Field(i).Type
+			}
+		}
+	}
+	valueType := func(key string) (reflect.Type, bool) {
+		if t.Kind() == reflect.Map {
+			return t.Elem(), true
+		}
+		ft, ok := fields[key]
+		return ft, ok
+	}
+
+	updated := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		updated[src.Content[i].Value] = src.Content[i+1]
+	}
+
+	var content []*yaml.Node
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		key, value := dst.Content[i], dst.Content[i+1]
+		vt, known := valueType(key.Value)
+		next, ok := updated[key.Value]
+		switch {
+		case ok:
+			mergeNode(value, next, vt)
+		case known:
+			continue
+		}
+		seen[key.Value] = true
+		content = append(content, key, value)
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		if !seen[src.Content[i].Value] {
+			content = append(content, src.Content[i], src.Content[i+1])
+		}
+	}
+	dst.Content = content
+}
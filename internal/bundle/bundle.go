@@ -0,0 +1,190 @@
+// Package bundle 将数据库、原始 Pod JSON 和扫描元数据打包为单个 tar.gz 归档，便于离线分析
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// 归档内的文件
+const (
+	ManifestFile = "manifest.json"
+	DBFile       = "kctl.db"
+	PodsFile     = "pods.json"
+)
+
+// Format 归档格式标识
+const Format = "kctl-bundle"
+
+// FormatVersion 当前归档格式版本
+const FormatVersion = 1
+
+// Manifest 归档元数据
+type Manifest struct {
+	Format    string              `json:"format"`
+	Version   int                 `json:"version"`
+	CreatedAt time.Time           `json:"createdAt"`
+	KubeletIP string              `json:"kubeletIP,omitempty"`
+	APIServer string              `json:"apiServer,omitempty"`
+	LastScan  time.Time           `json:"lastScan,omitempty"`
+	Counts    map[string]int      `json:"counts"` // 各表记录数（serviceAccounts、pods、findings 等）
+	Scans     []*types.ScanRecord `json:"scans,omitempty"`
+}
+
+// Write 将数据库文件、Pod 列表和元数据写入 tar.gz 归档
+func Write(path string, manifest *Manifest, dbPath string, pods []types.PodContainerInfo) error {
+	manifest.Format = Format
+	manifest.Version = FormatVersion
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return i18n.Errorf("序列化元数据失败: %w", err)
+	}
+	if pods == nil {
+		pods = []types.PodContainerInfo{}
+	}
+	podsJSON, err := json.MarshalIndent(pods, "", "  ")
+	if err != nil {
+		return i18n.Errorf("序列化 Pod 列表失败: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return i18n.Errorf("创建归档失败: %w", err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	err = writeEntry(tw, ManifestFile, manifest.CreatedAt, manifestJSON)
+	if err == nil {
+		err = writeEntry(tw, PodsFile, manifest.CreatedAt, podsJSON)
+	}
+	if err == nil {
+		err = writeFile(tw, DBFile, dbPath)
+	}
+	for _, closeErr := range []error{tw.Close(), gz.Close(), f.Close()} {
+		if err == nil && closeErr != nil {
+			err = i18n.Errorf("写入归档失败: %w", closeErr)
+		}
+	}
+	if err != nil {
+		_ = os.Remove(path)
+	}
+	return err
+}
+
+// writeEntry 写入内存中的文件内容
+func writeEntry(tw *tar.Writer, name string, modTime time.Time, data []byte) error {
+	hdr := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: modTime}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return i18n.Errorf("写入 %s 失败: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return i18n.Errorf("写入 %s 失败: %w", name, err)
+	}
+	return nil
+}
+
+// writeFile 写入磁盘上的文件
+func writeFile(tw *tar.Writer, name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return i18n.Errorf("读取 %s 失败: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return i18n.Errorf("读取 %s 失败: %w", path, err)
+	}
+	hdr := &tar.Header{Name: name, Mode: 0600, Size: info.Size(), ModTime: info.ModTime()}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return i18n.Errorf("写入 %s 失败: %w", name, err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return i18n.Errorf("写入 %s 失败: %w", name, err)
+	}
+	return nil
+}
+
+// Read 读取归档，将数据库解压到 dbPath（文件不能已存在），返回元数据和 Pod 列表
+func Read(path, dbPath string) (*Manifest, []types.PodContainerInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, i18n.Errorf("打开归档失败: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, nil, i18n.Errorf("不是有效的 kctl 归档: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	var manifest *Manifest
+	var pods []types.PodContainerInfo
+	hasDB := false
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, i18n.Errorf("读取归档失败: %w", err)
+		}
+
+		switch hdr.Name {
+		case ManifestFile:
+			manifest = &Manifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, nil, i18n.Errorf("解析 %s 失败: %w", ManifestFile, err)
+			}
+			if manifest.Format != Format {
+				return nil, nil, i18n.Errorf("不是有效的 kctl 归档: format=%q", manifest.Format)
+			}
+			if manifest.Version > FormatVersion {
+				return nil, nil, i18n.Errorf("归档版本 %d 高于当前支持的版本 %d，请升级 kctl", manifest.Version, FormatVersion)
+			}
+		case PodsFile:
+			if err := json.NewDecoder(tr).Decode(&pods); err != nil {
+				return nil, nil, i18n.Errorf("解析 %s 失败: %w", PodsFile, err)
+			}
+		case DBFile:
+			if err := extract(tr, dbPath); err != nil {
+				return nil, nil, err
+			}
+			hasDB = true
+		}
+	}
+
+	if manifest == nil || !hasDB {
+		if hasDB {
+			_ = os.Remove(dbPath)
+		}
+		return nil, nil, i18n.Errorf("不是有效的 kctl 归档: 缺少 %s 或 %s", ManifestFile, DBFile)
+	}
+	return manifest, pods, nil
+}
+
+// extract 将归档中的文件写入 path（不覆盖已有文件）
+func extract(r io.Reader, path string) error {
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return i18n.Errorf("创建数据库文件失败: %w", err)
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		_ = out.Close()
+		_ = os.Remove(path)
+		return i18n.Errorf("解压数据库失败: %w", err)
+	}
+	return out.Close()
+}
@@ -3,33 +3,67 @@ package client
 import (
 	"context"
 	"crypto/tls"
-	"fmt"
 	"net"
 	"net/http"
-	"net/url"
 	"time"
 
 	"github.com/gorilla/websocket"
-	"golang.org/x/net/proxy"
 	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
 )
 
+// Dialer 建立网络连接
+type Dialer interface {
+	DialContext(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
 // Config 客户端通用配置
 type Config struct {
-	// 代理设置
+	// 代理设置：socks5://、socks5h://、http:// 或 https://，可附带 user:pass@ 认证信息
 	ProxyURL string
 
 	// 超时设置
 	Timeout        time.Duration
 	ConnectTimeout time.Duration
 
-	// TLS 设置
+	// TLS 设置：SkipTLSVerify 为 false 时验证服务端证书（set tls-verify on）
+	// 设置了 PinnedCerts 时只接受 SHA256 指纹在其中的证书，否则按 CACertPath / CACertPEM 中的 CA 验证，都未设置时使用系统根证书
 	SkipTLSVerify bool
 	CACertPath    string
+	CACertPEM     []byte
+	PinnedCerts   []string
+
+	// 客户端证书认证（PEM），与 Token 可同时使用
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
 
-	// 重试设置
+	// 重试设置：HTTP 请求遇到临时错误时最多重试 MaxRetries 次，间隔从 RetryInterval 开始指数增长
 	MaxRetries    int
 	RetryInterval time.Duration
+
+	// Keepalive 交互式 exec 会话发送 WebSocket ping 的间隔，0 表示不发送；会话断开后按重试设置自动重连
+	Keepalive time.Duration
+
+	// IdleConnsPerHost 每个目标保持的空闲连接数，与同一目标上的并发数一致时并发请求可复用连接；0 使用 Go 默认值
+	IdleConnsPerHost int
+
+	// RawDumpDir 非空时将 Kubelet 原始响应（/pods、/configz、/stats 等）按原样保存到该目录
+	RawDumpDir string
+
+	// API Server 请求的模拟身份（Impersonate-User / Impersonate-Group），Kubelet 不支持模拟
+	ImpersonateUser   string
+	ImpersonateGroups []string
+
+	// 自定义 User-Agent 及附加到每个请求的请求头（set user-agent / set header），为空时使用 Go 默认值
+	UserAgent string
+	Headers   map[string]string
+
+	// Tunnel 非空时所有连接经由它建立（如 SSH 跳板机），设置了代理时代理也经由它连接
+	Tunnel Dialer
+
+	// Pacer 非空时所有请求和连接按其设置限速并加入随机延迟（set rate-limit / set jitter）
+	Pacer *Pacer
 }
 
 // DefaultConfig 返回默认配置
@@ -39,7 +73,8 @@ func DefaultConfig() *Config {
 		ConnectTimeout: config.DefaultConnectTimeout,
 		SkipTLSVerify:  true,
 		MaxRetries:     config.DefaultMaxRetries,
-		RetryInterval:  time.Second,
+		RetryInterval:  config.DefaultRetryInterval,
+		Keepalive:      config.DefaultKeepaliveInterval,
 	}
 }
 
@@ -55,31 +90,131 @@ func (c *Config) WithTimeout(timeout time.Duration) *Config {
 	return c
 }
 
+// WithClientCert 设置客户端证书和私钥（PEM）
+func (c *Config) WithClientCert(certPEM, keyPEM []byte) *Config {
+	c.ClientCertPEM = certPEM
+	c.ClientKeyPEM = keyPEM
+	return c
+}
+
+// TLSConfig 生成 TLS 配置，设置了客户端证书时一并加载
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: c.SkipTLSVerify}
+	switch {
+	case c.SkipTLSVerify:
+	case len(c.PinnedCerts) > 0:
+		// 固定指纹时跳过内置的证书链验证，由 VerifyConnection 比对指纹
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = c.verifyPinned()
+	default:
+		roots, err := c.rootCAs()
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = roots
+	}
+	if len(c.ClientCertPEM) == 0 && len(c.ClientKeyPEM) == 0 {
+		return tlsConfig, nil
+	}
+	cert, err := tls.X509KeyPair(c.ClientCertPEM, c.ClientKeyPEM)
+	if err != nil {
+		return nil, i18n.Errorf("加载客户端证书失败: %w", err)
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	return tlsConfig, nil
+}
+
+// BearerAuth 返回 Bearer 认证头，Token 为空时返回空字符串（仅使用客户端证书认证）
+func BearerAuth(token string) string {
+	if token == "" {
+		return ""
+	}
+	return "Bearer " + token
+}
+
+// SetAuthHeader 设置 Authorization 请求头，认证头为空时不设置
+func SetAuthHeader(h http.Header, authHeader string) {
+	if authHeader != "" {
+		h.Set("Authorization", authHeader)
+	}
+}
+
+// SetImpersonateHeaders 设置模拟身份请求头，未设置模拟用户时不设置
+func (c *Config) SetImpersonateHeaders(h http.Header) {
+	if c == nil || c.ImpersonateUser == "" {
+		return
+	}
+	h.Set("Impersonate-User", c.ImpersonateUser)
+	for _, group := range c.ImpersonateGroups {
+		h.Add("Impersonate-Group", group)
+	}
+}
+
+// SetRequestHeaders 设置自定义 User-Agent 和附加请求头，未设置时不修改
+func (c *Config) SetRequestHeaders(h http.Header) {
+	if c == nil {
+		return
+	}
+	for name, value := range c.Headers {
+		h.Set(name, value)
+	}
+	if c.UserAgent != "" {
+		h.Set("User-Agent", c.UserAgent)
+	}
+}
+
+// hasRequestHeaders 是否设置了自定义 User-Agent 或附加请求头
+func (c *Config) hasRequestHeaders() bool {
+	return c.UserAgent != "" || len(c.Headers) > 0
+}
+
+// headerTransport 为每个 HTTP 请求设置自定义 User-Agent 和附加请求头
+type headerTransport struct {
+	next http.RoundTripper
+	cfg  *Config
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	t.cfg.SetRequestHeaders(req.Header)
+	return t.next.RoundTrip(req)
+}
+
 // NewHTTPClient 创建 HTTP 客户端
 func NewHTTPClient(cfg *Config) (*http.Client, error) {
 	if cfg == nil {
 		cfg = DefaultConfig()
 	}
 
+	tlsConfig, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	// 连接超时作用于 TCP 连接（经由代理时为连接代理）和 TLS 握手
+	dial, err := cfg.dialer()
+	if err != nil {
+		return nil, err
+	}
 	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: cfg.SkipTLSVerify,
-		},
+		TLSClientConfig:     tlsConfig,
+		DialContext:         dial,
+		TLSHandshakeTimeout: cfg.ConnectTimeout,
+		MaxIdleConnsPerHost: cfg.IdleConnsPerHost,
 	}
 
-	// 配置代理
-	if cfg.ProxyURL != "" {
-		dialer, err := createSOCKS5Dialer(cfg.ProxyURL)
-		if err != nil {
-			return nil, err
-		}
-		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-			return dialer.Dial(network, addr)
-		}
+	var rt http.RoundTripper = logging.Transport(transport)
+	if cfg.hasRequestHeaders() {
+		rt = &headerTransport{next: rt, cfg: cfg}
+	}
+	if cfg.Pacer != nil {
+		rt = &pacedTransport{next: rt, pacer: cfg.Pacer}
+	}
+	// 每次重试都重新限速并记录日志；Timeout 是整个请求（含重试）的超时
+	if cfg.MaxRetries > 0 {
+		rt = &retryTransport{next: rt, maxRetries: cfg.MaxRetries, interval: cfg.RetryInterval}
 	}
-
 	return &http.Client{
-		Transport: transport,
+		Transport: rt,
 		Timeout:   cfg.Timeout,
 	}, nil
 }
@@ -90,36 +225,51 @@ func NewWebSocketDialer(cfg *Config) (*websocket.Dialer, error) {
 		cfg = DefaultConfig()
 	}
 
+	tlsConfig, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
 	dialer := &websocket.Dialer{
-		TLSClientConfig:  &tls.Config{InsecureSkipVerify: cfg.SkipTLSVerify},
+		TLSClientConfig:  tlsConfig,
 		Subprotocols:     []string{"v4.channel.k8s.io"},
 		HandshakeTimeout: config.DefaultWebSocketTimeout,
 	}
 
-	// 配置代理
-	if cfg.ProxyURL != "" {
-		socksDialer, err := createSOCKS5Dialer(cfg.ProxyURL)
-		if err != nil {
+	// 配置连接超时、代理、隧道和请求节奏
+	dial, err := cfg.dialer()
+	if err != nil {
+		return nil, err
+	}
+	dialer.NetDialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if err := cfg.Pacer.Wait(ctx); err != nil {
 			return nil, err
 		}
-		dialer.NetDial = func(network, addr string) (net.Conn, error) {
-			return socksDialer.Dial(network, addr)
-		}
+		return dial(ctx, network, addr)
 	}
 
 	return dialer, nil
 }
 
-// createSOCKS5Dialer 创建 SOCKS5 代理拨号器
-func createSOCKS5Dialer(proxyURL string) (proxy.Dialer, error) {
-	u, err := url.Parse(proxyURL)
-	if err != nil {
-		return nil, fmt.Errorf("解析代理 URL 失败: %w", err)
+// dialer 返回建立 TCP 连接的函数，配置了隧道和代理时依次经由隧道、代理
+func (c *Config) dialer() (dialFunc, error) {
+	var forward Dialer = &net.Dialer{Timeout: c.ConnectTimeout}
+	if c.Tunnel != nil {
+		forward = c.Tunnel
 	}
-
-	if u.Scheme != "socks5" && u.Scheme != "socks5h" {
-		return nil, fmt.Errorf("不支持的代理协议: %s，仅支持 socks5 或 socks5h", u.Scheme)
+	if c.ProxyURL != "" {
+		return newProxyDialer(c.ProxyURL, forward, c.ConnectTimeout, c.SkipTLSVerify)
 	}
+	return forward.DialContext, nil
+}
 
-	return proxy.SOCKS5("tcp", u.Host, nil, proxy.Direct)
+// DialTCP 建立 TCP 连接（配置了代理时经由代理），设置了 Pacer 时先等待
+func DialTCP(ctx context.Context, cfg *Config, addr string) (net.Conn, error) {
+	if err := cfg.Pacer.Wait(ctx); err != nil {
+		return nil, err
+	}
+	dial, err := cfg.dialer()
+	if err != nil {
+		return nil, err
+	}
+	return dial(ctx, "tcp", addr)
 }
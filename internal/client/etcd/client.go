@@ -0,0 +1,163 @@
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"kctl/internal/client"
+	"kctl/internal/i18n"
+)
+
+// ErrUnauthorized etcd 拒绝访问（开启了 --client-cert-auth 或用户认证）
+var ErrUnauthorized = i18n.New("etcd 拒绝访问")
+
+// Version etcd /version 响应
+type Version struct {
+	Server  string `json:"etcdserver"`
+	Cluster string `json:"etcdcluster"`
+}
+
+// RangeResult 键范围查询结果
+type RangeResult struct {
+	Keys  []string
+	Count int64 // 范围内的键总数（不受 limit 限制）
+}
+
+// Client etcd v3 HTTP 网关（/v3/kv/...）客户端
+// kube-apiserver 使用的 etcd 默认同时开放 gRPC 和 HTTP 网关，无需 gRPC 依赖
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient 创建 etcd 客户端，endpoint 如 https://10.0.0.10:2379；cfg 中的客户端证书用于双向 TLS 认证
+func NewClient(endpoint string, cfg *client.Config) (*Client, error) {
+	httpClient, err := client.NewHTTPClient(cfg)
+	if err != nil {
+		return nil, i18n.Errorf("创建 HTTP 客户端失败: %w", err)
+	}
+	return &Client{endpoint: strings.TrimSuffix(endpoint, "/"), httpClient: httpClient}, nil
+}
+
+// Endpoint 返回 etcd 地址
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// Version 获取 etcd 版本（etcd 不对 /version 做客户端认证，可用于确认端口是 etcd）
+func (c *Client) Version(ctx context.Context) (*Version, error) {
+	body, err := c.do(ctx, http.MethodGet, "/version", nil)
+	if err != nil {
+		return nil, err
+	}
+	var version Version
+	if err := json.Unmarshal(body, &version); err != nil || version.Server == "" {
+		return nil, i18n.Errorf("响应不像是 etcd: %s", truncate(body))
+	}
+	return &version, nil
+}
+
+// RangeKeys 列出前缀下的键（只取键，不取值），最多 limit 个
+func (c *Client) RangeKeys(ctx context.Context, prefix string, limit int) (*RangeResult, error) {
+	request := map[string]any{
+		"key":       base64.StdEncoding.EncodeToString([]byte(prefix)),
+		"range_end": base64.StdEncoding.EncodeToString(prefixEnd(prefix)),
+		"keys_only": true,
+		"limit":     limit,
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.do(ctx, http.MethodPost, "/v3/kv/range", data)
+	if err != nil {
+		return nil, err
+	}
+
+	// gRPC 网关将 int64 编码为字符串
+	var response struct {
+		Kvs []struct {
+			Key string `json:"key"`
+		} `json:"kvs"`
+		Count string `json:"count"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthorized, response.Error)
+	}
+
+	result := &RangeResult{}
+	result.Count, _ = strconv.ParseInt(response.Count, 10, 64)
+	for _, kv := range response.Kvs {
+		key, err := base64.StdEncoding.DecodeString(kv.Key)
+		if err != nil {
+			continue
+		}
+		result.Keys = append(result.Keys, string(key))
+	}
+	return result, nil
+}
+
+// do 发送请求，200 时返回响应体
+func (c *Client) do(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reader)
+	if err != nil {
+		return nil, i18n.Errorf("创建请求失败: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, i18n.Errorf("请求 etcd 失败: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, i18n.Errorf("读取响应失败: %w", err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("%w (HTTP %d): %s", ErrUnauthorized, resp.StatusCode, truncate(data))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, i18n.Errorf("etcd 返回错误 (HTTP %d): %s", resp.StatusCode, truncate(data))
+	}
+	return data, nil
+}
+
+// prefixEnd 返回前缀范围查询的 range_end（最后一个字节加一）
+func prefixEnd(prefix string) []byte {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return []byte{0}
+}
+
+// truncate 截断错误响应，避免输出整页 HTML
+func truncate(body []byte) string {
+	s := strings.TrimSpace(string(body))
+	if len(s) > 200 {
+		return s[:200] + "..."
+	}
+	return s
+}
@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/term"
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// ExecRedial 重新建立交互式 exec 的 WebSocket 连接，用于断线重连
+type ExecRedial func(ctx context.Context) (*websocket.Conn, error)
+
+// StreamInteractive 在已建立的 exec WebSocket 连接上转发终端输入输出（不保活、不重连）
+func StreamInteractive(conn *websocket.Conn, opts *types.ExecOptions) error {
+	return StreamInteractiveSession(context.Background(), nil, conn, nil, opts)
+}
+
+// StreamInteractiveSession 在 exec WebSocket 连接上转发终端输入输出，并关闭使用过的连接
+// cfg.Keepalive 大于 0 时按该间隔发送 ping 保活，收到过 pong 后超过两个间隔没有任何数据即判定连接已断开；
+// redial 非 nil 且开启了 TTY 时，连接在远程命令结束前意外断开会按 cfg 的重试设置（MaxRetries / RetryInterval）重新连接
+func StreamInteractiveSession(ctx context.Context, cfg *Config, conn *websocket.Conn, redial ExecRedial, opts *types.ExecOptions) error {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	if !opts.TTY {
+		redial = nil
+	}
+
+	// 如果启用了 TTY，将终端设置为 raw 模式
+	if opts.TTY {
+		fd := int(os.Stdin.Fd())
+		if term.IsTerminal(fd) {
+			oldState, err := term.MakeRaw(fd)
+			if err != nil {
+				_ = conn.Close()
+				return i18n.Errorf("设置终端 raw 模式失败: %w", err)
+			}
+			defer func() { _ = term.Restore(fd, oldState) }()
+		}
+	}
+
+	// 标准输入在多次连接之间共享，由同一个 goroutine 读取
+	done := make(chan struct{})
+	var stdin <-chan []byte
+	var stdinWG sync.WaitGroup
+	if opts.Stdin {
+		stdin = readStdin(done, &stdinWG)
+	}
+	defer func() {
+		close(done)
+		// 与远程命令结束后一样，等待读取标准输入的 goroutine 退出，避免其读走控制台的下一次输入
+		stdinWG.Wait()
+	}()
+
+	for {
+		ended, err := streamConn(ctx, conn, stdin, cfg.Keepalive)
+		_ = conn.Close()
+		if ended || redial == nil || ctx.Err() != nil {
+			return nil
+		}
+
+		conn, err = reconnectExec(ctx, cfg, redial, err)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// reconnectExec 按重试设置重新建立 exec 连接，cause 为连接断开的原因
+func reconnectExec(ctx context.Context, cfg *Config, redial ExecRedial, cause error) (*websocket.Conn, error) {
+	reason := "connection closed"
+	if cause != nil {
+		reason = cause.Error()
+	}
+	fmt.Fprintf(os.Stderr, "\r\n[!] Connection lost (%s)\r\n", reason)
+
+	var lastErr error
+	for attempt := 0; attempt < cfg.MaxRetries; attempt++ {
+		delay := retryDelay(cfg.RetryInterval, attempt, "")
+		fmt.Fprintf(os.Stderr, "[*] Reconnecting in %s (%d/%d)...\r\n", delay, attempt+1, cfg.MaxRetries)
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+		conn, err := redial(ctx)
+		if err == nil {
+			fmt.Fprintf(os.Stderr, "[+] Reconnected\r\n")
+			return conn, nil
+		}
+		lastErr = err
+		fmt.Fprintf(os.Stderr, "[!] Reconnect failed: %v\r\n", err)
+	}
+	if lastErr == nil {
+		return nil, i18n.Errorf("交互式会话连接中断: %s（set retries 可开启自动重连）", reason)
+	}
+	return nil, i18n.Errorf("交互式会话连接中断，重连失败: %w", lastErr)
+}
+
+// readStdin 持续读取标准输入并发送到返回的通道，读取结束（EOF 或出错）时关闭通道
+func readStdin(done <-chan struct{}, wg *sync.WaitGroup) <-chan []byte {
+	ch := make(chan []byte)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(ch)
+		for {
+			buf := make([]byte, 1024)
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				select {
+				case ch <- buf[:n]:
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// streamConn 在单个连接上转发输入输出，直到远程命令结束或连接断开
+// ended 为 true 表示远程命令已结束（收到 error 通道的状态或正常关闭），否则连接意外断开，err 为断开原因
+func streamConn(ctx context.Context, conn *websocket.Conn, stdin <-chan []byte, keepalive time.Duration) (bool, error) {
+	// 上下文取消时关闭连接，中断阻塞的读取
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	// 收到过 pong 后才启用读取超时，不响应 ping 的服务端只发送 ping 维持连接活跃
+	var alive atomic.Bool
+	extend := func() {
+		if alive.Load() {
+			_ = conn.SetReadDeadline(time.Now().Add(2 * keepalive))
+		}
+	}
+	if keepalive > 0 {
+		conn.SetPongHandler(func(string) error {
+			alive.Store(true)
+			extend()
+			return nil
+		})
+	}
+
+	type readResult struct {
+		ended bool
+		err   error
+	}
+	readDone := make(chan readResult, 1)
+	go func() {
+		ended := false
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if ended || websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					readDone <- readResult{ended: true}
+				} else {
+					readDone <- readResult{err: err}
+				}
+				return
+			}
+			extend()
+			if len(message) < 1 {
+				continue
+			}
+
+			data := message[1:]
+			switch message[0] {
+			case StreamStdout:
+				_, _ = os.Stdout.Write(data)
+			case StreamStderr:
+				_, _ = os.Stderr.Write(data)
+			case StreamError:
+				// error 通道的状态表示远程命令已结束，之后连接关闭不是断线
+				ended = true
+				var execStatus types.ExecStatus
+				if json.Unmarshal(data, &execStatus) == nil && execStatus.Status == "Success" {
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "\n[Error] %s\n", string(data))
+			}
+		}
+	}()
+
+	var pings <-chan time.Time
+	if keepalive > 0 {
+		ticker := time.NewTicker(keepalive)
+		defer ticker.Stop()
+		pings = ticker.C
+	}
+
+	for {
+		select {
+		case result := <-readDone:
+			if ctx.Err() != nil {
+				return true, nil
+			}
+			return result.ended, result.err
+		case data, ok := <-stdin:
+			if !ok {
+				// 标准输入结束，继续接收输出直到远程命令结束
+				stdin = nil
+				continue
+			}
+			// 发送数据，第一个字节是通道编号 (stdin = 0)；写入失败时由读取端报告断开
+			msg := append([]byte{StreamStdin}, data...)
+			_ = conn.WriteMessage(websocket.BinaryMessage, msg)
+		case <-pings:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(keepalive)); err != nil && !errors.Is(err, websocket.ErrCloseSent) {
+				_ = conn.Close()
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// admissionPrefix 准入注册 API 路径前缀
+const admissionPrefix = "/apis/admissionregistration.k8s.io/v1"
+
+// ListMutatingWebhooks 列出 MutatingWebhookConfiguration 中的 Webhook
+func (c *k8sClient) ListMutatingWebhooks(ctx context.Context) ([]types.WebhookInfo, error) {
+	return c.listWebhooks(ctx, "Mutating", admissionPrefix+"/mutatingwebhookconfigurations")
+}
+
+// ListValidatingWebhooks 列出 ValidatingWebhookConfiguration 中的 Webhook
+func (c *k8sClient) ListValidatingWebhooks(ctx context.Context) ([]types.WebhookInfo, error) {
+	return c.listWebhooks(ctx, "Validating", admissionPrefix+"/validatingwebhookconfigurations")
+}
+
+func (c *k8sClient) listWebhooks(ctx context.Context, kind, path string) ([]types.WebhookInfo, error) {
+	body, err := c.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.WebhookConfigurationListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	var webhooks []types.WebhookInfo
+	for _, item := range response.Items {
+		for _, w := range item.Webhooks {
+			info := types.WebhookInfo{
+				Kind:              kind,
+				Configuration:     item.Metadata.Name,
+				Name:              w.Name,
+				FailurePolicy:     w.FailurePolicy,
+				TimeoutSeconds:    w.TimeoutSeconds,
+				NamespaceSelector: w.NamespaceSelector,
+				ObjectSelector:    w.ObjectSelector,
+				Rules:             w.Rules,
+				Endpoint:          w.ClientConfig.URL,
+			}
+			if info.FailurePolicy == "" {
+				info.FailurePolicy = "Fail"
+			}
+			if svc := w.ClientConfig.Service; svc != nil {
+				port := svc.Port
+				if port == 0 {
+					port = 443
+				}
+				info.Endpoint = svc.Namespace + "/" + svc.Name + ":" + strconv.Itoa(port) + svc.Path
+			}
+			webhooks = append(webhooks, info)
+		}
+	}
+	return webhooks, nil
+}
@@ -0,0 +1,20 @@
+package k8s
+
+import (
+	"context"
+	"net/url"
+)
+
+// GetMetrics 获取 API Server /metrics（Prometheus 文本格式）
+func (c *k8sClient) GetMetrics(ctx context.Context) ([]byte, error) {
+	return c.get(ctx, "/metrics")
+}
+
+// ListPodsRaw 按标签选择器列出 Pod，返回原始 PodList JSON（labelSelector 为空时不过滤）
+func (c *k8sClient) ListPodsRaw(ctx context.Context, namespace, labelSelector string) ([]byte, error) {
+	path := corePath("pods", namespace)
+	if labelSelector != "" {
+		path += "?labelSelector=" + url.QueryEscape(labelSelector)
+	}
+	return c.get(ctx, path)
+}
@@ -5,10 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
+	"github.com/gorilla/websocket"
 	"kctl/config"
 	"kctl/internal/client"
+	"kctl/internal/i18n"
 	"kctl/pkg/types"
 )
 
@@ -18,15 +21,58 @@ type Client interface {
 	CheckPermission(ctx context.Context, req *PermissionRequest) (bool, error)
 	CheckPermissions(ctx context.Context, reqs []PermissionRequest) ([]types.PermissionCheck, error)
 	CheckCommonPermissions(ctx context.Context, namespace string) ([]types.PermissionCheck, error)
+
+	// 集群资源查询
+	ListNodes(ctx context.Context) ([]types.NodeInfo, error)
+	ListConfigMaps(ctx context.Context, namespace string) ([]types.ConfigMapInfo, error)
+	ListServiceAccounts(ctx context.Context, namespace string) ([]types.ServiceAccountInfo, error)
+	ListNamespaces(ctx context.Context) ([]types.NamespaceInfo, error)
+	ListServices(ctx context.Context, namespace string) ([]types.ServiceInfo, error)
+	ListEndpoints(ctx context.Context, namespace string) (map[string][]types.ServiceEndpoint, error)
+	ListPodSecurityPolicies(ctx context.Context) ([]types.PodSecurityPolicyInfo, error)
+	GetVersion(ctx context.Context) (string, error)
+
+	// API Server 配置检查（apiserver audit）
+	GetMetrics(ctx context.Context) ([]byte, error)
+	ListPodsRaw(ctx context.Context, namespace, labelSelector string) ([]byte, error)
+
+	// RBAC 对象查询（rbac who-can / analyze）
+	ListClusterRoles(ctx context.Context) ([]types.RoleInfo, error)
+	ListRoles(ctx context.Context, namespace string) ([]types.RoleInfo, error)
+	ListClusterRoleBindings(ctx context.Context) ([]types.RoleBindingInfo, error)
+	ListRoleBindings(ctx context.Context, namespace string) ([]types.RoleBindingInfo, error)
+
+	// 准入 Webhook 查询（webhooks）
+	ListMutatingWebhooks(ctx context.Context) ([]types.WebhookInfo, error)
+	ListValidatingWebhooks(ctx context.Context) ([]types.WebhookInfo, error)
+
+	// 通过 pods/exec 子资源执行命令
+	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
+	ExecInteractive(ctx context.Context, opts *types.ExecOptions) error
+
+	// 通过 nodes/proxy 子资源访问 Kubelet /exec
+	NodeProxyExec(ctx context.Context, node string, opts *types.ExecOptions) (*types.ExecResult, error)
+	NodeProxyExecInteractive(ctx context.Context, node string, opts *types.ExecOptions) error
+
+	// 临时容器（debug）
+	AddEphemeralContainer(ctx context.Context, opts *types.EphemeralContainerOptions) error
+	GetContainerStatus(ctx context.Context, namespace, pod, name string) (*types.ContainerStatus, error)
+	Attach(ctx context.Context, opts *types.ExecOptions) error
+
+	// 通用资源创建/删除（deploy）
+	Create(ctx context.Context, ref types.ResourceRef, body []byte) error
+	Delete(ctx context.Context, ref types.ResourceRef) error
 }
 
 // PermissionRequest 权限检查请求
+// Resource 以 / 开头时按非资源 URL 检查（nonResourceAttributes）
 type PermissionRequest struct {
 	Resource    string
 	Verb        string
 	Namespace   string
 	Group       string
 	Subresource string
+	Name        string // 资源名称，为空时检查所有资源
 }
 
 // k8sClient K8s API 客户端实现
@@ -34,6 +80,7 @@ type k8sClient struct {
 	apiServer  string
 	token      string
 	httpClient *http.Client
+	wsDialer   *websocket.Dialer
 	config     *client.Config
 }
 
@@ -49,17 +96,42 @@ func NewClient(apiServer, token string, cfg *client.Config) (Client, error) {
 
 	httpClient, err := client.NewHTTPClient(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("创建 HTTP 客户端失败: %w", err)
+		return nil, i18n.Errorf("创建 HTTP 客户端失败: %w", err)
+	}
+
+	wsDialer, err := client.NewWebSocketDialer(cfg)
+	if err != nil {
+		return nil, i18n.Errorf("创建 WebSocket 拨号器失败: %w", err)
 	}
 
 	return &k8sClient{
 		apiServer:  apiServer,
 		token:      token,
 		httpClient: httpClient,
+		wsDialer:   wsDialer,
 		config:     cfg,
 	}, nil
 }
 
+// authHeader 返回认证头，未设置 Token 时为空（使用客户端证书认证）
+func (c *k8sClient) authHeader() string {
+	return client.BearerAuth(c.token)
+}
+
+// setHeaders 设置认证头和模拟身份请求头
+func (c *k8sClient) setHeaders(h http.Header) {
+	client.SetAuthHeader(h, c.authHeader())
+	c.config.SetImpersonateHeaders(h)
+}
+
+// headers 返回 exec / attach 连接使用的请求头（WebSocket 握手不经过 HTTP Transport，需附带自定义请求头）
+func (c *k8sClient) headers() http.Header {
+	h := http.Header{}
+	c.setHeaders(h)
+	c.config.SetRequestHeaders(h)
+	return h
+}
+
 // SelfSubjectAccessReviewRequest 请求结构
 type SelfSubjectAccessReviewRequest struct {
 	APIVersion string                  `json:"apiVersion"`
@@ -68,7 +140,8 @@ type SelfSubjectAccessReviewRequest struct {
 }
 
 type AccessReviewRequestSpec struct {
-	ResourceAttributes *ResourceAttributes `json:"resourceAttributes,omitempty"`
+	ResourceAttributes    *ResourceAttributes    `json:"resourceAttributes,omitempty"`
+	NonResourceAttributes *NonResourceAttributes `json:"nonResourceAttributes,omitempty"`
 }
 
 type ResourceAttributes struct {
@@ -77,6 +150,12 @@ type ResourceAttributes struct {
 	Group       string `json:"group,omitempty"`
 	Resource    string `json:"resource"`
 	Subresource string `json:"subresource,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
+
+type NonResourceAttributes struct {
+	Path string `json:"path"`
+	Verb string `json:"verb"`
 }
 
 // SelfSubjectAccessReviewResponse 响应结构
@@ -94,44 +173,50 @@ func (c *k8sClient) CheckPermission(ctx context.Context, req *PermissionRequest)
 	reviewReq := &SelfSubjectAccessReviewRequest{
 		APIVersion: "authorization.k8s.io/v1",
 		Kind:       "SelfSubjectAccessReview",
-		Spec: AccessReviewRequestSpec{
-			ResourceAttributes: &ResourceAttributes{
-				Namespace:   req.Namespace,
-				Verb:        req.Verb,
-				Group:       req.Group,
-				Resource:    req.Resource,
-				Subresource: req.Subresource,
-			},
-		},
+	}
+	if config.IsNonResourceURL(req.Resource) {
+		reviewReq.Spec.NonResourceAttributes = &NonResourceAttributes{
+			Path: req.Resource,
+			Verb: req.Verb,
+		}
+	} else {
+		reviewReq.Spec.ResourceAttributes = &ResourceAttributes{
+			Namespace:   req.Namespace,
+			Verb:        req.Verb,
+			Group:       req.Group,
+			Resource:    req.Resource,
+			Subresource: req.Subresource,
+			Name:        req.Name,
+		}
 	}
 
 	body, err := json.Marshal(reviewReq)
 	if err != nil {
-		return false, fmt.Errorf("序列化请求失败: %w", err)
+		return false, i18n.Errorf("序列化请求失败: %w", err)
 	}
 
 	url := c.apiServer + "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews"
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 	if err != nil {
-		return false, fmt.Errorf("创建请求失败: %w", err)
+		return false, i18n.Errorf("创建请求失败: %w", err)
 	}
 
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	c.setHeaders(httpReq.Header)
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return false, fmt.Errorf("请求 K8s API Server 失败: %w", err)
+		return false, i18n.Errorf("请求 K8s API Server 失败: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("K8s API Server 返回错误状态: %d", resp.StatusCode)
+		return false, i18n.Errorf("K8s API Server 返回错误状态: %d", resp.StatusCode)
 	}
 
 	var response SelfSubjectAccessReviewResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return false, fmt.Errorf("解析响应失败: %w", err)
+		return false, i18n.Errorf("解析响应失败: %w", err)
 	}
 
 	return response.Status.Allowed, nil
@@ -175,3 +260,235 @@ func (c *k8sClient) CheckCommonPermissions(ctx context.Context, namespace string
 
 	return c.CheckPermissions(ctx, reqs)
 }
+
+// get 发送 GET 请求并返回响应体
+func (c *k8sClient) get(ctx context.Context, path string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, path, "", nil)
+}
+
+// do 发送请求，2xx 时返回响应体
+func (c *k8sClient) do(ctx context.Context, method, path, contentType string, body []byte) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.apiServer+path, reader)
+	if err != nil {
+		return nil, i18n.Errorf("创建请求失败: %w", err)
+	}
+
+	c.setHeaders(httpReq.Header)
+	httpReq.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, i18n.Errorf("请求 K8s API Server 失败: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, i18n.Errorf("认证失败：Token 无效")
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, i18n.Errorf("权限被拒绝：Token 无权访问 %s", path)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, i18n.Errorf("K8s API Server 返回错误状态: %d%s", resp.StatusCode, statusMessage(resp.Body))
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// statusMessage 从错误响应中提取 Status.message
+func statusMessage(r io.Reader) string {
+	var status struct {
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r, 64*1024)).Decode(&status); err != nil || status.Message == "" {
+		return ""
+	}
+	return " (" + status.Message + ")"
+}
+
+// ListNodes 列出集群节点
+func (c *k8sClient) ListNodes(ctx context.Context) ([]types.NodeInfo, error) {
+	body, err := c.get(ctx, "/api/v1/nodes")
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.NodeListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	var nodes []types.NodeInfo
+	for _, item := range response.Items {
+		node := types.NodeInfo{
+			Name:             item.Metadata.Name,
+			KubeletPort:      item.Status.DaemonEndpoints.KubeletEndpoint.Port,
+			KubeletVersion:   item.Status.NodeInfo.KubeletVersion,
+			OSImage:          item.Status.NodeInfo.OSImage,
+			KernelVersion:    item.Status.NodeInfo.KernelVersion,
+			ContainerRuntime: item.Status.NodeInfo.ContainerRuntimeVersion,
+			Architecture:     item.Status.NodeInfo.Architecture,
+			Source:           "apiserver",
+			ProviderID:       item.Spec.ProviderID,
+			Labels:           item.Metadata.Labels,
+		}
+		for _, addr := range item.Status.Addresses {
+			if addr.Type == "InternalIP" && node.InternalIP == "" {
+				node.InternalIP = addr.Address
+			}
+		}
+		for _, cond := range item.Status.Conditions {
+			if cond.Type == "Ready" {
+				node.Ready = cond.Status == "True"
+			}
+		}
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// GetVersion 获取 API Server 版本（/version）
+func (c *k8sClient) GetVersion(ctx context.Context) (string, error) {
+	body, err := c.get(ctx, "/version")
+	if err != nil {
+		return "", err
+	}
+
+	var info struct {
+		GitVersion string `json:"gitVersion"`
+	}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", i18n.Errorf("解析响应失败: %w", err)
+	}
+	return info.GitVersion, nil
+}
+
+// ListConfigMaps 列出 ConfigMap（namespace 为空时列出所有命名空间）
+func (c *k8sClient) ListConfigMaps(ctx context.Context, namespace string) ([]types.ConfigMapInfo, error) {
+	path := "/api/v1/configmaps"
+	if namespace != "" {
+		path = "/api/v1/namespaces/" + namespace + "/configmaps"
+	}
+
+	body, err := c.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.ConfigMapListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	var cms []types.ConfigMapInfo
+	for _, item := range response.Items {
+		cms = append(cms, types.ConfigMapInfo{
+			Name:      item.Metadata.Name,
+			Namespace: item.Metadata.Namespace,
+			Data:      item.Data,
+		})
+	}
+
+	return cms, nil
+}
+
+// ListServiceAccounts 列出 ServiceAccount（namespace 为空时列出所有命名空间）
+func (c *k8sClient) ListServiceAccounts(ctx context.Context, namespace string) ([]types.ServiceAccountInfo, error) {
+	path := "/api/v1/serviceaccounts"
+	if namespace != "" {
+		path = "/api/v1/namespaces/" + namespace + "/serviceaccounts"
+	}
+
+	body, err := c.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.ServiceAccountListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	var sas []types.ServiceAccountInfo
+	for _, item := range response.Items {
+		sas = append(sas, types.ServiceAccountInfo{
+			Name:        item.Metadata.Name,
+			Namespace:   item.Metadata.Namespace,
+			Annotations: item.Metadata.Annotations,
+		})
+	}
+
+	return sas, nil
+}
+
+// ListNamespaces 列出命名空间
+func (c *k8sClient) ListNamespaces(ctx context.Context) ([]types.NamespaceInfo, error) {
+	body, err := c.get(ctx, "/api/v1/namespaces")
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.NamespaceListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	var namespaces []types.NamespaceInfo
+	for _, item := range response.Items {
+		namespaces = append(namespaces, types.NamespaceInfo{
+			Name:   item.Metadata.Name,
+			Phase:  item.Status.Phase,
+			Labels: item.Metadata.Labels,
+		})
+	}
+
+	return namespaces, nil
+}
+
+// ListPodSecurityPolicies 列出 PodSecurityPolicy（集群不支持 PSP 时返回 ErrNotFound）
+func (c *k8sClient) ListPodSecurityPolicies(ctx context.Context) ([]types.PodSecurityPolicyInfo, error) {
+	body, err := c.get(ctx, "/apis/policy/v1beta1/podsecuritypolicies")
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.PodSecurityPolicyListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	var psps []types.PodSecurityPolicyInfo
+	for _, item := range response.Items {
+		psp := types.PodSecurityPolicyInfo{
+			Name:                item.Metadata.Name,
+			Privileged:          item.Spec.Privileged,
+			HostPID:             item.Spec.HostPID,
+			HostIPC:             item.Spec.HostIPC,
+			HostNetwork:         item.Spec.HostNetwork,
+			Volumes:             item.Spec.Volumes,
+			AllowedCapabilities: item.Spec.AllowedCapabilities,
+			RunAsUserRule:       item.Spec.RunAsUser.Rule,
+		}
+		for _, hp := range item.Spec.AllowedHostPaths {
+			path := hp.PathPrefix
+			if hp.ReadOnly {
+				path += " (ro)"
+			}
+			psp.AllowedHostPaths = append(psp.AllowedHostPaths, path)
+		}
+		psps = append(psps, psp)
+	}
+
+	return psps, nil
+}
@@ -0,0 +1,107 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"kctl/internal/client"
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// AddEphemeralContainer 通过 pods/ephemeralcontainers 子资源向 Pod 注入临时容器
+func (c *k8sClient) AddEphemeralContainer(ctx context.Context, opts *types.EphemeralContainerOptions) error {
+	container := map[string]interface{}{
+		"name":                     opts.Name,
+		"image":                    opts.Image,
+		"imagePullPolicy":          "IfNotPresent",
+		"stdin":                    true,
+		"tty":                      true,
+		"terminationMessagePolicy": "File",
+	}
+	if len(opts.Command) > 0 {
+		container["command"] = opts.Command
+	}
+	if opts.TargetContainer != "" {
+		container["targetContainerName"] = opts.TargetContainer
+	}
+
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"ephemeralContainers": []interface{}{container},
+		},
+	}
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return i18n.Errorf("序列化请求失败: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/ephemeralcontainers",
+		url.PathEscape(opts.Namespace), url.PathEscape(opts.Pod))
+	_, err = c.do(ctx, "PATCH", path, "application/strategic-merge-patch+json", body)
+	return err
+}
+
+// GetContainerStatus 获取容器（含临时容器）状态，容器尚未出现在 Pod 状态中时返回 nil
+func (c *k8sClient) GetContainerStatus(ctx context.Context, namespace, pod, name string) (*types.ContainerStatus, error) {
+	body, err := c.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/pods/%s",
+		url.PathEscape(namespace), url.PathEscape(pod)))
+	if err != nil {
+		return nil, err
+	}
+
+	type containerStatus struct {
+		Name  string `json:"name"`
+		State struct {
+			Waiting *struct {
+				Reason string `json:"reason"`
+			} `json:"waiting"`
+			Running    *struct{} `json:"running"`
+			Terminated *struct {
+				Reason string `json:"reason"`
+			} `json:"terminated"`
+		} `json:"state"`
+	}
+	var response struct {
+		Status struct {
+			ContainerStatuses          []containerStatus `json:"containerStatuses"`
+			EphemeralContainerStatuses []containerStatus `json:"ephemeralContainerStatuses"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	all := append(response.Status.ContainerStatuses, response.Status.EphemeralContainerStatuses...)
+	for _, cs := range all {
+		if cs.Name != name {
+			continue
+		}
+		status := &types.ContainerStatus{Name: cs.Name}
+		switch {
+		case cs.State.Running != nil:
+			status.State = "Running"
+		case cs.State.Terminated != nil:
+			status.State = "Terminated"
+			status.Reason = cs.State.Terminated.Reason
+		case cs.State.Waiting != nil:
+			status.State = "Waiting"
+			status.Reason = cs.State.Waiting.Reason
+		}
+		return status, nil
+	}
+	return nil, nil
+}
+
+// Attach 通过 pods/attach 子资源连接到容器主进程（交互式）
+func (c *k8sClient) Attach(ctx context.Context, opts *types.ExecOptions) error {
+	conn, err := client.DialExecHeaders(ctx, c.wsDialer, c.buildStreamURL("attach", opts), c.headers())
+	if err != nil {
+		return wrapExecError(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	return client.StreamInteractive(conn, opts)
+}
@@ -0,0 +1,172 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"kctl/internal/client"
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// Exec 通过 API Server 的 pods/exec 子资源在 Pod 中执行命令（非交互式）
+func (c *k8sClient) Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	conn, err := client.DialExecHeaders(ctx, c.wsDialer, c.buildStreamURL("exec", opts), c.headers())
+	if err != nil {
+		return nil, wrapExecError(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	// 上下文取消时关闭连接，中断阻塞的读取
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	result, err := client.ReadExecOutput(conn)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return result, err
+}
+
+// ExecInteractive 通过 API Server 的 pods/exec 子资源交互式执行命令，连接保活，TTY 会话意外断开时重新连接
+func (c *k8sClient) ExecInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	return c.streamInteractive(ctx, c.buildStreamURL("exec", opts), opts, wrapExecError)
+}
+
+// NodeProxyExec 通过 nodes/proxy 子资源转发到节点 Kubelet 的 /exec（非交互式）
+// 只需要 nodes/proxy 权限，不经过 pods/exec 的鉴权与审计
+func (c *k8sClient) NodeProxyExec(ctx context.Context, node string, opts *types.ExecOptions) (*types.ExecResult, error) {
+	conn, err := client.DialExecHeaders(ctx, c.wsDialer, c.buildNodeProxyExecURL(node, opts), c.headers())
+	if err != nil {
+		return nil, wrapNodeProxyError(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	result, err := client.ReadExecOutput(conn)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return result, err
+}
+
+// NodeProxyExecInteractive 通过 nodes/proxy 子资源交互式执行
+func (c *k8sClient) NodeProxyExecInteractive(ctx context.Context, node string, opts *types.ExecOptions) error {
+	return c.streamInteractive(ctx, c.buildNodeProxyExecURL(node, opts), opts, wrapNodeProxyError)
+}
+
+// streamInteractive 建立交互式 exec 连接并转发终端输入输出，断线重连使用同一 URL，wrapErr 转换连接错误
+func (c *k8sClient) streamInteractive(ctx context.Context, execURL string, opts *types.ExecOptions, wrapErr func(error) error) error {
+	dial := func(ctx context.Context) (*websocket.Conn, error) {
+		conn, err := client.DialExecHeaders(ctx, c.wsDialer, execURL, c.headers())
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+		return conn, nil
+	}
+	conn, err := dial(ctx)
+	if err != nil {
+		return err
+	}
+	return client.StreamInteractiveSession(ctx, c.config, conn, dial, opts)
+}
+
+// buildNodeProxyExecURL 构建 nodes/{node}/proxy/exec WebSocket URL（使用 Kubelet 的参数名）
+func (c *k8sClient) buildNodeProxyExecURL(node string, opts *types.ExecOptions) string {
+	params := url.Values{}
+	if opts.Stdin {
+		params.Add("input", "1")
+	}
+	if opts.Stdout {
+		params.Add("output", "1")
+	}
+	if opts.Stderr {
+		params.Add("error", "1")
+	}
+	if opts.TTY {
+		params.Add("tty", "1")
+	}
+	for _, cmd := range opts.Command {
+		params.Add("command", cmd)
+	}
+
+	return fmt.Sprintf("%s/api/v1/nodes/%s/proxy/exec/%s/%s/%s?%s",
+		c.wsBase(), url.PathEscape(node), url.PathEscape(opts.Namespace),
+		url.PathEscape(opts.Pod), url.PathEscape(opts.Container), params.Encode())
+}
+
+// wsBase 返回 API Server 的 WebSocket 基础地址
+func (c *k8sClient) wsBase() string {
+	base := c.apiServer
+	switch {
+	case strings.HasPrefix(base, "https://"):
+		base = "wss://" + strings.TrimPrefix(base, "https://")
+	case strings.HasPrefix(base, "http://"):
+		base = "ws://" + strings.TrimPrefix(base, "http://")
+	}
+	return base
+}
+
+// buildStreamURL 构建 pods/exec 或 pods/attach WebSocket URL
+func (c *k8sClient) buildStreamURL(subresource string, opts *types.ExecOptions) string {
+	base := c.wsBase()
+
+	// 注意: API Server 使用 stdin/stdout/stderr，与 Kubelet 的 input/output/error 不同
+	params := url.Values{}
+	if opts.Container != "" {
+		params.Add("container", opts.Container)
+	}
+	if opts.Stdin {
+		params.Add("stdin", "true")
+	}
+	if opts.Stdout {
+		params.Add("stdout", "true")
+	}
+	if opts.Stderr {
+		params.Add("stderr", "true")
+	}
+	if opts.TTY {
+		params.Add("tty", "true")
+	}
+	for _, cmd := range opts.Command {
+		params.Add("command", cmd)
+	}
+
+	return fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s/%s?%s",
+		base, url.PathEscape(opts.Namespace), url.PathEscape(opts.Pod), subresource, params.Encode())
+}
+
+// wrapExecError 为常见的 API Server 拒绝原因补充说明
+func wrapExecError(err error) error {
+	var dialErr *client.ExecDialError
+	if errors.As(err, &dialErr) {
+		switch dialErr.StatusCode {
+		case http.StatusUnauthorized:
+			return i18n.Errorf("API Server 认证失败：Token 无效: %w", err)
+		case http.StatusForbidden:
+			return i18n.Errorf("当前 Token 无 pods/exec 或 pods/attach 权限: %w", err)
+		}
+	}
+	return err
+}
+
+// wrapNodeProxyError 为 nodes/proxy 拒绝原因补充说明
+func wrapNodeProxyError(err error) error {
+	var dialErr *client.ExecDialError
+	if errors.As(err, &dialErr) {
+		switch dialErr.StatusCode {
+		case http.StatusUnauthorized:
+			return i18n.Errorf("API Server 认证失败：Token 无效: %w", err)
+		case http.StatusForbidden:
+			return i18n.Errorf("当前 Token 无 nodes/proxy 权限: %w", err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// rbacPrefix RBAC API 路径前缀
+const rbacPrefix = "/apis/rbac.authorization.k8s.io/v1"
+
+// rbacPath 构建 RBAC 资源路径（namespace 为空时列出所有命名空间）
+func rbacPath(resource, namespace string) string {
+	if namespace != "" {
+		return rbacPrefix + "/namespaces/" + url.PathEscape(namespace) + "/" + resource
+	}
+	return rbacPrefix + "/" + resource
+}
+
+// ListClusterRoles 列出 ClusterRole
+func (c *k8sClient) ListClusterRoles(ctx context.Context) ([]types.RoleInfo, error) {
+	return c.listRoles(ctx, "ClusterRole", rbacPath("clusterroles", ""))
+}
+
+// ListRoles 列出 Role（namespace 为空时列出所有命名空间）
+func (c *k8sClient) ListRoles(ctx context.Context, namespace string) ([]types.RoleInfo, error) {
+	return c.listRoles(ctx, "Role", rbacPath("roles", namespace))
+}
+
+// ListClusterRoleBindings 列出 ClusterRoleBinding
+func (c *k8sClient) ListClusterRoleBindings(ctx context.Context) ([]types.RoleBindingInfo, error) {
+	return c.listBindings(ctx, "ClusterRoleBinding", rbacPath("clusterrolebindings", ""))
+}
+
+// ListRoleBindings 列出 RoleBinding（namespace 为空时列出所有命名空间）
+func (c *k8sClient) ListRoleBindings(ctx context.Context, namespace string) ([]types.RoleBindingInfo, error) {
+	return c.listBindings(ctx, "RoleBinding", rbacPath("rolebindings", namespace))
+}
+
+func (c *k8sClient) listRoles(ctx context.Context, kind, path string) ([]types.RoleInfo, error) {
+	body, err := c.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.RoleListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	var roles []types.RoleInfo
+	for _, item := range response.Items {
+		role := types.RoleInfo{
+			Kind:      kind,
+			Name:      item.Metadata.Name,
+			Namespace: item.Metadata.Namespace,
+			Labels:    item.Metadata.Labels,
+			Rules:     item.Rules,
+		}
+		if item.AggregationRule != nil {
+			for _, selector := range item.AggregationRule.ClusterRoleSelectors {
+				role.AggregationSelectors = append(role.AggregationSelectors, selector.MatchLabels)
+			}
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func (c *k8sClient) listBindings(ctx context.Context, kind, path string) ([]types.RoleBindingInfo, error) {
+	body, err := c.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.RoleBindingListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	var bindings []types.RoleBindingInfo
+	for _, item := range response.Items {
+		bindings = append(bindings, types.RoleBindingInfo{
+			Kind:      kind,
+			Name:      item.Metadata.Name,
+			Namespace: item.Metadata.Namespace,
+			RoleRef:   item.RoleRef,
+			Subjects:  item.Subjects,
+		})
+	}
+	return bindings, nil
+}
@@ -0,0 +1,89 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// ErrNotFound API Server 返回 404
+var ErrNotFound = i18n.New("资源不存在")
+
+// resourcePlurals Kind 到资源名（复数）的映射，未列出的使用小写 Kind + s
+var resourcePlurals = map[string]string{
+	"NetworkPolicy":     "networkpolicies",
+	"PodSecurityPolicy": "podsecuritypolicies",
+	"Ingress":           "ingresses",
+	"StorageClass":      "storageclasses",
+}
+
+// clusterScopedKinds 集群级资源
+var clusterScopedKinds = map[string]bool{
+	"Namespace":          true,
+	"Node":               true,
+	"PersistentVolume":   true,
+	"ClusterRole":        true,
+	"ClusterRoleBinding": true,
+	"StorageClass":       true,
+	"PodSecurityPolicy":  true,
+}
+
+// ResourceName 返回 Kind 对应的资源名（复数小写）
+func ResourceName(kind string) string {
+	if plural, ok := resourcePlurals[kind]; ok {
+		return plural
+	}
+	return strings.ToLower(kind) + "s"
+}
+
+// IsClusterScoped 返回 Kind 是否为集群级资源
+func IsClusterScoped(kind string) bool {
+	return clusterScopedKinds[kind]
+}
+
+// APIGroup 返回 apiVersion 中的 API 组（核心组为空）
+func APIGroup(apiVersion string) string {
+	if group, _, ok := strings.Cut(apiVersion, "/"); ok {
+		return group
+	}
+	return ""
+}
+
+// PermissionFor 构造对资源执行 verb 的权限检查请求
+func PermissionFor(ref types.ResourceRef, verb string) PermissionRequest {
+	return PermissionRequest{
+		Resource:  ResourceName(ref.Kind),
+		Verb:      verb,
+		Group:     APIGroup(ref.APIVersion),
+		Namespace: ref.Namespace,
+	}
+}
+
+// collectionPath 构建资源集合路径
+func collectionPath(ref types.ResourceRef) string {
+	prefix := "/apis/" + ref.APIVersion
+	if APIGroup(ref.APIVersion) == "" {
+		prefix = "/api/" + ref.APIVersion
+	}
+	if ref.Namespace != "" && !IsClusterScoped(ref.Kind) {
+		prefix += "/namespaces/" + url.PathEscape(ref.Namespace)
+	}
+	return prefix + "/" + ResourceName(ref.Kind)
+}
+
+// Create 创建资源，body 为 JSON 格式的对象
+func (c *k8sClient) Create(ctx context.Context, ref types.ResourceRef, body []byte) error {
+	_, err := c.do(ctx, http.MethodPost, collectionPath(ref), "application/json", body)
+	return err
+}
+
+// Delete 删除资源（后台级联删除其下属对象）
+func (c *k8sClient) Delete(ctx context.Context, ref types.ResourceRef) error {
+	path := collectionPath(ref) + "/" + url.PathEscape(ref.Name) + "?propagationPolicy=Background"
+	_, err := c.do(ctx, http.MethodDelete, path, "", nil)
+	return err
+}
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// corePath 构建 core API 资源路径（namespace 为空时列出所有命名空间）
+func corePath(resource, namespace string) string {
+	if namespace != "" {
+		return "/api/v1/namespaces/" + url.PathEscape(namespace) + "/" + resource
+	}
+	return "/api/v1/" + resource
+}
+
+// ListServices 列出 Service（namespace 为空时列出所有命名空间）
+func (c *k8sClient) ListServices(ctx context.Context, namespace string) ([]types.ServiceInfo, error) {
+	body, err := c.get(ctx, corePath("services", namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.ServiceListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	var services []types.ServiceInfo
+	for _, item := range response.Items {
+		svc := types.ServiceInfo{
+			Namespace:    item.Metadata.Namespace,
+			Name:         item.Metadata.Name,
+			Type:         item.Spec.Type,
+			ClusterIP:    item.Spec.ClusterIP,
+			ExternalIPs:  item.Spec.ExternalIPs,
+			ExternalName: item.Spec.ExternalName,
+			Selector:     item.Spec.Selector,
+			Source:       "apiserver",
+		}
+		for _, ingress := range item.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				svc.ExternalIPs = append(svc.ExternalIPs, ingress.IP)
+			} else if ingress.Hostname != "" {
+				svc.ExternalIPs = append(svc.ExternalIPs, ingress.Hostname)
+			}
+		}
+		for _, port := range item.Spec.Ports {
+			svc.Ports = append(svc.Ports, types.ServicePort{
+				Name:       port.Name,
+				Protocol:   port.Protocol,
+				Port:       port.Port,
+				TargetPort: parseTargetPort(port.TargetPort, port.Port),
+				NodePort:   port.NodePort,
+			})
+		}
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
+// ListEndpoints 列出就绪的 Service 后端，返回 namespace/name 到后端列表的映射
+func (c *k8sClient) ListEndpoints(ctx context.Context, namespace string) (map[string][]types.ServiceEndpoint, error) {
+	body, err := c.get(ctx, corePath("endpoints", namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	var response types.EndpointsListResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	endpoints := make(map[string][]types.ServiceEndpoint)
+	for _, item := range response.Items {
+		key := item.Metadata.Namespace + "/" + item.Metadata.Name
+		for _, subset := range item.Subsets {
+			for _, addr := range subset.Addresses {
+				ep := types.ServiceEndpoint{IP: addr.IP}
+				if addr.TargetRef != nil && addr.TargetRef.Kind == "Pod" {
+					ep.Pod = addr.TargetRef.Name
+				}
+				endpoints[key] = append(endpoints[key], ep)
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// parseTargetPort 解析 targetPort（整数或端口名），未设置时与 port 相同
+func parseTargetPort(raw json.RawMessage, port int) string {
+	s := strings.Trim(string(raw), `"`)
+	if s == "" || s == "null" {
+		return strconv.Itoa(port)
+	}
+	return s
+}
@@ -2,14 +2,16 @@ package kubelet
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
+	"regexp"
 
 	"github.com/gorilla/websocket"
 	"kctl/internal/client"
+	"kctl/internal/i18n"
 	"kctl/pkg/types"
 )
 
@@ -23,13 +25,23 @@ type Client interface {
 	// 命令执行
 	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
 	ExecInteractive(ctx context.Context, opts *types.ExecOptions) error
+	ExecSPDY(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
+	ExecSPDYInteractive(ctx context.Context, opts *types.ExecOptions) error
 	Run(ctx context.Context, opts *types.RunOptions) (*types.RunResult, error)
+	ExecStream(ctx context.Context, opts *types.ExecOptions) (*client.ExecStream, error)
 
 	// 端口转发
 	PortForward(ctx context.Context, opts *types.PortForwardOptions, stopChan <-chan struct{}) error
 
 	// 健康检查
 	ValidatePort(ctx context.Context) (*types.ProbeResult, error)
+
+	// 节点信息
+	GetSpec(ctx context.Context) (*types.KubeletSpec, error)
+	GetVersion(ctx context.Context) (string, error)
+	GetServingCertificates(ctx context.Context) ([]*x509.Certificate, error)
+	GetConfigz(ctx context.Context) (*types.KubeletConfiguration, error)
+	GetStatsSummary(ctx context.Context) (*types.KubeletStatsSummary, error)
 }
 
 // kubeletClient Kubelet 客户端实现
@@ -50,12 +62,12 @@ func NewClient(ip string, port int, token string, cfg *client.Config) (Client, e
 
 	httpClient, err := client.NewHTTPClient(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("创建 HTTP 客户端失败: %w", err)
+		return nil, i18n.Errorf("创建 HTTP 客户端失败: %w", err)
 	}
 
 	wsDialer, err := client.NewWebSocketDialer(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("创建 WebSocket 拨号器失败: %w", err)
+		return nil, i18n.Errorf("创建 WebSocket 拨号器失败: %w", err)
 	}
 
 	return &kubeletClient{
@@ -73,9 +85,17 @@ func (c *kubeletClient) baseURL() string {
 	return fmt.Sprintf("https://%s:%d", c.ip, c.port)
 }
 
-// authHeader 返回认证头
+// authHeader 返回认证头，未设置 Token 时为空（使用客户端证书认证）
 func (c *kubeletClient) authHeader() string {
-	return fmt.Sprintf("Bearer %s", c.token)
+	return client.BearerAuth(c.token)
+}
+
+// headers 返回 exec WebSocket 连接使用的请求头：认证头及自定义 User-Agent / 附加请求头
+func (c *kubeletClient) headers() http.Header {
+	h := http.Header{}
+	client.SetAuthHeader(h, c.authHeader())
+	c.config.SetRequestHeaders(h)
+	return h
 }
 
 // GetPods 获取 Pod 列表
@@ -87,7 +107,7 @@ func (c *kubeletClient) GetPods(ctx context.Context) (*types.KubeletPodsResponse
 
 	var response types.KubeletPodsResponse
 	if err := json.Unmarshal(raw, &response); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %w", err)
+		return nil, i18n.Errorf("解析响应失败: %w", err)
 	}
 
 	return &response, nil
@@ -95,33 +115,7 @@ func (c *kubeletClient) GetPods(ctx context.Context) (*types.KubeletPodsResponse
 
 // GetPodsRaw 获取原始 Pod 数据
 func (c *kubeletClient) GetPodsRaw(ctx context.Context) ([]byte, error) {
-	url := c.baseURL() + "/pods"
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
-	}
-
-	req.Header.Set("Authorization", c.authHeader())
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("请求 Kubelet API 失败: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, fmt.Errorf("认证失败：Token 无效或无权限访问 Kubelet API")
-	}
-	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("权限被拒绝：Token 无权访问 /pods 端点")
-	}
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("kubelet API 返回错误 (HTTP %d): %s", resp.StatusCode, string(body))
-	}
-
-	return io.ReadAll(resp.Body)
+	return c.get(ctx, "/pods")
 }
 
 // GetPodsWithContainers 获取 Pod 及容器信息
@@ -130,137 +124,7 @@ func (c *kubeletClient) GetPodsWithContainers(ctx context.Context) ([]types.PodC
 	if err != nil {
 		return nil, err
 	}
-
-	var result []types.PodContainerInfo
-	for _, item := range response.Items {
-		info := types.PodContainerInfo{
-			Namespace:      item.Metadata.Namespace,
-			PodName:        item.Metadata.Name,
-			UID:            item.Metadata.UID,
-			Status:         item.Status.Phase,
-			PodIP:          item.Status.PodIP,
-			HostIP:         item.Status.HostIP,
-			NodeName:       item.Spec.NodeName,
-			ServiceAccount: item.Spec.ServiceAccount,
-			CreatedAt:      item.Metadata.CreationTimestamp,
-		}
-
-		// 构建 Volume 映射表（用于查找挂载源）
-		volumeMap := make(map[string]types.VolumeDetail)
-		for _, vol := range item.Spec.Volumes {
-			vd := types.VolumeDetail{Name: vol.Name}
-			if vol.HostPath != nil {
-				vd.Type = "hostPath"
-				vd.Source = vol.HostPath.Path
-				info.SecurityFlags.HasHostPath = true
-			} else if vol.Secret != nil {
-				vd.Type = "secret"
-				vd.Source = vol.Secret.SecretName
-				info.SecurityFlags.HasSecretMount = true
-			} else {
-				vd.Type = "other"
-			}
-			volumeMap[vol.Name] = vd
-			info.Volumes = append(info.Volumes, vd)
-		}
-
-		// 构建容器状态映射
-		containerStatusMap := make(map[string]struct {
-			ContainerID string
-			Ready       bool
-			State       string
-			StartedAt   string
-		})
-		for _, cs := range item.Status.ContainerStatuses {
-			status := struct {
-				ContainerID string
-				Ready       bool
-				State       string
-				StartedAt   string
-			}{Ready: cs.Ready}
-
-			// 解析容器 ID（格式: containerd://abc123... 或 docker://abc123...）
-			if cs.ContainerID != "" {
-				containerID := cs.ContainerID
-				// 移除运行时前缀
-				if idx := strings.Index(containerID, "://"); idx != -1 {
-					containerID = containerID[idx+3:]
-				}
-				// 取前 12 个字符作为短 ID
-				if len(containerID) >= 12 {
-					status.ContainerID = containerID[:12]
-				} else {
-					status.ContainerID = containerID
-				}
-			}
-
-			if cs.State.Running != nil {
-				status.State = "Running"
-				status.StartedAt = cs.State.Running.StartedAt
-			} else if cs.State.Waiting != nil {
-				status.State = "Waiting: " + cs.State.Waiting.Reason
-			} else if cs.State.Terminated != nil {
-				status.State = "Terminated: " + cs.State.Terminated.Reason
-			}
-			containerStatusMap[cs.Name] = status
-		}
-
-		// 解析容器信息
-		for _, container := range item.Spec.Containers {
-			cd := types.ContainerDetail{
-				Name:  container.Name,
-				Image: container.Image,
-			}
-
-			// 获取容器状态
-			if cs, ok := containerStatusMap[container.Name]; ok {
-				cd.ContainerID = cs.ContainerID
-				cd.Ready = cs.Ready
-				cd.State = cs.State
-				cd.StartedAt = cs.StartedAt
-			}
-
-			// 检查安全上下文
-			if container.SecurityContext != nil {
-				if container.SecurityContext.Privileged != nil && *container.SecurityContext.Privileged {
-					cd.Privileged = true
-					info.SecurityFlags.Privileged = true
-				}
-				if container.SecurityContext.AllowPrivilegeEscalation != nil && *container.SecurityContext.AllowPrivilegeEscalation {
-					cd.AllowPE = true
-					info.SecurityFlags.AllowPrivilegeEscalation = true
-				}
-			}
-
-			// 解析 Volume 挂载
-			for _, vm := range container.VolumeMounts {
-				vmd := types.VolumeMountDetail{
-					Name:      vm.Name,
-					MountPath: vm.MountPath,
-					ReadOnly:  vm.ReadOnly,
-				}
-
-				// 查找对应的 Volume 定义
-				if vd, ok := volumeMap[vm.Name]; ok {
-					vmd.Type = vd.Type
-					vmd.Source = vd.Source
-				}
-
-				cd.VolumeMounts = append(cd.VolumeMounts, vmd)
-
-				// 检查是否挂载了 SA Token 路径
-				if strings.HasPrefix(vm.MountPath, "/var/run/secrets/kubernetes.io/serviceaccount") {
-					info.SecurityFlags.HasSATokenMount = true
-				}
-			}
-
-			info.Containers = append(info.Containers, cd)
-		}
-
-		result = append(result, info)
-	}
-
-	return result, nil
+	return ConvertPods(response), nil
 }
 
 // ValidatePort 验证 Kubelet 端口
@@ -274,7 +138,7 @@ func (c *kubeletClient) ValidatePort(ctx context.Context) (*types.ProbeResult, e
 	healthzURL := c.baseURL() + "/healthz"
 	req, err := http.NewRequestWithContext(ctx, "GET", healthzURL, nil)
 	if err != nil {
-		result.Error = fmt.Errorf("创建请求失败: %w", err)
+		result.Error = i18n.Errorf("创建请求失败: %w", err)
 		return result, nil
 	}
 
@@ -299,11 +163,11 @@ func (c *kubeletClient) ValidatePort(ctx context.Context) (*types.ProbeResult, e
 	podsURL := c.baseURL() + "/pods"
 	req, err = http.NewRequestWithContext(ctx, "GET", podsURL, nil)
 	if err != nil {
-		result.Error = fmt.Errorf("创建请求失败: %w", err)
+		result.Error = i18n.Errorf("创建请求失败: %w", err)
 		return result, nil
 	}
 
-	req.Header.Set("Authorization", c.authHeader())
+	client.SetAuthHeader(req.Header, c.authHeader())
 
 	resp, err = c.httpClient.Do(req)
 	if err != nil {
@@ -322,6 +186,130 @@ func (c *kubeletClient) ValidatePort(ctx context.Context) (*types.ProbeResult, e
 	}
 
 	result.IsKubelet = false
-	result.Error = fmt.Errorf("端口响应不像是 Kubelet")
+	result.Error = i18n.Errorf("端口响应不像是 Kubelet")
 	return result, nil
 }
+
+// get 发送带认证的 GET 请求并返回响应体
+func (c *kubeletClient) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL()+path, nil)
+	if err != nil {
+		return nil, i18n.Errorf("创建请求失败: %w", err)
+	}
+
+	client.SetAuthHeader(req.Header, c.authHeader())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, i18n.Errorf("请求 Kubelet API 失败: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, i18n.Errorf("读取响应失败: %w", err)
+	}
+	c.dumpRaw(path, resp.StatusCode, body)
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, i18n.Errorf("认证失败：Token 无效或无权限访问 Kubelet API")
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return nil, i18n.Errorf("权限被拒绝：Token 无权访问 %s 端点", path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, i18n.Errorf("kubelet API 返回错误 (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
+
+// dumpRaw 开启 --raw-dump 时按原样保存响应（包括错误响应）
+// 目录在设置时已检查可写，保存失败不影响请求本身
+func (c *kubeletClient) dumpRaw(path string, status int, body []byte) {
+	if c.config.RawDumpDir == "" {
+		return
+	}
+	_, _ = client.DumpRaw(c.config.RawDumpDir, fmt.Sprintf("%s:%d", c.ip, c.port), path, status, body)
+}
+
+// GetSpec 获取节点机器信息（/spec/）
+func (c *kubeletClient) GetSpec(ctx context.Context) (*types.KubeletSpec, error) {
+	body, err := c.get(ctx, "/spec/")
+	if err != nil {
+		return nil, err
+	}
+
+	var spec types.KubeletSpec
+	if err := json.Unmarshal(body, &spec); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	return &spec, nil
+}
+
+// GetConfigz 获取 Kubelet 运行配置（/configz）
+func (c *kubeletClient) GetConfigz(ctx context.Context) (*types.KubeletConfiguration, error) {
+	body, err := c.get(ctx, "/configz")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp types.KubeletConfigzResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	return &resp.KubeletConfig, nil
+}
+
+// GetStatsSummary 获取节点和 Pod 的资源使用（/stats/summary）
+func (c *kubeletClient) GetStatsSummary(ctx context.Context) (*types.KubeletStatsSummary, error) {
+	body, err := c.get(ctx, "/stats/summary")
+	if err != nil {
+		return nil, err
+	}
+
+	var summary types.KubeletStatsSummary
+	if err := json.Unmarshal(body, &summary); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+
+	return &summary, nil
+}
+
+// buildInfoRe 匹配 /metrics 中 kubernetes_build_info 的 git_version 标签
+var buildInfoRe = regexp.MustCompile(`kubernetes_build_info\{[^}]*git_version="([^"]+)"`)
+
+// GetVersion 从 /metrics 的 kubernetes_build_info 指标获取 Kubelet 版本
+func (c *kubeletClient) GetVersion(ctx context.Context) (string, error) {
+	body, err := c.get(ctx, "/metrics")
+	if err != nil {
+		return "", err
+	}
+
+	m := buildInfoRe.FindSubmatch(body)
+	if m == nil {
+		return "", i18n.Errorf("/metrics 中未找到 kubernetes_build_info")
+	}
+	return string(m[1]), nil
+}
+
+// GetServingCertificates 获取 Kubelet 服务端证书链（通过 /healthz 的 TLS 握手）
+func (c *kubeletClient) GetServingCertificates(ctx context.Context) ([]*x509.Certificate, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL()+"/healthz", nil)
+	if err != nil {
+		return nil, i18n.Errorf("创建请求失败: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, i18n.Errorf("请求 Kubelet API 失败: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.TLS == nil || len(resp.TLS.PeerCertificates) == 0 {
+		return nil, i18n.Errorf("未获取到 TLS 证书")
+	}
+	return resp.TLS.PeerCertificates, nil
+}
@@ -2,11 +2,293 @@ package kubelet
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
+	"kctl/config"
+	"kctl/internal/i18n"
 	"kctl/pkg/types"
 )
 
+// ParsePods 解析 /pods 响应（或 kubectl get pods -o json 的输出）为 Pod 及容器信息
+// 用于离线分析预先获取的 JSON
+func ParsePods(raw []byte) ([]types.PodContainerInfo, error) {
+	var response types.KubeletPodsResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, i18n.Errorf("解析 Pod 列表失败: %w", err)
+	}
+	return ConvertPods(&response), nil
+}
+
+// ConvertPods 将 /pods 响应转换为 Pod 及容器信息，并标记安全风险
+func ConvertPods(response *types.KubeletPodsResponse) []types.PodContainerInfo {
+	var result []types.PodContainerInfo
+	for _, item := range response.Items {
+		info := types.PodContainerInfo{
+			Namespace:      item.Metadata.Namespace,
+			PodName:        item.Metadata.Name,
+			UID:            item.Metadata.UID,
+			Status:         item.Status.Phase,
+			PodIP:          item.Status.PodIP,
+			HostIP:         item.Status.HostIP,
+			NodeName:       item.Spec.NodeName,
+			ServiceAccount: item.Spec.ServiceAccount,
+			CreatedAt:      item.Metadata.CreationTimestamp,
+			Labels:         item.Metadata.Labels,
+		}
+		info.SecurityFlags.HostPID = item.Spec.HostPID
+		info.SecurityFlags.HostNetwork = item.Spec.HostNetwork
+		info.SecurityFlags.HostIPC = item.Spec.HostIPC
+		info.PodSecurity = item.Spec.SecurityContext
+		info.Tolerations = item.Spec.Tolerations
+
+		// 构建 Volume 映射表（用于查找挂载源）
+		volumeMap := make(map[string]types.VolumeDetail)
+		for _, vol := range item.Spec.Volumes {
+			vd := types.VolumeDetail{Name: vol.Name}
+			if vol.HostPath != nil {
+				vd.Type = "hostPath"
+				vd.Source = vol.HostPath.Path
+				info.SecurityFlags.HasHostPath = true
+			} else if vol.Secret != nil {
+				vd.Type = "secret"
+				vd.Source = vol.Secret.SecretName
+				info.SecurityFlags.HasSecretMount = true
+			} else if vol.ConfigMap != nil {
+				vd.Type = "configMap"
+				vd.Source = vol.ConfigMap.Name
+			} else if vol.EmptyDir != nil {
+				vd.Type = "emptyDir"
+				vd.Source = vol.EmptyDir.Medium
+			} else if vol.Projected != nil {
+				vd.Type = "projected"
+				vd.Source = projectedSources(vol.Projected)
+			} else if vol.PersistentVolumeClaim != nil {
+				vd.Type = "persistentVolumeClaim"
+				vd.Source = vol.PersistentVolumeClaim.ClaimName
+			} else if vol.DownwardAPI != nil {
+				vd.Type = "downwardAPI"
+			} else {
+				vd.Type = "other"
+			}
+			volumeMap[vol.Name] = vd
+			info.Volumes = append(info.Volumes, vd)
+		}
+
+		// 构建容器状态映射（容器名在 Pod 内唯一，三类容器共用）
+		containerStatusMap := make(map[string]containerStatus)
+		for _, statuses := range [][]types.KubeletContainerStatus{
+			item.Status.ContainerStatuses,
+			item.Status.InitContainerStatuses,
+			item.Status.EphemeralContainerStatuses,
+		} {
+			for _, cs := range statuses {
+				containerStatusMap[cs.Name] = parseContainerStatus(cs)
+			}
+		}
+
+		// 解析容器信息，init 和临时容器单独存放，但同样计入安全标识
+		convert := func(container types.KubeletContainer) types.ContainerDetail {
+			cd := convertContainer(container, containerStatusMap, volumeMap,
+				item.Metadata.Annotations[appArmorAnnotationPrefix+container.Name])
+			if cd.Privileged {
+				info.SecurityFlags.Privileged = true
+			}
+			if cd.AllowPE {
+				info.SecurityFlags.AllowPrivilegeEscalation = true
+			}
+			markContainerFlags(&info.SecurityFlags, cd, item.Spec.SecurityContext)
+			for _, vm := range cd.VolumeMounts {
+				// 检查是否挂载了 SA Token 路径
+				if strings.HasPrefix(vm.MountPath, "/var/run/secrets/kubernetes.io/serviceaccount") {
+					info.SecurityFlags.HasSATokenMount = true
+				}
+			}
+			return cd
+		}
+		for _, container := range item.Spec.Containers {
+			info.Containers = append(info.Containers, convert(container))
+		}
+		for _, container := range item.Spec.InitContainers {
+			cd := convert(container)
+			cd.Sidecar = container.RestartPolicy == "Always"
+			info.InitContainers = append(info.InitContainers, cd)
+		}
+		for _, container := range item.Spec.EphemeralContainers {
+			cd := convert(container)
+			cd.Target = container.TargetContainerName
+			info.EphemeralContainers = append(info.EphemeralContainers, cd)
+		}
+
+		result = append(result, info)
+	}
+
+	return result
+}
+
+// containerStatus 容器运行状态
+type containerStatus struct {
+	ContainerID string
+	Ready       bool
+	State       string
+	StartedAt   string
+}
+
+// parseContainerStatus 解析容器状态
+func parseContainerStatus(cs types.KubeletContainerStatus) containerStatus {
+	status := containerStatus{Ready: cs.Ready}
+
+	// 解析容器 ID（格式: containerd://abc123... 或 docker://abc123...）
+	if cs.ContainerID != "" {
+		containerID := cs.ContainerID
+		// 移除运行时前缀
+		if idx := strings.Index(containerID, "://"); idx != -1 {
+			containerID = containerID[idx+3:]
+		}
+		// 取前 12 个字符作为短 ID
+		if len(containerID) >= 12 {
+			status.ContainerID = containerID[:12]
+		} else {
+			status.ContainerID = containerID
+		}
+	}
+
+	if cs.State.Running != nil {
+		status.State = "Running"
+		status.StartedAt = cs.State.Running.StartedAt
+	} else if cs.State.Waiting != nil {
+		status.State = "Waiting: " + cs.State.Waiting.Reason
+	} else if cs.State.Terminated != nil {
+		status.State = "Terminated: " + cs.State.Terminated.Reason
+	}
+	return status
+}
+
+// convertContainer 将容器规格及状态转换为容器详情，appArmor 为旧版 AppArmor 注解值
+func convertContainer(container types.KubeletContainer, statuses map[string]containerStatus,
+	volumes map[string]types.VolumeDetail, appArmor string) types.ContainerDetail {
+	cd := types.ContainerDetail{
+		Name:  container.Name,
+		Image: container.Image,
+	}
+	for _, env := range container.Env {
+		if env.Value == "" {
+			continue
+		}
+		if cd.Env == nil {
+			cd.Env = make(map[string]string)
+		}
+		cd.Env[env.Name] = env.Value
+	}
+
+	// 获取容器状态
+	if cs, ok := statuses[container.Name]; ok {
+		cd.ContainerID = cs.ContainerID
+		cd.Ready = cs.Ready
+		cd.State = cs.State
+		cd.StartedAt = cs.StartedAt
+	}
+
+	// 检查安全上下文
+	if sc := container.SecurityContext; sc != nil {
+		cd.Privileged = sc.Privileged != nil && *sc.Privileged
+		cd.AllowPE = sc.AllowPrivilegeEscalation != nil && *sc.AllowPrivilegeEscalation
+		cd.RunAsUser = sc.RunAsUser
+		cd.RunAsGroup = sc.RunAsGroup
+		if sc.RunAsRoot {
+			runAsNonRoot := true
+			cd.RunAsNonRoot = &runAsNonRoot
+		}
+		cd.ReadOnlyRoot = sc.ReadOnlyRootFilesystem != nil && *sc.ReadOnlyRootFilesystem
+		if sc.Capabilities != nil {
+			cd.CapAdd = sc.Capabilities.Add
+			cd.CapDrop = sc.Capabilities.Drop
+		}
+		cd.Seccomp = sc.SeccompProfile.String()
+		cd.AppArmor = sc.AppArmorProfile.String()
+	}
+	if cd.AppArmor == "" {
+		cd.AppArmor = appArmorAnnotation(appArmor)
+	}
+
+	// 解析 Volume 挂载
+	for _, vm := range container.VolumeMounts {
+		vmd := types.VolumeMountDetail{
+			Name:      vm.Name,
+			MountPath: vm.MountPath,
+			ReadOnly:  vm.ReadOnly,
+		}
+		// 查找对应的 Volume 定义
+		if vd, ok := volumes[vm.Name]; ok {
+			vmd.Type = vd.Type
+			vmd.Source = vd.Source
+		}
+		cd.VolumeMounts = append(cd.VolumeMounts, vmd)
+	}
+	return cd
+}
+
+// markContainerFlags 根据容器（及继承的 Pod 级）安全上下文标记高危 capabilities、root 用户和缺失的 seccomp
+func markContainerFlags(flags *types.SecurityFlags, cd types.ContainerDetail, podSC *types.PodSecurityContext) {
+	for _, capability := range cd.CapAdd {
+		if config.IsDangerousCapability(capability) {
+			flags.AddCapability(strings.TrimPrefix(strings.ToUpper(capability), "CAP_"))
+		}
+	}
+
+	runAsUser, seccomp := cd.RunAsUser, cd.Seccomp
+	if podSC != nil {
+		if runAsUser == nil {
+			runAsUser = podSC.RunAsUser
+		}
+		if seccomp == "" {
+			seccomp = podSC.SeccompProfile.String()
+		}
+	}
+	if runAsUser != nil && *runAsUser == 0 {
+		flags.RunAsRoot = true
+	}
+	// 未配置 seccomp 时，除非 Kubelet 启用了 SeccompDefault，容器以 Unconfined 运行
+	if seccomp == "" || strings.EqualFold(seccomp, "Unconfined") {
+		flags.NoSeccomp = true
+	}
+}
+
+// appArmorAnnotationPrefix 旧版 AppArmor 注解前缀（Kubernetes 1.30 之前），后接容器名
+const appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+
+// appArmorAnnotation 将旧版 AppArmor 注解值转换为 appArmorProfile 的格式
+func appArmorAnnotation(value string) string {
+	switch {
+	case value == "":
+		return ""
+	case value == "runtime/default":
+		return "RuntimeDefault"
+	case value == "unconfined":
+		return "Unconfined"
+	case strings.HasPrefix(value, "localhost/"):
+		return "Localhost/" + strings.TrimPrefix(value, "localhost/")
+	default:
+		return value
+	}
+}
+
+// projectedSources 汇总 projected 卷的来源，如 serviceAccountToken,configMap:kube-root-ca.crt
+func projectedSources(vol *types.ProjectedVol) string {
+	var sources []string
+	for _, src := range vol.Sources {
+		switch {
+		case src.ServiceAccountToken != nil:
+			sources = append(sources, "serviceAccountToken")
+		case src.Secret != nil:
+			sources = append(sources, "secret:"+src.Secret.SecretName)
+		case src.ConfigMap != nil:
+			sources = append(sources, "configMap:"+src.ConfigMap.Name)
+		}
+	}
+	return strings.Join(sources, ",")
+}
+
 // ExtractPodRecords 从原始数据中提取有安全价值的信息
 func ExtractPodRecords(rawData []byte, kubeletIP string) ([]*types.PodRecord, error) {
 	var response types.KubeletPodsFullResponse
@@ -33,14 +315,18 @@ func ExtractPodRecords(rawData []byte, kubeletIP string) ([]*types.PodRecord, er
 		}
 
 		// 提取容器安全信息
-		containers := extractContainerInfo(item.Spec.Containers)
+		containers := extractContainerInfo(item.Spec.Containers, "")
+		containers = append(containers, extractContainerInfo(item.Spec.InitContainers, "init")...)
+		containers = append(containers, extractContainerInfo(item.Spec.EphemeralContainers, "ephemeral")...)
 		if len(containers) > 0 {
 			containersJSON, _ := json.Marshal(containers)
 			record.Containers = string(containersJSON)
 		}
 
 		// 提取敏感卷信息
-		volumes := extractSensitiveVolumes(item.Spec.Volumes, item.Spec.Containers)
+		allContainers := append(append(append([]types.ContainerSpec{}, item.Spec.Containers...),
+			item.Spec.InitContainers...), item.Spec.EphemeralContainers...)
+		volumes := extractSensitiveVolumes(item.Spec.Volumes, allContainers)
 		if len(volumes) > 0 {
 			volumesJSON, _ := json.Marshal(volumes)
 			record.Volumes = string(volumesJSON)
@@ -58,13 +344,14 @@ func ExtractPodRecords(rawData []byte, kubeletIP string) ([]*types.PodRecord, er
 	return records, nil
 }
 
-// extractContainerInfo 提取容器安全信息
-func extractContainerInfo(containers []types.ContainerSpec) []types.ContainerInfo {
+// extractContainerInfo 提取容器安全信息，kind 为空（普通容器）、init 或 ephemeral
+func extractContainerInfo(containers []types.ContainerSpec, kind string) []types.ContainerInfo {
 	var infos []types.ContainerInfo
 
 	for _, c := range containers {
 		info := types.ContainerInfo{
 			Name:  c.Name,
+			Kind:  kind,
 			Image: c.Image,
 		}
 
@@ -87,6 +374,10 @@ func extractContainerInfo(containers []types.ContainerSpec) []types.ContainerInf
 			if c.SecurityContext.ReadOnlyRootFilesystem != nil {
 				info.ReadOnlyRootFilesystem = *c.SecurityContext.ReadOnlyRootFilesystem
 			}
+			if c.SecurityContext.Capabilities != nil {
+				info.Capabilities = c.SecurityContext.Capabilities.Add
+			}
+			info.SeccompProfile = c.SecurityContext.SeccompProfile.String()
 		}
 
 		infos = append(infos, info)
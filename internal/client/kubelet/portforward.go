@@ -11,8 +11,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/moby/spdystream"
+	"kctl/internal/client"
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
 	"kctl/pkg/types"
 )
 
@@ -46,14 +50,14 @@ func (c *kubeletClient) PortForward(ctx context.Context, opts *types.PortForward
 	}
 
 	// 1. 建立 SPDY 连接
-	if err := pf.dial(); err != nil {
-		return fmt.Errorf("建立 SPDY 连接失败: %w", err)
+	if err := pf.dial(ctx); err != nil {
+		return i18n.Errorf("建立 SPDY 连接失败: %w", err)
 	}
 	defer pf.close()
 
 	// 2. 为每个端口启动本地监听
 	if err := pf.startListeners(); err != nil {
-		return fmt.Errorf("启动本地监听失败: %w", err)
+		return i18n.Errorf("启动本地监听失败: %w", err)
 	}
 
 	// 3. 等待停止信号或错误
@@ -68,61 +72,76 @@ func (c *kubeletClient) PortForward(ctx context.Context, opts *types.PortForward
 }
 
 // dial 建立 SPDY 连接
-func (pf *portForwarder) dial() error {
+func (pf *portForwarder) dial(ctx context.Context) error {
 	// 构建 URL
 	path := fmt.Sprintf("/portForward/%s/%s", pf.opts.Namespace, pf.opts.Pod)
 
 	// 建立 TLS 连接
-	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	tlsConfig, err := pf.client.config.TLSConfig()
+	if err != nil {
+		return err
+	}
 	addr := fmt.Sprintf("%s:%d", pf.client.ip, pf.client.port)
 
-	conn, err := tls.Dial("tcp", addr, tlsConfig)
+	start := time.Now()
+	rawConn, err := client.DialTCP(ctx, pf.client.config, addr)
 	if err != nil {
-		return fmt.Errorf("TLS 连接失败: %w", err)
+		logging.Request("spdy", http.MethodPost, "https://"+addr+path, 0, start, err)
+		return i18n.Errorf("TLS 连接失败: %w", err)
+	}
+	tlsConfig.ServerName = pf.client.ip
+	conn := tls.Client(rawConn, tlsConfig)
+	if err := conn.HandshakeContext(ctx); err != nil {
+		_ = rawConn.Close()
+		logging.Request("spdy", http.MethodPost, "https://"+addr+path, 0, start, err)
+		return i18n.Errorf("TLS 连接失败: %w", err)
 	}
 
 	// 发送 HTTP Upgrade 请求
 	req, err := http.NewRequest("POST", path, nil)
 	if err != nil {
 		conn.Close()
-		return fmt.Errorf("创建请求失败: %w", err)
+		return i18n.Errorf("创建请求失败: %w", err)
 	}
 
 	req.Header.Set("Connection", "Upgrade")
 	req.Header.Set("Upgrade", "SPDY/3.1")
 	req.Header.Set("X-Stream-Protocol-Version", PortForwardProtocolV1Name)
-	req.Header.Set("Authorization", pf.client.authHeader())
+	client.SetAuthHeader(req.Header, pf.client.authHeader())
+	pf.client.config.SetRequestHeaders(req.Header)
 	req.Host = addr
 
 	if err := req.Write(conn); err != nil {
 		conn.Close()
-		return fmt.Errorf("发送请求失败: %w", err)
+		return i18n.Errorf("发送请求失败: %w", err)
 	}
 
 	// 读取响应
 	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
 	if err != nil {
+		logging.Request("spdy", http.MethodPost, "https://"+addr+path, 0, start, err)
 		conn.Close()
-		return fmt.Errorf("读取响应失败: %w", err)
+		return i18n.Errorf("读取响应失败: %w", err)
 	}
 
+	logging.Request("spdy", http.MethodPost, "https://"+addr+path, resp.StatusCode, start, nil)
 	if resp.StatusCode != http.StatusSwitchingProtocols {
 		conn.Close()
-		return fmt.Errorf("升级协议失败: HTTP %d", resp.StatusCode)
+		return i18n.Errorf("升级协议失败: HTTP %d", resp.StatusCode)
 	}
 
 	// 验证协议
 	protocol := resp.Header.Get("X-Stream-Protocol-Version")
 	if protocol != PortForwardProtocolV1Name {
 		conn.Close()
-		return fmt.Errorf("不支持的协议: %s", protocol)
+		return i18n.Errorf("不支持的协议: %s", protocol)
 	}
 
 	// 创建 SPDY 连接
 	spdyConn, err := spdystream.NewConnection(conn, false)
 	if err != nil {
 		conn.Close()
-		return fmt.Errorf("创建 SPDY 连接失败: %w", err)
+		return i18n.Errorf("创建 SPDY 连接失败: %w", err)
 	}
 
 	go spdyConn.Serve(spdystream.NoOpStreamHandler)
@@ -139,7 +158,7 @@ func (pf *portForwarder) startListeners() error {
 		if err != nil {
 			// 关闭已创建的监听器
 			pf.closeListeners()
-			return fmt.Errorf("监听 %s 失败: %w", addr, err)
+			return i18n.Errorf("监听 %s 失败: %w", addr, err)
 		}
 		pf.listeners = append(pf.listeners, listener)
 
@@ -8,6 +8,8 @@ import (
 	"net/url"
 	"strings"
 
+	"kctl/internal/client"
+	"kctl/internal/i18n"
 	"kctl/pkg/types"
 )
 
@@ -23,40 +25,40 @@ func (c *kubeletClient) Run(ctx context.Context, opts *types.RunOptions) (*types
 	// 创建 POST 请求
 	req, err := http.NewRequestWithContext(ctx, "POST", runURL, strings.NewReader(data.Encode()))
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
+		return nil, i18n.Errorf("创建请求失败: %w", err)
 	}
 
 	// 设置请求头
-	req.Header.Set("Authorization", c.authHeader())
+	client.SetAuthHeader(req.Header, c.authHeader())
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	// 发送请求
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("请求 Kubelet /run API 失败: %w", err)
+		return nil, i18n.Errorf("请求 Kubelet /run API 失败: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	// 读取响应
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %w", err)
+		return nil, i18n.Errorf("读取响应失败: %w", err)
 	}
 
-	result := &types.RunResult{}
+	result := &types.RunResult{StatusCode: resp.StatusCode}
 
 	// 检查响应状态
 	switch resp.StatusCode {
 	case http.StatusOK:
 		result.Output = string(body)
 	case http.StatusUnauthorized:
-		result.Error = "认证失败：Token 无效或无权限访问 Kubelet API"
+		result.Error = i18n.T("认证失败：Token 无效或无权限访问 Kubelet API")
 	case http.StatusForbidden:
-		result.Error = "权限被拒绝：Token 无权访问 /run 端点"
+		result.Error = i18n.T("权限被拒绝：Token 无权访问 /run 端点")
 	case http.StatusNotFound:
-		result.Error = fmt.Sprintf("Pod 或容器不存在: %s/%s/%s", opts.Namespace, opts.Pod, opts.Container)
+		result.Error = i18n.Tf("Pod 或容器不存在: %s/%s/%s", opts.Namespace, opts.Pod, opts.Container)
 	default:
-		result.Error = fmt.Sprintf("Kubelet API 返回错误 (HTTP %d): %s", resp.StatusCode, string(body))
+		result.Error = i18n.Tf("Kubelet API 返回错误 (HTTP %d): %s", resp.StatusCode, string(body))
 	}
 
 	return result, nil
@@ -0,0 +1,45 @@
+package kubelet
+
+import (
+	"context"
+	"strings"
+
+	"kctl/internal/client"
+	"kctl/pkg/types"
+)
+
+// ExecSPDY 通过 SPDY/3.1 调用 Kubelet /exec（非交互式）
+// 部分 Kubelet 前置代理不支持 WebSocket 升级时使用
+func (c *kubeletClient) ExecSPDY(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	conn, err := client.DialSPDY(ctx, c.config, c.buildSPDYExecURL(opts), c.authHeader(), client.SPDYExecProtocol)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	// 上下文取消时关闭连接，中断阻塞的读取
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	result, err := client.SPDYExec(conn, opts)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return result, err
+}
+
+// ExecSPDYInteractive 通过 SPDY/3.1 调用 Kubelet /exec（交互式）
+func (c *kubeletClient) ExecSPDYInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	conn, err := client.DialSPDY(ctx, c.config, c.buildSPDYExecURL(opts), c.authHeader(), client.SPDYExecProtocol)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	return client.SPDYInteractive(conn, opts)
+}
+
+// buildSPDYExecURL 构建 SPDY exec URL（与 WebSocket 相同的路径和参数）
+func (c *kubeletClient) buildSPDYExecURL(opts *types.ExecOptions) string {
+	return "https://" + strings.TrimPrefix(c.buildExecURL(opts), "wss://")
+}
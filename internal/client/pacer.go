@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"kctl/internal/i18n"
+)
+
+// Pacer 控制发往 Kubelet / API Server 的请求节奏：限制速率并在请求之间加入随机延迟
+// 同一会话创建的所有客户端共享一个 Pacer，修改设置后已创建的客户端立即生效；nil 表示不限制
+type Pacer struct {
+	mu        sync.Mutex
+	rate      float64       // 每秒最多请求数，0 表示不限速
+	interval  time.Duration // 相邻请求的最小间隔
+	jitterMin time.Duration
+	jitterMax time.Duration
+	next      time.Time // 下一个请求最早可发出的时间
+}
+
+// NewPacer 创建不限制的 Pacer
+func NewPacer() *Pacer {
+	return &Pacer{}
+}
+
+// SetRate 设置每秒最多请求数，<= 0 时不限速
+func (p *Pacer) SetRate(perSecond float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rate, p.interval = 0, 0
+	if perSecond > 0 {
+		p.rate = perSecond
+		p.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	p.next = time.Time{}
+}
+
+// SetJitter 设置每个请求前的随机延迟范围，maxDelay 为 0 时关闭
+func (p *Pacer) SetJitter(minDelay, maxDelay time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.jitterMin, p.jitterMax = minDelay, maxDelay
+	p.next = time.Time{}
+}
+
+// Rate 返回每秒最多请求数，0 表示不限速
+func (p *Pacer) Rate() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rate
+}
+
+// Jitter 返回随机延迟范围
+func (p *Pacer) Jitter() (time.Duration, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.jitterMin, p.jitterMax
+}
+
+// Wait 等待到可以发出下一个请求，ctx 取消时提前返回
+// 并发请求按到达顺序排队：每个请求在上一个请求之后至少间隔 1/rate，再加上随机延迟
+func (p *Pacer) Wait(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	if p.interval == 0 && p.jitterMax == 0 {
+		p.mu.Unlock()
+		return nil
+	}
+	at := time.Now()
+	if p.next.After(at) {
+		at = p.next
+	}
+	if p.jitterMax > 0 {
+		at = at.Add(p.jitterMin + rand.N(p.jitterMax-p.jitterMin+1))
+	}
+	p.next = at.Add(p.interval)
+	p.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pacedTransport 每个 HTTP 请求发出前等待 Pacer
+type pacedTransport struct {
+	next  http.RoundTripper
+	pacer *Pacer
+}
+
+func (t *pacedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.pacer.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// ParseRate 解析速率：5/s、30/m、2/h 或纯数字（每秒），off / 0 表示不限速
+func ParseRate(s string) (float64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "off" || s == "none" || s == "0" {
+		return 0, nil
+	}
+	num, unit, _ := strings.Cut(s, "/")
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n <= 0 {
+		return 0, i18n.Errorf("无效的速率: %s (示例: 5/s, 30/m)", s)
+	}
+	switch unit {
+	case "", "s", "sec":
+		return n, nil
+	case "m", "min":
+		return n / 60, nil
+	case "h":
+		return n / 3600, nil
+	}
+	return 0, i18n.Errorf("无效的速率单位: %s (可用: s, m, h)", unit)
+}
+
+// ParseJitter 解析随机延迟范围：200-800ms、1s-3s、1-3s，单个值如 500ms 表示 0 到该值，off 表示关闭
+func ParseJitter(s string) (time.Duration, time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "off" || s == "none" || s == "0" {
+		return 0, 0, nil
+	}
+	lo, hi, isRange := strings.Cut(s, "-")
+	if !isRange {
+		lo, hi = "0s", lo
+	}
+	maxDelay, err := time.ParseDuration(hi)
+	if err != nil {
+		return 0, 0, i18n.Errorf("无效的延迟范围: %s (示例: 200-800ms, 1-3s)", s)
+	}
+	// 下限未写单位时沿用上限的单位（200-800ms）
+	if _, err := strconv.ParseFloat(lo, 64); err == nil {
+		lo += strings.TrimLeft(hi, "0123456789.")
+	}
+	minDelay, err := time.ParseDuration(lo)
+	if err != nil || minDelay < 0 || maxDelay < minDelay {
+		return 0, 0, i18n.Errorf("无效的延迟范围: %s (示例: 200-800ms, 1-3s)", s)
+	}
+	return minDelay, maxDelay, nil
+}
+
+// FormatRate 格式化速率，0 返回空字符串
+func FormatRate(perSecond float64) string {
+	switch {
+	case perSecond <= 0:
+		return ""
+	case perSecond >= 1:
+		return strconv.FormatFloat(perSecond, 'g', 6, 64) + "/s"
+	}
+	return strconv.FormatFloat(perSecond*60, 'g', 6, 64) + "/m"
+}
+
+// FormatJitter 格式化随机延迟范围，关闭时返回空字符串
+func FormatJitter(minDelay, maxDelay time.Duration) string {
+	if maxDelay == 0 {
+		return ""
+	}
+	return minDelay.String() + "-" + maxDelay.String()
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+
+	"kctl/internal/i18n"
+)
+
+// dialFunc 建立 TCP 连接
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// ValidateProxyURL 检查代理地址是否受支持：socks5://、socks5h://、http://、https://，可附带 user:pass@
+func ValidateProxyURL(proxyURL string) error {
+	_, err := parseProxyURL(proxyURL)
+	return err
+}
+
+// RedactProxyURL 隐藏代理地址中的密码，用于显示
+func RedactProxyURL(proxyURL string) string {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return proxyURL
+	}
+	return u.Redacted()
+}
+
+// parseProxyURL 解析代理地址，http / https 未指定端口时使用 80 / 443
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, i18n.Errorf("解析代理 URL 失败: %w", err)
+	}
+	switch u.Scheme {
+	case "socks5", "socks5h", "http", "https":
+	default:
+		return nil, i18n.Errorf("不支持的代理协议: %s，仅支持 socks5、socks5h、http 或 https", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, i18n.Errorf("代理 URL 缺少主机: %s", RedactProxyURL(proxyURL))
+	}
+	if u.Port() == "" {
+		switch u.Scheme {
+		case "http":
+			u.Host = net.JoinHostPort(u.Hostname(), "80")
+		case "https":
+			u.Host = net.JoinHostPort(u.Hostname(), "443")
+		default:
+			return nil, i18n.Errorf("代理 URL 缺少端口: %s", RedactProxyURL(proxyURL))
+		}
+	}
+	return u, nil
+}
+
+// newProxyDialer 创建经由代理的拨号函数：socks5 使用 SOCKS5，http / https 使用 CONNECT 隧道
+// URL 中的用户名和密码用于代理认证（SOCKS5 用户名密码认证或 Proxy-Authorization Basic）
+// forward 为连接代理使用的拨号器
+func newProxyDialer(proxyURL string, forward Dialer, connectTimeout time.Duration, skipTLSVerify bool) (dialFunc, error) {
+	u, err := parseProxyURL(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "http" || u.Scheme == "https" {
+		d := &connectDialer{proxy: u, forward: forward, timeout: connectTimeout, skipTLSVerify: skipTLSVerify}
+		return d.DialContext, nil
+	}
+
+	var auth *proxy.Auth
+	if u.User != nil {
+		password, _ := u.User.Password()
+		auth = &proxy.Auth{User: u.User.Username(), Password: password}
+	}
+	socks, err := proxy.SOCKS5("tcp", u.Host, auth, forwardDialer{forward})
+	if err != nil {
+		return nil, i18n.Errorf("创建 SOCKS5 代理拨号器失败: %w", err)
+	}
+	if cd, ok := socks.(proxy.ContextDialer); ok {
+		return cd.DialContext, nil
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return socks.Dial(network, addr)
+	}, nil
+}
+
+// connectDialer 通过 HTTP CONNECT 建立隧道，https 代理先与代理建立 TLS 连接
+type connectDialer struct {
+	proxy         *url.URL
+	forward       Dialer
+	timeout       time.Duration
+	skipTLSVerify bool
+}
+
+func (d *connectDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := d.forward.DialContext(ctx, "tcp", d.proxy.Host)
+	if err != nil {
+		return nil, i18n.Errorf("连接代理失败: %w", err)
+	}
+
+	// 握手期间遵守 ctx 的截止时间，未设置时使用连接超时
+	deadline, ok := ctx.Deadline()
+	if !ok && d.timeout > 0 {
+		deadline = time.Now().Add(d.timeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	if d.proxy.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName:         d.proxy.Hostname(),
+			InsecureSkipVerify: d.skipTLSVerify,
+		})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, i18n.Errorf("代理 TLS 握手失败: %w", err)
+		}
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if d.proxy.User != nil {
+		password, _ := d.proxy.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(d.proxy.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, i18n.Errorf("发送 CONNECT 请求失败: %w", err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, i18n.Errorf("读取 CONNECT 响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		_ = conn.Close()
+		if resp.StatusCode == http.StatusProxyAuthRequired {
+			return nil, i18n.Errorf("代理认证失败 (HTTP 407)，请在代理 URL 中提供 user:pass@")
+		}
+		return nil, i18n.Errorf("代理 CONNECT %s 失败: %s", addr, resp.Status)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+	// 代理在响应后紧接着发送的数据已读入缓冲区，不能丢弃
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// forwardDialer 将 Dialer 适配为 proxy.Dialer，SOCKS5 拨号器优先使用其 DialContext
+type forwardDialer struct {
+	Dialer
+}
+
+func (d forwardDialer) Dial(network, addr string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, addr)
+}
+
+// bufferedConn 先读取缓冲区中剩余数据的连接
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"kctl/internal/i18n"
+)
+
+// RawIndexFile 原始响应目录中的索引文件（每行一条 JSON 记录）
+const RawIndexFile = "index.jsonl"
+
+// RawRecord 原始响应索引记录，SHA256 用于事后证明文件未被修改
+type RawRecord struct {
+	Time   time.Time `json:"time"`
+	Target string    `json:"target"` // ip:port
+	Path   string    `json:"path"`   // 请求路径，如 /pods
+	Status int       `json:"status"` // HTTP 状态码
+	Size   int       `json:"size"`
+	SHA256 string    `json:"sha256"`
+	File   string    `json:"file"` // 相对于原始响应目录的路径
+}
+
+// rawDumpMu 串行化同一进程内对索引文件的追加
+var rawDumpMu sync.Mutex
+
+// DumpRaw 将原始响应体按原样保存到 dir/<target>/<时间>_<路径>.<json|txt>，并追加索引记录
+func DumpRaw(dir, target, path string, status int, body []byte) (*RawRecord, error) {
+	now := time.Now()
+	ext := ".txt"
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		ext = ".json"
+	}
+	name := strings.Trim(strings.NewReplacer("/", "_", "?", "_", "&", "_", "=", "-").Replace(path), "_")
+	if name == "" {
+		name = "root"
+	}
+	sum := sha256.Sum256(body)
+	record := &RawRecord{
+		Time:   now,
+		Target: target,
+		Path:   path,
+		Status: status,
+		Size:   len(body),
+		SHA256: hex.EncodeToString(sum[:]),
+	}
+	base := filepath.Join(strings.ReplaceAll(target, ":", "_"), now.Format("20060102-150405.000")+"_"+name)
+
+	rawDumpMu.Lock()
+	defer rawDumpMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(base)), 0700); err != nil {
+		return nil, i18n.Errorf("创建原始响应目录失败: %w", err)
+	}
+	// 同一毫秒内的相同请求追加序号，已保存的证据不覆盖
+	var f *os.File
+	for i := 0; f == nil; i++ {
+		record.File = base + ext
+		if i > 0 {
+			record.File = fmt.Sprintf("%s-%d%s", base, i, ext)
+		}
+		var err error
+		f, err = os.OpenFile(filepath.Join(dir, record.File), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil && !os.IsExist(err) {
+			return nil, i18n.Errorf("保存原始响应失败: %w", err)
+		}
+	}
+	_, err := f.Write(body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, i18n.Errorf("保存原始响应失败: %w", err)
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return nil, i18n.Errorf("序列化索引失败: %w", err)
+	}
+	index, err := os.OpenFile(filepath.Join(dir, RawIndexFile), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, i18n.Errorf("写入索引失败: %w", err)
+	}
+	defer func() { _ = index.Close() }()
+	if _, err := index.Write(append(line, '\n')); err != nil {
+		return nil, i18n.Errorf("写入索引失败: %w", err)
+	}
+	return record, nil
+}
+
+// PrepareRawDumpDir 创建原始响应目录并检查可写
+func PrepareRawDumpDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return i18n.Errorf("创建原始响应目录失败: %w", err)
+	}
+	f, err := os.CreateTemp(dir, ".kctl-write-test-")
+	if err != nil {
+		return i18n.Errorf("原始响应目录不可写: %w", err)
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
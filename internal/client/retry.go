@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
+)
+
+// maxRetryBackoff 单次重试等待的上限
+const maxRetryBackoff = 30 * time.Second
+
+// retryTransport 在连接失败、超时或服务端返回 429 / 502 / 503 / 504 时按指数退避重试请求
+// 只有幂等请求（GET、HEAD、OPTIONS）会在收到响应或连接中断后重试；其他请求（如 /run、创建资源）只在连接未建立时重试，避免重复执行
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	interval   time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		var retryAfter string
+		if resp != nil {
+			retryAfter = resp.Header.Get("Retry-After")
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+		}
+		if req.Body != nil && req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		delay := retryDelay(t.interval, attempt, retryAfter)
+		logging.Debug("retrying request", logging.Fields{
+			"method":  req.Method,
+			"url":     logging.RedactURL(req.URL.String()),
+			"attempt": attempt + 1,
+			"delay":   delay.String(),
+			"reason":  retryReason(resp, err),
+		})
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry 判断请求失败是否为可重试的临时错误
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	// 请求体无法重放时不重试
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return isDialError(err) || (isIdempotent(req.Method) && isTransientError(err))
+	}
+	if !isIdempotent(req.Method) {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent 请求方法是否可以安全地重复发送
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isDialError 是否为建立连接阶段的错误（请求尚未发出），经由代理时为连接代理的错误
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isTransientError 是否为超时、连接重置等临时网络错误；TLS 证书错误等不会因重试而恢复
+func isTransientError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "connection reset") || strings.Contains(msg, "broken pipe")
+}
+
+// retryDelay 计算第 attempt 次重试前的等待时间：interval * 2^attempt，服务端指定 Retry-After（秒）时使用其值，不超过 maxRetryBackoff
+func retryDelay(interval time.Duration, attempt int, retryAfter string) time.Duration {
+	if secs, err := strconv.Atoi(strings.TrimSpace(retryAfter)); err == nil && secs >= 0 {
+		return min(time.Duration(secs)*time.Second, maxRetryBackoff)
+	}
+	delay := interval
+	for i := 0; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
+
+// retryReason 描述重试原因，用于日志
+func retryReason(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
+
+// sleepContext 等待 d，ctx 取消时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ParseTimeout 解析超时或间隔：Go duration 格式（30s、1m30s、500ms），纯数字按秒计算
+func ParseTimeout(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		s = strconv.FormatFloat(n, 'f', -1, 64) + "s"
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, i18n.Errorf("无效的时长: %s (示例: 30s, 1m, 500ms)", s)
+	}
+	return d, nil
+}
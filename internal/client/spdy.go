@@ -0,0 +1,276 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moby/spdystream"
+	"golang.org/x/term"
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
+	"kctl/pkg/types"
+)
+
+// SPDY exec 协议常量
+const (
+	SPDYExecProtocol = "v4.channel.k8s.io"
+
+	spdyStreamTypeHeader = "streamType"
+	spdyStreamError      = "error"
+	spdyStreamStdin      = "stdin"
+	spdyStreamStdout     = "stdout"
+	spdyStreamStderr     = "stderr"
+
+	// spdyStreamReplyTimeout 等待服务端确认流创建的超时
+	spdyStreamReplyTimeout = 30 * time.Second
+)
+
+// DialSPDY 通过 HTTP Upgrade 建立 SPDY/3.1 连接，握手被拒绝时返回 ExecDialError
+func DialSPDY(ctx context.Context, cfg *Config, rawURL, authHeader, protocol string) (*spdystream.Connection, error) {
+	start := time.Now()
+	conn, err := dialSPDY(ctx, cfg, rawURL, authHeader, protocol)
+	status := http.StatusSwitchingProtocols
+	var dialErr *ExecDialError
+	if errors.As(err, &dialErr) {
+		status = dialErr.StatusCode
+	} else if err != nil {
+		status = 0
+	}
+	logging.Request("spdy", http.MethodPost, rawURL, status, start, err)
+	return conn, err
+}
+
+func dialSPDY(ctx context.Context, cfg *Config, rawURL, authHeader, protocol string) (*spdystream.Connection, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, i18n.Errorf("解析 URL 失败: %w", err)
+	}
+
+	tlsConfig, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	rawConn, err := DialTCP(ctx, cfg, u.Host)
+	if err != nil {
+		return nil, i18n.Errorf("SPDY 连接失败: %w", err)
+	}
+	conn := net.Conn(rawConn)
+	if u.Scheme == "https" {
+		tlsConfig.ServerName = u.Hostname()
+		tlsConn := tls.Client(rawConn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = rawConn.Close()
+			return nil, i18n.Errorf("TLS 握手失败: %w", err)
+		}
+		conn = tlsConn
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u.RequestURI(), nil)
+	if err != nil {
+		_ = conn.Close()
+		return nil, i18n.Errorf("创建请求失败: %w", err)
+	}
+	req.Host = u.Host
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "SPDY/3.1")
+	req.Header.Set("X-Stream-Protocol-Version", protocol)
+	SetAuthHeader(req.Header, authHeader)
+	cfg.SetRequestHeaders(req.Header)
+
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, i18n.Errorf("发送请求失败: %w", err)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, i18n.Errorf("读取响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		_ = conn.Close()
+		return nil, &ExecDialError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	if got := resp.Header.Get("X-Stream-Protocol-Version"); got != protocol {
+		_ = conn.Close()
+		return nil, i18n.Errorf("不支持的协议: %q", got)
+	}
+
+	spdyConn, err := spdystream.NewConnection(conn, false)
+	if err != nil {
+		_ = conn.Close()
+		return nil, i18n.Errorf("创建 SPDY 连接失败: %w", err)
+	}
+	go spdyConn.Serve(spdystream.NoOpStreamHandler)
+	return spdyConn, nil
+}
+
+// spdyExecStreams exec 使用的 SPDY 流
+type spdyExecStreams struct {
+	errStream *spdystream.Stream
+	stdin     *spdystream.Stream
+	stdout    *spdystream.Stream
+	stderr    *spdystream.Stream
+}
+
+// createSPDYExecStreams 按 opts 创建 error/stdin/stdout/stderr 流
+func createSPDYExecStreams(conn *spdystream.Connection, opts *types.ExecOptions) (*spdyExecStreams, error) {
+	create := func(streamType string) (*spdystream.Stream, error) {
+		headers := http.Header{}
+		headers.Set(spdyStreamTypeHeader, streamType)
+		stream, err := conn.CreateStream(headers, nil, false)
+		if err != nil {
+			return nil, i18n.Errorf("创建 %s 流失败: %w", streamType, err)
+		}
+		if err := stream.WaitTimeout(spdyStreamReplyTimeout); err != nil {
+			return nil, i18n.Errorf("等待 %s 流确认失败: %w", streamType, err)
+		}
+		return stream, nil
+	}
+
+	s := &spdyExecStreams{}
+	var err error
+	if s.errStream, err = create(spdyStreamError); err != nil {
+		return nil, err
+	}
+	if opts.Stdin {
+		if s.stdin, err = create(spdyStreamStdin); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Stdout {
+		if s.stdout, err = create(spdyStreamStdout); err != nil {
+			return nil, err
+		}
+	}
+	// TTY 模式下 stderr 合并到 stdout
+	if opts.Stderr && !opts.TTY {
+		if s.stderr, err = create(spdyStreamStderr); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// SPDYExec 在 SPDY 连接上执行非交互式命令
+func SPDYExec(conn *spdystream.Connection, opts *types.ExecOptions) (*types.ExecResult, error) {
+	streams, err := createSPDYExecStreams(conn, opts)
+	if err != nil {
+		return nil, err
+	}
+	if streams.stdin != nil {
+		_ = streams.stdin.Close()
+	}
+
+	var stdout, stderr []byte
+	var wg sync.WaitGroup
+	if streams.stdout != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stdout, _ = io.ReadAll(streams.stdout)
+		}()
+	}
+	if streams.stderr != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stderr, _ = io.ReadAll(streams.stderr)
+		}()
+	}
+	wg.Wait()
+
+	result := &types.ExecResult{Stdout: string(stdout), Stderr: string(stderr)}
+	applySPDYStatus(result, streams.errStream)
+	return result, nil
+}
+
+// SPDYInteractive 在 SPDY 连接上转发终端输入输出
+func SPDYInteractive(conn *spdystream.Connection, opts *types.ExecOptions) error {
+	if opts.TTY {
+		fd := int(os.Stdin.Fd())
+		if term.IsTerminal(fd) {
+			oldState, err := term.MakeRaw(fd)
+			if err != nil {
+				return i18n.Errorf("设置终端 raw 模式失败: %w", err)
+			}
+			defer func() { _ = term.Restore(fd, oldState) }()
+		}
+	}
+
+	streams, err := createSPDYExecStreams(conn, opts)
+	if err != nil {
+		return err
+	}
+
+	// stdin 读取会阻塞，不等待其结束
+	if streams.stdin != nil {
+		go func() {
+			_, _ = io.Copy(streams.stdin, os.Stdin)
+			_ = streams.stdin.Close()
+		}()
+	}
+
+	var wg sync.WaitGroup
+	if streams.stdout != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = io.Copy(os.Stdout, streams.stdout)
+		}()
+	}
+	if streams.stderr != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = io.Copy(os.Stderr, streams.stderr)
+		}()
+	}
+	wg.Wait()
+
+	result := &types.ExecResult{}
+	applySPDYStatus(result, streams.errStream)
+	if result.Error != "" {
+		fmt.Fprintf(os.Stderr, "\n[Error] %s\n", result.Error)
+	}
+	return nil
+}
+
+// applySPDYStatus 读取 error 流中的 Status 并写入执行结果
+func applySPDYStatus(result *types.ExecResult, errStream *spdystream.Stream) {
+	data, _ := io.ReadAll(errStream)
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return
+	}
+
+	var status types.ExecStatus
+	if err := json.Unmarshal([]byte(text), &status); err != nil {
+		result.Error = text
+		return
+	}
+	if status.Status != "Success" {
+		result.Error = status.Message
+		if result.Error == "" {
+			result.Error = text
+		}
+		result.ExitCode = parseExitCode(&status)
+	}
+}
@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
+	"kctl/pkg/types"
+)
+
+// WebSocket 子协议通道编号
+const (
+	StreamStdin  = 0 // stdin 通道
+	StreamStdout = 1 // stdout 通道
+	StreamStderr = 2 // stderr 通道
+	StreamError  = 3 // error 通道
+	StreamResize = 4 // resize 通道 (TTY)
+)
+
+// DialExec 建立 exec WebSocket 连接，握手失败时返回带 HTTP 状态码的错误
+func DialExec(ctx context.Context, dialer *websocket.Dialer, execURL, authHeader string) (*websocket.Conn, error) {
+	headers := http.Header{}
+	SetAuthHeader(headers, authHeader)
+	return DialExecHeaders(ctx, dialer, execURL, headers)
+}
+
+// DialExecHeaders 使用指定请求头建立 exec WebSocket 连接（如附带模拟身份请求头）
+func DialExecHeaders(ctx context.Context, dialer *websocket.Dialer, execURL string, headers http.Header) (*websocket.Conn, error) {
+	start := time.Now()
+	conn, resp, err := dialer.DialContext(ctx, execURL, headers)
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	logging.Request("websocket", http.MethodGet, execURL, status, start, err)
+	if err != nil {
+		if resp != nil {
+			body, _ := io.ReadAll(resp.Body)
+			return nil, &ExecDialError{StatusCode: resp.StatusCode, Body: string(body)}
+		}
+		return nil, i18n.Errorf("WebSocket 连接失败: %w", err)
+	}
+	return conn, nil
+}
+
+// ExecDialError exec 连接握手（WebSocket 或 SPDY 升级）被服务端拒绝
+type ExecDialError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *ExecDialError) Error() string {
+	return i18n.Tf("exec 连接升级失败 (HTTP %d): %s", e.StatusCode, e.Body)
+}
+
+// ReadExecOutput 读取非交互式 exec 的输出直到连接关闭
+func ReadExecOutput(conn *websocket.Conn) (*types.ExecResult, error) {
+	result := &types.ExecResult{}
+	var mu sync.Mutex
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				break
+			}
+			if result.Error == "" && !strings.Contains(err.Error(), "close") {
+				result.Error = err.Error()
+			}
+			break
+		}
+
+		if len(message) < 1 {
+			continue
+		}
+
+		// 第一个字节是通道编号
+		channel := message[0]
+		data := string(message[1:])
+
+		mu.Lock()
+		switch channel {
+		case StreamStdout:
+			result.Stdout += data
+		case StreamStderr:
+			result.Stderr += data
+		case StreamError:
+			// 解析 exec 状态响应
+			var execStatus types.ExecStatus
+			if err := json.Unmarshal([]byte(data), &execStatus); err == nil {
+				// 只有当 status 不是 Success 时才认为是错误
+				if execStatus.Status != "Success" {
+					result.Error = execStatus.Message
+					if result.Error == "" {
+						result.Error = data
+					}
+					result.ExitCode = parseExitCode(&execStatus)
+				}
+			} else {
+				// 无法解析为 JSON，作为原始错误处理
+				result.Error = data
+			}
+		}
+		mu.Unlock()
+	}
+
+	return result, nil
+}
+
+// maxExecStreamStderr ExecStream 保留的 stderr 字节数，用于连接结束后报告中继错误
+const maxExecStreamStderr = 4096
+
+// ExecStream 以 exec WebSocket 连接中命令的 stdin / stdout 作为读写端的字节流
+// Read 只返回 stdout，stderr 和 error 通道的内容保留下来，连接结束后由 Err 返回
+type ExecStream struct {
+	conn *websocket.Conn
+	buf  []byte
+
+	wmu sync.Mutex // WebSocket 同一时间只能有一个写入者
+
+	mu     sync.Mutex
+	stderr []byte
+	status string
+}
+
+// NewExecStream 包装已建立的 exec WebSocket 连接（需开启 input 和 output）
+func NewExecStream(conn *websocket.Conn) *ExecStream {
+	return &ExecStream{conn: conn}
+}
+
+func (s *ExecStream) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		_, message, err := s.conn.ReadMessage()
+		if err != nil {
+			return 0, io.EOF
+		}
+		if len(message) < 1 {
+			continue
+		}
+		switch message[0] {
+		case StreamStdout:
+			s.buf = message[1:]
+		case StreamStderr:
+			s.mu.Lock()
+			if room := maxExecStreamStderr - len(s.stderr); room > 0 {
+				s.stderr = append(s.stderr, message[1:min(len(message), room+1)]...)
+			}
+			s.mu.Unlock()
+		case StreamError:
+			status := string(message[1:])
+			var execStatus types.ExecStatus
+			if err := json.Unmarshal(message[1:], &execStatus); err == nil {
+				if execStatus.Status == "Success" {
+					continue
+				}
+				if execStatus.Message != "" {
+					status = execStatus.Message
+				}
+			}
+			s.mu.Lock()
+			s.status = status
+			s.mu.Unlock()
+		}
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+func (s *ExecStream) Write(p []byte) (int, error) {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	msg := append([]byte{StreamStdin}, p...)
+	if err := s.conn.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close 关闭连接，Kubelet 随之结束容器中的命令
+func (s *ExecStream) Close() error {
+	return s.conn.Close()
+}
+
+// Err 返回命令输出到 stderr 的内容或非成功的退出状态，没有时返回 nil
+func (s *ExecStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if msg := strings.TrimSpace(string(s.stderr)); msg != "" {
+		return errors.New(msg)
+	}
+	if s.status != "" {
+		return errors.New(s.status)
+	}
+	return nil
+}
+
+// parseExitCode 从 exec 状态中解析远程命令退出码
+// 非零退出时 reason 为 NonZeroExitCode，退出码位于 details.causes 中 reason 为 ExitCode 的条目
+// 其他失败（容器不存在、可执行文件不存在等）不是远程命令的退出码，返回 0，由 Error 报告
+func parseExitCode(status *types.ExecStatus) int {
+	if status.Reason == "NonZeroExitCode" && status.Details != nil {
+		for _, cause := range status.Details.Causes {
+			if cause.Reason != "ExitCode" {
+				continue
+			}
+			if code, err := strconv.Atoi(strings.TrimSpace(cause.Message)); err == nil {
+				return code
+			}
+		}
+	}
+	return 0
+}
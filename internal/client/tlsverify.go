@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
+	"os"
+	"slices"
+	"strings"
+
+	"kctl/internal/i18n"
+)
+
+// CertFingerprint 返回证书（DER）的 SHA256 指纹，小写十六进制
+func CertFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseFingerprint 解析 SHA256 指纹：十六进制，可带 sha256: 前缀和冒号分隔（openssl x509 -fingerprint -sha256 的输出）
+func ParseFingerprint(s string) (string, error) {
+	fp := strings.ToLower(strings.TrimSpace(s))
+	fp = strings.TrimPrefix(fp, "sha256:")
+	fp = strings.TrimPrefix(fp, "sha256 fingerprint=")
+	fp = strings.ReplaceAll(fp, ":", "")
+	if b, err := hex.DecodeString(fp); err != nil || len(b) != sha256.Size {
+		return "", i18n.Errorf("无效的 SHA256 指纹: %s", s)
+	}
+	return fp, nil
+}
+
+// LoadCABundle 读取 PEM 格式的 CA 证书文件，文件中没有有效证书时返回错误
+func LoadCABundle(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取 CA 证书失败: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, i18n.Errorf("CA 证书文件中没有有效的 PEM 证书: %s", path)
+	}
+	return pool, nil
+}
+
+// rootCAs 返回验证服务端证书使用的 CA：CACertPath 与 CACertPEM 中的证书，都未设置时返回 nil（使用系统根证书）
+func (c *Config) rootCAs() (*x509.CertPool, error) {
+	if c.CACertPath == "" && len(c.CACertPEM) == 0 {
+		return nil, nil
+	}
+	pool := x509.NewCertPool()
+	if c.CACertPath != "" {
+		p, err := LoadCABundle(c.CACertPath)
+		if err != nil {
+			return nil, err
+		}
+		pool = p
+	}
+	if len(c.CACertPEM) > 0 && !pool.AppendCertsFromPEM(c.CACertPEM) {
+		return nil, i18n.Errorf("CA 证书中没有有效的 PEM 证书")
+	}
+	return pool, nil
+}
+
+// verifyPinned 返回只接受 PinnedCerts 中证书的验证函数（按叶子证书 SHA256 指纹匹配，不验证证书链和主机名）
+func (c *Config) verifyPinned() func(tls.ConnectionState) error {
+	pins := slices.Clone(c.PinnedCerts)
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return i18n.New("服务端未提供证书")
+		}
+		leaf := cs.PeerCertificates[0]
+		fp := CertFingerprint(leaf)
+		if !slices.Contains(pins, fp) {
+			return i18n.Errorf("服务端证书未固定 (%s, sha256 %s)，确认后可用 set pin-cert 添加", leaf.Subject.CommonName, fp)
+		}
+		return nil
+	}
+}
+
+// FetchCertificates 不验证证书，与 addr 进行 TLS 握手（经由配置的隧道和代理），返回服务端证书链，用于固定指纹
+func FetchCertificates(ctx context.Context, cfg *Config, addr string) ([]*x509.Certificate, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, i18n.Errorf("无效的地址: %s", addr)
+	}
+	rawConn, err := DialTCP(ctx, cfg, addr)
+	if err != nil {
+		return nil, i18n.Errorf("连接 %s 失败: %w", addr, err)
+	}
+	conn := tls.Client(rawConn, &tls.Config{ServerName: host, InsecureSkipVerify: true})
+	defer func() { _ = conn.Close() }()
+	if err := conn.HandshakeContext(ctx); err != nil {
+		return nil, i18n.Errorf("TLS 握手失败: %w", err)
+	}
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, i18n.New("服务端未提供证书")
+	}
+	return certs, nil
+}
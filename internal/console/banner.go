@@ -40,7 +40,12 @@ func PrintBanner(s *session.Session) {
 		p.Colored(config.ColorGreen, s.GetModeString()))
 
 	// 打印目标信息
-	if s.Config.KubeletIP != "" {
+	if s.IsViewer() {
+		p.Printf("  %s Viewer: %s %s\n",
+			p.Colored(config.ColorBlue, "[*]"),
+			p.Colored(config.ColorYellow, s.DB.DisplayPath()),
+			p.Colored(config.ColorGray, "(read-only, cluster commands disabled)"))
+	} else if s.Config.KubeletIP != "" {
 		targetInfo := fmt.Sprintf("%s:%d", s.Config.KubeletIP, s.Config.KubeletPort)
 		note := ""
 		if s.InPod {
@@ -56,6 +61,12 @@ func PrintBanner(s *session.Session) {
 			p.Colored(config.ColorGray, "(not set, use 'set target <ip>')"))
 	}
 
+	if s.IsSafeMode() {
+		p.Printf("  %s Safe mode: %s\n",
+			p.Colored(config.ColorBlue, "[*]"),
+			p.Colored(config.ColorYellow, "exec, deploy and other actions against the cluster are blocked"))
+	}
+
 	// 打印帮助提示
 	p.Printf("  %s Type '%s' for available commands\n",
 		p.Colored(config.ColorBlue, "[*]"),
@@ -0,0 +1,204 @@
+package console
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"kctl/internal/console/commands"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+)
+
+// 桥接协议方法
+const (
+	BridgeMethodExec     = "exec"     // 执行控制台命令（默认）
+	BridgeMethodCommands = "commands" // 列出可用命令
+	BridgeMethodPing     = "ping"     // 存活检查
+	BridgeMethodShutdown = "shutdown" // 退出桥接
+)
+
+// bridgeMaxLine 单个请求的最大长度
+const bridgeMaxLine = 4 * 1024 * 1024
+
+// BridgeRequest 桥接请求（每行一个 JSON 对象）
+type BridgeRequest struct {
+	ID      json.RawMessage `json:"id,omitempty"`      // 原样回显
+	Method  string          `json:"method,omitempty"`  // 为空时为 exec
+	Command string          `json:"command,omitempty"` // 控制台命令行，如 "sa list --risky"
+	Args    []string        `json:"args,omitempty"`    // 已拆分的参数，优先于 Command（无需处理引号）
+}
+
+// BridgeResponse 桥接响应（每行一个 JSON 对象）
+type BridgeResponse struct {
+	ID       json.RawMessage `json:"id,omitempty"`
+	Event    string          `json:"event,omitempty"` // 非请求响应的事件，如 ready
+	OK       bool            `json:"ok"`
+	ExitCode int             `json:"exit_code"`
+	Output   string          `json:"output,omitempty"` // 命令输出（无颜色）
+	Error    string          `json:"error,omitempty"`
+	Commands []BridgeCommand `json:"commands,omitempty"`
+}
+
+// BridgeCommand 命令描述（commands 方法）
+type BridgeCommand struct {
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Description string   `json:"description"`
+	Usage       string   `json:"usage"`
+	Destructive bool     `json:"destructive"`     // 以 cluster-admin 身份执行时需要 --confirm
+	Flags       []string `json:"flags,omitempty"` // 顶层选项（不含子命令选项）
+}
+
+// ServeBridge 以换行分隔的 JSON 在 in/out 上提供控制台命令，供编排框架调用
+// 命令依次执行，输出被捕获并去除颜色；交互式命令（exec -it 等）的标准输入为空
+func (c *Console) ServeBridge(in io.Reader, out io.Writer) error {
+	color.NoColor = true
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+
+	ready := c.capture(func() {
+		st := c.restoreSession(false)
+		c.autoConnect()
+		c.restoreCurrentSA(st)
+	})
+	if err := enc.Encode(BridgeResponse{Event: "ready", OK: true, Output: ready}); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), bridgeMaxLine)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var req BridgeRequest
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			if err := enc.Encode(BridgeResponse{OK: false, ExitCode: 1, Error: i18n.Tf("无效的请求: %v", err)}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		resp := c.handleBridge(&req)
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+		if req.Method == BridgeMethodShutdown {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// handleBridge 处理单个桥接请求
+func (c *Console) handleBridge(req *BridgeRequest) BridgeResponse {
+	resp := BridgeResponse{ID: req.ID, OK: true}
+
+	switch req.Method {
+	case "", BridgeMethodExec:
+		args := req.Args
+		if len(args) == 0 {
+			args = parseArgs(req.Command)
+		}
+		if len(args) == 0 {
+			resp.OK, resp.ExitCode, resp.Error = false, 1, i18n.T("缺少 command 或 args")
+			return resp
+		}
+
+		var err error
+		resp.Output = c.capture(func() {
+			if len(req.Args) > 0 {
+				err = c.executor.RunArgs(args)
+			} else {
+				// 命令行形式支持管道过滤，如 "pods | grep nginx"
+				err = c.executor.Run(req.Command)
+			}
+		})
+		if err != nil {
+			resp.OK = false
+			resp.ExitCode = commands.ExitCode(err)
+			resp.Error = err.Error()
+		}
+
+	case BridgeMethodCommands:
+		resp.Commands = bridgeCommands()
+
+	case BridgeMethodPing, BridgeMethodShutdown:
+
+	default:
+		resp.OK, resp.ExitCode = false, 1
+		resp.Error = i18n.Tf("未知方法: %s (可用: exec, commands, ping, shutdown)", req.Method)
+	}
+
+	return resp
+}
+
+// capture 执行 fn 并返回其全部输出
+// 会话打印器和直接写 os.Stdout 的输出（表格、远程命令流）都被重定向，标准输入替换为空
+func (c *Console) capture(fn func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		fn()
+		return ""
+	}
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	origStdout, origStdin, origPrinter := os.Stdout, os.Stdin, c.session.Printer
+	os.Stdout = w
+	if devNull, err := os.Open(os.DevNull); err == nil {
+		os.Stdin = devNull
+		defer func() { _ = devNull.Close() }()
+	}
+	c.session.Printer = output.NewPrinterWithWriter(w, w)
+
+	defer func() {
+		os.Stdout, os.Stdin, c.session.Printer = origStdout, origStdin, origPrinter
+	}()
+
+	fn()
+
+	_ = w.Close()
+	result := <-done
+	_ = r.Close()
+	return result
+}
+
+// bridgeCommands 返回所有命令的描述（按名称排序）
+func bridgeCommands() []BridgeCommand {
+	var list []BridgeCommand
+	for _, cmd := range commands.All() {
+		destructive := false
+		if d, ok := cmd.(commands.Destructive); ok {
+			destructive = d.IsDestructive(nil)
+		}
+		var flags []string
+		if c, ok := cmd.(commands.Completable); ok {
+			for _, f := range c.Flags(nil) {
+				flags = append(flags, f.Name)
+			}
+		}
+		list = append(list, BridgeCommand{
+			Name:        cmd.Name(),
+			Aliases:     cmd.Aliases(),
+			Description: cmd.Description(),
+			Usage:       cmd.Usage(),
+			Destructive: destructive,
+			Flags:       flags,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
@@ -0,0 +1,303 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/client"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// APIServerCmd apiserver 命令
+type APIServerCmd struct{}
+
+func init() {
+	Register(&APIServerCmd{})
+}
+
+func (c *APIServerCmd) Name() string {
+	return "apiserver"
+}
+
+func (c *APIServerCmd) Aliases() []string {
+	return nil
+}
+
+func (c *APIServerCmd) Description() string {
+	return "检查 API Server 不安全端口、匿名访问和准入插件配置"
+}
+
+func (c *APIServerCmd) Usage() string {
+	return `apiserver audit [target] [options]
+
+检查 API Server 的常见错误配置：
+  - 旧版不安全端口（默认 8080，不经认证授权）
+  - 不带凭据访问 /version、/api、/metrics、/debug/pprof、/api/v1/{namespaces,pods,secrets}
+  - kube-apiserver 启动参数（authorization-mode、token-auth-file、审计日志、静态加密等）和启用的准入插件
+
+启动参数从 kube-system 中 kube-apiserver 静态 Pod 的规格读取（API Server 或当前 Kubelet 为控制平面节点时），
+读不到时从 /metrics 的准入插件指标推断实际运行的插件
+target 为 API Server 地址（URL 或 host[:port]），默认使用 'set api-server' 设置的地址
+
+发现写入数据库（来源 apiserver，hunt list 查看）
+
+选项：
+  --insecure-port <port>  不安全端口（默认 ` + strconv.Itoa(config.DefaultAPIServerInsecurePort) + `）
+
+示例：
+  apiserver audit
+  apiserver audit https://10.0.0.10:6443
+  apiserver audit 10.0.0.10 --insecure-port 8081`
+}
+
+// Flags apiserver 的选项补全
+func (c *APIServerCmd) Flags(args []string) []completion.Flag {
+	if len(args) == 0 {
+		return nil
+	}
+	return []completion.Flag{
+		{Name: "--insecure-port", Arg: "<port>", Description: "不安全端口"},
+	}
+}
+
+// Suggestions apiserver 的子命令补全
+func (c *APIServerCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return subcommandSuggestions(args,
+		completion.Suggestion{Text: "audit", Description: "检查不安全端口、匿名访问和准入插件"},
+	)
+}
+
+// apiServerCheck 一次端点探测的结果
+type apiServerCheck struct {
+	id       string
+	endpoint string
+	status   string
+	finding  *security.APIServerFinding
+}
+
+func (c *APIServerCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	if len(args) == 0 || args[0] != "audit" {
+		return i18n.Errorf("用法: apiserver audit [target] [--insecure-port <port>]")
+	}
+	target := ""
+	insecurePort := config.DefaultAPIServerInsecurePort
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--insecure-port":
+			if i+1 >= len(args) {
+				return i18n.Errorf("--insecure-port 需要指定端口")
+			}
+			port, err := strconv.Atoi(args[i+1])
+			if err != nil || port <= 0 || port > 65535 {
+				return i18n.Errorf("无效的端口: %s", args[i+1])
+			}
+			insecurePort = port
+			i++
+		default:
+			if strings.HasPrefix(args[i], "-") || target != "" {
+				return i18n.Errorf("未知参数: %s", args[i])
+			}
+			target = args[i]
+		}
+	}
+
+	base, err := c.resolveTarget(sess, target)
+	if err != nil {
+		return err
+	}
+	host := base.Hostname()
+
+	// 匿名请求不带会话中的客户端证书和模拟身份
+	cfg := *sess.GetClientConfig()
+	cfg.ClientCertPEM, cfg.ClientKeyPEM = nil, nil
+	cfg.ImpersonateUser, cfg.ImpersonateGroups = "", nil
+	cfg.Timeout = config.DefaultProbeTimeout
+	cfg.MaxRetries = 0 // 探测以连接失败为结果，不重试
+	httpClient, err := client.NewHTTPClient(&cfg)
+	if err != nil {
+		return i18n.Errorf("创建 HTTP 客户端失败: %w", err)
+	}
+
+	p.Printf("%s Probing %s without credentials...\n", p.Colored(config.ColorBlue, "[*]"), base.String())
+	var checks []apiServerCheck
+
+	insecure := "http://" + net.JoinHostPort(host, strconv.Itoa(insecurePort))
+	status, body, err := c.fetch(httpClient, insecure+"/api")
+	check := apiServerCheck{id: "insecure-port", endpoint: insecure + "/api", status: c.formatStatus(status, err)}
+	if status == http.StatusOK && strings.Contains(string(body), `"versions"`) {
+		check.finding = &security.APIServerFinding{ID: "insecure-port", Severity: config.RiskCritical, Title: "不安全端口开放",
+			Detail: fmt.Sprintf("%s 不经认证和授权即可访问 API，等同集群管理员", insecure)}
+	}
+	checks = append(checks, check)
+
+	var metrics string
+	for _, probe := range security.APIServerAnonymousProbes {
+		status, body, err := c.fetch(httpClient, base.String()+probe.Path)
+		check := apiServerCheck{id: probe.ID, endpoint: probe.Path, status: c.formatStatus(status, err)}
+		if status == http.StatusOK {
+			check.finding = &security.APIServerFinding{ID: probe.ID, Severity: probe.Severity, Title: probe.Title, Detail: probe.Detail}
+			if probe.Path == "/metrics" {
+				metrics = string(body)
+			}
+		}
+		checks = append(checks, check)
+	}
+
+	configFindings, plugins, source := c.auditConfig(sess, target == "", metrics)
+
+	now := time.Now()
+	var records []*types.FindingRecord
+	addRecord := func(f *security.APIServerFinding) {
+		records = append(records, &types.FindingRecord{
+			Source:      "apiserver",
+			Severity:    string(f.Severity),
+			Category:    "apiserver",
+			Location:    f.ID + "@" + base.Host,
+			Title:       f.Title,
+			Evidence:    f.Detail,
+			CollectedAt: now,
+			KubeletIP:   sess.Config.KubeletIP,
+		})
+	}
+
+	var rows [][]string
+	for _, check := range checks {
+		result := p.Colored(config.ColorGreen, "ok")
+		if check.finding != nil {
+			addRecord(check.finding)
+			result = p.Formatter().FormatRiskLevelColored(check.finding.Severity) + " " + check.finding.Title
+		}
+		rows = append(rows, []string{check.id, check.endpoint, check.status, result})
+	}
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"CHECK", "ENDPOINT", "STATUS", "RESULT"}, rows)
+
+	if source != "" {
+		p.Println()
+		p.Printf("  %s %s\n", p.Colored(config.ColorWhite, "Admission plugins"), p.Colored(config.ColorGray, "("+source+")"))
+		p.Printf("    %s\n", strings.Join(plugins, ", "))
+	}
+	for i := range configFindings {
+		f := &configFindings[i]
+		addRecord(f)
+		p.Println()
+		p.Printf("  %s %s\n", p.Formatter().FormatRiskLevelColored(f.Severity), f.Title)
+		p.Printf("      %s\n", p.Colored(config.ColorGray, f.Detail))
+	}
+
+	if len(records) > 0 {
+		if _, err := sess.FindingDB.SaveBatch(records); err != nil {
+			p.Warning(i18n.Tf("保存 API Server 检查结果失败: %v", err))
+		}
+	}
+
+	p.Println()
+	p.Printf("%s %d finding(s) for %s\n", p.Colored(config.ColorGreen, "[+]"), len(records), base.Host)
+	if source == "" {
+		p.Printf("%s Admission plugins not discoverable (kube-apiserver pod spec and /metrics unreadable)\n",
+			p.Colored(config.ColorYellow, "[!]"))
+	}
+	return nil
+}
+
+// resolveTarget 解析 API Server 地址，未指定时使用会话设置的地址
+func (c *APIServerCmd) resolveTarget(sess *session.Session, target string) (*url.URL, error) {
+	if target == "" {
+		target = sess.Config.APIServer
+	}
+	if target == "" {
+		return nil, i18n.Errorf("未设置 API Server，请指定 target 或使用 'set api-server <addr>' 设置")
+	}
+	if !strings.Contains(target, "://") {
+		target = "https://" + target
+	}
+	u, err := url.Parse(target)
+	if err != nil || u.Hostname() == "" {
+		return nil, i18n.Errorf("无效的 API Server 地址: %s", target)
+	}
+	u.Path, u.RawQuery = "", ""
+	return u, nil
+}
+
+// auditConfig 读取 kube-apiserver 启动参数审计配置和准入插件，读不到时从 /metrics 推断准入插件
+// useToken 为 false（指定了其他 target）时不使用会话凭据，只能依赖匿名读取的 metrics
+func (c *APIServerCmd) auditConfig(sess *session.Session, useToken bool, metrics string) ([]security.APIServerFinding, []string, string) {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	var command []string
+	source := ""
+	if useToken {
+		if k8s, err := sess.GetK8sClient(sess.GetActiveToken()); err == nil {
+			if raw, err := k8s.ListPodsRaw(ctx, "kube-system", "component=kube-apiserver"); err == nil {
+				command = security.FindComponentCommand(raw, "kube-apiserver")
+				source = "kube-apiserver pod spec via apiserver"
+			}
+			if command == nil && metrics == "" {
+				if data, err := k8s.GetMetrics(ctx); err == nil {
+					metrics = string(data)
+				}
+			}
+		}
+	}
+	if command == nil && sess.Config.KubeletIP != "" {
+		if kubelet, err := sess.GetKubeletClient(); err == nil {
+			if raw, err := kubelet.GetPodsRaw(ctx); err == nil {
+				command = security.FindComponentCommand(raw, "kube-apiserver")
+				source = "kube-apiserver pod spec via kubelet"
+			}
+		}
+	}
+
+	if command != nil {
+		p.Printf("%s Auditing kube-apiserver flags (%s)\n", p.Colored(config.ColorBlue, "[*]"), source)
+		flags := security.ParseComponentFlags(command)
+		plugins := security.EffectiveAdmissionPlugins(flags)
+		findings := append(security.AuditAPIServerFlags(flags), security.AuditAdmissionPlugins(plugins, false)...)
+		return findings, plugins, source
+	}
+	if plugins := security.AdmissionPluginsFromMetrics(metrics); len(plugins) > 0 {
+		source = "observed in /metrics, may be incomplete"
+		return security.AuditAdmissionPlugins(plugins, true), plugins, source
+	}
+	return nil, nil, ""
+}
+
+// fetch 不带凭据发送 GET 请求，返回状态码和响应体
+func (c *APIServerCmd) fetch(httpClient *http.Client, rawURL string) (int, []byte, error) {
+	resp, err := httpClient.Get(rawURL)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// formatStatus 格式化探测状态：HTTP 状态码或连接失败
+func (c *APIServerCmd) formatStatus(status int, err error) string {
+	if status != 0 {
+		return strconv.Itoa(status)
+	}
+	if err != nil {
+		return "unreachable"
+	}
+	return "-"
+}
@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/client"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// CISCmd cis 命令
+type CISCmd struct{}
+
+func init() {
+	Register(&CISCmd{})
+}
+
+func (c *CISCmd) Name() string {
+	return "cis"
+}
+
+func (c *CISCmd) Aliases() []string {
+	return nil
+}
+
+func (c *CISCmd) Description() string {
+	return "按 CIS Kubernetes Benchmark 检查 Kubelet"
+}
+
+func (c *CISCmd) Usage() string {
+	return `cis [kubelet]
+
+按 ` + security.CISBenchmark + ` 第 4.2 节检查当前 Kubelet，逐项输出 PASS / FAIL / WARN：
+  4.2.1   --anonymous-auth 设置为 false
+  4.2.2   --authorization-mode 不为 AlwaysAllow
+  4.2.3   --client-ca-file 已设置
+  4.2.4   --read-only-port 设置为 0
+  4.2.5   --streaming-connection-idle-timeout 不为 0
+  4.2.6   --protect-kernel-defaults 设置为 true
+  4.2.7   --make-iptables-util-chains 设置为 true
+  4.2.10  --tls-cert-file 和 --tls-private-key-file 已设置
+  4.2.11  --rotate-certificates 不为 false
+  4.2.12  RotateKubeletServerCertificate 已启用
+  4.2.13  只使用强加密套件
+
+配置从 /configz 读取（需要 nodes/proxy 权限或匿名访问），同时从当前位置实际探测：
+不带凭据请求 /pods（4.2.1、4.2.2）、只读端口（4.2.4）和服务端证书（4.2.10、4.2.12）
+探测结果优先于配置；两者都无法确定的项为 WARN
+4.2.8 hostname-override、4.2.9 event-qps 只能在节点上确认，不在检查范围内
+
+未通过的项写入数据库（来源 cis，hunt list 查看）
+
+示例：
+  cis
+  cis kubelet`
+}
+
+// Suggestions cis 的章节补全
+func (c *CISCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return subcommandSuggestions(args,
+		completion.Suggestion{Text: "kubelet", Description: "第 4.2 节 Kubelet"},
+	)
+}
+
+func (c *CISCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	if len(args) > 1 || (len(args) == 1 && args[0] != "kubelet") {
+		return i18n.Errorf("用法: cis [kubelet]")
+	}
+
+	kubelet, err := sess.GetKubeletClient()
+	if err != nil {
+		return err
+	}
+	ctx := sess.Context()
+	target := net.JoinHostPort(sess.Config.KubeletIP, strconv.Itoa(sess.Config.KubeletPort))
+
+	p.Printf("%s Checking kubelet %s against %s\n", p.Colored(config.ColorBlue, "[*]"), target, security.CISBenchmark)
+	cfg, err := kubelet.GetConfigz(ctx)
+	if err != nil {
+		p.Printf("%s /configz unavailable (%v), falling back to probes\n", p.Colored(config.ColorYellow, "[!]"), err)
+		cfg = nil
+	}
+
+	vantage := c.probe(sess, cfg)
+	if chain, err := kubelet.GetServingCertificates(ctx); err == nil {
+		vantage.ServingCert = chain[0]
+	}
+
+	checks := security.CISKubeletBenchmark(cfg, vantage)
+	counts := make(map[string]int)
+	var rows [][]string
+	for _, check := range checks {
+		counts[check.Result]++
+		rows = append(rows, []string{check.ID, c.formatResult(p, check.Result), check.Title, p.Colored(config.ColorGray, check.Evidence)})
+	}
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"ID", "RESULT", "CHECK", "EVIDENCE"}, rows)
+
+	c.save(sess, checks)
+
+	p.Println()
+	p.Printf("%s %s: %s pass, %s fail, %s warn\n",
+		p.Colored(config.ColorGreen, "[+]"), target,
+		p.Colored(config.ColorGreen, strconv.Itoa(counts[security.CISPass])),
+		p.Colored(config.ColorRed, strconv.Itoa(counts[security.CISFail])),
+		p.Colored(config.ColorYellow, strconv.Itoa(counts[security.CISWarn])))
+	return nil
+}
+
+// probe 不带凭据请求 Kubelet /pods 和只读端口 /healthz
+func (c *CISCmd) probe(sess *session.Session, cfg *types.KubeletConfiguration) security.KubeletVantage {
+	vantage := security.KubeletVantage{ReadOnlyPort: config.DefaultKubeletReadOnlyPort}
+	if cfg != nil && cfg.ReadOnlyPort > 0 {
+		vantage.ReadOnlyPort = cfg.ReadOnlyPort
+	}
+
+	// 匿名请求不带会话中的客户端证书
+	clientCfg := *sess.GetClientConfig()
+	clientCfg.ClientCertPEM, clientCfg.ClientKeyPEM = nil, nil
+	clientCfg.ImpersonateUser, clientCfg.ImpersonateGroups = "", nil
+	clientCfg.Timeout = config.DefaultProbeTimeout
+	clientCfg.MaxRetries = 0 // 探测以连接失败为结果，不重试
+	httpClient, err := client.NewHTTPClient(&clientCfg)
+	if err != nil {
+		return vantage
+	}
+
+	ip := sess.Config.KubeletIP
+	if resp, err := httpClient.Get("https://" + net.JoinHostPort(ip, strconv.Itoa(sess.Config.KubeletPort)) + "/pods"); err == nil {
+		_ = resp.Body.Close()
+		vantage.AnonymousStatus = resp.StatusCode
+	}
+
+	open := false
+	if resp, err := httpClient.Get("http://" + net.JoinHostPort(ip, strconv.Itoa(vantage.ReadOnlyPort)) + "/healthz"); err == nil {
+		_ = resp.Body.Close()
+		open = resp.StatusCode == http.StatusOK
+	}
+	vantage.ReadOnlyOpen = &open
+	return vantage
+}
+
+// formatResult 着色检查结果
+func (c *CISCmd) formatResult(p output.Printer, result string) string {
+	switch result {
+	case security.CISPass:
+		return p.Colored(config.ColorGreen, result)
+	case security.CISFail:
+		return p.Colored(config.ColorRed, result)
+	default:
+		return p.Colored(config.ColorYellow, result)
+	}
+}
+
+// save 保存未通过的检查项（来源 cis，位置为 基准编号@节点IP）
+func (c *CISCmd) save(sess *session.Session, checks []security.CISCheck) {
+	now := time.Now()
+	var records []*types.FindingRecord
+	for _, check := range checks {
+		if check.Result != security.CISFail {
+			continue
+		}
+		records = append(records, &types.FindingRecord{
+			Source:      "cis",
+			Severity:    string(check.Severity),
+			Category:    "kubelet",
+			Location:    check.ID + "@" + sess.Config.KubeletIP,
+			Title:       "CIS " + check.ID + " " + check.Title,
+			Evidence:    check.Evidence,
+			CollectedAt: now,
+			KubeletIP:   sess.Config.KubeletIP,
+		})
+	}
+	if len(records) == 0 {
+		return
+	}
+	if _, err := sess.FindingDB.SaveBatch(records); err != nil {
+		sess.Printer.Warning(i18n.Tf("保存 CIS 检查结果失败: %v", err))
+	}
+}
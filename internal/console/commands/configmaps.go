@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// ConfigMapsCmd configmaps 命令
+type ConfigMapsCmd struct{}
+
+func init() {
+	Register(&ConfigMapsCmd{})
+}
+
+func (c *ConfigMapsCmd) Name() string {
+	return "configmaps"
+}
+
+func (c *ConfigMapsCmd) Aliases() []string {
+	return []string{"cm"}
+}
+
+func (c *ConfigMapsCmd) Description() string {
+	return "列出 ConfigMap 并搜索其中的凭据"
+}
+
+// IsPaged configmaps 输出 ConfigMap 表格
+func (c *ConfigMapsCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *ConfigMapsCmd) Usage() string {
+	return `configmaps [options]
+
+通过 API Server 列出 ConfigMap（需要 configmaps list 权限，使用当前 SA 的 Token）
+
+选项：
+  -n <namespace>      指定命名空间（默认所有命名空间）
+  --grep-creds        用凭据规则扫描 ConfigMap 内容（密码、Token、连接字符串等），
+                      命中结果保存到 findings 表（hunt list 查看）
+  --reveal            显示完整证据（默认遮盖）
+
+示例：
+  configmaps
+  configmaps -n kube-system
+  configmaps --grep-creds
+  cm --grep-creds -n default --reveal`
+}
+
+// Flags configmaps 的选项补全
+func (c *ConfigMapsCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		flagNamespace,
+		{Name: "--grep-creds", Description: "搜索凭据"},
+		{Name: "--reveal", Description: "显示完整证据"},
+	}
+}
+
+func (c *ConfigMapsCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	namespace := ""
+	grepCreds, reveal := false, false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 < len(args) {
+				namespace = args[i+1]
+				i++
+			}
+		case "--grep-creds", "--grep":
+			grepCreds = true
+		case "--reveal":
+			reveal = true
+		}
+	}
+
+	if sess.Config.APIServer == "" {
+		return i18n.Errorf("未设置 API Server，请使用 'set api-server <addr>' 设置")
+	}
+
+	k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+	if err != nil {
+		return err
+	}
+
+	scope := "all namespaces"
+	if namespace != "" {
+		scope = "namespace " + namespace
+	}
+	p.Printf("%s Listing configmaps in %s...\n", p.Colored(config.ColorBlue, "[*]"), scope)
+
+	cms, err := k8s.ListConfigMaps(ctx, namespace)
+	if err != nil {
+		return i18n.Errorf("获取 ConfigMap 失败: %w", err)
+	}
+
+	sort.Slice(cms, func(i, j int) bool {
+		if cms[i].Namespace != cms[j].Namespace {
+			return cms[i].Namespace < cms[j].Namespace
+		}
+		return cms[i].Name < cms[j].Name
+	})
+
+	if !grepCreds {
+		var rows [][]string
+		for _, cm := range cms {
+			rows = append(rows, []string{cm.Namespace, cm.Name, fmt.Sprintf("%d", len(cm.Data)), c.keysSummary(cm.Data)})
+		}
+		p.Println()
+		output.NewTablePrinter().PrintSimple([]string{"NAMESPACE", "NAME", "KEYS", "DATA"}, rows)
+		p.Println()
+		p.Printf("%s Found %d configmaps\n", p.Colored(config.ColorGreen, "[+]"), len(cms))
+		return nil
+	}
+
+	findings := c.grepCredentials(sess, cms)
+	if _, err := sess.FindingDB.SaveBatch(findings); err != nil {
+		return i18n.Errorf("保存 findings 失败: %w", err)
+	}
+
+	if len(findings) > 0 {
+		var rows [][]string
+		for _, f := range findings {
+			evidence := f.Evidence
+			if !reveal {
+				evidence = security.MaskSecret(evidence)
+			}
+			rows = append(rows, []string{
+				p.Formatter().FormatRiskLevelColored(config.RiskLevel(f.Severity)),
+				f.Namespace + "/" + f.Pod,
+				f.Location,
+				f.Title,
+				evidence,
+			})
+		}
+		p.Println()
+		output.NewTablePrinter().PrintSimple([]string{"SEVERITY", "CONFIGMAP", "KEY", "TITLE", "EVIDENCE"}, rows)
+	}
+
+	p.Println()
+	p.Printf("%s %d credential hits in %d configmaps\n",
+		p.Colored(config.ColorGreen, "[+]"),
+		len(findings), len(cms))
+	if len(findings) > 0 {
+		p.Info(i18n.T("结果已保存到 findings 表，使用 'hunt list' 查看"))
+	}
+
+	return nil
+}
+
+// grepCredentials 扫描 ConfigMap 内容中的凭据
+func (c *ConfigMapsCmd) grepCredentials(sess *session.Session, cms []types.ConfigMapInfo) []*types.FindingRecord {
+	var findings []*types.FindingRecord
+	now := time.Now()
+
+	for _, cm := range cms {
+		keys := make([]string, 0, len(cm.Data))
+		for k := range cm.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			for _, hit := range security.GrepCredentials(key, cm.Data[key]) {
+				location := key
+				if strings.Contains(strings.TrimSpace(cm.Data[key]), "\n") {
+					location = fmt.Sprintf("%s:%d", key, hit.Line)
+				}
+				findings = append(findings, &types.FindingRecord{
+					Source:      "configmaps",
+					Severity:    string(hit.Severity),
+					Category:    "configmap",
+					Namespace:   cm.Namespace,
+					Pod:         cm.Name,
+					Location:    location,
+					Title:       hit.Title,
+					Evidence:    hit.Evidence,
+					CollectedAt: now,
+					KubeletIP:   sess.Config.KubeletIP,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return config.RiskLevelOrder[config.RiskLevel(findings[i].Severity)] <
+			config.RiskLevelOrder[config.RiskLevel(findings[j].Severity)]
+	})
+	return findings
+}
+
+// keysSummary 显示 ConfigMap 键名摘要
+func (c *ConfigMapsCmd) keysSummary(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if len(keys) > 3 {
+		return strings.Join(keys[:3], ",") + ",..."
+	}
+	if len(keys) == 0 {
+		return "-"
+	}
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"strconv"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// ConfigzCmd configz 命令
+type ConfigzCmd struct{}
+
+func init() {
+	Register(&ConfigzCmd{})
+}
+
+func (c *ConfigzCmd) Name() string {
+	return "configz"
+}
+
+func (c *ConfigzCmd) Aliases() []string {
+	return nil
+}
+
+func (c *ConfigzCmd) Description() string {
+	return "获取并审计 Kubelet 配置"
+}
+
+func (c *ConfigzCmd) Usage() string {
+	return `configz
+
+获取当前 Kubelet 的 /configz 并检查不安全的配置：
+  - anonymous-auth 已启用（与 AlwaysAllow 同时启用时可匿名执行命令）
+  - authorization-mode 为 AlwaysAllow
+  - Webhook 认证已禁用
+  - 只读端口 (10255) 已启用
+  - 未配置客户端 CA、未启用 protectKernelDefaults / 证书轮换
+
+发现写入数据库（来源 configz），可用 hunt list 查看
+访问 /configz 需要 nodes/proxy 权限或匿名访问
+
+示例：
+  configz`
+}
+
+func (c *ConfigzCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	if len(args) > 0 {
+		return i18n.Errorf("用法: configz")
+	}
+
+	kubelet, err := sess.GetKubeletClient()
+	if err != nil {
+		return err
+	}
+	cfg, err := kubelet.GetConfigz(sess.Context())
+	if err != nil {
+		return i18n.Errorf("获取 /configz 失败: %w", err)
+	}
+
+	p.Printf("%s Kubelet config (%s:%d):\n",
+		p.Colored(config.ColorBlue, "[*]"), sess.Config.KubeletIP, sess.Config.KubeletPort)
+	p.Printf("    %-22s: %s\n", "anonymous-auth", formatOptionalBool(cfg.Authentication.Anonymous.Enabled))
+	p.Printf("    %-22s: %s\n", "authentication-webhook", formatOptionalBool(cfg.Authentication.Webhook.Enabled))
+	p.Printf("    %-22s: %s\n", "authorization-mode", orDash(cfg.Authorization.Mode))
+	p.Printf("    %-22s: %s\n", "read-only-port", strconv.Itoa(cfg.ReadOnlyPort))
+	p.Printf("    %-22s: %s\n", "client-ca-file", orDash(cfg.Authentication.X509.ClientCAFile))
+	p.Printf("    %-22s: %s\n", "static-pod-path", orDash(cfg.StaticPodPath))
+
+	findings := security.AuditKubeletConfig(cfg)
+	p.Println()
+	if len(findings) == 0 {
+		p.Printf("%s No insecure kubelet settings found\n", p.Colored(config.ColorGreen, "[+]"))
+		return nil
+	}
+	for _, f := range findings {
+		p.Printf("    %s %s\n", p.Formatter().FormatRiskLevelColored(f.Severity), f.Title)
+		p.Printf("      %s\n", p.Colored(config.ColorGray, f.Detail))
+	}
+	c.save(sess, findings)
+
+	p.Println()
+	p.Printf("%s %d insecure setting(s) found\n", p.Colored(config.ColorYellow, "[!]"), len(findings))
+	return nil
+}
+
+// save 保存配置审计结果（来源 configz，位置为 检查标识@节点IP，多个节点的结果互不覆盖）
+func (c *ConfigzCmd) save(sess *session.Session, findings []security.KubeletConfigFinding) {
+	now := time.Now()
+	var records []*types.FindingRecord
+	for _, f := range findings {
+		records = append(records, &types.FindingRecord{
+			Source:      "configz",
+			Severity:    string(f.Severity),
+			Category:    "kubelet",
+			Location:    f.ID + "@" + sess.Config.KubeletIP,
+			Title:       f.Title,
+			Evidence:    f.Detail,
+			CollectedAt: now,
+			KubeletIP:   sess.Config.KubeletIP,
+		})
+	}
+	if _, err := sess.FindingDB.SaveBatch(records); err != nil {
+		sess.Printer.Warning(i18n.Tf("保存配置审计结果失败: %v", err))
+	}
+}
+
+// formatOptionalBool 格式化可能缺失的布尔配置
+func formatOptionalBool(v *bool) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatBool(*v)
+}
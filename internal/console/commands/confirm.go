@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+
+	"kctl/config"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// YesFlag 跳过破坏性或高噪声操作前的确认提示
+const YesFlag = "--yes"
+
+// confirmMaxNamespaces 确认摘要中最多列出的命名空间数
+const confirmMaxNamespaces = 8
+
+// confirmAction 在交互式控制台中执行破坏性或高噪声操作前输出影响范围并询问是否继续
+// 指定 --yes 或在脚本、-x、--bridge 等非交互方式下执行时不询问，直接继续
+func confirmAction(sess *session.Session, yes bool, summary string, details ...string) bool {
+	if yes || !sess.Interactive || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return true
+	}
+
+	p := sess.Printer
+	p.Printf("%s %s\n", p.Colored(config.ColorYellow, "[!]"), summary)
+	for _, d := range details {
+		p.Printf("    %s\n", p.Colored(config.ColorGray, d))
+	}
+	p.Printf("%s Continue? [y/N] ", p.Colored(config.ColorYellow, "[?]"))
+	key := readKey(int(os.Stdin.Fd()))
+	if key == 'y' || key == 'Y' {
+		p.Println("y")
+		return true
+	}
+	p.Println()
+	p.Printf("%s Cancelled (add %s to skip this prompt)\n", p.Colored(config.ColorBlue, "[*]"), YesFlag)
+	return false
+}
+
+// readKey 读取单个按键，读取失败时返回 0
+// 控制台执行命令期间 go-prompt 未恢复行缓冲模式，按行读取时 Enter 无法结束输入，因此只读一个字节
+func readKey(fd int) byte {
+	if state, err := term.MakeRaw(fd); err == nil {
+		defer func() { _ = term.Restore(fd, state) }()
+	}
+	buf := make([]byte, 1)
+	if _, err := os.Stdin.Read(buf); err != nil {
+		return 0
+	}
+	return buf[0]
+}
+
+// podBlastRadius 描述批量操作涉及的 Pod：摘要为 Pod、命名空间和节点数，详情为各命名空间的 Pod 数
+func podBlastRadius(pods []types.PodContainerInfo) (string, []string) {
+	perNs := make(map[string]int)
+	nodes := make(map[string]bool)
+	for _, pod := range pods {
+		perNs[pod.Namespace]++
+		if pod.NodeName != "" {
+			nodes[pod.NodeName] = true
+		}
+	}
+	namespaces := make([]string, 0, len(perNs))
+	for ns := range perNs {
+		namespaces = append(namespaces, ns)
+	}
+	// Pod 多的命名空间在前
+	sort.Slice(namespaces, func(i, j int) bool {
+		if perNs[namespaces[i]] != perNs[namespaces[j]] {
+			return perNs[namespaces[i]] > perNs[namespaces[j]]
+		}
+		return namespaces[i] < namespaces[j]
+	})
+
+	summary := fmt.Sprintf("%d pod(s) in %d namespace(s)", len(pods), len(namespaces))
+	if len(nodes) > 0 {
+		summary += fmt.Sprintf(" on %d node(s)", len(nodes))
+	}
+	var parts []string
+	for i, ns := range namespaces {
+		if i == confirmMaxNamespaces {
+			parts = append(parts, fmt.Sprintf("+%d more", len(namespaces)-i))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d)", ns, perNs[ns]))
+	}
+	return summary, []string{"namespaces: " + strings.Join(parts, ", ")}
+}
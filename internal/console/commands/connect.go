@@ -1,10 +1,8 @@
 package commands
 
 import (
-	"context"
-	"fmt"
-
 	"kctl/config"
+	"kctl/internal/i18n"
 	"kctl/internal/session"
 )
 
@@ -46,7 +44,7 @@ func (c *ConnectCmd) Usage() string {
 
 func (c *ConnectCmd) Execute(sess *session.Session, args []string) error {
 	p := sess.Printer
-	ctx := context.Background()
+	ctx := sess.Context()
 
 	// 如果提供了 IP 参数，自动设置 target
 	if len(args) > 0 {
@@ -58,11 +56,11 @@ func (c *ConnectCmd) Execute(sess *session.Session, args []string) error {
 
 	// 检查配置
 	if sess.Config.KubeletIP == "" {
-		return fmt.Errorf("未设置 Kubelet IP，请使用 'set target <ip>' 设置或 'connect <ip>'")
+		return i18n.Errorf("未设置 Kubelet IP，请使用 'set target <ip>' 设置或 'connect <ip>'")
 	}
 
-	if sess.Config.Token == "" {
-		return fmt.Errorf("未设置 Token，请使用 'set token <token>' 或 'set token-file <path>' 设置")
+	if !sess.HasCredentials() {
+		return i18n.Errorf("未设置 Token 或客户端证书，请使用 'set token <token>'、'set token-file <path>' 或 'set client-cert <path>' 设置")
 	}
 
 	p.Printf("%s Connecting to Kubelet %s:%d...\n",
@@ -73,18 +71,26 @@ func (c *ConnectCmd) Execute(sess *session.Session, args []string) error {
 	// 使用懒加载的 GetKubeletClient（会自动连接）
 	kubelet, err := sess.GetKubeletClient()
 	if err != nil {
-		return fmt.Errorf("连接失败: %w", err)
+		return i18n.Errorf("连接失败: %w", err)
 	}
 
 	// 验证连接
 	result, err := kubelet.ValidatePort(ctx)
 	if err != nil {
-		p.Warning("连接成功，但无法验证 Kubelet 端口")
+		p.Warning(i18n.T("连接成功，但无法验证 Kubelet 端口"))
 	} else if result.IsKubelet {
 		p.Success("Connected successfully")
+	} else if !result.Reachable {
+		p.Warning(i18n.Tf("无法连接 Kubelet: %v", result.Error))
 	} else {
-		p.Warning("连接成功，但目标可能不是 Kubelet")
+		p.Warning(i18n.T("连接成功，但目标可能不是 Kubelet"))
 	}
 
+	// 版本识别与已知 CVE 匹配
+	FingerprintKubelet(ctx, sess)
+
+	// 服务端证书审计
+	AuditKubeletCert(ctx, sess)
+
 	return nil
 }
@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// CredsCmd creds 命令
+type CredsCmd struct{}
+
+func init() {
+	Register(&CredsCmd{})
+}
+
+func (c *CredsCmd) Name() string {
+	return "creds"
+}
+
+func (c *CredsCmd) Aliases() []string {
+	return []string{"credentials"}
+}
+
+func (c *CredsCmd) Description() string {
+	return "管理收集到的节点凭据"
+}
+
+// IsReadOnly list / show 只读取数据库
+func (c *CredsCmd) IsReadOnly(args []string) bool {
+	return len(args) == 0 || args[0] == "list" || args[0] == "ls" || args[0] == "show"
+}
+
+// IsPaged creds 输出凭据表格
+func (c *CredsCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *CredsCmd) Usage() string {
+	return `creds [list|show|use|delete] [id]
+
+管理 harvest 收集到的凭据（kubeconfig Token、客户端证书）
+
+子命令：
+  list            列出凭据（默认）
+  show <id>       显示凭据内容（Token / 证书和私钥 PEM）
+  use <id>        使用凭据作为当前身份（Token 或客户端证书），未设置 api-server 时同时使用凭据中的地址
+  delete <id>     删除凭据（all 删除全部）
+
+示例：
+  harvest node-creds
+  creds
+  creds use 3
+  creds show 3
+  creds delete all`
+}
+
+// Suggestions creds 的子命令及凭据 ID 补全
+func (c *CredsCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) == 0 {
+		return []completion.Suggestion{
+			{Text: "list", Description: "列出凭据"},
+			{Text: "show", Description: "显示凭据内容"},
+			{Text: "use", Description: "使用凭据作为当前身份"},
+			{Text: "delete", Description: "删除凭据"},
+		}
+	}
+	if len(args) != 1 || args[0] == "list" || args[0] == "ls" {
+		return nil
+	}
+	records, err := sess.CredDB.GetAll()
+	if err != nil {
+		return nil
+	}
+	var suggestions []completion.Suggestion
+	for _, r := range records {
+		suggestions = append(suggestions, completion.Suggestion{
+			Text:        strconv.FormatInt(r.ID, 10),
+			Description: r.Kind + " " + r.Identity,
+		})
+	}
+	if args[0] == "delete" || args[0] == "rm" {
+		suggestions = append(suggestions, completion.Suggestion{Text: "all", Description: "删除全部凭据"})
+	}
+	return suggestions
+}
+
+func (c *CredsCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) == 0 {
+		return c.list(sess)
+	}
+
+	sub := args[0]
+	switch sub {
+	case "list", "ls":
+		return c.list(sess)
+	case "show", "use", "delete", "rm":
+	default:
+		return i18n.Errorf("未知子命令: %s (可用: list, show, use, delete)", sub)
+	}
+
+	if len(args) < 2 {
+		return i18n.Errorf("用法: creds %s <id>", sub)
+	}
+	if (sub == "delete" || sub == "rm") && args[1] == "all" {
+		if err := sess.CredDB.Clear(); err != nil {
+			return err
+		}
+		sess.Printer.Success("All credentials deleted")
+		return nil
+	}
+
+	id, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return i18n.Errorf("无效的凭据 ID: %s", args[1])
+	}
+	cred, err := sess.CredDB.Get(id)
+	if err != nil {
+		return err
+	}
+
+	switch sub {
+	case "show":
+		return c.show(sess, cred)
+	case "use":
+		return c.use(sess, cred)
+	default:
+		if err := sess.CredDB.Delete(cred.ID); err != nil {
+			return err
+		}
+		sess.Printer.Success(fmt.Sprintf("Deleted credential %d (%s)", cred.ID, cred.Identity))
+		return nil
+	}
+}
+
+func (c *CredsCmd) list(sess *session.Session) error {
+	p := sess.Printer
+	records, err := sess.CredDB.GetAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		p.Info(i18n.T("没有收集到的凭据，使用 'harvest node-creds' 收集"))
+		return nil
+	}
+
+	tf := sess.TimeFormatter(false)
+	var rows [][]string
+	for _, r := range records {
+		rows = append(rows, credentialRow(p, tf, r))
+	}
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"ID", "KIND", "IDENTITY", "GROUPS", "EXPIRES", "SOURCE"}, rows)
+	p.Println()
+	return nil
+}
+
+func (c *CredsCmd) show(sess *session.Session, cred *types.CredentialRecord) error {
+	p := sess.Printer
+	p.Printf("%s %s (%s)\n", p.Colored(config.ColorCyan, "Identity:"), cred.Identity, cred.Kind)
+	if cred.Groups != "" {
+		p.Printf("%s %s\n", p.Colored(config.ColorCyan, "Groups:"), cred.Groups)
+	}
+	if cred.Server != "" {
+		p.Printf("%s %s\n", p.Colored(config.ColorCyan, "Server:"), cred.Server)
+	}
+	p.Printf("%s %s\n", p.Colored(config.ColorCyan, "Source:"), cred.Source)
+	p.Println()
+	switch cred.Kind {
+	case types.CredentialToken:
+		p.Println(cred.Token)
+	case types.CredentialClientCert:
+		p.Print(cred.ClientCert)
+		p.Print(cred.ClientKey)
+	}
+	return nil
+}
+
+// use 将凭据设置为当前身份
+func (c *CredsCmd) use(sess *session.Session, cred *types.CredentialRecord) error {
+	p := sess.Printer
+
+	if sess.Config.APIServer == "" && cred.Server != "" {
+		if err := sess.SetAPIServerURL(cred.Server); err == nil {
+			p.Printf("%s API Server set to: %s:%d (from credential)\n",
+				p.Colored(config.ColorBlue, "[*]"), sess.Config.APIServer, sess.Config.APIServerPort)
+		}
+	}
+
+	// 凭据整体替换当前身份，避免 Token 与证书同时生效
+	switch cred.Kind {
+	case types.CredentialToken:
+		sess.Config.Token = cred.Token
+		sess.Config.TokenFile = ""
+		sess.ClearClientCert()
+	case types.CredentialClientCert:
+		sess.Config.Token = ""
+		sess.Config.TokenFile = ""
+		sess.SetClientCert(cred.ClientCert, cred.ClientKey, fmt.Sprintf("credential %d", cred.ID))
+	default:
+		return i18n.Errorf("未知的凭据类型: %s", cred.Kind)
+	}
+	p.Success(fmt.Sprintf("Using credential %d: %s", cred.ID, cred.Identity))
+	reconnect(sess, p, true)
+	return nil
+}
@@ -0,0 +1,419 @@
+package commands
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/client"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// DashboardsCmd dashboards 命令
+type DashboardsCmd struct{}
+
+func init() {
+	Register(&DashboardsCmd{})
+}
+
+func (c *DashboardsCmd) Name() string {
+	return "dashboards"
+}
+
+func (c *DashboardsCmd) Aliases() []string {
+	return []string{"ui"}
+}
+
+func (c *DashboardsCmd) Description() string {
+	return "发现集群内管理界面并测试匿名访问和默认口令"
+}
+
+func (c *DashboardsCmd) Usage() string {
+	return `dashboards [options]
+
+从 Service（API Server）和缓存的 Pod 镜像中识别 kubernetes-dashboard、Rancher、ArgoCD、Grafana、
+Kubeflow、Jenkins 等管理界面，逐个测试：
+  - 匿名访问：Dashboard skip 登录、ArgoCD / Grafana 匿名用户、Jenkins Script Console、Kubeflow 未启用认证
+  - 默认口令：Rancher admin/admin、Grafana admin/admin、ArgoCD admin/<argocd-server Pod 名称>
+默认从本机直接访问 ClusterIP / Pod IP（kctl 运行在集群网络内时），--from 时在 Pod 内通过 curl 访问
+
+可访问的管理界面作为横向移动目标写入数据库（来源 dashboards，hunt list 查看）
+注意：默认口令测试会在目标应用留下登录记录，安全模式 (set safe-mode on) 下跳过
+
+选项：
+  -n <namespace>      只检查指定命名空间
+  --from <pod>        在指定 Pod 内通过 curl 发起请求（默认使用当前 SA 的 Pod）
+  --no-auth           只识别和测试可达性，不测试匿名访问和默认口令
+
+示例：
+  dashboards
+  ui -n monitoring
+  ui --from default/nginx
+  dashboards --no-auth`
+}
+
+// Flags dashboards 的选项补全
+func (c *DashboardsCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		flagNamespace,
+		{Name: "--from", Arg: "<pod>", Description: "在指定 Pod 内通过 curl 发起请求"},
+		{Name: "--no-auth", Description: "不测试匿名访问和默认口令"},
+	}
+}
+
+// uiTarget 识别出的管理界面
+type uiTarget struct {
+	ui        *security.ManagementUI
+	level     config.RiskLevel
+	namespace string
+	name      string   // Service 或 Pod 名称
+	kind      string   // svc 或 pod
+	addrs     []string // host:port
+	pods      []string // 后端 Pod 名称
+	exposure  string   // NodePort / LoadBalancer 暴露方式
+}
+
+// uiRequester 发送管理界面检查请求
+type uiRequester func(method, url, contentType, body string) (int, string, error)
+
+func (c *DashboardsCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	namespace, from := "", ""
+	viaPod, noAuth := false, false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 < len(args) {
+				namespace = args[i+1]
+				i++
+			}
+		case "--from":
+			if i+1 >= len(args) {
+				return i18n.Errorf("--from 需要指定 Pod")
+			}
+			from = args[i+1]
+			viaPod = true
+			i++
+		case "--no-auth":
+			noAuth = true
+		default:
+			return i18n.Errorf("未知参数: %s", args[i])
+		}
+	}
+
+	// --from 通过 exec 发起请求，安全模式下拒绝
+	if viaPod {
+		if err := sess.CheckSafeMode("dashboards --from"); err != nil {
+			return err
+		}
+	}
+
+	targets := c.discover(sess, namespace)
+	if len(targets) == 0 {
+		p.Info("No management UIs found")
+		return nil
+	}
+	// 默认口令测试会登录目标应用，安全模式下跳过
+	safeMode := sess.IsSafeMode()
+	if safeMode && !noAuth {
+		p.Printf("%s Safe mode: skipping default credential login checks\n", p.Colored(config.ColorYellow, "[!]"))
+	}
+
+	request, via, err := c.requester(sess, viaPod, from)
+	if err != nil {
+		return err
+	}
+	p.Printf("%s Checking %d management UI(s) %s...\n", p.Colored(config.ColorBlue, "[*]"), len(targets), via)
+
+	now := time.Now()
+	var records []*types.FindingRecord
+	var rows [][]string
+	type hit struct {
+		url   string
+		check security.UICheck
+	}
+	var hits []hit
+	reachable := 0
+	for _, t := range targets {
+		baseURL := ""
+		for _, addr := range t.addrs {
+			if baseURL = c.probeScheme(request, addr); baseURL != "" {
+				break
+			}
+		}
+		status := p.Colored(config.ColorGray, "unreachable")
+		var found []security.UICheck
+		if baseURL != "" {
+			reachable++
+			status = p.Colored(config.ColorGreen, "reachable")
+			records = append(records, c.record(sess, t, now, "ui-reachable@"+baseURL, t.level,
+				t.ui.Label+" 管理界面可访问（横向移动目标）", c.reachableEvidence(t, baseURL)))
+
+			if !noAuth {
+				for _, check := range t.ui.Checks {
+					if safeMode && check.Method != http.MethodGet {
+						continue
+					}
+					if c.runCheck(request, baseURL, check, t.pods) {
+						found = append(found, check)
+						hits = append(hits, hit{url: baseURL, check: check})
+						records = append(records, c.record(sess, t, now, check.ID+"@"+baseURL, check.Severity, check.Title, check.Detail))
+					}
+				}
+			}
+		}
+
+		var ids []string
+		for _, check := range found {
+			ids = append(ids, p.Formatter().FormatRiskLevelColored(check.Severity)+" "+check.ID)
+		}
+		result := orDash(strings.Join(ids, ", "))
+		rows = append(rows, []string{
+			t.namespace,
+			t.kind + "/" + t.name,
+			p.Formatter().FormatRiskLevelColored(t.level) + " " + t.ui.Label,
+			orDash(strings.Join(t.addrs, ",")),
+			orDash(t.exposure),
+			status,
+			result,
+		})
+	}
+	if len(records) > 0 {
+		if _, err := sess.FindingDB.SaveBatch(records); err != nil {
+			p.Warning(i18n.Tf("保存管理界面检查结果失败: %v", err))
+		}
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"NAMESPACE", "TARGET", "UI", "ADDRESS", "EXPOSED", "STATUS", "WEAK AUTH"}, rows)
+	for _, h := range hits {
+		p.Println()
+		p.Printf("  %s %s %s\n", p.Formatter().FormatRiskLevelColored(h.check.Severity), h.check.Title, p.Colored(config.ColorGray, h.url+h.check.Path))
+		p.Printf("      %s\n", p.Colored(config.ColorGray, h.check.Detail))
+	}
+
+	p.Println()
+	p.Printf("%s %d management UI(s), %d reachable, %d weak auth finding(s)\n",
+		p.Colored(config.ColorGreen, "[+]"), len(targets), reachable, len(hits))
+	if reachable < len(targets) && !viaPod {
+		p.Printf("%s Unreachable from local host, retry with --from <pod> or use portforward\n", p.Colored(config.ColorYellow, "[!]"))
+	}
+	return nil
+}
+
+// discover 从 Service 和缓存的 Pod 镜像识别管理界面，已由 Service 覆盖的 Pod 不重复列出
+func (c *DashboardsCmd) discover(sess *session.Session, namespace string) []uiTarget {
+	p := sess.Printer
+	var targets []uiTarget
+	covered := make(map[string]bool)
+
+	if sess.Config.APIServer != "" {
+		services, err := (&ServicesCmd{}).listFromAPI(sess, namespace)
+		if err != nil {
+			p.Warning(i18n.Tf("API Server 获取 Service 失败，只从 Pod 缓存识别: %v", err))
+		}
+		for _, svc := range services {
+			target := security.ClassifyService(svc)
+			if target == nil {
+				continue
+			}
+			ui := security.FindManagementUI(target.Label)
+			if ui == nil {
+				continue
+			}
+			t := uiTarget{ui: ui, level: target.Level, namespace: svc.Namespace, name: svc.Name, kind: "svc"}
+			if svc.Type == "NodePort" || svc.Type == "LoadBalancer" {
+				t.exposure = svc.Type
+			}
+			for _, ep := range svc.Endpoints {
+				if ep.Pod != "" {
+					t.pods = append(t.pods, ep.Pod)
+					covered[svc.Namespace+"/"+ep.Pod] = true
+				}
+			}
+			for _, port := range svc.Ports {
+				if strings.EqualFold(port.Protocol, "UDP") {
+					continue
+				}
+				if svc.ClusterIP != "" && svc.ClusterIP != "None" {
+					t.addrs = append(t.addrs, net.JoinHostPort(svc.ClusterIP, strconv.Itoa(port.Port)))
+				} else if len(svc.Endpoints) > 0 {
+					if _, err := strconv.Atoi(port.TargetPort); err == nil {
+						t.addrs = append(t.addrs, net.JoinHostPort(svc.Endpoints[0].IP, port.TargetPort))
+					}
+				}
+			}
+			targets = append(targets, t)
+		}
+	}
+
+	for _, pod := range sess.GetCachedPods() {
+		if (namespace != "" && pod.Namespace != namespace) || pod.PodIP == "" || covered[pod.Namespace+"/"+pod.PodName] {
+			continue
+		}
+		for _, container := range pod.Containers {
+			ui := security.ManagementUIForImage(container.Image)
+			if ui == nil {
+				continue
+			}
+			t := uiTarget{ui: ui, level: c.level(ui), namespace: pod.Namespace, name: pod.PodName, kind: "pod", pods: []string{pod.PodName}}
+			for _, port := range ui.Ports {
+				t.addrs = append(t.addrs, net.JoinHostPort(pod.PodIP, strconv.Itoa(port)))
+			}
+			targets = append(targets, t)
+			break
+		}
+	}
+
+	sort.SliceStable(targets, func(i, j int) bool {
+		return config.RiskLevelOrder[targets[i].level] < config.RiskLevelOrder[targets[j].level]
+	})
+	return targets
+}
+
+// level 管理界面作为横向移动目标的价值，取 InterestingServices 中的等级
+func (c *DashboardsCmd) level(ui *security.ManagementUI) config.RiskLevel {
+	for _, svc := range config.InterestingServices {
+		if svc.Label == ui.Label {
+			return svc.Level
+		}
+	}
+	return config.RiskMedium
+}
+
+// requester 返回发送检查请求的函数：本机直接访问，或在 Pod 内执行 curl
+func (c *DashboardsCmd) requester(sess *session.Session, viaPod bool, from string) (uiRequester, string, error) {
+	if !viaPod {
+		cfg := *sess.GetClientConfig()
+		cfg.ClientCertPEM, cfg.ClientKeyPEM = nil, nil
+		cfg.Timeout = config.DefaultProbeTimeout
+		cfg.MaxRetries = 0 // 探测以连接失败为结果，不重试
+		httpClient, err := client.NewHTTPClient(&cfg)
+		if err != nil {
+			return nil, "", i18n.Errorf("创建 HTTP 客户端失败: %w", err)
+		}
+		// 登录接口以状态码判断结果，不跟随重定向
+		httpClient.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+		return func(method, url, contentType, body string) (int, string, error) {
+			req, err := http.NewRequestWithContext(sess.Context(), method, url, strings.NewReader(body))
+			if err != nil {
+				return 0, "", err
+			}
+			if contentType != "" {
+				req.Header.Set("Content-Type", contentType)
+			}
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				return 0, "", err
+			}
+			defer func() { _ = resp.Body.Close() }()
+			data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+			return resp.StatusCode, string(data), err
+		}, "from local", nil
+	}
+
+	ref, err := resolveWorkPod(sess, from)
+	if err != nil {
+		return nil, "", err
+	}
+	executor, err := sess.GetExecTransport()
+	if err != nil {
+		return nil, "", err
+	}
+	exec := func(script string) (*types.ExecResult, error) {
+		return executor.Exec(sess.Context(), &types.ExecOptions{
+			Namespace: ref.Namespace,
+			Pod:       ref.Pod,
+			Container: ref.Container,
+			Command:   []string{"sh", "-c", script},
+			Stdout:    true,
+			Stderr:    true,
+		})
+	}
+	result, err := exec("command -v curl")
+	if err != nil {
+		return nil, "", i18n.Errorf("探测 curl 失败: %w", err)
+	}
+	if strings.TrimSpace(result.Stdout) == "" {
+		return nil, "", i18n.Errorf("Pod %s/%s 中没有 curl", ref.Namespace, ref.Pod)
+	}
+	timeout := int(config.DefaultProbeTimeout / time.Second)
+	return func(method, url, contentType, body string) (int, string, error) {
+		result, err := exec(security.UICurlCommand(method, url, contentType, body, timeout))
+		if err != nil {
+			return 0, "", err
+		}
+		return security.ParseUICurl(result.Stdout)
+	}, "from " + ref.Namespace + "/" + ref.Pod, nil
+}
+
+// probeScheme 依次尝试 https / http 访问首页，返回可访问的基础 URL，都不可访问时返回空字符串
+func (c *DashboardsCmd) probeScheme(request uiRequester, addr string) string {
+	for _, scheme := range []string{"https", "http"} {
+		status, body, err := request("GET", scheme+"://"+addr+"/", "", "")
+		if err != nil || status == 0 {
+			continue
+		}
+		// 对 HTTPS 端口发送明文请求时 Go 服务端返回 400
+		if status == http.StatusBadRequest && strings.Contains(body, "HTTPS server") {
+			continue
+		}
+		return scheme + "://" + addr
+	}
+	return ""
+}
+
+// runCheck 执行一项检查，请求体包含 Pod 名称占位符时逐个尝试后端 Pod
+func (c *DashboardsCmd) runCheck(request uiRequester, baseURL string, check security.UICheck, pods []string) bool {
+	bodies := []string{check.Body}
+	if strings.Contains(check.Body, security.UIPodPlaceholder) {
+		bodies = nil
+		for _, pod := range pods {
+			bodies = append(bodies, strings.ReplaceAll(check.Body, security.UIPodPlaceholder, pod))
+		}
+	}
+	return slices.ContainsFunc(bodies, func(body string) bool {
+		status, resp, err := request(check.Method, baseURL+check.Path, check.ContentType, body)
+		return err == nil && check.Match(status, resp)
+	})
+}
+
+// record 生成管理界面发现
+func (c *DashboardsCmd) record(sess *session.Session, t uiTarget, now time.Time, location string, severity config.RiskLevel, title, evidence string) *types.FindingRecord {
+	return &types.FindingRecord{
+		Source:      "dashboards",
+		Severity:    string(severity),
+		Category:    "management-ui",
+		Namespace:   t.namespace,
+		Pod:         t.name,
+		Location:    location,
+		Title:       title,
+		Evidence:    evidence,
+		CollectedAt: now,
+		KubeletIP:   sess.Config.KubeletIP,
+	}
+}
+
+// reachableEvidence 可访问发现的证据：地址、暴露方式和后端 Pod
+func (c *DashboardsCmd) reachableEvidence(t uiTarget, baseURL string) string {
+	evidence := t.kind + "/" + t.name + " " + baseURL
+	if t.exposure != "" {
+		evidence += "，通过 " + t.exposure + " 对集群外暴露"
+	}
+	if len(t.pods) > 0 {
+		evidence += "，后端 Pod: " + strings.Join(t.pods, ",")
+	}
+	return evidence
+}
@@ -0,0 +1,303 @@
+package commands
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"kctl/config"
+	k8sclient "kctl/internal/client/k8s"
+	"kctl/internal/console/completion"
+	"kctl/internal/console/picker"
+	"kctl/internal/i18n"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// DebugCmd debug 命令
+type DebugCmd struct{}
+
+func init() {
+	Register(&DebugCmd{})
+}
+
+// 临时容器启动等待参数
+const (
+	debugDefaultImage = "busybox"
+	debugWaitTimeout  = 2 * time.Minute
+	debugPollInterval = time.Second
+)
+
+func (c *DebugCmd) Name() string {
+	return "debug"
+}
+
+func (c *DebugCmd) Aliases() []string {
+	return nil
+}
+
+func (c *DebugCmd) Description() string {
+	return "注入临时调试容器"
+}
+
+// IsDestructive debug 会修改目标 Pod 的 spec
+func (c *DebugCmd) IsDestructive(args []string) bool {
+	return true
+}
+
+func (c *DebugCmd) Usage() string {
+	return `debug <pod> [options] [-- <command>]
+
+通过 API Server 的 pods/ephemeralcontainers 子资源向 Pod 注入临时容器，
+并以交互方式连接（pods/attach），适用于镜像中没有 shell 的 Pod
+
+选项：
+  -n <namespace>      指定命名空间（默认从 Pod 缓存推断，否则为 default；也可写为 <namespace>/<pod>）
+  --image <image>     调试镜像（默认: busybox）
+                      多架构时可写为 amd64=<image>,arm64=<image>，按目标节点架构选择
+  --target <name>     共享进程命名空间的目标容器（默认: 第一个容器）
+  --name <name>       临时容器名称（默认: kctl-debug-xxxxx）
+  --no-attach         只注入，不连接
+  --confirm           当前 SA 为 cluster-admin 时必须指定
+
+需要权限：
+  pods/ephemeralcontainers patch, pods/attach create
+
+注意：
+  临时容器无法通过 API 删除，退出 shell 后容器终止，但会保留在 Pod spec 中，
+  直到 Pod 被删除重建
+
+示例：
+  debug distroless-app
+  debug -n prod api-7d9f --image nicolaka/netshoot
+  debug api-7d9f --target app -- sh -c 'cat /proc/1/environ'`
+}
+
+// Flags debug 的选项补全
+func (c *DebugCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		flagNamespace,
+		{Name: "--image", Arg: "<image>", Description: "调试镜像（默认: busybox）", Values: completion.Choices(
+			completion.Suggestion{Text: "busybox", Description: "默认调试镜像"},
+			completion.Suggestion{Text: "alpine", Description: "带 apk 的最小镜像"},
+			completion.Suggestion{Text: "nicolaka/netshoot", Description: "网络排查工具集"},
+		)},
+		{Name: "--target", Arg: "<name>", Description: "共享进程命名空间的目标容器", Values: completion.Containers},
+		{Name: "--name", Arg: "<name>", Description: "临时容器名称"},
+		{Name: "--no-attach", Description: "只注入，不连接"},
+		flagConfirm,
+		flagSeparator,
+	}
+}
+
+// Suggestions debug 的 Pod 补全
+func (c *DebugCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return podSuggestions(sess, c, args, completion.RunningPods)
+}
+
+func (c *DebugCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	opts := &types.EphemeralContainerOptions{Image: debugDefaultImage}
+	noAttach := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 < len(args) {
+				opts.Namespace = args[i+1]
+				i++
+			}
+		case "--image":
+			if i+1 < len(args) {
+				opts.Image = args[i+1]
+				i++
+			}
+		case "--target":
+			if i+1 < len(args) {
+				opts.TargetContainer = args[i+1]
+				i++
+			}
+		case "--name":
+			if i+1 < len(args) {
+				opts.Name = args[i+1]
+				i++
+			}
+		case "--no-attach":
+			noAttach = true
+		case "--":
+			opts.Command = args[i+1:]
+			i = len(args)
+		default:
+			if !strings.HasPrefix(args[i], "-") && opts.Pod == "" {
+				opts.Pod = args[i]
+			}
+		}
+	}
+
+	if opts.Pod == "" {
+		return i18n.Errorf("用法: debug <pod> [--image <image>]")
+	}
+	if err := c.resolveTarget(sess, opts); err != nil {
+		if errors.Is(err, picker.ErrCancelled) {
+			return nil
+		}
+		return err
+	}
+	if opts.Name == "" {
+		opts.Name = "kctl-debug-" + randomSuffix()
+	}
+	image, err := selectImage(ctx, sess, opts.Image, c.podNode(sess, opts.Namespace, opts.Pod))
+	if err != nil {
+		return err
+	}
+	opts.Image = image
+
+	k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+	if err != nil {
+		return err
+	}
+
+	// 权限预检查
+	if err := c.checkPermissions(sess, k8s, opts.Namespace); err != nil {
+		return err
+	}
+
+	p.Printf("%s Injecting ephemeral container %s (%s) into %s/%s...\n",
+		p.Colored(config.ColorBlue, "[*]"), opts.Name, opts.Image, opts.Namespace, opts.Pod)
+	if err := k8s.AddEphemeralContainer(ctx, opts); err != nil {
+		return i18n.Errorf("注入临时容器失败: %w", err)
+	}
+
+	if err := waitContainerRunning(sess, k8s, opts.Namespace, opts.Pod, opts.Name, opts.Image); err != nil {
+		return err
+	}
+	p.Success(fmt.Sprintf("Ephemeral container %s is running", opts.Name))
+
+	if !noAttach {
+		p.Info(i18n.T("连接中，若无提示符请按回车；输入 exit 退出"))
+		err = k8s.Attach(ctx, &types.ExecOptions{
+			Namespace: opts.Namespace,
+			Pod:       opts.Pod,
+			Container: opts.Name,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    false,
+			TTY:       true,
+		})
+		p.Println()
+		if err != nil {
+			return i18n.Errorf("连接临时容器失败: %w", err)
+		}
+	}
+
+	c.printCleanup(sess, opts)
+	return nil
+}
+
+// podNode 返回缓存中 Pod 所在节点，未缓存时为空
+func (c *DebugCmd) podNode(sess *session.Session, namespace, name string) string {
+	for _, pod := range sess.GetCachedPods() {
+		if pod.Namespace == namespace && pod.PodName == name {
+			return pod.NodeName
+		}
+	}
+	return ""
+}
+
+// resolveTarget 从 Pod 缓存推断命名空间和目标容器
+func (c *DebugCmd) resolveTarget(sess *session.Session, opts *types.EphemeralContainerOptions) error {
+	ref, err := resolvePod(sess, opts.Pod, opts.Namespace, opts.TargetContainer)
+	if err != nil {
+		return err
+	}
+	opts.Namespace, opts.Pod, opts.TargetContainer = ref.Namespace, ref.Pod, ref.Container
+	return nil
+}
+
+// checkPermissions 检查注入与连接所需权限，检查本身失败时只给出警告
+func (c *DebugCmd) checkPermissions(sess *session.Session, k8s k8sclient.Client, namespace string) error {
+	p := sess.Printer
+	required := []k8sclient.PermissionRequest{
+		{Resource: "pods", Subresource: "ephemeralcontainers", Verb: "patch", Namespace: namespace},
+		{Resource: "pods", Subresource: "attach", Verb: "create", Namespace: namespace},
+	}
+
+	var missing []string
+	for _, req := range required {
+		allowed, err := k8s.CheckPermission(sess.Context(), &req)
+		if err != nil {
+			p.Warning(i18n.Tf("权限预检查失败，继续尝试: %v", err))
+			return nil
+		}
+		if !allowed {
+			missing = append(missing, fmt.Sprintf("%s/%s %s", req.Resource, req.Subresource, req.Verb))
+		}
+	}
+	if len(missing) > 0 {
+		return i18n.Errorf("当前 Token 在 %s 中缺少权限: %s", namespace, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// waitContainerRunning 等待容器（含临时容器）进入 Running 状态，镜像拉取失败时立即返回
+func waitContainerRunning(sess *session.Session, k8s k8sclient.Client, namespace, pod, container, image string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+	deadline := time.Now().Add(debugWaitTimeout)
+	lastReason := ""
+
+	for {
+		status, err := k8s.GetContainerStatus(ctx, namespace, pod, container)
+		if err != nil {
+			return i18n.Errorf("获取容器状态失败: %w", err)
+		}
+		if status != nil {
+			switch status.State {
+			case "Running":
+				return nil
+			case "Terminated":
+				return i18n.Errorf("容器已退出: %s", status.Reason)
+			case "Waiting":
+				switch status.Reason {
+				case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+					return i18n.Errorf("拉取镜像 %s 失败: %s", image, status.Reason)
+				}
+				if status.Reason != "" && status.Reason != lastReason {
+					p.Printf("%s Waiting: %s\n", p.Colored(config.ColorBlue, "[*]"), status.Reason)
+					lastReason = status.Reason
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return i18n.Errorf("等待容器启动超时 (%s)", debugWaitTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(debugPollInterval):
+		}
+	}
+}
+
+// printCleanup 输出清理说明
+func (c *DebugCmd) printCleanup(sess *session.Session, opts *types.EphemeralContainerOptions) {
+	p := sess.Printer
+	p.Printf("%s Cleanup:\n", p.Colored(config.ColorYellow, "[!]"))
+	p.Printf("    - %s\n", i18n.T("临时容器无法通过 API 删除，退出主进程后即终止"))
+	p.Printf("    - %s\n", i18n.Tf("容器 %s 会保留在 Pod spec 中，删除/重建 Pod 后才会消失:", opts.Name))
+	p.Printf("      kubectl -n %s delete pod %s   %s\n", opts.Namespace, opts.Pod, i18n.T("(由控制器管理时会自动重建)"))
+}
+
+// randomSuffix 生成 5 位随机后缀
+func randomSuffix() string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)[:5]
+}
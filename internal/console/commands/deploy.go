@@ -0,0 +1,506 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"kctl/config"
+	k8sclient "kctl/internal/client/k8s"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/manifest"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// DeployCmd deploy 命令
+type DeployCmd struct{}
+
+func init() {
+	Register(&DeployCmd{})
+}
+
+// deployCustom 自定义清单模板名
+const deployCustom = "custom"
+
+func (c *DeployCmd) Name() string {
+	return "deploy"
+}
+
+func (c *DeployCmd) Aliases() []string {
+	return nil
+}
+
+func (c *DeployCmd) Description() string {
+	return "部署提权 Pod / DaemonSet"
+}
+
+// IsDestructive 除 list 和 --dry-run 外都会在集群中创建或删除资源
+func (c *DeployCmd) IsDestructive(args []string) bool {
+	if len(args) > 0 && (args[0] == "list" || args[0] == "ls") {
+		return false
+	}
+	for _, arg := range args {
+		if arg == "--dry-run" {
+			return false
+		}
+	}
+	return true
+}
+
+// IsReadOnly 只允许 deploy list
+func (c *DeployCmd) IsReadOnly(args []string) bool {
+	return len(args) > 0 && (args[0] == "list" || args[0] == "ls")
+}
+
+func (c *DeployCmd) Usage() string {
+	return `deploy <template> [options]
+deploy custom --file <manifest> [options]
+deploy list
+deploy --cleanup [name]
+
+在拥有 pods create（DaemonSet 需 daemonsets create）权限时部署用于逃逸的工作负载
+创建的资源及其存活相关设置记录在数据库中，使用 deploy --cleanup 删除
+部署后输出存活能力建议：节点压力驱逐、污点驱逐和 descheduler 下能否保留
+
+模板：
+  hostpath     特权 Pod，将节点根目录挂载到 /host
+  nsenter      特权 + hostPID Pod，nsenter 进入节点命名空间
+  node-shell   特权 DaemonSet，在每个节点上运行一个 Pod
+  custom       自定义 YAML/JSON 清单（支持 --- 多文档）
+
+选项：
+  -n <namespace>      命名空间（默认: 当前 SA 的命名空间，否则为 default）
+  --name <name>       资源名称（默认: kctl-<template>-xxxxx）
+  --image <image>     镜像（默认: ` + manifest.DefaultImage + `）
+                      多架构时可写为 amd64=<image>,arm64=<image>，按目标节点架构选择
+  --node <node>       调度到指定节点
+  --priority-class <name>  priorityClassName，如 system-node-critical（节点压力时最后被驱逐）
+  --tolerations <all|none> 容忍所有污点（默认: all）或不设置容忍
+  --restart-policy <policy> restartPolicy: Always (默认), OnFailure, Never
+  --file, -f <file>   自定义清单文件（custom）
+  --dry-run           只输出清单，不创建
+  --cleanup [name]    删除已记录的资源（不指定名称时删除全部）
+  --yes               不询问确认（交互式控制台中默认先显示将创建或删除的资源及影响范围）
+  --confirm           当前 SA 为 cluster-admin 时必须指定
+
+注意：
+  默认使用内存数据库，退出控制台后记录丢失；需跨会话清理时使用 'kctl console --db <file>'
+
+示例：
+  deploy hostpath --node worker-1
+  deploy nsenter -n kube-system --name metrics-agent
+  deploy nsenter -n kube-system --priority-class system-node-critical
+  deploy node-shell --dry-run
+  deploy hostpath --node worker-1 --image amd64=alpine,arm64=arm64v8/alpine
+  deploy custom -f payload.yaml
+  deploy --cleanup`
+}
+
+// Flags deploy 的选项补全
+func (c *DeployCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		flagNamespace,
+		{Name: "--name", Arg: "<name>", Description: "资源名称"},
+		{Name: "--image", Arg: "<image>", Description: "镜像（默认: " + manifest.DefaultImage + "）"},
+		{Name: "--node", Arg: "<node>", Description: "调度到指定节点", Values: completion.Nodes},
+		flagPriorityClass,
+		flagTolerations,
+		flagRestartPolicy,
+		{Name: "--file", Short: "-f", Arg: "<file>", Description: "自定义清单文件"},
+		{Name: "--dry-run", Description: "只输出清单"},
+		{Name: "--cleanup", Arg: "[name]", Description: "删除已部署的资源", Values: deployedResources},
+		flagYes,
+		flagConfirm,
+	}
+}
+
+// Suggestions deploy 的模板补全
+func (c *DeployCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) > 0 {
+		return nil
+	}
+	var suggestions []completion.Suggestion
+	for _, t := range manifest.Templates() {
+		suggestions = append(suggestions, completion.Suggestion{Text: t.Name, Description: t.Description})
+	}
+	return append(suggestions,
+		completion.Suggestion{Text: "custom", Description: "自定义清单 (--file)"},
+		completion.Suggestion{Text: "list", Description: "列出模板和已部署资源"},
+	)
+}
+
+// deployedResources 补全数据库中已部署的资源名
+func deployedResources(sess *session.Session, _ []string) []completion.Suggestion {
+	var suggestions []completion.Suggestion
+	if records, err := sess.DeployDB.GetAll(); err == nil {
+		for _, r := range records {
+			suggestions = append(suggestions, completion.Suggestion{Text: r.Resource.Name, Description: r.Resource.String()})
+		}
+	}
+	return suggestions
+}
+
+func (c *DeployCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) > 0 && (args[0] == "list" || args[0] == "ls") {
+		return c.list(sess)
+	}
+
+	template := ""
+	file := ""
+	dryRun := false
+	cleanup := false
+	cleanupName := ""
+	yes := false
+	opts := manifest.Options{Image: manifest.DefaultImage}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 < len(args) {
+				opts.Namespace = args[i+1]
+				i++
+			}
+		case "--name":
+			if i+1 < len(args) {
+				opts.Name = args[i+1]
+				i++
+			}
+		case "--image":
+			if i+1 < len(args) {
+				opts.Image = args[i+1]
+				i++
+			}
+		case "--node":
+			if i+1 < len(args) {
+				opts.Node = args[i+1]
+				i++
+			}
+		case "--priority-class":
+			if i+1 < len(args) {
+				opts.PriorityClass = args[i+1]
+				i++
+			}
+		case "--tolerations":
+			if i+1 < len(args) {
+				opts.Tolerations = args[i+1]
+				i++
+			}
+		case "--restart-policy":
+			if i+1 < len(args) {
+				opts.RestartPolicy = args[i+1]
+				i++
+			}
+		case "--file", "-f":
+			if i+1 < len(args) {
+				file = args[i+1]
+				i++
+			}
+		case "--dry-run":
+			dryRun = true
+		case YesFlag:
+			yes = true
+		case "--cleanup":
+			cleanup = true
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				cleanupName = args[i+1]
+				i++
+			}
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return i18n.Errorf("未知选项: %s", args[i])
+			}
+			template = args[i]
+		}
+	}
+
+	if cleanup {
+		return c.cleanup(sess, cleanupName, yes)
+	}
+
+	if file != "" && template == "" {
+		template = deployCustom
+	}
+	if template == "" {
+		return i18n.Errorf("用法: deploy <hostpath|nsenter|node-shell|custom>，输入 'deploy list' 查看模板")
+	}
+
+	if opts.Namespace == "" {
+		opts.Namespace = "default"
+		if sa := sess.GetCurrentSA(); sa != nil {
+			opts.Namespace = sa.Namespace
+		}
+	}
+
+	image, err := selectImage(sess.Context(), sess, opts.Image, opts.Node)
+	if err != nil {
+		return err
+	}
+	opts.Image = image
+
+	objects, next, err := c.build(template, file, opts)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		for i, obj := range objects {
+			data, err := obj.YAML()
+			if err != nil {
+				return i18n.Errorf("生成清单失败: %w", err)
+			}
+			if i > 0 {
+				sess.Printer.Println("---")
+			}
+			sess.Printer.Printf("%s", data)
+		}
+		return nil
+	}
+
+	summary, details := deployBlastRadius(sess, objects)
+	if !confirmAction(sess, yes, "About to create "+summary, details...) {
+		return nil
+	}
+	return c.deploy(sess, template, opts.Node, objects, next)
+}
+
+// deployBlastRadius 描述将创建的对象，并提示 DaemonSet、Webhook 和集群级资源的影响范围
+func deployBlastRadius(sess *session.Session, objects []manifest.Object) (string, []string) {
+	var namespaces []string
+	var details []string
+	for _, obj := range objects {
+		ref := obj.Ref()
+		detail := ref.String()
+		switch ref.Kind {
+		case "DaemonSet":
+			nodes := "every schedulable node"
+			if n := len(sess.GetCachedNodes()); n > 0 {
+				nodes = fmt.Sprintf("every schedulable node (%d known)", n)
+			}
+			detail += ": one privileged pod on " + nodes
+		case "MutatingWebhookConfiguration", "ValidatingWebhookConfiguration":
+			detail += ": intercepts matching API requests cluster-wide, a failing webhook can block them"
+		default:
+			if ref.Namespace == "" {
+				detail += ": cluster-scoped"
+			}
+		}
+		details = append(details, detail)
+		if ref.Namespace != "" && !slices.Contains(namespaces, ref.Namespace) {
+			namespaces = append(namespaces, ref.Namespace)
+		}
+	}
+
+	summary := fmt.Sprintf("%d resource(s)", len(objects))
+	if len(namespaces) > 0 {
+		summary += " in namespace(s) " + strings.Join(namespaces, ", ")
+	}
+	return summary, details
+}
+
+// build 根据模板或清单文件生成要创建的对象
+func (c *DeployCmd) build(template, file string, opts manifest.Options) ([]manifest.Object, string, error) {
+	if template == deployCustom {
+		if file == "" {
+			return nil, "", i18n.Errorf("custom 模板需要 --file <manifest>")
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, "", i18n.Errorf("读取清单失败: %w", err)
+		}
+		objects, err := manifest.Parse(data, opts.Namespace)
+		return objects, "", err
+	}
+
+	t, ok := manifest.Get(template)
+	if !ok {
+		return nil, "", i18n.Errorf("未知模板: %s，输入 'deploy list' 查看模板", template)
+	}
+	if err := opts.Validate(template); err != nil {
+		return nil, "", err
+	}
+	if opts.Name == "" {
+		opts.Name = "kctl-" + template + "-" + randomSuffix()
+	}
+	next := fmt.Sprintf(t.Next, opts.Namespace, opts.Name)
+	return []manifest.Object{t.Build(opts)}, next, nil
+}
+
+// deploy 预检查权限后依次创建对象并记录到数据库
+func (c *DeployCmd) deploy(sess *session.Session, template, node string, objects []manifest.Object, next string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+	if err != nil {
+		return err
+	}
+
+	// 权限预检查
+	for _, obj := range objects {
+		ref := obj.Ref()
+		req := k8sclient.PermissionFor(ref, "create")
+		allowed, err := k8s.CheckPermission(ctx, &req)
+		if err != nil {
+			p.Warning(i18n.Tf("权限预检查失败，继续尝试: %v", err))
+			break
+		}
+		if !allowed {
+			return i18n.Errorf("当前 Token 无权创建 %s (%s create)", ref, req.Resource)
+		}
+	}
+
+	created := 0
+	for _, obj := range objects {
+		ref := obj.Ref()
+		body, err := obj.JSON()
+		if err != nil {
+			return i18n.Errorf("序列化 %s 失败: %w", ref, err)
+		}
+
+		if err := k8s.Create(ctx, ref, body); err != nil {
+			return i18n.Errorf("创建 %s 失败: %w", ref, err)
+		}
+		created++
+		p.Success(fmt.Sprintf("Created %s", ref))
+
+		record := &types.DeploymentRecord{
+			Resource:  ref,
+			Template:  template,
+			Node:      node,
+			Settings:  manifest.Settings(obj),
+			CreatedAt: time.Now(),
+		}
+		if err := sess.DeployDB.Save(record); err != nil {
+			p.Warning(i18n.Tf("记录 %s 失败，需手动清理: %v", ref, err))
+		}
+	}
+
+	p.Printf("%s %d resource(s) deployed, remove with 'deploy --cleanup'\n",
+		p.Colored(config.ColorGreen, "[+]"), created)
+	for _, obj := range objects {
+		printSurvivability(p, obj)
+	}
+	if next != "" {
+		p.Info(i18n.T("下一步: ") + next)
+	}
+	return nil
+}
+
+// printSurvivability 输出对象在驱逐和 descheduler 下的存活能力建议
+func printSurvivability(p output.Printer, obj manifest.Object) {
+	advice := manifest.Survivability(obj)
+	if len(advice) == 0 {
+		return
+	}
+	p.Printf("%s Survivability of %s:\n", p.Colored(config.ColorBlue, "[*]"), obj.Ref())
+	for _, a := range advice {
+		mark := p.Colored(config.ColorGreen, "+")
+		if !a.OK {
+			mark = p.Colored(config.ColorYellow, "-")
+		}
+		p.Printf("    %s %s\n", mark, a.Text)
+	}
+}
+
+// cleanup 删除已记录的资源，name 为空时删除全部
+func (c *DeployCmd) cleanup(sess *session.Session, name string, yes bool) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	records, err := sess.DeployDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取部署记录失败: %w", err)
+	}
+
+	var targets []*types.DeploymentRecord
+	for _, r := range records {
+		if name == "" || r.Resource.Name == name {
+			targets = append(targets, r)
+		}
+	}
+	if len(targets) == 0 {
+		p.Info(i18n.T("没有需要清理的资源"))
+		return nil
+	}
+	var details []string
+	for _, r := range targets {
+		details = append(details, r.Resource.String())
+	}
+	if !confirmAction(sess, yes, fmt.Sprintf("About to delete %d resource(s)", len(targets)), details...) {
+		return nil
+	}
+
+	k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+	if err != nil {
+		return err
+	}
+
+	removed, failed := 0, 0
+	for _, r := range targets {
+		err := k8s.Delete(ctx, r.Resource)
+		switch {
+		case err == nil:
+			p.Success(fmt.Sprintf("Deleted %s", r.Resource))
+		case errors.Is(err, k8sclient.ErrNotFound):
+			p.Printf("%s %s already gone\n", p.Colored(config.ColorBlue, "[*]"), r.Resource)
+		default:
+			p.Error(i18n.Tf("删除 %s 失败: %v", r.Resource, err))
+			failed++
+			continue
+		}
+		if err := sess.DeployDB.Delete(r.ID); err != nil {
+			p.Warning(i18n.Tf("移除记录 %s 失败: %v", r.Resource, err))
+		}
+		removed++
+	}
+
+	p.Printf("%s %d resource(s) cleaned up", p.Colored(config.ColorGreen, "[+]"), removed)
+	if failed > 0 {
+		p.Printf(" %s", p.Colored(config.ColorRed, fmt.Sprintf("(%d failed, kept for retry)", failed)))
+	}
+	p.Println()
+	return nil
+}
+
+// list 显示内置模板和已部署的资源
+func (c *DeployCmd) list(sess *session.Session) error {
+	p := sess.Printer
+
+	var rows [][]string
+	for _, t := range manifest.Templates() {
+		rows = append(rows, []string{t.Name, t.Description})
+	}
+	rows = append(rows, []string{deployCustom, "自定义 YAML/JSON 清单 (--file)"})
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"TEMPLATE", "DESCRIPTION"}, rows)
+
+	records, err := sess.DeployDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取部署记录失败: %w", err)
+	}
+	p.Println()
+	if len(records) == 0 {
+		p.Info(i18n.T("没有已部署的资源"))
+		return nil
+	}
+
+	tf := sess.TimeFormatter(false)
+	rows = nil
+	for _, r := range records {
+		node := r.Node
+		if node == "" {
+			node = "-"
+		}
+		rows = append(rows, []string{r.Resource.Kind, r.Resource.Namespace, r.Resource.Name, r.Template, node, orDash(r.Settings), tf.Format(r.CreatedAt)})
+	}
+	output.NewTablePrinter().PrintSimple([]string{"KIND", "NAMESPACE", "NAME", "TEMPLATE", "NODE", "SETTINGS", "CREATED"}, rows)
+	p.Println()
+	p.Printf("%s %d deployed resource(s)\n", p.Colored(config.ColorGreen, "[+]"), len(records))
+	return nil
+}
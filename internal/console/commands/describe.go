@@ -0,0 +1,438 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/console/picker"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// DescribeCmd describe 命令
+type DescribeCmd struct{}
+
+func init() {
+	Register(&DescribeCmd{})
+}
+
+func (c *DescribeCmd) Name() string {
+	return "describe"
+}
+
+func (c *DescribeCmd) Aliases() []string {
+	return []string{"desc"}
+}
+
+func (c *DescribeCmd) Description() string {
+	return "显示 Pod 的完整安全配置"
+}
+
+// IsReadOnly describe 只读取 Pod 缓存
+func (c *DescribeCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+// IsPaged describe 输出 Pod 详情
+func (c *DescribeCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *DescribeCmd) Usage() string {
+	return `describe <pod> [options]
+
+显示缓存中 Pod 的完整记录：主机命名空间、Pod / 容器安全上下文（runAsUser、
+capabilities、seccomp、AppArmor、sysctls）、镜像、挂载、卷和污点容忍
+高危配置以红色标出；比 pods --detail 更完整
+
+选项：
+  -n <namespace>    指定命名空间（也可使用 namespace/pod 形式）
+  --absolute        显示绝对时间（默认相对时间）
+
+示例：
+  describe nginx-7d8b49557c-abcde
+  describe kube-system/kube-proxy-x7k2p
+  describe coredns-5d78c9869d-abcde -n kube-system`
+}
+
+// Flags describe 的选项补全
+func (c *DescribeCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "-n", Arg: "<namespace>", Description: "指定命名空间", Values: completion.Namespaces},
+		flagAbsolute,
+	}
+}
+
+// Suggestions describe 的 Pod 补全（包括非 Running 状态的 Pod）
+func (c *DescribeCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return podSuggestions(sess, c, args, completion.Pods)
+}
+
+func (c *DescribeCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	name, namespace := "", ""
+	absolute := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 >= len(args) {
+				return i18n.Errorf("-n 需要指定命名空间")
+			}
+			namespace = args[i+1]
+			i++
+		case "--absolute":
+			absolute = true
+		default:
+			if name != "" {
+				return i18n.Errorf("多余的参数: %s", args[i])
+			}
+			name = args[i]
+		}
+	}
+	if name == "" {
+		return i18n.Errorf("用法: describe <pod> [-n <namespace>]")
+	}
+
+	if len(sess.GetCachedPods()) == 0 && !sess.IsViewer() {
+		kubelet, err := sess.GetKubeletClient()
+		if err != nil {
+			return err
+		}
+		pods, err := kubelet.GetPodsWithContainers(sess.Context())
+		if err != nil {
+			return i18n.Errorf("获取 Pod 列表失败: %w", err)
+		}
+		sess.CachePods(pods)
+	}
+
+	ref, err := resolvePod(sess, name, namespace, "")
+	if errors.Is(err, picker.ErrCancelled) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !ref.Cached {
+		return i18n.Errorf("缓存中没有 Pod: %s/%s，使用 pods --refresh 更新", ref.Namespace, ref.Pod)
+	}
+	for _, pod := range sess.GetCachedPods() {
+		if pod.Namespace == ref.Namespace && pod.PodName == ref.Pod {
+			p.Println()
+			c.print(p, pod, sess.TimeFormatter(absolute))
+			return nil
+		}
+	}
+	return i18n.Errorf("缓存中没有 Pod: %s/%s", ref.Namespace, ref.Pod)
+}
+
+// print 分节输出 Pod 记录
+func (c *DescribeCmd) print(p output.Printer, pod types.PodContainerInfo, tf output.TimeFormatter) {
+	statusColor := config.ColorGreen
+	if pod.Status != "Running" {
+		statusColor = config.ColorYellow
+	}
+
+	p.Printf("  %s\n", p.Colored(config.ColorWhite, pod.Namespace+"/"+pod.PodName))
+	p.Println("  " + p.Colored(config.ColorGray, strings.Repeat("─", 60)))
+	c.field(p, "Status", p.Colored(statusColor, pod.Status))
+	c.field(p, "Node", orDash(pod.NodeName))
+	c.field(p, "Pod IP", orDash(pod.PodIP))
+	c.field(p, "Host IP", orDash(pod.HostIP))
+	c.field(p, "ServiceAccount", orDash(pod.ServiceAccount))
+	if pod.CreatedAt != "" {
+		c.field(p, "Created", tf.FormatString(pod.CreatedAt))
+	}
+	if pod.UID != "" {
+		c.field(p, "UID", p.Colored(config.ColorGray, pod.UID))
+	}
+	if len(pod.Labels) > 0 {
+		keys := make([]string, 0, len(pod.Labels))
+		for k := range pod.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for i, k := range keys {
+			label := ""
+			if i == 0 {
+				label = "Labels"
+			}
+			c.field(p, label, p.Colored(config.ColorGray, k+"="+pod.Labels[k]))
+		}
+	}
+
+	c.section(p, "Host Namespaces", 0)
+	c.field(p, "hostNetwork", c.danger(p, pod.SecurityFlags.HostNetwork))
+	c.field(p, "hostPID", c.danger(p, pod.SecurityFlags.HostPID))
+	c.field(p, "hostIPC", c.danger(p, pod.SecurityFlags.HostIPC))
+
+	c.section(p, "Pod Security Context", 0)
+	sc := pod.PodSecurity
+	if sc == nil {
+		sc = &types.PodSecurityContext{}
+	}
+	c.field(p, "runAsUser", c.user(p, sc.RunAsUser))
+	c.field(p, "runAsGroup", optionalInt(sc.RunAsGroup))
+	c.field(p, "runAsNonRoot", formatOptionalBool(sc.RunAsNonRoot))
+	c.field(p, "fsGroup", optionalInt(sc.FSGroup))
+	if len(sc.SupplementalGroups) > 0 {
+		groups := make([]string, len(sc.SupplementalGroups))
+		for i, g := range sc.SupplementalGroups {
+			groups[i] = strconv.FormatInt(g, 10)
+		}
+		c.field(p, "supplementalGroups", strings.Join(groups, ", "))
+	}
+	c.field(p, "seccomp", c.profile(p, sc.SeccompProfile.String(), ""))
+	c.field(p, "appArmor", c.profile(p, sc.AppArmorProfile.String(), ""))
+	for i, sysctl := range sc.Sysctls {
+		label := ""
+		if i == 0 {
+			label = "sysctls"
+		}
+		c.field(p, label, sysctl.Name+"="+sysctl.Value)
+	}
+
+	c.section(p, "Containers", len(pod.Containers))
+	for i, container := range pod.Containers {
+		c.printContainer(p, pod, container, i+1, tf)
+	}
+	if len(pod.InitContainers) > 0 {
+		c.section(p, "Init Containers", len(pod.InitContainers))
+		for i, container := range pod.InitContainers {
+			c.printContainer(p, pod, container, i+1, tf)
+		}
+	}
+	if len(pod.EphemeralContainers) > 0 {
+		c.section(p, "Ephemeral Containers", len(pod.EphemeralContainers))
+		for i, container := range pod.EphemeralContainers {
+			c.printContainer(p, pod, container, i+1, tf)
+		}
+	}
+
+	if len(pod.Volumes) > 0 {
+		c.section(p, "Volumes", len(pod.Volumes))
+		for _, vol := range pod.Volumes {
+			p.Printf("      %-24s %s", vol.Name, p.Colored(volumeTypeColor(vol.Type), "["+vol.Type+"]"))
+			if vol.Source != "" {
+				p.Printf(" -> %s", p.Colored(config.ColorCyan, vol.Source))
+			}
+			p.Println()
+		}
+	}
+
+	if len(pod.Tolerations) > 0 {
+		c.section(p, "Tolerations", len(pod.Tolerations))
+		for _, t := range pod.Tolerations {
+			p.Printf("      %s\n", c.toleration(p, t))
+		}
+	}
+	p.Println()
+}
+
+// printContainer 输出单个容器的安全配置
+func (c *DescribeCmd) printContainer(p output.Printer, pod types.PodContainerInfo, container types.ContainerDetail, index int, tf output.TimeFormatter) {
+	stateColor := config.ColorGreen
+	if !strings.HasPrefix(container.State, "Running") {
+		stateColor = config.ColorYellow
+	}
+
+	sidecar := ""
+	if container.Sidecar {
+		sidecar = p.Colored(config.ColorGray, " (sidecar)")
+	}
+	p.Printf("      %s %s%s\n",
+		p.Colored(config.ColorCyan, fmt.Sprintf("[%d]", index)),
+		p.Colored(config.ColorWhite, container.Name), sidecar)
+	c.subfield(p, "Image", p.Colored(config.ColorGray, container.Image))
+	if container.Target != "" {
+		c.subfield(p, "Target", container.Target)
+	}
+	c.subfield(p, "State", p.Colored(stateColor, orDash(container.State)))
+	if container.StartedAt != "" {
+		c.subfield(p, "Started", tf.FormatString(container.StartedAt))
+	}
+	if container.ContainerID != "" {
+		c.subfield(p, "Container ID", p.Colored(config.ColorGray, container.ContainerID))
+	}
+
+	c.subfield(p, "privileged", c.danger(p, container.Privileged))
+	c.subfield(p, "allowPrivEsc", c.warn(p, container.AllowPE))
+	c.subfield(p, "readOnlyRootFS", strconv.FormatBool(container.ReadOnlyRoot))
+
+	runAsUser := c.user(p, container.RunAsUser)
+	if container.RunAsUser == nil && pod.PodSecurity != nil && pod.PodSecurity.RunAsUser != nil {
+		runAsUser = c.user(p, pod.PodSecurity.RunAsUser) + p.Colored(config.ColorGray, " (pod)")
+	}
+	c.subfield(p, "runAsUser", runAsUser)
+	if container.RunAsGroup != nil {
+		c.subfield(p, "runAsGroup", optionalInt(container.RunAsGroup))
+	}
+	if container.RunAsNonRoot != nil {
+		c.subfield(p, "runAsNonRoot", formatOptionalBool(container.RunAsNonRoot))
+	}
+
+	var caps []string
+	for _, capability := range container.CapAdd {
+		if config.IsDangerousCapability(capability) {
+			caps = append(caps, p.Colored(config.ColorRed, "+"+capability))
+		} else {
+			caps = append(caps, p.Colored(config.ColorYellow, "+"+capability))
+		}
+	}
+	for _, capability := range container.CapDrop {
+		caps = append(caps, p.Colored(config.ColorGreen, "-"+capability))
+	}
+	if len(caps) > 0 {
+		c.subfield(p, "capabilities", strings.Join(caps, " "))
+	} else {
+		c.subfield(p, "capabilities", p.Colored(config.ColorGray, "runtime default"))
+	}
+
+	podSeccomp, podAppArmor := "", ""
+	if pod.PodSecurity != nil {
+		podSeccomp = pod.PodSecurity.SeccompProfile.String()
+		podAppArmor = pod.PodSecurity.AppArmorProfile.String()
+	}
+	c.subfield(p, "seccomp", c.profile(p, container.Seccomp, podSeccomp))
+	c.subfield(p, "appArmor", c.profile(p, container.AppArmor, podAppArmor))
+
+	if len(container.Env) > 0 {
+		names := make([]string, 0, len(container.Env))
+		for name := range container.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		c.subfield(p, "Env", p.Colored(config.ColorGray, strings.Join(names, ", ")))
+	}
+
+	if len(container.VolumeMounts) > 0 {
+		c.subfield(p, "Mounts", "")
+		for _, vm := range container.VolumeMounts {
+			mountColor := config.ColorWhite
+			if vm.Type == "hostPath" {
+				mountColor = config.ColorRed
+			}
+			readOnly := ""
+			if vm.ReadOnly {
+				readOnly = p.Colored(config.ColorGray, " (ro)")
+			}
+			p.Printf("            %s %s%s", p.Colored(mountColor, vm.MountPath),
+				p.Colored(volumeTypeColor(vm.Type), "["+orDash(vm.Type)+"]"), readOnly)
+			if vm.Source != "" {
+				p.Printf(" <- %s", p.Colored(config.ColorCyan, vm.Source))
+			}
+			p.Println()
+		}
+	}
+}
+
+func (c *DescribeCmd) section(p output.Printer, title string, count int) {
+	p.Println()
+	if count > 0 {
+		p.Printf("    %s (%d)\n", p.Colored(config.ColorYellow, title), count)
+		return
+	}
+	p.Printf("    %s\n", p.Colored(config.ColorYellow, title))
+}
+
+func (c *DescribeCmd) field(p output.Printer, name, value string) {
+	p.Printf("      %-20s %s\n", name, value)
+}
+
+func (c *DescribeCmd) subfield(p output.Printer, name, value string) {
+	p.Printf("          %-16s %s\n", name, value)
+}
+
+// danger 为 true 时以红色显示
+func (c *DescribeCmd) danger(p output.Printer, v bool) string {
+	if v {
+		return p.Colored(config.ColorRed, "true")
+	}
+	return "false"
+}
+
+// warn 为 true 时以黄色显示
+func (c *DescribeCmd) warn(p output.Printer, v bool) string {
+	if v {
+		return p.Colored(config.ColorYellow, "true")
+	}
+	return "false"
+}
+
+// user 格式化 runAsUser，root 以红色显示
+func (c *DescribeCmd) user(p output.Printer, uid *int64) string {
+	if uid == nil {
+		return p.Colored(config.ColorGray, "image default")
+	}
+	if *uid == 0 {
+		return p.Colored(config.ColorRed, "0 (root)")
+	}
+	return strconv.FormatInt(*uid, 10)
+}
+
+// profile 格式化 seccomp / AppArmor 配置，容器未设置时显示继承的 Pod 级配置
+func (c *DescribeCmd) profile(p output.Printer, value, inherited string) string {
+	suffix := ""
+	if value == "" && inherited != "" {
+		value, suffix = inherited, p.Colored(config.ColorGray, " (pod)")
+	}
+	switch {
+	case value == "":
+		return p.Colored(config.ColorYellow, "not set")
+	case strings.EqualFold(value, "Unconfined"):
+		return p.Colored(config.ColorRed, value) + suffix
+	default:
+		return p.Colored(config.ColorGreen, value) + suffix
+	}
+}
+
+// toleration 格式化污点容忍，容忍所有污点时以红色显示
+func (c *DescribeCmd) toleration(p output.Printer, t types.Toleration) string {
+	if t.Key == "" && t.Operator == "Exists" {
+		text := "* (tolerates all taints)"
+		if t.Effect != "" {
+			text = "*:" + t.Effect + " (tolerates all " + t.Effect + " taints)"
+		}
+		return p.Colored(config.ColorRed, text)
+	}
+	text := t.Key
+	if t.Operator == "Exists" {
+		text += " exists"
+	} else if t.Value != "" {
+		text += "=" + t.Value
+	}
+	if t.Effect != "" {
+		text += ":" + t.Effect
+	}
+	if t.TolerationSeconds != nil {
+		text += p.Colored(config.ColorGray, fmt.Sprintf(" for %ds", *t.TolerationSeconds))
+	}
+	return text
+}
+
+// volumeTypeColor 卷类型的颜色：hostPath 红色，secret 黄色，其余灰色
+func volumeTypeColor(volumeType string) config.ColorName {
+	switch volumeType {
+	case "hostPath":
+		return config.ColorRed
+	case "secret":
+		return config.ColorYellow
+	default:
+		return config.ColorGray
+	}
+}
+
+// optionalInt 格式化可能缺失的整数配置
+func optionalInt(v *int64) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatInt(*v, 10)
+}
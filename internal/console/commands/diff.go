@@ -0,0 +1,369 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// DiffCmd diff 命令
+type DiffCmd struct{}
+
+func init() {
+	Register(&DiffCmd{})
+}
+
+func (c *DiffCmd) Name() string {
+	return "diff"
+}
+
+func (c *DiffCmd) Aliases() []string {
+	return nil
+}
+
+func (c *DiffCmd) Description() string {
+	return "比较两次 sa scan 的结果"
+}
+
+// IsReadOnly diff 只读取数据库中的扫描快照
+func (c *DiffCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+// IsPaged diff 输出两次扫描的差异报告
+func (c *DiffCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *DiffCmd) Usage() string {
+	return `diff [<scan1> [<scan2>]]
+
+比较两次 sa scan 的结果快照（扫描 ID 见 sa history）：
+  - 新增 / 消失的 ServiceAccount
+  - 风险升级（如 MEDIUM -> CRITICAL、获得 cluster-admin）和风险降低
+  - 权限变化（新增 / 失去的权限）
+
+不指定扫描时比较最近两次扫描，只指定一次时与最近一次扫描比较
+被动扫描（sa scan --passive）不检查权限，涉及被动扫描时不比较权限
+抽样扫描（sa scan --sample）只覆盖部分 Pod，未被抽到的 SA 不计为新增或消失
+
+示例：
+  diff            比较最近两次扫描
+  diff 3          比较扫描 #3 和最近一次扫描
+  diff 3 5        比较扫描 #3 和 #5`
+}
+
+// Suggestions diff 的扫描 ID 补全
+func (c *DiffCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) >= 2 || sess.ScanDB == nil {
+		return nil
+	}
+	scans, err := sess.ScanDB.GetAll()
+	if err != nil {
+		return nil
+	}
+	tf := sess.TimeFormatter(false)
+	var suggestions []completion.Suggestion
+	for i := len(scans) - 1; i >= 0; i-- {
+		s := scans[i]
+		suggestions = append(suggestions, completion.Suggestion{
+			Text:        strconv.FormatInt(s.ID, 10),
+			Description: fmt.Sprintf("%s, %s, %d SAs", tf.Format(s.StartedAt), s.Mode, s.SAs),
+		})
+	}
+	return suggestions
+}
+
+func (c *DiffCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	if len(args) > 2 {
+		return i18n.Errorf("用法: diff [<scan1> [<scan2>]]")
+	}
+	var ids []int64
+	for _, arg := range args {
+		id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+		if err != nil || id <= 0 {
+			return i18n.Errorf("无效的扫描 ID: %s", arg)
+		}
+		ids = append(ids, id)
+	}
+
+	scans, err := sess.ScanDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取扫描记录失败: %w", err)
+	}
+	switch len(ids) {
+	case 0:
+		if len(scans) < 2 {
+			return i18n.Errorf("至少需要两次扫描记录才能比较（当前 %d 次），见 sa history", len(scans))
+		}
+		ids = []int64{scans[len(scans)-2].ID, scans[len(scans)-1].ID}
+	case 1:
+		if len(scans) == 0 {
+			return i18n.Errorf("没有扫描记录，请先执行 'sa scan'")
+		}
+		ids = append(ids, scans[len(scans)-1].ID)
+	}
+	if ids[0] == ids[1] {
+		return i18n.Errorf("不能将扫描 #%d 与自身比较", ids[0])
+	}
+
+	from, err := c.load(sess, ids[0])
+	if err != nil {
+		return err
+	}
+	to, err := c.load(sess, ids[1])
+	if err != nil {
+		return err
+	}
+
+	tf := sess.TimeFormatter(false)
+	p.Printf("%s Comparing scan #%d (%s, %s) -> scan #%d (%s, %s)\n",
+		p.Colored(config.ColorBlue, "[*]"),
+		from.scan.ID, tf.Format(from.scan.StartedAt), from.scan.Mode,
+		to.scan.ID, tf.Format(to.scan.StartedAt), to.scan.Mode)
+	for _, s := range []*types.ScanRecord{from.scan, to.scan} {
+		if s.Sampled > 0 {
+			p.Printf("%s Scan #%d is sampled (%d pods), SAs it did not cover are not reported as added/removed\n",
+				p.Colored(config.ColorYellow, "[!]"), s.ID, s.Sampled)
+		} else if s.Partial {
+			p.Printf("%s Scan #%d is partial (sampled or interrupted), added/removed SAs may reflect coverage rather than changes\n",
+				p.Colored(config.ColorYellow, "[!]"), s.ID)
+		}
+	}
+	if from.scan.Target != to.scan.Target {
+		p.Printf("%s Scans were taken from different targets (%s, %s)\n",
+			p.Colored(config.ColorYellow, "[!]"), from.scan.Target, to.scan.Target)
+	}
+
+	d := diffScans(from.results, to.results, from.scan.Sampled > 0, to.scan.Sampled > 0)
+	c.print(p, d)
+	return nil
+}
+
+// scanSnapshot 一次扫描的记录及结果快照
+type scanSnapshot struct {
+	scan    *types.ScanRecord
+	results []*types.ServiceAccountRecord
+}
+
+func (c *DiffCmd) load(sess *session.Session, id int64) (*scanSnapshot, error) {
+	scan, err := sess.ScanDB.Get(id)
+	if err != nil {
+		return nil, i18n.Errorf("读取扫描 #%d 失败: %w", id, err)
+	}
+	if scan == nil {
+		return nil, i18n.Errorf("扫描 #%d 不存在，见 sa history", id)
+	}
+	results, err := sess.ScanDB.GetResults(id)
+	if err != nil {
+		return nil, i18n.Errorf("读取扫描 #%d 结果失败: %w", id, err)
+	}
+	return &scanSnapshot{scan: scan, results: results}, nil
+}
+
+// saChange 同一 SA 在两次扫描间的变化
+type saChange struct {
+	key            string
+	from, to       config.RiskLevel
+	added, removed []string // 新增 / 失去的权限
+}
+
+// scanDiff 两次扫描的比较结果
+type scanDiff struct {
+	added, removed []*types.ServiceAccountRecord
+	regressions    []saChange // 风险升级
+	improvements   []saChange // 风险降低
+	permChanges    []saChange // 风险等级不变但权限变化
+	unchecked      int        // 任一侧未检查权限而跳过权限比较的 SA 数
+	uncovered      int        // 只出现在一侧、且另一侧为抽样扫描而未计入新增 / 消失的 SA 数
+}
+
+// diffScans 按 namespace/name 比较两次扫描的 SA 快照
+// 一侧为抽样扫描时，只出现在另一侧的 SA 可能只是未被抽到，计入 uncovered 而不是新增 / 消失
+func diffScans(from, to []*types.ServiceAccountRecord, fromSampled, toSampled bool) scanDiff {
+	var d scanDiff
+	before := make(map[string]*types.ServiceAccountRecord, len(from))
+	for _, r := range from {
+		before[r.Namespace+"/"+r.Name] = r
+	}
+	seen := make(map[string]bool, len(to))
+
+	for _, r := range to {
+		key := r.Namespace + "/" + r.Name
+		seen[key] = true
+		old, ok := before[key]
+		if !ok {
+			if fromSampled {
+				d.uncovered++
+			} else {
+				d.added = append(d.added, r)
+			}
+			continue
+		}
+
+		change := saChange{key: key, from: effectiveRisk(old), to: effectiveRisk(r)}
+		if old.PermissionsChecked() && r.PermissionsChecked() {
+			change.added, change.removed = diffPermissions(old.Permissions, r.Permissions)
+		} else {
+			d.unchecked++
+		}
+
+		switch order := config.RiskLevelOrder; {
+		case order[change.to] < order[change.from]:
+			d.regressions = append(d.regressions, change)
+		case order[change.to] > order[change.from]:
+			d.improvements = append(d.improvements, change)
+		case len(change.added) > 0 || len(change.removed) > 0:
+			d.permChanges = append(d.permChanges, change)
+		}
+	}
+	for _, r := range from {
+		if seen[r.Namespace+"/"+r.Name] {
+			continue
+		}
+		if toSampled {
+			d.uncovered++
+		} else {
+			d.removed = append(d.removed, r)
+		}
+	}
+	return d
+}
+
+// effectiveRisk cluster-admin 显示为 ADMIN
+func effectiveRisk(r *types.ServiceAccountRecord) config.RiskLevel {
+	if r.IsClusterAdmin {
+		return config.RiskAdmin
+	}
+	return config.RiskLevel(r.RiskLevel)
+}
+
+// diffPermissions 比较两个 JSON 权限列表，返回新增和失去的权限
+func diffPermissions(fromJSON, toJSON string) (added, removed []string) {
+	before := permissionSet(fromJSON)
+	after := permissionSet(toJSON)
+	for perm := range after {
+		if !before[perm] {
+			added = append(added, perm)
+		}
+	}
+	for perm := range before {
+		if !after[perm] {
+			removed = append(removed, perm)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// permissionSet 解析权限列表为 "verb resource[/subresource][.group]" 集合
+func permissionSet(permsJSON string) map[string]bool {
+	var perms []types.SAPermission
+	_ = json.Unmarshal([]byte(permsJSON), &perms)
+	set := make(map[string]bool, len(perms))
+	for _, perm := range perms {
+		if !perm.Allowed {
+			continue
+		}
+		resource := perm.Resource
+		if perm.Subresource != "" {
+			resource += "/" + perm.Subresource
+		}
+		if perm.Group != "" {
+			resource += "." + perm.Group
+		}
+		set[perm.Verb+" "+resource] = true
+	}
+	return set
+}
+
+func (c *DiffCmd) print(p output.Printer, d scanDiff) {
+	f := p.Formatter()
+
+	if len(d.added) > 0 {
+		p.Println()
+		p.Printf("%s New ServiceAccounts (%d):\n", p.Colored(config.ColorGreen, "[+]"), len(d.added))
+		for _, r := range d.added {
+			p.Printf("    %s %s/%s\n", f.FormatRiskLevelColored(effectiveRisk(r)), r.Namespace, r.Name)
+		}
+	}
+	if len(d.removed) > 0 {
+		p.Println()
+		p.Printf("%s Removed ServiceAccounts (%d):\n", p.Colored(config.ColorGray, "[-]"), len(d.removed))
+		for _, r := range d.removed {
+			p.Printf("    %s %s/%s\n", f.FormatRiskLevelColored(effectiveRisk(r)), r.Namespace, r.Name)
+		}
+	}
+	if len(d.regressions) > 0 {
+		p.Println()
+		p.Printf("%s Risk regressions (%d):\n", p.Colored(config.ColorRed, "[!]"), len(d.regressions))
+		c.printChanges(p, d.regressions)
+	}
+	if len(d.improvements) > 0 {
+		p.Println()
+		p.Printf("%s Risk reduced (%d):\n", p.Colored(config.ColorGreen, "[+]"), len(d.improvements))
+		c.printChanges(p, d.improvements)
+	}
+	if len(d.permChanges) > 0 {
+		p.Println()
+		p.Printf("%s Permission changes (%d):\n", p.Colored(config.ColorBlue, "[*]"), len(d.permChanges))
+		c.printChanges(p, d.permChanges)
+	}
+
+	p.Println()
+	total := len(d.added) + len(d.removed) + len(d.regressions) + len(d.improvements) + len(d.permChanges)
+	if total == 0 {
+		p.Printf("%s No changes between scans\n", p.Colored(config.ColorGreen, "[+]"))
+	} else {
+		p.Printf("%s Diff: %d new, %d removed, %s, %d reduced, %d permission change(s)\n",
+			p.Colored(config.ColorGreen, "[+]"),
+			len(d.added), len(d.removed),
+			c.regressionCount(p, len(d.regressions)),
+			len(d.improvements), len(d.permChanges))
+	}
+	if d.uncovered > 0 {
+		p.Printf("%s %d SA(s) appear in only one scan but were outside the sample of the other\n",
+			p.Colored(config.ColorGray, "[*]"), d.uncovered)
+	}
+	if d.unchecked > 0 {
+		p.Printf("%s %d SA(s) were not compared for permissions (passive scan, permissions not checked)\n",
+			p.Colored(config.ColorGray, "[*]"), d.unchecked)
+	}
+}
+
+func (c *DiffCmd) regressionCount(p output.Printer, n int) string {
+	text := fmt.Sprintf("%d regression(s)", n)
+	if n > 0 {
+		return p.Colored(config.ColorRed, text)
+	}
+	return text
+}
+
+func (c *DiffCmd) printChanges(p output.Printer, changes []saChange) {
+	f := p.Formatter()
+	for _, ch := range changes {
+		if ch.from != ch.to {
+			p.Printf("    %s  %s -> %s\n", ch.key, f.FormatRiskLevelColored(ch.from), f.FormatRiskLevelColored(ch.to))
+		} else {
+			p.Printf("    %s  %s\n", ch.key, f.FormatRiskLevelColored(ch.to))
+		}
+		for _, perm := range ch.added {
+			p.Printf("      %s %s\n", p.Colored(config.ColorRed, "+"), perm)
+		}
+		for _, perm := range ch.removed {
+			p.Printf("      %s %s\n", p.Colored(config.ColorGreen, "-"), perm)
+		}
+	}
+}
@@ -3,12 +3,15 @@ package commands
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
 	"kctl/internal/output"
 	"kctl/internal/session"
 	"kctl/pkg/network"
@@ -57,6 +60,24 @@ func (c *DiscoverCmd) Usage() string {
   discover 10.0.0.0/16 -c 200`
 }
 
+// Flags discover 的选项补全
+func (c *DiscoverCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--port", Short: "-p", Arg: "<ports>", Description: "端口 (默认: 10250)", Values: completion.Choices(
+			completion.Suggestion{Text: "10250", Description: "Kubelet API (默认)"},
+			completion.Suggestion{Text: "10255", Description: "Kubelet 只读端口"},
+			completion.Suggestion{Text: "10250,10255", Description: "常用 Kubelet 端口"},
+		)},
+		{Name: "--concurrency", Short: "-c", Arg: "<n>", Description: "并发数 (默认: 100)", Values: completion.Concurrency},
+		{Name: "--timeout", Short: "-t", Arg: "<seconds>", Description: "超时秒数 (默认: 3)", Values: completion.Choices(
+			completion.Suggestion{Text: "1", Description: "1 秒"},
+			completion.Suggestion{Text: "3", Description: "3 秒 (默认)"},
+			completion.Suggestion{Text: "5", Description: "5 秒"},
+		)},
+		{Name: "--all", Description: "显示所有开放端口"},
+	}
+}
+
 // discoverOptions 命令选项
 type discoverOptions struct {
 	target      string
@@ -79,7 +100,7 @@ func (c *DiscoverCmd) Execute(sess *session.Session, args []string) error {
 	// 解析目标
 	targets, err := network.ParseTargets(opts.target)
 	if err != nil {
-		return fmt.Errorf("解析目标失败: %w", err)
+		return i18n.Errorf("解析目标失败: %w", err)
 	}
 
 	totalTargets := len(targets) * len(opts.ports)
@@ -141,7 +162,7 @@ func (c *DiscoverCmd) parseArgs(args []string) (*discoverOptions, error) {
 	}
 
 	if len(args) == 0 {
-		return nil, fmt.Errorf("用法: discover <target> [options]")
+		return nil, i18n.Errorf("用法: discover <target> [options]")
 	}
 
 	// 第一个参数是目标
@@ -152,7 +173,7 @@ func (c *DiscoverCmd) parseArgs(args []string) (*discoverOptions, error) {
 		switch args[i] {
 		case "-p", "--port":
 			if i+1 >= len(args) {
-				return nil, fmt.Errorf("-p 需要指定端口")
+				return nil, i18n.Errorf("-p 需要指定端口")
 			}
 			i++
 			ports, err := network.ParsePorts(args[i])
@@ -163,23 +184,23 @@ func (c *DiscoverCmd) parseArgs(args []string) (*discoverOptions, error) {
 
 		case "-c", "--concurrency":
 			if i+1 >= len(args) {
-				return nil, fmt.Errorf("-c 需要指定并发数")
+				return nil, i18n.Errorf("-c 需要指定并发数")
 			}
 			i++
 			n, err := strconv.Atoi(args[i])
 			if err != nil || n <= 0 {
-				return nil, fmt.Errorf("无效的并发数: %s", args[i])
+				return nil, i18n.Errorf("无效的并发数: %s", args[i])
 			}
 			opts.concurrency = n
 
 		case "-t", "--timeout":
 			if i+1 >= len(args) {
-				return nil, fmt.Errorf("-t 需要指定超时秒数")
+				return nil, i18n.Errorf("-t 需要指定超时秒数")
 			}
 			i++
 			n, err := strconv.Atoi(args[i])
 			if err != nil || n <= 0 {
-				return nil, fmt.Errorf("无效的超时秒数: %s", args[i])
+				return nil, i18n.Errorf("无效的超时秒数: %s", args[i])
 			}
 			opts.timeout = time.Duration(n) * time.Second
 
@@ -197,19 +218,27 @@ func (c *DiscoverCmd) validateKubelets(ctx context.Context, sess *session.Sessio
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 
-	// 并发验证
-	semaphore := make(chan struct{}, 20)
+	// 自定义 User-Agent 和附加请求头
+	headers := http.Header{}
+	sess.GetClientConfig().SetRequestHeaders(headers)
+
+	// 并发验证，受会话工作池限制
+	workers := sess.Workers()
 
 	for _, port := range openPorts {
 		wg.Add(1)
-		semaphore <- struct{}{}
 
 		go func(ip string, portNum int) {
 			defer wg.Done()
-			defer func() { <-semaphore }()
+
+			key := session.KubeletKey(ip, portNum)
+			if err := workers.Acquire(ctx, key); err != nil {
+				return
+			}
+			defer workers.Release(key)
 
 			// 使用现有的 Kubelet 验证逻辑
-			result := network.ValidateKubeletPort(ip, portNum, sess.Config.Token, timeout)
+			result := network.ValidateKubeletPort(ip, portNum, sess.Config.Token, timeout, headers)
 
 			node := types.KubeletNode{
 				IP:           ip,
@@ -268,7 +297,7 @@ func (c *DiscoverCmd) printResults(p output.Printer, openPorts []network.ScanRes
 	}
 
 	if len(displayResults) == 0 {
-		p.Warning("没有发现 Kubelet 节点")
+		p.Warning(i18n.T("没有发现 Kubelet 节点"))
 		return
 	}
 
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"errors"
+
+	"kctl/internal/i18n"
+)
+
+// ExitCodeError 表示远程命令以非零退出码结束
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return i18n.Tf("命令退出码: %d", e.Code)
+}
+
+// ExitCode 将命令执行错误转换为进程退出码
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *ExitCodeError
+	if errors.As(err, &exitErr) && exitErr.Code != 0 {
+		return exitErr.Code
+	}
+	return 1
+}
@@ -0,0 +1,437 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/client/etcd"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/network"
+	"kctl/pkg/types"
+)
+
+// EtcdCmd etcd 命令
+type EtcdCmd struct{}
+
+func init() {
+	Register(&EtcdCmd{})
+}
+
+func (c *EtcdCmd) Name() string {
+	return "etcd"
+}
+
+func (c *EtcdCmd) Aliases() []string {
+	return nil
+}
+
+func (c *EtcdCmd) Description() string {
+	return "检查 etcd 端口暴露和未授权访问"
+}
+
+func (c *EtcdCmd) Usage() string {
+	return `etcd [target] [options]
+
+检查 etcd 客户端端口（2379）和节点间端口（2380）的暴露情况：
+  1. 从本机探测端口连通性，--from 时同时从 Pod 内探测（etcd 应只允许 kube-apiserver 访问）
+  2. 对本机可达的 2379 端口，依次尝试 https / http 匿名读取 /registry/secrets/ 下的键
+  3. 使用凭据库中的客户端证书（harvest node-creds 收集的 apiserver-etcd-client 等）读取同样的键
+
+target 为 IP、主机名、CIDR 或 IP 范围，默认检查 API Server 主机和缓存中的控制平面节点（先执行 nodes 缓存节点）
+可读取 Secret 的发现以 CRITICAL 级别写入数据库（来源 etcd，hunt list 查看）
+
+选项：
+  --from <pod>        同时在指定 Pod 内探测端口（默认使用当前 SA 的 Pod）
+  --cred <id>         只使用指定的客户端证书凭据
+  --limit <n>         列出的 Secret 键数量（默认 ` + strconv.Itoa(config.DefaultEtcdKeyLimit) + `）
+
+示例：
+  etcd
+  etcd 10.0.0.10
+  etcd 10.0.0.0/24 --from default/nginx
+  etcd 10.0.0.10 --cred 3`
+}
+
+// Flags etcd 的选项补全
+func (c *EtcdCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--from", Arg: "<pod>", Description: "同时在指定 Pod 内探测端口"},
+		{Name: "--cred", Arg: "<id>", Description: "只使用指定的客户端证书凭据"},
+		{Name: "--limit", Arg: "<n>", Description: "列出的 Secret 键数量"},
+	}
+}
+
+// etcdOptions etcd 命令参数
+type etcdOptions struct {
+	target  string
+	from    string
+	podScan bool
+	credID  int64
+	limit   int
+}
+
+// etcdAccess 一次成功读取 Secret 键的结果
+type etcdAccess struct {
+	endpoint string
+	identity string // anonymous 或凭据描述
+	id       string // 发现 ID，如 anonymous、cred-3
+	result   *etcd.RangeResult
+}
+
+func (c *EtcdCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	opts, err := c.parseArgs(args)
+	if err != nil {
+		return err
+	}
+	targets, err := c.resolveTargets(sess, opts.target)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return i18n.Errorf("没有可检查的目标，请指定 target 或先设置 API Server / 执行 nodes")
+	}
+	ports := []int{config.EtcdClientPort, config.EtcdPeerPort}
+
+	p.Printf("%s Probing etcd ports on %d host(s) from local...\n", p.Colored(config.ColorBlue, "[*]"), len(targets))
+	local := make(map[string]bool)
+	scanner := network.NewScanner(network.ScanOptions{
+		Targets: targets,
+		Ports:   ports,
+		Timeout: config.DefaultProbeTimeout,
+	})
+	for r := range scanner.Scan(ctx) {
+		if r.Open {
+			local[net.JoinHostPort(r.IP, strconv.Itoa(r.Port))] = true
+		}
+	}
+
+	var pod map[string]bool
+	var ref session.PodRef
+	if opts.podScan {
+		scan := network.PodScanOptions{
+			Targets:     targets,
+			Ports:       ports,
+			Concurrency: config.DefaultPodScanConcurrency,
+			Timeout:     config.DefaultPodScanTimeout,
+		}
+		var exec podShell
+		ref, exec, err = openPodScan(sess, opts.from, &scan)
+		if err != nil {
+			return err
+		}
+		p.Printf("%s Probing etcd ports from %s/%s (method: %s)...\n", p.Colored(config.ColorBlue, "[*]"), ref.Namespace, ref.Pod, scan.Method)
+		result, err := exec(network.PodScanScript(scan))
+		if err != nil {
+			return i18n.Errorf("Pod 内探测失败: %w", err)
+		}
+		pod = make(map[string]bool)
+		for _, r := range network.ParsePodScan(result.Stdout) {
+			pod[net.JoinHostPort(r.IP, strconv.Itoa(r.Port))] = true
+		}
+	}
+
+	creds, err := c.clientCerts(sess, opts.credID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var records []*types.FindingRecord
+	var accesses []etcdAccess
+	var rows [][]string
+	for _, host := range targets {
+		for _, port := range ports {
+			addr := net.JoinHostPort(host, strconv.Itoa(port))
+			if !local[addr] && !pod[addr] {
+				continue
+			}
+			version, access := "-", "-"
+			if port == config.EtcdClientPort && local[addr] {
+				found, v := c.probeAccess(sess, host, creds, opts.limit)
+				version = orDash(v)
+				if len(found) > 0 {
+					access = p.Colored(config.ColorRed, strconv.Itoa(len(found))+" identity(s) can read secrets")
+				} else if v != "" {
+					access = p.Colored(config.ColorGreen, "denied")
+				}
+				accesses = append(accesses, found...)
+				for _, a := range found {
+					records = append(records, &types.FindingRecord{
+						Source:      "etcd",
+						Severity:    string(config.RiskCritical),
+						Category:    "etcd",
+						Location:    a.id + "@" + a.endpoint,
+						Title:       "etcd 可读取 Secret（" + a.identity + "）",
+						Evidence:    c.evidence(a.result),
+						CollectedAt: now,
+						KubeletIP:   sess.Config.KubeletIP,
+					})
+				}
+			}
+			if pod[addr] {
+				records = append(records, &types.FindingRecord{
+					Source:      "etcd",
+					Severity:    string(config.RiskHigh),
+					Category:    "etcd",
+					Namespace:   ref.Namespace,
+					Pod:         ref.Pod,
+					Container:   ref.Container,
+					Location:    "pod-reachable@" + addr,
+					Title:       "Pod 网络可直接访问 etcd 端口",
+					Evidence:    fmt.Sprintf("%s/%s 可连接 %s，etcd 应只允许 kube-apiserver 访问", ref.Namespace, ref.Pod, addr),
+					CollectedAt: now,
+					KubeletIP:   sess.Config.KubeletIP,
+				})
+			}
+
+			row := []string{addr, c.formatReachable(p, local[addr])}
+			if opts.podScan {
+				row = append(row, c.formatReachable(p, pod[addr]))
+			}
+			rows = append(rows, append(row, version, access))
+		}
+	}
+	if len(records) > 0 {
+		if _, err := sess.FindingDB.SaveBatch(records); err != nil {
+			p.Warning(i18n.Tf("保存 etcd 检查结果失败: %v", err))
+		}
+	}
+
+	if len(rows) == 0 {
+		p.Println()
+		p.Printf("%s etcd ports not reachable on %d host(s)\n", p.Colored(config.ColorGreen, "[+]"), len(targets))
+		return nil
+	}
+	headers := []string{"ADDRESS", "LOCAL"}
+	if opts.podScan {
+		headers = append(headers, "POD")
+	}
+	p.Println()
+	output.NewTablePrinter().PrintSimple(append(headers, "VERSION", "ACCESS"), rows)
+
+	for _, a := range accesses {
+		p.Println()
+		p.Printf("  %s %s %s %s\n", p.Formatter().FormatRiskLevelColored(config.RiskCritical), a.endpoint,
+			p.Colored(config.ColorGray, "as"), a.identity)
+		p.Printf("      %s\n", p.Colored(config.ColorGray, fmt.Sprintf("%d key(s) under %s", a.result.Count, config.EtcdSecretsPrefix)))
+		for _, key := range a.result.Keys {
+			p.Printf("      %s\n", key)
+		}
+	}
+
+	p.Println()
+	p.Printf("%s %d etcd port(s) reachable, %d access path(s) to secrets\n",
+		p.Colored(config.ColorGreen, "[+]"), len(rows), len(accesses))
+	if len(creds) == 0 && len(accesses) == 0 {
+		p.Printf("%s No client certificates in credential store, use 'harvest node-creds' on a control-plane node to collect etcd client certs\n",
+			p.Colored(config.ColorYellow, "[!]"))
+	}
+	return nil
+}
+
+// parseArgs 解析 etcd 命令参数
+func (c *EtcdCmd) parseArgs(args []string) (*etcdOptions, error) {
+	opts := &etcdOptions{limit: config.DefaultEtcdKeyLimit}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--from":
+			if i+1 >= len(args) {
+				return nil, i18n.Errorf("--from 需要指定 Pod")
+			}
+			opts.from = args[i+1]
+			opts.podScan = true
+			i++
+		case "--cred":
+			if i+1 >= len(args) {
+				return nil, i18n.Errorf("--cred 需要指定凭据 ID")
+			}
+			id, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil {
+				return nil, i18n.Errorf("无效的凭据 ID: %s", args[i+1])
+			}
+			opts.credID = id
+			i++
+		case "--limit":
+			if i+1 >= len(args) {
+				return nil, i18n.Errorf("--limit 需要指定数量")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n <= 0 {
+				return nil, i18n.Errorf("无效的数量: %s", args[i+1])
+			}
+			opts.limit = n
+			i++
+		default:
+			if strings.HasPrefix(args[i], "-") || opts.target != "" {
+				return nil, i18n.Errorf("未知参数: %s", args[i])
+			}
+			opts.target = args[i]
+		}
+	}
+	return opts, nil
+}
+
+// resolveTargets 解析检查目标；未指定时使用 API Server 主机和缓存中的控制平面节点
+func (c *EtcdCmd) resolveTargets(sess *session.Session, target string) ([]string, error) {
+	if target != "" {
+		if net.ParseIP(target) == nil && !strings.ContainsAny(target, "/-") {
+			return []string{target}, nil
+		}
+		targets, err := network.ParseTargets(target)
+		if err != nil {
+			return nil, err
+		}
+		if len(targets) > config.MaxPodScanTargets {
+			return nil, i18n.Errorf("目标过多: %d 个 IP（最多 %d 个）", len(targets), config.MaxPodScanTargets)
+		}
+		return targets, nil
+	}
+
+	var targets []string
+	seen := make(map[string]bool)
+	add := func(host string) {
+		if host != "" && !seen[host] {
+			seen[host] = true
+			targets = append(targets, host)
+		}
+	}
+	if sess.Config.APIServer != "" {
+		if u, err := url.Parse(sess.Config.APIServer); err == nil {
+			add(u.Hostname())
+		}
+	}
+	for _, node := range sess.GetCachedNodes() {
+		if c.isControlPlane(node) {
+			add(node.InternalIP)
+		}
+	}
+	return targets, nil
+}
+
+// isControlPlane 按节点角色标签判断控制平面节点（kubeadm 部署的 etcd 与控制平面同机）
+func (c *EtcdCmd) isControlPlane(node types.NodeInfo) bool {
+	for _, label := range []string{"node-role.kubernetes.io/control-plane", "node-role.kubernetes.io/master"} {
+		if _, ok := node.Labels[label]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// clientCerts 返回凭据库中的客户端证书凭据，id 非零时只返回指定凭据
+func (c *EtcdCmd) clientCerts(sess *session.Session, id int64) ([]*types.CredentialRecord, error) {
+	if id != 0 {
+		cred, err := sess.CredDB.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if cred.Kind != types.CredentialClientCert {
+			return nil, i18n.Errorf("凭据 %d 不是客户端证书", id)
+		}
+		return []*types.CredentialRecord{cred}, nil
+	}
+
+	records, err := sess.CredDB.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var certs []*types.CredentialRecord
+	for _, cred := range records {
+		if cred.Kind == types.CredentialClientCert {
+			certs = append(certs, cred)
+		}
+	}
+	return certs, nil
+}
+
+// probeAccess 依次尝试匿名（https、http）和客户端证书读取 /registry/secrets/，返回 etcd 版本和成功的访问
+func (c *EtcdCmd) probeAccess(sess *session.Session, host string, creds []*types.CredentialRecord, limit int) ([]etcdAccess, string) {
+	p := sess.Printer
+	ctx := sess.Context()
+	addr := net.JoinHostPort(host, strconv.Itoa(config.EtcdClientPort))
+
+	base := *sess.GetClientConfig()
+	base.ClientCertPEM, base.ClientKeyPEM = nil, nil
+	base.Timeout = config.DefaultProbeTimeout
+	base.MaxRetries = 0 // 探测以连接失败为结果，不重试
+
+	var found []etcdAccess
+	version := ""
+	for _, scheme := range []string{"https", "http"} {
+		cli, err := etcd.NewClient(scheme+"://"+addr, &base)
+		if err != nil {
+			p.Warning(err.Error())
+			continue
+		}
+		v, err := cli.Version(ctx)
+		if err != nil {
+			continue
+		}
+		version = v.Server
+		result, err := cli.RangeKeys(ctx, config.EtcdSecretsPrefix, limit)
+		if err == nil {
+			found = append(found, etcdAccess{endpoint: cli.Endpoint(), identity: "anonymous", id: "anonymous", result: result})
+		} else if !errors.Is(err, etcd.ErrUnauthorized) {
+			p.Warning(fmt.Sprintf("%s: %v", cli.Endpoint(), err))
+		}
+		// 能取得版本说明协议正确，http 与 https 不会同时开放在同一端口
+		break
+	}
+
+	// TLS 握手要求客户端证书时 /version 也无法匿名访问，此时由证书取得版本
+	endpoint := "https://" + addr
+	for _, cred := range creds {
+		cfg := base
+		cfg.WithClientCert([]byte(cred.ClientCert), []byte(cred.ClientKey))
+		cli, err := etcd.NewClient(endpoint, &cfg)
+		if err != nil {
+			p.Warning(i18n.Tf("凭据 %d: %v", cred.ID, err))
+			continue
+		}
+		if version == "" {
+			if v, err := cli.Version(ctx); err == nil {
+				version = v.Server
+			}
+		}
+		result, err := cli.RangeKeys(ctx, config.EtcdSecretsPrefix, limit)
+		if err != nil {
+			continue
+		}
+		found = append(found, etcdAccess{
+			endpoint: endpoint,
+			identity: fmt.Sprintf("cred #%d %s", cred.ID, cred.Identity),
+			id:       "cred-" + strconv.FormatInt(cred.ID, 10),
+			result:   result,
+		})
+	}
+	return found, version
+}
+
+// evidence 发现的证据：键总数和前几个键
+func (c *EtcdCmd) evidence(result *etcd.RangeResult) string {
+	keys := result.Keys
+	if len(keys) > 5 {
+		keys = keys[:5]
+	}
+	return i18n.Tf("%s 下共 %d 个键: %s", config.EtcdSecretsPrefix, result.Count, strings.Join(keys, ", "))
+}
+
+// formatReachable 格式化端口连通性
+func (c *EtcdCmd) formatReachable(p output.Printer, open bool) string {
+	if open {
+		return p.Colored(config.ColorRed, "open")
+	}
+	return p.Colored(config.ColorGray, "closed")
+}
@@ -1,15 +1,29 @@
 package commands
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"net"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/console/picker"
+	"kctl/internal/i18n"
 	"kctl/internal/session"
+	"kctl/pkg/limiter"
 	"kctl/pkg/types"
 )
 
@@ -36,6 +50,11 @@ func (c *ExecCmd) Aliases() []string {
 	return nil
 }
 
+// IsDestructive exec 可在 Pod 中执行任意命令
+func (c *ExecCmd) IsDestructive(args []string) bool {
+	return true
+}
+
 func (c *ExecCmd) Description() string {
 	return "执行命令"
 }
@@ -45,48 +64,93 @@ func (c *ExecCmd) Usage() string {
 exec -it [pod]                    进入交互式 shell
 
 在 Pod 中执行命令
+未指定 Pod 时使用当前 SA 关联的 Pod；没有时在控制台中弹出可过滤的 Pod 列表（输入过滤，Enter 确认，Esc 取消）
 
 选项：
-  -n <namespace>      指定命名空间
+  -n <namespace>      指定命名空间（默认从 Pod 缓存推断，也可写为 <namespace>/<pod>）
   -c <container>      指定容器
-  -it                 交互式 shell（自动探测可用 shell）
+  -it                 交互式 shell（自动探测可用 shell），连接意外断开时按 set retries 自动重连
+  --resume            -it 时在 Pod 内的 tmux 或 screen 会话中运行 shell，重连后回到同一个 shell
   --shell <shell>     指定 shell 路径（默认自动探测）
   --all-pods          在所有 Pod 中执行命令
+  --all-nodes         配合 --all-pods，在 discover 发现的所有 Kubelet 及当前目标的 Pod 中执行，结果按节点分组
   --filter <pods>     排除指定 Pod（逗号分隔）
   --filter-ns <ns>    排除指定命名空间（逗号分隔）
-  --concurrency <n>   并发数（默认: 10）
+  --concurrency <n>   最大并发数，实际并发自适应调整（默认: 10）
+  --probe             执行前探测容器存活，跳过不可用的 Pod
+  --yes               --all-pods 时不询问确认（交互式控制台中默认先显示涉及的 Pod 和命名空间）
+  -e, --env <K=V>     注入环境变量（可重复，通过 env 包装执行，覆盖 set env 默认值）
+  --confirm           当前 SA 为 cluster-admin 时必须指定
+
+执行通道（set exec-via）：
+  auto         默认。依次协商 websocket → spdy → api → nodes-proxy → run，使用第一个可用的通道
+               （未设置 Kubelet 时只协商 api → nodes-proxy）
+  websocket    Kubelet /exec（WebSocket），kubelet 为其别名
+  spdy         Kubelet /exec（SPDY/3.1），前置代理不支持 WebSocket 时使用
+  api          API Server pods/exec 子资源（使用当前 SA Token，需要 pods/exec 权限）
+  nodes-proxy  API Server nodes/proxy 转发到 Kubelet（需要 nodes/proxy get 权限）
+  run          旧版 Kubelet /run（不支持 -it，参数中的空格无法保留）
+  使用 'show transports' 查看当前目标上各通道是否可用
 
 示例：
   exec -- whoami                              执行单条命令
   exec nginx -- cat /etc/passwd               在指定 Pod 中执行
-  exec -it                                    进入当前 SA Pod 的交互式 shell
+  exec -it                                    进入当前 SA Pod 的交互式 shell（未选择 SA 时弹出 Pod 选择器）
+  exec -it -n kube-system                     从指定命名空间的 Pod 中选择
   exec -it nginx                              进入指定 Pod 的交互式 shell
+  exec -it --resume nginx                     断线重连后恢复 shell（需要 Pod 内有 tmux 或 screen）
   exec --all-pods -- whoami                   在所有 Pod 中执行
   exec --all-pods -n kube-system -- id        在指定命名空间的所有 Pod 中执行
   exec --all-pods --filter kube-proxy -- id   排除指定 Pod
+  exec --all-pods --probe -- ./long-task.sh   跳过崩溃重启中的 Pod
+  exec --all-pods --all-nodes -- cat /etc/hostname  在所有节点的 Pod 中执行
+  exec -e HTTPS_PROXY=http://10.0.0.5:3128 nginx -- curl https://example.com
   exec --all-pods --filter-ns kube-system,kubernetes-dashboard -- id  排除命名空间`
 }
 
+// Flags exec 的选项补全
+func (c *ExecCmd) Flags(args []string) []completion.Flag {
+	return withFlags([]completion.Flag{
+		{Name: "-it", Description: "交互式 shell"},
+		{Name: "--shell", Arg: "<shell>", Description: "指定 shell 路径", Values: completion.Shells},
+		{Name: "--resume", Description: "在 tmux/screen 中运行 shell，重连后恢复"},
+		flagNamespace,
+		flagContainer,
+		{Name: "--all-pods", Description: "在所有 Pod 中执行"},
+		{Name: "--all-nodes", Description: "在所有发现的 Kubelet 上执行"},
+	}, batchFlags, []completion.Flag{
+		{Name: "--probe", Description: "执行前探测容器存活"},
+		flagYes,
+		{Name: "--env", Short: "-e", Arg: "<K=V>", Description: "注入环境变量 KEY=VAL"},
+		flagConfirm,
+		flagSeparator,
+	})
+}
+
+// Suggestions exec 的 Pod 补全
+func (c *ExecCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return podSuggestions(sess, c, args, completion.RunningPods)
+}
+
 func (c *ExecCmd) Execute(sess *session.Session, args []string) error {
 	p := sess.Printer
-	ctx := context.Background()
-
-	// 检查连接
-	kubelet, err := sess.GetKubeletClient()
-	if err != nil {
-		return err
-	}
+	ctx := sess.Context()
 
 	// 解析参数
 	namespace := ""
 	container := ""
 	podName := ""
 	interactive := false
+	resume := false
 	shellPath := ""
 	allPods := false
+	allNodes := false
 	filterPods := ""
 	filterNs := ""
 	concurrency := 10
+	probe := false
+	yes := false
+	var envFlags []string
 	var command []string
 
 	// 查找 -- 分隔符
@@ -116,6 +180,8 @@ func (c *ExecCmd) Execute(sess *session.Session, args []string) error {
 			}
 		case "-it", "-ti", "--interactive":
 			interactive = true
+		case "--resume":
+			resume = true
 		case "--shell":
 			if i+1 < len(args) {
 				shellPath = args[i+1]
@@ -123,6 +189,8 @@ func (c *ExecCmd) Execute(sess *session.Session, args []string) error {
 			}
 		case "--all-pods":
 			allPods = true
+		case "--all-nodes":
+			allNodes = true
 		case "--filter":
 			if i+1 < len(args) {
 				filterPods = args[i+1]
@@ -140,6 +208,15 @@ func (c *ExecCmd) Execute(sess *session.Session, args []string) error {
 				}
 				i++
 			}
+		case "--probe":
+			probe = true
+		case YesFlag:
+			yes = true
+		case "-e", "--env":
+			if i+1 < len(args) {
+				envFlags = append(envFlags, args[i+1])
+				i++
+			}
 		case "--":
 			// 跳过
 		default:
@@ -154,22 +231,51 @@ func (c *ExecCmd) Execute(sess *session.Session, args []string) error {
 		command = args[cmdStart:]
 	}
 
-	// 多 Pod 执行模式
+	// 合并会话默认环境变量与 --env
+	env, err := buildExecEnv(sess.Config.Env, envFlags)
+	if err != nil {
+		return err
+	}
+	if len(command) > 0 {
+		command = withEnv(env, command)
+	}
+
+	if allNodes && !allPods {
+		return i18n.Errorf("--all-nodes 需要与 --all-pods 一起使用")
+	}
 	if allPods {
 		if interactive {
-			return fmt.Errorf("--all-pods 不支持交互式模式")
+			return i18n.Errorf("--all-pods 不支持交互式模式")
 		}
 		if len(command) == 0 {
-			return fmt.Errorf("--all-pods 模式必须指定命令")
+			return i18n.Errorf("--all-pods 模式必须指定命令")
 		}
-		return c.execAllPods(ctx, sess, kubelet, namespace, filterPods, filterNs, concurrency, command)
+	}
+
+	// 多节点执行模式：每个 Kubelet 使用各自的执行通道
+	if allNodes {
+		return c.execAllNodes(ctx, sess, namespace, filterPods, filterNs, concurrency, probe, yes, command)
+	}
+
+	// 选择执行通道（见 set exec-via）
+	executor, err := sess.GetExecTransport()
+	if err != nil {
+		return err
+	}
+	if via := sess.Config.ExecVia; via != config.ExecViaAuto && via != config.ExecViaWebSocket && via != config.ExecViaKubelet {
+		p.Printf("%s Exec via %s\n", p.Colored(config.ColorBlue, "[*]"), executor.Path())
+	}
+
+	// 多 Pod 执行模式
+	if allPods {
+		return c.execAllPods(ctx, sess, executor, namespace, filterPods, filterNs, concurrency, probe, yes, command)
 	}
 
 	// 如果是交互模式但没有指定命令，需要探测 shell
 	if interactive && len(command) == 0 {
 		// 稍后探测 shell
 	} else if !interactive && len(command) == 0 {
-		return fmt.Errorf("用法: exec [pod] -- <command> 或 exec -it [pod]")
+		return i18n.Errorf("用法: exec [pod] -- <command> 或 exec -it [pod]")
 	}
 
 	// 如果没有指定 Pod，尝试使用当前 SA 的 Pod
@@ -192,52 +298,41 @@ func (c *ExecCmd) Execute(sess *session.Session, args []string) error {
 		}
 	}
 
-	if podName == "" {
-		return fmt.Errorf("请指定 Pod 名称或先使用 'use' 选择一个 SA")
-	}
-
-	// 如果没有指定命名空间，尝试从缓存中查找
-	if namespace == "" {
-		pods := sess.GetCachedPods()
-		for _, pod := range pods {
-			if pod.PodName == podName {
-				namespace = pod.Namespace
-				if container == "" && len(pod.Containers) > 0 {
-					container = pod.Containers[0].Name
-				}
-				break
-			}
+	// 交互式控制台中弹出选择器
+	if podName == "" && sess.Interactive {
+		podName, err = pickPod(sess, namespace)
+		if errors.Is(err, picker.ErrCancelled) {
+			return nil
+		}
+		if err != nil {
+			return err
 		}
 	}
 
-	if namespace == "" {
-		namespace = "default"
+	if podName == "" {
+		return i18n.Errorf("请指定 Pod 名称或先使用 'use' 选择一个 SA")
 	}
 
-	// 如果没有指定容器，获取第一个容器
-	if container == "" {
-		pods := sess.GetCachedPods()
-		for _, pod := range pods {
-			if pod.PodName == podName && pod.Namespace == namespace {
-				if len(pod.Containers) > 0 {
-					container = pod.Containers[0].Name
-				}
-				break
-			}
-		}
+	ref, err := resolvePod(sess, podName, namespace, container)
+	if errors.Is(err, picker.ErrCancelled) {
+		return nil
 	}
+	if err != nil {
+		return err
+	}
+	namespace, podName, container = ref.Namespace, ref.Pod, ref.Container
 
 	// 交互式模式
 	if interactive {
-		return c.execInteractive(ctx, sess, kubelet, namespace, podName, container, shellPath)
+		return c.execInteractive(ctx, sess, executor, namespace, podName, container, shellPath, env, resume)
 	}
 
 	// 非交互式执行
-	return c.execCommand(ctx, sess, kubelet, namespace, podName, container, command)
+	return c.execCommand(ctx, sess, executor, namespace, podName, container, command)
 }
 
 // execCommand 执行单条命令
-func (c *ExecCmd) execCommand(ctx context.Context, sess *session.Session, kubelet interface {
+func (c *ExecCmd) execCommand(ctx context.Context, sess *session.Session, executor interface {
 	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
 }, namespace, podName, container string, command []string) error {
 	p := sess.Printer
@@ -253,9 +348,9 @@ func (c *ExecCmd) execCommand(ctx context.Context, sess *session.Session, kubele
 		TTY:       false,
 	}
 
-	result, err := kubelet.Exec(ctx, opts)
+	result, err := executor.Exec(ctx, opts)
 	if err != nil {
-		return fmt.Errorf("执行命令失败: %w", err)
+		return i18n.Errorf("执行命令失败: %w", err)
 	}
 
 	if result.Stdout != "" {
@@ -264,18 +359,29 @@ func (c *ExecCmd) execCommand(ctx context.Context, sess *session.Session, kubele
 			p.Println()
 		}
 	}
+	if result.Stderr != "" {
+		p.Print(result.Stderr)
+		if !strings.HasSuffix(result.Stderr, "\n") {
+			p.Println()
+		}
+	}
+
+	// 远程命令非零退出时返回退出码；其他失败（容器或可执行文件不存在等）报告 Kubelet 返回的错误
+	if result.ExitCode != 0 {
+		return &ExitCodeError{Code: result.ExitCode}
+	}
 	if result.Error != "" {
-		p.Error(result.Error)
+		return i18n.Errorf("执行命令失败: %s", result.Error)
 	}
 
 	return nil
 }
 
 // execInteractive 交互式 shell
-func (c *ExecCmd) execInteractive(ctx context.Context, sess *session.Session, kubelet interface {
+func (c *ExecCmd) execInteractive(ctx context.Context, sess *session.Session, executor interface {
 	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
 	ExecInteractive(ctx context.Context, opts *types.ExecOptions) error
-}, namespace, podName, container, shellPath string) error {
+}, namespace, podName, container, shellPath string, env []string, resume bool) error {
 	p := sess.Printer
 
 	// 如果指定了 shell，直接使用
@@ -283,17 +389,17 @@ func (c *ExecCmd) execInteractive(ctx context.Context, sess *session.Session, ku
 		p.Printf("%s Starting shell: %s\n",
 			p.Colored(config.ColorBlue, "[*]"),
 			p.Colored(config.ColorGreen, shellPath))
-		return c.startShell(ctx, kubelet, namespace, podName, container, shellPath)
+		return c.startShell(ctx, executor, namespace, podName, container, c.shellCommand(ctx, sess, executor, namespace, podName, container, shellPath, env, resume))
 	}
 
 	// 探测可用的 shell
 	p.Printf("%s Detecting available shells...\n",
 		p.Colored(config.ColorBlue, "[*]"))
 
-	availableShells := c.detectShells(ctx, kubelet, namespace, podName, container)
+	availableShells := c.detectShells(ctx, executor, namespace, podName, container)
 
 	if len(availableShells) == 0 {
-		return fmt.Errorf("未找到可用的 shell，请使用 --shell 指定")
+		return i18n.Errorf("未找到可用的 shell，请使用 --shell 指定")
 	}
 
 	// 显示可用的 shell
@@ -309,15 +415,56 @@ func (c *ExecCmd) execInteractive(ctx context.Context, sess *session.Session, ku
 	p.Printf("%s Using: %s\n",
 		p.Colored(config.ColorBlue, "[*]"),
 		p.Colored(config.ColorGreen, selectedShell))
+	command := c.shellCommand(ctx, sess, executor, namespace, podName, container, selectedShell, env, resume)
 	p.Printf("%s Press Ctrl+D or type 'exit' to quit\n",
 		p.Colored(config.ColorGray, "[*]"))
 	p.Println()
 
-	return c.startShell(ctx, kubelet, namespace, podName, container, selectedShell)
+	return c.startShell(ctx, executor, namespace, podName, container, command)
+}
+
+// shellCommand 生成启动 shell 的命令；resume 时用 Pod 内的 tmux 或 screen 包装，断线重连后重新接入同一会话
+func (c *ExecCmd) shellCommand(ctx context.Context, sess *session.Session, executor interface {
+	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
+}, namespace, podName, container, shell string, env []string, resume bool) []string {
+	p := sess.Printer
+	command := withEnv(env, []string{shell})
+	if !resume {
+		return command
+	}
+
+	result, err := executor.Exec(ctx, &types.ExecOptions{
+		Namespace: namespace,
+		Pod:       podName,
+		Container: container,
+		Command:   []string{"sh", "-c", "command -v tmux || command -v screen"},
+		Stdout:    true,
+		Stderr:    true,
+	})
+	multiplexer := ""
+	if err == nil && result.Error == "" {
+		multiplexer = path.Base(strings.TrimSpace(strings.SplitN(result.Stdout, "\n", 2)[0]))
+	}
+
+	// tmux / screen 需要 TERM，exec 的 TTY 不会设置；-D 断开上一次连接遗留的客户端
+	name := fmt.Sprintf("kctl-%06x", rand.IntN(1<<24))
+	wrapEnv := append([]string{"TERM=xterm-256color"}, env...)
+	switch multiplexer {
+	case "tmux":
+		command = withEnv(wrapEnv, []string{"tmux", "new-session", "-A", "-D", "-s", name, shell})
+	case "screen":
+		command = withEnv(wrapEnv, []string{"screen", "-D", "-RR", "-S", name, shell})
+	default:
+		p.Warning(i18n.T("Pod 内没有 tmux 或 screen，断线重连后将启动新的 shell"))
+		return command
+	}
+	p.Printf("%s Shell runs in %s session %s; reconnects resume it\n",
+		p.Colored(config.ColorBlue, "[*]"), multiplexer, p.Colored(config.ColorGreen, name))
+	return command
 }
 
 // detectShells 探测可用的 shell
-func (c *ExecCmd) detectShells(ctx context.Context, kubelet interface {
+func (c *ExecCmd) detectShells(ctx context.Context, executor interface {
 	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
 }, namespace, podName, container string) []string {
 	var available []string
@@ -335,7 +482,7 @@ func (c *ExecCmd) detectShells(ctx context.Context, kubelet interface {
 			TTY:       false,
 		}
 
-		result, err := kubelet.Exec(ctx, opts)
+		result, err := executor.Exec(ctx, opts)
 		if err == nil && result.Error == "" {
 			available = append(available, shell)
 		}
@@ -356,7 +503,7 @@ func (c *ExecCmd) detectShells(ctx context.Context, kubelet interface {
 				TTY:       false,
 			}
 
-			result, err := kubelet.Exec(ctx, opts)
+			result, err := executor.Exec(ctx, opts)
 			if err == nil && result.Error == "" && result.Stdout != "" {
 				path := strings.TrimSpace(result.Stdout)
 				if path != "" {
@@ -370,40 +517,182 @@ func (c *ExecCmd) detectShells(ctx context.Context, kubelet interface {
 }
 
 // startShell 启动交互式 shell
-func (c *ExecCmd) startShell(ctx context.Context, kubelet interface {
+func (c *ExecCmd) startShell(ctx context.Context, executor interface {
 	ExecInteractive(ctx context.Context, opts *types.ExecOptions) error
-}, namespace, podName, container, shell string) error {
+}, namespace, podName, container string, command []string) error {
 	opts := &types.ExecOptions{
 		Namespace: namespace,
 		Pod:       podName,
 		Container: container,
-		Command:   []string{shell},
+		Command:   command,
 		Stdin:     true,
 		Stdout:    true,
 		Stderr:    true,
 		TTY:       true,
 	}
 
-	return kubelet.ExecInteractive(ctx, opts)
+	return executor.ExecInteractive(ctx, opts)
 }
 
-// execAllPods 在多个 Pod 中并发执行命令
-func (c *ExecCmd) execAllPods(ctx context.Context, sess *session.Session, kubelet interface {
+// podExecutor 在容器中执行非交互式命令
+type podExecutor interface {
 	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
-}, namespace, filterPods, filterNs string, concurrency int, command []string) error {
-	p := sess.Printer
+}
 
+// execTarget 批量执行的目标容器及其所在 Kubelet
+type execTarget struct {
+	node     string // Kubelet 的键（ip:port），工作池按节点分配槽位
+	pod      types.PodContainerInfo
+	executor podExecutor
+}
+
+// execAllPods 在当前 Kubelet 缓存的所有 Pod 中并发执行命令
+func (c *ExecCmd) execAllPods(ctx context.Context, sess *session.Session, executor podExecutor, namespace, filterPods, filterNs string, concurrency int, probe, yes bool, command []string) error {
 	// 获取缓存的 Pod
 	pods := sess.GetCachedPods()
 	if len(pods) == 0 {
-		return fmt.Errorf("没有缓存的 Pod，请先执行 'pods' 命令")
+		return i18n.Errorf("没有缓存的 Pod，请先执行 'pods' 命令")
+	}
+
+	var targets []execTarget
+	node := sess.CurrentKubeletKey()
+	for _, pod := range filterExecPods(pods, namespace, filterPods, filterNs) {
+		targets = append(targets, execTarget{node: node, pod: pod, executor: executor})
+	}
+	if len(targets) == 0 {
+		return i18n.Errorf("没有匹配的 Pod")
+	}
+
+	return c.execTargets(ctx, sess, targets, nil, concurrency, probe, yes, command)
+}
+
+// execAllNodes 在所有发现的 Kubelet 上的 Pod 中并发执行命令，Pod 列表从各 Kubelet 实时获取，结果按节点分组
+func (c *ExecCmd) execAllNodes(ctx context.Context, sess *session.Session, namespace, filterPods, filterNs string, concurrency int, probe, yes bool, command []string) error {
+	p := sess.Printer
+
+	nodes := execNodes(sess)
+	if len(nodes) == 0 {
+		return i18n.Errorf("没有可用的 Kubelet，请先执行 'discover' 发现节点或使用 'set target' 设置目标")
+	}
+	// 执行通道设置对所有节点相同，先行检查
+	if _, err := sess.KubeletExecTransport(nodes[0].IP, nodes[0].Port); err != nil {
+		return err
+	}
+
+	p.Printf("%s Listing pods on %d kubelets...\n", p.Colored(config.ColorBlue, "[*]"), len(nodes))
+
+	type nodePods struct {
+		targets []execTarget
+		err     error
+	}
+	listed := make([]nodePods, len(nodes))
+	workers := sess.Workers()
+	var wg sync.WaitGroup
+
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node types.KubeletNode) {
+			defer wg.Done()
+
+			key := session.KubeletKey(node.IP, node.Port)
+			executor, err := sess.KubeletExecTransport(node.IP, node.Port)
+			if err != nil {
+				listed[i].err = err
+				return
+			}
+			kubelet, err := sess.KubeletClientFor(node.IP, node.Port)
+			if err != nil {
+				listed[i].err = err
+				return
+			}
+
+			if err := workers.Acquire(ctx, key); err != nil {
+				listed[i].err = err
+				return
+			}
+			pods, err := kubelet.GetPodsWithContainers(ctx)
+			workers.Release(key)
+			if err != nil {
+				listed[i].err = err
+				return
+			}
+
+			for _, pod := range filterExecPods(pods, namespace, filterPods, filterNs) {
+				if pod.NodeName == "" {
+					pod.NodeName = key
+				}
+				listed[i].targets = append(listed[i].targets, execTarget{node: key, pod: pod, executor: executor})
+			}
+		}(i, node)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var targets []execTarget
+	var order []string
+	for i, node := range nodes {
+		key := session.KubeletKey(node.IP, node.Port)
+		if listed[i].err != nil {
+			p.Printf("%s %s %s\n",
+				p.Colored(config.ColorYellow, "[!]"),
+				key,
+				p.Colored(config.ColorGray, "(skipped: "+listed[i].err.Error()+")"))
+			continue
+		}
+		targets = append(targets, listed[i].targets...)
+		order = append(order, key)
+	}
+	if len(order) == 0 {
+		return i18n.Errorf("无法从任何 Kubelet 获取 Pod 列表")
 	}
+	if len(targets) == 0 {
+		return i18n.Errorf("没有匹配的 Pod")
+	}
+	p.Println()
+
+	return c.execTargets(ctx, sess, targets, order, concurrency, probe, yes, command)
+}
 
+// execNodes 返回多节点执行的 Kubelet：discover 发现的 Kubelet 及当前目标，按 IP 和端口去重
+func execNodes(sess *session.Session) []types.KubeletNode {
+	var nodes []types.KubeletNode
+	seen := make(map[string]bool)
+	add := func(node types.KubeletNode) {
+		key := session.KubeletKey(node.IP, node.Port)
+		if !seen[key] {
+			seen[key] = true
+			nodes = append(nodes, node)
+		}
+	}
+
+	if sess.Config.KubeletIP != "" {
+		add(types.KubeletNode{IP: sess.Config.KubeletIP, Port: sess.Config.KubeletPort})
+	}
+	// 发现结果按完成顺序缓存，按 IP 和端口排序使输出稳定
+	discovered := slices.Clone(sess.GetCachedKubelets())
+	slices.SortFunc(discovered, func(a, b types.KubeletNode) int {
+		if c := bytes.Compare(net.ParseIP(a.IP).To16(), net.ParseIP(b.IP).To16()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Port, b.Port)
+	})
+	for _, node := range discovered {
+		if node.IsKubelet {
+			add(node)
+		}
+	}
+	return nodes
+}
+
+// filterExecPods 按 -n、--filter、--filter-ns 过滤 Pod，只保留 Running 状态的 Pod
+func filterExecPods(pods []types.PodContainerInfo, namespace, filterPods, filterNs string) []types.PodContainerInfo {
 	// 解析 filter 列表
 	podFilterList := parseFilterList(filterPods)
 	nsFilterList := parseFilterList(filterNs)
 
-	// 过滤 Pod
 	var targetPods []types.PodContainerInfo
 	for _, pod := range pods {
 		// 按命名空间过滤（-n 参数，只保留指定命名空间）
@@ -424,38 +713,76 @@ func (c *ExecCmd) execAllPods(ctx context.Context, sess *session.Session, kubele
 		}
 		targetPods = append(targetPods, pod)
 	}
+	return targetPods
+}
+
+// execTargets 在目标容器中并发执行命令并打印结果
+// nodes 非空时结果按节点分组，按 nodes 的顺序打印
+func (c *ExecCmd) execTargets(ctx context.Context, sess *session.Session, targets []execTarget, nodes []string, concurrency int, probe, yes bool, command []string) error {
+	p := sess.Printer
 
-	if len(targetPods) == 0 {
-		return fmt.Errorf("没有匹配的 Pod")
+	targetPods := make([]types.PodContainerInfo, len(targets))
+	for i, t := range targets {
+		targetPods[i] = t.pod
+	}
+	summary, details := podBlastRadius(targetPods)
+	if !confirmAction(sess, yes, fmt.Sprintf("About to run '%s' in %s", strings.Join(command, " "), summary), details...) {
+		return nil
 	}
 
-	p.Printf("%s Executing on %d pods (concurrency: %d)...\n\n",
-		p.Colored(config.ColorBlue, "[*]"),
-		len(targetPods), concurrency)
+	// 执行前探测容器存活，跳过不可用的 Pod
+	if probe {
+		targets = c.probeTargets(ctx, sess, targets, concurrency)
+		if len(targets) == 0 {
+			return i18n.Errorf("没有存活的 Pod")
+		}
+	}
+
+	// 自适应并发：Kubelet 报错或超时时自动降低并发，健康时逐步提升
+	lim := limiter.NewAdaptive(concurrency)
+
+	printPacing(sess)
+	if len(nodes) > 0 {
+		p.Printf("%s Executing on %d pods across %d nodes (concurrency: adaptive, max %d)...\n\n",
+			p.Colored(config.ColorBlue, "[*]"),
+			len(targets), len(nodes), lim.Max())
+	} else {
+		p.Printf("%s Executing on %d pods (concurrency: adaptive, max %d)...\n\n",
+			p.Colored(config.ColorBlue, "[*]"),
+			len(targets), lim.Max())
+	}
 
 	// 执行结果
 	type execResultItem struct {
+		Node      string
 		Namespace string
 		Pod       string
 		Container string
 		Stdout    string
+		Stderr    string
 		Error     string
+		ExitCode  int
 		Success   bool
+		Time      time.Time
 	}
 
 	var results []execResultItem
 	var mu sync.Mutex
 	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, concurrency)
 
-	for _, pod := range targetPods {
+	// 会话工作池限制所有批量命令同时发往同一 Kubelet 的请求数
+	workers := sess.Workers()
+
+	for _, target := range targets {
+		if err := lim.Acquire(ctx); err != nil {
+			break
+		}
 		wg.Add(1)
-		semaphore <- struct{}{}
 
-		go func(pod types.PodContainerInfo) {
+		go func(target execTarget) {
 			defer wg.Done()
-			defer func() { <-semaphore }()
 
+			pod := target.pod
 			container := ""
 			if len(pod.Containers) > 0 {
 				container = pod.Containers[0].Name
@@ -472,13 +799,26 @@ func (c *ExecCmd) execAllPods(ctx context.Context, sess *session.Session, kubele
 				TTY:       false,
 			}
 
-			result, err := kubelet.Exec(ctx, opts)
+			if err := workers.Acquire(ctx, target.node); err != nil {
+				lim.Release(true)
+				return
+			}
+			result, err := target.executor.Exec(ctx, opts)
+			workers.Release(target.node)
+			lim.Release(err == nil)
+
+			// 中断时丢弃未完成的结果
+			if ctx.Err() != nil {
+				return
+			}
 
 			item := execResultItem{
+				Node:      target.node,
 				Namespace: pod.Namespace,
 				Pod:       pod.PodName,
 				Container: container,
 				Success:   true,
+				Time:      time.Now(),
 			}
 
 			if err != nil {
@@ -487,18 +827,24 @@ func (c *ExecCmd) execAllPods(ctx context.Context, sess *session.Session, kubele
 			} else if result.Error != "" {
 				item.Success = false
 				item.Error = result.Error
+				item.ExitCode = result.ExitCode
+				item.Stdout = result.Stdout
+				item.Stderr = result.Stderr
 			} else {
 				item.Stdout = result.Stdout
+				item.Stderr = result.Stderr
 			}
 
 			mu.Lock()
 			results = append(results, item)
 			mu.Unlock()
-		}(pod)
+		}(target)
 	}
 
 	wg.Wait()
 
+	interrupted := ctx.Err() != nil
+
 	// 统计结果
 	successCount := 0
 	failCount := 0
@@ -510,8 +856,37 @@ func (c *ExecCmd) execAllPods(ctx context.Context, sess *session.Session, kubele
 		}
 	}
 
+	// 按节点分组时，结果按节点顺序排列
+	if len(nodes) > 0 {
+		rank := make(map[string]int, len(nodes))
+		for i, node := range nodes {
+			rank[node] = i
+		}
+		sort.SliceStable(results, func(i, j int) bool {
+			return rank[results[i].Node] < rank[results[j].Node]
+		})
+	}
+
 	// 打印结果
-	for _, r := range results {
+	for i, r := range results {
+		if len(nodes) > 0 && (i == 0 || results[i-1].Node != r.Node) {
+			ok, failed := 0, 0
+			for _, other := range results {
+				if other.Node != r.Node {
+					continue
+				}
+				if other.Success {
+					ok++
+				} else {
+					failed++
+				}
+			}
+			p.Printf("%s Node %s %s\n\n",
+				p.Colored(config.ColorBlue, "[*]"),
+				r.Node,
+				p.Colored(config.ColorGray, fmt.Sprintf("(%d success, %d failed)", ok, failed)))
+		}
+
 		if r.Success {
 			p.Printf("%s %s/%s\n",
 				p.Colored(config.ColorGreen, "[+]"),
@@ -524,23 +899,177 @@ func (c *ExecCmd) execAllPods(ctx context.Context, sess *session.Session, kubele
 				}
 			}
 		} else {
-			p.Printf("%s %s/%s\n",
-				p.Colored(config.ColorRed, "[-]"),
-				r.Namespace, r.Pod)
+			if r.ExitCode != 0 {
+				p.Printf("%s %s/%s %s\n",
+					p.Colored(config.ColorRed, "[-]"),
+					r.Namespace, r.Pod,
+					p.Colored(config.ColorGray, fmt.Sprintf("(exit %d)", r.ExitCode)))
+			} else {
+				p.Printf("%s %s/%s\n",
+					p.Colored(config.ColorRed, "[-]"),
+					r.Namespace, r.Pod)
+			}
+			if r.Stdout != "" {
+				lines := strings.Split(strings.TrimRight(r.Stdout, "\n"), "\n")
+				for _, line := range lines {
+					p.Printf("    %s\n", line)
+				}
+			}
 			p.Printf("    %s\n", p.Colored(config.ColorRed, r.Error))
 		}
 		p.Println()
 	}
 
 	// 打印统计
+	if interrupted {
+		p.Warning(i18n.Tf("执行已中断，显示部分结果 (%d/%d)", len(results), len(targets)))
+	}
 	p.Printf("%s Completed: %s, %s\n",
 		p.Colored(config.ColorBlue, "[*]"),
 		p.Colored(config.ColorGreen, fmt.Sprintf("%d success", successCount)),
 		p.Colored(config.ColorRed, fmt.Sprintf("%d failed", failCount)))
 
+	// 保存执行结果，供 results 查看和导出
+	if len(results) > 0 {
+		records := make([]*types.ExecResultRecord, 0, len(results))
+		cmdline := strings.Join(command, " ")
+		for _, r := range results {
+			records = append(records, &types.ExecResultRecord{
+				Time:      r.Time,
+				Node:      r.Node,
+				Namespace: r.Namespace,
+				Pod:       r.Pod,
+				Container: r.Container,
+				Command:   cmdline,
+				Stdout:    r.Stdout,
+				Stderr:    r.Stderr,
+				ExitCode:  r.ExitCode,
+				Error:     r.Error,
+			})
+		}
+		runID, err := sess.ExecDB.SaveRun(records)
+		if err != nil {
+			p.Warning(i18n.Tf("保存执行结果失败: %v", err))
+		} else {
+			p.Printf("%s Results saved as run #%d (use 'results --run %d' to review)\n",
+				p.Colored(config.ColorBlue, "[*]"), runID, runID)
+		}
+	}
+
 	return nil
 }
 
+// probeTargets 使用 true 命令探测目标容器是否存活，返回存活的目标
+func (c *ExecCmd) probeTargets(ctx context.Context, sess *session.Session, targets []execTarget, concurrency int) []execTarget {
+	p := sess.Printer
+	p.Printf("%s Probing %d pods...\n", p.Colored(config.ColorBlue, "[*]"), len(targets))
+
+	type probeItem struct {
+		pod    types.PodContainerInfo
+		reason string
+	}
+
+	var alive []execTarget
+	var dead []probeItem
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	lim := limiter.NewAdaptive(concurrency)
+	workers := sess.Workers()
+
+	for _, target := range targets {
+		if err := lim.Acquire(ctx); err != nil {
+			break
+		}
+		wg.Add(1)
+
+		go func(target execTarget) {
+			defer wg.Done()
+
+			pod := target.pod
+			reason := ""
+			if len(pod.Containers) > 0 && pod.Containers[0].State != "" && pod.Containers[0].State != "Running" {
+				// 容器状态已表明不可用，无需探测
+				lim.Release(true)
+				reason = i18n.T("容器状态: ") + pod.Containers[0].State
+			} else if err := workers.Acquire(ctx, target.node); err != nil {
+				lim.Release(true)
+				return
+			} else {
+				reason = probeContainer(ctx, target.executor, pod)
+				workers.Release(target.node)
+				lim.Release(ctx.Err() == nil)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if reason == "" {
+				alive = append(alive, target)
+			} else {
+				dead = append(dead, probeItem{pod: pod, reason: reason})
+			}
+		}(target)
+	}
+
+	wg.Wait()
+
+	for _, d := range dead {
+		p.Printf("%s %s/%s %s\n",
+			p.Colored(config.ColorYellow, "[!]"),
+			d.pod.Namespace, d.pod.PodName,
+			p.Colored(config.ColorGray, "(skipped: "+d.reason+")"))
+	}
+	p.Printf("%s %d alive, %d skipped\n\n",
+		p.Colored(config.ColorBlue, "[*]"),
+		len(alive), len(dead))
+
+	return alive
+}
+
+// probeContainer 在容器中执行 true 探测存活，返回不可用原因，存活时返回空字符串
+// 命令不存在（如 distroless 镜像）说明运行时可以进入容器，同样视为存活
+func probeContainer(ctx context.Context, executor podExecutor, pod types.PodContainerInfo) string {
+	container := ""
+	if len(pod.Containers) > 0 {
+		container = pod.Containers[0].Name
+	}
+
+	result, err := executor.Exec(ctx, &types.ExecOptions{
+		Namespace: pod.Namespace,
+		Pod:       pod.PodName,
+		Container: container,
+		Command:   []string{"true"},
+		Stdout:    true,
+		Stderr:    true,
+	})
+	if err != nil {
+		return err.Error()
+	}
+
+	lower := strings.ToLower(result.Error)
+	if strings.Contains(lower, "not running") || strings.Contains(lower, "container not found") {
+		return result.Error
+	}
+	return ""
+}
+
+// resolveWorkPod 解析执行辅助脚本的 Pod，未指定时使用当前 SA 的第一个 Pod
+func resolveWorkPod(sess *session.Session, podName string) (session.PodRef, error) {
+	namespace, container := "", ""
+	if podName == "" {
+		sa := sess.GetCurrentSA()
+		if sa != nil && sa.Pods != "" && sa.Pods != "[]" {
+			var pods []types.SAPodInfo
+			if err := json.Unmarshal([]byte(sa.Pods), &pods); err == nil && len(pods) > 0 {
+				podName, namespace, container = pods[0].Name, pods[0].Namespace, pods[0].Container
+			}
+		}
+	}
+	if podName == "" {
+		return session.PodRef{}, i18n.Errorf("请使用 --from <pod> 指定 Pod 或先使用 'use' 选择一个 SA")
+	}
+	return sess.ResolvePod(podName, namespace, container)
+}
+
 // parseFilterList 解析逗号分隔的 filter 列表
 func parseFilterList(filter string) []string {
 	if filter == "" {
@@ -566,3 +1095,65 @@ func matchFilterList(s string, filters []string) bool {
 	}
 	return false
 }
+
+// envKeyRe 环境变量名
+var envKeyRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseEnvAssignment 解析 KEY=VAL 形式的环境变量
+func ParseEnvAssignment(s string) (string, string, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return "", "", i18n.Errorf("无效的环境变量: %s (格式: KEY=VAL)", s)
+	}
+	if !envKeyRe.MatchString(key) {
+		return "", "", i18n.Errorf("无效的环境变量名: %s", key)
+	}
+	return key, value, nil
+}
+
+// buildExecEnv 合并会话默认环境变量与命令行指定的环境变量（命令行优先）
+// 返回按变量名排序的 KEY=VAL 列表
+func buildExecEnv(defaults map[string]string, flags []string) ([]string, error) {
+	merged := make(map[string]string, len(defaults)+len(flags))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for _, f := range flags {
+		key, value, err := ParseEnvAssignment(f)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = value
+	}
+
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, k := range keys {
+		env = append(env, k+"="+merged[k])
+	}
+	return env, nil
+}
+
+// withEnv 使用 env 包装命令以注入环境变量，无需 shell 引号转义
+func withEnv(env, command []string) []string {
+	if len(env) == 0 {
+		return command
+	}
+	wrapped := make([]string, 0, len(env)+len(command)+1)
+	wrapped = append(wrapped, "env")
+	wrapped = append(wrapped, env...)
+	return append(wrapped, command...)
+}
+
+// printPacing 设置了 rate-limit / jitter 时提示批量操作的请求会被放慢
+func printPacing(sess *session.Session) {
+	if pacing := sess.PacingSummary(); pacing != "" {
+		p := sess.Printer
+		p.Printf("%s Pacing requests: %s\n", p.Colored(config.ColorBlue, "[*]"), pacing)
+	}
+}
@@ -26,6 +26,11 @@ func (c *ExitCmd) Description() string {
 	return "退出控制台"
 }
 
+// IsReadOnly exit 仅退出控制台
+func (c *ExitCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
 func (c *ExitCmd) Usage() string {
 	return `exit
 
@@ -1,12 +1,31 @@
 package commands
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"kctl/config"
+	"kctl/internal/bundle"
+	"kctl/internal/console/completion"
+	"kctl/internal/filter"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/report"
+	"kctl/internal/sarif"
+	"kctl/internal/security"
 	"kctl/internal/session"
+	"kctl/pkg/types"
 )
 
 // ExportCmd export 命令
@@ -28,26 +47,103 @@ func (c *ExportCmd) Description() string {
 	return "导出结果"
 }
 
+// IsReadOnly export 只读取扫描结果
+func (c *ExportCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
 func (c *ExportCmd) Usage() string {
-	return `export <format>
+	return `export <format> [file] [options]
+export --out <file> [options]
 
-导出扫描结果
+导出扫描结果，未指定文件时输出到终端
+指定输出文件时可省略格式，按扩展名识别（.json .jsonl .csv .sarif .md .html，.tar.gz 为 bundle）；
+文件名以 .gz 结尾时使用 gzip 压缩（如 results.json.gz）
 
 格式：
-  json    JSON 格式
-  csv     CSV 格式
+  json      JSON 格式：SA、Pod、检查发现（findings）、exec --all-pods 执行结果（results）和扫描记录
+  jsonl     JSON Lines：每行一条记录 {"type": ..., "data": ...}，type 为 meta、scan、serviceAccount、pod、
+            finding、execResult，data 与 json 格式中的记录相同；直接从数据库逐行读取写出，
+            不在内存中构建完整结果，适合 Pod 数量很多的集群
+  csv       CSV 格式（RFC 4180 转义），每次导出一张表：sas（默认）、pods 或 findings
+  sarif     SARIF 2.1.0：SA 的风险权限（规则 ID 对应风险查找表，rbac/<key>）、Pod 安全标识（pod/<flag>）
+            和检查发现（finding/<source>/...），可导入 GitHub code scanning / DefectDojo
+  markdown  Markdown 报告：风险概要、问题列表（标注 MITRE ATT&CK 技术）、ATT&CK for Containers 覆盖矩阵
+            和对集群执行的操作记录（oplog）
+  html      同 markdown，单文件 HTML
+  bundle    完整归档：数据库、原始 Pod JSON 和扫描元数据打包为单个 tar.gz
+            （包含 Token 和收集的凭据），在分析机上用 import bundle 打开
+
+选项：
+  --out, -o <file>  输出文件（也可直接写在格式后）
+  --where <expr>    只导出匹配过滤表达式的 SA / Pod（@name 引用已保存的过滤器，见 filter）
+                    指定 --where 时不包含检查发现（json / jsonl 同时不包含执行结果）
+  --sheet <name>    csv 导出的表：sas、pods、findings（默认 sas）
+  --delimiter <c>   csv 分隔符（默认 ,），制表符写作 tab
+  --bom             csv 开头写入 UTF-8 BOM，便于 Excel 正确识别中文
 
 示例：
   export json
-  export csv`
+  export csv
+  export csv pods.csv --sheet pods --bom
+  export csv --sheet findings --delimiter ';'
+  export sarif
+  export markdown
+  export html report.html             写入文件
+  export json --out results.json
+  export jsonl cluster.jsonl.gz       流式导出并压缩
+  export --out results.json.gz        按扩展名识别格式，gzip 压缩
+  export --out findings.sarif
+  export json --where @prod-risky
+  export bundle                       写入 kctl-bundle-<时间>.tar.gz
+  export bundle field.tar.gz`
+}
+
+// Flags export 的选项补全
+func (c *ExportCmd) Flags(args []string) []completion.Flag {
+	out := completion.Flag{Name: "--out", Short: "-o", Arg: "<file>", Description: "输出文件（按扩展名识别格式，.gz 压缩）"}
+	if len(args) > 0 && args[0] == "bundle" {
+		return []completion.Flag{out}
+	}
+	flags := []completion.Flag{out, flagWhere}
+	if len(args) > 0 && args[0] == "csv" {
+		flags = append(flags,
+			completion.Flag{Name: "--sheet", Arg: "<name>", Description: "导出的表", Values: completion.Choices(
+				completion.Suggestion{Text: "sas", Description: "ServiceAccount（默认）"},
+				completion.Suggestion{Text: "pods", Description: "Pod"},
+				completion.Suggestion{Text: "findings", Description: "检查发现"},
+			)},
+			completion.Flag{Name: "--delimiter", Arg: "<c>", Description: "分隔符（制表符写作 tab）"},
+			completion.Flag{Name: "--bom", Description: "写入 UTF-8 BOM"},
+		)
+	}
+	return flags
+}
+
+// Suggestions export 的格式补全
+func (c *ExportCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return subcommandSuggestions(args,
+		completion.Suggestion{Text: "json", Description: "JSON 格式"},
+		completion.Suggestion{Text: "jsonl", Description: "JSON Lines，每行一条记录（流式写出，适合大集群）"},
+		completion.Suggestion{Text: "csv", Description: "CSV 格式"},
+		completion.Suggestion{Text: "sarif", Description: "SARIF 2.1.0（code scanning / DefectDojo）"},
+		completion.Suggestion{Text: "markdown", Description: "Markdown 报告（含 ATT&CK 覆盖矩阵）"},
+		completion.Suggestion{Text: "html", Description: "HTML 报告（含 ATT&CK 覆盖矩阵）"},
+		completion.Suggestion{Text: "bundle", Description: "完整归档（数据库 + Pod JSON + 元数据）"},
+	)
 }
 
 // ExportData 导出数据结构
 type ExportData struct {
-	ScanTime        string      `json:"scanTime"`
-	KubeletIP       string      `json:"kubeletIP"`
-	ServiceAccounts []ExportSA  `json:"serviceAccounts"`
-	Pods            []ExportPod `json:"pods"`
+	GeneratedAt     string                    `json:"generatedAt"`
+	ScanTime        string                    `json:"scanTime"`
+	KubeletIP       string                    `json:"kubeletIP"`
+	APIServer       string                    `json:"apiServer,omitempty"`
+	Scans           []*types.ScanRecord       `json:"scans"`
+	ServiceAccounts []ExportSA                `json:"serviceAccounts"`
+	Pods            []ExportPod               `json:"pods"`
+	Findings        []*types.FindingRecord    `json:"findings"`
+	ExecResults     []*types.ExecResultRecord `json:"execResults"`
 }
 
 type ExportSA struct {
@@ -57,147 +153,858 @@ type ExportSA struct {
 	IsClusterAdmin bool     `json:"isClusterAdmin"`
 	Permissions    []string `json:"permissions"`
 	Pods           []string `json:"pods"`
+	Tags           []string `json:"tags,omitempty"`
+	Note           string   `json:"note,omitempty"`
 }
 
 type ExportPod struct {
-	Namespace string `json:"namespace"`
-	Name      string `json:"name"`
-	Status    string `json:"status"`
-	PodIP     string `json:"podIP"`
-	Flags     string `json:"flags"`
+	Namespace      string   `json:"namespace"`
+	Name           string   `json:"name"`
+	Status         string   `json:"status"`
+	PodIP          string   `json:"podIP"`
+	Node           string   `json:"node"`
+	ServiceAccount string   `json:"serviceAccount"`
+	Images         []string `json:"images,omitempty"`
+	Flags          string   `json:"flags"`
+}
+
+// exportFormats 可用的导出格式（md 为 markdown 的简写）
+var exportFormats = []string{"json", "jsonl", "csv", "sarif", "markdown", "md", "html", "bundle"}
+
+// exportExtensions 文件扩展名对应的导出格式
+var exportExtensions = map[string]string{
+	".json":     "json",
+	".jsonl":    "jsonl",
+	".ndjson":   "jsonl",
+	".csv":      "csv",
+	".sarif":    "sarif",
+	".md":       "markdown",
+	".markdown": "markdown",
+	".html":     "html",
+	".htm":      "html",
+}
+
+// exportFormatForPath 根据文件名识别导出格式，.tar.gz / .tgz 为 bundle，其他格式可再加 .gz 压缩
+func exportFormatForPath(path string) (string, error) {
+	name := strings.ToLower(path)
+	if strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") {
+		return "bundle", nil
+	}
+	if format, ok := exportExtensions[filepath.Ext(strings.TrimSuffix(name, ".gz"))]; ok {
+		return format, nil
+	}
+	return "", i18n.Errorf("无法根据文件名识别导出格式: %s（支持 .json、.jsonl、.csv、.sarif、.md、.html，可加 .gz 压缩；.tar.gz 为 bundle），请指定格式", path)
 }
 
 func (c *ExportCmd) Execute(sess *session.Session, args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("用法: export <json|csv>")
+		return i18n.Errorf("用法: export <json|jsonl|csv|sarif|markdown|html|bundle> [file] 或 export --out <file>")
 	}
 
-	format := strings.ToLower(args[0])
+	// 格式可省略，由输出文件的扩展名决定
+	format := ""
+	if !strings.HasPrefix(args[0], "-") {
+		if name := strings.ToLower(args[0]); slices.Contains(exportFormats, name) {
+			format, args = name, args[1:]
+		}
+	}
+
+	where, file := "", ""
+	csvOpts := csvOptions{sheet: "sas", delimiter: ','}
+	var csvFlags []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--where", "-w":
+			if i+1 < len(args) {
+				where = args[i+1]
+				i++
+			}
+		case "--sheet", "--delimiter":
+			if i+1 >= len(args) {
+				return i18n.Errorf("%s 需要参数", args[i])
+			}
+			csvFlags = append(csvFlags, args[i])
+			if args[i] == "--sheet" {
+				csvOpts.sheet = strings.ToLower(args[i+1])
+				if !slices.Contains(csvSheets, csvOpts.sheet) {
+					return i18n.Errorf("未知的表: %s (可用: %s)", args[i+1], strings.Join(csvSheets, ", "))
+				}
+			} else {
+				delimiter, err := parseCSVDelimiter(args[i+1])
+				if err != nil {
+					return err
+				}
+				csvOpts.delimiter = delimiter
+			}
+			i++
+		case "--bom":
+			csvFlags = append(csvFlags, args[i])
+			csvOpts.bom = true
+		case "--out", "-o":
+			if i+1 >= len(args) {
+				return i18n.Errorf("%s 需要参数", args[i])
+			}
+			file = args[i+1]
+			i++
+		default:
+			if strings.HasPrefix(args[i], "-") || file != "" {
+				return i18n.Errorf("未知参数: %s", args[i])
+			}
+			file = args[i]
+		}
+	}
+
+	if format == "" {
+		if file == "" {
+			return i18n.Errorf("请指定导出格式或带扩展名的输出文件 (--out results.json)")
+		}
+		detected, err := exportFormatForPath(file)
+		if err != nil {
+			return err
+		}
+		format = detected
+	}
+	if len(csvFlags) > 0 && format != "csv" {
+		return i18n.Errorf("%s 只适用于 csv 格式", csvFlags[0])
+	}
+	if format == "bundle" {
+		if where != "" {
+			return i18n.Errorf("bundle 不支持 --where")
+		}
+		return c.exportBundle(sess, file)
+	}
+
+	expr, err := parseWhere(where)
+	if err != nil {
+		return err
+	}
 
 	// 检查是否有数据
 	if !sess.IsScanned {
-		return fmt.Errorf("没有扫描数据，请先执行 'scan'")
+		return i18n.Errorf("没有扫描数据，请先执行 'scan'")
+	}
+
+	if format == "jsonl" {
+		return c.streamJSONL(sess, expr, file)
 	}
 
+	var data []byte
+	var summary string
 	switch format {
 	case "json":
-		return c.exportJSON(sess)
+		data, summary, err = c.exportJSON(sess, expr)
 	case "csv":
-		return c.exportCSV(sess)
+		data, summary, err = c.exportCSV(sess, expr, csvOpts)
+	case "sarif":
+		data, summary, err = c.exportSARIF(sess, expr)
+	case "markdown", "md", "html":
+		data, summary, err = c.exportReport(sess, expr, format)
 	default:
-		return fmt.Errorf("不支持的格式: %s (可用: json, csv)", format)
+		return i18n.Errorf("不支持的格式: %s (可用: json, jsonl, csv, sarif, markdown, html, bundle)", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	p := sess.Printer
+	if file == "" {
+		p.Print(string(data))
+		return nil
+	}
+	if err := writeExportFile(file, data); err != nil {
+		return err
+	}
+	p.Success(fmt.Sprintf("Exported %s to %s (%s)", format, file, summary))
+	return nil
+}
+
+// exportFile 导出文件，文件名以 .gz 结尾时使用 gzip 压缩
+type exportFile struct {
+	file *os.File
+	gz   *gzip.Writer
+	buf  *bufio.Writer
+}
+
+// createExportFile 创建（覆盖）导出文件
+func createExportFile(path string) (*exportFile, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, i18n.Errorf("写入文件失败: %w", err)
+	}
+	f := &exportFile{file: file}
+	if strings.HasSuffix(strings.ToLower(path), ".gz") {
+		f.gz = gzip.NewWriter(file)
+		f.buf = bufio.NewWriter(f.gz)
+	} else {
+		f.buf = bufio.NewWriter(file)
+	}
+	return f, nil
+}
+
+func (f *exportFile) Write(p []byte) (int, error) {
+	return f.buf.Write(p)
+}
+
+// Close 刷新缓冲、结束 gzip 流并关闭文件，返回第一个错误
+func (f *exportFile) Close() error {
+	err := f.buf.Flush()
+	if f.gz != nil {
+		if gzErr := f.gz.Close(); err == nil {
+			err = gzErr
+		}
+	}
+	if closeErr := f.file.Close(); err == nil {
+		err = closeErr
 	}
+	if err != nil {
+		return i18n.Errorf("写入文件失败: %w", err)
+	}
+	return nil
 }
 
-func (c *ExportCmd) exportJSON(sess *session.Session) error {
+// writeExportFile 将导出内容写入文件，文件名以 .gz 结尾时使用 gzip 压缩
+func writeExportFile(path string, data []byte) error {
+	f, err := createExportFile(path)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return i18n.Errorf("写入文件失败: %w", err)
+	}
+	return f.Close()
+}
+
+// printerWriter 将写入的内容原样交给 Printer 输出
+type printerWriter struct {
+	p output.Printer
+}
+
+func (w printerWriter) Write(p []byte) (int, error) {
+	w.p.Print(string(p))
+	return len(p), nil
+}
+
+// exportLine export jsonl 的一行
+type exportLine struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+// exportMeta export jsonl 第一行的导出信息，对应 json 格式的顶层字段
+type exportMeta struct {
+	GeneratedAt string `json:"generatedAt"`
+	ScanTime    string `json:"scanTime"`
+	KubeletIP   string `json:"kubeletIP"`
+	APIServer   string `json:"apiServer,omitempty"`
+}
+
+// streamJSONL 以 JSON Lines 格式导出到文件或终端
+func (c *ExportCmd) streamJSONL(sess *session.Session, expr *filter.Expr, path string) error {
 	p := sess.Printer
+	if path == "" {
+		_, err := c.exportJSONL(sess, expr, printerWriter{p: p})
+		return err
+	}
+
+	f, err := createExportFile(path)
+	if err != nil {
+		return err
+	}
+	summary, err := c.exportJSONL(sess, expr, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	p.Success(fmt.Sprintf("Exported jsonl to %s (%s)", path, summary))
+	return nil
+}
+
+// exportJSONL 逐条写出导出记录，每行一个 JSON 对象
+// SA、Pod、检查发现和执行结果直接从数据库游标读取，不在内存中构建完整结果；过滤规则与 exportJSON 相同
+func (c *ExportCmd) exportJSONL(sess *session.Session, expr *filter.Expr, w io.Writer) (string, error) {
+	tf := sess.TimeFormatter(true)
+	enc := json.NewEncoder(w)
+	emit := func(typ string, data any) error {
+		if err := enc.Encode(exportLine{Type: typ, Data: data}); err != nil {
+			return i18n.Errorf("写入 %s 记录失败: %w", typ, err)
+		}
+		return nil
+	}
+
+	err := emit("meta", exportMeta{
+		GeneratedAt: tf.In(time.Now()).Format(time.RFC3339),
+		ScanTime:    tf.In(sess.LastScanTime).Format(time.RFC3339),
+		KubeletIP:   sess.Config.KubeletIP,
+		APIServer:   sess.Config.APIServer,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// 扫描记录
+	scans, err := sess.ScanDB.GetAll()
+	if err != nil {
+		return "", i18n.Errorf("读取扫描记录失败: %w", err)
+	}
+	for _, scan := range scans {
+		scan.StartedAt = tf.In(scan.StartedAt)
+		if err := emit("scan", scan); err != nil {
+			return "", err
+		}
+	}
 
+	// Pod 风险索引需要在遍历 SA 游标之前查询（内存数据库只有一个连接）
+	var risks map[string]string
+	if expr != nil {
+		risks = podRiskIndex(sess)
+	}
+
+	var saCount, podCount, findingCount, resultCount int
+	err = sess.SADB.Each(func(sa *types.ServiceAccountRecord) error {
+		if expr != nil && !expr.Match(filter.SARecord(sa)) {
+			return nil
+		}
+		saCount++
+		return emit("serviceAccount", newExportSA(sa))
+	})
+	if err != nil {
+		return "", i18n.Errorf("导出 ServiceAccount 失败: %w", err)
+	}
+
+	emitPod := func(pod types.PodContainerInfo) error {
+		if expr != nil && !expr.Match(filter.PodRecord(pod, risks[pod.Namespace+"/"+pod.ServiceAccount])) {
+			return nil
+		}
+		podCount++
+		return emit("pod", newExportPod(pod))
+	}
+	// 文件数据库从 pod_cache 表的游标读取；内存数据库不持久化 Pod 快照，使用会话中的缓存
+	if sess.DB.IsInMemory() {
+		for _, pod := range sess.GetCachedPods() {
+			if err := emitPod(pod); err != nil {
+				return "", err
+			}
+		}
+	} else if err := sess.PodCacheDB.Each(emitPod); err != nil {
+		return "", i18n.Errorf("导出 Pod 失败: %w", err)
+	}
+
+	// 检查发现和执行结果不对应 SA / Pod 过滤
+	if expr == nil {
+		err = sess.FindingDB.Each(func(f *types.FindingRecord) error {
+			f.CollectedAt = tf.In(f.CollectedAt)
+			findingCount++
+			return emit("finding", f)
+		})
+		if err != nil {
+			return "", i18n.Errorf("导出检查发现失败: %w", err)
+		}
+
+		err = sess.ExecDB.Each(func(r *types.ExecResultRecord) error {
+			r.Time = tf.In(r.Time)
+			resultCount++
+			return emit("execResult", r)
+		})
+		if err != nil {
+			return "", i18n.Errorf("导出执行结果失败: %w", err)
+		}
+	}
+
+	return fmt.Sprintf("%d SA(s), %d pod(s), %d finding(s), %d exec result(s)",
+		saCount, podCount, findingCount, resultCount), nil
+}
+
+// exportJSON 导出 SA、Pod、检查发现、批量执行结果和扫描记录
+// 指定过滤表达式时只包含匹配的 SA / Pod，不包含检查发现和执行结果
+func (c *ExportCmd) exportJSON(sess *session.Session, expr *filter.Expr) ([]byte, string, error) {
+	tf := sess.TimeFormatter(true)
 	data := ExportData{
-		ScanTime:  sess.LastScanTime.Format(time.RFC3339),
-		KubeletIP: sess.Config.KubeletIP,
+		GeneratedAt:     tf.In(time.Now()).Format(time.RFC3339),
+		ScanTime:        tf.In(sess.LastScanTime).Format(time.RFC3339),
+		KubeletIP:       sess.Config.KubeletIP,
+		APIServer:       sess.Config.APIServer,
+		Scans:           []*types.ScanRecord{},
+		ServiceAccounts: []ExportSA{},
+		Pods:            []ExportPod{},
+		Findings:        []*types.FindingRecord{},
+		ExecResults:     []*types.ExecResultRecord{},
 	}
 
 	// 获取 SA
 	sas, err := sess.SADB.GetAll()
 	if err != nil {
-		return fmt.Errorf("获取 ServiceAccount 失败: %w", err)
+		return nil, "", i18n.Errorf("获取 ServiceAccount 失败: %w", err)
 	}
 
 	for _, sa := range sas {
-		exportSA := ExportSA{
-			Namespace:      sa.Namespace,
-			Name:           sa.Name,
-			RiskLevel:      sa.RiskLevel,
-			IsClusterAdmin: sa.IsClusterAdmin,
-		}
-
-		// 解析权限
-		if sa.Permissions != "" && sa.Permissions != "[]" {
-			var perms []struct {
-				Resource string `json:"resource"`
-				Verb     string `json:"verb"`
-			}
-			if err := json.Unmarshal([]byte(sa.Permissions), &perms); err == nil {
-				for _, perm := range perms {
-					exportSA.Permissions = append(exportSA.Permissions, perm.Resource+":"+perm.Verb)
+		if expr != nil && !expr.Match(filter.SARecord(sa)) {
+			continue
+		}
+		data.ServiceAccounts = append(data.ServiceAccounts, newExportSA(sa))
+	}
+
+	// 获取 Pod
+	pods := sess.GetCachedPods()
+	var risks map[string]string
+	if expr != nil {
+		risks = podRiskIndex(sess)
+	}
+	for _, pod := range pods {
+		if expr != nil && !expr.Match(filter.PodRecord(pod, risks[pod.Namespace+"/"+pod.ServiceAccount])) {
+			continue
+		}
+		data.Pods = append(data.Pods, newExportPod(pod))
+	}
+
+	// 扫描记录
+	scans, err := sess.ScanDB.GetAll()
+	if err != nil {
+		return nil, "", i18n.Errorf("读取扫描记录失败: %w", err)
+	}
+	for _, scan := range scans {
+		scan.StartedAt = tf.In(scan.StartedAt)
+		data.Scans = append(data.Scans, scan)
+	}
+
+	// 检查发现和执行结果不对应 SA / Pod 过滤
+	if expr == nil {
+		findings, err := sess.FindingDB.GetAll()
+		if err != nil {
+			return nil, "", i18n.Errorf("获取检查发现失败: %w", err)
+		}
+		for _, f := range findings {
+			f.CollectedAt = tf.In(f.CollectedAt)
+			data.Findings = append(data.Findings, f)
+		}
+
+		results, err := sess.ExecDB.GetAll()
+		if err != nil {
+			return nil, "", i18n.Errorf("读取执行结果失败: %w", err)
+		}
+		for _, r := range results {
+			r.Time = tf.In(r.Time)
+			data.ExecResults = append(data.ExecResults, r)
+		}
+	}
+
+	// 输出 JSON
+	output, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, "", i18n.Errorf("序列化 JSON 失败: %w", err)
+	}
+
+	summary := fmt.Sprintf("%d SA(s), %d pod(s), %d finding(s), %d exec result(s)",
+		len(data.ServiceAccounts), len(data.Pods), len(data.Findings), len(data.ExecResults))
+	return append(output, '\n'), summary, nil
+}
+
+// newExportSA 将 ServiceAccount 记录转换为导出结构，解析权限和 Pod 列表
+func newExportSA(sa *types.ServiceAccountRecord) ExportSA {
+	exportSA := ExportSA{
+		Namespace:      sa.Namespace,
+		Name:           sa.Name,
+		RiskLevel:      sa.RiskLevel,
+		IsClusterAdmin: sa.IsClusterAdmin,
+		Tags:           sa.TagList(),
+		Note:           sa.Note,
+	}
+
+	// 解析权限
+	if sa.Permissions != "" && sa.Permissions != "[]" {
+		var perms []struct {
+			Resource string `json:"resource"`
+			Verb     string `json:"verb"`
+		}
+		if err := json.Unmarshal([]byte(sa.Permissions), &perms); err == nil {
+			for _, perm := range perms {
+				exportSA.Permissions = append(exportSA.Permissions, perm.Resource+":"+perm.Verb)
+			}
+		}
+	}
+
+	// 解析 Pod
+	if sa.Pods != "" && sa.Pods != "[]" {
+		var pods []struct {
+			Namespace string `json:"namespace"`
+			Name      string `json:"name"`
+		}
+		if err := json.Unmarshal([]byte(sa.Pods), &pods); err == nil {
+			for _, pod := range pods {
+				exportSA.Pods = append(exportSA.Pods, pod.Namespace+"/"+pod.Name)
+			}
+		}
+	}
+	return exportSA
+}
+
+// newExportPod 将缓存的 Pod 转换为导出结构
+func newExportPod(pod types.PodContainerInfo) ExportPod {
+	var images []string
+	for _, ct := range pod.Containers {
+		images = append(images, ct.Image)
+	}
+	return ExportPod{
+		Namespace:      pod.Namespace,
+		Name:           pod.PodName,
+		Status:         pod.Status,
+		PodIP:          pod.PodIP,
+		Node:           pod.NodeName,
+		ServiceAccount: pod.ServiceAccount,
+		Images:         images,
+		Flags:          strings.Join(security.ActiveSecurityFlags(pod.SecurityFlags), ","),
+	}
+}
+
+// csvSheets export csv 可导出的表
+var csvSheets = []string{"sas", "pods", "findings"}
+
+// csvOptions export csv 的选项
+type csvOptions struct {
+	sheet     string // sas、pods 或 findings
+	delimiter rune
+	bom       bool // 写入 UTF-8 BOM，Excel 据此识别编码
+}
+
+// parseCSVDelimiter 解析 CSV 分隔符：单个字符，tab 或 \t 表示制表符
+func parseCSVDelimiter(s string) (rune, error) {
+	if s == "tab" || s == `\t` {
+		return '\t', nil
+	}
+	runes := []rune(s)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\r' || runes[0] == '\n' {
+		return 0, i18n.Errorf("无效的分隔符: %q（单个字符，制表符写作 tab）", s)
+	}
+	return runes[0], nil
+}
+
+// exportCSV 使用 encoding/csv 导出一张表，字段中的分隔符、引号和换行均正确转义
+func (c *ExportCmd) exportCSV(sess *session.Session, expr *filter.Expr, opts csvOptions) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if opts.bom {
+		buf.WriteString("\xEF\xBB\xBF")
+	}
+	w := csv.NewWriter(&buf)
+	w.Comma = opts.delimiter
+
+	count := 0
+	write := func(record ...string) {
+		_ = w.Write(record)
+		count++
+	}
+
+	switch opts.sheet {
+	case "pods":
+		_ = w.Write([]string{"namespace", "name", "status", "pod_ip", "node", "service_account", "images", "flags"})
+		var risks map[string]string
+		if expr != nil {
+			risks = podRiskIndex(sess)
+		}
+		for _, pod := range sess.GetCachedPods() {
+			if expr != nil && !expr.Match(filter.PodRecord(pod, risks[pod.Namespace+"/"+pod.ServiceAccount])) {
+				continue
+			}
+			var images []string
+			for _, ct := range pod.Containers {
+				images = append(images, ct.Image)
+			}
+			write(pod.Namespace, pod.PodName, pod.Status, pod.PodIP, pod.NodeName, pod.ServiceAccount,
+				strings.Join(images, ";"), strings.Join(security.ActiveSecurityFlags(pod.SecurityFlags), ";"))
+		}
+
+	case "findings":
+		if expr != nil {
+			return nil, "", i18n.Errorf("findings 表不支持 --where")
+		}
+		findings, err := sess.FindingDB.GetAll()
+		if err != nil {
+			return nil, "", i18n.Errorf("获取检查发现失败: %w", err)
+		}
+		tf := sess.TimeFormatter(true)
+		_ = w.Write([]string{"severity", "source", "category", "namespace", "pod", "container", "location", "title", "evidence", "collected_at"})
+		for _, f := range findings {
+			write(f.Severity, f.Source, f.Category, f.Namespace, f.Pod, f.Container, f.Location, f.Title, f.Evidence,
+				tf.In(f.CollectedAt).Format(time.RFC3339))
+		}
+
+	default:
+		sas, err := sess.SADB.GetAll()
+		if err != nil {
+			return nil, "", i18n.Errorf("获取 ServiceAccount 失败: %w", err)
+		}
+		_ = w.Write([]string{"namespace", "name", "risk_level", "is_cluster_admin", "permissions", "tags", "note"})
+		for _, sa := range sas {
+			if expr != nil && !expr.Match(filter.SARecord(sa)) {
+				continue
+			}
+
+			// 解析权限
+			var perms []string
+			if sa.Permissions != "" && sa.Permissions != "[]" {
+				var permList []struct {
+					Resource string `json:"resource"`
+					Verb     string `json:"verb"`
+				}
+				if err := json.Unmarshal([]byte(sa.Permissions), &permList); err == nil {
+					for _, perm := range permList {
+						perms = append(perms, perm.Resource+":"+perm.Verb)
+					}
 				}
 			}
+			write(sa.Namespace, sa.Name, sa.RiskLevel, strconv.FormatBool(sa.IsClusterAdmin),
+				strings.Join(perms, ";"), sa.Tags, sa.Note)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, "", i18n.Errorf("生成 CSV 失败: %w", err)
+	}
+	return buf.Bytes(), fmt.Sprintf("%s sheet, %d row(s)", opts.sheet, count), nil
+}
+
+// collectIssues 收集导出的问题：SA 按命中的风险查找表键聚合，Pod 每个安全标识一条，检查发现逐条收集
+// 指定过滤表达式时只包含匹配的 SA / Pod，不包含检查发现
+func (c *ExportCmd) collectIssues(sess *session.Session, expr *filter.Expr) ([]report.Item, error) {
+	var items []report.Item
+
+	sas, err := sess.SADB.GetAll()
+	if err != nil {
+		return nil, i18n.Errorf("获取 ServiceAccount 失败: %w", err)
+	}
+	for _, sa := range sas {
+		if expr != nil && !expr.Match(filter.SARecord(sa)) {
+			continue
+		}
+		object := sa.Namespace + "/" + sa.Name
+		if sa.IsClusterAdmin {
+			items = append(items, report.Item{
+				Rule: "rbac/cluster-admin", Name: "ServiceAccount 为集群管理员", Description: "拥有 */* 全部权限，可完全控制集群",
+				Severity: config.RiskAdmin, Tags: []string{"rbac"}, Kind: "serviceaccount", Object: object,
+				Message: object + " 为集群管理员（*/*）", Techniques: security.PermissionTechniques("*"),
+			})
+			continue
+		}
+
+		var perms []types.SAPermission
+		if sa.Permissions == "" || json.Unmarshal([]byte(sa.Permissions), &perms) != nil {
+			continue
+		}
+		type match struct {
+			level config.RiskLevel
+			verbs []string
+		}
+		matched := make(map[string]*match)
+		var keys []string
+		for _, perm := range perms {
+			if !perm.Allowed {
+				continue
+			}
+			key, level, ok := config.MatchRiskRule(perm.Group, perm.Resource, perm.Subresource, perm.Verb)
+			if !ok {
+				continue
+			}
+			m := matched[key]
+			if m == nil {
+				m = &match{level: level}
+				matched[key] = m
+				keys = append(keys, key)
+			}
+			if !slices.Contains(m.verbs, perm.Verb) {
+				m.verbs = append(m.verbs, perm.Verb)
+			}
 		}
+		for _, key := range keys {
+			m := matched[key]
+			items = append(items, report.Item{
+				Rule: "rbac/" + key, Name: fmt.Sprintf("%s 风险权限: %s", m.level, key),
+				Description: "命中风险查找表规则 " + key + "（rules 命令查看和调整）",
+				Severity:    m.level, Tags: []string{"rbac"}, Kind: "serviceaccount", Object: object,
+				Message:    fmt.Sprintf("%s 拥有 %s 的 %s 权限", object, key, strings.Join(m.verbs, ",")),
+				Techniques: security.PermissionTechniques(key),
+			})
+		}
+	}
+
+	var risks map[string]string
+	if expr != nil {
+		risks = podRiskIndex(sess)
+	}
+	for _, pod := range sess.GetCachedPods() {
+		if expr != nil && !expr.Match(filter.PodRecord(pod, risks[pod.Namespace+"/"+pod.ServiceAccount])) {
+			continue
+		}
+		object := pod.Namespace + "/" + pod.PodName
+		for _, name := range security.ActiveSecurityFlags(pod.SecurityFlags) {
+			flag := config.SecurityFlagConfigs[name]
+			message := object + ": " + flag.Description
+			if name == "Capabilities" {
+				message += "（" + strings.Join(pod.SecurityFlags.Capabilities, ",") + "）"
+			}
+			items = append(items, report.Item{
+				Rule: "pod/" + name, Name: flag.Description, Severity: config.RiskLevel(flag.Level),
+				Tags: []string{"pod-security"}, Kind: "pod", Object: object, Message: message,
+				Techniques: security.SecurityFlagTechniques(name),
+			})
+		}
+	}
 
-		// 解析 Pod
-		if sa.Pods != "" && sa.Pods != "[]" {
-			var pods []struct {
-				Namespace string `json:"namespace"`
-				Name      string `json:"name"`
+	if expr == nil {
+		findings, err := sess.FindingDB.GetAll()
+		if err != nil {
+			return nil, i18n.Errorf("获取检查发现失败: %w", err)
+		}
+		for _, f := range findings {
+			// 带检查标识的位置（id@target）按检查标识归为一条规则，其余按类别
+			rule, check := f.Category, ""
+			if id, _, ok := strings.Cut(f.Location, "@"); ok {
+				rule, check = id, id
 			}
-			if err := json.Unmarshal([]byte(sa.Pods), &pods); err == nil {
-				for _, pod := range pods {
-					exportSA.Pods = append(exportSA.Pods, pod.Namespace+"/"+pod.Name)
+
+			kind, object := "pod", strings.Trim(f.Namespace+"/"+f.Pod, "/")
+			if f.Namespace == "" {
+				kind = f.Category
+				if object == "" {
+					kind, object = "cluster", f.Location
 				}
 			}
+			message := f.Title
+			if f.Evidence != "" {
+				message += ": " + f.Evidence
+			}
+			items = append(items, report.Item{
+				Rule: "finding/" + f.Source + "/" + rule, Name: f.Title, Severity: config.RiskLevel(f.Severity),
+				Tags: []string{f.Source}, Kind: kind, Object: object, Key: f.Container + "|" + f.Location,
+				Message: message, Techniques: security.FindingTechniques(f.Source, f.Category, check),
+			})
 		}
+	}
+	return items, nil
+}
 
-		data.ServiceAccounts = append(data.ServiceAccounts, exportSA)
+// exportSARIF 导出 SARIF 2.1.0，ATT&CK 技术写入规则标签
+func (c *ExportCmd) exportSARIF(sess *session.Session, expr *filter.Expr) ([]byte, string, error) {
+	items, err := c.collectIssues(sess, expr)
+	if err != nil {
+		return nil, "", err
+	}
+	b := sarif.NewBuilder(map[string]string{
+		"scanTime":  sess.TimeFormatter(true).In(sess.LastScanTime).Format(time.RFC3339),
+		"kubeletIP": sess.Config.KubeletIP,
+	})
+	for _, item := range items {
+		tags := item.Tags
+		for _, id := range item.Techniques {
+			tags = append(tags, "mitre-attack/"+id)
+		}
+		b.AddRule(item.Rule, item.Name, item.Description, item.Severity, tags...)
+		b.AddResult(item.Rule, item.Severity, item.Message, item.Kind, item.Object, item.Key)
 	}
 
-	// 获取 Pod
-	pods := sess.GetCachedPods()
-	for _, pod := range pods {
-		data.Pods = append(data.Pods, ExportPod{
-			Namespace: pod.Namespace,
-			Name:      pod.PodName,
-			Status:    pod.Status,
-			PodIP:     pod.PodIP,
-		})
+	output, err := json.MarshalIndent(b.Log(), "", "  ")
+	if err != nil {
+		return nil, "", i18n.Errorf("序列化 SARIF 失败: %w", err)
 	}
+	return append(output, '\n'), fmt.Sprintf("%d result(s)", len(items)), nil
+}
 
-	// 输出 JSON
-	output, err := json.MarshalIndent(data, "", "  ")
+// exportReport 导出 Markdown / HTML 报告，包含 ATT&CK 覆盖矩阵
+func (c *ExportCmd) exportReport(sess *session.Session, expr *filter.Expr, format string) ([]byte, string, error) {
+	items, err := c.collectIssues(sess, expr)
 	if err != nil {
-		return fmt.Errorf("序列化 JSON 失败: %w", err)
+		return nil, "", err
 	}
+	tf := sess.TimeFormatter(true)
+	operations, err := sess.OpDB.GetAll()
+	if err != nil {
+		return nil, "", i18n.Errorf("读取操作记录失败: %w", err)
+	}
+	for _, op := range operations {
+		op.Time = tf.In(op.Time)
+	}
+	r := &report.Report{
+		KubeletIP:   sess.Config.KubeletIP,
+		APIServer:   sess.Config.APIServer,
+		ScanTime:    tf.In(sess.LastScanTime),
+		GeneratedAt: tf.In(time.Now()),
+		Items:       items,
+		Operations:  operations,
+	}
+	r.SortItems()
 
-	p.Println(string(output))
-	return nil
+	var buf strings.Builder
+	if format == "html" {
+		err = r.WriteHTML(&buf)
+	} else {
+		err = r.WriteMarkdown(&buf)
+	}
+	if err != nil {
+		return nil, "", i18n.Errorf("生成报告失败: %w", err)
+	}
+	return []byte(buf.String()), fmt.Sprintf("%d issue(s), %d ATT&CK technique(s)", len(items), len(r.Techniques())), nil
 }
 
-func (c *ExportCmd) exportCSV(sess *session.Session) error {
+// exportBundle 将数据库快照、原始 Pod JSON 和扫描元数据写入单个 tar.gz 归档，path 为空时使用带时间的默认文件名
+func (c *ExportCmd) exportBundle(sess *session.Session, path string) error {
 	p := sess.Printer
 
-	// 获取 SA
-	sas, err := sess.SADB.GetAll()
+	if path == "" {
+		path = fmt.Sprintf("kctl-bundle-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+	if _, err := os.Stat(path); err == nil {
+		return i18n.Errorf("文件已存在: %s", path)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "kctl-bundle-")
 	if err != nil {
-		return fmt.Errorf("获取 ServiceAccount 失败: %w", err)
+		return i18n.Errorf("创建临时目录失败: %w", err)
 	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
 
-	// 输出 CSV 头
-	p.Println("namespace,name,risk_level,is_cluster_admin,permissions")
+	dbPath := filepath.Join(tmpDir, bundle.DBFile)
+	if err := sess.DB.SnapshotTo(dbPath); err != nil {
+		return err
+	}
 
-	for _, sa := range sas {
-		// 解析权限
-		perms := ""
-		if sa.Permissions != "" && sa.Permissions != "[]" {
-			var permList []struct {
-				Resource string `json:"resource"`
-				Verb     string `json:"verb"`
-			}
-			if err := json.Unmarshal([]byte(sa.Permissions), &permList); err == nil {
-				var permStrs []string
-				for _, perm := range permList {
-					permStrs = append(permStrs, perm.Resource+":"+perm.Verb)
-				}
-				perms = strings.Join(permStrs, ";")
-			}
+	pods := sess.GetCachedPods()
+	manifest := &bundle.Manifest{
+		CreatedAt: time.Now(),
+		KubeletIP: sess.Config.KubeletIP,
+		APIServer: sess.Config.APIServer,
+		LastScan:  sess.LastScanTime,
+		Counts:    map[string]int{"pods": len(pods)},
+	}
+	counters := map[string]func() (int, error){
+		"serviceAccounts": sess.SADB.Count,
+		"findings":        sess.FindingDB.Count,
+		"credentials":     sess.CredDB.Count,
+		"deployments":     sess.DeployDB.Count,
+		"scans":           sess.ScanDB.Count,
+		"ports":           sess.PortDB.Count,
+		"execResults":     sess.ExecDB.Count,
+	}
+	for name, count := range counters {
+		if n, err := count(); err == nil {
+			manifest.Counts[name] = n
 		}
+	}
+	if scans, err := sess.ScanDB.GetAll(); err == nil {
+		manifest.Scans = scans
+	}
 
-		// 输出 CSV 行
-		p.Printf("%s,%s,%s,%t,\"%s\"\n",
-			sa.Namespace,
-			sa.Name,
-			sa.RiskLevel,
-			sa.IsClusterAdmin,
-			perms)
+	if err := bundle.Write(path, manifest, dbPath, pods); err != nil {
+		return err
 	}
 
+	size := ""
+	if info, err := os.Stat(path); err == nil {
+		size = fmt.Sprintf(", %.1f KB", float64(info.Size())/1024)
+	}
+	p.Success(fmt.Sprintf("Bundle written to %s (%d SAs, %d pods, %d findings, %d scans%s)",
+		path, manifest.Counts["serviceAccounts"], len(pods), manifest.Counts["findings"], manifest.Counts["scans"], size))
+	p.Printf("%s Bundle contains SA tokens and harvested credentials, open it offline with 'import bundle %s'\n",
+		p.Colored(config.ColorYellow, "[!]"), path)
 	return nil
 }
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/session"
+)
+
+// ExitCodeFindings --fail-on 命中时的进程退出码，与命令执行失败（1）区分
+const ExitCodeFindings = 3
+
+// ParseFailOnLevel 解析 --fail-on 的风险等级（忽略大小写）
+func ParseFailOnLevel(s string) (config.RiskLevel, error) {
+	level := config.RiskLevel(strings.ToUpper(s))
+	if _, ok := config.RiskLevelOrder[level]; !ok || level == config.RiskNone {
+		return "", i18n.Errorf("无效的风险等级: %s (可用: admin, critical, high, medium, low)", s)
+	}
+	return level, nil
+}
+
+// CountIssuesAtOrAbove 按风险等级统计不低于 level 的问题数
+// 问题范围与 export sarif 相同：SA 风险权限、Pod 安全标识和检查发现
+func CountIssuesAtOrAbove(sess *session.Session, level config.RiskLevel) (map[config.RiskLevel]int, error) {
+	items, err := (&ExportCmd{}).collectIssues(sess, nil)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[config.RiskLevel]int)
+	for _, item := range items {
+		if order, ok := config.RiskLevelOrder[item.Severity]; ok && order <= config.RiskLevelOrder[level] {
+			counts[item.Severity]++
+		}
+	}
+	return counts, nil
+}
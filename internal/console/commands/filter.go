@@ -0,0 +1,238 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/filter"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+)
+
+// FilterCmd filter 命令
+type FilterCmd struct{}
+
+func init() {
+	Register(&FilterCmd{})
+}
+
+func (c *FilterCmd) Name() string {
+	return "filter"
+}
+
+func (c *FilterCmd) Aliases() []string {
+	return nil
+}
+
+func (c *FilterCmd) Description() string {
+	return "管理命名过滤器"
+}
+
+// IsReadOnly filter 只读写本地配置文件
+func (c *FilterCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+func (c *FilterCmd) Usage() string {
+	return `filter <list|save|delete|test> [args]
+
+保存过滤表达式并在 pods / sa list / export 中通过 --where @<name> 复用
+过滤器保存在用户配置文件中（默认 ~/.kctl/config.yaml，可用 KCTL_CONFIG 覆盖）
+
+子命令：
+  list                   列出已保存的过滤器
+  save <name> <expr>     保存过滤器（同名覆盖）
+  delete <name>          删除过滤器
+  test <expr|@name>      校验表达式，并统计当前数据中的匹配数量
+  fields                 列出可用字段
+
+表达式语法：
+  比较    ==  !=  ~ (正则)  !~  >=  <=  >  <
+  逻辑    &&  ||  !  ( )
+  布尔    单独的字段名表示为真，如 privileged
+  risk 字段按严重程度比较: ADMIN > CRITICAL > HIGH > MEDIUM > LOW > NONE
+
+示例：
+  filter save prod-risky 'namespace~"^prod" && risk>=HIGH'
+  filter test @prod-risky
+  pods --where @prod-risky
+  sa list --where 'admin || risk>=CRITICAL'
+  export json --where @prod-risky
+  filter delete prod-risky`
+}
+
+// Suggestions filter 的子命令及已保存过滤器补全
+func (c *FilterCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) == 0 {
+		return []completion.Suggestion{
+			{Text: "list", Description: "列出已保存的过滤器"},
+			{Text: "save", Description: "保存过滤器"},
+			{Text: "delete", Description: "删除过滤器"},
+			{Text: "test", Description: "校验表达式"},
+			{Text: "fields", Description: "列出可用字段"},
+		}
+	}
+	if len(args) != 1 {
+		return nil
+	}
+	switch args[0] {
+	case "test":
+		return completion.SavedFilters(sess, args)
+	case "delete", "rm":
+		suggestions := completion.SavedFilters(sess, args)
+		for i := range suggestions {
+			suggestions[i].Text = strings.TrimPrefix(suggestions[i].Text, "@")
+		}
+		return suggestions
+	}
+	return nil
+}
+
+func (c *FilterCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) == 0 {
+		return i18n.Errorf("用法: filter <list|save|delete|test|fields>")
+	}
+
+	p := sess.Printer
+
+	switch args[0] {
+	case "list", "ls":
+		return c.list(sess)
+
+	case "save":
+		if len(args) < 3 {
+			return i18n.Errorf("用法: filter save <name> <expr>")
+		}
+		name := args[1]
+		if err := filter.Save(name, strings.Join(args[2:], " ")); err != nil {
+			return err
+		}
+		path, _ := config.UserConfigPath()
+		p.Success(fmt.Sprintf("Filter '%s' saved to %s", name, path))
+		return nil
+
+	case "delete", "rm":
+		if len(args) < 2 {
+			return i18n.Errorf("用法: filter delete <name>")
+		}
+		if err := filter.Delete(args[1]); err != nil {
+			return err
+		}
+		p.Success(fmt.Sprintf("Filter '%s' deleted", args[1]))
+		return nil
+
+	case "test":
+		if len(args) < 2 {
+			return i18n.Errorf("用法: filter test <expr|@name>")
+		}
+		return c.test(sess, strings.Join(args[1:], " "))
+
+	case "fields":
+		var rows [][]string
+		for _, name := range filter.FieldNames() {
+			rows = append(rows, []string{name, filter.KnownFields[name]})
+		}
+		p.Println()
+		output.NewTablePrinter().PrintSimple([]string{"FIELD", "DESCRIPTION"}, rows)
+		p.Println()
+		return nil
+
+	default:
+		return i18n.Errorf("未知子命令: %s (可用: list, save, delete, test, fields)", args[0])
+	}
+}
+
+// list 列出已保存的过滤器
+func (c *FilterCmd) list(sess *session.Session) error {
+	p := sess.Printer
+
+	saved, err := filter.Saved()
+	if err != nil {
+		return err
+	}
+	if len(saved) == 0 {
+		p.Warning(i18n.T("没有已保存的过滤器，使用 'filter save <name> <expr>' 创建"))
+		return nil
+	}
+
+	names := make([]string, 0, len(saved))
+	for name := range saved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rows [][]string
+	for _, name := range names {
+		rows = append(rows, []string{"@" + name, saved[name]})
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"NAME", "EXPRESSION"}, rows)
+	p.Printf("\n  %s\n\n", i18n.Tf("共 %d 个过滤器", len(rows)))
+	return nil
+}
+
+// test 校验表达式并统计匹配数量
+func (c *FilterCmd) test(sess *session.Session, arg string) error {
+	p := sess.Printer
+
+	expr, err := filter.Resolve(arg)
+	if err != nil {
+		return err
+	}
+	p.Printf("%s Expression OK: %s\n", p.Colored(config.ColorBlue, "[*]"), expr.String())
+
+	if pods := sess.GetCachedPods(); len(pods) > 0 {
+		risks := podRiskIndex(sess)
+		matched := 0
+		for _, pod := range pods {
+			if expr.Match(filter.PodRecord(pod, risks[pod.Namespace+"/"+pod.ServiceAccount])) {
+				matched++
+			}
+		}
+		p.Printf("    Pods:            %d/%d matched\n", matched, len(pods))
+	}
+
+	if sess.IsScanned {
+		sas, err := sess.SADB.GetAll()
+		if err != nil {
+			return i18n.Errorf("获取 ServiceAccount 失败: %w", err)
+		}
+		matched := 0
+		for _, sa := range sas {
+			if expr.Match(filter.SARecord(sa)) {
+				matched++
+			}
+		}
+		p.Printf("    ServiceAccounts: %d/%d matched\n", matched, len(sas))
+	}
+	return nil
+}
+
+// parseWhere 解析 --where 参数值，未指定时返回 nil
+func parseWhere(value string) (*filter.Expr, error) {
+	if value == "" {
+		return nil, nil
+	}
+	return filter.Resolve(value)
+}
+
+// podRiskIndex 返回 namespace/sa -> 风险等级，未扫描时为空
+func podRiskIndex(sess *session.Session) map[string]string {
+	index := make(map[string]string)
+	if !sess.IsScanned || sess.SADB == nil {
+		return index
+	}
+	sas, err := sess.SADB.GetAll()
+	if err != nil {
+		return index
+	}
+	for _, sa := range sas {
+		index[sa.Namespace+"/"+sa.Name] = filter.SARecord(sa)["risk"]
+	}
+	return index
+}
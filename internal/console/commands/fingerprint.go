@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/internal/vulndb"
+)
+
+// FingerprintKubelet 识别 Kubelet 版本并输出匹配的已知 CVE
+// 版本来源依次为: Kubelet /metrics、节点缓存、API Server /version
+func FingerprintKubelet(ctx context.Context, sess *session.Session) {
+	p := sess.Printer
+
+	version, source := detectKubeletVersion(ctx, sess)
+	if version == "" {
+		p.Printf("%s Kubelet version: %s\n",
+			p.Colored(config.ColorBlue, "[*]"),
+			p.Colored(config.ColorGray, "unknown"))
+		return
+	}
+	sess.KubeletVersion = version
+
+	p.Printf("%s Kubelet version: %s %s\n",
+		p.Colored(config.ColorBlue, "[*]"),
+		p.Colored(config.ColorCyan, version),
+		p.Colored(config.ColorGray, "("+source+")"))
+
+	matches, err := vulndb.Match(version)
+	if err != nil {
+		p.Warning(i18n.Tf("CVE 匹配失败: %v", err))
+		return
+	}
+	if len(matches) == 0 {
+		p.Printf("%s No known kubelet-related CVEs for this version\n",
+			p.Colored(config.ColorGreen, "[+]"))
+		return
+	}
+
+	p.Printf("%s %d known CVEs may affect this version:\n",
+		p.Colored(config.ColorRed, "[!]"), len(matches))
+	for _, cve := range matches {
+		note := ""
+		if cve.OS != "" {
+			note = p.Colored(config.ColorGray, i18n.Tf(" (仅 %s 节点)", cve.OS))
+		}
+		p.Printf("    %s %-16s %-10s %s%s\n",
+			p.Formatter().FormatRiskLevelColored(cve.Severity),
+			cve.ID,
+			cve.Component,
+			cve.Title,
+			note)
+	}
+}
+
+// AuditKubeletCert 解析 Kubelet 服务端证书并输出低危审计发现
+func AuditKubeletCert(ctx context.Context, sess *session.Session) {
+	p := sess.Printer
+
+	kubelet, err := sess.GetKubeletClient()
+	if err != nil {
+		return
+	}
+	chain, err := kubelet.GetServingCertificates(ctx)
+	if err != nil {
+		sess.KubeletCertExp = time.Time{}
+		p.Printf("%s Kubelet cert: %s\n",
+			p.Colored(config.ColorBlue, "[*]"),
+			p.Colored(config.ColorGray, "unavailable"))
+		return
+	}
+
+	cert := chain[0]
+	sess.KubeletCertExp = cert.NotAfter
+	p.Printf("%s Kubelet cert: %s %s\n",
+		p.Colored(config.ColorBlue, "[*]"),
+		p.Colored(config.ColorCyan, cert.Subject.CommonName),
+		p.Colored(config.ColorGray, fmt.Sprintf("(issuer %s, expires %s)",
+			cert.Issuer.CommonName, cert.NotAfter.Format("2006-01-02"))))
+
+	for _, f := range security.AuditServingCert(chain, time.Now()) {
+		p.Printf("    %s %s: %s\n",
+			p.Formatter().FormatRiskLevelColored(f.Severity),
+			f.Title,
+			f.Detail)
+	}
+}
+
+// detectKubeletVersion 探测 Kubelet 版本，返回版本与来源
+func detectKubeletVersion(ctx context.Context, sess *session.Session) (string, string) {
+	if kubelet, err := sess.GetKubeletClient(); err == nil {
+		if version, err := kubelet.GetVersion(ctx); err == nil && version != "" {
+			return version, "kubelet /metrics"
+		}
+	}
+
+	for _, node := range sess.GetCachedNodes() {
+		if node.InternalIP == sess.Config.KubeletIP && strings.HasPrefix(node.KubeletVersion, "v") {
+			return node.KubeletVersion, "node status"
+		}
+	}
+
+	if sess.Config.APIServer != "" {
+		if k8s, err := sess.GetK8sClient(sess.GetActiveToken()); err == nil {
+			if version, err := k8s.GetVersion(ctx); err == nil && version != "" {
+				return version, "apiserver /version"
+			}
+		}
+	}
+
+	return "", ""
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"strings"
+
+	"kctl/internal/console/completion"
+	"kctl/internal/manifest"
+	"kctl/internal/session"
+)
+
+// 多个命令共用的选项补全元数据
+var (
+	flagConfirm   = completion.Flag{Name: ConfirmFlag, Description: "以 cluster-admin 身份执行时确认"}
+	flagYes       = completion.Flag{Name: YesFlag, Description: "跳过影响范围确认提示"}
+	flagSeparator = completion.Flag{Name: completion.Separator, Description: "命令分隔符"}
+	flagNamespace = completion.Flag{Name: "-n", Arg: "<namespace>", Description: "指定命名空间", Values: completion.Namespaces}
+	flagContainer = completion.Flag{Name: "-c", Arg: "<container>", Description: "指定容器", Values: completion.Containers}
+	flagWhere     = completion.Flag{Name: "--where", Short: "-w", Arg: "<expr>", Description: "按过滤表达式筛选", Values: completion.SavedFilters}
+	flagAbsolute  = completion.Flag{Name: "--absolute", Description: "显示绝对时间"}
+)
+
+// 部署 Pod 时的存活相关选项（deploy / nodeshell）
+var (
+	flagPriorityClass = completion.Flag{Name: "--priority-class", Arg: "<name>", Description: "priorityClassName", Values: completion.Choices(
+		completion.Suggestion{Text: "system-node-critical", Description: "节点关键（最后被驱逐）"},
+		completion.Suggestion{Text: "system-cluster-critical", Description: "集群关键"},
+	)}
+	flagTolerations = completion.Flag{Name: "--tolerations", Arg: "<all|none>", Description: "污点容忍（默认: all）", Values: completion.Choices(
+		completion.Suggestion{Text: manifest.TolerateAll, Description: "容忍所有污点"},
+		completion.Suggestion{Text: manifest.TolerateNone, Description: "不设置容忍"},
+	)}
+	flagRestartPolicy = completion.Flag{Name: "--restart-policy", Arg: "<policy>", Description: "restartPolicy（默认: Always）", Values: completion.Choices(
+		completion.Suggestion{Text: "Always"},
+		completion.Suggestion{Text: "OnFailure"},
+		completion.Suggestion{Text: "Never"},
+	)}
+)
+
+// batchFlags exec / run / hunt 批量执行共用的选项
+var batchFlags = []completion.Flag{
+	{Name: "--filter", Arg: "<pods>", Description: "排除指定 Pod（逗号分隔）", Values: completion.ExcludePods},
+	{Name: "--filter-ns", Arg: "<ns>", Description: "排除指定命名空间（逗号分隔）", Values: completion.Namespaces},
+	{Name: "--concurrency", Arg: "<n>", Description: "最大并发数（默认: 10）", Values: completion.Concurrency},
+}
+
+// withFlags 拼接多组选项
+func withFlags(groups ...[]completion.Flag) []completion.Flag {
+	var flags []completion.Flag
+	for _, g := range groups {
+		flags = append(flags, g...)
+	}
+	return flags
+}
+
+// positionalArgs 返回已输入的位置参数（去掉选项及带值选项的值）
+func positionalArgs(flags []completion.Flag, args []string) []string {
+	takesValue := make(map[string]bool)
+	for _, f := range flags {
+		if f.Arg != "" {
+			takesValue[f.Name] = true
+			if f.Short != "" {
+				takesValue[f.Short] = true
+			}
+		}
+	}
+
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case takesValue[args[i]]:
+			i++
+		case !strings.HasPrefix(args[i], "-"):
+			positional = append(positional, args[i])
+		}
+	}
+	return positional
+}
+
+// podSuggestions 尚未输入 Pod 名时返回 Pod 候选（已输入 -n 时只显示该命名空间的 Pod）
+func podSuggestions(sess *session.Session, cmd Completable, args []string, pods completion.ValueFunc) []completion.Suggestion {
+	if len(positionalArgs(cmd.Flags(args), args)) > 0 {
+		return nil
+	}
+	return pods(sess, args)
+}
+
+// subcommandSuggestions 正在输入第一个参数时返回子命令候选，否则返回 nil
+func subcommandSuggestions(args []string, subs ...completion.Suggestion) []completion.Suggestion {
+	if len(args) > 0 {
+		return nil
+	}
+	return subs
+}
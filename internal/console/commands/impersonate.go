@@ -0,0 +1,252 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"kctl/config"
+	k8sclient "kctl/internal/client/k8s"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/rbac"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// ImpersonateCmd impersonate 命令
+type ImpersonateCmd struct{}
+
+func init() {
+	Register(&ImpersonateCmd{})
+}
+
+func (c *ImpersonateCmd) Name() string {
+	return "impersonate"
+}
+
+func (c *ImpersonateCmd) Aliases() []string {
+	return nil
+}
+
+func (c *ImpersonateCmd) Description() string {
+	return "测试并采用模拟身份访问 API Server"
+}
+
+func (c *ImpersonateCmd) Usage() string {
+	return `impersonate
+impersonate test <user> [-g <group>]...
+impersonate <user> [-g <group>]...
+impersonate sa <namespace/name>
+impersonate off
+
+当前 Token 拥有 impersonate 权限时，以其他用户 / 组 / SA 的身份访问 API Server
+（请求附带 Impersonate-User / Impersonate-Group 头，Kubelet 请求不受影响）
+
+不带参数时显示当前模拟身份
+test 只检查能否模拟并列出模拟后的权限，不采用；不带 test 时检查通过后采用该身份
+sa 模拟 ServiceAccount（用户 system:serviceaccount:<ns>:<name>，只需 serviceaccounts 的 impersonate 权限）
+
+选项：
+  -g, --group <group>  同时模拟的组（可重复，或逗号分隔）
+  --force              跳过 impersonate 权限检查直接采用
+
+示例：
+  impersonate test system:admin -g system:masters
+  impersonate system:admin -g system:masters
+  impersonate sa kube-system/clusterrole-aggregation-controller
+  impersonate off`
+}
+
+// Flags impersonate 的选项补全
+func (c *ImpersonateCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--group", Short: "-g", Arg: "<group>", Description: "同时模拟的组", Values: completion.Choices(
+			completion.Suggestion{Text: "system:masters", Description: "绕过 RBAC 的超级用户组"},
+			completion.Suggestion{Text: "system:nodes", Description: "节点组"},
+			completion.Suggestion{Text: "system:authenticated", Description: "所有已认证身份"},
+		)},
+		{Name: "--force", Description: "跳过权限检查直接采用"},
+	}
+}
+
+// Suggestions impersonate 的子命令补全
+func (c *ImpersonateCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) > 0 {
+		return nil
+	}
+	return []completion.Suggestion{
+		{Text: "test", Description: "测试模拟身份，不采用"},
+		{Text: "sa", Description: "模拟 ServiceAccount"},
+		{Text: "off", Description: "取消模拟"},
+	}
+}
+
+func (c *ImpersonateCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	if len(args) == 0 {
+		if sess.Config.ImpersonateUser == "" {
+			p.Info("Not impersonating")
+			return nil
+		}
+		p.Printf("%s Impersonating %s\n", p.Colored(config.ColorYellow, "[!]"),
+			formatImpersonation(sess.Config.ImpersonateUser, sess.Config.ImpersonateGroups))
+		return nil
+	}
+	if args[0] == "off" || args[0] == "none" {
+		sess.SetImpersonation("", nil)
+		p.Success("Impersonation disabled")
+		return nil
+	}
+
+	adopt := true
+	if args[0] == "test" {
+		adopt = false
+		args = args[1:]
+	}
+
+	user := ""
+	var groups []string
+	force := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-g", "--group":
+			if i+1 >= len(args) {
+				return i18n.Errorf("%s 需要指定组", args[i])
+			}
+			groups = append(groups, parseFilterList(args[i+1])...)
+			i++
+		case "--force":
+			force = true
+		case "sa":
+			if user != "" || i+1 >= len(args) {
+				return i18n.Errorf("用法: impersonate sa <namespace/name>")
+			}
+			namespace, name, ok := strings.Cut(args[i+1], "/")
+			if !ok || namespace == "" || name == "" {
+				return i18n.Errorf("无效的 SA: %s (格式: namespace/name)", args[i+1])
+			}
+			user = "system:serviceaccount:" + namespace + ":" + name
+			i++
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return i18n.Errorf("未知参数: %s", args[i])
+			}
+			if user != "" {
+				return i18n.Errorf("只能指定一个用户: %s", args[i])
+			}
+			user = args[i]
+		}
+	}
+	if user == "" {
+		return i18n.Errorf("用法: impersonate [test] <user> [-g <group>]...")
+	}
+	if sess.Config.APIServer == "" {
+		return i18n.Errorf("未设置 API Server，请使用 'set api-server <addr>' 设置")
+	}
+
+	identity := formatImpersonation(user, groups)
+	if !force {
+		if err := c.checkAllowed(sess, user, groups); err != nil {
+			return err
+		}
+		if err := c.showPermissions(sess, user, groups); err != nil {
+			return err
+		}
+	}
+	if !adopt {
+		return nil
+	}
+	sess.SetImpersonation(user, groups)
+	p.Success(fmt.Sprintf("API Server requests will impersonate: %s ('impersonate off' to stop)", identity))
+	return nil
+}
+
+// checkAllowed 检查当前 Token（不模拟）能否模拟用户及各个组
+func (c *ImpersonateCmd) checkAllowed(sess *session.Session, user string, groups []string) error {
+	p := sess.Printer
+	k8s, err := sess.NewK8sClientAs(sess.GetActiveToken(), "", nil)
+	if err != nil {
+		return err
+	}
+
+	reqs := []k8sclient.PermissionRequest{impersonateRequest(user)}
+	for _, group := range groups {
+		reqs = append(reqs, k8sclient.PermissionRequest{Resource: "groups", Verb: "impersonate", Name: group})
+	}
+
+	var denied []string
+	for _, req := range reqs {
+		allowed, err := k8s.CheckPermission(sess.Context(), &req)
+		if err != nil {
+			return i18n.Errorf("检查 impersonate 权限失败: %w", err)
+		}
+		if !allowed {
+			denied = append(denied, req.Resource+"/"+req.Name)
+		}
+	}
+	if len(denied) > 0 {
+		return i18n.Errorf("当前 Token 无权模拟: %s", strings.Join(denied, ", "))
+	}
+	p.Printf("%s Current token can impersonate %s\n", p.Colored(config.ColorGreen, "[+]"), formatImpersonation(user, groups))
+	return nil
+}
+
+// showPermissions 以模拟身份检查常用权限并输出风险等级
+func (c *ImpersonateCmd) showPermissions(sess *session.Session, user string, groups []string) error {
+	p := sess.Printer
+	k8s, err := sess.NewK8sClientAs(sess.GetActiveToken(), user, groups)
+	if err != nil {
+		return err
+	}
+
+	p.Printf("%s Checking permissions as %s...\n", p.Colored(config.ColorBlue, "[*]"), user)
+	perms, err := k8s.CheckCommonPermissions(sess.Context(), "")
+	if err != nil {
+		return i18n.Errorf("以模拟身份检查权限失败: %w", err)
+	}
+	assessment := rbac.AssessRiskFromPermissions(perms)
+
+	p.Printf("    %-14s: %s\n", "Risk", p.Formatter().FormatRiskLevelColored(assessment.Level))
+	if names := permNames(assessment.AdminPerms); len(names) > 0 {
+		p.Printf("    %-14s: %s\n", "Admin", p.Colored(config.ColorRed, strings.Join(names, ", ")))
+	}
+	if names := permNames(assessment.DangerousPerms); len(names) > 0 {
+		p.Printf("    %-14s: %s\n", "Dangerous", p.Colored(config.ColorYellow, strings.Join(names, ", ")))
+	}
+	if assessment.IsClusterAdmin {
+		p.Printf("%s Impersonated identity is cluster-admin\n", p.Colored(config.ColorRed, "[!]"))
+	}
+	return nil
+}
+
+// impersonateRequest 模拟用户所需的权限：SA 用户名检查 serviceaccounts，其余检查 users
+func impersonateRequest(user string) k8sclient.PermissionRequest {
+	if rest, ok := strings.CutPrefix(user, "system:serviceaccount:"); ok {
+		if namespace, name, ok := strings.Cut(rest, ":"); ok {
+			return k8sclient.PermissionRequest{Resource: "serviceaccounts", Verb: "impersonate", Namespace: namespace, Name: name}
+		}
+	}
+	return k8sclient.PermissionRequest{Resource: "users", Verb: "impersonate", Name: user}
+}
+
+// formatImpersonation 格式化模拟身份，如 system:admin (groups: system:masters)
+func formatImpersonation(user string, groups []string) string {
+	if len(groups) == 0 {
+		return user
+	}
+	return user + " (groups: " + strings.Join(groups, ", ") + ")"
+}
+
+// permNames 格式化权限列表，如 pods/exec:create
+func permNames(results []types.PermissionCheckResult) []string {
+	var names []string
+	for _, r := range results {
+		resource := r.Resource
+		if r.Subresource != "" {
+			resource += "/" + r.Subresource
+		}
+		names = append(names, resource+":"+r.Verb)
+	}
+	return names
+}
@@ -0,0 +1,287 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/bundle"
+	"kctl/internal/client/kubelet"
+	"kctl/internal/console/commands/sa"
+	"kctl/internal/console/completion"
+	"kctl/internal/db"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// ImportCmd import 命令
+type ImportCmd struct{}
+
+func init() {
+	Register(&ImportCmd{})
+}
+
+func (c *ImportCmd) Name() string {
+	return "import"
+}
+
+func (c *ImportCmd) Aliases() []string {
+	return nil
+}
+
+func (c *ImportCmd) Description() string {
+	return "导入归档或离线 Pod JSON"
+}
+
+// IsReadOnly import bundle 只写入新的本地数据库文件，import pods 会写入当前数据库
+func (c *ImportCmd) IsReadOnly(args []string) bool {
+	return len(args) > 0 && args[0] == "bundle"
+}
+
+func (c *ImportCmd) Usage() string {
+	return `import bundle <file> [--out <db>]
+import pods <file>
+
+bundle  将 export bundle 生成的归档解压为数据库文件，并写入归档中的原始 Pod 列表和扫描时间
+        之后使用 kctl console --viewer --db <db> 离线浏览（sa list、pods、hunt list、diff 等）
+pods    离线分析预先获取的 Kubelet /pods 响应（或 kubectl get pods -o json 的输出），不访问网络：
+        标记特权 / hostPath / hostPID 等风险，按 Pod 规格评估 SA 风险（同 sa scan --passive），
+        检查环境变量中的明文凭据和云身份配置，结果写入当前数据库
+
+选项：
+  --out, -o <db>  bundle 解压的数据库文件路径（默认为归档同名的 .db 文件，不覆盖已有文件）
+
+示例：
+  import bundle field.tar.gz
+  import bundle field.tar.gz --out /tmp/field.db
+  import pods pods.json
+
+命令行离线分析：kctl analyze pods.json`
+}
+
+// Flags import 的选项补全
+func (c *ImportCmd) Flags(args []string) []completion.Flag {
+	if len(args) == 0 {
+		return nil
+	}
+	if args[0] != "bundle" {
+		return nil
+	}
+	return []completion.Flag{
+		{Name: "--out", Short: "-o", Arg: "<db>", Description: "数据库文件路径"},
+	}
+}
+
+// Suggestions import 的子命令补全
+func (c *ImportCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return subcommandSuggestions(args,
+		completion.Suggestion{Text: "bundle", Description: "导入 export bundle 归档"},
+		completion.Suggestion{Text: "pods", Description: "离线分析 /pods JSON"},
+	)
+}
+
+func (c *ImportCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) == 0 {
+		return i18n.Errorf("用法: import <bundle|pods> <file>")
+	}
+	switch args[0] {
+	case "bundle":
+		return c.importBundle(sess, args[1:])
+	case "pods":
+		if len(args) != 2 {
+			return i18n.Errorf("用法: import pods <file>")
+		}
+		return c.importPods(sess, args[1])
+	default:
+		return i18n.Errorf("未知的导入类型: %s (可用: bundle, pods)", args[0])
+	}
+}
+
+// importBundle 解压 export bundle 归档为数据库文件
+func (c *ImportCmd) importBundle(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	path, out := "", ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--out", "-o":
+			if i+1 >= len(args) {
+				return i18n.Errorf("%s 需要指定文件路径", args[i])
+			}
+			out = args[i+1]
+			i++
+		default:
+			if path != "" {
+				return i18n.Errorf("多余的参数: %s", args[i])
+			}
+			path = args[i]
+		}
+	}
+	if path == "" {
+		return i18n.Errorf("用法: import bundle <file> [--out <db>]")
+	}
+	if out == "" {
+		out = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(path, ".gz"), ".tar"), ".tgz") + ".db"
+	}
+	if _, err := os.Stat(out); err == nil {
+		return i18n.Errorf("文件已存在: %s，使用 --out 指定其他路径", out)
+	}
+
+	manifest, pods, err := bundle.Read(path, out)
+	if err != nil {
+		return err
+	}
+	if err := c.restore(out, manifest, pods); err != nil {
+		_ = os.Remove(out)
+		return err
+	}
+
+	tf := sess.TimeFormatter(true)
+	p.Success(fmt.Sprintf("Imported %s into %s", path, out))
+	p.Printf("    Created:   %s\n", tf.Format(manifest.CreatedAt))
+	if manifest.KubeletIP != "" {
+		p.Printf("    Kubelet:   %s\n", manifest.KubeletIP)
+	}
+	if manifest.APIServer != "" {
+		p.Printf("    APIServer: %s\n", manifest.APIServer)
+	}
+	if !manifest.LastScan.IsZero() {
+		p.Printf("    Last scan: %s\n", tf.Format(manifest.LastScan))
+	}
+	p.Printf("    Contents:  %d SAs, %d pods, %d findings, %d credentials, %d scans\n",
+		manifest.Counts["serviceAccounts"], len(pods), manifest.Counts["findings"],
+		manifest.Counts["credentials"], manifest.Counts["scans"])
+	p.Printf("%s Open offline with: kctl console --viewer --db %s\n", p.Colored(config.ColorBlue, "[*]"), out)
+	return nil
+}
+
+// restore 将归档中的 Pod 列表和扫描时间写入解压出的数据库
+// 导出时为内存数据库的归档不含 Pod 快照，需要从 pods.json 补充，查看模式才能浏览 Pod
+func (c *ImportCmd) restore(path string, manifest *bundle.Manifest, pods []types.PodContainerInfo) error {
+	database, err := db.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	if len(pods) > 0 {
+		if err := db.NewPodCacheRepository(database).Replace(pods); err != nil {
+			return err
+		}
+	}
+	if !manifest.LastScan.IsZero() {
+		if err := database.SetMeta(db.MetaLastScan, manifest.LastScan.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importPods 离线分析 /pods JSON：风险标记、被动 SA 评估、环境变量凭据和云身份检查
+func (c *ImportCmd) importPods(sess *session.Session, path string) error {
+	p := sess.Printer
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return i18n.Errorf("读取文件失败: %w", err)
+	}
+	pods, err := kubelet.ParsePods(raw)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return i18n.Errorf("文件中没有 Pod: %s", path)
+	}
+
+	namespaces := make(map[string]bool)
+	nodes := make(map[string]bool)
+	for _, pod := range pods {
+		namespaces[pod.Namespace] = true
+		if pod.NodeName != "" {
+			nodes[pod.NodeName] = true
+		}
+	}
+	p.Printf("%s Offline analysis of %s: %d pods, %d namespaces, %d nodes (no network access)\n",
+		p.Colored(config.ColorBlue, "[*]"), path, len(pods), len(namespaces), len(nodes))
+
+	sess.CachePods(pods)
+	if err := sa.AnalyzeOffline(sess, pods, "offline:"+filepath.Base(path)); err != nil {
+		return err
+	}
+
+	findings := c.envCredentials(sess, pods)
+	p.Println()
+	if len(findings) == 0 {
+		p.Printf("%s No plaintext credentials in container env\n", p.Colored(config.ColorGreen, "[+]"))
+	} else {
+		if _, err := sess.FindingDB.SaveBatch(findings); err != nil {
+			return i18n.Errorf("保存 findings 失败: %w", err)
+		}
+		var rows [][]string
+		for _, f := range findings {
+			rows = append(rows, []string{
+				p.Formatter().FormatRiskLevelColored(config.RiskLevel(f.Severity)),
+				f.Namespace + "/" + f.Pod,
+				f.Container,
+				f.Location,
+				f.Title,
+				security.MaskSecret(f.Evidence),
+			})
+		}
+		p.Printf("%s %d credential(s) in container env:\n", p.Colored(config.ColorYellow, "[!]"), len(findings))
+		output.NewTablePrinter().PrintSimple([]string{"SEVERITY", "POD", "CONTAINER", "LOCATION", "TITLE", "EVIDENCE"}, rows)
+	}
+
+	p.Println()
+	p.Info(i18n.T("使用 pods、sa list、hunt list --reveal 浏览离线分析结果"))
+	return nil
+}
+
+// envCredentials 检查 Pod 规格中容器环境变量的明文凭据（来源 podspec）
+func (c *ImportCmd) envCredentials(sess *session.Session, pods []types.PodContainerInfo) []*types.FindingRecord {
+	var findings []*types.FindingRecord
+	now := time.Now()
+
+	for _, pod := range pods {
+		for _, container := range pod.Containers {
+			names := make([]string, 0, len(container.Env))
+			for name := range container.Env {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				m, ok := security.MatchCredential(name, container.Env[name])
+				if !ok {
+					continue
+				}
+				findings = append(findings, &types.FindingRecord{
+					Source:      "podspec",
+					Severity:    string(m.Severity),
+					Category:    "env",
+					Namespace:   pod.Namespace,
+					Pod:         pod.PodName,
+					Container:   container.Name,
+					Location:    "env:" + name,
+					Title:       m.Title,
+					Evidence:    m.Evidence,
+					CollectedAt: now,
+					KubeletIP:   sess.Config.KubeletIP,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return config.RiskLevelOrder[config.RiskLevel(findings[i].Severity)] <
+			config.RiskLevelOrder[config.RiskLevel(findings[j].Severity)]
+	})
+	return findings
+}
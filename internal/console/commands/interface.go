@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"kctl/internal/console/completion"
 	"kctl/internal/session"
 )
 
@@ -22,6 +23,45 @@ type Command interface {
 	Execute(sess *session.Session, args []string) error
 }
 
+// ConfirmFlag 以 cluster-admin 身份执行破坏性命令时需要的确认参数
+const ConfirmFlag = "--confirm"
+
+// Destructive 可能修改目标环境的命令
+// 当前 SA 为 cluster-admin 时，执行器要求显式指定 --confirm
+type Destructive interface {
+	IsDestructive(args []string) bool
+}
+
+// ReadOnly 只读取本地数据、不访问集群也不修改数据库的命令
+// 查看模式（console --viewer）下只允许执行 IsReadOnly 返回 true 的命令
+type ReadOnly interface {
+	IsReadOnly(args []string) bool
+}
+
+// Completable 提供选项补全数据的命令
+// args 为命令名之后已输入完成的参数，有子命令的命令可按子命令返回不同选项
+type Completable interface {
+	Flags(args []string) []completion.Flag
+}
+
+// Suggester 提供位置参数补全的命令（子命令、Pod 名等）
+type Suggester interface {
+	Suggestions(sess *session.Session, args []string) []completion.Suggestion
+}
+
+// Suggest 返回命令在已输入参数之后的补全候选（未按前缀过滤）
+func Suggest(sess *session.Session, cmd Command, args []string) []completion.Suggestion {
+	var flags []completion.Flag
+	if c, ok := cmd.(Completable); ok {
+		flags = c.Flags(args)
+	}
+	var positional []completion.Suggestion
+	if s, ok := cmd.(Suggester); ok {
+		positional = s.Suggestions(sess, args)
+	}
+	return completion.Complete(sess, flags, positional, args)
+}
+
 // 命令注册表
 var registry = make(map[string]Command)
 
@@ -62,3 +102,9 @@ func Names() []string {
 	}
 	return names
 }
+
+// Paged 输出表格或报告的命令
+// 开启分页（set pager on）时执行器缓存其输出，超过终端高度时通过分页器显示
+type Paged interface {
+	IsPaged(args []string) bool
+}
@@ -1,9 +1,9 @@
 package commands
 
 import (
-	"fmt"
-
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
 	"kctl/internal/session"
 )
 
@@ -41,6 +41,14 @@ func (c *ModeCmd) Usage() string {
   mode k8s             切换到 Kubernetes 模式（简写）`
 }
 
+// Suggestions mode 的模式补全
+func (c *ModeCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return subcommandSuggestions(args,
+		completion.Suggestion{Text: "kubelet", Description: "Kubelet API (10250)"},
+		completion.Suggestion{Text: "kubernetes", Description: "API Server (6443)"},
+	)
+}
+
 func (c *ModeCmd) Execute(sess *session.Session, args []string) error {
 	p := sess.Printer
 
@@ -51,7 +59,7 @@ func (c *ModeCmd) Execute(sess *session.Session, args []string) error {
 
 	newMode := session.ParseMode(args[0])
 	if newMode == "" {
-		return fmt.Errorf("无效的模式: %s，可选: kubelet, kubernetes", args[0])
+		return i18n.Errorf("无效的模式: %s，可选: kubelet, kubernetes", args[0])
 	}
 
 	oldMode := sess.GetMode()
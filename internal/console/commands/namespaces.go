@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"sort"
+	"strconv"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// NamespacesCmd namespaces 命令
+type NamespacesCmd struct{}
+
+func init() {
+	Register(&NamespacesCmd{})
+}
+
+func (c *NamespacesCmd) Name() string {
+	return "namespaces"
+}
+
+func (c *NamespacesCmd) Aliases() []string {
+	return []string{"ns"}
+}
+
+func (c *NamespacesCmd) Description() string {
+	return "列出命名空间及风险汇总"
+}
+
+// IsReadOnly --local 只使用 Pod 缓存和数据库
+func (c *NamespacesCmd) IsReadOnly(args []string) bool {
+	for _, arg := range args {
+		if arg == "--local" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPaged namespaces 输出命名空间表格
+func (c *NamespacesCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *NamespacesCmd) Usage() string {
+	return `namespaces [options]
+
+按命名空间汇总 Pod 和 SA 风险，快速定位值得关注的命名空间：
+  PODS        当前节点上的 Pod 数（来自 Pod 缓存）
+  RISKY       风险为 MEDIUM 及以上的 Pod 数（特权、HostPath、高危 capability、主机命名空间等）
+  SAS         数据库中该命名空间的 SA 数
+  ADMIN       其中的集群管理员 SA 数
+  PSA         Pod Security Admission 的 enforce 级别（未设置时等同 privileged）
+
+优先通过 API Server 列出命名空间（需要 list namespaces 权限，可获取 PSA 标签），
+失败时从 Pod 缓存和数据库推导
+
+选项：
+  --local             不访问 API Server，只从 Pod 缓存和数据库推导
+  --risky             只显示有风险 Pod 或管理员 SA 的命名空间
+
+示例：
+  namespaces
+  ns --risky
+  ns --local`
+}
+
+// Flags namespaces 的选项补全
+func (c *NamespacesCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--local", Description: "只从 Pod 缓存和数据库推导"},
+		{Name: "--risky", Description: "只显示有风险的命名空间"},
+	}
+}
+
+// namespaceSummary 单个命名空间的汇总
+type namespaceSummary struct {
+	info   types.NamespaceInfo
+	pods   int
+	risky  int
+	sas    int
+	admins int
+}
+
+func (c *NamespacesCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	local, riskyOnly := false, false
+	for _, arg := range args {
+		switch arg {
+		case "--local":
+			local = true
+		case "--risky":
+			riskyOnly = true
+		default:
+			return i18n.Errorf("未知参数: %s", arg)
+		}
+	}
+
+	summaries := make(map[string]*namespaceSummary)
+	get := func(name string) *namespaceSummary {
+		s, ok := summaries[name]
+		if !ok {
+			s = &namespaceSummary{info: types.NamespaceInfo{Name: name}}
+			summaries[name] = s
+		}
+		return s
+	}
+
+	source := "pods/db"
+	if !local && sess.Config.APIServer != "" {
+		k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+		var namespaces []types.NamespaceInfo
+		if err == nil {
+			namespaces, err = k8s.ListNamespaces(sess.Context())
+		}
+		if err != nil {
+			p.Warning(i18n.Tf("API Server 获取命名空间失败，从 Pod 缓存和数据库推导: %v", err))
+		} else {
+			source = "apiserver"
+			for _, ns := range namespaces {
+				get(ns.Name).info = ns
+			}
+		}
+	}
+
+	if len(sess.GetCachedPods()) == 0 && !local {
+		if kubelet, err := sess.GetKubeletClient(); err == nil {
+			if pods, err := kubelet.GetPodsWithContainers(sess.Context()); err == nil {
+				sess.CachePods(pods)
+			} else {
+				p.Warning(i18n.Tf("获取 Pod 列表失败: %v", err))
+			}
+		}
+	}
+	for _, pod := range sess.GetCachedPods() {
+		s := get(pod.Namespace)
+		s.pods++
+		if config.RiskLevelOrder[security.PassiveRiskLevel(pod.SecurityFlags)] <= config.RiskLevelOrder[config.RiskMedium] {
+			s.risky++
+		}
+	}
+
+	sas, err := sess.SADB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取 ServiceAccount 失败: %w", err)
+	}
+	for _, sa := range sas {
+		s := get(sa.Namespace)
+		s.sas++
+		if sa.IsClusterAdmin {
+			s.admins++
+		}
+	}
+
+	if len(summaries) == 0 {
+		p.Info(i18n.T("没有命名空间数据，请先执行 pods 或 sa scan"))
+		return nil
+	}
+
+	var list []*namespaceSummary
+	for _, s := range summaries {
+		if riskyOnly && s.risky == 0 && s.admins == 0 {
+			continue
+		}
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.admins != b.admins {
+			return a.admins > b.admins
+		}
+		if a.risky != b.risky {
+			return a.risky > b.risky
+		}
+		return a.info.Name < b.info.Name
+	})
+
+	var rows [][]string
+	for _, s := range list {
+		rows = append(rows, []string{
+			s.info.Name,
+			strconv.Itoa(s.pods),
+			c.count(p, s.risky, config.ColorYellow),
+			strconv.Itoa(s.sas),
+			c.count(p, s.admins, config.ColorRed),
+			c.formatPSA(p, s.info, source == "apiserver"),
+		})
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"NAMESPACE", "PODS", "RISKY", "SAS", "ADMIN", "PSA"}, rows)
+	p.Println()
+	p.Printf("%s %d namespaces (source: %s)\n", p.Colored(config.ColorGreen, "[+]"), len(list), source)
+	return nil
+}
+
+// count 非零计数着色
+func (c *NamespacesCmd) count(p output.Printer, n int, color config.ColorName) string {
+	if n == 0 {
+		return "0"
+	}
+	return p.Colored(color, strconv.Itoa(n))
+}
+
+// formatPSA 格式化 PSA enforce 级别，未从 API Server 获取标签时为 -
+func (c *NamespacesCmd) formatPSA(p output.Printer, ns types.NamespaceInfo, known bool) string {
+	if !known {
+		return "-"
+	}
+	switch level := ns.PodSecurityLevel("enforce"); level {
+	case "":
+		return p.Colored(config.ColorRed, "(none)")
+	case "privileged":
+		return p.Colored(config.ColorRed, level)
+	case "baseline":
+		return p.Colored(config.ColorYellow, level)
+	default:
+		return p.Colored(config.ColorGreen, level)
+	}
+}
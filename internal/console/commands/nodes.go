@@ -0,0 +1,278 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/network"
+	"kctl/pkg/types"
+)
+
+// NodesCmd nodes 命令
+type NodesCmd struct{}
+
+func init() {
+	Register(&NodesCmd{})
+}
+
+func (c *NodesCmd) Name() string {
+	return "nodes"
+}
+
+func (c *NodesCmd) Aliases() []string {
+	return []string{"no"}
+}
+
+func (c *NodesCmd) Description() string {
+	return "列出集群节点及 Kubelet 版本"
+}
+
+// IsPaged nodes 输出节点表格
+func (c *NodesCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *NodesCmd) Usage() string {
+	return `nodes [options]
+
+列出集群节点、内部 IP、Kubelet 版本、系统/内核及容器运行时
+优先通过 API Server 获取（需要 list nodes 权限），失败时回退到当前 Kubelet 的 /pods 与 /spec/
+
+REACHABLE 列标记节点 Kubelet 端口是否可从当前位置直连（可作为扫描目标）
+
+选项：
+  --no-probe      不探测 Kubelet 端口可达性
+
+示例：
+  nodes
+  nodes --no-probe`
+}
+
+// Flags nodes 的选项补全
+func (c *NodesCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--no-probe", Description: "不探测 Kubelet 端口可达性"},
+	}
+}
+
+func (c *NodesCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	probe := true
+	for _, arg := range args {
+		if arg == "--no-probe" {
+			probe = false
+		}
+	}
+
+	var nodes []types.NodeInfo
+
+	// 优先使用 API Server
+	if sess.Config.APIServer != "" {
+		p.Printf("%s Listing nodes from API Server...\n", p.Colored(config.ColorBlue, "[*]"))
+		k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+		if err == nil {
+			nodes, err = k8s.ListNodes(ctx)
+		}
+		if err != nil {
+			p.Warning(i18n.Tf("API Server 获取节点失败: %v", err))
+		}
+	}
+
+	// 回退到 Kubelet
+	if len(nodes) == 0 {
+		p.Printf("%s Falling back to Kubelet /pods and /spec/...\n", p.Colored(config.ColorBlue, "[*]"))
+		node, err := c.nodeFromKubelet(sess)
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, *node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	sess.CacheNodes(nodes)
+
+	// 探测可达性
+	var reachable map[string]bool
+	if probe {
+		reachable = c.probeNodes(nodes)
+	}
+
+	var rows [][]string
+	for _, n := range nodes {
+		rows = append(rows, []string{
+			n.Name,
+			n.InternalIP,
+			c.formatReady(p, n),
+			n.KubeletVersion,
+			n.Architecture,
+			n.OSImage,
+			n.KernelVersion,
+			n.ContainerRuntime,
+			c.formatReachable(p, sess, n, reachable, probe),
+		})
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple(
+		[]string{"NAME", "INTERNAL-IP", "STATUS", "KUBELET", "ARCH", "OS-IMAGE", "KERNEL", "RUNTIME", "REACHABLE"},
+		rows)
+
+	p.Println()
+	p.Printf("%s Found %d nodes (source: %s)\n",
+		p.Colored(config.ColorGreen, "[+]"),
+		len(nodes), nodes[0].Source)
+
+	return nil
+}
+
+// nodeFromKubelet 从当前 Kubelet 获取节点信息
+func (c *NodesCmd) nodeFromKubelet(sess *session.Session) (*types.NodeInfo, error) {
+	ctx := sess.Context()
+
+	kubelet, err := sess.GetKubeletClient()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &types.NodeInfo{
+		InternalIP:       sess.Config.KubeletIP,
+		KubeletPort:      sess.Config.KubeletPort,
+		KubeletVersion:   "-",
+		OSImage:          "-",
+		KernelVersion:    "-",
+		ContainerRuntime: "-",
+		Architecture:     "-",
+		Source:           "kubelet",
+	}
+
+	pods := sess.GetCachedPods()
+	if len(pods) == 0 {
+		pods, err = kubelet.GetPodsWithContainers(ctx)
+		if err != nil {
+			return nil, i18n.Errorf("获取 Pod 列表失败: %w", err)
+		}
+		sess.CachePods(pods)
+	}
+	for _, pod := range pods {
+		if pod.NodeName != "" {
+			node.Name = pod.NodeName
+			if pod.HostIP != "" {
+				node.InternalIP = pod.HostIP
+			}
+			break
+		}
+	}
+	if node.Name == "" {
+		node.Name = sess.Config.KubeletIP
+	}
+
+	// 版本取自 /metrics 的 kubernetes_build_info
+	if version, err := kubelet.GetVersion(ctx); err == nil && version != "" {
+		node.KubeletVersion = version
+	}
+
+	// /spec/ 仅提供机器信息（不含版本），单独输出
+	if spec, err := kubelet.GetSpec(ctx); err == nil {
+		p := sess.Printer
+		p.Printf("%s Machine: %d cores, %s memory, machine-id %s\n",
+			p.Colored(config.ColorBlue, "[*]"),
+			spec.NumCores, formatBytes(spec.MemoryCapacity), spec.MachineID)
+	}
+
+	// 通过运行中的容器执行 uname -m 探测架构（最多尝试 3 个）
+	attempts := 0
+	for _, pod := range pods {
+		if attempts >= 3 {
+			break
+		}
+		if pod.Status != "Running" || len(pod.Containers) == 0 {
+			continue
+		}
+		attempts++
+		if arch, err := sess.ResolveArch(ctx, pod, pod.Containers[0].Name); err == nil {
+			node.Architecture = arch
+			break
+		}
+	}
+
+	// 能获取到 Pod 说明 Kubelet 可用
+	node.Ready = true
+
+	return node, nil
+}
+
+// probeNodes 并发探测各节点 Kubelet 端口
+func (c *NodesCmd) probeNodes(nodes []types.NodeInfo) map[string]bool {
+	result := make(map[string]bool)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, n := range nodes {
+		if n.InternalIP == "" {
+			continue
+		}
+		port := n.KubeletPort
+		if port == 0 {
+			port = config.DefaultKubeletPort
+		}
+
+		wg.Add(1)
+		go func(ip string, port int) {
+			defer wg.Done()
+			r := network.ProbePort(ip, port, 2*time.Second)
+			mu.Lock()
+			result[ip] = r.Reachable
+			mu.Unlock()
+		}(n.InternalIP, port)
+	}
+
+	wg.Wait()
+	return result
+}
+
+// formatReady 格式化节点状态
+func (c *NodesCmd) formatReady(p output.Printer, n types.NodeInfo) string {
+	if n.Ready {
+		return p.Colored(config.ColorGreen, "Ready")
+	}
+	return p.Colored(config.ColorRed, "NotReady")
+}
+
+// formatReachable 格式化可达性标记
+func (c *NodesCmd) formatReachable(p output.Printer, sess *session.Session, n types.NodeInfo, reachable map[string]bool, probed bool) string {
+	if n.InternalIP == sess.Config.KubeletIP {
+		return p.Colored(config.ColorCyan, "current")
+	}
+	if !probed {
+		return "-"
+	}
+	if reachable[n.InternalIP] {
+		return p.Colored(config.ColorGreen, "yes")
+	}
+	return p.Colored(config.ColorGray, "no")
+}
+
+// formatBytes 格式化字节数
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ci", float64(b)/float64(div), "KMGTPE"[exp])
+}
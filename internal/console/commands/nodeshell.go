@@ -0,0 +1,300 @@
+package commands
+
+import (
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/manifest"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// NodeShellCmd nodeshell 命令
+type NodeShellCmd struct{}
+
+func init() {
+	Register(&NodeShellCmd{})
+}
+
+func (c *NodeShellCmd) Name() string {
+	return "nodeshell"
+}
+
+func (c *NodeShellCmd) Aliases() []string {
+	return []string{"ns-shell"}
+}
+
+func (c *NodeShellCmd) Description() string {
+	return "通过特权 hostPID Pod 获取节点 shell"
+}
+
+// IsDestructive nodeshell 在节点上执行命令，必要时部署特权 Pod
+func (c *NodeShellCmd) IsDestructive(args []string) bool {
+	return true
+}
+
+func (c *NodeShellCmd) Usage() string {
+	return `nodeshell [options] [-- <command>]
+
+在特权 + hostPID Pod 中执行 nsenter -t 1 -m -u -i -n 进入节点命名空间，获取节点 shell
+优先使用缓存中已存在的特权 hostPID Pod；没有时部署 nsenter 模板（见 deploy）
+
+选项：
+  --node <node>       目标节点（默认: 当前 Kubelet 所在节点）
+  --pod <[ns/]name>   使用指定 Pod（跳过自动探测）
+  --shell <shell>     节点上使用的 shell（默认: sh）
+  --no-deploy         没有可用 Pod 时不自动部署
+  -n <namespace>      自动部署的命名空间（默认: 当前 SA 的命名空间，否则为 default）
+  --image <image>     自动部署的镜像（默认: ` + manifest.DefaultImage + `）
+                      多架构时可写为 amd64=<image>,arm64=<image>，按目标节点架构选择
+  --priority-class <name>  自动部署 Pod 的 priorityClassName，如 system-node-critical
+  --tolerations <all|none> 自动部署 Pod 容忍所有污点（默认: all）或不设置容忍
+  --restart-policy <policy> 自动部署 Pod 的 restartPolicy（默认: Always）
+  --confirm           当前 SA 为 cluster-admin 时必须指定
+
+自动部署的 Pod 及其存活相关设置记录在数据库中，使用 deploy --cleanup 删除
+
+示例：
+  nodeshell                               进入当前节点的 shell
+  nodeshell --node worker-2               进入指定节点的 shell
+  nodeshell -- cat /etc/kubernetes/kubelet.conf
+  nodeshell --pod kube-system/node-agent-x7k2p --shell bash`
+}
+
+// Flags nodeshell 的选项补全
+func (c *NodeShellCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--node", Arg: "<node>", Description: "目标节点", Values: completion.Nodes},
+		{Name: "--pod", Arg: "<[ns/]name>", Description: "使用指定的特权 hostPID Pod", Values: privilegedHostPIDPods},
+		{Name: "--shell", Arg: "<shell>", Description: "节点上使用的 shell（默认: sh）"},
+		{Name: "--no-deploy", Description: "没有可用 Pod 时不自动部署"},
+		{Name: "-n", Arg: "<namespace>", Description: "自动部署的命名空间", Values: completion.Namespaces},
+		{Name: "--image", Arg: "<image>", Description: "自动部署的镜像（默认: " + manifest.DefaultImage + "）"},
+		flagPriorityClass,
+		flagTolerations,
+		flagRestartPolicy,
+		flagConfirm,
+		flagSeparator,
+	}
+}
+
+// privilegedHostPIDPods 补全特权 + hostPID 的 Pod（ns/name）
+func privilegedHostPIDPods(sess *session.Session, _ []string) []completion.Suggestion {
+	var suggestions []completion.Suggestion
+	for _, pod := range sess.GetCachedPods() {
+		if pod.SecurityFlags.Privileged && pod.SecurityFlags.HostPID {
+			suggestions = append(suggestions, completion.Suggestion{Text: pod.Namespace + "/" + pod.PodName, Description: pod.NodeName})
+		}
+	}
+	return suggestions
+}
+
+func (c *NodeShellCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	node := ""
+	target := ""
+	shell := "sh"
+	noDeploy := false
+	opts := manifest.Options{Image: manifest.DefaultImage}
+	var command []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--":
+			command = args[i+1:]
+			i = len(args)
+		case "--node":
+			if i+1 < len(args) {
+				node = args[i+1]
+				i++
+			}
+		case "--pod":
+			if i+1 < len(args) {
+				target = args[i+1]
+				i++
+			}
+		case "--shell":
+			if i+1 < len(args) {
+				shell = args[i+1]
+				i++
+			}
+		case "--no-deploy":
+			noDeploy = true
+		case "-n":
+			if i+1 < len(args) {
+				opts.Namespace = args[i+1]
+				i++
+			}
+		case "--image":
+			if i+1 < len(args) {
+				opts.Image = args[i+1]
+				i++
+			}
+		case "--priority-class":
+			if i+1 < len(args) {
+				opts.PriorityClass = args[i+1]
+				i++
+			}
+		case "--tolerations":
+			if i+1 < len(args) {
+				opts.Tolerations = args[i+1]
+				i++
+			}
+		case "--restart-policy":
+			if i+1 < len(args) {
+				opts.RestartPolicy = args[i+1]
+				i++
+			}
+		default:
+			return i18n.Errorf("未知选项: %s", args[i])
+		}
+	}
+
+	if node == "" {
+		node = c.kubeletNode(sess)
+	}
+
+	namespace, pod, container, err := c.findPod(sess, target, node)
+	if err != nil {
+		return err
+	}
+
+	if pod == "" {
+		if noDeploy {
+			if node != "" {
+				return i18n.Errorf("节点 %s 上没有可用的特权 hostPID Pod", node)
+			}
+			return i18n.Errorf("没有可用的特权 hostPID Pod")
+		}
+		p.Printf("%s No privileged hostPID pod found%s, deploying one\n",
+			p.Colored(config.ColorBlue, "[*]"), nodeSuffix(node))
+		if opts.Image, err = selectImage(ctx, sess, opts.Image, node); err != nil {
+			return err
+		}
+		namespace, pod, container, err = c.deployPod(sess, node, opts)
+		if err != nil {
+			return err
+		}
+	} else {
+		p.Printf("%s Using privileged hostPID pod %s/%s (%s)\n",
+			p.Colored(config.ColorBlue, "[*]"), namespace, pod, container)
+	}
+
+	executor, err := sess.GetExecTransport()
+	if err != nil {
+		return err
+	}
+
+	nsenter := []string{"nsenter", "-t", "1", "-m", "-u", "-i", "-n", "--"}
+	if len(command) > 0 {
+		return (&ExecCmd{}).execCommand(ctx, sess, executor, namespace, pod, container, append(nsenter, command...))
+	}
+
+	p.Printf("%s Entering host namespaces (exit to return)\n", p.Colored(config.ColorGreen, "[+]"))
+	return executor.ExecInteractive(ctx, &types.ExecOptions{
+		Namespace: namespace,
+		Pod:       pod,
+		Container: container,
+		Command:   append(nsenter, shell),
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    true,
+		TTY:       true,
+	})
+}
+
+// kubeletNode 根据 Pod 缓存返回当前 Kubelet 所在节点
+func (c *NodeShellCmd) kubeletNode(sess *session.Session) string {
+	if sess.Config.KubeletIP == "" {
+		return ""
+	}
+	for _, pod := range sess.GetCachedPods() {
+		if pod.HostIP == sess.Config.KubeletIP && pod.NodeName != "" {
+			return pod.NodeName
+		}
+	}
+	return ""
+}
+
+// findPod 返回指定的 Pod 或缓存中节点上第一个 Running 的特权 hostPID Pod，未找到时 pod 为空
+func (c *NodeShellCmd) findPod(sess *session.Session, target, node string) (namespace, pod, container string, err error) {
+	pods := sess.GetCachedPods()
+
+	if target != "" {
+		ref, err := sess.ResolvePod(target, "", "")
+		if err != nil {
+			return "", "", "", err
+		}
+		for _, p := range pods {
+			if p.Namespace == ref.Namespace && p.PodName == ref.Pod {
+				return p.Namespace, p.PodName, privilegedContainerName(p), nil
+			}
+		}
+		return "", "", "", i18n.Errorf("缓存中没有 Pod: %s，请先执行 pods --refresh", target)
+	}
+
+	for _, p := range pods {
+		if p.Status != "Running" || !p.SecurityFlags.Privileged || !p.SecurityFlags.HostPID {
+			continue
+		}
+		if node != "" && p.NodeName != node {
+			continue
+		}
+		return p.Namespace, p.PodName, privilegedContainerName(p), nil
+	}
+	return "", "", "", nil
+}
+
+// deployPod 部署 nsenter 模板并等待容器启动
+func (c *NodeShellCmd) deployPod(sess *session.Session, node string, opts manifest.Options) (namespace, pod, container string, err error) {
+	if opts.Namespace == "" {
+		opts.Namespace = "default"
+		if sa := sess.GetCurrentSA(); sa != nil {
+			opts.Namespace = sa.Namespace
+		}
+	}
+	opts.Node = node
+
+	deploy := &DeployCmd{}
+	objects, _, err := deploy.build("nsenter", "", opts)
+	if err != nil {
+		return "", "", "", err
+	}
+	if err := deploy.deploy(sess, "nsenter", node, objects, ""); err != nil {
+		return "", "", "", err
+	}
+
+	ref := objects[0].Ref()
+	k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+	if err != nil {
+		return "", "", "", err
+	}
+	// nsenter 模板的容器名与 Pod 名相同
+	if err := waitContainerRunning(sess, k8s, ref.Namespace, ref.Name, ref.Name, opts.Image); err != nil {
+		return "", "", "", err
+	}
+	return ref.Namespace, ref.Name, ref.Name, nil
+}
+
+// privilegedContainerName 返回 Pod 中第一个特权容器，没有时返回第一个容器
+func privilegedContainerName(pod types.PodContainerInfo) string {
+	for _, c := range pod.Containers {
+		if c.Privileged {
+			return c.Name
+		}
+	}
+	if len(pod.Containers) > 0 {
+		return pod.Containers[0].Name
+	}
+	return ""
+}
+
+// nodeSuffix 输出中的节点说明
+func nodeSuffix(node string) string {
+	if node == "" {
+		return ""
+	}
+	return " on " + node
+}
@@ -0,0 +1,259 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// OplogCmd oplog 命令
+type OplogCmd struct{}
+
+func init() {
+	Register(&OplogCmd{})
+}
+
+func (c *OplogCmd) Name() string {
+	return "oplog"
+}
+
+func (c *OplogCmd) Aliases() []string {
+	return []string{"audit"}
+}
+
+func (c *OplogCmd) Description() string {
+	return "查看对集群执行的操作记录"
+}
+
+// IsReadOnly oplog 只读取数据库
+func (c *OplogCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+// IsPaged oplog list 输出操作记录表格
+func (c *OplogCmd) IsPaged(args []string) bool {
+	return len(args) == 0 || args[0] != "export"
+}
+
+func (c *OplogCmd) Usage() string {
+	return `oplog [list] [options]
+oplog export <json|csv> [file]
+
+查看 kctl 对集群执行的每个修改和执行操作：exec（含 sa scan / hunt 读取文件）、交互式 shell、
+Kubelet /run、端口转发、临时容器、attach，以及 deploy / nodeshell 等创建和删除的资源
+记录时间、操作、目标 Pod 或资源、执行的命令、使用的身份、请求端点、触发的控制台命令和结果，
+保存在会话数据库的 operations 表中（只追加），export markdown / html 报告中附带操作记录
+
+选项：
+  --action <action>   只显示指定操作 (exec, exec-interactive, run, port-forward, attach, ephemeral, create, delete)
+  --target <text>     只显示目标包含指定文本的记录（如 Pod 名、命名空间）
+  --failed            只显示失败的操作
+  --last <n>          只显示最近 n 条
+
+示例：
+  oplog
+  oplog --action exec --target kube-system
+  oplog --failed
+  oplog export csv operations.csv
+  oplog export json`
+}
+
+// oplogDetailWidth 列表中 DETAIL 列的最大宽度，完整内容见 oplog export
+const oplogDetailWidth = 40
+
+// oplogActions 可筛选的操作类型
+var oplogActions = []string{
+	types.OpExec, types.OpExecInteractive, types.OpRun, types.OpPortForward,
+	types.OpAttach, types.OpEphemeral, types.OpCreate, types.OpDelete,
+}
+
+// Flags oplog list 的选项补全
+func (c *OplogCmd) Flags(args []string) []completion.Flag {
+	if len(args) > 0 && args[0] == "export" {
+		return nil
+	}
+	var actions []completion.Suggestion
+	for _, action := range oplogActions {
+		actions = append(actions, completion.Suggestion{Text: action})
+	}
+	return []completion.Flag{
+		{Name: "--action", Arg: "<action>", Description: "按操作类型筛选", Values: completion.Choices(actions...)},
+		{Name: "--target", Arg: "<text>", Description: "按目标筛选"},
+		{Name: "--failed", Description: "只显示失败的操作"},
+		{Name: "--last", Arg: "<n>", Description: "只显示最近 n 条"},
+	}
+}
+
+// Suggestions oplog 的子命令和导出格式补全
+func (c *OplogCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	switch {
+	case len(args) == 0:
+		return []completion.Suggestion{
+			{Text: "list", Description: "列出操作记录"},
+			{Text: "export", Description: "导出操作记录"},
+		}
+	case len(args) == 1 && args[0] == "export":
+		return []completion.Suggestion{
+			{Text: "json", Description: "JSON 格式"},
+			{Text: "csv", Description: "CSV 格式"},
+		}
+	}
+	return nil
+}
+
+func (c *OplogCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) > 0 && args[0] == "export" {
+		return c.export(sess, args[1:])
+	}
+	if len(args) > 0 && args[0] == "list" {
+		args = args[1:]
+	}
+
+	var action, target string
+	failed, last := false, 0
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--action", "--target", "--last":
+			if i+1 >= len(args) {
+				return i18n.Errorf("%s 需要参数", args[i])
+			}
+			value := args[i+1]
+			i++
+			switch args[i-1] {
+			case "--action":
+				action = value
+			case "--target":
+				target = value
+			default:
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 1 {
+					return i18n.Errorf("无效的条数: %s", value)
+				}
+				last = n
+			}
+		case "--failed":
+			failed = true
+		default:
+			return i18n.Errorf("未知参数: %s", args[i])
+		}
+	}
+
+	records, err := sess.OpDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取操作记录失败: %w", err)
+	}
+	total := len(records)
+	var matched []*types.OperationRecord
+	for _, r := range records {
+		if (action != "" && r.Action != action) || (target != "" && !strings.Contains(r.Target, target)) || (failed && !r.Failed()) {
+			continue
+		}
+		matched = append(matched, r)
+	}
+	if last > 0 && len(matched) > last {
+		matched = matched[len(matched)-last:]
+	}
+
+	p := sess.Printer
+	if len(matched) == 0 {
+		p.Info(i18n.T("没有匹配的操作记录"))
+		return nil
+	}
+
+	tf := sess.TimeFormatter(false)
+	var rows [][]string
+	for _, r := range matched {
+		result := p.Colored(config.ColorGreen, r.Result)
+		if r.Failed() {
+			result = p.Colored(config.ColorRed, r.Result)
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(r.ID, 10),
+			tf.Format(r.Time),
+			r.Action,
+			r.Target,
+			runewidth.Truncate(r.Detail, oplogDetailWidth, "..."),
+			r.Identity,
+			result,
+		})
+	}
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"ID", "TIME", "ACTION", "TARGET", "DETAIL", "IDENTITY", "RESULT"}, rows)
+	p.Printf("\n  %s\n\n", p.Colored(config.ColorGray, fmt.Sprintf("%d / %d operation(s)", len(matched), total)))
+	return nil
+}
+
+// export 导出全部操作记录，未指定文件时输出到终端
+func (c *OplogCmd) export(sess *session.Session, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return i18n.Errorf("用法: oplog export <json|csv> [file]")
+	}
+	format := strings.ToLower(args[0])
+	if format != "json" && format != "csv" {
+		return i18n.Errorf("不支持的格式: %s (可用: json, csv)", format)
+	}
+
+	records, err := sess.OpDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取操作记录失败: %w", err)
+	}
+
+	var buf strings.Builder
+	if format == "json" {
+		err = writeOplogJSON(&buf, records)
+	} else {
+		err = writeOplogCSV(&buf, records)
+	}
+	if err != nil {
+		return err
+	}
+
+	p := sess.Printer
+	if len(args) == 1 {
+		p.Print(buf.String())
+		return nil
+	}
+	if err := os.WriteFile(args[1], []byte(buf.String()), 0600); err != nil {
+		return i18n.Errorf("写入文件失败: %w", err)
+	}
+	p.Success(fmt.Sprintf("Exported %d operation(s) to %s", len(records), args[1]))
+	return nil
+}
+
+func writeOplogJSON(w io.Writer, records []*types.OperationRecord) error {
+	if records == nil {
+		records = []*types.OperationRecord{}
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return i18n.Errorf("序列化 JSON 失败: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func writeOplogCSV(w io.Writer, records []*types.OperationRecord) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"id", "time", "action", "target", "detail", "identity", "via", "command", "result", "error"})
+	for _, r := range records {
+		_ = cw.Write([]string{
+			strconv.FormatInt(r.ID, 10), r.Time.Format(time.RFC3339), r.Action, r.Target, r.Detail,
+			r.Identity, r.Via, r.Command, r.Result, r.Error,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
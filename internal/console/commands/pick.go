@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/picker"
+	"kctl/internal/i18n"
+	"kctl/internal/session"
+)
+
+// pickPod 交互式选择缓存中 Running 状态的 Pod，返回 namespace/name；namespace 非空时只列出该命名空间的 Pod
+func pickPod(sess *session.Session, namespace string) (string, error) {
+	var items []picker.Item
+	for _, pod := range sess.GetCachedPods() {
+		if pod.Status != "Running" || (namespace != "" && pod.Namespace != namespace) {
+			continue
+		}
+		var images []string
+		for _, c := range pod.Containers {
+			images = append(images, c.Image)
+		}
+		items = append(items, picker.Item{
+			Text:        pod.Namespace + "/" + pod.PodName,
+			Description: strings.TrimSpace(pod.NodeName + "  " + strings.Join(images, ",")),
+		})
+	}
+	if len(items) == 0 {
+		return "", i18n.Errorf("没有缓存的 Running Pod，请先执行 'pods' 或指定 Pod 名称")
+	}
+
+	p := sess.Printer
+	return picker.Pick(p.Colored(config.ColorYellow, "[?]")+" Select a pod", items)
+}
+
+// resolvePod 与 sess.ResolvePod 相同，但在交互式控制台中遇到同名 Pod 存在于多个命名空间时
+// 弹出选择器让用户选择命名空间；非交互式时直接返回 *session.AmbiguousPodError
+func resolvePod(sess *session.Session, name, namespace, container string) (session.PodRef, error) {
+	ref, err := sess.ResolvePod(name, namespace, container)
+	var ambiguous *session.AmbiguousPodError
+	if !errors.As(err, &ambiguous) || !sess.Interactive {
+		return ref, err
+	}
+
+	details := make(map[string]string)
+	for _, pod := range sess.GetCachedPods() {
+		if pod.PodName == ambiguous.Name {
+			details[pod.Namespace] = strings.TrimSpace(pod.Status + "  " + pod.NodeName)
+		}
+	}
+	items := make([]picker.Item, len(ambiguous.Namespaces))
+	for i, ns := range ambiguous.Namespaces {
+		items[i] = picker.Item{Text: ns + "/" + ambiguous.Name, Description: details[ns]}
+	}
+
+	p := sess.Printer
+	p.Warning(i18n.Tf("Pod %s 存在于多个命名空间", ambiguous.Name))
+	choice, err := picker.Pick(p.Colored(config.ColorYellow, "[?]")+" Select a namespace", items)
+	if err != nil {
+		return session.PodRef{}, err
+	}
+	return sess.ResolvePod(choice, "", container)
+}
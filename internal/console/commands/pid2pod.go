@@ -10,6 +10,8 @@ import (
 	"github.com/mitchellh/go-ps"
 
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
 	"kctl/internal/session"
 	"kctl/pkg/proc"
 	"kctl/pkg/types"
@@ -51,6 +53,14 @@ func (c *Pid2PodCmd) Usage() string {
   pid2pod --all              显示所有进程`
 }
 
+// Flags pid2pod 的选项补全
+func (c *Pid2PodCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--pid", Arg: "<pid>", Description: "只查看指定 PID"},
+		{Name: "--all", Description: "显示所有进程（包括非容器进程）"},
+	}
+}
+
 // podProcessInfo 进程与 Pod 的映射信息
 type podProcessInfo struct {
 	PID         int
@@ -67,7 +77,7 @@ func (c *Pid2PodCmd) Execute(sess *session.Session, args []string) error {
 
 	// 检查是否在 Pod 内
 	if !sess.InPod {
-		return fmt.Errorf("此功能仅在 Pod 内可用（需要访问 /proc 文件系统）")
+		return i18n.Errorf("此功能仅在 Pod 内可用（需要访问 /proc 文件系统）")
 	}
 
 	// 解析参数
@@ -98,7 +108,7 @@ func (c *Pid2PodCmd) Execute(sess *session.Session, args []string) error {
 	p.Printf("%s Fetching pods from Kubelet...\n", p.Colored(config.ColorBlue, "[*]"))
 	pods, err := kubelet.GetPodsWithContainers(ctx)
 	if err != nil {
-		return fmt.Errorf("获取 Pod 列表失败: %w", err)
+		return i18n.Errorf("获取 Pod 列表失败: %w", err)
 	}
 
 	// 构建 containerID -> Pod 映射
@@ -108,7 +118,7 @@ func (c *Pid2PodCmd) Execute(sess *session.Session, args []string) error {
 	p.Printf("%s Scanning processes...\n", p.Colored(config.ColorBlue, "[*]"))
 	processes, err := ps.Processes()
 	if err != nil {
-		return fmt.Errorf("获取进程列表失败: %w", err)
+		return i18n.Errorf("获取进程列表失败: %w", err)
 	}
 
 	// 匹配进程与 Pod
@@ -173,9 +183,9 @@ func (c *Pid2PodCmd) Execute(sess *session.Session, args []string) error {
 	p.Println()
 	if len(results) == 0 {
 		if targetPID != 0 {
-			p.Warning(fmt.Sprintf("未找到 PID %d 或该进程不是容器进程", targetPID))
+			p.Warning(i18n.Tf("未找到 PID %d 或该进程不是容器进程", targetPID))
 		} else {
-			p.Warning("未找到容器进程")
+			p.Warning(i18n.T("未找到容器进程"))
 		}
 		return nil
 	}
@@ -0,0 +1,380 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"kctl/config"
+	"kctl/internal/client/kubelet"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/network"
+	"kctl/pkg/types"
+)
+
+// 全局 pivot 管理，同一时间只运行一个
+var (
+	activePivot *pivotInstance
+	pivotMutex  sync.Mutex
+)
+
+// pivotInstance 运行中的 SOCKS5 代理，每个连接通过 Kubelet /exec 在 Pod 中启动一个中继
+type pivotInstance struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	listener net.Listener
+	kubelet  kubelet.Client
+	ref      session.PodRef
+	relay    string
+
+	active atomic.Int64
+	total  atomic.Int64
+
+	mu      sync.Mutex
+	lastErr string
+}
+
+// PivotCmd pivot 命令
+type PivotCmd struct{}
+
+func init() {
+	Register(&PivotCmd{})
+}
+
+func (c *PivotCmd) Name() string {
+	return "pivot"
+}
+
+func (c *PivotCmd) Aliases() []string {
+	return nil
+}
+
+func (c *PivotCmd) Description() string {
+	return "经由 Pod 出口的 SOCKS5 代理"
+}
+
+func (c *PivotCmd) Usage() string {
+	return `pivot [options] <pod>
+
+在本地开启 SOCKS5 代理，流量从指定 Pod 内发出，供 curl、proxychains 等工具访问集群内服务
+每个连接通过 Kubelet /exec (WebSocket) 在 Pod 中启动一个中继（socat、nc、bash /dev/tcp 或 python3），
+不向 Pod 写入文件；目标地址在 Pod 内解析，可使用集群 DNS 名称
+
+选项：
+  -n <namespace>      指定命名空间（默认从 Pod 缓存推断，也可写为 <namespace>/<pod>）
+  -c <container>      指定容器
+  --port <port>       本地 SOCKS5 端口（默认: 1080）
+  --address <addr>    监听地址（默认: 127.0.0.1），代理不做认证
+  --relay <tool>      中继方式: socat、nc、bash、python3（默认按此顺序自动选择）
+
+子命令：
+  stop                停止当前代理
+  status              显示当前代理状态（不带参数时相同）
+
+示例：
+  pivot nginx                                  经由 nginx 的网络访问集群
+  pivot -n kube-system coredns --port 9050     指定命名空间和端口
+  pivot nginx --relay python3                  指定中继方式
+  pivot stop                                   停止代理
+
+  curl --socks5-hostname 127.0.0.1:1080 http://redis.default.svc:6379`
+}
+
+// Flags pivot 的选项补全
+func (c *PivotCmd) Flags(args []string) []completion.Flag {
+	relays := make([]completion.Suggestion, 0, len(network.PivotRelays))
+	for _, relay := range network.PivotRelays {
+		relays = append(relays, completion.Suggestion{Text: relay})
+	}
+	return []completion.Flag{
+		flagNamespace,
+		flagContainer,
+		{Name: "--port", Arg: "<port>", Description: "本地 SOCKS5 端口"},
+		{Name: "--address", Arg: "<addr>", Description: "监听地址", Values: completion.Choices(
+			completion.Suggestion{Text: "127.0.0.1", Description: "仅本地访问（默认）"},
+			completion.Suggestion{Text: "0.0.0.0", Description: "所有接口"},
+		)},
+		{Name: "--relay", Arg: "<tool>", Description: "中继方式", Values: completion.Choices(relays...)},
+	}
+}
+
+// Suggestions pivot 的 Pod 补全
+func (c *PivotCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	pods := podSuggestions(sess, c, args, completion.RunningPods)
+	if len(args) == 0 {
+		pods = append([]completion.Suggestion{
+			{Text: "stop", Description: "停止当前代理"},
+			{Text: "status", Description: "显示当前代理状态"},
+		}, pods...)
+	}
+	return pods
+}
+
+func (c *PivotCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	if len(args) == 0 || args[0] == "status" {
+		return showPivot(p)
+	}
+	if args[0] == "stop" {
+		return stopPivot(p)
+	}
+
+	// 中继在 Pod 中执行命令，安全模式下提前拒绝
+	if err := sess.CheckSafeMode("pivot"); err != nil {
+		return err
+	}
+
+	kubeletClient, err := sess.GetKubeletClient()
+	if err != nil {
+		return err
+	}
+
+	namespace := ""
+	container := ""
+	address := "127.0.0.1"
+	port := config.DefaultPivotPort
+	relay := ""
+	podName := ""
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 < len(args) {
+				namespace = args[i+1]
+				i++
+			}
+		case "-c":
+			if i+1 < len(args) {
+				container = args[i+1]
+				i++
+			}
+		case "--address":
+			if i+1 < len(args) {
+				address = args[i+1]
+				i++
+			}
+		case "--port":
+			if i+1 < len(args) {
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n <= 0 || n > 65535 {
+					return i18n.Errorf("无效的端口: %s", args[i+1])
+				}
+				port = n
+				i++
+			}
+		case "--relay":
+			if i+1 < len(args) {
+				relay = args[i+1]
+				i++
+			}
+		default:
+			if !strings.HasPrefix(args[i], "-") && podName == "" {
+				podName = args[i]
+			}
+		}
+	}
+
+	if podName == "" {
+		return i18n.Errorf("请指定 Pod 名称，或使用 'pivot stop' 停止当前代理")
+	}
+	if relay != "" && !slices.Contains(network.PivotRelays, relay) {
+		return i18n.Errorf("未知的中继方式: %s (可用: %s)", relay, strings.Join(network.PivotRelays, ", "))
+	}
+
+	pivotMutex.Lock()
+	running := activePivot != nil
+	pivotMutex.Unlock()
+	if running {
+		return i18n.Errorf("已有 pivot 代理在运行，请先执行 'pivot stop' 停止")
+	}
+
+	ref, err := sess.ResolvePod(podName, namespace, container)
+	if err != nil {
+		return err
+	}
+
+	// 探测 Pod 内可用的中继工具
+	p.Printf("%s Probing relay tools in %s/%s...\n", p.Colored(config.ColorBlue, "[*]"), ref.Namespace, ref.Pod)
+	result, err := kubeletClient.Exec(sess.Context(), &types.ExecOptions{
+		Namespace: ref.Namespace,
+		Pod:       ref.Pod,
+		Container: ref.Container,
+		Command:   []string{"sh", "-c", network.PivotToolsScript},
+		Stdout:    true,
+		Stderr:    true,
+	})
+	if err != nil {
+		return i18n.Errorf("探测中继工具失败: %w", err)
+	}
+	if result.Error != "" && result.Stdout == "" {
+		return i18n.Errorf("探测中继工具失败: %s", result.Error)
+	}
+	tools := network.ParsePivotTools(result.Stdout)
+	switch {
+	case tools.Relay() == "":
+		return i18n.Errorf("Pod 中没有可用的中继工具 (%s)", strings.Join(network.PivotRelays, "、"))
+	case relay == "":
+		relay = tools.Relay()
+	case !tools.Has(relay):
+		return i18n.Errorf("Pod 中没有 %s，可使用 --relay %s", relay, tools.Relay())
+	}
+
+	listenAddr := net.JoinHostPort(address, strconv.Itoa(port))
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return i18n.Errorf("监听 %s 失败: %w", listenAddr, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pv := &pivotInstance{
+		ctx:      ctx,
+		cancel:   cancel,
+		listener: listener,
+		kubelet:  kubeletClient,
+		ref:      ref,
+		relay:    relay,
+	}
+	pivotMutex.Lock()
+	activePivot = pv
+	pivotMutex.Unlock()
+
+	go pv.serve()
+
+	p.Success(fmt.Sprintf("SOCKS5 proxy listening on %s (egress from %s/%s, relay: %s)", listenAddr, ref.Namespace, ref.Pod, relay))
+	if ip := net.ParseIP(address); ip == nil || !ip.IsLoopback() {
+		p.Warning(fmt.Sprintf("The proxy has no authentication, anyone who can reach %s can use it", listenAddr))
+	}
+	p.Printf("%s Example: %s\n", p.Colored(config.ColorGray, "[*]"),
+		p.Colored(config.ColorCyan, fmt.Sprintf("curl --socks5-hostname %s http://<service>.<namespace>.svc", listenAddr)))
+	p.Printf("%s To stop: %s\n", p.Colored(config.ColorGray, "[*]"), p.Colored(config.ColorCyan, "pivot stop"))
+	return nil
+}
+
+// serve 接受 SOCKS5 连接直到代理停止
+func (pv *pivotInstance) serve() {
+	for {
+		conn, err := pv.listener.Accept()
+		if err != nil {
+			return
+		}
+		go pv.handle(conn)
+	}
+}
+
+// handle 完成 SOCKS5 握手，在 Pod 中启动到目标地址的中继并双向转发
+func (pv *pivotInstance) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	host, port, err := network.SOCKS5Accept(conn)
+	if err != nil {
+		return
+	}
+	target := net.JoinHostPort(host, strconv.Itoa(port))
+	command, err := network.PivotRelayCommand(pv.relay, host, port)
+	if err != nil {
+		_ = network.SOCKS5Reply(conn, network.SOCKS5HostUnreachable)
+		pv.fail(target, err)
+		return
+	}
+
+	stream, err := pv.kubelet.ExecStream(pv.ctx, &types.ExecOptions{
+		Namespace: pv.ref.Namespace,
+		Pod:       pv.ref.Pod,
+		Container: pv.ref.Container,
+		Command:   command,
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    true,
+	})
+	if err != nil {
+		_ = network.SOCKS5Reply(conn, network.SOCKS5GeneralFailure)
+		pv.fail(target, err)
+		return
+	}
+	defer func() { _ = stream.Close() }()
+
+	// 代理停止时中断转发中的连接
+	stop := context.AfterFunc(pv.ctx, func() {
+		_ = stream.Close()
+		_ = conn.Close()
+	})
+	defer stop()
+
+	// 中继在 Pod 内连接目标，连接是否成功只能在之后从中继的输出得知
+	if err := network.SOCKS5Reply(conn, network.SOCKS5Succeeded); err != nil {
+		return
+	}
+	pv.total.Add(1)
+	pv.active.Add(1)
+	defer pv.active.Add(-1)
+
+	go func() {
+		_, _ = io.Copy(stream, conn)
+		_ = stream.Close()
+	}()
+	_, _ = io.Copy(conn, stream)
+
+	if err := stream.Err(); err != nil {
+		pv.fail(target, err)
+	}
+}
+
+// fail 记录最近一次连接失败，pivot status 中显示
+func (pv *pivotInstance) fail(target string, err error) {
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+	pv.lastErr = target + ": " + err.Error()
+}
+
+// showPivot 显示当前代理状态
+func showPivot(p output.Printer) error {
+	pivotMutex.Lock()
+	pv := activePivot
+	pivotMutex.Unlock()
+
+	if pv == nil {
+		p.Printf("%s No pivot running, start one with %s\n", p.Colored(config.ColorGray, "[*]"), p.Colored(config.ColorCyan, "pivot <pod>"))
+		return nil
+	}
+
+	pv.mu.Lock()
+	lastErr := pv.lastErr
+	pv.mu.Unlock()
+
+	p.Println()
+	p.Printf("  %-12s: %s\n", "Listen", pv.listener.Addr().String())
+	p.Printf("  %-12s: %s/%s\n", "Egress Pod", pv.ref.Namespace, pv.ref.Pod)
+	p.Printf("  %-12s: %s\n", "Relay", pv.relay)
+	p.Printf("  %-12s: %d active, %d total\n", "Connections", pv.active.Load(), pv.total.Load())
+	if lastErr != "" {
+		p.Printf("  %-12s: %s\n", "Last Error", p.Colored(config.ColorYellow, lastErr))
+	}
+	p.Println()
+	return nil
+}
+
+// stopPivot 停止当前代理并断开转发中的连接
+func stopPivot(p output.Printer) error {
+	pivotMutex.Lock()
+	pv := activePivot
+	activePivot = nil
+	pivotMutex.Unlock()
+
+	if pv == nil {
+		return i18n.Errorf("没有正在运行的 pivot 代理")
+	}
+	_ = pv.listener.Close()
+	pv.cancel()
+	p.Success(fmt.Sprintf("Pivot stopped (%d connection(s) relayed)", pv.total.Load()))
+	return nil
+}
@@ -0,0 +1,334 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/plugin"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+var (
+	pluginsOnce   sync.Once
+	loadedPlugins []*PluginCmd
+	pluginErrors  []error
+)
+
+// LoadPlugins 发现插件目录中的插件并注册为控制台命令，与内置命令重名的插件不注册
+func LoadPlugins() {
+	pluginsOnce.Do(func() {
+		plugins, errs := plugin.Discover(plugin.Dir())
+		pluginErrors = errs
+		for _, p := range plugins {
+			if conflict := pluginConflict(p); conflict != "" {
+				pluginErrors = append(pluginErrors, i18n.Errorf("%s: 与已有命令 %s 重名，未加载", p.Path, conflict))
+				continue
+			}
+			cmd := &PluginCmd{plugin: p}
+			Register(cmd)
+			loadedPlugins = append(loadedPlugins, cmd)
+		}
+	})
+}
+
+// pluginConflict 返回插件名或别名中与已注册命令重名的一个
+func pluginConflict(p *plugin.Plugin) string {
+	for _, name := range append([]string{p.Name}, p.Aliases...) {
+		if _, ok := Get(name); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// PluginCmd 外部插件命令
+type PluginCmd struct {
+	plugin *plugin.Plugin
+}
+
+func (c *PluginCmd) Name() string {
+	return c.plugin.Name
+}
+
+func (c *PluginCmd) Aliases() []string {
+	return c.plugin.Aliases
+}
+
+func (c *PluginCmd) Description() string {
+	return c.plugin.Description
+}
+
+func (c *PluginCmd) Usage() string {
+	usage := c.plugin.Usage
+	if usage == "" {
+		usage = c.plugin.Name + " [args...]\n\n" + c.plugin.Description
+	}
+	return usage + "\n\n插件: " + c.plugin.Path
+}
+
+func (c *PluginCmd) IsReadOnly(args []string) bool {
+	return c.plugin.ReadOnly
+}
+
+func (c *PluginCmd) IsDestructive(args []string) bool {
+	return c.plugin.Destructive
+}
+
+func (c *PluginCmd) Flags(args []string) []completion.Flag {
+	var flags []completion.Flag
+	for _, f := range c.plugin.Flags {
+		flag := completion.Flag{Name: f.Name, Short: f.Short, Arg: f.Arg, Description: f.Description, Values: pluginValues(f.Complete)}
+		if len(f.Values) > 0 {
+			choices := make([]completion.Suggestion, len(f.Values))
+			for i, v := range f.Values {
+				choices[i] = completion.Suggestion{Text: v}
+			}
+			flag.Values = completion.Choices(choices...)
+		}
+		flags = append(flags, flag)
+	}
+	return flags
+}
+
+func (c *PluginCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if values := pluginValues(c.plugin.Complete); values != nil {
+		return values(sess, args)
+	}
+	return nil
+}
+
+// pluginValues Manifest 中 complete 对应的补全
+func pluginValues(kind string) completion.ValueFunc {
+	switch kind {
+	case "pods":
+		return completion.RunningPods
+	case "namespaces":
+		return completion.Namespaces
+	case "serviceaccounts":
+		return completion.ServiceAccounts
+	case "nodes":
+		return completion.Nodes
+	}
+	return nil
+}
+
+func (c *PluginCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	// 插件拿到凭据后可以绕过会话层直接访问集群，安全模式下只允许声明为只读的插件
+	if !c.plugin.ReadOnly {
+		if err := sess.CheckSafeMode("plugin " + c.plugin.Name); err != nil {
+			return err
+		}
+	}
+
+	req, err := c.request(sess, args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.plugin.Run(sess.Context(), req, os.Stdout, os.Stderr)
+	if resp != nil {
+		if resp.Output != "" {
+			p.Println(strings.TrimRight(resp.Output, "\n"))
+		}
+		if n, saveErr := c.saveFindings(sess, resp.Findings); saveErr != nil {
+			p.Warning(i18n.Tf("保存插件发现失败: %v", saveErr))
+		} else if n > 0 {
+			p.Printf("%s %d finding(s) saved from plugin %s\n", p.Colored(config.ColorBlue, "[*]"), n, c.plugin.Name)
+		}
+		if resp.Error != "" && err == nil {
+			return errors.New(resp.Error)
+		}
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &ExitCodeError{Code: exitErr.ExitCode()}
+	}
+	if err != nil {
+		return i18n.Errorf("执行插件 %s 失败: %w", c.plugin.Name, err)
+	}
+	return nil
+}
+
+// request 构建插件请求，按 Manifest.Needs 附带会话数据
+func (c *PluginCmd) request(sess *session.Session, args []string) (*plugin.Request, error) {
+	cfg := sess.Config
+	req := &plugin.Request{
+		Version: plugin.ProtocolVersion,
+		Command: c.plugin.Name,
+		Args:    args,
+		Session: plugin.SessionInfo{
+			KubeletIP:   cfg.KubeletIP,
+			KubeletPort: cfg.KubeletPort,
+			APIServer:   sess.APIServerURL(),
+			Token:       cfg.Token,
+			ClientCert:  cfg.ClientCert,
+			ClientKey:   cfg.ClientKey,
+			CACert:      cfg.CACert,
+			Proxy:       cfg.ProxyURL,
+			Mode:        string(sess.Mode),
+		},
+	}
+	if !sess.DB.IsInMemory() {
+		req.Session.DBPath = sess.DB.Path()
+	}
+	if sa := sess.GetCurrentSA(); sa != nil {
+		req.Session.CurrentSA = &plugin.CurrentSA{
+			Namespace:      sa.Namespace,
+			Name:           sa.Name,
+			Token:          sa.Token,
+			RiskLevel:      sa.RiskLevel,
+			IsClusterAdmin: sa.IsClusterAdmin,
+		}
+	}
+
+	// 安全模式下只读插件同样不附带任何凭据
+	safe := sess.IsSafeMode()
+	if safe {
+		req.Session.Token, req.Session.ClientCert, req.Session.ClientKey, req.Session.Proxy = "", "", "", ""
+		if req.Session.CurrentSA != nil {
+			req.Session.CurrentSA.Token = ""
+		}
+	}
+
+	var err error
+	if c.plugin.Needs(plugin.NeedPods) {
+		req.Pods = sess.GetCachedPods()
+	}
+	if c.plugin.Needs(plugin.NeedServiceAccounts) {
+		if req.ServiceAccounts, err = sess.SADB.GetAll(); err != nil {
+			return nil, i18n.Errorf("获取 ServiceAccount 失败: %w", err)
+		}
+		if safe {
+			for _, sa := range req.ServiceAccounts {
+				sa.Token = ""
+			}
+		}
+	}
+	if c.plugin.Needs(plugin.NeedFindings) {
+		if req.Findings, err = sess.FindingDB.GetAll(); err != nil {
+			return nil, i18n.Errorf("获取检查发现失败: %w", err)
+		}
+	}
+	return req, nil
+}
+
+// saveFindings 保存插件返回的检查发现，查看模式下不保存
+func (c *PluginCmd) saveFindings(sess *session.Session, findings []*types.FindingRecord) (int, error) {
+	if len(findings) == 0 || sess.IsViewer() {
+		return 0, nil
+	}
+	now := time.Now()
+	for _, f := range findings {
+		if f.Source == "" {
+			f.Source = c.plugin.Name
+		}
+		if f.KubeletIP == "" {
+			f.KubeletIP = sess.Config.KubeletIP
+		}
+		if f.CollectedAt.IsZero() {
+			f.CollectedAt = now
+		}
+		f.Severity = strings.ToUpper(f.Severity)
+	}
+	return sess.FindingDB.SaveBatch(findings)
+}
+
+// PluginsCmd plugins 命令
+type PluginsCmd struct{}
+
+func init() {
+	Register(&PluginsCmd{})
+}
+
+func (c *PluginsCmd) Name() string {
+	return "plugins"
+}
+
+func (c *PluginsCmd) Aliases() []string {
+	return nil
+}
+
+func (c *PluginsCmd) Description() string {
+	return "列出已加载的插件"
+}
+
+// IsReadOnly plugins 只列出本地插件
+func (c *PluginsCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+// IsPaged plugins 输出插件表格
+func (c *PluginsCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *PluginsCmd) Usage() string {
+	return `plugins
+
+列出插件目录（默认 ~/.kctl/plugins，可用 KCTL_PLUGINS 覆盖）中已加载的插件及加载失败的原因
+插件是可执行文件，启动时发现并注册为控制台命令：
+
+  <plugin> --kctl-describe   输出 JSON 描述:
+    {"name": "imagegrep", "description": "按镜像查找 Pod", "readOnly": true,
+     "needs": ["pods"], "flags": [{"name": "--image", "arg": "<regex>"}]}
+
+  <plugin> [args...]          执行，标准输入为 JSON 请求:
+    {"version": 1, "command": "imagegrep", "args": [...],
+     "session": {"kubeletIP", "kubeletPort", "apiServer", "token", "proxy", "currentSA", ...},
+     "pods": [...], "serviceAccounts": [...], "findings": [...]}
+
+  标准输出、标准错误直接显示，退出码作为命令的退出码
+  "output": "json" 时标准输出为 {"output": "...", "findings": [...], "error": "..."}，
+  其中的 findings 保存到数据库（可被 export sarif / --fail-on 使用）
+
+needs 可选: pods, serviceaccounts, findings
+complete 可选: pods, namespaces, serviceaccounts, nodes`
+}
+
+func (c *PluginsCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	if len(loadedPlugins) == 0 && len(pluginErrors) == 0 {
+		p.Warning(i18n.Tf("没有插件，将可执行文件放入 %s 后重新启动 kctl", plugin.Dir()))
+		return nil
+	}
+
+	if len(loadedPlugins) > 0 {
+		var rows [][]string
+		for _, cmd := range loadedPlugins {
+			mode := ""
+			if cmd.plugin.ReadOnly {
+				mode = "read-only"
+			}
+			if cmd.plugin.Destructive {
+				mode = "destructive"
+			}
+			rows = append(rows, []string{cmd.Name(), cmd.Description(), strings.Join(cmd.plugin.Manifest.Needs, ","), mode, cmd.plugin.Path})
+		}
+		p.Println()
+		output.NewTablePrinter().PrintSimple([]string{"NAME", "DESCRIPTION", "NEEDS", "MODE", "PATH"}, rows)
+		p.Printf("\n  %s\n", i18n.Tf("共 %d 个插件", len(rows)))
+	}
+
+	if len(pluginErrors) > 0 {
+		p.Println()
+		p.Printf("  %s\n", p.Colored(config.ColorYellow, i18n.T("加载失败:")))
+		for _, err := range pluginErrors {
+			p.Printf("    %s\n", err)
+		}
+	}
+	p.Println()
+	return nil
+}
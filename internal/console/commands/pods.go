@@ -3,9 +3,13 @@ package commands
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/filter"
+	"kctl/internal/i18n"
 	"kctl/internal/output"
 	"kctl/internal/session"
 	"kctl/pkg/types"
@@ -30,6 +34,16 @@ func (c *PodsCmd) Description() string {
 	return "列出 Pod"
 }
 
+// IsReadOnly 使用缓存时只读，--refresh 会访问 Kubelet
+func (c *PodsCmd) IsReadOnly(args []string) bool {
+	return !slices.Contains(args, "--refresh")
+}
+
+// IsPaged pods 输出 Pod 表格
+func (c *PodsCmd) IsPaged(args []string) bool {
+	return true
+}
+
 func (c *PodsCmd) Usage() string {
 	return `pods [options]
 
@@ -40,13 +54,31 @@ func (c *PodsCmd) Usage() string {
   --privileged, -P    只显示特权 Pod
   --running, -R       只显示 Running 状态的 Pod
   -n <namespace>      按命名空间过滤
+  --where <expr>      按过滤表达式筛选（@name 引用已保存的过滤器，见 filter）
   --refresh           强制刷新（重新从 Kubelet 获取）
+  --absolute          详细信息中显示绝对时间（默认相对时间）
 
 示例：
   pods                    列出所有 Pod
   pods --detail           显示详细信息
+  pods -d --absolute      显示详细信息，时间以绝对格式显示
   pods --privileged       只显示特权 Pod
-  pods -n kube-system     只显示 kube-system 命名空间的 Pod`
+  pods -n kube-system     只显示 kube-system 命名空间的 Pod
+  pods --where 'privileged && namespace!=kube-system'
+  pods --where @prod-risky`
+}
+
+// Flags pods 的选项补全
+func (c *PodsCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--detail", Short: "-d", Description: "显示详细信息"},
+		{Name: "--privileged", Short: "-P", Description: "只显示特权 Pod"},
+		{Name: "--running", Short: "-R", Description: "只显示 Running 状态"},
+		{Name: "-n", Arg: "<namespace>", Description: "按命名空间过滤", Values: completion.Namespaces},
+		flagWhere,
+		{Name: "--refresh", Description: "强制刷新"},
+		flagAbsolute,
+	}
 }
 
 func (c *PodsCmd) Execute(sess *session.Session, args []string) error {
@@ -58,7 +90,9 @@ func (c *PodsCmd) Execute(sess *session.Session, args []string) error {
 	onlyPrivileged := false
 	onlyRunning := false
 	namespace := ""
+	where := ""
 	refresh := false
+	absolute := false
 
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
@@ -73,16 +107,28 @@ func (c *PodsCmd) Execute(sess *session.Session, args []string) error {
 				namespace = args[i+1]
 				i++
 			}
+		case "--where", "-w":
+			if i+1 < len(args) {
+				where = args[i+1]
+				i++
+			}
 		case "--refresh":
 			refresh = true
+		case "--absolute":
+			absolute = true
 		}
 	}
 
+	expr, err := parseWhere(where)
+	if err != nil {
+		return err
+	}
+
 	// 获取 Pod 列表
 	pods := sess.GetCachedPods()
 
-	// 如果没有缓存或需要刷新，从 Kubelet 获取
-	if len(pods) == 0 || refresh {
+	// 如果没有缓存或需要刷新，从 Kubelet 获取（查看模式只使用数据库中的快照）
+	if (len(pods) == 0 || refresh) && !sess.IsViewer() {
 		kubelet, err := sess.GetKubeletClient()
 		if err != nil {
 			return err
@@ -93,7 +139,7 @@ func (c *PodsCmd) Execute(sess *session.Session, args []string) error {
 
 		pods, err = kubelet.GetPodsWithContainers(ctx)
 		if err != nil {
-			return fmt.Errorf("获取 Pod 列表失败: %w", err)
+			return i18n.Errorf("获取 Pod 列表失败: %w", err)
 		}
 
 		// 缓存
@@ -101,11 +147,15 @@ func (c *PodsCmd) Execute(sess *session.Session, args []string) error {
 	}
 
 	if len(pods) == 0 {
-		p.Warning("没有找到 Pod")
+		p.Warning(i18n.T("没有找到 Pod"))
 		return nil
 	}
 
 	// 过滤
+	var risks map[string]string
+	if expr != nil {
+		risks = podRiskIndex(sess)
+	}
 	var filtered []types.PodContainerInfo
 	for _, pod := range pods {
 		// 命名空间过滤
@@ -123,11 +173,16 @@ func (c *PodsCmd) Execute(sess *session.Session, args []string) error {
 			continue
 		}
 
+		// 表达式过滤
+		if expr != nil && !expr.Match(filter.PodRecord(pod, risks[pod.Namespace+"/"+pod.ServiceAccount])) {
+			continue
+		}
+
 		filtered = append(filtered, pod)
 	}
 
 	if len(filtered) == 0 {
-		p.Warning("没有符合条件的 Pod")
+		p.Warning(i18n.T("没有符合条件的 Pod"))
 		return nil
 	}
 
@@ -135,12 +190,12 @@ func (c *PodsCmd) Execute(sess *session.Session, args []string) error {
 
 	// 根据是否显示详情选择输出格式
 	if showDetail {
-		c.printDetail(p, filtered)
+		c.printDetail(p, filtered, sess.TimeFormatter(absolute))
 	} else {
 		c.printTable(p, filtered)
 	}
 
-	p.Printf("\n  共 %d 个 Pod\n\n", len(filtered))
+	p.Printf("\n  %s\n\n", i18n.Tf("共 %d 个 Pod", len(filtered)))
 
 	return nil
 }
@@ -165,7 +220,7 @@ func (c *PodsCmd) printTable(p output.Printer, pods []types.PodContainerInfo) {
 }
 
 // printDetail 详细信息输出
-func (c *PodsCmd) printDetail(p output.Printer, pods []types.PodContainerInfo) {
+func (c *PodsCmd) printDetail(p output.Printer, pods []types.PodContainerInfo, tf output.TimeFormatter) {
 	for i, pod := range pods {
 		// Pod 标题
 		statusColor := config.ColorGreen
@@ -187,7 +242,7 @@ func (c *PodsCmd) printDetail(p output.Printer, pods []types.PodContainerInfo) {
 		p.Printf("    %-18s: %s\n", "Node", pod.NodeName)
 		p.Printf("    %-18s: %s\n", "ServiceAccount", pod.ServiceAccount)
 		if pod.CreatedAt != "" {
-			p.Printf("    %-18s: %s\n", "Created", pod.CreatedAt)
+			p.Printf("    %-18s: %s\n", "Created", tf.FormatString(pod.CreatedAt))
 		}
 		if pod.UID != "" {
 			p.Printf("    %-18s: %s\n", "UID", p.Colored(config.ColorGray, pod.UID))
@@ -200,7 +255,21 @@ func (c *PodsCmd) printDetail(p output.Printer, pods []types.PodContainerInfo) {
 		p.Println()
 		p.Printf("    %s (%d)\n", p.Colored(config.ColorYellow, "Containers"), len(pod.Containers))
 		for j, container := range pod.Containers {
-			c.printContainerDetail(p, container, j+1)
+			c.printContainerDetail(p, container, j+1, tf)
+		}
+		if len(pod.InitContainers) > 0 {
+			p.Println()
+			p.Printf("    %s (%d)\n", p.Colored(config.ColorYellow, "Init Containers"), len(pod.InitContainers))
+			for j, container := range pod.InitContainers {
+				c.printContainerDetail(p, container, j+1, tf)
+			}
+		}
+		if len(pod.EphemeralContainers) > 0 {
+			p.Println()
+			p.Printf("    %s (%d)\n", p.Colored(config.ColorYellow, "Ephemeral Containers"), len(pod.EphemeralContainers))
+			for j, container := range pod.EphemeralContainers {
+				c.printContainerDetail(p, container, j+1, tf)
+			}
 		}
 
 		// Volumes
@@ -229,22 +298,29 @@ func (c *PodsCmd) printDetail(p output.Printer, pods []types.PodContainerInfo) {
 }
 
 // printContainerDetail 打印容器详情
-func (c *PodsCmd) printContainerDetail(p output.Printer, container types.ContainerDetail, index int) {
+func (c *PodsCmd) printContainerDetail(p output.Printer, container types.ContainerDetail, index int, tf output.TimeFormatter) {
 	// 容器名称和状态
 	stateColor := config.ColorGreen
 	if !strings.HasPrefix(container.State, "Running") {
 		stateColor = config.ColorYellow
 	}
 
-	p.Printf("      %s %s\n",
+	sidecar := ""
+	if container.Sidecar {
+		sidecar = p.Colored(config.ColorGray, " (sidecar)")
+	}
+	p.Printf("      %s %s%s\n",
 		p.Colored(config.ColorCyan, fmt.Sprintf("[%d]", index)),
-		p.Colored(config.ColorWhite, container.Name))
+		p.Colored(config.ColorWhite, container.Name), sidecar)
 
 	p.Printf("          %-14s: %s\n", "Image", p.Colored(config.ColorGray, container.Image))
+	if container.Target != "" {
+		p.Printf("          %-14s: %s\n", "Target", container.Target)
+	}
 	p.Printf("          %-14s: %s\n", "State", p.Colored(stateColor, container.State))
 
 	if container.StartedAt != "" {
-		p.Printf("          %-14s: %s\n", "Started", container.StartedAt)
+		p.Printf("          %-14s: %s\n", "Started", tf.FormatString(container.StartedAt))
 	}
 
 	// 安全上下文
@@ -298,20 +374,7 @@ func (c *PodsCmd) printContainerDetail(p output.Printer, container types.Contain
 
 // buildFlags 构建简短的 flags 字符串
 func (c *PodsCmd) buildFlags(p output.Printer, flags types.SecurityFlags) string {
-	var result []string
-
-	if flags.Privileged {
-		result = append(result, p.Colored(config.ColorRed, "PRIV"))
-	}
-	if flags.AllowPrivilegeEscalation {
-		result = append(result, p.Colored(config.ColorYellow, "PE"))
-	}
-	if flags.HasHostPath {
-		result = append(result, p.Colored(config.ColorRed, "HP"))
-	}
-	if flags.HasSecretMount {
-		result = append(result, p.Colored(config.ColorYellow, "SEC"))
-	}
+	result := p.Formatter().FormatFlagAbbrevs(flags)
 	if flags.HasSATokenMount {
 		result = append(result, p.Colored(config.ColorGreen, "SA"))
 	}
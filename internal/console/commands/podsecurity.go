@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"kctl/config"
+	k8sclient "kctl/internal/client/k8s"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// PodSecurityCmd podsecurity 命令
+type PodSecurityCmd struct{}
+
+func init() {
+	Register(&PodSecurityCmd{})
+}
+
+func (c *PodSecurityCmd) Name() string {
+	return "podsecurity"
+}
+
+func (c *PodSecurityCmd) Aliases() []string {
+	return []string{"psa"}
+}
+
+func (c *PodSecurityCmd) Description() string {
+	return "检查 PSA / PSP，找出可部署特权 Pod 的命名空间"
+}
+
+// IsPaged podsecurity 输出 Pod 安全检查报告
+func (c *PodSecurityCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *PodSecurityCmd) Usage() string {
+	return `podsecurity [options]
+
+通过 API Server 读取命名空间的 pod-security.kubernetes.io 标签（及旧版 PodSecurityPolicy），
+找出允许特权 Pod 的命名空间，并检查当前 Token 能否在其中创建 Pod —— 即逃逸 Pod 可以部署在哪里
+
+  ENFORCE     PSA enforce 级别，未设置时按 privileged 处理（集群默认级别在 API 中不可见）
+  PRIVILEGED  是否允许特权 / hostPID / hostPath Pod
+  CREATE      当前 Token 能否在该命名空间创建 Pod
+  DEPLOY      两者都满足时可直接执行 deploy -n <namespace>
+
+集群仍启用 PSP（Kubernetes 1.25 之前）时，同时列出允许逃逸手段的 PSP 及当前 Token 能否 use
+
+选项：
+  -n <namespace>      只检查指定命名空间
+  --all               同时显示不允许特权 Pod 的命名空间
+
+示例：
+  podsecurity
+  psa --all
+  psa -n monitoring`
+}
+
+// Flags podsecurity 的选项补全
+func (c *PodSecurityCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		flagNamespace,
+		{Name: "--all", Description: "同时显示不允许特权 Pod 的命名空间"},
+	}
+}
+
+func (c *PodSecurityCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	namespace := ""
+	all := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 < len(args) {
+				namespace = args[i+1]
+				i++
+			}
+		case "--all":
+			all = true
+		default:
+			return i18n.Errorf("未知参数: %s", args[i])
+		}
+	}
+
+	if sess.Config.APIServer == "" {
+		return i18n.Errorf("未设置 API Server，请使用 'set api-server <addr>' 设置")
+	}
+	k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+	if err != nil {
+		return err
+	}
+
+	p.Printf("%s Reading namespace Pod Security labels...\n", p.Colored(config.ColorBlue, "[*]"))
+	namespaces, err := k8s.ListNamespaces(ctx)
+	if err != nil {
+		return i18n.Errorf("获取命名空间失败: %w", err)
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Name < namespaces[j].Name
+	})
+
+	var rows [][]string
+	var deployable []string
+	checked, privilegedCount := 0, 0
+	for _, ns := range namespaces {
+		if namespace != "" && ns.Name != namespace {
+			continue
+		}
+		checked++
+		privileged := security.PSAAllowsPrivileged(ns)
+		if privileged {
+			privilegedCount++
+		} else if !all {
+			continue
+		}
+		canCreate, err := k8s.CheckPermission(ctx, &k8sclient.PermissionRequest{Resource: "pods", Verb: "create", Namespace: ns.Name})
+		if err != nil {
+			p.Warning(i18n.Tf("检查 %s 中的 create pods 权限失败: %v", ns.Name, err))
+		}
+
+		verdict := "-"
+		if privileged && canCreate {
+			verdict = p.Colored(config.ColorRed, "yes")
+			deployable = append(deployable, ns.Name)
+		}
+		rows = append(rows, []string{
+			ns.Name,
+			c.formatLevel(p, ns),
+			c.formatModes(ns),
+			c.yesNo(p, privileged, config.ColorRed),
+			c.yesNo(p, canCreate, config.ColorYellow),
+			verdict,
+		})
+	}
+	if namespace != "" && checked == 0 {
+		return i18n.Errorf("命名空间不存在: %s", namespace)
+	}
+
+	if len(rows) > 0 {
+		p.Println()
+		output.NewTablePrinter().PrintSimple([]string{"NAMESPACE", "ENFORCE", "WARN/AUDIT", "PRIVILEGED", "CREATE", "DEPLOY"}, rows)
+	}
+	p.Println()
+	p.Printf("%s %d of %d namespace(s) allow privileged pods\n", p.Colored(config.ColorGreen, "[+]"), privilegedCount, checked)
+	if len(deployable) > 0 {
+		p.Printf("%s Escape pod can be deployed in: %s (deploy hostpath -n <namespace>)\n",
+			p.Colored(config.ColorRed, "[!]"), strings.Join(deployable, ", "))
+	}
+
+	c.checkPSP(sess, k8s)
+	return nil
+}
+
+// checkPSP 列出允许逃逸手段的 PodSecurityPolicy 及当前 Token 能否 use
+func (c *PodSecurityCmd) checkPSP(sess *session.Session, k8s k8sclient.Client) {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	psps, err := k8s.ListPodSecurityPolicies(ctx)
+	if errors.Is(err, k8sclient.ErrNotFound) {
+		p.Printf("%s PodSecurityPolicy API not served (removed in Kubernetes 1.25), PSA labels apply\n", p.Colored(config.ColorBlue, "[*]"))
+		return
+	}
+	if err != nil {
+		p.Warning(i18n.Tf("获取 PodSecurityPolicy 失败: %v", err))
+		return
+	}
+	if len(psps) == 0 {
+		return
+	}
+
+	var rows [][]string
+	var usable []string
+	for _, psp := range psps {
+		vectors := security.PSPEscapeVectors(psp)
+		if len(vectors) == 0 {
+			continue
+		}
+		canUse, err := k8s.CheckPermission(ctx, &k8sclient.PermissionRequest{
+			Resource: "podsecuritypolicies", Group: "policy", Verb: "use", Name: psp.Name,
+		})
+		if err != nil {
+			p.Warning(i18n.Tf("检查 PSP %s 的 use 权限失败: %v", psp.Name, err))
+		}
+		if canUse && security.PSPAllowsPrivileged(psp) {
+			usable = append(usable, psp.Name)
+		}
+		rows = append(rows, []string{
+			psp.Name,
+			c.yesNo(p, security.PSPAllowsPrivileged(psp), config.ColorRed),
+			strings.Join(vectors, ", "),
+			c.yesNo(p, canUse, config.ColorYellow),
+		})
+	}
+
+	p.Println()
+	p.Printf("%s PodSecurityPolicy enabled, %d policies\n", p.Colored(config.ColorYellow, "[!]"), len(psps))
+	if len(rows) > 0 {
+		p.Println()
+		output.NewTablePrinter().PrintSimple([]string{"PSP", "PRIVILEGED", "ALLOWS", "USE"}, rows)
+	}
+	p.Println()
+	if len(usable) > 0 {
+		p.Printf("%s Current token can use privileged PSP: %s\n", p.Colored(config.ColorRed, "[!]"), strings.Join(usable, ", "))
+	} else {
+		p.Printf("%s No privileged PSP usable by the current token, privileged pods may be rejected even where PSA allows them\n",
+			p.Colored(config.ColorBlue, "[*]"))
+	}
+}
+
+// formatLevel 格式化 enforce 级别（含版本），未设置时标注
+func (c *PodSecurityCmd) formatLevel(p output.Printer, ns types.NamespaceInfo) string {
+	if ns.PodSecurityLevel("enforce") == "" {
+		return p.Colored(config.ColorRed, "(none)")
+	}
+	level := security.EffectivePSALevel(ns)
+	if version := ns.PodSecurityLevel("enforce-version"); version != "" && version != "latest" {
+		level += "@" + version
+	}
+	switch security.EffectivePSALevel(ns) {
+	case security.PSAPrivileged:
+		return p.Colored(config.ColorRed, level)
+	case security.PSABaseline:
+		return p.Colored(config.ColorYellow, level)
+	default:
+		return p.Colored(config.ColorGreen, level)
+	}
+}
+
+// formatModes 格式化 warn / audit 级别
+func (c *PodSecurityCmd) formatModes(ns types.NamespaceInfo) string {
+	return orDash(ns.PodSecurityLevel("warn")) + "/" + orDash(ns.PodSecurityLevel("audit"))
+}
+
+// yesNo 格式化布尔值，true 时着色
+func (c *PodSecurityCmd) yesNo(p output.Printer, v bool, color config.ColorName) string {
+	if v {
+		return p.Colored(color, "yes")
+	}
+	return "no"
+}
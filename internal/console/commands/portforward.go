@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
@@ -10,6 +11,9 @@ import (
 	"time"
 
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/console/picker"
+	"kctl/internal/i18n"
 	"kctl/internal/output"
 	"kctl/internal/session"
 	"kctl/pkg/types"
@@ -51,7 +55,7 @@ func (c *PortForwardCmd) Usage() string {
 通过 Kubelet /portForward API 进行端口转发
 
 选项：
-  -n <namespace>      指定命名空间
+  -n <namespace>      指定命名空间（默认从 Pod 缓存推断，也可写为 <namespace>/<pod>）
   --address <addr>    监听地址（默认: 127.0.0.1）
   --timeout <seconds> 超时时间（秒），0 表示无限（默认: 0）
 
@@ -67,6 +71,27 @@ func (c *PortForwardCmd) Usage() string {
   pf stop                                      停止端口转发`
 }
 
+// Flags portforward 的选项补全
+func (c *PortForwardCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		flagNamespace,
+		{Name: "--address", Arg: "<addr>", Description: "监听地址", Values: completion.Choices(
+			completion.Suggestion{Text: "127.0.0.1", Description: "仅本地访问（默认）"},
+			completion.Suggestion{Text: "0.0.0.0", Description: "所有接口"},
+		)},
+		{Name: "--timeout", Arg: "<seconds>", Description: "超时时间（秒），0 表示无限"},
+	}
+}
+
+// Suggestions portforward 的 Pod 补全
+func (c *PortForwardCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	pods := podSuggestions(sess, c, args, completion.RunningPods)
+	if len(args) == 0 {
+		pods = append([]completion.Suggestion{{Text: "stop", Description: "停止当前端口转发"}}, pods...)
+	}
+	return pods
+}
+
 func (c *PortForwardCmd) Execute(sess *session.Session, args []string) error {
 	p := sess.Printer
 
@@ -75,6 +100,11 @@ func (c *PortForwardCmd) Execute(sess *session.Session, args []string) error {
 		return stopPortForward(p)
 	}
 
+	// 转发的连接在后台建立，安全模式下提前拒绝
+	if err := sess.CheckSafeMode("portforward"); err != nil {
+		return err
+	}
+
 	ctx := context.Background()
 
 	// 检查连接
@@ -126,37 +156,31 @@ func (c *PortForwardCmd) Execute(sess *session.Session, args []string) error {
 	}
 
 	if podName == "" {
-		return fmt.Errorf("请指定 Pod 名称，或使用 'pf stop' 停止当前转发")
+		return i18n.Errorf("请指定 Pod 名称，或使用 'pf stop' 停止当前转发")
 	}
 	if len(ports) == 0 {
-		return fmt.Errorf("请指定端口映射，格式: <local_port>:<remote_port>")
+		return i18n.Errorf("请指定端口映射，格式: <local_port>:<remote_port>")
 	}
 
 	// 检查是否已有活动的端口转发
 	pfMutex.Lock()
 	if activePortForward != nil {
 		pfMutex.Unlock()
-		return fmt.Errorf("已有端口转发在运行，请先执行 'pf stop' 停止")
+		return i18n.Errorf("已有端口转发在运行，请先执行 'pf stop' 停止")
 	}
 	pfMutex.Unlock()
 
-	// 从缓存查找 namespace
-	if namespace == "" {
-		pods := sess.GetCachedPods()
-		for _, pod := range pods {
-			if pod.PodName == podName {
-				namespace = pod.Namespace
-				break
-			}
-		}
+	ref, err := resolvePod(sess, podName, namespace, "")
+	if errors.Is(err, picker.ErrCancelled) {
+		return nil
 	}
-	if namespace == "" {
-		namespace = "default"
+	if err != nil {
+		return err
 	}
 
 	opts := &types.PortForwardOptions{
-		Namespace: namespace,
-		Pod:       podName,
+		Namespace: ref.Namespace,
+		Pod:       ref.Pod,
 		Ports:     ports,
 		Address:   address,
 	}
@@ -164,7 +188,7 @@ func (c *PortForwardCmd) Execute(sess *session.Session, args []string) error {
 	// 创建停止控制端口
 	stopListener, stopPort, err := createStopListener()
 	if err != nil {
-		return fmt.Errorf("创建停止监听器失败: %w", err)
+		return i18n.Errorf("创建停止监听器失败: %w", err)
 	}
 
 	// 设置停止信号
@@ -182,7 +206,7 @@ func (c *PortForwardCmd) Execute(sess *session.Session, args []string) error {
 	p.Printf("%s Forwarding ports:\n", p.Colored(config.ColorBlue, "[*]"))
 	for _, pm := range ports {
 		p.Printf("    %s:%d -> %s/%s:%d\n",
-			address, pm.Local, namespace, podName, pm.Remote)
+			address, pm.Local, ref.Namespace, ref.Pod, pm.Remote)
 	}
 	p.Println()
 	p.Printf("%s To stop: %s or %s\n",
@@ -242,7 +266,7 @@ func stopPortForward(p output.Printer) error {
 	defer pfMutex.Unlock()
 
 	if activePortForward == nil {
-		return fmt.Errorf("没有正在运行的端口转发")
+		return i18n.Errorf("没有正在运行的端口转发")
 	}
 
 	// 通过连接停止端口来触发停止
@@ -295,17 +319,17 @@ func createStopListener() (net.Listener, int, error) {
 func parsePortMapping(s string) (types.PortMapping, error) {
 	parts := strings.Split(s, ":")
 	if len(parts) != 2 {
-		return types.PortMapping{}, fmt.Errorf("无效的端口映射格式: %s (应为 local:remote)", s)
+		return types.PortMapping{}, i18n.Errorf("无效的端口映射格式: %s (应为 local:remote)", s)
 	}
 
 	local, err := strconv.ParseUint(parts[0], 10, 16)
 	if err != nil {
-		return types.PortMapping{}, fmt.Errorf("无效的本地端口: %s", parts[0])
+		return types.PortMapping{}, i18n.Errorf("无效的本地端口: %s", parts[0])
 	}
 
 	remote, err := strconv.ParseUint(parts[1], 10, 16)
 	if err != nil {
-		return types.PortMapping{}, fmt.Errorf("无效的远程端口: %s", parts[1])
+		return types.PortMapping{}, i18n.Errorf("无效的远程端口: %s", parts[1])
 	}
 
 	return types.PortMapping{
@@ -0,0 +1,500 @@
+package commands
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/client"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/notify"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/token"
+)
+
+// ProfileCmd profile 命令
+type ProfileCmd struct{}
+
+func init() {
+	Register(&ProfileCmd{})
+}
+
+func (c *ProfileCmd) Name() string {
+	return "profile"
+}
+
+func (c *ProfileCmd) Aliases() []string {
+	return nil
+}
+
+func (c *ProfileCmd) Description() string {
+	return "管理配置文件中的 profile"
+}
+
+// IsReadOnly 查看 profile 只读本地配置文件
+func (c *ProfileCmd) IsReadOnly(args []string) bool {
+	return len(args) == 0 || slices.Contains([]string{"list", "ls", "show"}, args[0])
+}
+
+// IsPaged 分页 profile list / show
+func (c *ProfileCmd) IsPaged(args []string) bool {
+	return c.IsReadOnly(args)
+}
+
+func (c *ProfileCmd) Usage() string {
+	return `profile <list|use|show|save|delete|default> [name]
+
+profile 是保存在用户配置文件中（默认 ~/.kctl/config.yaml，可用 KCTL_CONFIG 覆盖）的一组会话默认值，
+配置项与 set 同名。启动时通过 --profile 选择，未指定时使用 default-profile
+
+子命令：
+  list                   列出 profile（* 当前，D 默认）
+  use <name>             应用 profile 并重新连接（只覆盖 profile 中设置的项）
+  show <name>            显示 profile 内容
+  save <name>            将当前会话设置保存为 profile（同名覆盖，Token 仅保存 token-file）
+  delete <name>          删除 profile
+  default <name|none>    设置启动时默认使用的 profile
+
+配置文件示例：
+  default-profile: lab
+  profiles:
+    lab:
+      target: 10.0.0.1
+      token-file: ~/engagements/lab/token
+      proxy: socks5://127.0.0.1:1080
+      concurrency: 5
+    prod:
+      kubeconfig: ~/engagements/prod/stolen.kubeconfig
+      context: prod-admin
+      time-format: absolute
+      timezone: utc
+
+示例：
+  profile list
+  profile use prod
+  profile save lab
+  profile default lab`
+}
+
+// Suggestions profile 的子命令及 profile 名补全
+func (c *ProfileCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) == 0 {
+		return []completion.Suggestion{
+			{Text: "list", Description: "列出 profile"},
+			{Text: "use", Description: "应用 profile"},
+			{Text: "show", Description: "显示 profile 内容"},
+			{Text: "save", Description: "保存当前设置为 profile"},
+			{Text: "delete", Description: "删除 profile"},
+			{Text: "default", Description: "设置默认 profile"},
+		}
+	}
+	if len(args) != 1 || !slices.Contains([]string{"use", "show", "save", "delete", "rm", "default"}, args[0]) {
+		return nil
+	}
+	cfg, err := config.LoadUserConfig()
+	if err != nil {
+		return nil
+	}
+	var suggestions []completion.Suggestion
+	for _, name := range sortedProfileNames(cfg) {
+		suggestions = append(suggestions, completion.Suggestion{Text: name, Description: profileSummary(cfg.Profiles[name])})
+	}
+	return suggestions
+}
+
+func (c *ProfileCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) == 0 {
+		return c.list(sess)
+	}
+	p := sess.Printer
+
+	sub := args[0]
+	if sub == "list" || sub == "ls" {
+		return c.list(sess)
+	}
+	if len(args) < 2 {
+		return i18n.Errorf("用法: profile %s <name>", sub)
+	}
+	name := args[1]
+
+	cfg, err := config.LoadUserConfig()
+	if err != nil {
+		return err
+	}
+
+	switch sub {
+	case "use":
+		profile, err := cfg.Profile(name)
+		if err != nil {
+			return err
+		}
+		if err := ApplyProfile(sess, name, profile); err != nil {
+			return err
+		}
+		p.Success(fmt.Sprintf("Profile '%s' applied", name))
+		reconnect(sess, p, true)
+		return nil
+
+	case "show":
+		profile, err := cfg.Profile(name)
+		if err != nil {
+			return err
+		}
+		c.show(p, name, profile)
+		return nil
+
+	case "save":
+		profile, skippedToken := ProfileFromSession(sess)
+		if cfg.Profiles == nil {
+			cfg.Profiles = make(map[string]*config.Profile)
+		}
+		cfg.Profiles[name] = profile
+		if err := config.SaveUserConfig(cfg); err != nil {
+			return err
+		}
+		sess.Config.Profile = name
+		path, _ := config.UserConfigPath()
+		p.Success(fmt.Sprintf("Profile '%s' saved to %s", name, path))
+		if skippedToken {
+			p.Warning(i18n.T("Token 不是从文件加载的，未保存；如需保存请使用 'set token-file <path>'"))
+		}
+		return nil
+
+	case "delete", "rm":
+		if _, err := cfg.Profile(name); err != nil {
+			return err
+		}
+		delete(cfg.Profiles, name)
+		if cfg.DefaultProfile == name {
+			cfg.DefaultProfile = ""
+		}
+		if err := config.SaveUserConfig(cfg); err != nil {
+			return err
+		}
+		p.Success(fmt.Sprintf("Profile '%s' deleted", name))
+		return nil
+
+	case "default":
+		if name == "none" || name == "off" {
+			cfg.DefaultProfile = ""
+		} else if _, err := cfg.Profile(name); err != nil {
+			return err
+		} else {
+			cfg.DefaultProfile = name
+		}
+		if err := config.SaveUserConfig(cfg); err != nil {
+			return err
+		}
+		if cfg.DefaultProfile == "" {
+			p.Success("Default profile cleared")
+		} else {
+			p.Success(fmt.Sprintf("Default profile set to: %s", name))
+		}
+		return nil
+
+	default:
+		return i18n.Errorf("未知子命令: %s (可用: list, use, show, save, delete, default)", sub)
+	}
+}
+
+// list 列出 profile
+func (c *ProfileCmd) list(sess *session.Session) error {
+	p := sess.Printer
+
+	cfg, err := config.LoadUserConfig()
+	if err != nil {
+		return err
+	}
+	if len(cfg.Profiles) == 0 {
+		p.Warning(i18n.T("没有 profile，使用 'profile save <name>' 保存当前设置"))
+		return nil
+	}
+
+	var rows [][]string
+	for _, name := range sortedProfileNames(cfg) {
+		mark := ""
+		if name == sess.Config.Profile {
+			mark += "*"
+		}
+		if name == cfg.DefaultProfile {
+			mark += "D"
+		}
+		rows = append(rows, []string{mark, name, profileSummary(cfg.Profiles[name])})
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"", "NAME", "SETTINGS"}, rows)
+	p.Printf("\n  %s\n\n", i18n.Tf("共 %d 个 profile", len(rows)))
+	return nil
+}
+
+// show 显示 profile 中设置的项
+func (c *ProfileCmd) show(p output.Printer, name string, profile *config.Profile) {
+	p.Println()
+	p.Printf("  %s\n\n", p.Colored(config.ColorYellow, "Profile "+name+":"))
+	for _, kv := range profileEntries(profile) {
+		p.Printf("  %-16s: %s\n", kv[0], kv[1])
+	}
+	p.Println()
+}
+
+// profileEntries profile 中设置的项（key, value），Token 类的值截断显示
+func profileEntries(profile *config.Profile) [][2]string {
+	var entries [][2]string
+	add := func(key, value string) {
+		if value != "" {
+			entries = append(entries, [2]string{key, value})
+		}
+	}
+	addInt := func(key string, value int) {
+		if value > 0 {
+			add(key, strconv.Itoa(value))
+		}
+	}
+	truncate := func(value string) string {
+		if len(value) > 20 {
+			return value[:20] + "..."
+		}
+		return value
+	}
+
+	add("target", profile.Target)
+	addInt("port", profile.Port)
+	add("token", truncate(profile.Token))
+	add("token-file", profile.TokenFile)
+	add("client-cert", profile.ClientCert)
+	add("client-key", profile.ClientKey)
+	add("kubeconfig", profile.Kubeconfig)
+	add("context", profile.Context)
+	add("api-server", profile.APIServer)
+	addInt("api-port", profile.APIPort)
+	add("proxy", client.RedactProxyURL(profile.Proxy))
+	add("raw-dump", profile.RawDump)
+	addInt("concurrency", profile.Concurrency)
+	add("rules-file", profile.RulesFile)
+	envKeys := make([]string, 0, len(profile.Env))
+	for k := range profile.Env {
+		envKeys = append(envKeys, k)
+	}
+	sort.Strings(envKeys)
+	for _, k := range envKeys {
+		add("env", k+"="+profile.Env[k])
+	}
+	add("exec-via", profile.ExecVia)
+	add("time-format", profile.TimeFormat)
+	add("timezone", profile.TimeZone)
+	add("pager", profile.Pager)
+	add("notify-url", profile.NotifyURL)
+	add("sync-server", profile.SyncServer)
+	add("sync-token", truncate(profile.SyncToken))
+	return entries
+}
+
+// profileSummary profile 的一行摘要
+func profileSummary(profile *config.Profile) string {
+	var parts []string
+	for _, kv := range profileEntries(profile) {
+		switch kv[0] {
+		case "target", "kubeconfig", "context", "api-server", "proxy":
+			parts = append(parts, kv[0]+"="+kv[1])
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%d settings", len(profileEntries(profile)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// ApplyProfile 将 profile 应用到会话，只覆盖 profile 中设置的项；不输出、不重新连接
+// 先加载 kubeconfig，profile 中的其余项覆盖其中的值（与命令行参数的顺序相同）
+func ApplyProfile(sess *session.Session, name string, profile *config.Profile) error {
+	if profile.Kubeconfig != "" {
+		if _, err := sess.LoadKubeconfig(config.ExpandHome(profile.Kubeconfig), profile.Context); err != nil {
+			return err
+		}
+	}
+	if profile.Target != "" {
+		sess.Config.KubeletIP = profile.Target
+	}
+	if profile.Port > 0 {
+		sess.Config.KubeletPort = profile.Port
+	}
+	if profile.TokenFile != "" {
+		path := config.ExpandHome(profile.TokenFile)
+		tokenStr, err := token.Read(path)
+		if err != nil {
+			return i18n.Errorf("读取 Token 文件失败: %w", err)
+		}
+		sess.Config.Token = tokenStr
+		sess.Config.TokenFile = path
+	}
+	if profile.Token != "" {
+		sess.Config.Token = profile.Token
+		sess.Config.TokenFile = ""
+	}
+	if profile.ClientCert != "" {
+		if err := sess.LoadClientCert(config.ExpandHome(profile.ClientCert)); err != nil {
+			return err
+		}
+	}
+	if profile.ClientKey != "" {
+		if err := sess.LoadClientKey(config.ExpandHome(profile.ClientKey)); err != nil {
+			return err
+		}
+	}
+	if profile.APIServer != "" {
+		sess.Config.APIServer = profile.APIServer
+	}
+	if profile.APIPort > 0 {
+		sess.Config.APIServerPort = profile.APIPort
+	}
+	if profile.Proxy != "" {
+		sess.Config.ProxyURL = profile.Proxy
+	}
+	if profile.RawDump != "" {
+		dir := config.ExpandHome(profile.RawDump)
+		if err := client.PrepareRawDumpDir(dir); err != nil {
+			return err
+		}
+		sess.Config.RawDumpDir = dir
+	}
+	if profile.Concurrency > 0 {
+		sess.Config.Concurrency = profile.Concurrency
+	}
+	if profile.RulesFile != "" {
+		path := config.ExpandHome(profile.RulesFile)
+		if _, err := config.LoadRulesFile(path); err != nil {
+			return err
+		}
+		sess.Config.RulesFile = path
+	}
+	if len(profile.Env) > 0 {
+		if sess.Config.Env == nil {
+			sess.Config.Env = make(map[string]string)
+		}
+		maps.Copy(sess.Config.Env, profile.Env)
+	}
+	if profile.ExecVia != "" {
+		via := strings.ToLower(profile.ExecVia)
+		if via == config.ExecViaKubelet {
+			via = config.ExecViaWebSocket
+		}
+		if !slices.Contains(config.ExecViaOptions, via) {
+			return i18n.Errorf("无效的执行通道: %s (可用: %s)", profile.ExecVia, strings.Join(config.ExecViaOptions, ", "))
+		}
+		sess.Config.ExecVia = via
+	}
+	switch strings.ToLower(profile.TimeFormat) {
+	case "":
+	case "relative":
+		sess.Config.AbsoluteTime = false
+	case "absolute":
+		sess.Config.AbsoluteTime = true
+	default:
+		return i18n.Errorf("无效的时间格式: %s (可用: relative, absolute)", profile.TimeFormat)
+	}
+	if profile.TimeZone != "" {
+		tz := strings.ToLower(profile.TimeZone)
+		if tz != config.TimeZoneLocal && tz != config.TimeZoneUTC {
+			return i18n.Errorf("无效的时区: %s (可用: local, utc)", profile.TimeZone)
+		}
+		sess.Config.TimeZone = tz
+	}
+	switch strings.ToLower(profile.Pager) {
+	case "":
+	case "on", "true":
+		sess.Config.Pager = true
+	case "off", "false":
+		sess.Config.Pager = false
+	default:
+		return i18n.Errorf("无效的分页设置: %s (可用: on, off)", profile.Pager)
+	}
+	if profile.NotifyURL != "" {
+		if err := notify.ValidateURL(profile.NotifyURL); err != nil {
+			return err
+		}
+		sess.Config.NotifyURL = profile.NotifyURL
+	}
+	if profile.SyncServer != "" {
+		sess.Config.SyncURL = profile.SyncServer
+	}
+	if profile.SyncToken != "" {
+		sess.Config.SyncToken = profile.SyncToken
+	}
+
+	sess.Config.Profile = name
+	return nil
+}
+
+// ProfileFromSession 由当前会话设置生成 profile，路径转换为绝对路径
+// Token 只保存 token-file；Token 不是从文件加载时不保存，skippedToken 为 true
+func ProfileFromSession(sess *session.Session) (profile *config.Profile, skippedToken bool) {
+	cfg := sess.Config
+	profile = &config.Profile{
+		Target:     cfg.KubeletIP,
+		Kubeconfig: absPath(cfg.Kubeconfig),
+		Context:    cfg.KubeContext,
+		APIServer:  cfg.APIServer,
+		Proxy:      cfg.ProxyURL,
+		RawDump:    absPath(cfg.RawDumpDir),
+		RulesFile:  absPath(cfg.RulesFile),
+		Env:        maps.Clone(cfg.Env),
+		NotifyURL:  cfg.NotifyURL,
+		SyncServer: cfg.SyncURL,
+		SyncToken:  cfg.SyncToken,
+		ClientCert: absPath(cfg.ClientCertFile),
+		ClientKey:  absPath(cfg.ClientKeyFile),
+		TokenFile:  absPath(cfg.TokenFile),
+	}
+	if cfg.KubeletPort != config.DefaultKubeletPort {
+		profile.Port = cfg.KubeletPort
+	}
+	if cfg.APIServerPort != 443 {
+		profile.APIPort = cfg.APIServerPort
+	}
+	if cfg.Concurrency != config.DefaultScanConcurrency {
+		profile.Concurrency = cfg.Concurrency
+	}
+	if cfg.ExecVia != "" && cfg.ExecVia != config.ExecViaAuto {
+		profile.ExecVia = cfg.ExecVia
+	}
+	if cfg.AbsoluteTime {
+		profile.TimeFormat = "absolute"
+	}
+	if cfg.TimeZone != "" && cfg.TimeZone != config.TimeZoneLocal {
+		profile.TimeZone = cfg.TimeZone
+	}
+	if cfg.Pager {
+		profile.Pager = "on"
+	}
+	skippedToken = cfg.Token != "" && cfg.TokenFile == "" && cfg.Kubeconfig == ""
+	return profile, skippedToken
+}
+
+// absPath 相对路径转换为绝对路径，保存的 profile 不依赖当前目录
+func absPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// sortedProfileNames 按名称排序的 profile 名
+func sortedProfileNames(cfg *config.UserConfig) []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,392 @@
+package commands
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/network"
+	"kctl/pkg/types"
+)
+
+// PscanCmd pscan 命令
+type PscanCmd struct{}
+
+func init() {
+	Register(&PscanCmd{})
+}
+
+func (c *PscanCmd) Name() string {
+	return "pscan"
+}
+
+func (c *PscanCmd) Aliases() []string {
+	return nil
+}
+
+func (c *PscanCmd) Description() string {
+	return "在 Pod 内进行 TCP 端口扫描"
+}
+
+// IsReadOnly list 只读取数据库
+func (c *PscanCmd) IsReadOnly(args []string) bool {
+	return len(args) > 0 && (args[0] == "list" || args[0] == "ls")
+}
+
+func (c *PscanCmd) Usage() string {
+	return `pscan <target> [options]
+pscan list
+pscan clear
+
+通过 exec 在 Pod 内执行 TCP connect 扫描，从 Pod 的网络位置侦察横向移动目标，无需向 Pod 投放工具：
+优先使用 bash 的 /dev/tcp，没有 bash 时使用 nc -z
+开放端口写入数据库（pscan list 查看），同一 Pod 重复扫描时覆盖
+
+目标格式与 discover 相同（单次最多 ` + strconv.Itoa(config.MaxPodScanTargets) + ` 个 IP）：
+  10.96.0.10            单个 IP
+  10.244.0.0/24         CIDR 网段
+  10.244.0.1-50         IP 范围
+
+选项：
+  -p, --ports <ports>   端口，如 80,443,6379 或 8000-8100（默认: 常见服务端口）
+  --from <pod>          执行扫描的 Pod（默认使用当前 SA 的 Pod）
+  -t, --timeout <sec>   单个连接超时秒数（默认: ` + strconv.Itoa(config.DefaultPodScanTimeout) + `）
+  -c, --concurrency <n> Pod 内每批并发连接数（默认: ` + strconv.Itoa(config.DefaultPodScanConcurrency) + `）
+  --method <bash|nc>    指定扫描方式（默认自动探测）
+
+示例：
+  pscan 10.96.0.0/24
+  pscan 10.244.1.0/24 --ports 6379,3306,5432 --from default/nginx
+  pscan 10.0.0.1-20 -p 10250 -t 2
+  pscan list`
+}
+
+// Flags pscan 的选项补全
+func (c *PscanCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--ports", Short: "-p", Arg: "<ports>", Description: "扫描端口"},
+		{Name: "--from", Arg: "<pod>", Description: "执行扫描的 Pod"},
+		{Name: "--timeout", Short: "-t", Arg: "<sec>", Description: "单个连接超时秒数"},
+		{Name: "--concurrency", Short: "-c", Arg: "<n>", Description: "每批并发连接数"},
+		{Name: "--method", Arg: "<method>", Description: "扫描方式", Values: completion.Choices(
+			completion.Suggestion{Text: network.PodScanBash, Description: "bash /dev/tcp"},
+			completion.Suggestion{Text: network.PodScanNC, Description: "nc -z"},
+		)},
+	}
+}
+
+// Suggestions pscan 的子命令补全
+func (c *PscanCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) > 0 {
+		return nil
+	}
+	return []completion.Suggestion{
+		{Text: "list", Description: "列出发现的开放端口"},
+		{Text: "clear", Description: "清空开放端口记录"},
+	}
+}
+
+// pscanOptions pscan 参数
+type pscanOptions struct {
+	target string
+	from   string
+	method string
+	scan   network.PodScanOptions
+}
+
+func (c *PscanCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) == 0 {
+		return i18n.Errorf("用法: pscan <target> [--ports <ports>] [--from <pod>]")
+	}
+	switch args[0] {
+	case "list", "ls":
+		return c.list(sess)
+	case "clear":
+		if err := sess.PortDB.Clear(); err != nil {
+			return i18n.Errorf("清空开放端口记录失败: %w", err)
+		}
+		sess.Printer.Success("Port scan results cleared")
+		return nil
+	}
+
+	opts, err := c.parseArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := sess.CheckSafeMode("pscan"); err != nil {
+		return err
+	}
+	return c.scan(sess, opts)
+}
+
+// parseArgs 解析扫描参数
+func (c *PscanCmd) parseArgs(args []string) (*pscanOptions, error) {
+	opts := &pscanOptions{
+		scan: network.PodScanOptions{
+			Ports:       config.DefaultPodScanPorts,
+			Concurrency: config.DefaultPodScanConcurrency,
+			Timeout:     config.DefaultPodScanTimeout,
+		},
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		needValue := func() (string, error) {
+			if i+1 >= len(args) {
+				return "", i18n.Errorf("%s 需要指定值", arg)
+			}
+			i++
+			return args[i], nil
+		}
+		switch arg {
+		case "-p", "--ports":
+			v, err := needValue()
+			if err != nil {
+				return nil, err
+			}
+			ports, err := network.ParsePorts(v)
+			if err != nil {
+				return nil, err
+			}
+			opts.scan.Ports = ports
+		case "--from":
+			v, err := needValue()
+			if err != nil {
+				return nil, err
+			}
+			opts.from = v
+		case "-t", "--timeout":
+			v, err := needValue()
+			if err != nil {
+				return nil, err
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				return nil, i18n.Errorf("无效的超时秒数: %s", v)
+			}
+			opts.scan.Timeout = n
+		case "-c", "--concurrency":
+			v, err := needValue()
+			if err != nil {
+				return nil, err
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				return nil, i18n.Errorf("无效的并发数: %s", v)
+			}
+			opts.scan.Concurrency = n
+		case "--method":
+			v, err := needValue()
+			if err != nil {
+				return nil, err
+			}
+			if v != network.PodScanBash && v != network.PodScanNC {
+				return nil, i18n.Errorf("无效的扫描方式: %s (可用: bash, nc)", v)
+			}
+			opts.method = v
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return nil, i18n.Errorf("未知参数: %s", arg)
+			}
+			if opts.target != "" {
+				return nil, i18n.Errorf("只能指定一个目标: %s", arg)
+			}
+			opts.target = arg
+		}
+	}
+	if opts.target == "" {
+		return nil, i18n.Errorf("用法: pscan <target> [--ports <ports>] [--from <pod>]")
+	}
+
+	targets, err := network.ParseTargets(opts.target)
+	if err != nil {
+		return nil, i18n.Errorf("解析目标失败: %w", err)
+	}
+	if len(targets) > config.MaxPodScanTargets {
+		return nil, i18n.Errorf("目标过多: %d 个 IP（最多 %d 个），请缩小网段", len(targets), config.MaxPodScanTargets)
+	}
+	opts.scan.Targets = targets
+	return opts, nil
+}
+
+// scan 在 Pod 内分批执行扫描脚本并保存结果
+func (c *PscanCmd) scan(sess *session.Session, opts *pscanOptions) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	opts.scan.Method = opts.method
+	ref, exec, err := openPodScan(sess, opts.from, &opts.scan)
+	if err != nil {
+		return err
+	}
+
+	targets := opts.scan.Targets
+	total := len(targets) * len(opts.scan.Ports)
+	p.Printf("%s Scanning %s from %s/%s (ports: %s, %d probes, method: %s)\n",
+		p.Colored(config.ColorBlue, "[*]"), opts.target, ref.Namespace, ref.Pod,
+		formatPorts(opts.scan.Ports), total, opts.scan.Method)
+
+	start := time.Now()
+	var open []network.ScanResult
+	progress := newProgressBar(p, total)
+	for i := 0; i < len(targets); i += config.PodScanChunkSize {
+		if ctx.Err() != nil {
+			break
+		}
+		chunk := opts.scan
+		chunk.Targets = targets[i:min(i+config.PodScanChunkSize, len(targets))]
+		result, err := exec(network.PodScanScript(chunk))
+		if err != nil {
+			p.Println()
+			p.Warning(i18n.Tf("扫描 %s 起的 %d 个 IP 失败: %v", chunk.Targets[0], len(chunk.Targets), err))
+			continue
+		}
+		open = append(open, network.ParsePodScan(result.Stdout)...)
+		progress.Update(min(i+config.PodScanChunkSize, len(targets)) * len(opts.scan.Ports))
+	}
+	progress.Finish()
+
+	source := ref.Namespace + "/" + ref.Pod
+	now := time.Now()
+	records := make([]*types.PortRecord, 0, len(open))
+	for _, r := range open {
+		records = append(records, &types.PortRecord{
+			IP:        r.IP,
+			Port:      r.Port,
+			Service:   security.PortService(r.Port),
+			Source:    source,
+			ScannedAt: now,
+			KubeletIP: sess.Config.KubeletIP,
+		})
+	}
+	if len(records) > 0 {
+		if _, err := sess.PortDB.SaveBatch(records); err != nil {
+			p.Warning(i18n.Tf("保存扫描结果失败: %v", err))
+		}
+	}
+
+	c.printPorts(p, records, false, sess.TimeFormatter(false))
+	p.Printf("%s %d open port(s) on %d host(s) in %s\n", p.Colored(config.ColorGreen, "[+]"),
+		len(records), c.countHosts(records), time.Since(start).Round(time.Second))
+	return nil
+}
+
+// podShell 在 Pod 中执行 sh -c 脚本
+type podShell func(script string) (*types.ExecResult, error)
+
+// openPodScan 解析扫描使用的 Pod（默认当前 SA 的 Pod）并探测可用工具，
+// scan.Method 为空时自动选择扫描方式
+func openPodScan(sess *session.Session, from string, scan *network.PodScanOptions) (session.PodRef, podShell, error) {
+	ref, err := resolveWorkPod(sess, from)
+	if err != nil {
+		return ref, nil, err
+	}
+	executor, err := sess.GetExecTransport()
+	if err != nil {
+		return ref, nil, err
+	}
+	exec := func(script string) (*types.ExecResult, error) {
+		return executor.Exec(sess.Context(), &types.ExecOptions{
+			Namespace: ref.Namespace,
+			Pod:       ref.Pod,
+			Container: ref.Container,
+			Command:   []string{"sh", "-c", script},
+			Stdout:    true,
+			Stderr:    true,
+		})
+	}
+
+	result, err := exec(network.PodScanToolsScript)
+	if err != nil {
+		return ref, nil, i18n.Errorf("探测扫描工具失败: %w", err)
+	}
+	if result.Error != "" && result.Stdout == "" {
+		return ref, nil, i18n.Errorf("探测扫描工具失败: %s", result.Error)
+	}
+	tools := network.ParsePodScanTools(result.Stdout)
+	if scan.Method == "" {
+		scan.Method = tools.Method()
+	}
+	if scan.Method == "" {
+		return ref, nil, i18n.Errorf("Pod %s/%s 中没有 bash 或 nc，无法扫描", ref.Namespace, ref.Pod)
+	}
+	scan.HasTimeout = tools.Timeout
+	return ref, exec, nil
+}
+
+// list 列出数据库中的开放端口
+func (c *PscanCmd) list(sess *session.Session) error {
+	records, err := sess.PortDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取开放端口失败: %w", err)
+	}
+	if len(records) == 0 {
+		sess.Printer.Info(i18n.T("没有开放端口记录，请先执行 pscan <target>"))
+		return nil
+	}
+	c.printPorts(sess.Printer, records, true, sess.TimeFormatter(false))
+	sess.Printer.Printf("%s %d open port(s) on %d host(s)\n", sess.Printer.Colored(config.ColorGreen, "[+]"),
+		len(records), c.countHosts(records))
+	return nil
+}
+
+// printPorts 输出开放端口表格，withSource 时显示扫描来源和时间
+func (c *PscanCmd) printPorts(p output.Printer, records []*types.PortRecord, withSource bool, tf output.TimeFormatter) {
+	if len(records) == 0 {
+		p.Println()
+		return
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].IP != records[j].IP {
+			return compareIPs(records[i].IP, records[j].IP)
+		}
+		return records[i].Port < records[j].Port
+	})
+
+	headers := []string{"IP", "PORT", "SERVICE"}
+	if withSource {
+		headers = append(headers, "SOURCE", "SCANNED")
+	}
+	var rows [][]string
+	for _, r := range records {
+		row := []string{r.IP, strconv.Itoa(r.Port), orDash(r.Service)}
+		if withSource {
+			row = append(row, r.Source, tf.Format(r.ScannedAt))
+		}
+		rows = append(rows, row)
+	}
+	p.Println()
+	output.NewTablePrinter().PrintSimple(headers, rows)
+	p.Println()
+}
+
+// countHosts 统计不同 IP 数
+func (c *PscanCmd) countHosts(records []*types.PortRecord) int {
+	hosts := make(map[string]bool)
+	for _, r := range records {
+		hosts[r.IP] = true
+	}
+	return len(hosts)
+}
+
+// compareIPs 按数值比较 IPv4 地址，无法解析时按字符串比较
+func compareIPs(a, b string) bool {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	if len(pa) != 4 || len(pb) != 4 {
+		return a < b
+	}
+	for i := range pa {
+		x, _ := strconv.Atoi(pa[i])
+		y, _ := strconv.Atoi(pb[i])
+		if x != y {
+			return x < y
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+)
+
+// QueryCmd query 命令
+type QueryCmd struct{}
+
+func init() {
+	Register(&QueryCmd{})
+}
+
+func (c *QueryCmd) Name() string {
+	return "query"
+}
+
+func (c *QueryCmd) Aliases() []string {
+	return nil
+}
+
+func (c *QueryCmd) Description() string {
+	return "对会话数据库执行 SQL 查询"
+}
+
+// IsReadOnly 默认在只读会话中查询，--write 会修改数据库
+func (c *QueryCmd) IsReadOnly(args []string) bool {
+	return !slices.Contains(args, "--write")
+}
+
+// IsPaged query 输出查询结果表格
+func (c *QueryCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *QueryCmd) Usage() string {
+	return `query [options] "<sql>"
+
+对会话数据库（--db 指定的 SQLite 文件或 PostgreSQL，未指定时为内存数据库）执行 SQL，结果以表格显示
+默认只读：只能执行单条 SELECT / WITH / VALUES / EXPLAIN 语句，并在只读事务中执行，修改由数据库拒绝
+
+表：
+  pods, service_accounts, scans, scan_results, findings, deployments, credentials,
+  ports, operations, exec_results, pod_cache, meta
+
+选项：
+  --limit <n>   最多显示 n 行（默认 500，0 为不限制）
+  --wide        不截断长字段
+  --write       允许修改数据库（查看模式下不可用）
+
+示例：
+  query "SELECT namespace, COUNT(*) AS n FROM pods GROUP BY namespace ORDER BY n DESC"
+  query "SELECT namespace, name, risk_level FROM service_accounts WHERE permissions LIKE '%secrets%'"
+  query --wide "SELECT pod, stdout FROM exec_results WHERE run_id = 3"
+  query --write "DELETE FROM findings WHERE source = 'hunt'"`
+}
+
+// queryDefaultLimit query 默认最多显示的行数
+const queryDefaultLimit = 500
+
+// queryCellWidth 未指定 --wide 时单元格的最大宽度
+const queryCellWidth = 60
+
+// Flags query 的选项补全
+func (c *QueryCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--limit", Arg: "<n>", Description: "最多显示 n 行（0 为不限制）"},
+		{Name: "--wide", Description: "不截断长字段"},
+		{Name: "--write", Description: "允许修改数据库"},
+	}
+}
+
+func (c *QueryCmd) Execute(sess *session.Session, args []string) error {
+	limit, wide, write := queryDefaultLimit, false, false
+	var parts []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--limit":
+			if i+1 >= len(args) {
+				return i18n.Errorf("--limit 需要参数")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 0 {
+				return i18n.Errorf("无效的行数: %s", args[i+1])
+			}
+			limit = n
+			i++
+		case "--wide":
+			wide = true
+		case "--write":
+			write = true
+		default:
+			parts = append(parts, args[i])
+		}
+	}
+	query := strings.TrimSpace(strings.Join(parts, " "))
+	if query == "" {
+		return i18n.Errorf("用法: query [options] \"<sql>\"")
+	}
+
+	result, err := sess.DB.Query(sess.Context(), query, write, limit)
+	if err != nil {
+		return i18n.Errorf("查询失败: %w", err)
+	}
+
+	p := sess.Printer
+	if len(result.Columns) == 0 {
+		p.Success(i18n.T("语句已执行"))
+		return nil
+	}
+	if len(result.Rows) == 0 {
+		p.Info(i18n.T("查询没有返回结果"))
+		return nil
+	}
+
+	tf := sess.TimeFormatter(true)
+	rows := make([][]string, 0, len(result.Rows))
+	for _, values := range result.Rows {
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = formatQueryValue(p, tf, v, wide)
+		}
+		rows = append(rows, row)
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple(result.Columns, rows)
+	summary := i18n.Tf("共 %d 行", len(rows))
+	if result.Truncated {
+		summary = i18n.Tf("仅显示前 %d 行，使用 --limit 显示更多", len(rows))
+	}
+	p.Printf("\n  %s\n\n", p.Colored(config.ColorGray, summary))
+	return nil
+}
+
+// formatQueryValue 格式化查询结果中的值：NULL 显示为灰色，时间按会话时区显示，
+// 未指定 wide 时换行合并为空格并截断
+func formatQueryValue(p output.Printer, tf output.TimeFormatter, v any, wide bool) string {
+	var s string
+	switch v := v.(type) {
+	case nil:
+		return p.Colored(config.ColorGray, "NULL")
+	case time.Time:
+		s = tf.In(v).Format(time.RFC3339)
+	default:
+		s = fmt.Sprint(v)
+	}
+	if wide {
+		return s
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	return runewidth.Truncate(s, queryCellWidth, "...")
+}
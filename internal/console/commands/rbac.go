@@ -0,0 +1,380 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/rbac"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// RbacCmd rbac 命令
+type RbacCmd struct{}
+
+func init() {
+	Register(&RbacCmd{})
+}
+
+func (c *RbacCmd) Name() string {
+	return "rbac"
+}
+
+func (c *RbacCmd) Aliases() []string {
+	return nil
+}
+
+func (c *RbacCmd) Description() string {
+	return "反查 RBAC 绑定、审计 SA 的最小权限"
+}
+
+// IsPaged rbac 输出角色绑定表格
+func (c *RbacCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *RbacCmd) Usage() string {
+	return `rbac who-can <verb> <resource> [-n <namespace>] [--held]
+rbac admins [--held]
+rbac analyze [namespace/name] [--all]
+
+读取 ClusterRole、ClusterRoleBinding、Role、RoleBinding（需要 rbac.authorization.k8s.io 的 list 权限，
+使用当前 SA 的 Token），反查拥有指定权限的主体，并标出已持有 Token 的 SA（HELD 列）
+
+资源格式与 kubectl auth can-i 相同：pods、pods/exec、deployments.apps、/metrics
+组 system:serviceaccounts[:<ns>] 和 system:authenticated 视为包含对应的 SA
+
+analyze 审计直接绑定到已扫描 SA 的角色，给出最小权限建议，结果写入数据库（来源 rbac，hunt list 查看）：
+  - 通配规则（verbs / resources / apiGroups 为 *）
+  - escalate、bind、impersonate 动词
+  - 聚合 ClusterRole 及可写 ClusterRole 带来的聚合标签滥用
+  - 引用不存在角色的悬空绑定、被其他规则完全覆盖的冗余规则
+当前 Token 无法读取 RBAC 时，依次尝试数据库中其他未过期的 Token
+
+选项：
+  -n <namespace>      只计入该命名空间的 RoleBinding（ClusterRoleBinding 始终计入）
+  --held              只显示已持有 Token 的主体
+  --all               analyze 时同时显示没有发现的 SA
+
+示例：
+  rbac who-can create pods -n kube-system
+  rbac who-can create pods/exec
+  rbac who-can get secrets --held
+  rbac admins
+  rbac analyze
+  rbac analyze kube-system/default`
+}
+
+// Flags rbac 的选项补全
+func (c *RbacCmd) Flags(args []string) []completion.Flag {
+	held := completion.Flag{Name: "--held", Description: "只显示已持有 Token 的主体"}
+	if len(args) > 0 {
+		switch args[0] {
+		case "who-can":
+			return []completion.Flag{flagNamespace, held}
+		case "analyze":
+			return []completion.Flag{{Name: "--all", Description: "同时显示没有发现的 SA"}}
+		}
+	}
+	return []completion.Flag{held}
+}
+
+// Suggestions rbac 的子命令补全
+func (c *RbacCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) > 0 {
+		return nil
+	}
+	return []completion.Suggestion{
+		{Text: "who-can", Description: "谁拥有指定权限"},
+		{Text: "admins", Description: "拥有全部权限的主体"},
+		{Text: "analyze", Description: "审计已扫描 SA 的最小权限"},
+	}
+}
+
+func (c *RbacCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) == 0 {
+		return i18n.Errorf("用法: rbac who-can <verb> <resource> | rbac admins")
+	}
+
+	namespace := ""
+	heldOnly, all := false, false
+	var positional []string
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 < len(args) {
+				namespace = args[i+1]
+				i++
+			}
+		case "--held":
+			heldOnly = true
+		case "--all":
+			all = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return i18n.Errorf("未知参数: %s", args[i])
+			}
+			positional = append(positional, args[i])
+		}
+	}
+
+	switch args[0] {
+	case "who-can":
+		if len(positional) != 2 {
+			return i18n.Errorf("用法: rbac who-can <verb> <resource> [-n <namespace>]")
+		}
+		req := rbac.ParseAccessRequest(positional[0], positional[1], namespace)
+		return c.query(sess, req.String(), heldOnly, func(policy *rbac.Policy) []rbac.Grant {
+			return policy.WhoCan(req)
+		})
+	case "admins":
+		if len(positional) > 0 || namespace != "" {
+			return i18n.Errorf("用法: rbac admins [--held]")
+		}
+		return c.query(sess, "do anything (* * *)", heldOnly, func(policy *rbac.Policy) []rbac.Grant {
+			return policy.Admins()
+		})
+	case "analyze":
+		if len(positional) > 1 || namespace != "" {
+			return i18n.Errorf("用法: rbac analyze [namespace/name] [--all]")
+		}
+		target := ""
+		if len(positional) == 1 {
+			target = positional[0]
+		}
+		return c.analyze(sess, target, all)
+	default:
+		return i18n.Errorf("未知子命令: %s (可用: who-can, admins, analyze)", args[0])
+	}
+}
+
+// loadPolicy 使用当前 Token 读取 RBAC 对象，fallback 为 true 时当前 Token 失败后依次尝试 sas 中未过期的 Token
+func (c *RbacCmd) loadPolicy(sess *session.Session, fallback bool, sas []*types.ServiceAccountRecord) (*rbac.Policy, error) {
+	if sess.Config.APIServer == "" {
+		return nil, i18n.Errorf("未设置 API Server，请使用 'set api-server <addr>' 设置")
+	}
+	p := sess.Printer
+	p.Printf("%s Loading RBAC bindings...\n", p.Colored(config.ColorBlue, "[*]"))
+
+	active := sess.GetActiveToken()
+	policy, err := c.loadPolicyWith(sess, active)
+	if err != nil && fallback {
+		for _, sa := range sas {
+			if sa.Token == "" || sa.IsExpired || sa.Token == active {
+				continue
+			}
+			if policy, err = c.loadPolicyWith(sess, sa.Token); err == nil {
+				p.Printf("%s Using %s/%s to read RBAC\n", p.Colored(config.ColorBlue, "[*]"), sa.Namespace, sa.Name)
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range policy.Warnings {
+		p.Warning(w + i18n.T("，结果只包含 ClusterRoleBinding"))
+	}
+	return policy, nil
+}
+
+// loadPolicyWith 使用指定 Token 读取 RBAC 对象
+func (c *RbacCmd) loadPolicyWith(sess *session.Session, token string) (*rbac.Policy, error) {
+	k8s, err := sess.GetK8sClient(token)
+	if err != nil {
+		return nil, err
+	}
+	return rbac.LoadPolicy(sess.Context(), k8s)
+}
+
+// query 读取 RBAC 对象并输出 grants，与已持有的 Token 交叉比对
+func (c *RbacCmd) query(sess *session.Session, what string, heldOnly bool, find func(*rbac.Policy) []rbac.Grant) error {
+	p := sess.Printer
+
+	sas, err := sess.SADB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取 ServiceAccount 失败: %w", err)
+	}
+	policy, err := c.loadPolicy(sess, false, nil)
+	if err != nil {
+		return err
+	}
+
+	grants := find(policy)
+	sort.SliceStable(grants, func(i, j int) bool {
+		a, b := grants[i], grants[j]
+		if (a.Binding.Namespace == "") != (b.Binding.Namespace == "") {
+			return a.Binding.Namespace == ""
+		}
+		return rbac.SubjectString(a.Subject) < rbac.SubjectString(b.Subject)
+	})
+
+	var rows [][]string
+	subjects := make(map[string]bool)
+	heldSAs := make(map[string]bool)
+	for _, g := range grants {
+		held := rbac.HeldTokens(g.Subject, sas)
+		if heldOnly && len(held) == 0 {
+			continue
+		}
+		subject := rbac.SubjectString(g.Subject)
+		subjects[subject] = true
+		for _, sa := range held {
+			heldSAs[sa.Namespace+"/"+sa.Name] = true
+		}
+
+		scope := g.Scope()
+		if scope == "cluster" {
+			scope = p.Colored(config.ColorRed, scope)
+		}
+		rows = append(rows, []string{
+			subject,
+			g.Binding.Kind + "/" + g.Binding.Name,
+			g.Role.Kind + "/" + g.Role.Name,
+			scope,
+			c.formatHeld(p, held),
+		})
+	}
+
+	if len(rows) == 0 {
+		p.Printf("%s No subjects can %s\n", p.Colored(config.ColorBlue, "[*]"), what)
+		return nil
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"SUBJECT", "BINDING", "ROLE", "SCOPE", "HELD"}, rows)
+	p.Println()
+	p.Printf("%s %d subject(s) can %s\n", p.Colored(config.ColorGreen, "[+]"), len(subjects), what)
+	if len(heldSAs) > 0 {
+		p.Printf("%s %d held token(s) have this access, switch with 'sa use <namespace/name>'\n",
+			p.Colored(config.ColorRed, "[!]"), len(heldSAs))
+	}
+	return nil
+}
+
+// formatHeld 格式化已持有 Token 的 SA，超过 3 个时省略
+func (c *RbacCmd) formatHeld(p output.Printer, held []*types.ServiceAccountRecord) string {
+	if len(held) == 0 {
+		return "-"
+	}
+	var names []string
+	for i, sa := range held {
+		if i == 3 {
+			names = append(names, fmt.Sprintf("+%d", len(held)-3))
+			break
+		}
+		names = append(names, sa.Namespace+"/"+sa.Name)
+	}
+	return p.Colored(config.ColorRed, strings.Join(names, ","))
+}
+
+// analyze 审计已扫描 SA 的最小权限，target 为 namespace/name 时只审计该 SA
+func (c *RbacCmd) analyze(sess *session.Session, target string, all bool) error {
+	p := sess.Printer
+
+	sas, err := sess.SADB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取 ServiceAccount 失败: %w", err)
+	}
+	if target != "" {
+		namespace, name, ok := strings.Cut(target, "/")
+		if !ok || namespace == "" || name == "" {
+			return i18n.Errorf("无效的 SA: %s (格式: namespace/name)", target)
+		}
+		sas = slices.DeleteFunc(sas, func(sa *types.ServiceAccountRecord) bool {
+			return sa.Namespace != namespace || sa.Name != name
+		})
+		if len(sas) == 0 {
+			sas = []*types.ServiceAccountRecord{{Namespace: namespace, Name: name}}
+		}
+	}
+	if len(sas) == 0 {
+		return i18n.Errorf("数据库中没有 ServiceAccount，请先执行 'sa scan'")
+	}
+
+	policy, err := c.loadPolicy(sess, true, sas)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var records []*types.FindingRecord
+	analyzed, withIssues := 0, 0
+	for _, sa := range sas {
+		a := policy.AnalyzeServiceAccount(sa.Namespace, sa.Name)
+		analyzed++
+		if len(a.Issues) > 0 {
+			withIssues++
+		} else if !all && target == "" {
+			continue
+		}
+		c.printAnalysis(p, a)
+
+		for _, issue := range a.Issues {
+			records = append(records, &types.FindingRecord{
+				Source:      "rbac",
+				Severity:    string(issue.Severity),
+				Category:    "rbac",
+				Namespace:   a.Namespace,
+				Pod:         a.Name,
+				Location:    issue.Location(),
+				Title:       issue.Title,
+				Evidence:    issue.Detail,
+				CollectedAt: now,
+				KubeletIP:   sess.Config.KubeletIP,
+			})
+		}
+	}
+	if len(records) > 0 {
+		if _, err := sess.FindingDB.SaveBatch(records); err != nil {
+			p.Warning(i18n.Tf("保存 RBAC 审计结果失败: %v", err))
+		}
+	}
+
+	p.Println()
+	p.Printf("%s Analyzed %d service account(s), %d with least-privilege issues (%d findings)\n",
+		p.Colored(config.ColorGreen, "[+]"), analyzed, withIssues, len(records))
+	return nil
+}
+
+// printAnalysis 输出单个 SA 的审计结果
+func (c *RbacCmd) printAnalysis(p output.Printer, a *rbac.SAAnalysis) {
+	p.Println()
+	p.Printf("  %s %s\n",
+		p.Colored(config.ColorWhite, a.Namespace+"/"+a.Name),
+		p.Formatter().FormatRiskLevelColored(a.Level()))
+	if len(a.Bindings) == 0 {
+		p.Printf("    %s\n", p.Colored(config.ColorGray, "no direct bindings"))
+		return
+	}
+	for _, b := range a.Bindings {
+		scope := "cluster"
+		if b.Namespace != "" {
+			scope = b.Namespace
+		}
+		p.Printf("    %s %s -> %s/%s %s\n",
+			p.Colored(config.ColorGray, "binding"),
+			b.Kind+"/"+b.Name, b.RoleRef.Kind, b.RoleRef.Name,
+			p.Colored(config.ColorGray, "["+scope+"]"))
+	}
+	for _, issue := range a.Issues {
+		p.Printf("    %s %s %s\n",
+			p.Formatter().FormatRiskLevelColored(issue.Severity),
+			issue.Title,
+			p.Colored(config.ColorGray, "("+issue.Binding+")"))
+		p.Printf("      %s\n", p.Colored(config.ColorGray, issue.Detail))
+	}
+	if recs := a.Recommendations(); len(recs) > 0 {
+		p.Printf("    %s\n", p.Colored(config.ColorYellow, "Recommendations"))
+		for _, rec := range recs {
+			p.Printf("      - %s\n", rec)
+		}
+	}
+}
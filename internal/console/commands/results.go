@@ -0,0 +1,369 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// ResultsCmd results 命令
+type ResultsCmd struct{}
+
+func init() {
+	Register(&ResultsCmd{})
+}
+
+func (c *ResultsCmd) Name() string {
+	return "results"
+}
+
+func (c *ResultsCmd) Aliases() []string {
+	return nil
+}
+
+func (c *ResultsCmd) Description() string {
+	return "查看 exec --all-pods 的执行结果"
+}
+
+// IsReadOnly results 只读取数据库
+func (c *ResultsCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+// IsPaged results list 输出执行结果表格
+func (c *ResultsCmd) IsPaged(args []string) bool {
+	return len(args) == 0 || args[0] != "export"
+}
+
+func (c *ResultsCmd) Usage() string {
+	return `results [list] [options]
+results show <id>
+results export json [file] [--run <id>]
+
+查看 exec --all-pods 保存的执行结果：每个容器的 Pod、所经 Kubelet、命令、标准输出、标准错误、退出码和时间
+每次批量执行保存为一轮（run），结果保存在会话数据库的 exec_results 表中，不会随屏幕输出丢失
+
+选项：
+  --run <id|last>     只显示指定轮次的结果（last 为最近一轮）
+  --pod <text>        只显示 namespace/pod 包含指定文本的结果
+  --node <text>       只显示 Kubelet 包含指定文本的结果
+  --failed            只显示失败的结果（请求失败或退出码非零）
+  --last <n>          只显示最近 n 条
+
+示例：
+  results
+  results --run last --failed
+  results --pod kube-system/
+  results show 42
+  results export json results.json
+  results export json --run 3`
+}
+
+// resultsOutputWidth 列表中 OUTPUT 列的最大宽度，完整输出见 results show
+const resultsOutputWidth = 40
+
+// resultsFilter results 的筛选条件
+type resultsFilter struct {
+	run    string
+	pod    string
+	node   string
+	failed bool
+	last   int
+}
+
+// Flags results list 的选项补全
+func (c *ResultsCmd) Flags(args []string) []completion.Flag {
+	if len(args) > 0 && args[0] == "show" {
+		return nil
+	}
+	flags := []completion.Flag{
+		{Name: "--run", Arg: "<id|last>", Description: "按执行轮次筛选"},
+	}
+	if len(args) > 0 && args[0] == "export" {
+		return flags
+	}
+	return append(flags,
+		completion.Flag{Name: "--pod", Arg: "<text>", Description: "按 Pod 筛选"},
+		completion.Flag{Name: "--node", Arg: "<text>", Description: "按 Kubelet 筛选"},
+		completion.Flag{Name: "--failed", Description: "只显示失败的结果"},
+		completion.Flag{Name: "--last", Arg: "<n>", Description: "只显示最近 n 条"},
+	)
+}
+
+// Suggestions results 的子命令和导出格式补全
+func (c *ResultsCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	switch {
+	case len(args) == 0:
+		return []completion.Suggestion{
+			{Text: "list", Description: "列出执行结果"},
+			{Text: "show", Description: "查看单条结果的完整输出"},
+			{Text: "export", Description: "导出执行结果"},
+		}
+	case len(args) == 1 && args[0] == "export":
+		return []completion.Suggestion{
+			{Text: "json", Description: "JSON 格式"},
+		}
+	}
+	return nil
+}
+
+func (c *ResultsCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "export":
+			return c.export(sess, args[1:])
+		case "show":
+			return c.show(sess, args[1:])
+		case "list":
+			args = args[1:]
+		}
+	}
+
+	var filter resultsFilter
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--run", "--pod", "--node", "--last":
+			if i+1 >= len(args) {
+				return i18n.Errorf("%s 需要参数", args[i])
+			}
+			value := args[i+1]
+			i++
+			switch args[i-1] {
+			case "--run":
+				filter.run = value
+			case "--pod":
+				filter.pod = value
+			case "--node":
+				filter.node = value
+			default:
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 1 {
+					return i18n.Errorf("无效的条数: %s", value)
+				}
+				filter.last = n
+			}
+		case "--failed":
+			filter.failed = true
+		default:
+			return i18n.Errorf("未知参数: %s", args[i])
+		}
+	}
+
+	records, err := sess.ExecDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取执行结果失败: %w", err)
+	}
+	total := len(records)
+	matched, err := filterExecResults(records, filter)
+	if err != nil {
+		return err
+	}
+
+	p := sess.Printer
+	if len(matched) == 0 {
+		if total == 0 {
+			p.Info(i18n.T("没有执行结果，使用 'exec --all-pods -- <command>' 执行后自动保存"))
+		} else {
+			p.Info(i18n.T("没有匹配的执行结果"))
+		}
+		return nil
+	}
+
+	tf := sess.TimeFormatter(false)
+	var rows [][]string
+	for _, r := range matched {
+		exit := p.Colored(config.ColorGreen, "0")
+		outputText := r.Stdout
+		if r.Failed() {
+			exit = p.Colored(config.ColorRed, "error")
+			if r.ExitCode != 0 {
+				exit = p.Colored(config.ColorRed, strconv.Itoa(r.ExitCode))
+			}
+			if outputText == "" {
+				outputText = r.Error
+			}
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(r.ID, 10),
+			strconv.FormatInt(r.RunID, 10),
+			tf.Format(r.Time),
+			r.Node,
+			r.Namespace + "/" + r.Pod,
+			exit,
+			runewidth.Truncate(firstLine(outputText), resultsOutputWidth, "..."),
+		})
+	}
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"ID", "RUN", "TIME", "NODE", "POD", "EXIT", "OUTPUT"}, rows)
+	p.Printf("\n  %s\n\n", p.Colored(config.ColorGray, fmt.Sprintf("%d / %d result(s), use 'results show <id>' for full output", len(matched), total)))
+	return nil
+}
+
+// show 显示单条执行结果的完整输出
+func (c *ResultsCmd) show(sess *session.Session, args []string) error {
+	if len(args) != 1 {
+		return i18n.Errorf("用法: results show <id>")
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return i18n.Errorf("无效的结果 ID: %s", args[0])
+	}
+
+	records, err := sess.ExecDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取执行结果失败: %w", err)
+	}
+	var record *types.ExecResultRecord
+	for _, r := range records {
+		if r.ID == id {
+			record = r
+			break
+		}
+	}
+	if record == nil {
+		return i18n.Errorf("执行结果不存在: %d", id)
+	}
+
+	p := sess.Printer
+	tf := sess.TimeFormatter(false)
+	p.Println()
+	p.Printf("  %-10s: %d (run %d)\n", "ID", record.ID, record.RunID)
+	p.Printf("  %-10s: %s\n", "Time", tf.Format(record.Time))
+	if record.Node != "" {
+		p.Printf("  %-10s: %s\n", "Node", record.Node)
+	}
+	p.Printf("  %-10s: %s/%s\n", "Pod", record.Namespace, record.Pod)
+	if record.Container != "" {
+		p.Printf("  %-10s: %s\n", "Container", record.Container)
+	}
+	p.Printf("  %-10s: %s\n", "Command", record.Command)
+	p.Printf("  %-10s: %d\n", "Exit Code", record.ExitCode)
+	if record.Error != "" {
+		p.Printf("  %-10s: %s\n", "Error", p.Colored(config.ColorRed, record.Error))
+	}
+	for _, stream := range []struct{ name, text string }{{"Stdout", record.Stdout}, {"Stderr", record.Stderr}} {
+		if stream.text == "" {
+			continue
+		}
+		p.Printf("\n  %s\n", p.Colored(config.ColorCyan, stream.name))
+		for _, line := range strings.Split(strings.TrimRight(stream.text, "\n"), "\n") {
+			p.Printf("    %s\n", line)
+		}
+	}
+	p.Println()
+	return nil
+}
+
+// export 导出执行结果，未指定文件时输出到终端
+func (c *ResultsCmd) export(sess *session.Session, args []string) error {
+	var filter resultsFilter
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--run" {
+			if i+1 >= len(args) {
+				return i18n.Errorf("--run 需要参数")
+			}
+			filter.run = args[i+1]
+			i++
+			continue
+		}
+		positional = append(positional, args[i])
+	}
+	if len(positional) == 0 || len(positional) > 2 {
+		return i18n.Errorf("用法: results export json [file] [--run <id>]")
+	}
+	if format := strings.ToLower(positional[0]); format != "json" {
+		return i18n.Errorf("不支持的格式: %s (可用: json)", format)
+	}
+
+	records, err := sess.ExecDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取执行结果失败: %w", err)
+	}
+	records, err = filterExecResults(records, filter)
+	if err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	if err := writeExecResultsJSON(&buf, records); err != nil {
+		return err
+	}
+
+	p := sess.Printer
+	if len(positional) == 1 {
+		p.Print(buf.String())
+		return nil
+	}
+	if err := os.WriteFile(positional[1], []byte(buf.String()), 0600); err != nil {
+		return i18n.Errorf("写入文件失败: %w", err)
+	}
+	p.Success(fmt.Sprintf("Exported %d result(s) to %s", len(records), positional[1]))
+	return nil
+}
+
+// filterExecResults 按筛选条件过滤执行结果
+func filterExecResults(records []*types.ExecResultRecord, filter resultsFilter) ([]*types.ExecResultRecord, error) {
+	var runID int64
+	switch filter.run {
+	case "":
+	case "last":
+		for _, r := range records {
+			runID = max(runID, r.RunID)
+		}
+	default:
+		id, err := strconv.ParseInt(filter.run, 10, 64)
+		if err != nil || id < 1 {
+			return nil, i18n.Errorf("无效的执行轮次: %s", filter.run)
+		}
+		runID = id
+	}
+
+	var matched []*types.ExecResultRecord
+	for _, r := range records {
+		if (runID != 0 && r.RunID != runID) ||
+			(filter.pod != "" && !strings.Contains(r.Namespace+"/"+r.Pod, filter.pod)) ||
+			(filter.node != "" && !strings.Contains(r.Node, filter.node)) ||
+			(filter.failed && !r.Failed()) {
+			continue
+		}
+		matched = append(matched, r)
+	}
+	if filter.last > 0 && len(matched) > filter.last {
+		matched = matched[len(matched)-filter.last:]
+	}
+	return matched, nil
+}
+
+// firstLine 返回文本的第一个非空行
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func writeExecResultsJSON(w io.Writer, records []*types.ExecResultRecord) error {
+	if records == nil {
+		records = []*types.ExecResultRecord{}
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return i18n.Errorf("序列化 JSON 失败: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
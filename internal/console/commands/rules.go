@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+)
+
+// RulesCmd rules 命令
+type RulesCmd struct{}
+
+func init() {
+	Register(&RulesCmd{})
+}
+
+func (c *RulesCmd) Name() string {
+	return "rules"
+}
+
+func (c *RulesCmd) Aliases() []string {
+	return nil
+}
+
+func (c *RulesCmd) Description() string {
+	return "查看或调整风险评分规则"
+}
+
+// IsReadOnly 只允许查看规则
+func (c *RulesCmd) IsReadOnly(args []string) bool {
+	return len(args) > 0 && (args[0] == "list" || args[0] == "ls")
+}
+
+// IsPaged rules 输出规则列表
+func (c *RulesCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *RulesCmd) Usage() string {
+	return `rules <list|load|reset> [options]
+
+查看当前生效的风险评分规则，或在运行时加载自定义规则
+
+子命令：
+  list            显示风险查找表（CRITICAL/HIGH/MEDIUM）
+  load <file>     加载规则文件（YAML/JSON，等同 set rules-file）
+  reset           恢复内置规则
+
+list 选项：
+  --level <lvl>   只显示指定等级 (critical, high, medium)
+  --rules         同时显示权限敏感规则（按匹配顺序）
+  --checks        同时显示权限检查列表
+
+规则中的 group/resource 支持通配，适用于 CRD：
+  group: "*.argoproj.io", resource: "*"     匹配 Argo 所有资源
+  group: core, resource: "pods/*"           匹配 core 组 Pod 的全部子资源
+
+示例：
+  rules list
+  rules list --level critical
+  rules list --rules --checks
+  rules load ./rules.yaml
+  rules reset`
+}
+
+// Flags rules 的选项补全
+func (c *RulesCmd) Flags(args []string) []completion.Flag {
+	if len(args) == 0 || (args[0] != "list" && args[0] != "ls") {
+		return nil
+	}
+	return []completion.Flag{
+		{Name: "--level", Short: "-l", Arg: "<lvl>", Description: "按等级过滤", Values: completion.Severities},
+		{Name: "--rules", Description: "显示权限敏感规则"},
+		{Name: "--checks", Description: "显示权限检查列表"},
+	}
+}
+
+// Suggestions rules 的子命令补全
+func (c *RulesCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return subcommandSuggestions(args,
+		completion.Suggestion{Text: "list", Description: "显示生效的规则"},
+		completion.Suggestion{Text: "load", Description: "加载规则文件"},
+		completion.Suggestion{Text: "reset", Description: "恢复内置规则"},
+	)
+}
+
+func (c *RulesCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) == 0 {
+		return i18n.Errorf("用法: rules <list|load|reset>")
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		return c.list(sess, args[1:])
+
+	case "load":
+		if len(args) < 2 {
+			return i18n.Errorf("用法: rules load <file>")
+		}
+		return ApplyRulesFile(sess, args[1])
+
+	case "reset":
+		config.ResetRules()
+		sess.Config.RulesFile = ""
+		sess.Printer.Success("Rules reset to built-in defaults")
+		return nil
+
+	default:
+		return i18n.Errorf("未知子命令: %s (可用: list, load, reset)", args[0])
+	}
+}
+
+// list 显示当前生效的规则
+func (c *RulesCmd) list(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	var level config.RiskLevel
+	showRules, showChecks := false, false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--level", "-l":
+			if i+1 >= len(args) {
+				return i18n.Errorf("--level 需要参数")
+			}
+			i++
+			level = config.RiskLevel(strings.ToUpper(args[i]))
+			if level != config.RiskCritical && level != config.RiskHigh && level != config.RiskMedium {
+				return i18n.Errorf("无效的等级: %s (可用: critical, high, medium)", args[i])
+			}
+		case "--rules":
+			showRules = true
+		case "--checks":
+			showChecks = true
+		}
+	}
+
+	source := "built-in"
+	if sess.Config.RulesFile != "" {
+		source = sess.Config.RulesFile
+	}
+	p.Printf("%s Rule source: %s\n", p.Colored(config.ColorBlue, "[*]"), source)
+
+	// 风险查找表
+	var rows [][]string
+	for _, e := range config.EffectiveRiskTable() {
+		if level != "" && e.Level != level {
+			continue
+		}
+		group := e.Group
+		if group == "" {
+			group = "*"
+		}
+		rows = append(rows, []string{
+			p.Formatter().FormatRiskLevelColored(e.Level),
+			group,
+			e.Resource,
+			strings.Join(e.Verbs, ","),
+		})
+	}
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"LEVEL", "GROUP", "RESOURCE", "VERBS"}, rows)
+
+	if showRules {
+		var ruleRows [][]string
+		for i, r := range config.PermissionRiskRules {
+			ruleRows = append(ruleRows, []string{
+				fmt.Sprintf("%d", i+1),
+				config.PermissionLevelNames[r.Level],
+				orDash(r.Group),
+				r.Resource,
+				orDash(r.Subresource),
+				r.Verb,
+				r.Description,
+			})
+		}
+		p.Println()
+		output.NewTablePrinter().PrintSimple(
+			[]string{"#", "LEVEL", "GROUP", "RESOURCE", "SUBRESOURCE", "VERB", "DESCRIPTION"},
+			ruleRows)
+	}
+
+	if showChecks {
+		var checkRows [][]string
+		for _, perm := range config.PermissionsToCheck {
+			checkRows = append(checkRows, []string{
+				orDash(perm.Group),
+				perm.Resource,
+				orDash(perm.Subresource),
+				perm.Verb,
+			})
+		}
+		p.Println()
+		output.NewTablePrinter().PrintSimple([]string{"GROUP", "RESOURCE", "SUBRESOURCE", "VERB"}, checkRows)
+	}
+
+	p.Println()
+	p.Printf("%s %d risk entries, %d permission rules, %d permission checks\n",
+		p.Colored(config.ColorGreen, "[+]"),
+		len(rows), len(config.PermissionRiskRules), len(config.PermissionsToCheck))
+
+	return nil
+}
+
+// orDash 空字符串显示为 "-"
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -3,13 +3,18 @@ package commands
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"sync"
 
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/console/picker"
+	"kctl/internal/i18n"
 	"kctl/internal/session"
+	"kctl/pkg/limiter"
 	"kctl/pkg/types"
 )
 
@@ -32,19 +37,26 @@ func (c *RunCmd) Description() string {
 	return "通过 /run API 执行命令"
 }
 
+// IsDestructive run 可在 Pod 中执行任意命令
+func (c *RunCmd) IsDestructive(args []string) bool {
+	return true
+}
+
 func (c *RunCmd) Usage() string {
 	return `run [options] [pod]
 
 通过 Kubelet /run API 执行命令（HTTP POST 方式）
 
 选项：
-  -n <namespace>      指定命名空间
+  -n <namespace>      指定命名空间（默认从 Pod 缓存推断，也可写为 <namespace>/<pod>）
   -c <container>      指定容器
   --cmd <command>     要执行的命令（必需）
   --all-pods          在所有 Pod 中执行命令
+  --yes               --all-pods 时不询问确认（交互式控制台中默认先显示涉及的 Pod 和命名空间）
   --filter <pods>     排除指定 Pod（逗号分隔）
   --filter-ns <ns>    排除指定命名空间（逗号分隔）
   --concurrency <n>   并发数（默认: 10）
+  --confirm           当前 SA 为 cluster-admin 时必须指定
 
 示例：
   run nginx --cmd "id"                              在指定 Pod 中执行
@@ -59,6 +71,21 @@ func (c *RunCmd) Usage() string {
   - run 适合快速执行简单命令`
 }
 
+// Flags run 的选项补全
+func (c *RunCmd) Flags(args []string) []completion.Flag {
+	return withFlags([]completion.Flag{
+		{Name: "--cmd", Arg: "<command>", Description: "要执行的命令（必需）"},
+		flagNamespace,
+		flagContainer,
+		{Name: "--all-pods", Description: "在所有 Pod 中执行"},
+	}, batchFlags, []completion.Flag{flagYes, flagConfirm})
+}
+
+// Suggestions run 的 Pod 补全
+func (c *RunCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return podSuggestions(sess, c, args, completion.RunningPods)
+}
+
 func (c *RunCmd) Execute(sess *session.Session, args []string) error {
 	p := sess.Printer
 	ctx := context.Background()
@@ -78,6 +105,7 @@ func (c *RunCmd) Execute(sess *session.Session, args []string) error {
 	filterPods := ""
 	filterNs := ""
 	concurrency := 10
+	yes := false
 
 	// 解析选项
 	for i := 0; i < len(args); i++ {
@@ -99,6 +127,8 @@ func (c *RunCmd) Execute(sess *session.Session, args []string) error {
 			}
 		case "--all-pods":
 			allPods = true
+		case YesFlag:
+			yes = true
 		case "--filter":
 			if i+1 < len(args) {
 				filterPods = args[i+1]
@@ -125,12 +155,12 @@ func (c *RunCmd) Execute(sess *session.Session, args []string) error {
 
 	// 检查命令参数
 	if command == "" {
-		return fmt.Errorf("必须指定 --cmd 参数")
+		return i18n.Errorf("必须指定 --cmd 参数")
 	}
 
 	// 多 Pod 执行模式
 	if allPods {
-		return c.runAllPods(ctx, sess, kubelet, namespace, filterPods, filterNs, concurrency, command)
+		return c.runAllPods(ctx, sess, kubelet, namespace, filterPods, filterNs, concurrency, yes, command)
 	}
 
 	// 如果没有指定 Pod，尝试使用当前 SA 的 Pod
@@ -154,42 +184,20 @@ func (c *RunCmd) Execute(sess *session.Session, args []string) error {
 	}
 
 	if podName == "" {
-		return fmt.Errorf("请指定 Pod 名称或先使用 'use' 选择一个 SA")
+		return i18n.Errorf("请指定 Pod 名称或先使用 'use' 选择一个 SA")
 	}
 
-	// 如果没有指定命名空间，尝试从缓存中查找
-	if namespace == "" {
-		pods := sess.GetCachedPods()
-		for _, pod := range pods {
-			if pod.PodName == podName {
-				namespace = pod.Namespace
-				if container == "" && len(pod.Containers) > 0 {
-					container = pod.Containers[0].Name
-				}
-				break
-			}
-		}
+	ref, err := resolvePod(sess, podName, namespace, container)
+	if errors.Is(err, picker.ErrCancelled) {
+		return nil
 	}
-
-	if namespace == "" {
-		namespace = "default"
-	}
-
-	// 如果没有指定容器，获取第一个容器
-	if container == "" {
-		pods := sess.GetCachedPods()
-		for _, pod := range pods {
-			if pod.PodName == podName && pod.Namespace == namespace {
-				if len(pod.Containers) > 0 {
-					container = pod.Containers[0].Name
-				}
-				break
-			}
-		}
+	if err != nil {
+		return err
 	}
+	namespace, podName, container = ref.Namespace, ref.Pod, ref.Container
 
 	if container == "" {
-		return fmt.Errorf("无法确定容器名称，请使用 -c 指定")
+		return i18n.Errorf("无法确定容器名称，请使用 -c 指定")
 	}
 
 	// 执行命令
@@ -211,7 +219,7 @@ func (c *RunCmd) runCommand(ctx context.Context, sess *session.Session, kubelet
 
 	result, err := kubelet.Run(ctx, opts)
 	if err != nil {
-		return fmt.Errorf("执行命令失败: %w", err)
+		return i18n.Errorf("执行命令失败: %w", err)
 	}
 
 	if result.Error != "" {
@@ -231,13 +239,13 @@ func (c *RunCmd) runCommand(ctx context.Context, sess *session.Session, kubelet
 // runAllPods 在多个 Pod 中并发执行命令
 func (c *RunCmd) runAllPods(ctx context.Context, sess *session.Session, kubelet interface {
 	Run(ctx context.Context, opts *types.RunOptions) (*types.RunResult, error)
-}, namespace, filterPods, filterNs string, concurrency int, command string) error {
+}, namespace, filterPods, filterNs string, concurrency int, yes bool, command string) error {
 	p := sess.Printer
 
 	// 获取缓存的 Pod
 	pods := sess.GetCachedPods()
 	if len(pods) == 0 {
-		return fmt.Errorf("没有缓存的 Pod，请先执行 'pods' 命令")
+		return i18n.Errorf("没有缓存的 Pod，请先执行 'pods' 命令")
 	}
 
 	// 解析 filter 列表
@@ -267,12 +275,21 @@ func (c *RunCmd) runAllPods(ctx context.Context, sess *session.Session, kubelet
 	}
 
 	if len(targetPods) == 0 {
-		return fmt.Errorf("没有匹配的 Pod")
+		return i18n.Errorf("没有匹配的 Pod")
 	}
 
-	p.Printf("%s Executing on %d pods (concurrency: %d)...\n\n",
+	summary, details := podBlastRadius(targetPods)
+	if !confirmAction(sess, yes, fmt.Sprintf("About to run '%s' in %s", command, summary), details...) {
+		return nil
+	}
+
+	// 自适应并发：Kubelet 报错或超时时自动降低并发，健康时逐步提升
+	lim := limiter.NewAdaptive(concurrency)
+
+	printPacing(sess)
+	p.Printf("%s Executing on %d pods (concurrency: adaptive, max %d)...\n\n",
 		p.Colored(config.ColorBlue, "[*]"),
-		len(targetPods), concurrency)
+		len(targetPods), lim.Max())
 
 	// 执行结果
 	type runResultItem struct {
@@ -287,15 +304,17 @@ func (c *RunCmd) runAllPods(ctx context.Context, sess *session.Session, kubelet
 	var results []runResultItem
 	var mu sync.Mutex
 	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, concurrency)
+	// 会话工作池限制所有批量命令同时发往同一 Kubelet 的请求数
+	workers, key := sess.Workers(), sess.CurrentKubeletKey()
 
 	for _, pod := range targetPods {
+		if err := lim.Acquire(ctx); err != nil {
+			break
+		}
 		wg.Add(1)
-		semaphore <- struct{}{}
 
 		go func(pod types.PodContainerInfo) {
 			defer wg.Done()
-			defer func() { <-semaphore }()
 
 			container := ""
 			if len(pod.Containers) > 0 {
@@ -309,7 +328,13 @@ func (c *RunCmd) runAllPods(ctx context.Context, sess *session.Session, kubelet
 				Command:   command,
 			}
 
+			if err := workers.Acquire(ctx, key); err != nil {
+				lim.Release(true)
+				return
+			}
 			result, err := kubelet.Run(ctx, opts)
+			workers.Release(key)
+			lim.Release(err == nil)
 
 			item := runResultItem{
 				Namespace: pod.Namespace,
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"kctl/internal/console/commands/sa"
+	"kctl/internal/console/completion"
 	"kctl/internal/session"
 )
 
@@ -24,10 +25,30 @@ func (c *SACmd) Description() string {
 	return "ServiceAccount 相关操作"
 }
 
+// IsReadOnly 除 sa scan 外均只读取数据库
+func (c *SACmd) IsReadOnly(args []string) bool {
+	return sa.IsReadOnly(args)
+}
+
+// IsPaged sa list / history / info 的输出分页显示
+func (c *SACmd) IsPaged(args []string) bool {
+	return sa.IsPaged(args)
+}
+
 func (c *SACmd) Usage() string {
 	return sa.Usage()
 }
 
+// Flags sa 的选项补全（委托给子命令）
+func (c *SACmd) Flags(args []string) []completion.Flag {
+	return sa.Flags(args)
+}
+
+// Suggestions sa 的子命令及参数补全
+func (c *SACmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return sa.Suggestions(sess, args)
+}
+
 func (c *SACmd) Execute(sess *session.Session, args []string) error {
 	return sa.Execute(sess, args)
 }
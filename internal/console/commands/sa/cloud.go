@@ -0,0 +1,109 @@
+package sa
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/token"
+	"kctl/pkg/types"
+)
+
+// cloudSignals 收集云厂商识别所需的信息
+// queryAPI 为 true 时使用当前身份从 API Server 获取节点和 ServiceAccount，无权限时跳过
+func cloudSignals(ctx context.Context, sess *session.Session, pods []types.PodContainerInfo, results []SATokenResult, queryAPI bool) security.CloudSignals {
+	signals := security.CloudSignals{
+		APIServer: sess.Config.APIServer,
+		Nodes:     sess.GetCachedNodes(),
+		Pods:      pods,
+	}
+
+	seen := make(map[string]bool)
+	addIssuer := func(iss string) {
+		if iss != "" && !seen[iss] {
+			seen[iss] = true
+			signals.Issuers = append(signals.Issuers, iss)
+		}
+	}
+	for _, r := range results {
+		if r.TokenInfo != nil {
+			addIssuer(r.TokenInfo.Issuer)
+		}
+	}
+	if info, err := token.Parse(sess.GetActiveToken()); err == nil {
+		addIssuer(info.Issuer)
+	}
+
+	if !queryAPI || sess.Config.APIServer == "" {
+		return signals
+	}
+	k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+	if err != nil {
+		return signals
+	}
+	if len(signals.Nodes) == 0 || signals.Nodes[0].Source != "apiserver" {
+		if nodes, err := k8s.ListNodes(ctx); err == nil {
+			signals.Nodes = nodes
+			sess.CacheNodes(nodes)
+		}
+	}
+	if sas, err := k8s.ListServiceAccounts(ctx, ""); err == nil {
+		signals.ServiceAccounts = sas
+	}
+	return signals
+}
+
+// printCloudPosture 输出云厂商识别结果和云身份检查，并将发现保存到数据库
+func printCloudPosture(sess *session.Session, signals security.CloudSignals) {
+	p := sess.Printer
+
+	detection := security.DetectCloud(signals)
+	if detection.Provider == "" {
+		return
+	}
+
+	p.Println()
+	p.Printf("%s Cloud posture: %s %s\n",
+		p.Colored(config.ColorBlue, "[*]"),
+		p.Colored(config.ColorCyan, string(detection.Provider)),
+		p.Colored(config.ColorGray, "("+strings.Join(detection.Evidence, ", ")+")"))
+
+	findings := security.AuditCloud(detection.Provider, signals)
+	if len(findings) == 0 {
+		p.Printf("%s No cloud identity issues found\n", p.Colored(config.ColorGreen, "[+]"))
+		return
+	}
+	for _, f := range findings {
+		p.Printf("    %s %s\n", p.Formatter().FormatRiskLevelColored(f.Severity), f.Title)
+		p.Printf("      %s\n", p.Colored(config.ColorGray, f.Detail))
+	}
+	saveCloudFindings(sess, detection.Provider, findings)
+}
+
+// saveCloudFindings 保存云身份检查结果（来源 cloud，位置为检查标识）
+func saveCloudFindings(sess *session.Session, provider security.CloudProvider, findings []security.CloudFinding) {
+	if sess.FindingDB == nil {
+		return
+	}
+	now := time.Now()
+	var records []*types.FindingRecord
+	for _, f := range findings {
+		records = append(records, &types.FindingRecord{
+			Source:      "cloud",
+			Severity:    string(f.Severity),
+			Category:    strings.ToLower(string(provider)),
+			Location:    f.ID,
+			Title:       f.Title,
+			Evidence:    f.Detail,
+			CollectedAt: now,
+			KubeletIP:   sess.Config.KubeletIP,
+		})
+	}
+	if _, err := sess.FindingDB.SaveBatch(records); err != nil {
+		sess.Printer.Warning(i18n.Tf("保存云检查结果失败: %v", err))
+	}
+}
@@ -25,22 +25,8 @@ func buildFullResource(resource, subresource string) string {
 }
 
 func buildFlagsFromSecurityFlags(p output.Printer, flags types.SecurityFlags, perms []types.PermissionCheck) string {
-	var result []string
-	hasPriv := false
-
-	if flags.Privileged {
-		result = append(result, p.Colored(config.ColorRed, "PRIV"))
-		hasPriv = true
-	}
-	if flags.AllowPrivilegeEscalation {
-		result = append(result, p.Colored(config.ColorYellow, "PE"))
-	}
-	if flags.HasHostPath {
-		result = append(result, p.Colored(config.ColorRed, "HP"))
-	}
-	if flags.HasSecretMount {
-		result = append(result, p.Colored(config.ColorYellow, "SEC"))
-	}
+	result := p.Formatter().FormatFlagAbbrevs(flags)
+	hasPriv := flags.Privileged
 
 	if !hasPriv {
 		for _, perm := range perms {
@@ -62,22 +48,8 @@ func buildFlagsFromSecurityFlags(p output.Printer, flags types.SecurityFlags, pe
 }
 
 func buildFlagsFromSASecurityFlags(p output.Printer, flags types.SASecurityFlags, perms []types.SAPermission) string {
-	var result []string
-	hasPriv := false
-
-	if flags.Privileged {
-		result = append(result, p.Colored(config.ColorRed, "PRIV"))
-		hasPriv = true
-	}
-	if flags.AllowPrivilegeEscalation {
-		result = append(result, p.Colored(config.ColorYellow, "PE"))
-	}
-	if flags.HasHostPath {
-		result = append(result, p.Colored(config.ColorRed, "HP"))
-	}
-	if flags.HasSecretMount {
-		result = append(result, p.Colored(config.ColorYellow, "SEC"))
-	}
+	result := p.Formatter().FormatFlagAbbrevs(flags.PodFlags())
+	hasPriv := flags.Privileged
 
 	if !hasPriv {
 		for _, perm := range perms {
@@ -114,9 +86,10 @@ func formatPermissionsFromChecks(p output.Printer, perms []types.PermissionCheck
 		}
 		seen[key] = true
 
-		if config.IsCriticalPermission(resource, perm.Verb) {
+		switch config.PermissionRiskLevel(perm.Group, perm.Resource, perm.Subresource, perm.Verb) {
+		case config.RiskCritical:
 			key = p.Colored(config.ColorRed, key)
-		} else if config.IsHighPermission(resource, perm.Verb) {
+		case config.RiskHigh:
 			key = p.Colored(config.ColorYellow, key)
 		}
 		result = append(result, key)
@@ -147,9 +120,10 @@ func formatPermissionsFromSAPerms(p output.Printer, perms []types.SAPermission,
 		}
 		seen[key] = true
 
-		if config.IsCriticalPermission(resource, perm.Verb) {
+		switch config.PermissionRiskLevel(perm.Group, perm.Resource, perm.Subresource, perm.Verb) {
+		case config.RiskCritical:
 			key = p.Colored(config.ColorRed, key)
-		} else if config.IsHighPermission(resource, perm.Verb) {
+		case config.RiskHigh:
 			key = p.Colored(config.ColorYellow, key)
 		}
 		result = append(result, key)
@@ -0,0 +1,144 @@
+package sa
+
+import (
+	"fmt"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// HistoryCmd history 子命令
+type HistoryCmd struct{}
+
+func init() {
+	Register(&HistoryCmd{})
+}
+
+func (c *HistoryCmd) Name() string        { return "history" }
+func (c *HistoryCmd) Aliases() []string   { return []string{"scans"} }
+func (c *HistoryCmd) Description() string { return "列出历次 sa scan 记录" }
+
+func (c *HistoryCmd) Usage() string {
+	return `sa history [--absolute]
+
+列出历次 sa scan 的运行记录（扫描 ID、时间、目标、耗时、结果统计）
+每次扫描都会保存 SA 结果快照，使用 diff <scan1> <scan2> 比较两次扫描
+
+选项：
+  --absolute      显示绝对时间
+
+示例：
+  sa history
+  diff 3 5`
+}
+
+// Flags sa history 的选项补全
+func (c *HistoryCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--absolute", Description: "显示绝对时间"},
+	}
+}
+
+func (c *HistoryCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	absolute := false
+	for _, arg := range args {
+		if arg != "--absolute" {
+			return i18n.Errorf("未知选项: %s", arg)
+		}
+		absolute = true
+	}
+
+	scans, err := sess.ScanDB.GetAll()
+	if err != nil {
+		return i18n.Errorf("读取扫描记录失败: %w", err)
+	}
+	if len(scans) == 0 {
+		p.Info(i18n.T("没有扫描记录，请先执行 'sa scan'"))
+		return nil
+	}
+
+	tf := sess.TimeFormatter(absolute)
+	var rows [][]string
+	for _, s := range scans {
+		mode := s.Mode
+		switch {
+		case s.Sampled > 0:
+			mode += fmt.Sprintf(" (sampled %d)", s.Sampled)
+		case s.Partial:
+			mode += " (partial)"
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", s.ID),
+			tf.Format(s.StartedAt),
+			s.Target,
+			mode,
+			s.Duration.Round(time.Second).String(),
+			fmt.Sprintf("%d", s.Pods),
+			fmt.Sprintf("%d", s.SAs),
+			fmt.Sprintf("%d", s.Risky),
+			fmt.Sprintf("%d", s.Admins),
+		})
+	}
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"ID", "STARTED", "TARGET", "MODE", "DURATION", "PODS", "SAS", "RISKY", "ADMIN"}, rows)
+	p.Println()
+	p.Printf("%s %d scan(s), compare with 'diff <scan1> <scan2>'\n", p.Colored(config.ColorGreen, "[+]"), len(scans))
+	return nil
+}
+
+// newScanRecord 开始一次扫描时创建运行记录
+func newScanRecord(sess *session.Session, mode string) *types.ScanRecord {
+	return &types.ScanRecord{
+		StartedAt: time.Now(),
+		Target:    fmt.Sprintf("%s:%d", sess.Config.KubeletIP, sess.Config.KubeletPort),
+		Mode:      mode,
+	}
+}
+
+// recordScan 保存扫描记录和 SA 结果快照，并将扫描 ID 写入 records（随后保存到 service_accounts）
+// snapshot 为本次扫描看到的全部 SA，可能包含未覆盖保存的已有记录
+func recordScan(sess *session.Session, scan *types.ScanRecord, records, snapshot []*types.ServiceAccountRecord) {
+	if sess.ScanDB == nil {
+		return
+	}
+	scan.Duration = time.Since(scan.StartedAt)
+	scan.SAs = len(snapshot)
+	for _, r := range snapshot {
+		switch {
+		case r.IsClusterAdmin:
+			scan.Admins++
+		case config.RiskLevel(r.RiskLevel) == config.RiskCritical,
+			config.RiskLevel(r.RiskLevel) == config.RiskHigh,
+			config.RiskLevel(r.RiskLevel) == config.RiskMedium:
+			scan.Risky++
+		}
+	}
+
+	id, err := sess.ScanDB.Create(scan, snapshot)
+	if err != nil {
+		sess.Printer.Warning(i18n.Tf("保存扫描记录失败: %v", err))
+		return
+	}
+	for _, r := range records {
+		r.ScanID = id
+	}
+}
+
+// printScanRecorded 提示扫描 ID 以及如何与上一次扫描比较
+func printScanRecorded(p output.Printer, scan *types.ScanRecord) {
+	if scan.ID == 0 {
+		return
+	}
+	if scan.ID > 1 {
+		p.Printf("%s Recorded as scan #%d, run 'diff' to compare with the previous scan\n", p.Colored(config.ColorBlue, "[*]"), scan.ID)
+		return
+	}
+	p.Printf("%s Recorded as scan #%d\n", p.Colored(config.ColorBlue, "[*]"), scan.ID)
+}
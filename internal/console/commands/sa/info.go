@@ -3,8 +3,12 @@ package sa
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
 	"kctl/internal/output"
 	"kctl/internal/session"
 	"kctl/pkg/types"
@@ -21,11 +25,19 @@ func (c *InfoCmd) Aliases() []string   { return nil }
 func (c *InfoCmd) Description() string { return "显示当前 SA 详情" }
 
 func (c *InfoCmd) Usage() string {
-	return `sa info
+	return `sa info [--absolute]
 
 显示当前 ServiceAccount 的详细信息
 
-使用 'sa use <namespace/name>' 选择 SA 后，可以查看详情`
+使用 'sa use <namespace/name>' 选择 SA 后，可以查看详情
+
+选项：
+  --absolute    显示绝对时间（默认相对时间）`
+}
+
+// Flags sa info 的选项补全
+func (c *InfoCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{{Name: "--absolute", Description: "显示绝对时间"}}
 }
 
 func (c *InfoCmd) Execute(sess *session.Session, args []string) error {
@@ -33,9 +45,11 @@ func (c *InfoCmd) Execute(sess *session.Session, args []string) error {
 
 	sa := sess.GetCurrentSA()
 	if sa == nil {
-		return fmt.Errorf("未选择 ServiceAccount，请先使用 'sa use <namespace/name>' 选择")
+		return i18n.Errorf("未选择 ServiceAccount，请先使用 'sa use <namespace/name>' 选择")
 	}
 
+	tf := sess.TimeFormatter(slices.Contains(args, "--absolute"))
+
 	p.Println()
 	p.Printf("  %s\n", p.Colored(config.ColorCyan, "ServiceAccount Information"))
 	p.Println("  " + p.Colored(config.ColorGray, "─────────────────────────────────────────"))
@@ -43,7 +57,16 @@ func (c *InfoCmd) Execute(sess *session.Session, args []string) error {
 	p.Printf("  %-16s: %s\n", "Name", sa.Name)
 	p.Printf("  %-16s: %s\n", "Namespace", sa.Namespace)
 	p.Printf("  %-16s: %s\n", "Risk Level", c.formatRiskDisplay(p, sa))
-	p.Printf("  %-16s: %s\n", "Token Status", c.formatTokenStatus(p, sa))
+	p.Printf("  %-16s: %s\n", "Token Status", c.formatTokenStatus(p, sa, tf))
+	if !sa.CollectedAt.IsZero() {
+		p.Printf("  %-16s: %s\n", "Collected", tf.Format(sa.CollectedAt))
+	}
+	if sa.Tags != "" {
+		p.Printf("  %-16s: %s\n", "Tags", p.Colored(config.ColorMagenta, strings.Join(sa.TagList(), ", ")))
+	}
+	if sa.Note != "" {
+		p.Printf("  %-16s: %s\n", "Note", sa.Note)
+	}
 
 	p.Println()
 	c.printPermissions(p, sa)
@@ -66,13 +89,13 @@ func (c *InfoCmd) formatRiskDisplay(p output.Printer, sa *types.ServiceAccountRe
 	return p.Colored(display.Color, display.Label)
 }
 
-func (c *InfoCmd) formatTokenStatus(p output.Printer, sa *types.ServiceAccountRecord) string {
+func (c *InfoCmd) formatTokenStatus(p output.Printer, sa *types.ServiceAccountRecord, tf output.TimeFormatter) string {
 	status := p.Colored(config.ColorGreen, "Valid")
 	if sa.IsExpired {
 		status = p.Colored(config.ColorRed, "Expired")
 	}
 	if sa.TokenExpiration != "" {
-		status = fmt.Sprintf("%s (expires: %s)", status, sa.TokenExpiration)
+		status = fmt.Sprintf("%s (expires: %s)", status, tf.FormatString(sa.TokenExpiration))
 	}
 	return status
 }
@@ -85,7 +108,11 @@ func (c *InfoCmd) printPermissions(p output.Printer, sa *types.ServiceAccountRec
 		return
 	}
 
-	if sa.Permissions == "" || sa.Permissions == "[]" {
+	if !sa.PermissionsChecked() {
+		p.Printf("    %s\n", p.Colored(config.ColorGray, "(not checked - passive scan, run 'sa scan' to check permissions)"))
+		return
+	}
+	if sa.Permissions == "[]" {
 		p.Printf("    %s\n", p.Colored(config.ColorGray, "(not scanned - run 'sa scan' to check permissions)"))
 		return
 	}
@@ -99,9 +126,10 @@ func (c *InfoCmd) printPermissions(p output.Printer, sa *types.ServiceAccountRec
 	for _, perm := range perms {
 		resource := buildFullResource(perm.Resource, perm.Subresource)
 		permStr := fmt.Sprintf("%s:%s", resource, perm.Verb)
-		if config.IsCriticalPermission(resource, perm.Verb) {
+		switch config.PermissionRiskLevel(perm.Group, perm.Resource, perm.Subresource, perm.Verb) {
+		case config.RiskCritical:
 			permStr = p.Colored(config.ColorRed, permStr)
-		} else if config.IsHighPermission(resource, perm.Verb) {
+		case config.RiskHigh:
 			permStr = p.Colored(config.ColorYellow, permStr)
 		}
 		p.Printf("    - %s\n", permStr)
@@ -139,6 +167,30 @@ func (c *InfoCmd) printSecurityFlags(p output.Printer, flagsJSON string) {
 		p.Printf("    - %s\n", p.Colored(config.ColorYellow, "Secret Mount"))
 		hasFlags = true
 	}
+	if flags.HostNetwork {
+		p.Printf("    - %s\n", p.Colored(config.ColorYellow, "Host Network"))
+		hasFlags = true
+	}
+	if flags.HostPID {
+		p.Printf("    - %s\n", p.Colored(config.ColorYellow, "Host PID"))
+		hasFlags = true
+	}
+	if flags.HostIPC {
+		p.Printf("    - %s\n", p.Colored(config.ColorYellow, "Host IPC"))
+		hasFlags = true
+	}
+	if len(flags.Capabilities) > 0 {
+		p.Printf("    - %s\n", p.Colored(config.ColorRed, "Capabilities: "+strings.Join(flags.Capabilities, ", ")))
+		hasFlags = true
+	}
+	if flags.RunAsRoot {
+		p.Printf("    - %s\n", p.Colored(config.ColorYellow, "Runs as root (UID 0)"))
+		hasFlags = true
+	}
+	if flags.NoSeccomp {
+		p.Printf("    - %s\n", p.Colored(config.ColorGray, "No seccomp profile"))
+		hasFlags = true
+	}
 	if flags.HasSATokenMount {
 		p.Printf("    - %s\n", p.Colored(config.ColorGreen, "ServiceAccount Token Mount"))
 		hasFlags = true
@@ -0,0 +1,179 @@
+package sa
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/session"
+	"kctl/pkg/kubeconfig"
+	"kctl/pkg/types"
+)
+
+// KubeconfigCmd kubeconfig 子命令
+type KubeconfigCmd struct{}
+
+func init() {
+	Register(&KubeconfigCmd{})
+}
+
+func (c *KubeconfigCmd) Name() string        { return "kubeconfig" }
+func (c *KubeconfigCmd) Aliases() []string   { return []string{"kc"} }
+func (c *KubeconfigCmd) Description() string { return "生成 SA 的 kubeconfig" }
+
+func (c *KubeconfigCmd) Usage() string {
+	return `sa kubeconfig [namespace/name] [options]
+
+为已扫描的 ServiceAccount 生成可直接用于 kubectl 的 kubeconfig
+未指定 SA 时使用当前 SA（sa use）
+
+API Server 地址取自 'set api-server'，未设置时为 ` + config.DefaultK8sAPIServer + `
+默认跳过 TLS 校验；已通过 set kubeconfig 加载 CA 或在 Pod 内运行时自动嵌入 CA
+
+选项：
+  --out, -o <file>   写入文件（权限 0600），默认输出到终端
+  --ca <file>        嵌入指定的 CA 证书 (PEM)
+  --insecure         不嵌入 CA，跳过 TLS 校验
+
+示例：
+  sa kubeconfig kube-system/clusterrole-aggregation-controller
+  sa kubeconfig default/nginx --out nginx.kubeconfig
+  KUBECONFIG=nginx.kubeconfig kubectl auth can-i --list`
+}
+
+// Flags sa kubeconfig 的选项补全
+func (c *KubeconfigCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--out", Short: "-o", Arg: "<file>", Description: "写入文件"},
+		{Name: "--ca", Arg: "<file>", Description: "嵌入 CA 证书"},
+		{Name: "--insecure", Description: "跳过 TLS 校验"},
+	}
+}
+
+// Suggestions sa kubeconfig 的 SA 补全
+func (c *KubeconfigCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) > 0 {
+		return nil
+	}
+	return completion.ServiceAccounts(sess, args)
+}
+
+func (c *KubeconfigCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	target, out, caFile := "", "", ""
+	insecure := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--out", "-o":
+			if i+1 >= len(args) {
+				return i18n.Errorf("--out 需要文件路径")
+			}
+			i++
+			out = args[i]
+		case "--ca":
+			if i+1 >= len(args) {
+				return i18n.Errorf("--ca 需要文件路径")
+			}
+			i++
+			caFile = args[i]
+		case "--insecure":
+			insecure = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				return i18n.Errorf("未知选项: %s", args[i])
+			}
+			target = args[i]
+		}
+	}
+
+	sa, err := c.resolveSA(sess, target)
+	if err != nil {
+		return err
+	}
+	if sa.Token == "" {
+		return i18n.Errorf("%s/%s 没有可用的 Token", sa.Namespace, sa.Name)
+	}
+
+	caData, err := c.loadCA(sess, caFile, insecure)
+	if err != nil {
+		return err
+	}
+
+	data, err := kubeconfig.Build(kubeconfig.Options{
+		Server:    sess.APIServerURL(),
+		Token:     sa.Token,
+		Namespace: sa.Namespace,
+		User:      sa.Namespace + "-" + sa.Name,
+		CAData:    caData,
+	})
+	if err != nil {
+		return i18n.Errorf("生成 kubeconfig 失败: %w", err)
+	}
+
+	if sa.IsExpired {
+		p.Warning(i18n.Tf("%s/%s 的 Token 已过期，kubeconfig 可能无法使用", sa.Namespace, sa.Name))
+	}
+
+	if out == "" {
+		p.Println(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(out, data, 0o600); err != nil {
+		return i18n.Errorf("写入 kubeconfig 失败: %w", err)
+	}
+	p.Success(fmt.Sprintf("Kubeconfig for %s/%s written to %s", sa.Namespace, sa.Name, out))
+	p.Info(i18n.Tf("使用: KUBECONFIG=%s kubectl auth can-i --list", out))
+	return nil
+}
+
+// resolveSA 查找目标 SA，未指定时使用当前 SA
+func (c *KubeconfigCmd) resolveSA(sess *session.Session, target string) (*types.ServiceAccountRecord, error) {
+	if target == "" {
+		if sa := sess.GetCurrentSA(); sa != nil {
+			return sa, nil
+		}
+		return nil, i18n.Errorf("用法: sa kubeconfig <namespace/name>，或先使用 'sa use' 选择 SA")
+	}
+
+	parts := strings.SplitN(target, "/", 2)
+	if len(parts) != 2 {
+		return nil, i18n.Errorf("格式错误，请使用 namespace/sa-name 格式")
+	}
+
+	sa, err := sess.SADB.GetByName(parts[0], parts[1])
+	if err != nil {
+		return nil, i18n.Errorf("查找 ServiceAccount 失败: %w", err)
+	}
+	if sa == nil {
+		return nil, i18n.Errorf("未找到 ServiceAccount: %s，请先执行 'sa scan'", target)
+	}
+	return sa, nil
+}
+
+// loadCA 读取要嵌入的 CA 证书，返回 nil 表示跳过 TLS 校验
+func (c *KubeconfigCmd) loadCA(sess *session.Session, caFile string, insecure bool) ([]byte, error) {
+	if insecure {
+		return nil, nil
+	}
+	if caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, i18n.Errorf("读取 CA 证书失败: %w", err)
+		}
+		return data, nil
+	}
+	if sess.Config.CACert != "" {
+		return []byte(sess.Config.CACert), nil
+	}
+	if sess.InPod {
+		if data, err := os.ReadFile(config.DefaultCAPath); err == nil {
+			return data, nil
+		}
+	}
+	return nil, nil
+}
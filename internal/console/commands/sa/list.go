@@ -2,9 +2,13 @@ package sa
 
 import (
 	"encoding/json"
-	"fmt"
+	"slices"
+	"strings"
 
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/filter"
+	"kctl/internal/i18n"
 	"kctl/internal/output"
 	"kctl/internal/session"
 	"kctl/pkg/types"
@@ -29,6 +33,8 @@ func (c *ListCmd) Usage() string {
   --admin, -a     只显示 cluster-admin
   --risky, -r     只显示有风险权限的 SA
   -n <namespace>  按命名空间过滤
+  --tag <tag>     只显示带有指定标签的 SA（见 sa tag）
+  --where <expr>  按过滤表达式筛选（@name 引用已保存的过滤器，见 filter）
   --perms, -p     显示权限
   --token, -t     显示 Token
 
@@ -36,31 +42,58 @@ func (c *ListCmd) Usage() string {
   sa list                 列出所有 SA
   sa list --admin         只显示 cluster-admin
   sa list --risky         只显示有风险的 SA
-  sa list -n kube-system  只显示 kube-system 命名空间的 SA`
+  sa list -n kube-system  只显示 kube-system 命名空间的 SA
+  sa list --tag compromised
+  sa list --where 'namespace~"^prod" && risk>=HIGH'
+  sa list --where @prod-risky`
+}
+
+// Flags sa list 的选项补全
+func (c *ListCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--admin", Short: "-a", Description: "只显示 cluster-admin"},
+		{Name: "--risky", Short: "-r", Description: "只显示有风险的 SA"},
+		{Name: "-n", Arg: "<namespace>", Description: "按命名空间过滤", Values: completion.Namespaces},
+		{Name: "--tag", Arg: "<tag>", Description: "按标签过滤", Values: completion.Choices(commonTags...)},
+		{Name: "--where", Short: "-w", Arg: "<expr>", Description: "按过滤表达式筛选", Values: completion.SavedFilters},
+		{Name: "--perms", Short: "-p", Description: "显示权限"},
+		{Name: "--token", Short: "-t", Description: "显示 Token"},
+	}
 }
 
 func (c *ListCmd) Execute(sess *session.Session, args []string) error {
 	p := sess.Printer
 
 	if !sess.IsScanned {
-		return fmt.Errorf("请先执行 'sa scan' 扫描 ServiceAccount")
+		return i18n.Errorf("请先执行 'sa scan' 扫描 ServiceAccount")
 	}
 
-	onlyAdmin, onlyRisky, namespace, showPerms, showToken := c.parseArgs(args)
+	onlyAdmin, onlyRisky, namespace, tag, where, showPerms, showToken := c.parseArgs(args)
+
+	var expr *filter.Expr
+	if where != "" {
+		var err error
+		if expr, err = filter.Resolve(where); err != nil {
+			return err
+		}
+	}
 
 	sas, err := sess.SADB.GetAll()
 	if err != nil {
-		return fmt.Errorf("获取 ServiceAccount 失败: %w", err)
+		return i18n.Errorf("获取 ServiceAccount 失败: %w", err)
 	}
 
 	if len(sas) == 0 {
-		p.Warning("没有找到 ServiceAccount，请先执行 'sa scan'")
+		p.Warning(i18n.T("没有找到 ServiceAccount，请先执行 'sa scan'"))
 		return nil
 	}
 
 	var rows []output.SARow
 	for _, sa := range sas {
-		if !c.matchesFilter(sa, namespace, onlyAdmin, onlyRisky) {
+		if !c.matchesFilter(sa, namespace, tag, onlyAdmin, onlyRisky) {
+			continue
+		}
+		if expr != nil && !expr.Match(filter.SARecord(sa)) {
 			continue
 		}
 
@@ -73,7 +106,7 @@ func (c *ListCmd) Execute(sess *session.Session, args []string) error {
 			perms = []types.SAPermission{}
 		}
 
-		rows = append(rows, output.SARow{
+		row := output.SARow{
 			Risk:        formatRiskLabel(p, config.RiskLevel(sa.RiskLevel), sa.IsClusterAdmin),
 			Namespace:   sa.Namespace,
 			Name:        sa.Name,
@@ -81,22 +114,30 @@ func (c *ListCmd) Execute(sess *session.Session, args []string) error {
 			Flags:       buildFlagsFromSASecurityFlags(p, secFlags, perms),
 			Permissions: formatPermissionsFromSAPerms(p, perms, sa.IsClusterAdmin),
 			Token:       sa.Token,
-		})
+			Tags:        strings.Join(sa.TagList(), ","),
+			Note:        sa.Note,
+		}
+		// 被动扫描的记录没有 Token 和权限
+		if !sa.PermissionsChecked() {
+			row.TokenStatus = p.Colored(config.ColorGray, "not read")
+			row.Permissions = p.Colored(config.ColorGray, "not checked")
+		}
+		rows = append(rows, row)
 	}
 
 	if len(rows) == 0 {
-		p.Warning("没有符合条件的 ServiceAccount")
+		p.Warning(i18n.T("没有符合条件的 ServiceAccount"))
 		return nil
 	}
 
 	p.Println()
 	output.NewTablePrinter().PrintServiceAccounts(rows, showPerms, showToken)
-	p.Printf("\n  共 %d 个 ServiceAccount\n\n", len(rows))
+	p.Printf("\n  %s\n\n", i18n.Tf("共 %d 个 ServiceAccount", len(rows)))
 
 	return nil
 }
 
-func (c *ListCmd) parseArgs(args []string) (onlyAdmin, onlyRisky bool, namespace string, showPerms, showToken bool) {
+func (c *ListCmd) parseArgs(args []string) (onlyAdmin, onlyRisky bool, namespace, tag, where string, showPerms, showToken bool) {
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
 		case "--admin", "-a":
@@ -108,6 +149,16 @@ func (c *ListCmd) parseArgs(args []string) (onlyAdmin, onlyRisky bool, namespace
 				namespace = args[i+1]
 				i++
 			}
+		case "--tag":
+			if i+1 < len(args) {
+				tag = strings.ToLower(args[i+1])
+				i++
+			}
+		case "--where", "-w":
+			if i+1 < len(args) {
+				where = args[i+1]
+				i++
+			}
 		case "--perms", "-p":
 			showPerms = true
 		case "--token", "-t":
@@ -117,10 +168,13 @@ func (c *ListCmd) parseArgs(args []string) (onlyAdmin, onlyRisky bool, namespace
 	return
 }
 
-func (c *ListCmd) matchesFilter(sa *types.ServiceAccountRecord, namespace string, onlyAdmin, onlyRisky bool) bool {
+func (c *ListCmd) matchesFilter(sa *types.ServiceAccountRecord, namespace, tag string, onlyAdmin, onlyRisky bool) bool {
 	if namespace != "" && sa.Namespace != namespace {
 		return false
 	}
+	if tag != "" && !slices.Contains(sa.TagList(), tag) {
+		return false
+	}
 	if onlyAdmin && !sa.IsClusterAdmin {
 		return false
 	}
@@ -0,0 +1,113 @@
+package sa
+
+import (
+	"fmt"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// NoteCmd note 子命令
+type NoteCmd struct{}
+
+func init() {
+	Register(&NoteCmd{})
+}
+
+func (c *NoteCmd) Name() string        { return "note" }
+func (c *NoteCmd) Aliases() []string   { return nil }
+func (c *NoteCmd) Description() string { return "设置 SA 的备注" }
+
+func (c *NoteCmd) Usage() string {
+	return `sa note <namespace/name> ["text"|--clear]
+
+为 ServiceAccount 记录备注（如利用进度、排查结论），保存在数据库中，重新扫描不会丢失
+备注显示在 sa list、sa info 和 export 中
+
+选项：
+  --clear         清除备注
+
+示例：
+  sa note kube-system/cluster-admin "已获取 Token，用于横向移动"
+  sa note kube-system/cluster-admin          显示备注
+  sa note kube-system/cluster-admin --clear`
+}
+
+// Flags sa note 的选项补全
+func (c *NoteCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--clear", Description: "清除备注"},
+	}
+}
+
+// Suggestions sa note 的 SA 补全
+func (c *NoteCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) > 0 {
+		return nil
+	}
+	return completion.ServiceAccounts(sess, args)
+}
+
+func (c *NoteCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	if len(args) == 0 {
+		return i18n.Errorf("用法: sa note <namespace/name> [\"text\"|--clear]")
+	}
+	sa, err := lookupSA(sess, args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		if sa.Note == "" {
+			p.Info(i18n.Tf("%s/%s 没有备注", sa.Namespace, sa.Name))
+			return nil
+		}
+		p.Printf("%s %s/%s: %s\n", p.Colored(config.ColorBlue, "[*]"), sa.Namespace, sa.Name, sa.Note)
+		return nil
+	}
+
+	note := strings.Join(args[1:], " ")
+	if args[1] == "--clear" {
+		note = ""
+	}
+	if err := sess.SADB.SetNote(sa.Namespace, sa.Name, note); err != nil {
+		return err
+	}
+	updateCurrentSA(sess, sa.Namespace, sa.Name, func(cur *types.ServiceAccountRecord) { cur.Note = note })
+
+	if note == "" {
+		p.Success(fmt.Sprintf("Cleared note for %s/%s", sa.Namespace, sa.Name))
+	} else {
+		p.Success(fmt.Sprintf("Note saved for %s/%s", sa.Namespace, sa.Name))
+	}
+	return nil
+}
+
+// lookupSA 按 namespace/name 从数据库查找 SA
+func lookupSA(sess *session.Session, target string) (*types.ServiceAccountRecord, error) {
+	parts := strings.SplitN(target, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, i18n.Errorf("格式错误，请使用 namespace/sa-name 格式")
+	}
+	sa, err := sess.SADB.GetByName(parts[0], parts[1])
+	if err != nil {
+		return nil, i18n.Errorf("查找 ServiceAccount 失败: %w", err)
+	}
+	if sa == nil {
+		return nil, i18n.Errorf("未找到 ServiceAccount: %s，请先执行 'sa scan'", target)
+	}
+	return sa, nil
+}
+
+// updateCurrentSA 当前选中的 SA 为 namespace/name 时同步修改，使 sa info 立即反映变化
+func updateCurrentSA(sess *session.Session, namespace, name string, update func(*types.ServiceAccountRecord)) {
+	if cur := sess.GetCurrentSA(); cur != nil && cur.Namespace == namespace && cur.Name == name {
+		update(cur)
+	}
+}
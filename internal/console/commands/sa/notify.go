@@ -0,0 +1,90 @@
+package sa
+
+import (
+	"sort"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/notify"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// notifyScanFinished 设置了 notify-url 且本次扫描有 ADMIN / CRITICAL 结果时推送摘要
+// 统计按 SA 去重的扫描结果，以及扫描期间写入的 CRITICAL 检查发现（如云身份检查）
+func notifyScanFinished(sess *session.Session, scan *types.ScanRecord, results []SATokenResult) {
+	if sess.Config.NotifyURL == "" {
+		return
+	}
+	p := sess.Printer
+
+	summary := &notify.Summary{
+		ScanID:    scan.ID,
+		Target:    scan.Target,
+		Mode:      scan.Mode,
+		Partial:   scan.Partial,
+		StartedAt: scan.StartedAt,
+		Counts:    make(map[config.RiskLevel]int),
+	}
+	var top []notify.Item
+
+	seen := make(map[string]config.RiskLevel)
+	for _, r := range results {
+		key := r.Namespace + "/" + r.ServiceAccount
+		if prev, ok := seen[key]; ok && config.RiskLevelOrder[prev] <= config.RiskLevelOrder[r.RiskLevel] {
+			continue
+		}
+		seen[key] = r.RiskLevel
+	}
+	for key, level := range seen {
+		if level == config.RiskNone {
+			continue
+		}
+		summary.Counts[level]++
+		if level == config.RiskAdmin || level == config.RiskCritical {
+			top = append(top, notify.Item{Severity: level, Object: key, Title: "ServiceAccount " + notifyTitle(level)})
+		}
+	}
+
+	if sess.FindingDB != nil {
+		findings, err := sess.FindingDB.GetAll()
+		if err == nil {
+			for _, f := range findings {
+				if f.KubeletIP != sess.Config.KubeletIP || f.CollectedAt.Before(scan.StartedAt) ||
+					config.RiskLevel(f.Severity) != config.RiskCritical {
+					continue
+				}
+				summary.Counts[config.RiskCritical]++
+				top = append(top, notify.Item{Severity: config.RiskCritical, Object: f.Location, Title: f.Title})
+			}
+		}
+	}
+
+	if !summary.ShouldNotify() {
+		return
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].Severity != top[j].Severity {
+			return config.RiskLevelOrder[top[i].Severity] < config.RiskLevelOrder[top[j].Severity]
+		}
+		return top[i].Object < top[j].Object
+	})
+	if len(top) > notify.MaxTopItems {
+		top = top[:notify.MaxTopItems]
+	}
+	summary.Top = top
+
+	if err := notify.Send(sess.Context(), sess.Config.NotifyURL, summary); err != nil {
+		p.Warning(i18n.Tf("发送通知失败: %v", err))
+		return
+	}
+	p.Printf("%s Notification sent to %s\n", p.Colored(config.ColorBlue, "[*]"), sess.Config.NotifyURL)
+}
+
+// notifyTitle SA 结果的简短说明
+func notifyTitle(level config.RiskLevel) string {
+	if level == config.RiskAdmin {
+		return "has cluster-admin equivalent permissions"
+	}
+	return "has critical permissions or pod misconfigurations"
+}
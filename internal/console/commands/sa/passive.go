@@ -0,0 +1,159 @@
+package sa
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// AnalyzeOffline 对离线导入的 Pod 列表执行被动评估（import pods），source 记录为扫描目标
+func AnalyzeOffline(sess *session.Session, pods []types.PodContainerInfo, source string) error {
+	scan := newScanRecord(sess, types.ScanModePassive)
+	scan.Target = source
+	return (&ScanCmd{}).passiveScan(sess, pods, false, scan)
+}
+
+// passiveScan 只根据 Pod 规格和安全标识评估 SA 风险
+// 不读取 Token、不发送 SelfSubjectAccessReview，权限字段标记为未检查
+func (c *ScanCmd) passiveScan(sess *session.Session, pods []types.PodContainerInfo, onlyRisky bool, scan *types.ScanRecord) error {
+	p := sess.Printer
+
+	var results []SATokenResult
+	for _, pod := range pods {
+		if pod.Status != "Running" || pod.ServiceAccount == "" {
+			continue
+		}
+		result := SATokenResult{
+			Namespace:      pod.Namespace,
+			PodName:        pod.PodName,
+			ServiceAccount: pod.ServiceAccount,
+			SecurityFlags:  pod.SecurityFlags,
+			RiskLevel:      security.PassiveRiskLevel(pod.SecurityFlags),
+		}
+		if len(pod.Containers) > 0 {
+			result.Container = pod.Containers[0].Name
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		p.Warning(i18n.T("没有找到 Running 状态的 Pod"))
+		return nil
+	}
+	c.sortByRisk(results)
+
+	scan.Pods = len(results)
+	saved, skipped := c.savePassiveResults(sess, results, scan)
+	sess.MarkScanned()
+
+	var rows []output.ScanResultRow
+	for _, r := range results {
+		if onlyRisky && r.RiskLevel == config.RiskNone {
+			continue
+		}
+		rows = append(rows, output.ScanResultRow{
+			Risk:           formatRiskLabel(p, r.RiskLevel, false),
+			Namespace:      r.Namespace,
+			Pod:            r.PodName,
+			ServiceAccount: r.ServiceAccount,
+			TokenStatus:    p.Colored(config.ColorGray, "not read"),
+			Flags:          buildFlagsFromSecurityFlags(p, r.SecurityFlags, nil),
+			Permissions:    p.Colored(config.ColorGray, "not checked"),
+		})
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintScanResults(rows, true, false)
+
+	stats := c.calculateStats(results)
+	p.Println()
+	p.Printf("%s Passive scan complete: %d SAs from %d pods", p.Colored(config.ColorGreen, "[+]"), saved+skipped, len(results))
+	if stats.critical > 0 {
+		p.Printf(", %s CRITICAL", p.Colored(config.ColorRed, fmt.Sprintf("%d", stats.critical)))
+	}
+	if stats.high > 0 {
+		p.Printf(", %s HIGH", p.Colored(config.ColorYellow, fmt.Sprintf("%d", stats.high)))
+	}
+	p.Println()
+	if skipped > 0 {
+		p.Printf("%s %d SA(s) already have checked permissions, kept existing records\n",
+			p.Colored(config.ColorBlue, "[*]"), skipped)
+	}
+	p.Printf("%s Risk is derived from pod specs only; permissions were NOT checked and no tokens were read\n",
+		p.Colored(config.ColorYellow, "[!]"))
+	printScanRecorded(p, scan)
+	// 被动模式不访问 API Server，只使用 Pod 和已缓存的节点
+	printCloudPosture(sess, cloudSignals(sess.Context(), sess, pods, nil, false))
+	notifyScanFinished(sess, scan, results)
+	return nil
+}
+
+// savePassiveResults 按 SA 聚合并保存，已有完整扫描结果的 SA 不覆盖（快照中保留已有结果）
+func (c *ScanCmd) savePassiveResults(sess *session.Session, results []SATokenResult, scan *types.ScanRecord) (saved, skipped int) {
+	type aggregate struct {
+		record *types.ServiceAccountRecord
+		flags  types.SASecurityFlags
+		pods   []types.SAPodInfo
+		risk   config.RiskLevel
+	}
+	saMap := make(map[string]*aggregate)
+	var keys []string
+
+	for _, r := range results {
+		key := r.Namespace + "/" + r.ServiceAccount
+		agg, ok := saMap[key]
+		if !ok {
+			agg = &aggregate{
+				record: &types.ServiceAccountRecord{
+					Name:        r.ServiceAccount,
+					Namespace:   r.Namespace,
+					CollectedAt: time.Now(),
+					KubeletIP:   sess.Config.KubeletIP,
+				},
+				risk: config.RiskNone,
+			}
+			saMap[key] = agg
+			keys = append(keys, key)
+		}
+		agg.flags.Merge(r.SecurityFlags)
+		agg.pods = append(agg.pods, types.SAPodInfo{Name: r.PodName, Namespace: r.Namespace, Container: r.Container})
+		if config.RiskLevelOrder[r.RiskLevel] < config.RiskLevelOrder[agg.risk] {
+			agg.risk = r.RiskLevel
+		}
+	}
+	sort.Strings(keys)
+
+	var records, snapshot []*types.ServiceAccountRecord
+	for _, key := range keys {
+		agg := saMap[key]
+		if sess.SADB != nil {
+			if existing, err := sess.SADB.GetByName(agg.record.Namespace, agg.record.Name); err == nil && existing != nil && existing.PermissionsChecked() {
+				snapshot = append(snapshot, existing)
+				skipped++
+				continue
+			}
+		}
+		flagsJSON, _ := json.Marshal(agg.flags)
+		podsJSON, _ := json.Marshal(agg.pods)
+		agg.record.SecurityFlags = string(flagsJSON)
+		agg.record.Pods = string(podsJSON)
+		agg.record.RiskLevel = string(agg.risk)
+		records = append(records, agg.record)
+		snapshot = append(snapshot, agg.record)
+	}
+	recordScan(sess, scan, records, snapshot)
+
+	if sess.SADB == nil {
+		return len(records), skipped
+	}
+	saved, _ = sess.SADB.SaveBatch(records)
+	return saved, skipped
+}
@@ -0,0 +1,159 @@
+package sa
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// sampleOptions 抽样扫描选项
+type sampleOptions struct {
+	ratio    float64 // 每个 namespace/SA 分组的抽样比例，0 表示不按比例抽样
+	maxPerNs int     // 每个命名空间最多扫描的 Pod 数，0 表示不限制
+	seed     int64   // 随机种子，0 表示使用当前时间
+}
+
+// enabled 是否启用抽样
+func (o sampleOptions) enabled() bool {
+	return o.ratio > 0 || o.maxPerNs > 0
+}
+
+// describe 抽样规则描述
+func (o sampleOptions) describe() string {
+	var parts []string
+	if o.ratio > 0 {
+		parts = append(parts, fmt.Sprintf("%g%% per namespace/SA", o.ratio*100))
+	}
+	if o.maxPerNs > 0 {
+		parts = append(parts, fmt.Sprintf("max %d per namespace", o.maxPerNs))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseSampleArgs 解析抽样相关参数
+func parseSampleArgs(args []string) (sampleOptions, error) {
+	var opts sampleOptions
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--sample":
+			if i+1 >= len(args) {
+				return opts, i18n.Errorf("--sample 需要参数 (如 10%%)")
+			}
+			i++
+			ratio, err := parseSampleRatio(args[i])
+			if err != nil {
+				return opts, err
+			}
+			opts.ratio = ratio
+		case "--max-per-namespace":
+			if i+1 >= len(args) {
+				return opts, i18n.Errorf("--max-per-namespace 需要参数")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return opts, i18n.Errorf("无效的数量: %s (必须 >= 1)", args[i])
+			}
+			opts.maxPerNs = n
+		case "--seed":
+			if i+1 >= len(args) {
+				return opts, i18n.Errorf("--seed 需要参数")
+			}
+			i++
+			seed, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return opts, i18n.Errorf("无效的随机种子: %s", args[i])
+			}
+			opts.seed = seed
+		}
+	}
+	return opts, nil
+}
+
+// parseSampleRatio 解析抽样比例，支持 "10%" 和 "0.1" 两种写法
+func parseSampleRatio(s string) (float64, error) {
+	var ratio float64
+	var err error
+	if strings.HasSuffix(s, "%") {
+		ratio, err = strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		ratio /= 100
+	} else {
+		ratio, err = strconv.ParseFloat(s, 64)
+	}
+	if err != nil || ratio <= 0 || ratio > 1 {
+		return 0, i18n.Errorf("无效的抽样比例: %s (如 10%% 或 0.1)", s)
+	}
+	return ratio, nil
+}
+
+// samplePods 按 namespace/SA 分组随机抽样
+// 每个分组至少保留 1 个 Pod；限制每个命名空间数量时优先覆盖不同的 SA
+func samplePods(pods []types.PodContainerInfo, opts sampleOptions) []types.PodContainerInfo {
+	seed := opts.seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	// 按 namespace -> SA 分组（保持稳定顺序以便种子可复现）
+	groups := make(map[string]map[string][]types.PodContainerInfo)
+	for _, pod := range pods {
+		if groups[pod.Namespace] == nil {
+			groups[pod.Namespace] = make(map[string][]types.PodContainerInfo)
+		}
+		groups[pod.Namespace][pod.ServiceAccount] = append(groups[pod.Namespace][pod.ServiceAccount], pod)
+	}
+
+	var result []types.PodContainerInfo
+	for _, ns := range sortedKeys(groups) {
+		var perSA [][]types.PodContainerInfo
+		for _, sa := range sortedKeys(groups[ns]) {
+			group := groups[ns][sa]
+			rng.Shuffle(len(group), func(i, j int) { group[i], group[j] = group[j], group[i] })
+
+			if opts.ratio > 0 {
+				n := int(math.Ceil(float64(len(group)) * opts.ratio))
+				group = group[:max(n, 1)]
+			}
+			perSA = append(perSA, group)
+		}
+
+		// 轮询各 SA 分组，优先覆盖不同的 SA
+		var nsPods []types.PodContainerInfo
+		for round := 0; ; round++ {
+			added := false
+			for _, group := range perSA {
+				if round < len(group) {
+					nsPods = append(nsPods, group[round])
+					added = true
+				}
+			}
+			if !added {
+				break
+			}
+		}
+		if opts.maxPerNs > 0 && len(nsPods) > opts.maxPerNs {
+			nsPods = nsPods[:opts.maxPerNs]
+		}
+		result = append(result, nsPods...)
+	}
+
+	return result
+}
+
+// sortedKeys 返回排序后的 map 键
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
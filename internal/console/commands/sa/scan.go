@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -11,9 +12,12 @@ import (
 
 	"kctl/config"
 	k8sclient "kctl/internal/client/k8s"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
 	"kctl/internal/output"
 	"kctl/internal/rbac"
 	"kctl/internal/session"
+	"kctl/pkg/limiter"
 	"kctl/pkg/token"
 	"kctl/pkg/types"
 )
@@ -37,11 +41,39 @@ func (c *ScanCmd) Usage() string {
   --risky, -r     只显示有风险权限的 SA
   --perms, -p     显示完整权限列表
   --token, -t     显示 Token
+  --quiet, -q     不显示扫描进度
+  --passive       被动模式：只根据 Pod 规格和安全标识评估风险，不读取 Token、
+                  不调用 SelfSubjectAccessReview，权限字段标记为 "not checked"
+
+扫描结束后根据 API Server 地址、Token 签发者、节点标签和 Pod 特征识别 EKS / GKE / AKS，
+输出云身份检查（IRSA、GKE Workload Identity、AKS kubelet 身份、节点角色暴露），结果保存为 cloud 来源的发现
+
+抽样选项（适用于超大集群的快速排查，结果不完整）：
+  --sample <ratio>            按 namespace/SA 分组随机抽样 (如 10% 或 0.1，每组至少 1 个)
+  --max-per-namespace <n>     每个命名空间最多扫描 n 个 Pod（优先覆盖不同 SA）
+  --seed <n>                  随机种子，便于复现抽样结果
 
 示例：
   sa scan              扫描所有 SA
   sa scan --risky      只显示有风险的 SA
-  sa scan --perms      显示完整权限`
+  sa scan --perms      显示完整权限
+  sa scan --passive    不接触 SA Token 的被动扫描
+  sa scan --sample 10%
+  sa scan --max-per-namespace 5`
+}
+
+// Flags sa scan 的选项补全
+func (c *ScanCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--risky", Short: "-r", Description: "只显示有风险的 SA"},
+		{Name: "--perms", Short: "-p", Description: "显示权限"},
+		{Name: "--token", Short: "-t", Description: "显示 Token"},
+		{Name: "--quiet", Short: "-q", Description: "不显示扫描进度"},
+		{Name: "--passive", Description: "被动扫描（不读取 Token、不检查权限）"},
+		{Name: "--sample", Arg: "<ratio>", Description: "按 namespace/SA 抽样 (如 10%)"},
+		{Name: "--max-per-namespace", Arg: "<n>", Description: "每个命名空间最多扫描数"},
+		{Name: "--seed", Arg: "<n>", Description: "抽样随机种子"},
+	}
 }
 
 type SATokenResult struct {
@@ -56,48 +88,106 @@ type SATokenResult struct {
 	RiskLevel      config.RiskLevel
 	IsClusterAdmin bool
 	Error          string
+
+	kubeletFailed bool // Kubelet exec 请求本身失败（用于并发控制）
 }
 
 func (c *ScanCmd) Execute(sess *session.Session, args []string) error {
 	p := sess.Printer
-	ctx := context.Background()
+	ctx := sess.Context()
 
-	onlyRisky, showPerms, showToken := c.parseArgs(args)
+	onlyRisky, showPerms, showToken, quiet := c.parseArgs(args)
+	passive := slices.Contains(args, "--passive")
+	// 安全模式下不读取 Token（exec），改为被动扫描
+	if !passive && sess.IsSafeMode() {
+		passive = true
+		p.Printf("%s Safe mode: falling back to passive scan\n", p.Colored(config.ColorYellow, "[!]"))
+	}
+	sampling, err := parseSampleArgs(args)
+	if err != nil {
+		return err
+	}
 
 	kubelet, err := sess.GetKubeletClient()
 	if err != nil {
 		return err
 	}
 
-	p.Printf("%s Scanning ServiceAccount tokens...\n", p.Colored(config.ColorBlue, "[*]"))
+	mode := types.ScanModeActive
+	if passive {
+		mode = types.ScanModePassive
+	}
+	scan := newScanRecord(sess, mode)
+
+	if passive {
+		p.Printf("%s Passive scan: deriving risk from pod specs (no token reads, no SSAR)\n", p.Colored(config.ColorBlue, "[*]"))
+	} else {
+		p.Printf("%s Scanning ServiceAccount tokens...\n", p.Colored(config.ColorBlue, "[*]"))
+	}
 
 	pods, err := kubelet.GetPodsWithContainers(ctx)
 	if err != nil {
-		return fmt.Errorf("获取 Pod 列表失败: %w", err)
+		return i18n.Errorf("获取 Pod 列表失败: %w", err)
 	}
 	sess.CachePods(pods)
 
+	if passive {
+		return c.passiveScan(sess, pods, onlyRisky, scan)
+	}
+
 	targetPods := c.filterTargetPods(pods)
 	if len(targetPods) == 0 {
-		p.Warning("没有找到挂载 SA Token 的 Running Pod")
+		p.Warning(i18n.T("没有找到挂载 SA Token 的 Running Pod"))
 		return nil
 	}
 
 	p.Printf("%s Found %d pods with SA tokens\n", p.Colored(config.ColorBlue, "[*]"), len(targetPods))
-	p.Printf("%s Checking permissions... (%d concurrent)\n", p.Colored(config.ColorBlue, "[*]"), sess.Config.Concurrency)
 
-	allResults := c.scanConcurrently(ctx, sess, kubelet, targetPods)
+	totalPods := len(targetPods)
+	if sampling.enabled() {
+		targetPods = samplePods(targetPods, sampling)
+		p.Printf("%s SAMPLED: scanning %d/%d pods (%s), results are not exhaustive\n",
+			p.Colored(config.ColorYellow, "[!]"),
+			len(targetPods), totalPods, sampling.describe())
+	}
+	p.Printf("%s Checking permissions... (adaptive, max %d concurrent)\n", p.Colored(config.ColorBlue, "[*]"), sess.Config.Concurrency)
+	if pacing := sess.PacingSummary(); pacing != "" {
+		p.Printf("%s Pacing requests: %s\n", p.Colored(config.ColorBlue, "[*]"), pacing)
+	}
+	p.Printf("%s Press Ctrl+C to stop and show partial results\n", p.Colored(config.ColorGray, "[*]"))
+
+	progress := output.NewProgressPrinter(p, len(targetPods), "pods scanned").WithQuiet(quiet)
+	allResults := c.scanConcurrently(ctx, sess, kubelet, targetPods, progress)
+	progress.Finish()
+	if ctx.Err() != nil {
+		p.Println()
+		p.Warning(i18n.Tf("扫描已中断，显示部分结果 (%d/%d)", countCompleted(allResults), len(targetPods)))
+	}
 	c.sortByRisk(allResults)
 
-	savedCount := c.saveResults(sess, allResults)
+	scan.Pods = len(targetPods)
+	scan.Partial = sampling.enabled() || ctx.Err() != nil
+	if sampling.enabled() {
+		scan.Sampled = len(targetPods)
+	}
+	savedCount := c.saveResults(sess, allResults, scan)
 	sess.MarkScanned()
+	sess.LastScanSampled = sampling.enabled()
 
 	c.printResults(p, allResults, onlyRisky, showPerms, showToken, savedCount)
+	printScanRecorded(p, scan)
+	printCloudPosture(sess, cloudSignals(ctx, sess, pods, allResults, true))
+	notifyScanFinished(sess, scan, allResults)
+	if sampling.enabled() {
+		p.Printf("%s Results are SAMPLED (%d/%d pods), run 'sa scan' without sampling for full coverage\n",
+			p.Colored(config.ColorYellow, "[!]"),
+			len(targetPods), totalPods)
+	}
 
 	return nil
 }
 
-func (c *ScanCmd) parseArgs(args []string) (onlyRisky, showPerms, showToken bool) {
+func (c *ScanCmd) parseArgs(args []string) (onlyRisky, showPerms, showToken, quiet bool) {
 	for _, arg := range args {
 		switch arg {
 		case "--risky", "-r":
@@ -106,6 +196,8 @@ func (c *ScanCmd) parseArgs(args []string) (onlyRisky, showPerms, showToken bool
 			showPerms = true
 		case "--token", "-t":
 			showToken = true
+		case "--quiet", "-q":
+			quiet = true
 		}
 	}
 	return
@@ -123,18 +215,25 @@ func (c *ScanCmd) filterTargetPods(pods []types.PodContainerInfo) []types.PodCon
 
 func (c *ScanCmd) scanConcurrently(ctx context.Context, sess *session.Session, kubelet interface {
 	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
-}, pods []types.PodContainerInfo) []SATokenResult {
+}, pods []types.PodContainerInfo, progress *output.ProgressPrinter) []SATokenResult {
 	results := make(chan SATokenResult, len(pods))
 	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, sess.Config.Concurrency)
+	lim := limiter.NewAdaptive(sess.Config.Concurrency)
 
 	for _, pod := range pods {
 		wg.Add(1)
 		go func(pod types.PodContainerInfo) {
 			defer wg.Done()
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-			results <- c.scanPodToken(ctx, sess, kubelet, pod)
+			if err := lim.Acquire(ctx); err != nil {
+				return
+			}
+			result := c.scanPodToken(ctx, sess, kubelet, pod)
+			lim.Release(!result.kubeletFailed)
+			// 中断时丢弃未完成的结果
+			if ctx.Err() != nil && result.Error != "" {
+				return
+			}
+			results <- result
 		}(pod)
 	}
 
@@ -144,12 +243,28 @@ func (c *ScanCmd) scanConcurrently(ctx context.Context, sess *session.Session, k
 	}()
 
 	var allResults []SATokenResult
+	adminCount := 0
 	for result := range results {
 		allResults = append(allResults, result)
+		if result.IsClusterAdmin {
+			adminCount++
+		}
+		progress.Increment(fmt.Sprintf("%d ADMIN found", adminCount))
 	}
 	return allResults
 }
 
+// countCompleted 统计成功完成扫描的结果数
+func countCompleted(results []SATokenResult) int {
+	count := 0
+	for _, r := range results {
+		if r.Error == "" {
+			count++
+		}
+	}
+	return count
+}
+
 func (c *ScanCmd) scanPodToken(ctx context.Context, sess *session.Session, kubelet interface {
 	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
 }, pod types.PodContainerInfo) SATokenResult {
@@ -161,11 +276,17 @@ func (c *ScanCmd) scanPodToken(ctx context.Context, sess *session.Session, kubel
 	}
 
 	if len(pod.Containers) == 0 {
-		result.Error = "Pod 没有容器"
+		result.Error = i18n.T("Pod 没有容器")
 		return result
 	}
 	result.Container = pod.Containers[0].Name
 
+	// 会话工作池限制同时发往当前 Kubelet 的请求数，权限检查不占用槽位
+	workers, key := sess.Workers(), sess.CurrentKubeletKey()
+	if err := workers.Acquire(ctx, key); err != nil {
+		result.Error = i18n.Tf("exec 失败: %v", err)
+		return result
+	}
 	execResult, err := kubelet.Exec(ctx, &types.ExecOptions{
 		Namespace: pod.Namespace,
 		Pod:       pod.PodName,
@@ -174,24 +295,26 @@ func (c *ScanCmd) scanPodToken(ctx context.Context, sess *session.Session, kubel
 		Stdout:    true,
 		Stderr:    true,
 	})
+	workers.Release(key)
 	if err != nil {
-		result.Error = fmt.Sprintf("exec 失败: %v", err)
+		result.Error = i18n.Tf("exec 失败: %v", err)
+		result.kubeletFailed = true
 		return result
 	}
 	if execResult.Error != "" {
-		result.Error = fmt.Sprintf("读取 Token 失败: %s", execResult.Error)
+		result.Error = i18n.Tf("读取 Token 失败: %s", execResult.Error)
 		return result
 	}
 
 	result.Token = strings.TrimSpace(execResult.Stdout)
 	if result.Token == "" {
-		result.Error = "Token 为空"
+		result.Error = i18n.T("Token 为空")
 		return result
 	}
 
 	tokenInfo, err := token.Parse(result.Token)
 	if err != nil {
-		result.Error = fmt.Sprintf("解析 Token 失败: %v", err)
+		result.Error = i18n.Tf("解析 Token 失败: %v", err)
 		return result
 	}
 	result.TokenInfo = tokenInfo
@@ -199,13 +322,13 @@ func (c *ScanCmd) scanPodToken(ctx context.Context, sess *session.Session, kubel
 
 	k8s, err := sess.GetK8sClient(result.Token)
 	if err != nil {
-		result.Error = fmt.Sprintf("创建 K8s 客户端失败: %v", err)
+		result.Error = i18n.Tf("创建 K8s 客户端失败: %v", err)
 		return result
 	}
 
 	permissions, err := k8s.CheckCommonPermissions(ctx, tokenInfo.Namespace)
 	if err != nil {
-		result.Error = fmt.Sprintf("检查权限失败: %v", err)
+		result.Error = i18n.Tf("检查权限失败: %v", err)
 		return result
 	}
 	result.Permissions = permissions
@@ -229,7 +352,7 @@ func (c *ScanCmd) sortByRisk(results []SATokenResult) {
 	})
 }
 
-func (c *ScanCmd) saveResults(sess *session.Session, results []SATokenResult) int {
+func (c *ScanCmd) saveResults(sess *session.Session, results []SATokenResult, scan *types.ScanRecord) int {
 	saMap := make(map[string]*types.ServiceAccountRecord)
 
 	for _, result := range results {
@@ -250,6 +373,7 @@ func (c *ScanCmd) saveResults(sess *session.Session, results []SATokenResult) in
 	for _, record := range saMap {
 		records = append(records, record)
 	}
+	recordScan(sess, scan, records, records)
 
 	if sess.SADB != nil {
 		count, _ := sess.SADB.SaveBatch(records)
@@ -275,11 +399,7 @@ func (c *ScanCmd) mergeExistingRecord(existing *types.ServiceAccountRecord, resu
 	if err := json.Unmarshal([]byte(existing.SecurityFlags), &existingFlags); err != nil {
 		existingFlags = types.SASecurityFlags{}
 	}
-	existingFlags.Privileged = existingFlags.Privileged || result.SecurityFlags.Privileged
-	existingFlags.AllowPrivilegeEscalation = existingFlags.AllowPrivilegeEscalation || result.SecurityFlags.AllowPrivilegeEscalation
-	existingFlags.HasHostPath = existingFlags.HasHostPath || result.SecurityFlags.HasHostPath
-	existingFlags.HasSecretMount = existingFlags.HasSecretMount || result.SecurityFlags.HasSecretMount
-	existingFlags.HasSATokenMount = existingFlags.HasSATokenMount || result.SecurityFlags.HasSATokenMount
+	existingFlags.Merge(result.SecurityFlags)
 	flagsJSON, _ := json.Marshal(existingFlags)
 	existing.SecurityFlags = string(flagsJSON)
 }
@@ -320,13 +440,9 @@ func (c *ScanCmd) createNewRecord(sess *session.Session, result SATokenResult) *
 	permJSON, _ := json.Marshal(permissions)
 	record.Permissions = string(permJSON)
 
-	secFlagsJSON, _ := json.Marshal(types.SASecurityFlags{
-		Privileged:               result.SecurityFlags.Privileged,
-		AllowPrivilegeEscalation: result.SecurityFlags.AllowPrivilegeEscalation,
-		HasHostPath:              result.SecurityFlags.HasHostPath,
-		HasSecretMount:           result.SecurityFlags.HasSecretMount,
-		HasSATokenMount:          result.SecurityFlags.HasSATokenMount,
-	})
+	var secFlags types.SASecurityFlags
+	secFlags.Merge(result.SecurityFlags)
+	secFlagsJSON, _ := json.Marshal(secFlags)
 	record.SecurityFlags = string(secFlagsJSON)
 
 	podsJSON, _ := json.Marshal([]types.SAPodInfo{{
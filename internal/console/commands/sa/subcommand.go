@@ -1,6 +1,9 @@
 package sa
 
 import (
+	"sort"
+
+	"kctl/internal/console/completion"
 	"kctl/internal/session"
 )
 
@@ -72,11 +75,96 @@ ServiceAccount 相关操作
   scan        扫描所有 Pod 的 SA Token 权限
   use         选择 SA 作为当前身份
   info        显示当前 SA 详情
+  kubeconfig  生成 SA 的 kubeconfig
+  note        设置 SA 的备注
+  tag         为 SA 添加或移除标签（如 compromised）
+  history     列出历次 sa scan 记录（用 diff 比较）
 
 示例：
   sa                    列出所有 SA (等同于 sa list)
   sa list --risky       只显示有风险的 SA
   sa scan               扫描所有 SA
   sa use kube-system/default
-  sa info`
+  sa info
+  sa tag kube-system/default compromised
+  sa note kube-system/default "已获取 Token"
+  sa kubeconfig default/nginx --out nginx.kubeconfig`
+}
+
+// IsReadOnly 判断 sa 子命令是否只读（scan 会访问集群并写入数据库，note / tag 带内容时写入数据库）
+func IsReadOnly(args []string) bool {
+	if len(args) == 0 {
+		return true
+	}
+	cmd, ok := Get(args[0])
+	if !ok {
+		return true
+	}
+	switch cmd.Name() {
+	case "scan":
+		return false
+	case "note", "tag":
+		return len(args) <= 2
+	}
+	return true
+}
+
+// IsPaged 判断 sa 子命令的输出是否分页显示（列表和详情类子命令）
+func IsPaged(args []string) bool {
+	cmd, _ := resolve(args)
+	if cmd == nil {
+		return false
+	}
+	switch cmd.Name() {
+	case "list", "history", "info":
+		return true
+	}
+	return false
+}
+
+// completable 提供选项补全数据的子命令
+type completable interface {
+	Flags(args []string) []completion.Flag
+}
+
+// suggester 提供位置参数补全的子命令
+type suggester interface {
+	Suggestions(sess *session.Session, args []string) []completion.Suggestion
+}
+
+// Flags 返回子命令的选项补全，第一个参数不是子命令时按 list 处理
+func Flags(args []string) []completion.Flag {
+	cmd, rest := resolve(args)
+	if c, ok := cmd.(completable); ok {
+		return c.Flags(rest)
+	}
+	return nil
+}
+
+// Suggestions 返回子命令名或子命令的位置参数补全
+func Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) == 0 {
+		var suggestions []completion.Suggestion
+		for _, cmd := range GetAll() {
+			suggestions = append(suggestions, completion.Suggestion{Text: cmd.Name(), Description: cmd.Description()})
+		}
+		sort.Slice(suggestions, func(i, j int) bool { return suggestions[i].Text < suggestions[j].Text })
+		return suggestions
+	}
+	cmd, rest := resolve(args)
+	if s, ok := cmd.(suggester); ok && len(rest) < len(args) {
+		return s.Suggestions(sess, rest)
+	}
+	return nil
+}
+
+// resolve 与 Execute 相同的子命令解析：第一个参数不是子命令时为 list
+func resolve(args []string) (SubCommand, []string) {
+	if len(args) > 0 {
+		if cmd, ok := Get(args[0]); ok {
+			return cmd, args[1:]
+		}
+	}
+	cmd, _ := Get("list")
+	return cmd, args
 }
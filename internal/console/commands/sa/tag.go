@@ -0,0 +1,119 @@
+package sa
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// TagCmd tag 子命令
+type TagCmd struct{}
+
+func init() {
+	Register(&TagCmd{})
+}
+
+func (c *TagCmd) Name() string        { return "tag" }
+func (c *TagCmd) Aliases() []string   { return nil }
+func (c *TagCmd) Description() string { return "为 SA 添加或移除标签" }
+
+func (c *TagCmd) Usage() string {
+	return `sa tag <namespace/name> [tag...] [--remove <tag>...]
+
+为 ServiceAccount 添加标签，跟踪已利用或已排查的 SA，保存在数据库中，重新扫描不会丢失
+标签显示在 sa list、sa info 和 export 中，可用 sa list --tag <tag> 或 --where 'tags~"compromised"' 筛选
+
+常用标签：
+  compromised     已利用（已获取并使用 Token）
+  triaged         已排查
+  ignore          确认无价值
+
+选项：
+  --remove, -d    移除后续列出的标签
+
+示例：
+  sa tag kube-system/cluster-admin compromised
+  sa tag default/nginx triaged ignore
+  sa tag default/nginx --remove ignore
+  sa tag default/nginx                      显示标签`
+}
+
+// commonTags 常用标签补全
+var commonTags = []completion.Suggestion{
+	{Text: "compromised", Description: "已利用"},
+	{Text: "triaged", Description: "已排查"},
+	{Text: "ignore", Description: "确认无价值"},
+}
+
+// Flags sa tag 的选项补全
+func (c *TagCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--remove", Short: "-d", Description: "移除标签"},
+	}
+}
+
+// Suggestions sa tag 的 SA 及常用标签补全
+func (c *TagCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) == 0 {
+		return completion.ServiceAccounts(sess, args)
+	}
+	return commonTags
+}
+
+func (c *TagCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	if len(args) == 0 {
+		return i18n.Errorf("用法: sa tag <namespace/name> [tag...] [--remove <tag>...]")
+	}
+	sa, err := lookupSA(sess, args[0])
+	if err != nil {
+		return err
+	}
+
+	tags := sa.TagList()
+	if len(args) == 1 {
+		if len(tags) == 0 {
+			p.Info(i18n.Tf("%s/%s 没有标签", sa.Namespace, sa.Name))
+			return nil
+		}
+		p.Printf("%s %s/%s: %s\n", p.Colored(config.ColorBlue, "[*]"), sa.Namespace, sa.Name, strings.Join(tags, ", "))
+		return nil
+	}
+
+	remove := false
+	for _, arg := range args[1:] {
+		if arg == "--remove" || arg == "-d" {
+			remove = true
+			continue
+		}
+		tag := strings.ToLower(strings.TrimSpace(arg))
+		if tag == "" || strings.Contains(tag, ",") {
+			return i18n.Errorf("无效的标签: %q", arg)
+		}
+		if remove {
+			tags = slices.DeleteFunc(tags, func(t string) bool { return t == tag })
+		} else if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	if err := sess.SADB.SetTags(sa.Namespace, sa.Name, tags); err != nil {
+		return err
+	}
+	joined := strings.Join(tags, ",")
+	updateCurrentSA(sess, sa.Namespace, sa.Name, func(cur *types.ServiceAccountRecord) { cur.Tags = joined })
+
+	if len(tags) == 0 {
+		p.Success(fmt.Sprintf("Cleared tags for %s/%s", sa.Namespace, sa.Name))
+	} else {
+		p.Success(fmt.Sprintf("Tags for %s/%s: %s", sa.Namespace, sa.Name, strings.Join(tags, ", ")))
+	}
+	return nil
+}
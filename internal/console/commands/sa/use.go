@@ -2,10 +2,14 @@ package sa
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/console/picker"
+	"kctl/internal/i18n"
 	"kctl/internal/session"
 	"kctl/pkg/types"
 )
@@ -30,9 +34,10 @@ func (c *UseCmd) Description() string {
 }
 
 func (c *UseCmd) Usage() string {
-	return `sa use <namespace/name>
+	return `sa use [namespace/name]
 
 选择一个 ServiceAccount 作为当前操作目标
+不带参数时在控制台中弹出可过滤的 SA 列表（输入过滤，Enter 确认，Esc 取消）
 
 选择后：
   - 提示符会显示当前 SA 和风险等级
@@ -43,19 +48,37 @@ func (c *UseCmd) Usage() string {
   sa use default/nginx`
 }
 
+// Suggestions sa use 的 SA 补全
+func (c *UseCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	if len(args) > 0 {
+		return nil
+	}
+	return completion.ServiceAccounts(sess, args)
+}
+
 func (c *UseCmd) Execute(sess *session.Session, args []string) error {
 	p := sess.Printer
 
 	if len(args) == 0 {
-		// 没有参数时，列出可用的 SA
-		return c.listAvailableSAs(sess)
+		// 没有参数时，交互式控制台中弹出选择器，否则列出可用的 SA
+		if !sess.Interactive {
+			return c.listAvailableSAs(sess)
+		}
+		target, err := c.pickSA(sess)
+		if errors.Is(err, picker.ErrCancelled) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		args = []string{target}
 	}
 
 	// 解析 namespace/name
 	target := args[0]
 	parts := strings.SplitN(target, "/", 2)
 	if len(parts) != 2 {
-		return fmt.Errorf("格式错误，请使用 namespace/sa-name 格式")
+		return i18n.Errorf("格式错误，请使用 namespace/sa-name 格式")
 	}
 
 	namespace := parts[0]
@@ -64,12 +87,12 @@ func (c *UseCmd) Execute(sess *session.Session, args []string) error {
 	// 从数据库查找
 	sa, err := sess.SADB.GetByName(namespace, name)
 	if err != nil {
-		return fmt.Errorf("查找 ServiceAccount 失败: %w", err)
+		return i18n.Errorf("查找 ServiceAccount 失败: %w", err)
 	}
 
 	if sa == nil {
 		// 未找到，显示可用的 SA
-		p.Error(fmt.Sprintf("未找到 ServiceAccount: %s/%s", namespace, name))
+		p.Error(i18n.Tf("未找到 ServiceAccount: %s/%s", namespace, name))
 		p.Println()
 		return c.listAvailableSAs(sess)
 	}
@@ -95,6 +118,11 @@ func (c *UseCmd) Execute(sess *session.Session, args []string) error {
 			p.Colored(display.Color, display.Label))
 	}
 
+	// 被动扫描的记录没有 Token，API 请求仍使用 Kubelet Token
+	if sa.Token == "" {
+		p.Warning(i18n.T("该 SA 来自被动扫描，没有 Token，API 请求将使用当前 Kubelet Token"))
+	}
+
 	// 显示关联的 Pod
 	if sa.Pods != "" && sa.Pods != "[]" {
 		p.Printf("%s Associated Pods: %s\n",
@@ -111,14 +139,14 @@ func (c *UseCmd) listAvailableSAs(sess *session.Session) error {
 
 	sas, err := sess.SADB.GetAll()
 	if err != nil {
-		return fmt.Errorf("获取 ServiceAccount 列表失败: %w", err)
+		return i18n.Errorf("获取 ServiceAccount 列表失败: %w", err)
 	}
 
 	if len(sas) == 0 {
-		return fmt.Errorf("没有可用的 ServiceAccount，请先执行 'sa scan'")
+		return i18n.Errorf("没有可用的 ServiceAccount，请先执行 'sa scan'")
 	}
 
-	p.Printf("  %s\n\n", p.Colored(config.ColorYellow, "可用的 ServiceAccount:"))
+	p.Printf("  %s\n\n", p.Colored(config.ColorYellow, i18n.T("可用的 ServiceAccount:")))
 
 	for _, sa := range sas {
 		// 风险等级
@@ -136,11 +164,37 @@ func (c *UseCmd) listAvailableSAs(sess *session.Session) error {
 	}
 
 	p.Println()
-	p.Printf("  用法: %s\n\n", p.Colored(config.ColorCyan, "sa use <namespace/sa-name>"))
+	p.Printf("  %s%s\n\n", i18n.T("用法: "), p.Colored(config.ColorCyan, "sa use <namespace/sa-name>"))
 
 	return nil
 }
 
+// pickSA 交互式选择 ServiceAccount
+func (c *UseCmd) pickSA(sess *session.Session) (string, error) {
+	p := sess.Printer
+
+	sas, err := sess.SADB.GetAll()
+	if err != nil {
+		return "", i18n.Errorf("获取 ServiceAccount 列表失败: %w", err)
+	}
+	if len(sas) == 0 {
+		return "", i18n.Errorf("没有可用的 ServiceAccount，请先执行 'sa scan'")
+	}
+
+	items := make([]picker.Item, len(sas))
+	for i, sa := range sas {
+		risk := sa.RiskLevel
+		if sa.IsClusterAdmin {
+			risk = "ADMIN"
+		}
+		items[i] = picker.Item{
+			Text:        fmt.Sprintf("%s/%s", sa.Namespace, sa.Name),
+			Description: fmt.Sprintf("%-8s %s", risk, c.formatPods(sa.Pods)),
+		}
+	}
+	return picker.Pick(p.Colored(config.ColorYellow, "[?]")+" Select a service account", items)
+}
+
 func (c *UseCmd) formatPods(podsJSON string) string {
 	if podsJSON == "" || podsJSON == "[]" {
 		return "-"
@@ -0,0 +1,321 @@
+package commands
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// ServicesCmd services 命令
+type ServicesCmd struct{}
+
+func init() {
+	Register(&ServicesCmd{})
+}
+
+func (c *ServicesCmd) Name() string {
+	return "services"
+}
+
+func (c *ServicesCmd) Aliases() []string {
+	return []string{"svc"}
+}
+
+func (c *ServicesCmd) Description() string {
+	return "列出 Service / Endpoints，标出值得关注的目标"
+}
+
+// IsPaged services 输出 Service 表格
+func (c *ServicesCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *ServicesCmd) Usage() string {
+	return `services [options]
+
+通过 API Server 列出 Service 及其 Endpoints（需要 list services / endpoints 权限），
+标出 kubernetes-dashboard、tiller、argocd、prometheus、数据库等横向移动目标，
+并给出可直接使用的 portforward 命令（转发到后端 Pod 的 targetPort）
+
+无法访问 API Server 或使用 --from 时，在 Pod 内执行发现脚本：
+  - 读取 Kubernetes 注入的 <NAME>_SERVICE_HOST / <NAME>_PORT_* 环境变量（同命名空间的 Service）
+  - 用 getent hosts 解析常见管理界面的默认 Service 名称（跨命名空间）
+
+选项：
+  -n <namespace>      只列出指定命名空间
+  --interesting       只显示值得关注的目标
+  --from <pod>        在指定 Pod 内执行发现脚本（默认使用当前 SA 的 Pod）
+
+示例：
+  services
+  svc --interesting
+  svc -n monitoring
+  svc --from default/nginx`
+}
+
+// Flags services 的选项补全
+func (c *ServicesCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		flagNamespace,
+		{Name: "--interesting", Description: "只显示值得关注的目标"},
+		{Name: "--from", Arg: "<pod>", Description: "在指定 Pod 内执行发现脚本"},
+	}
+}
+
+func (c *ServicesCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	namespace, from := "", ""
+	interesting := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-n":
+			if i+1 < len(args) {
+				namespace = args[i+1]
+				i++
+			}
+		case "--from":
+			if i+1 >= len(args) {
+				return i18n.Errorf("--from 需要指定 Pod")
+			}
+			from = args[i+1]
+			i++
+		case "--interesting":
+			interesting = true
+		default:
+			return i18n.Errorf("未知参数: %s", args[i])
+		}
+	}
+
+	var services []types.ServiceInfo
+	var err error
+	source := "apiserver"
+	if from == "" && sess.Config.APIServer != "" {
+		services, err = c.listFromAPI(sess, namespace)
+		if err != nil {
+			p.Warning(i18n.Tf("API Server 获取 Service 失败，改为在 Pod 内发现: %v", err))
+		}
+	}
+	if services == nil {
+		source = "env/dns"
+		if services, err = c.listFromPod(sess, from); err != nil {
+			return err
+		}
+		if namespace != "" {
+			var filtered []types.ServiceInfo
+			for _, svc := range services {
+				if svc.Namespace == namespace {
+					filtered = append(filtered, svc)
+				}
+			}
+			services = filtered
+		}
+	}
+	if len(services) == 0 {
+		p.Info("No services found")
+		return nil
+	}
+
+	type entry struct {
+		svc    types.ServiceInfo
+		target *config.InterestingService
+	}
+	var list []entry
+	for _, svc := range services {
+		target := security.ClassifyService(svc)
+		if interesting && target == nil {
+			continue
+		}
+		list = append(list, entry{svc: svc, target: target})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if (a.target != nil) != (b.target != nil) {
+			return a.target != nil
+		}
+		if a.target != nil && a.target.Level != b.target.Level {
+			return config.RiskLevelOrder[a.target.Level] < config.RiskLevelOrder[b.target.Level]
+		}
+		if a.svc.Namespace != b.svc.Namespace {
+			return a.svc.Namespace < b.svc.Namespace
+		}
+		return a.svc.Name < b.svc.Name
+	})
+
+	var rows [][]string
+	targets := 0
+	for _, e := range list {
+		label := "-"
+		if e.target != nil {
+			targets++
+			label = p.Formatter().FormatRiskLevelColored(e.target.Level) + " " + e.target.Label
+		}
+		rows = append(rows, []string{
+			e.svc.Namespace,
+			e.svc.Name,
+			orDash(e.svc.Type),
+			c.formatAddress(e.svc),
+			c.formatPorts(e.svc.Ports),
+			c.formatBackends(e.svc.Endpoints),
+			label,
+		})
+	}
+
+	if len(rows) > 0 {
+		p.Println()
+		output.NewTablePrinter().PrintSimple([]string{"NAMESPACE", "NAME", "TYPE", "CLUSTER-IP", "PORTS", "BACKEND", "TARGET"}, rows)
+	}
+
+	hints := false
+	for _, e := range list {
+		if e.target == nil {
+			continue
+		}
+		if !hints {
+			p.Println()
+			hints = true
+		}
+		p.Printf("  %s %s/%s: %s\n", p.Colored(config.ColorYellow, "[!]"), e.svc.Namespace, e.svc.Name,
+			p.Colored(config.ColorGray, e.target.Note))
+		if cmd := c.portForwardHint(e.svc); cmd != "" {
+			p.Printf("      %s\n", cmd)
+		}
+	}
+
+	p.Println()
+	p.Printf("%s %d service(s), %d interesting target(s) (source: %s)\n",
+		p.Colored(config.ColorGreen, "[+]"), len(services), targets, source)
+	return nil
+}
+
+// listFromAPI 通过 API Server 列出 Service，并关联 Endpoints 中的后端 Pod
+func (c *ServicesCmd) listFromAPI(sess *session.Session, namespace string) ([]types.ServiceInfo, error) {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+	if err != nil {
+		return nil, err
+	}
+	p.Printf("%s Listing services...\n", p.Colored(config.ColorBlue, "[*]"))
+	services, err := k8s.ListServices(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if services == nil {
+		services = []types.ServiceInfo{}
+	}
+
+	endpoints, err := k8s.ListEndpoints(ctx, namespace)
+	if err != nil {
+		p.Warning(i18n.Tf("获取 Endpoints 失败，不显示后端 Pod: %v", err))
+		return services, nil
+	}
+	for i := range services {
+		services[i].Endpoints = endpoints[services[i].Namespace+"/"+services[i].Name]
+	}
+	return services, nil
+}
+
+// listFromPod 在 Pod 内执行发现脚本，从环境变量和 DNS 推导 Service
+func (c *ServicesCmd) listFromPod(sess *session.Session, podName string) ([]types.ServiceInfo, error) {
+	p := sess.Printer
+
+	ref, err := resolveWorkPod(sess, podName)
+	if err != nil {
+		return nil, err
+	}
+
+	executor, err := sess.GetExecTransport()
+	if err != nil {
+		return nil, err
+	}
+	p.Printf("%s Discovering services from %s/%s (env + DNS)...\n", p.Colored(config.ColorBlue, "[*]"), ref.Namespace, ref.Pod)
+	result, err := executor.Exec(sess.Context(), &types.ExecOptions{
+		Namespace: ref.Namespace,
+		Pod:       ref.Pod,
+		Container: ref.Container,
+		Command:   []string{"sh", "-c", security.ServiceDiscoveryScript()},
+		Stdout:    true,
+		Stderr:    true,
+	})
+	if err != nil {
+		return nil, i18n.Errorf("执行发现脚本失败: %w", err)
+	}
+	if result.Error != "" && result.Stdout == "" {
+		return nil, i18n.Errorf("执行发现脚本失败: %s", result.Error)
+	}
+	return security.ParseServiceDiscovery(result.Stdout, ref.Namespace), nil
+}
+
+// formatAddress 格式化 ClusterIP 及外部地址
+func (c *ServicesCmd) formatAddress(svc types.ServiceInfo) string {
+	addr := svc.ClusterIP
+	if svc.Type == "ExternalName" {
+		addr = svc.ExternalName
+	}
+	if len(svc.ExternalIPs) > 0 {
+		addr += " (" + strings.Join(svc.ExternalIPs, ",") + ")"
+	}
+	return orDash(addr)
+}
+
+// formatPorts 格式化端口，如 443->8443/TCP,80:30080/TCP
+func (c *ServicesCmd) formatPorts(ports []types.ServicePort) string {
+	var parts []string
+	for _, port := range ports {
+		s := strconv.Itoa(port.Port)
+		if port.TargetPort != "" && port.TargetPort != s {
+			s += "->" + port.TargetPort
+		}
+		if port.NodePort != 0 {
+			s += ":" + strconv.Itoa(port.NodePort)
+		}
+		if port.Protocol != "" {
+			s += "/" + strings.ToUpper(port.Protocol)
+		}
+		parts = append(parts, s)
+	}
+	return orDash(strings.Join(parts, ","))
+}
+
+// formatBackends 格式化后端 Pod，如 dashboard-7b5f (+2)
+func (c *ServicesCmd) formatBackends(endpoints []types.ServiceEndpoint) string {
+	if len(endpoints) == 0 {
+		return "-"
+	}
+	first := endpoints[0].Pod
+	if first == "" {
+		first = endpoints[0].IP
+	}
+	if len(endpoints) > 1 {
+		first += " (+" + strconv.Itoa(len(endpoints)-1) + ")"
+	}
+	return first
+}
+
+// portForwardHint 返回转发到第一个后端 Pod 的 portforward 命令（targetPort 为端口名时无法推断，跳过）
+func (c *ServicesCmd) portForwardHint(svc types.ServiceInfo) string {
+	if len(svc.Endpoints) == 0 || svc.Endpoints[0].Pod == "" {
+		return ""
+	}
+	var mappings []string
+	for _, port := range svc.Ports {
+		if _, err := strconv.Atoi(port.TargetPort); err != nil || strings.EqualFold(port.Protocol, "UDP") {
+			continue
+		}
+		mappings = append(mappings, port.TargetPort+":"+port.TargetPort)
+	}
+	if len(mappings) == 0 {
+		return ""
+	}
+	return "portforward -n " + svc.Namespace + " " + svc.Endpoints[0].Pod + " " + strings.Join(mappings, " ")
+}
@@ -1,12 +1,23 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"kctl/config"
+	"kctl/internal/client"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
 	"kctl/internal/output"
 	"kctl/internal/session"
+	"kctl/internal/transport"
+	"kctl/pkg/types"
 )
 
 // ShowCmd show 命令
@@ -28,8 +39,18 @@ func (c *ShowCmd) Description() string {
 	return "显示配置或状态信息"
 }
 
+// IsReadOnly show 只显示会话信息，transports 会在 Pod 中执行探测命令
+func (c *ShowCmd) IsReadOnly(args []string) bool {
+	return len(args) == 0 || (args[0] != "transports" && args[0] != "transport")
+}
+
+// IsPaged show 输出会话信息和列表
+func (c *ShowCmd) IsPaged(args []string) bool {
+	return c.IsReadOnly(args)
+}
+
 func (c *ShowCmd) Usage() string {
-	return `show <what>
+	return `show <what> [--absolute]
 
 显示配置或状态信息
 
@@ -38,35 +59,67 @@ func (c *ShowCmd) Usage() string {
   status     显示会话状态
   env        显示环境信息
   kubelets   显示发现的 Kubelet 节点
+  transports 探测各执行通道（见 set exec-via）在当前目标上是否可用
+             --pod <ns/name> 指定探测使用的 Pod（默认: 缓存中第一个 Running 的 Pod）
+
+--absolute 显示绝对时间（默认相对时间，见 set time-format / set timezone）
 
 示例：
   show options
   show status
-  show kubelets`
+  show status --absolute
+  show kubelets
+  show transports --pod kube-system/kube-proxy-x7k2p`
+}
+
+// Flags show 的选项补全
+func (c *ShowCmd) Flags(args []string) []completion.Flag {
+	if len(args) == 0 {
+		return nil
+	}
+	if args[0] == "transports" {
+		return []completion.Flag{{Name: "--pod", Arg: "<ns/name>", Description: "探测使用的 Pod", Values: completion.QualifiedPods}}
+	}
+	return []completion.Flag{flagAbsolute}
+}
+
+// Suggestions show 的子命令补全
+func (c *ShowCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return subcommandSuggestions(args,
+		completion.Suggestion{Text: "options", Description: "显示当前配置"},
+		completion.Suggestion{Text: "status", Description: "显示会话状态"},
+		completion.Suggestion{Text: "env", Description: "显示环境信息"},
+		completion.Suggestion{Text: "kubelets", Description: "显示发现的 Kubelet 节点"},
+		completion.Suggestion{Text: "transports", Description: "探测各执行通道是否可用"},
+	)
 }
 
 func (c *ShowCmd) Execute(sess *session.Session, args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("用法: show <options|status|env|kubelets>")
+		return i18n.Errorf("用法: show <options|status|env|kubelets|transports>")
 	}
 
 	what := args[0]
+	tf := sess.TimeFormatter(slices.Contains(args[1:], "--absolute"))
 
 	switch what {
 	case "options", "opts", "config":
 		c.showOptions(sess)
 
 	case "status", "stat":
-		c.showStatus(sess)
+		c.showStatus(sess, tf)
 
 	case "env":
 		c.showEnv(sess)
 
 	case "kubelets", "kubelet", "nodes":
-		c.showKubelets(sess)
+		c.showKubelets(sess, tf)
+
+	case "transports", "transport":
+		return c.showTransports(sess, args[1:])
 
 	default:
-		return fmt.Errorf("未知选项: %s (可用: options, status, env, kubelets)", what)
+		return i18n.Errorf("未知选项: %s (可用: options, status, env, kubelets, transports)", what)
 	}
 
 	return nil
@@ -79,6 +132,11 @@ func (c *ShowCmd) showOptions(sess *session.Session) {
 	p.Printf("  %s\n", p.Colored(config.ColorCyan, "Configuration"))
 	p.Println("  " + p.Colored(config.ColorGray, "─────────────────────────────────────────"))
 
+	// Profile
+	if sess.Config.Profile != "" {
+		p.Printf("  %-16s: %s\n", "Profile", sess.Config.Profile)
+	}
+
 	// Kubelet IP
 	kubeletIP := sess.Config.KubeletIP
 	if kubeletIP == "" {
@@ -102,6 +160,21 @@ func (c *ShowCmd) showOptions(sess *session.Session) {
 	}
 	p.Printf("  %-16s: %s\n", "Token", tokenStatus)
 
+	// Client Certificate
+	certStatus := p.Colored(config.ColorGray, "(not set)")
+	if sess.Config.ClientCert != "" {
+		certStatus = sess.Config.ClientCertFile
+		if sess.Config.ClientKey == "" {
+			certStatus += p.Colored(config.ColorYellow, " (missing key)")
+		}
+	}
+	p.Printf("  %-16s: %s\n", "Client Cert", certStatus)
+
+	// Kubeconfig
+	if sess.Config.Kubeconfig != "" {
+		p.Printf("  %-16s: %s (context: %s)\n", "Kubeconfig", sess.Config.Kubeconfig, sess.Config.KubeContext)
+	}
+
 	// API Server
 	apiServer := sess.Config.APIServer
 	if apiServer == "" {
@@ -110,19 +183,171 @@ func (c *ShowCmd) showOptions(sess *session.Session) {
 	p.Printf("  %-16s: %s:%d\n", "API Server", apiServer, sess.Config.APIServerPort)
 
 	// Proxy
-	proxy := sess.Config.ProxyURL
+	proxy := client.RedactProxyURL(sess.Config.ProxyURL)
 	if proxy == "" {
 		proxy = p.Colored(config.ColorGray, "(none)")
 	}
 	p.Printf("  %-16s: %s\n", "Proxy", proxy)
 
+	// Tunnel
+	tunnel := p.Colored(config.ColorGray, "(none)")
+	if t := sess.Tunnel(); t != nil {
+		state := "idle"
+		if t.Connected() {
+			state = "connected"
+		}
+		tunnel = client.RedactProxyURL(sess.Config.TunnelURL) + p.Colored(config.ColorGray, " ("+state+")")
+	}
+	p.Printf("  %-16s: %s\n", "SSH Tunnel", tunnel)
+
+	// TLS 验证
+	tlsVerify := p.Colored(config.ColorGray, "(off)")
+	if summary := sess.TLSVerifySummary(); summary != "" {
+		tlsVerify = "on" + p.Colored(config.ColorGray, " ("+summary+")")
+	}
+	p.Printf("  %-16s: %s\n", "TLS Verify", tlsVerify)
+
+	// User-Agent / Headers
+	userAgent := sess.Config.UserAgent
+	if userAgent == "" {
+		userAgent = p.Colored(config.ColorGray, "(Go default)")
+	}
+	p.Printf("  %-16s: %s\n", "User-Agent", userAgent)
+	headers := p.Colored(config.ColorGray, "(none)")
+	if len(sess.Config.Headers) > 0 {
+		var parts []string
+		for _, name := range slices.Sorted(maps.Keys(sess.Config.Headers)) {
+			parts = append(parts, name+": "+sess.Config.Headers[name])
+		}
+		headers = strings.Join(parts, ", ")
+	}
+	p.Printf("  %-16s: %s\n", "Headers", headers)
+
+	// Impersonation
+	impersonate := p.Colored(config.ColorGray, "(none)")
+	if sess.Config.ImpersonateUser != "" {
+		impersonate = p.Colored(config.ColorYellow, formatImpersonation(sess.Config.ImpersonateUser, sess.Config.ImpersonateGroups))
+	}
+	p.Printf("  %-16s: %s\n", "Impersonate", impersonate)
+
+	// Raw Dump
+	rawDump := sess.Config.RawDumpDir
+	if rawDump == "" {
+		rawDump = p.Colored(config.ColorGray, "(off)")
+	}
+	p.Printf("  %-16s: %s\n", "Raw Dump", rawDump)
+
 	// Concurrency
 	p.Printf("  %-16s: %d\n", "Concurrency", sess.Config.Concurrency)
+	workers, perTarget := sess.WorkerLimits()
+	p.Printf("  %-16s: %d (%d per kubelet)\n", "Workers", workers, perTarget)
+
+	// Rate Limit / Jitter
+	rateLimit := client.FormatRate(sess.Config.RateLimit)
+	if rateLimit == "" {
+		rateLimit = p.Colored(config.ColorGray, "(off)")
+	}
+	p.Printf("  %-16s: %s\n", "Rate Limit", rateLimit)
+	jitter := client.FormatJitter(sess.Config.JitterMin, sess.Config.JitterMax)
+	if jitter == "" {
+		jitter = p.Colored(config.ColorGray, "(off)")
+	}
+	p.Printf("  %-16s: %s\n", "Jitter", jitter)
+
+	// Timeouts / Retries
+	clientCfg := sess.GetClientConfig()
+	p.Printf("  %-16s: %s (connect %s)\n", "Timeout", clientCfg.Timeout, clientCfg.ConnectTimeout)
+	retries := p.Colored(config.ColorGray, "(off)")
+	if clientCfg.MaxRetries > 0 {
+		retries = fmt.Sprintf("%d (interval %s, exponential)", clientCfg.MaxRetries, clientCfg.RetryInterval)
+	}
+	p.Printf("  %-16s: %s\n", "Retries", retries)
+	keepalive := p.Colored(config.ColorGray, "(off)")
+	if clientCfg.Keepalive > 0 {
+		keepalive = clientCfg.Keepalive.String()
+	}
+	p.Printf("  %-16s: %s\n", "Keepalive", keepalive)
+
+	// Rules File
+	rulesFile := sess.Config.RulesFile
+	if rulesFile == "" {
+		rulesFile = p.Colored(config.ColorGray, "(built-in)")
+	}
+	p.Printf("  %-16s: %s\n", "Rules File", rulesFile)
+
+	// Notify URL
+	notifyURL := sess.Config.NotifyURL
+	if notifyURL == "" {
+		notifyURL = p.Colored(config.ColorGray, "(off)")
+	}
+	p.Printf("  %-16s: %s\n", "Notify URL", notifyURL)
+
+	// Team Server
+	syncURL := sess.Config.SyncURL
+	if syncURL == "" {
+		syncURL = p.Colored(config.ColorGray, "(none)")
+	} else if sess.Config.SyncToken == "" {
+		syncURL += p.Colored(config.ColorYellow, " (no token)")
+	}
+	p.Printf("  %-16s: %s\n", "Team Server", syncURL)
+
+	// Exec Env
+	envDisplay := p.Colored(config.ColorGray, "(none)")
+	if env, _ := buildExecEnv(sess.Config.Env, nil); len(env) > 0 {
+		envDisplay = strings.Join(env, " ")
+	}
+	p.Printf("  %-16s: %s\n", "Exec Env", envDisplay)
+
+	// Exec Via
+	p.Printf("  %-16s: %s\n", "Exec Via", sess.Config.ExecVia)
+
+	// Safe Mode
+	safeMode := "off"
+	if sess.IsSafeModeLocked() {
+		safeMode = p.Colored(config.ColorYellow, "on (--read-only)")
+	} else if sess.IsSafeMode() {
+		safeMode = p.Colored(config.ColorYellow, "on")
+	}
+	p.Printf("  %-16s: %s\n", "Safe Mode", safeMode)
+
+	// Time Format
+	timeFormat := "relative"
+	if sess.Config.AbsoluteTime {
+		timeFormat = "absolute"
+	}
+	p.Printf("  %-16s: %s (%s)\n", "Time Format", timeFormat, sess.Config.TimeZone)
+
+	// Pager
+	pager := "off"
+	if sess.Config.Pager {
+		pager = "on"
+		if env := os.Getenv(output.PagerEnv); env != "" {
+			pager += " ($PAGER: " + env + ")"
+		}
+	}
+	p.Printf("  %-16s: %s\n", "Pager", pager)
+
+	// Color
+	colorMode := "on"
+	if output.Plain() {
+		colorMode = "off (ASCII only)"
+	}
+	p.Printf("  %-16s: %s\n", "Color", colorMode)
+
+	// Language
+	p.Printf("  %-16s: %s\n", "Language", i18n.Lang())
+
+	// Log
+	logStatus := logging.Level()
+	if path := logging.File(); path != "" {
+		logStatus += ", file: " + path
+	}
+	p.Printf("  %-16s: %s\n", "Log", logStatus)
 
 	p.Println()
 }
 
-func (c *ShowCmd) showStatus(sess *session.Session) {
+func (c *ShowCmd) showStatus(sess *session.Session, tf output.TimeFormatter) {
 	p := sess.Printer
 
 	p.Println()
@@ -136,13 +361,25 @@ func (c *ShowCmd) showStatus(sess *session.Session) {
 	}
 	p.Printf("  %-16s: %s\n", "Connected", connStatus)
 
+	// Kubelet Version
+	if sess.KubeletVersion != "" {
+		p.Printf("  %-16s: %s\n", "Kubelet Version", sess.KubeletVersion)
+	}
+
+	// Kubelet Cert
+	if !sess.KubeletCertExp.IsZero() {
+		p.Printf("  %-16s: %s\n", "Kubelet Cert", "expires "+tf.Format(sess.KubeletCertExp))
+	}
+
 	// Scanned
 	scanStatus := p.Colored(config.ColorGray, "No")
 	if sess.IsScanned {
-		elapsed := time.Since(sess.LastScanTime)
-		scanStatus = fmt.Sprintf("%s (%s ago)",
+		scanStatus = fmt.Sprintf("%s (%s)",
 			p.Colored(config.ColorGreen, "Yes"),
-			formatDuration(elapsed))
+			tf.Format(sess.LastScanTime))
+		if sess.LastScanSampled {
+			scanStatus += " " + p.Colored(config.ColorYellow, "[sampled]")
+		}
 	}
 	p.Printf("  %-16s: %s\n", "Scanned", scanStatus)
 
@@ -193,25 +430,14 @@ func (c *ShowCmd) showEnv(sess *session.Session) {
 	// Database
 	dbMode := "Memory"
 	if sess.DB != nil && !sess.DB.IsInMemory() {
-		dbMode = sess.DB.Path()
+		dbMode = sess.DB.DisplayPath()
 	}
 	p.Printf("  %-16s: %s\n", "Database", dbMode)
 
 	p.Println()
 }
 
-// formatDuration 格式化时间间隔
-func formatDuration(d time.Duration) string {
-	if d < time.Minute {
-		return fmt.Sprintf("%d seconds", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		return fmt.Sprintf("%d minutes", int(d.Minutes()))
-	}
-	return fmt.Sprintf("%d hours", int(d.Hours()))
-}
-
-func (c *ShowCmd) showKubelets(sess *session.Session) {
+func (c *ShowCmd) showKubelets(sess *session.Session, tf output.TimeFormatter) {
 	p := sess.Printer
 
 	kubelets := sess.GetCachedKubelets()
@@ -226,15 +452,30 @@ func (c *ShowCmd) showKubelets(sess *session.Session) {
 		return
 	}
 
-	// 只显示 Kubelet 节点
+	// 只显示 Kubelet 节点，CLIENT 列标记当前目标及客户端池中已建立客户端的节点
+	pooled := make(map[string]bool)
+	for _, key := range sess.PooledKubelets() {
+		pooled[key] = true
+	}
+	current := session.KubeletKey(sess.Config.KubeletIP, sess.Config.KubeletPort)
+
 	var kubeletNodes [][]string
 	for _, k := range kubelets {
 		if k.IsKubelet {
+			key := session.KubeletKey(k.IP, k.Port)
+			state := ""
+			switch {
+			case key == current:
+				state = "current"
+			case pooled[key]:
+				state = "pooled"
+			}
 			kubeletNodes = append(kubeletNodes, []string{
 				k.IP,
 				fmt.Sprintf("%d", k.Port),
 				k.HealthPath,
-				k.DiscoveredAt.Format("15:04:05"),
+				state,
+				tf.Format(k.DiscoveredAt),
 			})
 		}
 	}
@@ -247,10 +488,118 @@ func (c *ShowCmd) showKubelets(sess *session.Session) {
 
 	tablePrinter := output.NewTablePrinter()
 	tablePrinter.PrintSimple(
-		[]string{"IP", "PORT", "HEALTH", "DISCOVERED"},
+		[]string{"IP", "PORT", "HEALTH", "CLIENT", "DISCOVERED"},
 		kubeletNodes,
 	)
 
-	p.Printf("\n  共 %d 个 Kubelet 节点\n", len(kubeletNodes))
-	p.Printf("  使用 'set target <ip>' 选择目标\n\n")
+	p.Printf("\n  %s\n", i18n.Tf("共 %d 个 Kubelet 节点", len(kubeletNodes)))
+	p.Printf("  %s\n\n", i18n.T("使用 'set target <ip>' 选择目标"))
+}
+
+// showTransports 在目标 Pod 中逐个探测执行通道
+func (c *ShowCmd) showTransports(sess *session.Session, args []string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	target := ""
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--pod" && i+1 < len(args) {
+			target = args[i+1]
+			i++
+		}
+	}
+
+	pod, err := c.transportProbePod(sess, target)
+	if err != nil {
+		return err
+	}
+	container := ""
+	if len(pod.Containers) > 0 {
+		container = pod.Containers[0].Name
+	}
+
+	p.Printf("%s Probing exec transports against %s/%s\n",
+		p.Colored(config.ColorBlue, "[*]"), pod.Namespace, pod.PodName)
+
+	current := sess.Config.ExecVia
+	if current == config.ExecViaKubelet {
+		current = config.ExecViaWebSocket
+	}
+
+	var rows [][]string
+	available := 0
+	for _, name := range config.ExecViaOptions[1:] {
+		mark := name
+		if name == current {
+			mark = name + " *"
+		}
+
+		t, err := sess.ExecTransportByName(name)
+		if err != nil {
+			rows = append(rows, []string{mark, "-", p.Colored(config.ColorGray, transportReason(err)), "-"})
+			continue
+		}
+
+		status := p.Colored(config.ColorGreen, "ok")
+		probeCtx, cancel := context.WithTimeout(ctx, transportProbeTimeout)
+		if err := transport.Probe(probeCtx, t, pod.Namespace, pod.PodName, container); err != nil {
+			status = p.Colored(config.ColorRed, transportReason(err))
+		} else {
+			available++
+		}
+		cancel()
+
+		interactive := "yes"
+		if !t.Interactive() {
+			interactive = "no"
+		}
+		rows = append(rows, []string{mark, t.Path(), status, interactive})
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"TRANSPORT", "PATH", "STATUS", "INTERACTIVE"}, rows)
+	p.Println()
+	p.Printf("%s %d/%d transport(s) available, exec via: %s\n",
+		p.Colored(config.ColorGreen, "[+]"), available, len(rows), sess.Config.ExecVia)
+	return nil
+}
+
+// transportProbeTimeout 单个通道的探测超时
+const transportProbeTimeout = 10 * time.Second
+
+// transportProbePod 返回探测使用的 Pod：指定的 ns/name 或缓存中第一个 Running 的 Pod
+func (c *ShowCmd) transportProbePod(sess *session.Session, target string) (*types.PodContainerInfo, error) {
+	pods := sess.GetCachedPods()
+	if len(pods) == 0 {
+		return nil, i18n.Errorf("没有缓存的 Pod，请先执行 pods")
+	}
+
+	if target != "" {
+		ns, name, ok := strings.Cut(target, "/")
+		if !ok {
+			return nil, i18n.Errorf("--pod 格式应为 namespace/name: %s", target)
+		}
+		for i := range pods {
+			if pods[i].Namespace == ns && pods[i].PodName == name {
+				return &pods[i], nil
+			}
+		}
+		return nil, i18n.Errorf("缓存中没有 Pod: %s", target)
+	}
+
+	for i := range pods {
+		if pods[i].Status == "Running" {
+			return &pods[i], nil
+		}
+	}
+	return nil, i18n.Errorf("缓存中没有 Running 状态的 Pod，请使用 --pod 指定")
+}
+
+// transportReason 将错误压缩为单行，便于表格显示
+func transportReason(err error) string {
+	reason := strings.Join(strings.Fields(err.Error()), " ")
+	if r := []rune(reason); len(r) > 80 {
+		reason = string(r[:78]) + ".."
+	}
+	return reason
 }
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/server"
+	"kctl/internal/session"
+)
+
+// SyncCmd sync 命令
+type SyncCmd struct{}
+
+func init() {
+	Register(&SyncCmd{})
+}
+
+func (c *SyncCmd) Name() string {
+	return "sync"
+}
+
+func (c *SyncCmd) Aliases() []string {
+	return nil
+}
+
+func (c *SyncCmd) Description() string {
+	return "将结果推送到团队服务器"
+}
+
+// IsReadOnly sync 只读取本地数据库，查看模式下也可以推送已有结果
+func (c *SyncCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+func (c *SyncCmd) Usage() string {
+	return `sync [<server>] [--api-token <token>] [--no-tokens] [--insecure]
+
+将本地的 ServiceAccount、检查发现、Pod 列表和扫描历史推送到团队服务器（kctl serve），
+多名操作者在不同节点上的结果合并为一份数据：
+  - 扫描按 目标 + 开始时间 去重，重复推送不会产生重复记录
+  - SA 按 namespace/name 合并：检查过权限的记录优先于被动扫描的记录，同等情况下保留较新的记录
+  - 服务器上已有的备注和标签保留
+  - Pod 按节点替换，其他节点的 Pod 保留
+
+服务器地址和令牌默认使用 set sync-server / set sync-token
+
+选项：
+  --api-token <token>   团队服务器的 API 令牌
+  --no-tokens           不推送 SA Token（服务器上已有的 Token 保留）
+  --insecure            不校验服务器证书（自签名证书）
+
+示例：
+  set sync-server https://team.example.com:8443
+  set sync-token 3f9c...
+  sync
+  sync http://10.8.0.1:8080 --api-token 3f9c... --no-tokens`
+}
+
+// Flags sync 的选项补全
+func (c *SyncCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--api-token", Arg: "<token>", Description: "团队服务器 API 令牌"},
+		{Name: "--no-tokens", Description: "不推送 SA Token"},
+		{Name: "--insecure", Description: "不校验服务器证书"},
+	}
+}
+
+func (c *SyncCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	serverURL, token := sess.Config.SyncURL, sess.Config.SyncToken
+	withTokens, insecure := true, false
+	positional := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--api-token":
+			if i+1 >= len(args) {
+				return i18n.Errorf("--api-token 需要指定令牌")
+			}
+			token = args[i+1]
+			i++
+		case "--no-tokens":
+			withTokens = false
+		case "--insecure":
+			insecure = true
+		default:
+			if positional {
+				return i18n.Errorf("多余的参数: %s", args[i])
+			}
+			serverURL, positional = args[i], true
+		}
+	}
+	if serverURL == "" {
+		return i18n.Errorf("未设置团队服务器，请使用 'set sync-server <url>' 或 'sync <url>'")
+	}
+	if token == "" {
+		return i18n.Errorf("未设置团队服务器令牌，请使用 'set sync-token <token>' 或 --api-token")
+	}
+
+	payload, err := server.BuildSyncPayload(sess, operatorName(), withTokens)
+	if err != nil {
+		return err
+	}
+	p.Printf("%s Pushing %d SAs, %d findings, %d pods, %d scans to %s...\n",
+		p.Colored(config.ColorBlue, "[*]"),
+		len(payload.ServiceAccounts), len(payload.Findings), len(payload.Pods), len(payload.Scans), serverURL)
+
+	result, err := server.Push(sess.Context(), serverURL, token, insecure, payload)
+	if err != nil {
+		return i18n.Errorf("同步失败: %w", err)
+	}
+	p.Success(fmt.Sprintf("Synced: SAs %d added, %d updated, %d kept; scans %d added, %d already synced; %d findings, %d pods",
+		result.SAsAdded, result.SAsUpdated, result.SAsKept, result.ScansAdded, result.ScansSkipped, result.Findings, result.Pods))
+	return nil
+}
+
+// operatorName 推送者标识 user@host
+func operatorName() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		name += "@" + host
+	}
+	return name
+}
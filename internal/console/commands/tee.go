@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"fmt"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+)
+
+// TeeCmd tee 命令
+type TeeCmd struct{}
+
+func init() {
+	Register(&TeeCmd{})
+}
+
+func (c *TeeCmd) Name() string {
+	return "tee"
+}
+
+func (c *TeeCmd) Aliases() []string {
+	return nil
+}
+
+func (c *TeeCmd) Description() string {
+	return "将后续控制台输出同时写入文件"
+}
+
+// IsReadOnly tee 只写本地文件
+func (c *TeeCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+func (c *TeeCmd) Usage() string {
+	return `tee [<file> [--append]|off]
+
+开启后每条命令及其全部输出（表格、exec 输出、发现等）同时写入文件，直到 tee off
+文件中去除颜色，每条命令前记录命令行，便于作为报告附录
+
+选项：
+  --append, -a    追加到已有文件（默认覆盖）
+
+示例：
+  tee session.log             开始记录
+  tee session.log --append    追加记录
+  tee                         显示当前状态
+  tee off                     停止记录`
+}
+
+// Flags tee 的选项补全
+func (c *TeeCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--append", Short: "-a", Description: "追加到已有文件"},
+	}
+}
+
+// Suggestions tee 的子命令补全
+func (c *TeeCmd) Suggestions(sess *session.Session, args []string) []completion.Suggestion {
+	return subcommandSuggestions(args,
+		completion.Suggestion{Text: "off", Description: "停止记录"},
+	)
+}
+
+func (c *TeeCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	path := ""
+	appendMode := false
+	for _, arg := range args {
+		switch arg {
+		case "--append", "-a":
+			appendMode = true
+		default:
+			if path != "" {
+				return i18n.Errorf("多余的参数: %s", arg)
+			}
+			path = arg
+		}
+	}
+
+	switch path {
+	case "":
+		if sess.Tee == nil {
+			p.Info(i18n.T("tee 未开启，使用 'tee <file>' 开始记录"))
+			return nil
+		}
+		p.Printf("%s Mirroring output to %s\n", p.Colored(config.ColorBlue, "[*]"), sess.Tee.Path())
+		return nil
+
+	case "off":
+		if sess.Tee == nil {
+			p.Info(i18n.T("tee 未开启"))
+			return nil
+		}
+		// 先输出提示再关闭，提示也会写入文件
+		t := sess.Tee
+		sess.Tee = nil
+		p.Success(fmt.Sprintf("Stopped mirroring output to %s", t.Path()))
+		return t.Close()
+	}
+
+	if sess.Tee != nil {
+		return i18n.Errorf("tee 已开启 (%s)，先执行 tee off", sess.Tee.Path())
+	}
+	t, err := output.OpenTee(path, appendMode)
+	if err != nil {
+		return err
+	}
+	sess.Tee = t
+	p.Success(fmt.Sprintf("Mirroring output to %s, stop with 'tee off'", path))
+	return nil
+}
@@ -0,0 +1,230 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// TopCmd top 命令
+type TopCmd struct{}
+
+func init() {
+	Register(&TopCmd{})
+}
+
+func (c *TopCmd) Name() string {
+	return "top"
+}
+
+func (c *TopCmd) Aliases() []string {
+	return nil
+}
+
+func (c *TopCmd) Description() string {
+	return "显示节点和 Pod 的 CPU / 内存使用"
+}
+
+// IsPaged top 输出资源使用表格
+func (c *TopCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *TopCmd) Usage() string {
+	return `top [options]
+
+从 Kubelet /stats/summary 获取节点和 Pod 的 CPU / 内存使用
+识别出的安全 / 监控代理（Falco、CrowdStrike、Datadog 等）在 AGENT 列标出
+
+选项：
+  --sort <cpu|mem>    排序字段（默认 cpu，从高到低）
+  --asc               从低到高排序，便于挑选空闲的 Pod 执行操作
+  -n <namespace>      按命名空间过滤
+  --limit, -l <n>     只显示前 n 个 Pod
+  --containers, -c    显示每个容器的使用
+
+示例：
+  top                    按 CPU 从高到低列出 Pod
+  top --sort mem -l 10   内存占用最高的 10 个 Pod
+  top --asc              最空闲的 Pod 在前
+  top -n kube-system -c  显示 kube-system 中各容器的使用`
+}
+
+// Flags top 的选项补全
+func (c *TopCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--sort", Arg: "<cpu|mem>", Description: "排序字段", Values: completion.Choices(
+			completion.Suggestion{Text: "cpu", Description: "按 CPU 排序"},
+			completion.Suggestion{Text: "mem", Description: "按内存排序"},
+		)},
+		{Name: "--asc", Description: "从低到高排序"},
+		{Name: "-n", Arg: "<namespace>", Description: "按命名空间过滤", Values: completion.Namespaces},
+		{Name: "--limit", Short: "-l", Arg: "<n>", Description: "只显示前 n 个 Pod"},
+		{Name: "--containers", Short: "-c", Description: "显示每个容器的使用"},
+	}
+}
+
+// podUsage 一个 Pod 的资源使用
+type podUsage struct {
+	stats  types.PodStats
+	cpu    uint64 // 毫核
+	memory uint64 // 字节
+	agent  string
+}
+
+func (c *TopCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+
+	sortBy := "cpu"
+	ascending := false
+	namespace := ""
+	limit := 0
+	showContainers := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--sort":
+			if i+1 >= len(args) {
+				return i18n.Errorf("--sort 需要指定 cpu 或 mem")
+			}
+			sortBy = args[i+1]
+			i++
+			if sortBy != "cpu" && sortBy != "mem" {
+				return i18n.Errorf("无效的排序字段: %s (可用: cpu, mem)", sortBy)
+			}
+		case "--asc":
+			ascending = true
+		case "-n":
+			if i+1 < len(args) {
+				namespace = args[i+1]
+				i++
+			}
+		case "--limit", "-l":
+			if i+1 >= len(args) {
+				return i18n.Errorf("%s 需要指定数量", args[i])
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n <= 0 {
+				return i18n.Errorf("无效的数量: %s", args[i+1])
+			}
+			limit = n
+			i++
+		case "--containers", "-c":
+			showContainers = true
+		default:
+			return i18n.Errorf("未知参数: %s", args[i])
+		}
+	}
+
+	kubelet, err := sess.GetKubeletClient()
+	if err != nil {
+		return err
+	}
+	summary, err := kubelet.GetStatsSummary(sess.Context())
+	if err != nil {
+		return i18n.Errorf("获取 /stats/summary 失败: %w", err)
+	}
+
+	node := summary.Node
+	p.Printf("%s Node %s: CPU %s, memory %s",
+		p.Colored(config.ColorBlue, "[*]"),
+		p.Colored(config.ColorCyan, orDash(node.NodeName)),
+		formatMilliCores(node.CPU.MilliCores()),
+		formatBytes(node.Memory.WorkingSet()))
+	if node.Memory != nil && node.Memory.AvailableBytes != nil {
+		p.Printf(" (%s available)", formatBytes(*node.Memory.AvailableBytes))
+	}
+	p.Println()
+
+	// 镜像信息来自 Pod 缓存，用于识别安全代理
+	cached := make(map[string]types.PodContainerInfo)
+	for _, pod := range sess.GetCachedPods() {
+		cached[pod.Namespace+"/"+pod.PodName] = pod
+	}
+
+	var usages []podUsage
+	agents := 0
+	for _, ps := range summary.Pods {
+		if namespace != "" && ps.PodRef.Namespace != namespace {
+			continue
+		}
+		u := podUsage{stats: ps, cpu: ps.CPU.MilliCores(), memory: ps.Memory.WorkingSet()}
+		pod, ok := cached[ps.PodRef.Namespace+"/"+ps.PodRef.Name]
+		if !ok {
+			pod = types.PodContainerInfo{Namespace: ps.PodRef.Namespace, PodName: ps.PodRef.Name}
+		}
+		if u.agent = security.DetectSecurityAgent(pod); u.agent != "" {
+			agents++
+		}
+		usages = append(usages, u)
+	}
+	if len(usages) == 0 {
+		p.Info(i18n.T("没有 Pod 资源使用数据"))
+		return nil
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		a, b := usages[i].cpu, usages[j].cpu
+		if sortBy == "mem" {
+			a, b = usages[i].memory, usages[j].memory
+		}
+		if ascending {
+			return a < b
+		}
+		return a > b
+	})
+	total := len(usages)
+	if limit > 0 && limit < len(usages) {
+		usages = usages[:limit]
+	}
+
+	var rows [][]string
+	for _, u := range usages {
+		agent := "-"
+		if u.agent != "" {
+			agent = p.Colored(config.ColorYellow, u.agent)
+		}
+		rows = append(rows, []string{
+			u.stats.PodRef.Namespace,
+			u.stats.PodRef.Name,
+			formatMilliCores(u.cpu),
+			formatBytes(u.memory),
+			agent,
+		})
+		if !showContainers {
+			continue
+		}
+		for _, cs := range u.stats.Containers {
+			rows = append(rows, []string{
+				"",
+				p.Colored(config.ColorGray, "└ "+cs.Name),
+				p.Colored(config.ColorGray, formatMilliCores(cs.CPU.MilliCores())),
+				p.Colored(config.ColorGray, formatBytes(cs.Memory.WorkingSet())),
+				"",
+			})
+		}
+	}
+
+	p.Println()
+	output.NewTablePrinter().PrintSimple([]string{"NAMESPACE", "POD", "CPU", "MEMORY", "AGENT"}, rows)
+	p.Println()
+	p.Printf("%s %d pods (showing %d)\n", p.Colored(config.ColorGreen, "[+]"), total, len(usages))
+	if agents > 0 {
+		p.Printf("%s %d security/monitoring agent pod(s) on this node, exec and process activity may be recorded\n",
+			p.Colored(config.ColorYellow, "[!]"), agents)
+	}
+	return nil
+}
+
+// formatMilliCores 格式化毫核数
+func formatMilliCores(m uint64) string {
+	return fmt.Sprintf("%dm", m)
+}
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/notify"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// PostureWatcher kctl watch 在多轮扫描之间保存的状态
+type PostureWatcher struct {
+	lastScan map[string]int64                    // 目标 -> 上一轮比较过的扫描 ID
+	pods     map[string][]types.PodContainerInfo // 目标 -> 上一轮的 Pod 列表
+}
+
+// PostureChange 与上一轮扫描相比新增的风险
+type PostureChange struct {
+	Severity config.RiskLevel
+	Kind     string // serviceaccount, pod
+	Object   string
+	Message  string
+}
+
+// NewPostureWatcher 创建 PostureWatcher
+func NewPostureWatcher() *PostureWatcher {
+	return &PostureWatcher{
+		lastScan: make(map[string]int64),
+		pods:     make(map[string][]types.PodContainerInfo),
+	}
+}
+
+// Compare 将当前目标最近一次 sa scan 与同一目标的上一次扫描比较，输出新增风险并推送 posture.changed 通知
+// SA 的比较基于数据库中的扫描快照（使用 --db 时可跨进程延续），特权 Pod 的比较基于本进程上一轮的 Pod 列表
+func (w *PostureWatcher) Compare(sess *session.Session) ([]PostureChange, error) {
+	p := sess.Printer
+	target := fmt.Sprintf("%s:%d", sess.Config.KubeletIP, sess.Config.KubeletPort)
+
+	scans, err := sess.ScanDB.GetAll()
+	if err != nil {
+		return nil, i18n.Errorf("读取扫描记录失败: %w", err)
+	}
+	var history []*types.ScanRecord
+	for _, s := range scans {
+		if s.Target == target {
+			history = append(history, s)
+		}
+	}
+	if len(history) == 0 || history[len(history)-1].ID == w.lastScan[target] {
+		p.Printf("%s No new scan recorded for %s, nothing to compare\n", p.Colored(config.ColorYellow, "[!]"), target)
+		return nil, nil
+	}
+	latest := history[len(history)-1]
+	w.lastScan[target] = latest.ID
+
+	prevPods, havePods := w.pods[target]
+	w.pods[target] = sess.GetCachedPods()
+
+	if len(history) < 2 {
+		p.Printf("%s Baseline recorded for %s (scan #%d)\n", p.Colored(config.ColorBlue, "[*]"), target, latest.ID)
+		return nil, nil
+	}
+	prev := history[len(history)-2]
+
+	var changes []PostureChange
+	from, err := sess.ScanDB.GetResults(prev.ID)
+	if err != nil {
+		return nil, i18n.Errorf("读取扫描 #%d 结果失败: %w", prev.ID, err)
+	}
+	to, err := sess.ScanDB.GetResults(latest.ID)
+	if err != nil {
+		return nil, i18n.Errorf("读取扫描 #%d 结果失败: %w", latest.ID, err)
+	}
+	changes = append(changes, saPostureChanges(diffScans(from, to, prev.Sampled > 0, latest.Sampled > 0))...)
+	if havePods {
+		changes = append(changes, privilegedPodChanges(prevPods, w.pods[target])...)
+	}
+
+	if len(changes) == 0 {
+		p.Printf("%s No new risks since scan #%d\n", p.Colored(config.ColorGreen, "[+]"), prev.ID)
+		return nil, nil
+	}
+	p.Printf("%s %d new risk(s) since scan #%d:\n", p.Colored(config.ColorRed, "[!]"), len(changes), prev.ID)
+	for _, ch := range changes {
+		p.Printf("    %s %s %s  %s\n", p.Formatter().FormatRiskLevelColored(ch.Severity), ch.Kind, ch.Object, p.Colored(config.ColorGray, ch.Message))
+	}
+	w.notify(sess, prev, latest, changes)
+	return changes, nil
+}
+
+// notify 设置了 notify-url 时推送变化
+func (w *PostureWatcher) notify(sess *session.Session, prev, latest *types.ScanRecord, changes []PostureChange) {
+	if sess.Config.NotifyURL == "" {
+		return
+	}
+	p := sess.Printer
+	summary := &notify.Summary{
+		Event:     notify.EventPostureChanged,
+		ScanID:    latest.ID,
+		Since:     prev.ID,
+		Target:    latest.Target,
+		Mode:      latest.Mode,
+		Partial:   latest.Partial,
+		StartedAt: latest.StartedAt,
+		Counts:    make(map[config.RiskLevel]int),
+	}
+	for _, ch := range changes {
+		summary.Counts[ch.Severity]++
+		summary.Changes = append(summary.Changes, notify.Item{Severity: ch.Severity, Object: ch.Kind + " " + ch.Object, Title: ch.Message})
+	}
+	if err := notify.Send(sess.Context(), sess.Config.NotifyURL, summary); err != nil {
+		p.Warning(i18n.Tf("发送通知失败: %v", err))
+		return
+	}
+	p.Printf("%s Notification sent to %s\n", p.Colored(config.ColorBlue, "[*]"), sess.Config.NotifyURL)
+}
+
+// saPostureChanges 新出现的 HIGH 及以上 SA 和风险升级的 SA
+func saPostureChanges(d scanDiff) []PostureChange {
+	var changes []PostureChange
+	for _, r := range d.added {
+		level := effectiveRisk(r)
+		if config.RiskLevelOrder[level] > config.RiskLevelOrder[config.RiskHigh] {
+			continue
+		}
+		message := "new ServiceAccount"
+		if level == config.RiskAdmin {
+			message = "new cluster-admin ServiceAccount"
+		}
+		changes = append(changes, PostureChange{Severity: level, Kind: "serviceaccount", Object: r.Namespace + "/" + r.Name, Message: message})
+	}
+	for _, ch := range d.regressions {
+		message := fmt.Sprintf("risk %s -> %s", ch.from, ch.to)
+		if ch.to == config.RiskAdmin {
+			message = fmt.Sprintf("became cluster-admin (was %s)", ch.from)
+		}
+		if len(ch.added) > 0 {
+			message += ", gained " + strings.Join(ch.added, ", ")
+		}
+		changes = append(changes, PostureChange{Severity: ch.to, Kind: "serviceaccount", Object: ch.key, Message: message})
+	}
+	return changes
+}
+
+// privilegedPodChanges 带有 HIGH 及以上安全标识、且同命名空间同 SA 的 Pod 在上一轮没有该标识的 Pod
+// 按命名空间和 SA 而不是 Pod 名比较，避免滚动更新产生的新 Pod 名被误报
+func privilegedPodChanges(before, after []types.PodContainerInfo) []PostureChange {
+	seen := make(map[string]bool)
+	for _, pod := range before {
+		for _, flag := range privilegedFlags(pod.SecurityFlags) {
+			seen[pod.Namespace+"/"+pod.ServiceAccount+"/"+flag] = true
+		}
+	}
+
+	var changes []PostureChange
+	for _, pod := range after {
+		var added []string
+		severity := config.RiskHigh
+		for _, flag := range privilegedFlags(pod.SecurityFlags) {
+			if seen[pod.Namespace+"/"+pod.ServiceAccount+"/"+flag] {
+				continue
+			}
+			added = append(added, flag)
+			if level := config.RiskLevel(config.SecurityFlagConfigs[flag].Level); config.RiskLevelOrder[level] < config.RiskLevelOrder[severity] {
+				severity = level
+			}
+		}
+		if len(added) > 0 {
+			changes = append(changes, PostureChange{
+				Severity: severity,
+				Kind:     "pod",
+				Object:   pod.Namespace + "/" + pod.PodName,
+				Message:  "newly " + strings.Join(added, ", "),
+			})
+		}
+	}
+	return changes
+}
+
+// privilegedFlags HIGH 及以上的安全标识
+func privilegedFlags(flags types.SecurityFlags) []string {
+	var names []string
+	for _, name := range security.ActiveSecurityFlags(flags) {
+		level := config.RiskLevel(config.SecurityFlagConfigs[name].Level)
+		if config.RiskLevelOrder[level] <= config.RiskLevelOrder[config.RiskHigh] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,241 @@
+package commands
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/security"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// WebhooksCmd webhooks 命令
+type WebhooksCmd struct{}
+
+func init() {
+	Register(&WebhooksCmd{})
+}
+
+func (c *WebhooksCmd) Name() string {
+	return "webhooks"
+}
+
+func (c *WebhooksCmd) Aliases() []string {
+	return []string{"admission"}
+}
+
+func (c *WebhooksCmd) Description() string {
+	return "枚举准入 Webhook 并提示绕过途径"
+}
+
+// IsPaged webhooks 输出 Webhook 表格
+func (c *WebhooksCmd) IsPaged(args []string) bool {
+	return true
+}
+
+func (c *WebhooksCmd) Usage() string {
+	return `webhooks [options]
+
+列出 MutatingWebhookConfiguration 和 ValidatingWebhookConfiguration（需要 admissionregistration.k8s.io 的 list 权限），
+显示拦截的资源、failurePolicy、namespaceSelector / objectSelector，并标出可绕过的 Webhook：
+  - failurePolicy 为 Ignore：Webhook 不可达或超时时请求直接放行
+  - namespaceSelector 排除的命名空间（如 kube-system）、依赖可修改的命名空间标签
+  - objectSelector：对象标签由创建者决定，不带匹配标签即可绕过
+  - 拦截 Pod 创建但未拦截 pods/ephemeralcontainers（可通过 debug 注入容器）
+能列出命名空间时，汇总不经过任何 Pod 准入 Webhook 的命名空间
+
+发现写入数据库（来源 webhooks，hunt list 查看）
+
+选项：
+  --pods              只显示拦截 Pod 创建的 Webhook
+  --bypass            只显示存在绕过途径的 Webhook
+
+示例：
+  webhooks
+  webhooks --pods
+  admission --bypass`
+}
+
+// Flags webhooks 的选项补全
+func (c *WebhooksCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "--pods", Description: "只显示拦截 Pod 创建的 Webhook"},
+		{Name: "--bypass", Description: "只显示存在绕过途径的 Webhook"},
+	}
+}
+
+func (c *WebhooksCmd) Execute(sess *session.Session, args []string) error {
+	p := sess.Printer
+	ctx := sess.Context()
+
+	podsOnly, bypassOnly := false, false
+	for _, arg := range args {
+		switch arg {
+		case "--pods":
+			podsOnly = true
+		case "--bypass":
+			bypassOnly = true
+		default:
+			return i18n.Errorf("未知参数: %s", arg)
+		}
+	}
+
+	if sess.Config.APIServer == "" {
+		return i18n.Errorf("未设置 API Server，请使用 'set api-server <addr>' 设置")
+	}
+	k8s, err := sess.GetK8sClient(sess.GetActiveToken())
+	if err != nil {
+		return err
+	}
+
+	p.Printf("%s Listing admission webhooks...\n", p.Colored(config.ColorBlue, "[*]"))
+	mutating, err := k8s.ListMutatingWebhooks(ctx)
+	if err != nil {
+		return i18n.Errorf("获取 MutatingWebhookConfiguration 失败: %w", err)
+	}
+	validating, err := k8s.ListValidatingWebhooks(ctx)
+	if err != nil {
+		return i18n.Errorf("获取 ValidatingWebhookConfiguration 失败: %w", err)
+	}
+	webhooks := append(mutating, validating...)
+	if len(webhooks) == 0 {
+		p.Info("No admission webhooks configured, requests are only checked by built-in admission plugins")
+		return nil
+	}
+
+	namespaces, err := k8s.ListNamespaces(ctx)
+	if err != nil {
+		p.Warning(i18n.Tf("获取命名空间失败，无法核对 namespaceSelector 排除的命名空间: %v", err))
+	}
+
+	now := time.Now()
+	var rows [][]string
+	var records []*types.FindingRecord
+	type detail struct {
+		webhook  types.WebhookInfo
+		bypasses []security.WebhookBypass
+	}
+	var details []detail
+	bypassable := 0
+	for _, w := range webhooks {
+		bypasses := security.WebhookBypasses(w, namespaces)
+		if len(bypasses) > 0 {
+			bypassable++
+		}
+		for _, b := range bypasses {
+			records = append(records, &types.FindingRecord{
+				Source:      "webhooks",
+				Severity:    string(b.Severity),
+				Category:    "admission",
+				Pod:         w.Configuration,
+				Location:    b.ID + "@" + w.Name,
+				Title:       b.Title,
+				Evidence:    b.Detail,
+				CollectedAt: now,
+				KubeletIP:   sess.Config.KubeletIP,
+			})
+		}
+		if (podsOnly && !security.WebhookInterceptsPods(w)) || (bypassOnly && len(bypasses) == 0) {
+			continue
+		}
+
+		risk := "-"
+		if len(bypasses) > 0 {
+			risk = p.Formatter().FormatRiskLevelColored(bypasses[0].Severity)
+			details = append(details, detail{webhook: w, bypasses: bypasses})
+		}
+		rows = append(rows, []string{
+			w.Kind,
+			w.Name,
+			c.formatRules(w.Rules),
+			c.formatFailurePolicy(p, w.FailurePolicy),
+			orDash(security.FormatSelector(w.NamespaceSelector)),
+			orDash(security.FormatSelector(w.ObjectSelector)),
+			risk,
+		})
+	}
+	if len(records) > 0 {
+		if _, err := sess.FindingDB.SaveBatch(records); err != nil {
+			p.Warning(i18n.Tf("保存 Webhook 检查结果失败: %v", err))
+		}
+	}
+
+	if len(rows) > 0 {
+		p.Println()
+		output.NewTablePrinter().PrintSimple([]string{"KIND", "WEBHOOK", "RULES", "FAILURE", "NAMESPACE SELECTOR", "OBJECT SELECTOR", "BYPASS"}, rows)
+	}
+	for _, d := range details {
+		p.Println()
+		p.Printf("  %s %s\n", p.Colored(config.ColorWhite, d.webhook.Name),
+			p.Colored(config.ColorGray, "("+d.webhook.Kind+"WebhookConfiguration/"+d.webhook.Configuration+", "+d.webhook.Endpoint+")"))
+		for _, b := range d.bypasses {
+			p.Printf("    %s %s\n", p.Formatter().FormatRiskLevelColored(b.Severity), b.Title)
+			p.Printf("      %s\n", p.Colored(config.ColorGray, b.Detail))
+		}
+	}
+
+	p.Println()
+	p.Printf("%s %d webhook(s) (%d mutating, %d validating), %d with bypass paths\n",
+		p.Colored(config.ColorGreen, "[+]"), len(webhooks), len(mutating), len(validating), bypassable)
+	if len(namespaces) > 0 {
+		if unguarded := c.unguardedNamespaces(webhooks, namespaces); len(unguarded) > 0 {
+			p.Printf("%s Pod creation not inspected by any webhook in: %s\n",
+				p.Colored(config.ColorYellow, "[!]"), strings.Join(unguarded, ", "))
+		}
+	}
+	return nil
+}
+
+// unguardedNamespaces 返回没有任何失败关闭（Fail）且不依赖 objectSelector 的 Webhook 拦截 Pod 创建的命名空间
+// 拦截 Pod 的 Webhook 都被排除时，逃逸 Pod 只受内置准入（PSA 等）约束
+func (c *WebhooksCmd) unguardedNamespaces(webhooks []types.WebhookInfo, namespaces []types.NamespaceInfo) []string {
+	var unguarded []string
+	for _, ns := range namespaces {
+		guarded := slices.ContainsFunc(webhooks, func(w types.WebhookInfo) bool {
+			return security.WebhookInterceptsPods(w) &&
+				w.FailurePolicy != "Ignore" &&
+				security.FormatSelector(w.ObjectSelector) == "" &&
+				security.SelectorMatches(w.NamespaceSelector, ns.Labels)
+		})
+		if !guarded {
+			unguarded = append(unguarded, ns.Name)
+		}
+	}
+	return unguarded
+}
+
+// formatRules 格式化拦截的资源，如 pods,deployments.apps [CREATE,UPDATE]
+func (c *WebhooksCmd) formatRules(rules []types.WebhookRule) string {
+	var parts []string
+	for _, rule := range rules {
+		var resources []string
+		for _, r := range rule.Resources {
+			for _, group := range rule.APIGroups {
+				if group != "" {
+					r += "." + group
+					break
+				}
+			}
+			resources = append(resources, r)
+		}
+		parts = append(parts, strings.Join(resources, ",")+" ["+strings.Join(rule.Operations, ",")+"]")
+	}
+	if len(parts) > 2 {
+		return strings.Join(parts[:2], "; ") + "; +" + strconv.Itoa(len(parts)-2)
+	}
+	return orDash(strings.Join(parts, "; "))
+}
+
+// formatFailurePolicy Ignore（失败放行）着色
+func (c *WebhooksCmd) formatFailurePolicy(p output.Printer, policy string) string {
+	if policy == "Ignore" {
+		return p.Colored(config.ColorYellow, policy)
+	}
+	return policy
+}
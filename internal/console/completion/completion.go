@@ -0,0 +1,287 @@
+// Package completion 定义命令补全元数据及通用的参数值补全来源
+// 命令通过 Flags / Suggestions 提供自己的补全数据，控制台只负责按前缀过滤和展示
+package completion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"kctl/internal/filter"
+	"kctl/internal/session"
+)
+
+// Suggestion 补全候选
+type Suggestion struct {
+	Text        string
+	Description string
+}
+
+// ValueFunc 返回选项值的补全候选，args 为命令名之后已输入完成的参数
+type ValueFunc func(sess *session.Session, args []string) []Suggestion
+
+// Flag 命令选项的补全元数据
+type Flag struct {
+	Name        string    // 选项名，如 --node
+	Short       string    // 短选项，如 -f，可为空
+	Arg         string    // 参数占位符，如 <node>；为空表示开关选项
+	Description string    // 说明
+	Values      ValueFunc // 参数值补全，为空时不补全（自由输入）
+}
+
+// Separator 命令分隔符，之后的参数原样传给目标命令，不再补全
+const Separator = "--"
+
+// Complete 根据已输入完成的参数计算补全候选（未按前缀过滤）
+// 上一个参数是带值选项时只返回该选项的值；出现 -- 后不再补全
+func Complete(sess *session.Session, flags []Flag, positional []Suggestion, args []string) []Suggestion {
+	for _, arg := range args {
+		if arg == Separator {
+			return nil
+		}
+	}
+
+	if prev := Previous(args); prev != "" {
+		for _, f := range flags {
+			if f.Arg != "" && (f.Name == prev || (f.Short != "" && f.Short == prev)) {
+				if f.Values == nil {
+					return nil
+				}
+				return f.Values(sess, args)
+			}
+		}
+	}
+
+	suggestions := append([]Suggestion(nil), positional...)
+	for _, f := range flags {
+		suggestions = append(suggestions, Suggestion{Text: f.Name, Description: f.Description})
+	}
+	return suggestions
+}
+
+// Previous 返回最后一个已输入完成的参数
+func Previous(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[len(args)-1]
+}
+
+// Choices 返回固定候选
+func Choices(choices ...Suggestion) ValueFunc {
+	return func(*session.Session, []string) []Suggestion {
+		return choices
+	}
+}
+
+// Namespaces 补全 Pod 缓存和已扫描 SA 中的命名空间
+func Namespaces(sess *session.Session, _ []string) []Suggestion {
+	counts := make(map[string]int)
+	for _, pod := range sess.GetCachedPods() {
+		counts[pod.Namespace]++
+	}
+	if sess.SADB != nil {
+		if sas, err := sess.SADB.GetAll(); err == nil {
+			for _, sa := range sas {
+				if _, ok := counts[sa.Namespace]; !ok {
+					counts[sa.Namespace] = 0
+				}
+			}
+		}
+	}
+
+	suggestions := make([]Suggestion, 0, len(counts))
+	for ns, n := range counts {
+		desc := "namespace"
+		if n > 0 {
+			desc = fmt.Sprintf("%d pods", n)
+		}
+		suggestions = append(suggestions, Suggestion{Text: ns, Description: desc})
+	}
+	sort.Slice(suggestions, func(i, j int) bool { return suggestions[i].Text < suggestions[j].Text })
+	return suggestions
+}
+
+// Pods 补全 Pod 缓存中的全部 Pod（包括非 Running 状态）
+func Pods(sess *session.Session, args []string) []Suggestion {
+	return podSuggestions(sess, args, false)
+}
+
+// RunningPods 补全 Running 状态的 Pod 名
+func RunningPods(sess *session.Session, args []string) []Suggestion {
+	return podSuggestions(sess, args, true)
+}
+
+// podSuggestions 补全 Pod 名，已输入 -n 时只显示该命名空间的 Pod
+// 同名 Pod 存在于多个命名空间时以 namespace/name 形式补全，避免执行时报歧义
+func podSuggestions(sess *session.Session, args []string, running bool) []Suggestion {
+	namespace := NamespaceArg(args)
+	pods := sess.GetCachedPods()
+
+	nameCount := make(map[string]int)
+	for _, pod := range pods {
+		nameCount[pod.PodName]++
+	}
+
+	var suggestions []Suggestion
+	for _, pod := range pods {
+		if (running && pod.Status != "Running") || (namespace != "" && pod.Namespace != namespace) {
+			continue
+		}
+		text := pod.PodName
+		if namespace == "" && nameCount[pod.PodName] > 1 {
+			text = pod.Namespace + "/" + pod.PodName
+		}
+		desc := pod.Namespace
+		if !running && pod.Status != "Running" {
+			desc += " " + pod.Status
+		}
+		suggestions = append(suggestions, Suggestion{Text: text, Description: desc})
+	}
+	return suggestions
+}
+
+// NamespaceArg 返回已输入的 -n / --namespace 的值
+func NamespaceArg(args []string) string {
+	namespace := ""
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "-n" || args[i] == "--namespace" {
+			namespace = args[i+1]
+			i++
+		}
+	}
+	return namespace
+}
+
+// ExcludePods 补全 --filter 要排除的 Pod 名
+func ExcludePods(sess *session.Session, _ []string) []Suggestion {
+	var suggestions []Suggestion
+	for _, pod := range sess.GetCachedPods() {
+		if pod.Status == "Running" {
+			suggestions = append(suggestions, Suggestion{Text: pod.PodName, Description: fmt.Sprintf("排除 %s", pod.Namespace)})
+		}
+	}
+	return suggestions
+}
+
+// Containers 补全容器名，已输入 Pod 名（name、namespace/name 或 --pod 的值）或 -n 时只显示匹配 Pod 的容器
+func Containers(sess *session.Session, args []string) []Suggestion {
+	podName := ""
+	namespace := ""
+	for i := 0; i < len(args); i++ {
+		switch {
+		case (args[i] == "-n" || args[i] == "--namespace") && i+1 < len(args):
+			namespace = args[i+1]
+			i++
+		case args[i] == "--pod" && i+1 < len(args):
+			podName = args[i+1]
+			i++
+		case !strings.HasPrefix(args[i], "-"):
+			podName = args[i]
+		}
+	}
+	if ns, name, ok := strings.Cut(podName, "/"); ok {
+		namespace, podName = ns, name
+	}
+
+	var suggestions []Suggestion
+	for _, pod := range sess.GetCachedPods() {
+		if podName != "" && pod.PodName != podName {
+			continue
+		}
+		if namespace != "" && pod.Namespace != namespace {
+			continue
+		}
+		for _, container := range pod.Containers {
+			suggestions = append(suggestions, Suggestion{
+				Text:        container.Name,
+				Description: fmt.Sprintf("%s/%s", pod.Namespace, pod.PodName),
+			})
+		}
+	}
+	return suggestions
+}
+
+// QualifiedPods 补全 namespace/name 形式的 Running Pod（--pod 等选项）
+func QualifiedPods(sess *session.Session, _ []string) []Suggestion {
+	var suggestions []Suggestion
+	for _, pod := range sess.GetCachedPods() {
+		if pod.Status == "Running" {
+			suggestions = append(suggestions, Suggestion{Text: pod.Namespace + "/" + pod.PodName, Description: pod.NodeName})
+		}
+	}
+	return suggestions
+}
+
+// Nodes 补全节点缓存中的节点名
+func Nodes(sess *session.Session, _ []string) []Suggestion {
+	var suggestions []Suggestion
+	for _, node := range sess.GetCachedNodes() {
+		suggestions = append(suggestions, Suggestion{Text: node.Name, Description: node.InternalIP})
+	}
+	return suggestions
+}
+
+// ServiceAccounts 补全数据库中已扫描的 SA（namespace/name）
+func ServiceAccounts(sess *session.Session, _ []string) []Suggestion {
+	var suggestions []Suggestion
+	if sess.SADB != nil {
+		if sas, err := sess.SADB.GetAll(); err == nil {
+			for _, sa := range sas {
+				desc := sa.RiskLevel
+				if sa.IsClusterAdmin {
+					desc = "ADMIN"
+				}
+				suggestions = append(suggestions, Suggestion{
+					Text:        fmt.Sprintf("%s/%s", sa.Namespace, sa.Name),
+					Description: desc,
+				})
+			}
+		}
+	}
+	if len(suggestions) == 0 {
+		suggestions = []Suggestion{{Text: "<namespace/sa-name>", Description: "先执行 sa scan 扫描 SA"}}
+	}
+	return suggestions
+}
+
+// SavedFilters 补全已保存的过滤器（@name）
+func SavedFilters(*session.Session, []string) []Suggestion {
+	saved, err := filter.Saved()
+	if err != nil {
+		return nil
+	}
+	var suggestions []Suggestion
+	for name, expr := range saved {
+		suggestions = append(suggestions, Suggestion{Text: "@" + name, Description: expr})
+	}
+	sort.Slice(suggestions, func(i, j int) bool { return suggestions[i].Text < suggestions[j].Text })
+	return suggestions
+}
+
+// 常用的固定候选
+var (
+	// Shells 常见 shell 路径
+	Shells = Choices(
+		Suggestion{Text: "/bin/bash", Description: "Bash shell"},
+		Suggestion{Text: "/bin/sh", Description: "Bourne shell"},
+		Suggestion{Text: "/bin/ash", Description: "Alpine shell"},
+		Suggestion{Text: "/bin/zsh", Description: "Z shell"},
+		Suggestion{Text: "/usr/bin/bash", Description: "Bash shell"},
+		Suggestion{Text: "/usr/bin/zsh", Description: "Z shell"},
+	)
+
+	// Concurrency 并发数
+	Concurrency = Choices(
+		Suggestion{Text: "50", Description: "低并发"},
+		Suggestion{Text: "100", Description: "默认"},
+		Suggestion{Text: "200", Description: "高并发"},
+	)
+
+	// Severities 严重程度
+	Severities = Choices(
+		Suggestion{Text: "critical", Description: "CRITICAL"},
+		Suggestion{Text: "high", Description: "HIGH"},
+		Suggestion{Text: "medium", Description: "MEDIUM"},
+	)
+)
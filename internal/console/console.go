@@ -5,25 +5,46 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/c-bata/go-prompt"
 
 	"kctl/config"
+	"kctl/internal/client"
 	"kctl/internal/console/commands"
+	"kctl/internal/console/history"
+	"kctl/internal/db"
+	"kctl/internal/i18n"
+	"kctl/internal/notify"
 	"kctl/internal/session"
 	"kctl/pkg/token"
 )
 
 // Options 控制台启动选项
 type Options struct {
-	Target    string // Kubelet IP
-	Port      int    // Kubelet 端口
-	TokenFile string // Token 文件路径
-	Token     string // Token 字符串
-	Proxy     string // SOCKS5 代理
-	APIServer string // API Server 地址
-	APIPort   int    // API Server 端口
+	Target      string // Kubelet IP
+	Port        int    // Kubelet 端口
+	Profile     string // 配置文件中的 profile，为空时使用 default-profile
+	TokenFile   string // Token 文件路径
+	Token       string // Token 字符串
+	CertFile    string // 客户端证书文件
+	KeyFile     string // 客户端私钥文件
+	Kubeconfig  string // kubeconfig 文件路径
+	KubeContext string // kubeconfig 上下文，为空时使用 current-context
+	Proxy       string // 代理地址 (socks5 / http / https)
+	APIServer   string // API Server 地址
+	APIPort     int    // API Server 端口
+	RulesFile   string // 自定义规则文件
+	DBPath      string // 数据库文件路径或 postgres:// 连接串，为空时使用内存数据库
+	Viewer      bool   // 以只读方式打开 DBPath，禁用所有访问集群的命令
+	RawDump     string // Kubelet 原始响应保存目录
+	FailOn      string // 单条命令模式下存在不低于该风险等级的问题时以 commands.ExitCodeFindings 退出
+	NotifyURL   string // 高风险结果通知 Webhook（同 set notify-url）
+	Concurrency int    // 扫描并发数（同 set concurrency）
+	Restore     bool   // 不询问，直接恢复 DBPath 中保存的上次会话状态
+	ReadOnly    bool   // 开启安全模式且不能关闭：禁止 exec、deploy 等修改或执行操作
 }
 
 // Console 交互式控制台
@@ -31,6 +52,9 @@ type Console struct {
 	session  *session.Session
 	executor *Executor
 	exitFlag bool
+	failOn   config.RiskLevel
+	restore  bool
+	search   historySearch
 }
 
 // New 创建控制台（使用默认选项）
@@ -40,9 +64,27 @@ func New() (*Console, error) {
 
 // NewWithOptions 使用指定选项创建控制台
 func NewWithOptions(opts Options) (*Console, error) {
-	sess, err := session.NewSession()
+	// 未通过命令行指定的选项使用 KCTL_* 环境变量
+	if err := applyEnv(&opts); err != nil {
+		return nil, err
+	}
+
+	sess, err := newSession(opts)
 	if err != nil {
-		return nil, fmt.Errorf("创建会话失败: %w", err)
+		return nil, i18n.Errorf("创建会话失败: %w", err)
+	}
+	if opts.ReadOnly {
+		sess.LockSafeMode()
+	}
+
+	// 命令历史，读取失败时只保存在内存中
+	if sess.History, err = history.Load(history.DefaultPath(sess.InPod)); err != nil {
+		sess.History, _ = history.Load("")
+	}
+
+	// 先应用配置文件中的 profile，命令行参数覆盖其中的值
+	if err := applyProfile(sess, opts.Profile); err != nil {
+		return nil, err
 	}
 
 	// 应用命令行参数覆盖
@@ -52,6 +94,12 @@ func NewWithOptions(opts Options) (*Console, error) {
 	if opts.Port > 0 {
 		sess.Config.KubeletPort = opts.Port
 	}
+	// 先加载 kubeconfig，其余命令行参数覆盖其中的值
+	if opts.Kubeconfig != "" {
+		if _, err := sess.LoadKubeconfig(opts.Kubeconfig, opts.KubeContext); err != nil {
+			return nil, err
+		}
+	}
 	if opts.TokenFile != "" {
 		if tokenStr, err := token.Read(opts.TokenFile); err == nil {
 			sess.Config.Token = tokenStr
@@ -61,31 +109,118 @@ func NewWithOptions(opts Options) (*Console, error) {
 	if opts.Token != "" {
 		sess.Config.Token = opts.Token
 	}
+	if opts.CertFile != "" {
+		if err := sess.LoadClientCert(opts.CertFile); err != nil {
+			return nil, err
+		}
+	}
+	if opts.KeyFile != "" {
+		if err := sess.LoadClientKey(opts.KeyFile); err != nil {
+			return nil, err
+		}
+	}
 	if opts.Proxy != "" {
 		sess.Config.ProxyURL = opts.Proxy
 	}
+	if opts.RawDump != "" {
+		if err := client.PrepareRawDumpDir(opts.RawDump); err != nil {
+			return nil, err
+		}
+		sess.Config.RawDumpDir = opts.RawDump
+	}
+	if opts.NotifyURL != "" {
+		if err := notify.ValidateURL(opts.NotifyURL); err != nil {
+			return nil, err
+		}
+		sess.Config.NotifyURL = opts.NotifyURL
+	}
 	if opts.APIServer != "" {
 		sess.Config.APIServer = opts.APIServer
 	}
 	if opts.APIPort > 0 {
 		sess.Config.APIServerPort = opts.APIPort
 	}
+	if opts.Concurrency > 0 {
+		sess.Config.Concurrency = opts.Concurrency
+	}
 
 	c := &Console{
 		session:  sess,
 		executor: NewExecutor(sess),
+		restore:  opts.Restore,
+	}
+	if opts.FailOn != "" {
+		level, err := commands.ParseFailOnLevel(opts.FailOn)
+		if err != nil {
+			return nil, err
+		}
+		c.failOn = level
+	}
+
+	if opts.RulesFile != "" {
+		if err := commands.ApplyRulesFile(sess, opts.RulesFile); err != nil {
+			return nil, err
+		}
 	}
 
 	return c, nil
 }
 
+// applyProfile 应用 --profile 指定的 profile，未指定时使用配置文件中的 default-profile
+func applyProfile(sess *session.Session, name string) error {
+	cfg, err := config.LoadUserConfig()
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = cfg.DefaultProfile
+	}
+	if name == "" {
+		return nil
+	}
+	profile, err := cfg.Profile(name)
+	if err != nil {
+		return err
+	}
+	if err := commands.ApplyProfile(sess, name, profile); err != nil {
+		return i18n.Errorf("应用 profile %s 失败: %w", name, err)
+	}
+	return nil
+}
+
+// newSession 按数据库选项创建会话
+func newSession(opts Options) (*session.Session, error) {
+	switch {
+	case opts.Viewer:
+		if opts.DBPath == "" {
+			return nil, i18n.Errorf("--viewer 需要通过 --db 指定数据库文件")
+		}
+		database, err := db.OpenReadOnly(opts.DBPath)
+		if err != nil {
+			return nil, err
+		}
+		return session.NewSessionWithDB(database), nil
+	case opts.DBPath != "":
+		database, err := db.Open(opts.DBPath)
+		if err != nil {
+			return nil, err
+		}
+		return session.NewSessionWithDB(database), nil
+	default:
+		return session.NewSession()
+	}
+}
+
 // Run 运行控制台主循环
 func (c *Console) Run() {
 	// 打印 Banner
 	PrintBanner(c.session)
 
-	// 自动连接
+	// 恢复上次的会话后自动连接
+	st := c.restoreSession(true)
 	c.autoConnect()
+	c.restoreCurrentSA(st)
+	c.printAdminBanner()
 
 	// 创建 prompt
 	p := prompt.New(
@@ -98,571 +233,167 @@ func (c *Console) Run() {
 		prompt.OptionPreviewSuggestionTextColor(prompt.Blue),
 		prompt.OptionSelectedSuggestionBGColor(prompt.LightGray),
 		prompt.OptionSuggestionBGColor(prompt.DarkGray),
+		prompt.OptionHistory(c.session.History.Entries()),
+		prompt.OptionAddKeyBind(prompt.KeyBind{Key: prompt.ControlR, Fn: c.reverseSearch}),
 	)
 
 	// 运行主循环
+	c.session.Interactive = true
 	p.Run()
 }
 
-// executorWrapper 命令执行包装器
-func (c *Console) executorWrapper(input string) {
-	c.executor.Execute(input)
-}
-
-// completer 自动补全
-func (c *Console) completer(d prompt.Document) []prompt.Suggest {
-	// 获取当前输入
-	text := d.TextBeforeCursor()
-	if text == "" {
-		return nil
-	}
-
-	args := parseArgs(text)
-	if len(args) == 0 {
-		return c.getCommandSuggestions("")
-	}
-
-	cmd := args[0]
-	word := d.GetWordBeforeCursor()
-
-	// 如果只有一个词且没有空格，补全命令
-	if len(args) == 1 && !strings.HasSuffix(text, " ") {
-		return c.getCommandSuggestions(word)
-	}
-
-	// 根据命令补全参数
-	switch cmd {
-	case "mode":
-		return c.getModeSuggestions(word)
-	case "exec":
-		return c.getExecSuggestions(args, word)
-	case "set":
-		return c.getSetSuggestions(word)
-	case "show":
-		return c.getShowSuggestions(word)
-	case "export":
-		return c.getExportSuggestions(word)
-	case "help", "?", "h":
-		return c.getCommandSuggestions(word)
-	case "sa":
-		return c.getSASuggestions(args, word)
-	case "pods", "po":
-		return c.getPodsFlagSuggestions(word)
-	case "scan":
-		return c.getScanFlagSuggestions(word)
-	case "discover", "disc":
-		return c.getDiscoverSuggestions(args, word)
-	case "run":
-		return c.getRunSuggestions(args, word)
-	case "portforward", "pf":
-		return c.getPortForwardSuggestions(args, word)
-	case "pid2pod", "p2p":
-		return c.getPid2PodSuggestions(word)
-	}
-
-	return nil
-}
-
-// getCommandSuggestions 获取命令建议
-func (c *Console) getCommandSuggestions(prefix string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "help", Description: "显示帮助信息"},
-		{Text: "mode", Description: "查看或切换运行模式"},
-		{Text: "connect", Description: "连接到 Kubelet"},
-		{Text: "discover", Description: "扫描网络发现 Kubelet"},
-		{Text: "sa", Description: "ServiceAccount 操作"},
-		{Text: "pods", Description: "列出 Pod"},
-		{Text: "exec", Description: "执行命令 (WebSocket)"},
-		{Text: "run", Description: "执行命令 (/run API)"},
-		{Text: "portforward", Description: "端口转发"},
-		{Text: "pid2pod", Description: "将 PID 映射到 Pod"},
-		{Text: "set", Description: "设置配置"},
-		{Text: "show", Description: "显示信息"},
-		{Text: "export", Description: "导出结果"},
-		{Text: "clear", Description: "清除缓存"},
-		{Text: "exit", Description: "退出控制台"},
-	}
-	return prompt.FilterHasPrefix(suggestions, prefix, true)
-}
-
-// getUseSuggestions 获取 use 命令的 SA 补全
-func (c *Console) getUseSuggestions(word string) []prompt.Suggest {
-	var suggestions []prompt.Suggest
-
-	// 从数据库获取已扫描的 SA
-	if c.session.SADB != nil {
-		sas, err := c.session.SADB.GetAll()
-		if err == nil {
-			for _, sa := range sas {
-				saName := fmt.Sprintf("%s/%s", sa.Namespace, sa.Name)
-				desc := sa.RiskLevel
-				if sa.IsClusterAdmin {
-					desc = "ADMIN"
-				}
-				suggestions = append(suggestions, prompt.Suggest{
-					Text:        saName,
-					Description: desc,
-				})
-			}
-		}
-	}
-
-	if len(suggestions) == 0 {
-		suggestions = []prompt.Suggest{
-			{Text: "<namespace/sa-name>", Description: "先执行 scan 扫描 SA"},
-		}
-	}
-
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
-
-// getExecSuggestions 获取 exec 命令的补全
-func (c *Console) getExecSuggestions(args []string, word string) []prompt.Suggest {
-	// 检查是否已经有 -- 分隔符
-	for _, arg := range args {
-		if arg == "--" {
-			return nil // -- 之后不补全
-		}
-	}
-
-	// 检查上一个参数，决定补全什么
-	if len(args) >= 2 {
-		lastArg := args[len(args)-1]
-		// 如果当前正在输入（word 不为空），检查倒数第二个参数
-		if word != "" && len(args) >= 2 {
-			lastArg = args[len(args)-2]
-		}
-
-		switch lastArg {
-		case "--shell":
-			// 补全 shell 路径
-			return c.getShellSuggestions(word)
-		case "-n":
-			// 补全命名空间
-			return c.getNamespaceSuggestions(word)
-		case "-c":
-			// 补全容器名
-			return c.getContainerSuggestions(args, word)
-		case "--filter":
-			// 补全要排除的 Pod
-			return c.getFilterPodSuggestions(word)
-		case "--filter-ns":
-			// 补全要排除的命名空间
-			return c.getNamespaceSuggestions(word)
-		case "--concurrency":
-			// 补全并发数
-			return c.getConcurrencySuggestions(word)
-		}
-	}
-
-	var suggestions []prompt.Suggest
-
-	// 补全选项
-	suggestions = append(suggestions,
-		prompt.Suggest{Text: "-it", Description: "交互式 shell"},
-		prompt.Suggest{Text: "--shell", Description: "指定 shell 路径"},
-		prompt.Suggest{Text: "-n", Description: "指定命名空间"},
-		prompt.Suggest{Text: "-c", Description: "指定容器"},
-		prompt.Suggest{Text: "--all-pods", Description: "在所有 Pod 中执行"},
-		prompt.Suggest{Text: "--filter", Description: "排除指定 Pod（逗号分隔）"},
-		prompt.Suggest{Text: "--filter-ns", Description: "排除指定命名空间（逗号分隔）"},
-		prompt.Suggest{Text: "--concurrency", Description: "并发数（默认: 10）"},
-		prompt.Suggest{Text: "--", Description: "命令分隔符"},
-	)
-
-	// 补全 Pod 名称
-	pods := c.session.GetCachedPods()
-	for _, pod := range pods {
-		if pod.Status == "Running" {
-			suggestions = append(suggestions, prompt.Suggest{
-				Text:        pod.PodName,
-				Description: pod.Namespace,
-			})
-		}
-	}
-
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
+// RunOnce 自动连接后执行单条命令，返回进程退出码
+func (c *Console) RunOnce(input string) int {
+	st := c.restoreSession(false)
+	c.autoConnect()
+	c.restoreCurrentSA(st)
 
-// getShellSuggestions 获取 shell 路径补全
-func (c *Console) getShellSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "/bin/bash", Description: "Bash shell"},
-		{Text: "/bin/sh", Description: "Bourne shell"},
-		{Text: "/bin/ash", Description: "Alpine shell"},
-		{Text: "/bin/zsh", Description: "Z shell"},
-		{Text: "/usr/bin/bash", Description: "Bash shell"},
-		{Text: "/usr/bin/zsh", Description: "Z shell"},
+	err := c.executor.Run(input)
+	if err != nil {
+		c.session.Printer.Error(err.Error())
 	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
+	return c.exitCode(err)
 }
 
-// getNamespaceSuggestions 获取命名空间补全
-func (c *Console) getNamespaceSuggestions(word string) []prompt.Suggest {
-	var suggestions []prompt.Suggest
-	seen := make(map[string]bool)
-
-	// 从缓存的 Pod 中获取命名空间
-	pods := c.session.GetCachedPods()
-	for _, pod := range pods {
-		if !seen[pod.Namespace] {
-			seen[pod.Namespace] = true
-			suggestions = append(suggestions, prompt.Suggest{
-				Text:        pod.Namespace,
-				Description: "namespace",
-			})
-		}
+// RunOffline 不连接集群，直接执行已拆分的命令参数，返回退出码（kctl analyze 使用）
+func (c *Console) RunOffline(args []string) int {
+	err := c.executor.RunArgs(args)
+	if err != nil {
+		c.session.Printer.Error(err.Error())
 	}
-
-	return prompt.FilterHasPrefix(suggestions, word, true)
+	return c.exitCode(err)
 }
 
-// getContainerSuggestions 获取容器名补全
-func (c *Console) getContainerSuggestions(args []string, word string) []prompt.Suggest {
-	var suggestions []prompt.Suggest
-
-	// 尝试找到指定的 Pod
-	podName := ""
-	namespace := ""
-	for i, arg := range args {
-		if arg == "-n" && i+1 < len(args) {
-			namespace = args[i+1]
-		}
-		if !strings.HasPrefix(arg, "-") && arg != "exec" {
-			podName = arg
-		}
-	}
-
-	pods := c.session.GetCachedPods()
-	for _, pod := range pods {
-		// 如果指定了 Pod 名，只显示该 Pod 的容器
-		if podName != "" && pod.PodName != podName {
-			continue
-		}
-		// 如果指定了命名空间，过滤
-		if namespace != "" && pod.Namespace != namespace {
-			continue
-		}
-
-		for _, container := range pod.Containers {
-			suggestions = append(suggestions, prompt.Suggest{
-				Text:        container.Name,
-				Description: fmt.Sprintf("%s/%s", pod.Namespace, pod.PodName),
-			})
-		}
+// exitCode 计算单条命令模式的退出码：命令失败时为命令的退出码，
+// 否则设置了 --fail-on 且存在不低于该等级的问题时为 commands.ExitCodeFindings
+func (c *Console) exitCode(err error) int {
+	if err != nil || c.failOn == "" {
+		return commands.ExitCode(err)
 	}
-
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
-
-// getFilterPodSuggestions 获取 filter 的 Pod 补全
-func (c *Console) getFilterPodSuggestions(word string) []prompt.Suggest {
-	var suggestions []prompt.Suggest
-	pods := c.session.GetCachedPods()
-	for _, pod := range pods {
-		if pod.Status == "Running" {
-			suggestions = append(suggestions, prompt.Suggest{
-				Text:        pod.PodName,
-				Description: fmt.Sprintf("排除 %s", pod.Namespace),
-			})
+	p := c.session.Printer
+	counts, err := commands.CountIssuesAtOrAbove(c.session, c.failOn)
+	if err != nil {
+		p.Error(i18n.Tf("--fail-on 统计失败: %v", err))
+		return 1
+	}
+	var parts []string
+	total := 0
+	for _, level := range []config.RiskLevel{config.RiskAdmin, config.RiskCritical, config.RiskHigh, config.RiskMedium, config.RiskLow} {
+		if counts[level] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[level], level))
+			total += counts[level]
 		}
 	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
-
-// getSetSuggestions 获取 set 命令建议
-func (c *Console) getSetSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "target", Description: "Kubelet IP 地址"},
-		{Text: "port", Description: "Kubelet 端口"},
-		{Text: "token", Description: "Token 字符串"},
-		{Text: "token-file", Description: "Token 文件路径"},
-		{Text: "api-server", Description: "API Server 地址"},
-		{Text: "api-port", Description: "API Server 端口"},
-		{Text: "proxy", Description: "SOCKS5 代理地址"},
-		{Text: "concurrency", Description: "扫描并发数"},
+	if total == 0 {
+		p.Printf("%s No issues at or above %s\n", p.Colored(config.ColorGreen, "[+]"), c.failOn)
+		return 0
 	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
+	p.Printf("%s %d issue(s) at or above %s (%s), exiting with code %d\n",
+		p.Colored(config.ColorRed, "[!]"), total, c.failOn, strings.Join(parts, ", "), commands.ExitCodeFindings)
+	return commands.ExitCodeFindings
 }
 
-// getShowSuggestions 获取 show 命令建议
-func (c *Console) getShowSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "options", Description: "显示当前配置"},
-		{Text: "status", Description: "显示会话状态"},
-		{Text: "env", Description: "显示环境信息"},
-	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
+// executorWrapper 命令执行包装器
+func (c *Console) executorWrapper(input string) {
+	_ = c.session.History.Add(input)
+	c.executor.Execute(input)
+	c.printAdminBanner()
 }
 
-// getExportSuggestions 获取 export 命令建议
-func (c *Console) getExportSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "json", Description: "JSON 格式"},
-		{Text: "csv", Description: "CSV 格式"},
+// printAdminBanner 当前 SA 为 cluster-admin 时在提示符上方显示红色警示
+// go-prompt 的提示符不支持 ANSI 颜色，因此在每条命令后单独输出一行
+func (c *Console) printAdminBanner() {
+	if !c.session.IsAdminActive() {
+		return
 	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
+	sa := c.session.GetCurrentSA()
+	p := c.session.Printer
+	p.Printf("%s\n", p.Colored(config.ColorRed,
+		fmt.Sprintf("[!] ADMIN: operating as cluster-admin %s/%s (destructive commands require %s)",
+			sa.Namespace, sa.Name, commands.ConfirmFlag)))
 }
 
-func (c *Console) getModeSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "kubelet", Description: "Kubelet API (10250)"},
-		{Text: "kubernetes", Description: "API Server (6443)"},
+// completer 自动补全，候选由各命令的 Flags / Suggestions 提供
+func (c *Console) completer(d prompt.Document) []prompt.Suggest {
+	// 获取当前输入
+	text := d.TextBeforeCursor()
+	if text == "" {
+		return nil
 	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
 
-// getSAFlagSuggestions 获取 sa 命令的选项补全
-func (c *Console) getSAFlagSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "scan", Description: "扫描所有 Pod 的 SA Token"},
-		{Text: "list", Description: "列出已扫描的 SA"},
-		{Text: "use", Description: "选择 SA 作为当前身份"},
-		{Text: "info", Description: "显示当前 SA 详情"},
-		{Text: "--admin", Description: "只显示 cluster-admin"},
-		{Text: "--risky", Description: "只显示有风险的 SA"},
-		{Text: "-n", Description: "按命名空间过滤"},
-		{Text: "--perms", Description: "显示权限"},
-		{Text: "--token", Description: "显示 Token"},
-	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
+	word := d.GetWordBeforeCursor()
 
-func (c *Console) getSASuggestions(args []string, word string) []prompt.Suggest {
-	if len(args) >= 2 {
-		subCmd := args[1]
-		if word != "" && len(args) == 2 {
-			return c.getSAFlagSuggestions(word)
+	// 管道之后补全过滤命令
+	if segments := splitPipe(text); len(segments) > 1 {
+		last := segments[len(segments)-1]
+		// 补全会替换光标前的整个单词，| 前后没有空格时不补全
+		if args := parseArgs(last); len(args) > 1 || (len(args) == 1 && strings.HasSuffix(last, " ")) || strings.Contains(word, "|") {
+			return nil
 		}
-		switch subCmd {
-		case "use":
-			return c.getUseSuggestions(word)
-		case "scan":
-			return c.getScanFlagSuggestions(word)
-		case "list":
-			return c.getSAListFlagSuggestions(word)
+		var suggestions []prompt.Suggest
+		for _, s := range pipeCommands {
+			suggestions = append(suggestions, prompt.Suggest{Text: s.Text, Description: i18n.T(s.Description)})
 		}
+		return prompt.FilterHasPrefix(suggestions, word, true)
 	}
-	return c.getSAFlagSuggestions(word)
-}
-
-func (c *Console) getSAListFlagSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "--admin", Description: "只显示 cluster-admin"},
-		{Text: "--risky", Description: "只显示有风险的 SA"},
-		{Text: "-n", Description: "按命名空间过滤"},
-		{Text: "--perms", Description: "显示权限"},
-		{Text: "--token", Description: "显示 Token"},
-	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
-
-// getPodsFlagSuggestions 获取 pods 命令的选项补全
-func (c *Console) getPodsFlagSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "--detail", Description: "显示详细信息"},
-		{Text: "--privileged", Description: "只显示特权 Pod"},
-		{Text: "--running", Description: "只显示 Running 状态"},
-		{Text: "-n", Description: "按命名空间过滤"},
-		{Text: "--refresh", Description: "强制刷新"},
-	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
 
-// getScanFlagSuggestions 获取 scan 命令的选项补全
-func (c *Console) getScanFlagSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "--risky", Description: "只显示有风险的 SA"},
-		{Text: "--perms", Description: "显示权限"},
-		{Text: "--token", Description: "显示 Token"},
+	args := parseArgs(text)
+	if len(args) == 0 {
+		return c.getCommandSuggestions("")
 	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
-
-// getDiscoverSuggestions 获取 discover 命令的选项补全
-func (c *Console) getDiscoverSuggestions(args []string, word string) []prompt.Suggest {
-	// 检查上一个参数，决定补全什么
-	if len(args) >= 2 {
-		lastArg := args[len(args)-1]
-		// 如果当前正在输入（word 不为空），检查倒数第二个参数
-		if word != "" && len(args) >= 2 {
-			lastArg = args[len(args)-2]
-		}
 
-		switch lastArg {
-		case "-p", "--port":
-			// 补全端口
-			return c.getPortSuggestions(word)
-		case "-c", "--concurrency":
-			// 补全并发数
-			return c.getConcurrencySuggestions(word)
-		case "-t", "--timeout":
-			// 补全超时
-			return c.getTimeoutSuggestions(word)
-		}
+	// 如果只有一个词且没有空格，补全命令
+	if len(args) == 1 && !strings.HasSuffix(text, " ") {
+		return c.getCommandSuggestions(word)
 	}
 
-	suggestions := []prompt.Suggest{
-		{Text: "-p", Description: "端口 (默认: 10250)"},
-		{Text: "--port", Description: "端口 (默认: 10250)"},
-		{Text: "-c", Description: "并发数 (默认: 100)"},
-		{Text: "--concurrency", Description: "并发数 (默认: 100)"},
-		{Text: "-t", Description: "超时秒数 (默认: 3)"},
-		{Text: "--timeout", Description: "超时秒数 (默认: 3)"},
-		{Text: "--all", Description: "显示所有开放端口"},
+	cmd, ok := commands.Get(args[0])
+	if !ok {
+		return nil
 	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
-
-// getRunSuggestions 获取 run 命令的补全
-func (c *Console) getRunSuggestions(args []string, word string) []prompt.Suggest {
-	// 检查上一个参数，决定补全什么
-	if len(args) >= 2 {
-		lastArg := args[len(args)-1]
-		// 如果当前正在输入（word 不为空），检查倒数第二个参数
-		if word != "" && len(args) >= 2 {
-			lastArg = args[len(args)-2]
-		}
 
-		switch lastArg {
-		case "-n":
-			// 补全命名空间
-			return c.getNamespaceSuggestions(word)
-		case "-c":
-			// 补全容器名
-			return c.getContainerSuggestions(args, word)
-		case "--filter":
-			// 补全要排除的 Pod
-			return c.getFilterPodSuggestions(word)
-		case "--filter-ns":
-			// 补全要排除的命名空间
-			return c.getNamespaceSuggestions(word)
-		case "--concurrency":
-			// 补全并发数
-			return c.getConcurrencySuggestions(word)
-		case "--cmd":
-			// 命令参数，不补全
-			return nil
-		}
+	// 正在输入的词不计入已完成的参数
+	rest := args[1:]
+	if word != "" && len(rest) > 0 {
+		rest = rest[:len(rest)-1]
 	}
 
 	var suggestions []prompt.Suggest
-
-	// 补全选项
-	suggestions = append(suggestions,
-		prompt.Suggest{Text: "--cmd", Description: "要执行的命令（必需）"},
-		prompt.Suggest{Text: "-n", Description: "指定命名空间"},
-		prompt.Suggest{Text: "-c", Description: "指定容器"},
-		prompt.Suggest{Text: "--all-pods", Description: "在所有 Pod 中执行"},
-		prompt.Suggest{Text: "--filter", Description: "排除指定 Pod（逗号分隔）"},
-		prompt.Suggest{Text: "--filter-ns", Description: "排除指定命名空间（逗号分隔）"},
-		prompt.Suggest{Text: "--concurrency", Description: "并发数（默认: 10）"},
-	)
-
-	// 补全 Pod 名称
-	pods := c.session.GetCachedPods()
-	for _, pod := range pods {
-		if pod.Status == "Running" {
-			suggestions = append(suggestions, prompt.Suggest{
-				Text:        pod.PodName,
-				Description: pod.Namespace,
-			})
-		}
-	}
-
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
-
-// getPortSuggestions 获取端口补全
-func (c *Console) getPortSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "10250", Description: "Kubelet API (默认)"},
-		{Text: "10255", Description: "Kubelet 只读端口"},
-		{Text: "10250,10255", Description: "常用 Kubelet 端口"},
-	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
-
-// getConcurrencySuggestions 获取并发数补全
-func (c *Console) getConcurrencySuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "50", Description: "低并发"},
-		{Text: "100", Description: "默认"},
-		{Text: "200", Description: "高并发"},
-	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
-
-// getTimeoutSuggestions 获取超时补全
-func (c *Console) getTimeoutSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "1", Description: "1 秒"},
-		{Text: "3", Description: "3 秒 (默认)"},
-		{Text: "5", Description: "5 秒"},
+	for _, s := range commands.Suggest(c.session, cmd, rest) {
+		suggestions = append(suggestions, prompt.Suggest{Text: s.Text, Description: s.Description})
 	}
 	return prompt.FilterHasPrefix(suggestions, word, true)
 }
 
-// getPid2PodSuggestions 获取 pid2pod 命令的补全
-func (c *Console) getPid2PodSuggestions(word string) []prompt.Suggest {
-	suggestions := []prompt.Suggest{
-		{Text: "--pid", Description: "只查看指定 PID"},
-		{Text: "--all", Description: "显示所有进程（包括非容器进程）"},
-	}
-	return prompt.FilterHasPrefix(suggestions, word, true)
-}
-
-// getPortForwardSuggestions 获取 portforward 命令的补全
-func (c *Console) getPortForwardSuggestions(args []string, word string) []prompt.Suggest {
-	// 检查上一个参数
-	if len(args) >= 2 {
-		lastArg := args[len(args)-1]
-		if word != "" && len(args) >= 2 {
-			lastArg = args[len(args)-2]
-		}
-
-		switch lastArg {
-		case "-n":
-			return c.getNamespaceSuggestions(word)
-		case "--address":
-			return []prompt.Suggest{
-				{Text: "127.0.0.1", Description: "仅本地访问（默认）"},
-				{Text: "0.0.0.0", Description: "所有接口"},
-			}
-		}
-	}
-
+// getCommandSuggestions 获取命令建议
+func (c *Console) getCommandSuggestions(prefix string) []prompt.Suggest {
 	var suggestions []prompt.Suggest
-
-	// 补全选项
-	suggestions = append(suggestions,
-		prompt.Suggest{Text: "-n", Description: "指定命名空间"},
-		prompt.Suggest{Text: "--address", Description: "监听地址"},
-	)
-
-	// 补全 Pod 名称
-	pods := c.session.GetCachedPods()
-	for _, pod := range pods {
-		if pod.Status == "Running" {
-			suggestions = append(suggestions, prompt.Suggest{
-				Text:        pod.PodName,
-				Description: pod.Namespace,
-			})
-		}
+	for _, cmd := range commands.All() {
+		suggestions = append(suggestions, prompt.Suggest{Text: cmd.Name(), Description: i18n.T(cmd.Description())})
 	}
-
-	return prompt.FilterHasPrefix(suggestions, word, true)
+	sort.Slice(suggestions, func(i, j int) bool { return suggestions[i].Text < suggestions[j].Text })
+	return prompt.FilterHasPrefix(suggestions, prefix, true)
 }
 
 // getPrompt 获取提示符
 func (c *Console) getPrompt() string {
-	return fmt.Sprintf("kctl [%s]> ", c.session.GetPromptDisplay())
+	if c.session.IsViewer() {
+		return fmt.Sprintf("kctl [viewer:%s]> ", filepath.Base(c.session.DB.DisplayPath()))
+	}
+	safe := ""
+	if c.session.IsSafeMode() {
+		safe = " [safe]"
+	}
+	if c.session.IsAdminActive() {
+		return fmt.Sprintf("kctl [%s]%s !ADMIN!> ", c.session.GetPromptDisplay(), safe)
+	}
+	return fmt.Sprintf("kctl [%s]%s> ", c.session.GetPromptDisplay(), safe)
 }
 
 // getLivePrefix 动态获取提示符
 // 注意：go-prompt 不支持在提示符中使用 ANSI 颜色代码，所以这里不着色
 func (c *Console) getLivePrefix() (string, bool) {
-	return fmt.Sprintf("kctl [%s]> ", c.session.GetPromptDisplay()), true
+	return c.getPrompt(), true
 }
 
 // autoConnect 自动连接到 Kubelet
@@ -670,14 +401,19 @@ func (c *Console) autoConnect() {
 	p := c.session.Printer
 	ctx := context.Background()
 
+	// 查看模式不连接集群
+	if c.session.IsViewer() {
+		return
+	}
+
 	// 检查是否有足够的配置信息
 	if c.session.Config.KubeletIP == "" {
-		p.Warning("未检测到 Kubelet IP，请使用 'set target <ip>' 设置后执行 'connect'")
+		p.Warning(i18n.T("未检测到 Kubelet IP，请使用 'set target <ip>' 设置后执行 'connect'"))
 		return
 	}
 
-	if c.session.Config.Token == "" {
-		p.Warning("未检测到 Token，请使用 'set token <token>' 设置后执行 'connect'")
+	if !c.session.HasCredentials() {
+		p.Warning(i18n.T("未检测到 Token 或客户端证书，请使用 'set token <token>' 或 'set client-cert <path>' 设置后执行 'connect'"))
 		return
 	}
 
@@ -688,31 +424,37 @@ func (c *Console) autoConnect() {
 
 	// 连接
 	if err := c.session.Connect(); err != nil {
-		p.Error(fmt.Sprintf("自动连接失败: %v", err))
-		p.Info("请检查配置后手动执行 'connect'")
+		p.Error(i18n.Tf("自动连接失败: %v", err))
+		p.Info(i18n.T("请检查配置后手动执行 'connect'"))
 		return
 	}
 
 	// 验证连接
 	kubelet, err := c.session.GetKubeletClient()
 	if err != nil {
-		p.Error(fmt.Sprintf("获取客户端失败: %v", err))
+		p.Error(i18n.Tf("获取客户端失败: %v", err))
 		return
 	}
 
 	// 尝试验证 Kubelet 端口
 	result, err := kubelet.ValidatePort(ctx)
 	if err != nil {
-		p.Warning(fmt.Sprintf("连接成功，但无法验证 Kubelet 端口: %v", err))
+		p.Warning(i18n.Tf("连接成功，但无法验证 Kubelet 端口: %v", err))
 	} else if result.IsKubelet {
 		p.Success("Connected successfully")
 	} else {
-		p.Warning("连接成功，但目标可能不是 Kubelet")
+		p.Warning(i18n.T("连接成功，但目标可能不是 Kubelet"))
 	}
 
+	// 版本识别与已知 CVE 匹配
+	commands.FingerprintKubelet(ctx, c.session)
+
+	// 服务端证书审计
+	commands.AuditKubeletCert(ctx, c.session)
+
 	// 解析当前 Token 并设置为当前 SA
 	if err := c.session.SetupCurrentSA(); err != nil {
-		p.Warning(fmt.Sprintf("设置 SA 失败: %v", err))
+		p.Warning(i18n.Tf("设置 SA 失败: %v", err))
 	}
 
 	fmt.Println() // 空行分隔
@@ -723,9 +465,10 @@ func (c *Console) GetSession() *session.Session {
 	return c.session
 }
 
-// Close 关闭控制台
+// Close 保存会话状态后关闭控制台
 func (c *Console) Close() {
 	if c.session != nil {
+		c.saveSession()
 		_ = c.session.Close()
 	}
 }
@@ -753,4 +496,7 @@ func RegisterCommands() {
 	// 在这里注册所有命令
 	// 命令会在各自的 init() 函数中自动注册
 	_ = commands.All() // 触发 init
+
+	// 内置命令注册后再加载插件，重名的插件不会覆盖内置命令
+	commands.LoadPlugins()
 }
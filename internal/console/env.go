@@ -0,0 +1,82 @@
+package console
+
+import (
+	"os"
+	"strconv"
+
+	"kctl/internal/i18n"
+)
+
+// 初始会话配置的环境变量，优先级：命令行参数 > 环境变量 > profile > 自动检测
+const (
+	EnvProfile     = "KCTL_PROFILE"     // 同 --profile
+	EnvTarget      = "KCTL_TARGET"      // 同 -t/--target
+	EnvPort        = "KCTL_PORT"        // 同 -p/--port
+	EnvToken       = "KCTL_TOKEN"       // 同 --token
+	EnvTokenFile   = "KCTL_TOKEN_FILE"  // 同 --token-file
+	EnvClientCert  = "KCTL_CLIENT_CERT" // 同 --client-cert
+	EnvClientKey   = "KCTL_CLIENT_KEY"  // 同 --client-key
+	EnvKubeconfig  = "KCTL_KUBECONFIG"  // 同 --kubeconfig
+	EnvContext     = "KCTL_CONTEXT"     // 同 --context
+	EnvProxy       = "KCTL_PROXY"       // 同 --proxy
+	EnvAPIServer   = "KCTL_API_SERVER"  // 同 --api-server
+	EnvAPIPort     = "KCTL_API_PORT"    // 同 --api-port
+	EnvConcurrency = "KCTL_CONCURRENCY" // 同 set concurrency
+	EnvRules       = "KCTL_RULES"       // 同 --rules
+	EnvDB          = "KCTL_DB"          // 同 --db
+	EnvRawDump     = "KCTL_RAW_DUMP"    // 同 --raw-dump
+	EnvNotifyURL   = "KCTL_NOTIFY_URL"  // 同 --notify-url
+)
+
+// applyEnv 用环境变量填充未通过命令行指定的选项
+// 命令行指定了 --token 或 --token-file 时忽略两个 Token 环境变量，避免环境中的 Token 覆盖命令行
+func applyEnv(opts *Options) error {
+	if opts.Token == "" && opts.TokenFile == "" {
+		opts.Token = os.Getenv(EnvToken)
+		opts.TokenFile = os.Getenv(EnvTokenFile)
+	}
+
+	strs := []struct {
+		field *string
+		env   string
+	}{
+		{&opts.Profile, EnvProfile},
+		{&opts.Target, EnvTarget},
+		{&opts.CertFile, EnvClientCert},
+		{&opts.KeyFile, EnvClientKey},
+		{&opts.Kubeconfig, EnvKubeconfig},
+		{&opts.KubeContext, EnvContext},
+		{&opts.Proxy, EnvProxy},
+		{&opts.APIServer, EnvAPIServer},
+		{&opts.RulesFile, EnvRules},
+		{&opts.DBPath, EnvDB},
+		{&opts.RawDump, EnvRawDump},
+		{&opts.NotifyURL, EnvNotifyURL},
+	}
+	for _, s := range strs {
+		if *s.field == "" {
+			*s.field = os.Getenv(s.env)
+		}
+	}
+
+	ints := []struct {
+		field *int
+		env   string
+	}{
+		{&opts.Port, EnvPort},
+		{&opts.APIPort, EnvAPIPort},
+		{&opts.Concurrency, EnvConcurrency},
+	}
+	for _, i := range ints {
+		value := os.Getenv(i.env)
+		if *i.field > 0 || value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return i18n.Errorf("无效的环境变量 %s=%s (需要正整数)", i.env, value)
+		}
+		*i.field = n
+	}
+	return nil
+}
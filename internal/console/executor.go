@@ -1,9 +1,19 @@
 package console
 
 import (
+	"context"
+	"os"
+	"os/signal"
 	"strings"
+	"time"
+
+	"golang.org/x/term"
 
 	"kctl/internal/console/commands"
+	"kctl/internal/console/history"
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
+	"kctl/internal/output"
 	"kctl/internal/session"
 )
 
@@ -18,16 +28,107 @@ func NewExecutor(sess *session.Session) *Executor {
 }
 
 // Execute 执行命令
+// 分页开启时表格和报告类命令的输出超过终端高度时通过分页器显示
+// tee 开启时命令的全部输出（含错误）同时写入 tee 文件
 func (e *Executor) Execute(input string) {
+	// 分页缓存先于 tee 开始，tee 转发的输出进入分页缓存
+	if e.paged(input) {
+		defer e.page()()
+	}
+	if t := e.session.Tee; t != nil && strings.TrimSpace(input) != "" {
+		defer e.tee(t, strings.TrimSpace(input))()
+	}
+	if err := e.Run(input); err != nil {
+		e.session.Printer.Error(err.Error())
+	}
+}
+
+// tee 将 os.Stdout 和会话打印器重定向到 tee 管道，返回恢复函数
+func (e *Executor) tee(t *output.Tee, input string) func() {
+	w, err := t.Begin(os.Stdout, input)
+	if err != nil {
+		return func() {}
+	}
+
+	origStdout, origPrinter := os.Stdout, e.session.Printer
+	os.Stdout = w
+	p := output.NewPrinterWithWriter(w, w)
+	p.SetWidth(origPrinter.Width())
+	e.session.Printer = p
+
+	return func() {
+		t.End()
+		os.Stdout, e.session.Printer = origStdout, origPrinter
+	}
+}
+
+// paged 是否需要分页显示该命令的输出
+func (e *Executor) paged(input string) bool {
+	if !e.session.Config.Pager || !e.session.Interactive || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return false
+	}
+	cmdLine, _, out, err := parsePipeline(strings.TrimSpace(input))
+	if err != nil || out != nil {
+		return false
+	}
+	args := parseArgs(cmdLine)
+	if len(args) == 0 {
+		return false
+	}
+	cmd, ok := commands.Get(args[0])
+	if !ok {
+		return false
+	}
+	p, ok := cmd.(commands.Paged)
+	return ok && p.IsPaged(args[1:])
+}
+
+// page 将 os.Stdout 和会话打印器重定向到分页缓存，返回的恢复函数将缓存的输出交给分页器
+func (e *Executor) page() func() {
+	c, err := output.BeginCapture()
+	if err != nil {
+		return func() {}
+	}
+
+	origStdout, origPrinter := os.Stdout, e.session.Printer
+	os.Stdout = c.Writer()
+	p := output.NewPrinterWithWriter(c.Writer(), c.Writer())
+	p.SetWidth(origPrinter.Width())
+	e.session.Printer = p
+
+	return func() {
+		data := c.End()
+		os.Stdout, e.session.Printer = origStdout, origPrinter
+		if err := output.Page(os.Stdout, os.Stdin, data); err != nil {
+			e.session.Printer.Error(err.Error())
+		}
+	}
+}
+
+// Run 执行命令并返回错误（不打印）
+// 支持通过管道过滤输出，如 pods | grep nginx，以及将输出重定向到文件，如 pods > pods.txt
+func (e *Executor) Run(input string) error {
 	input = strings.TrimSpace(input)
 	if input == "" {
-		return
+		return nil
+	}
+
+	cmdLine, filters, out, err := parsePipeline(input)
+	if err != nil {
+		return err
+	}
+	if len(filters) > 0 || out != nil {
+		return e.runPipeline(cmdLine, filters, out)
 	}
 
 	// 解析命令和参数
-	args := parseArgs(input)
+	return e.RunArgs(parseArgs(input))
+}
+
+// RunArgs 执行已拆分的命令参数并返回错误（不打印）
+func (e *Executor) RunArgs(args []string) error {
 	if len(args) == 0 {
-		return
+		return nil
 	}
 
 	cmdName := args[0]
@@ -36,14 +137,71 @@ func (e *Executor) Execute(input string) {
 	// 查找命令
 	cmd, ok := commands.Get(cmdName)
 	if !ok {
-		e.session.Printer.Error("未知命令: " + cmdName + "，输入 'help' 查看可用命令")
-		return
+		return i18n.Errorf("未知命令: %s，输入 'help' 查看可用命令", cmdName)
+	}
+
+	// 查看模式下只允许只读命令，并在执行前同步数据库中的最新结果
+	if e.session.IsViewer() {
+		if r, ok := cmd.(commands.ReadOnly); !ok || !r.IsReadOnly(cmdArgs) {
+			return i18n.Errorf("查看模式 (--viewer) 下禁止执行 '%s'", strings.Join(args, " "))
+		}
+		if err := e.session.ReloadFromDB(); err != nil {
+			return i18n.Errorf("读取数据库失败: %w", err)
+		}
 	}
 
+	cmdArgs, confirmed := stripConfirm(cmdArgs)
+
+	// 安全模式下禁止可能修改目标环境的命令，其他命令中的 exec 等操作由会话层拒绝
+	if d, ok := cmd.(commands.Destructive); ok && d.IsDestructive(cmdArgs) && e.session.IsSafeMode() {
+		return i18n.Errorf("安全模式 (safe-mode) 下禁止执行 '%s'", strings.Join(args, " "))
+	}
+
+	// 以 cluster-admin 身份执行破坏性命令时要求 --confirm
+	if d, ok := cmd.(commands.Destructive); ok && d.IsDestructive(cmdArgs) && e.session.IsAdminActive() && !confirmed {
+		return i18n.Errorf("当前 SA 为 cluster-admin，'%s' 可能影响生产环境，确认后添加 %s 重新执行", cmd.Name(), commands.ConfirmFlag)
+	}
+
+	// 命令执行期间捕获 Ctrl+C，取消上下文而不是退出控制台
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	e.session.SetContext(ctx)
+	defer e.session.SetContext(nil)
+
+	// 记录执行的命令，包含凭据的命令只记录配置项名称，URL 中的密码隐藏
+	line := history.Redact(strings.Join(args, " "))
+	logging.Info("command", logging.Fields{"command": line})
+	defer e.session.SetCommand(e.session.SetCommand(line))
+	start := time.Now()
+
 	// 执行命令
-	if err := cmd.Execute(e.session, cmdArgs); err != nil {
-		e.session.Printer.Error(err.Error())
+	err := cmd.Execute(e.session, cmdArgs)
+	fields := logging.Fields{"command": line, "duration": time.Since(start).Round(time.Millisecond).String()}
+	if err != nil {
+		fields["error"] = err.Error()
+		logging.Info("command failed", fields)
+	} else {
+		logging.Debug("command finished", fields)
+	}
+	return err
+}
+
+// stripConfirm 移除 -- 之前的 --confirm 参数（-- 之后属于远程命令，保持原样）
+func stripConfirm(args []string) ([]string, bool) {
+	confirmed := false
+	result := make([]string, 0, len(args))
+	for i, arg := range args {
+		if arg == "--" {
+			result = append(result, args[i:]...)
+			break
+		}
+		if arg == commands.ConfirmFlag {
+			confirmed = true
+			continue
+		}
+		result = append(result, arg)
 	}
+	return result, confirmed
 }
 
 // parseArgs 解析命令行参数（支持引号）
@@ -0,0 +1,120 @@
+// Package picker 基于 go-prompt 的交互式选择器：输入即模糊过滤，↑/↓ 或 Tab 选择，Enter 确认
+package picker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+
+	"kctl/internal/i18n"
+)
+
+// ErrCancelled 用户取消选择（Ctrl+C、Esc 或空输入时 Enter）
+var ErrCancelled = i18n.New("已取消选择")
+
+// maxVisible 同时显示的候选数
+const maxVisible = 12
+
+// Item 候选项
+type Item struct {
+	Text        string // 选中后返回的值
+	Description string // 说明，参与过滤
+}
+
+// Pick 显示可过滤的候选列表，返回选中项的 Text
+// 未选中候选直接按 Enter 时返回与输入模糊匹配的第一项
+func Pick(title string, items []Item) (string, error) {
+	if len(items) == 0 {
+		return "", i18n.Errorf("没有可选择的候选")
+	}
+
+	fmt.Printf("%s (type to filter, ↑/↓ to move, Enter to select, Esc to cancel)\n", title)
+
+	cancelled := false
+	cancel := func(*prompt.Buffer) { cancelled = true }
+	input := prompt.Input("  > ",
+		func(d prompt.Document) []prompt.Suggest {
+			return suggestions(items, d.TextBeforeCursor())
+		},
+		prompt.OptionPrefixTextColor(prompt.Yellow),
+		prompt.OptionPreviewSuggestionTextColor(prompt.Blue),
+		prompt.OptionSelectedSuggestionBGColor(prompt.LightGray),
+		prompt.OptionSuggestionBGColor(prompt.DarkGray),
+		prompt.OptionShowCompletionAtStart(),
+		prompt.OptionCompletionOnDown(),
+		prompt.OptionMaxSuggestion(maxVisible),
+		// 选中候选时替换整行输入而不是最后一个单词
+		prompt.OptionCompletionWordSeparator("\n"),
+		prompt.OptionAddKeyBind(
+			prompt.KeyBind{Key: prompt.ControlC, Fn: cancel},
+			prompt.KeyBind{Key: prompt.Escape, Fn: cancel},
+		),
+		prompt.OptionSetExitCheckerOnInput(func(string, bool) bool { return cancelled }),
+	)
+
+	input = strings.TrimSpace(input)
+	if cancelled || input == "" {
+		return "", ErrCancelled
+	}
+	for _, item := range items {
+		if item.Text == input {
+			return item.Text, nil
+		}
+	}
+	if matched := suggestions(items, input); len(matched) > 0 {
+		return matched[0].Text, nil
+	}
+	return "", i18n.Errorf("没有匹配 %q 的候选", input)
+}
+
+// suggestions 按输入过滤候选：输入中每个空格分隔的词都需要按顺序出现在 Text 或 Description 中（忽略大小写）
+// 每个词都连续出现的候选排在前面
+func suggestions(items []Item, query string) []prompt.Suggest {
+	terms := strings.Fields(strings.ToLower(query))
+	var exact, fuzzy []prompt.Suggest
+	for _, item := range items {
+		haystack := strings.ToLower(item.Text + " " + item.Description)
+		if !matchAll(haystack, terms) {
+			continue
+		}
+		s := prompt.Suggest{Text: item.Text, Description: item.Description}
+		if containsAll(haystack, terms) {
+			exact = append(exact, s)
+		} else {
+			fuzzy = append(fuzzy, s)
+		}
+	}
+	return append(exact, fuzzy...)
+}
+
+// containsAll 每个词都作为子串出现
+func containsAll(s string, terms []string) bool {
+	for _, term := range terms {
+		if !strings.Contains(s, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchAll 每个词都按字符顺序出现（子序列匹配，类似 fzf）
+func matchAll(s string, terms []string) bool {
+	for _, term := range terms {
+		if !fuzzyMatch(s, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func fuzzyMatch(s, term string) bool {
+	want := []rune(term)
+	i := 0
+	for _, r := range s {
+		if i < len(want) && r == want[i] {
+			i++
+		}
+	}
+	return i == len(want)
+}
@@ -0,0 +1,299 @@
+package console
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+)
+
+// lineFilter 管道中的过滤命令，处理命令输出的各行
+type lineFilter func(lines []string) []string
+
+// pipeCommands 管道中可用的过滤命令
+var pipeCommands = []completion.Suggestion{
+	{Text: "grep", Description: "按正则过滤行 (-i 忽略大小写, -v 反选, -c 计数, -F 固定字符串)"},
+	{Text: "head", Description: "只保留前 n 行 (默认 10)"},
+	{Text: "tail", Description: "只保留最后 n 行 (默认 10)"},
+}
+
+// splitPipe 按引号外的 | 拆分输入；|| 不作为管道（过滤表达式中的“或”）
+func splitPipe(input string) []string {
+	var segments []string
+	var current strings.Builder
+	quoteChar := rune(0)
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quoteChar != 0:
+			if r == quoteChar {
+				quoteChar = 0
+			}
+		case r == '"' || r == '\'':
+			quoteChar = r
+		case r == '|' && i+1 < len(runes) && runes[i+1] == '|':
+			current.WriteString("||")
+			i++
+			continue
+		case r == '|':
+			segments = append(segments, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	return append(segments, current.String())
+}
+
+// redirect 输出重定向的目标文件
+type redirect struct {
+	path       string
+	appendMode bool // >> 追加，> 覆盖
+}
+
+// splitRedirect 拆分引号外位于词首的 > file 或 >> file，如 sa scan --perms > scan.txt
+// 词中的 >（如 risk>HIGH）和 >= 比较运算符不视为重定向
+func splitRedirect(input string) (string, *redirect, error) {
+	quoteChar := rune(0)
+	runes := []rune(input)
+	for i, r := range runes {
+		switch {
+		case quoteChar != 0:
+			if r == quoteChar {
+				quoteChar = 0
+			}
+		case r == '"' || r == '\'':
+			quoteChar = r
+		case r == '>' && (i == 0 || runes[i-1] == ' ' || runes[i-1] == '\t'):
+			rest := string(runes[i+1:])
+			appendMode := strings.HasPrefix(rest, ">")
+			if appendMode {
+				rest = rest[1:]
+			}
+			if strings.HasPrefix(rest, "=") {
+				continue
+			}
+			args := parseArgs(rest)
+			if len(args) != 1 || strings.TrimSpace(string(runes[:i])) == "" {
+				return "", nil, i18n.Errorf("重定向语法错误: %s（用法: <command> > <file>）", input)
+			}
+			return string(runes[:i]), &redirect{path: args[0], appendMode: appendMode}, nil
+		}
+	}
+	return input, nil, nil
+}
+
+// parsePipeline 拆分命令、管道过滤命令和输出重定向，如 pods | grep nginx | head -5 > pods.txt
+func parsePipeline(input string) (string, []lineFilter, *redirect, error) {
+	input, out, err := splitRedirect(input)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	segments := splitPipe(input)
+	var filters []lineFilter
+	for _, segment := range segments[1:] {
+		args := parseArgs(segment)
+		if len(args) == 0 || strings.TrimSpace(segments[0]) == "" {
+			return "", nil, nil, i18n.Errorf("管道语法错误: %s", input)
+		}
+		filter, err := newLineFilter(args[0], args[1:])
+		if err != nil {
+			return "", nil, nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return segments[0], filters, out, nil
+}
+
+// newLineFilter 创建管道过滤命令
+func newLineFilter(name string, args []string) (lineFilter, error) {
+	switch name {
+	case "grep":
+		return grepFilter(args)
+	case "head":
+		n, err := lineCount(name, args)
+		if err != nil {
+			return nil, err
+		}
+		return func(lines []string) []string {
+			return lines[:min(n, len(lines))]
+		}, nil
+	case "tail":
+		n, err := lineCount(name, args)
+		if err != nil {
+			return nil, err
+		}
+		return func(lines []string) []string {
+			return lines[max(len(lines)-n, 0):]
+		}, nil
+	}
+	return nil, i18n.Errorf("不支持的管道命令: %s（可用: grep, head, tail）", name)
+}
+
+// grepFilter grep [-i] [-v] [-c] [-F] <pattern>，按去除颜色后的内容匹配，输出保留颜色
+func grepFilter(args []string) (lineFilter, error) {
+	var ignoreCase, invert, count, fixed bool
+	var pattern string
+	hasPattern := false
+	for i, arg := range args {
+		if arg == "--" {
+			if i+1 < len(args) {
+				pattern, hasPattern = args[i+1], true
+			}
+			break
+		}
+		if len(arg) > 1 && arg[0] == '-' {
+			for _, f := range arg[1:] {
+				switch f {
+				case 'i':
+					ignoreCase = true
+				case 'v':
+					invert = true
+				case 'c':
+					count = true
+				case 'F':
+					fixed = true
+				case 'E':
+				default:
+					return nil, i18n.Errorf("grep: 不支持的选项 -%c（可用: -i, -v, -c, -F, -E）", f)
+				}
+			}
+			continue
+		}
+		if hasPattern {
+			return nil, i18n.Errorf("grep: 只支持一个匹配模式，包含空格时请使用引号")
+		}
+		pattern, hasPattern = arg, true
+	}
+	if !hasPattern {
+		return nil, i18n.Errorf("用法: grep [-i] [-v] [-c] [-F] <pattern>")
+	}
+
+	if fixed {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, i18n.Errorf("grep: 无效的正则表达式: %w", err)
+	}
+
+	return func(lines []string) []string {
+		var matched []string
+		for _, line := range lines {
+			if re.MatchString(output.StripANSI(line)) != invert {
+				matched = append(matched, line)
+			}
+		}
+		if count {
+			return []string{strconv.Itoa(len(matched))}
+		}
+		return matched
+	}, nil
+}
+
+// lineCount head / tail 的行数：-n <n>、-<n> 或 <n>，默认 10
+func lineCount(name string, args []string) (int, error) {
+	value := "10"
+	switch {
+	case len(args) == 0:
+	case len(args) == 2 && args[0] == "-n":
+		value = args[1]
+	case len(args) == 1:
+		value = strings.TrimPrefix(args[0], "-")
+	default:
+		return 0, i18n.Errorf("用法: %s [-n <lines>]", name)
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, i18n.Errorf("%s: 无效的行数: %s", name, value)
+	}
+	return n, nil
+}
+
+// runPipeline 执行命令并将其输出依次经过管道过滤后写入 os.Stdout，指定重定向时去除颜色后写入文件
+// 命令返回的错误不经过过滤，由调用方打印
+func (e *Executor) runPipeline(cmdLine string, filters []lineFilter, out *redirect) error {
+	c, err := output.BeginCapture()
+	if err != nil {
+		return i18n.Errorf("创建管道失败: %w", err)
+	}
+
+	origStdout, origPrinter := os.Stdout, e.session.Printer
+	os.Stdout = c.Writer()
+	p := output.NewPrinterWithWriter(c.Writer(), c.Writer())
+	p.SetWidth(origPrinter.Width())
+	e.session.Printer = p
+
+	runErr := e.RunArgs(parseArgs(cmdLine))
+
+	data := c.End()
+	os.Stdout, e.session.Printer = origStdout, origPrinter
+
+	lines := outputLines(string(data))
+	for _, filter := range filters {
+		lines = filter(lines)
+	}
+	if out != nil {
+		if err := writeRedirect(out, lines); err != nil {
+			e.session.Printer.Error(err.Error())
+		} else {
+			e.session.Printer.Success(fmt.Sprintf("Wrote %d line(s) to %s", len(lines), out.path))
+		}
+		return runErr
+	}
+	if len(lines) > 0 {
+		fmt.Fprintln(os.Stdout, strings.Join(lines, "\n"))
+	}
+	return runErr
+}
+
+// writeRedirect 去除颜色后将输出行写入重定向文件
+func writeRedirect(out *redirect, lines []string) error {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if out.appendMode {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(out.path, flags, 0600)
+	if err != nil {
+		return i18n.Errorf("写入文件失败: %w", err)
+	}
+	var buf strings.Builder
+	for _, line := range lines {
+		buf.WriteString(output.StripANSI(line))
+		buf.WriteByte('\n')
+	}
+	if _, err := file.WriteString(buf.String()); err != nil {
+		_ = file.Close()
+		return i18n.Errorf("写入文件失败: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return i18n.Errorf("写入文件失败: %w", err)
+	}
+	return nil
+}
+
+// outputLines 将命令输出拆分为行，进度条等原地刷新的行只保留最后一次的内容
+func outputLines(text string) []string {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if j := strings.LastIndex(line, "\r"); j >= 0 {
+			line = line[j+1:]
+		}
+		lines[i] = line
+	}
+	return lines
+}
@@ -0,0 +1,326 @@
+package console
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/console/commands"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/session"
+)
+
+// scriptMaxDepth source 的最大嵌套层数
+const scriptMaxDepth = 8
+
+// ScriptOptions 脚本执行选项
+type ScriptOptions struct {
+	Path        string            // 脚本文件，- 表示标准输入
+	Args        []string          // 位置参数，脚本中以 ${1}、${2} ... 引用，${@} 为全部参数
+	Vars        map[string]string // 预定义变量（--var KEY=VAL），优先于脚本外的环境变量
+	StopOnError bool              // -e：任一命令失败时停止
+}
+
+var (
+	// scriptAssignRe 变量赋值行: NAME=value
+	scriptAssignRe = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)=(.*)$`)
+	// scriptVarRe 变量引用: ${NAME}；$$ 转义为 $
+	scriptVarRe = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*|[0-9]+|@)\}`)
+)
+
+// scriptDepth 当前 source 嵌套层数
+var scriptDepth int
+
+// RunScript 自动连接后执行脚本，返回进程退出码（kctl run 使用）
+func (c *Console) RunScript(opts ScriptOptions) int {
+	st := c.restoreSession(false)
+	c.autoConnect()
+	c.restoreCurrentSA(st)
+
+	err := c.executor.RunScript(opts)
+	if err != nil {
+		c.session.Printer.Error(err.Error())
+	}
+	return c.exitCode(err)
+}
+
+// RunScript 逐行执行脚本中的控制台命令
+//
+// 语法：
+//   - 空行和 # 开头的行忽略，行尾 \ 续行
+//   - NAME=value 定义变量，${NAME} 引用；未定义时依次查找位置参数和环境变量，都没有时该行报错
+//   - 只展开 ${...} 形式，exec 等远程命令中的 $VAR 原样保留；$$ 表示字面的 $
+//   - 行首 - 表示忽略该行的失败（即使使用 -e）
+//   - exit [code] 结束脚本
+func (e *Executor) RunScript(opts ScriptOptions) error {
+	if scriptDepth >= scriptMaxDepth {
+		return i18n.Errorf("source 嵌套超过 %d 层", scriptMaxDepth)
+	}
+	scriptDepth++
+	defer func() { scriptDepth-- }()
+
+	// 脚本中的命令不弹出选择器
+	interactive := e.session.Interactive
+	e.session.Interactive = false
+	defer func() { e.session.Interactive = interactive }()
+
+	lines, err := readScript(opts.Path)
+	if err != nil {
+		return err
+	}
+
+	p := e.session.Printer
+	name := filepath.Base(opts.Path)
+	vars := make(map[string]string, len(opts.Vars))
+	for k, v := range opts.Vars {
+		vars[k] = v
+	}
+
+	failed := 0
+	var lastErr error
+	for _, line := range lines {
+		ignoreError := strings.HasPrefix(line.text, "-")
+		text := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		prefix := p.Colored(config.ColorCyan, fmt.Sprintf("[%s:%d]", name, line.no))
+
+		expanded, err := expandScriptVars(text, vars, opts.Args)
+		if err == nil {
+			if m := scriptAssignRe.FindStringSubmatch(expanded); m != nil {
+				vars[m[1]] = unquote(strings.TrimSpace(m[2]))
+				continue
+			}
+
+			p.Printf("%s %s\n", prefix, expanded)
+			args := parseArgs(expanded)
+			if len(args) > 0 && (args[0] == "exit" || args[0] == "quit") {
+				return scriptExit(args)
+			}
+			err = e.Run(expanded)
+		}
+		if err == nil {
+			continue
+		}
+
+		p.Error(fmt.Sprintf("%s:%d: %v", name, line.no, err))
+		if ignoreError {
+			continue
+		}
+		failed++
+		lastErr = err
+		if opts.StopOnError {
+			return i18n.Errorf("%s:%d 执行失败，已停止 (-e): %w", name, line.no, err)
+		}
+	}
+
+	if failed > 0 {
+		return i18n.Errorf("%s: %d 条命令执行失败，最后一个错误: %w", name, failed, lastErr)
+	}
+	return nil
+}
+
+// scriptLine 脚本中的一条命令及其起始行号
+type scriptLine struct {
+	no   int
+	text string
+}
+
+// readScript 读取脚本，合并续行并去除空行和注释
+func readScript(path string) ([]scriptLine, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, i18n.Errorf("打开脚本失败: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		r = file
+	}
+
+	var lines []scriptLine
+	var current strings.Builder
+	start := 0
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for no := 1; scanner.Scan(); no++ {
+		text := strings.TrimSpace(scanner.Text())
+		if current.Len() == 0 {
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			start = no
+		}
+		if strings.HasSuffix(text, "\\") {
+			current.WriteString(strings.TrimSpace(strings.TrimSuffix(text, "\\")))
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(text)
+		lines = append(lines, scriptLine{no: start, text: current.String()})
+		current.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, i18n.Errorf("读取脚本失败: %w", err)
+	}
+	if current.Len() > 0 {
+		lines = append(lines, scriptLine{no: start, text: strings.TrimSpace(current.String())})
+	}
+	return lines, nil
+}
+
+// expandScriptVars 展开 ${NAME}、${1} 和 ${@}
+func expandScriptVars(text string, vars map[string]string, args []string) (string, error) {
+	var missing string
+	expanded := scriptVarRe.ReplaceAllStringFunc(text, func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		name := ref[2 : len(ref)-1]
+		if name == "@" {
+			return strings.Join(args, " ")
+		}
+		if n, err := strconv.Atoi(name); err == nil {
+			if n >= 1 && n <= len(args) {
+				return args[n-1]
+			}
+		} else if v, ok := vars[name]; ok {
+			return v
+		} else if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		if missing == "" {
+			missing = name
+		}
+		return ""
+	})
+	if missing != "" {
+		return "", i18n.Errorf("未定义的变量: ${%s}", missing)
+	}
+	return expanded, nil
+}
+
+// scriptExit 处理脚本中的 exit [code]
+func scriptExit(args []string) error {
+	if len(args) < 2 {
+		return nil
+	}
+	code, err := strconv.Atoi(args[1])
+	if err != nil {
+		return i18n.Errorf("无效的退出码: %s", args[1])
+	}
+	if code == 0 {
+		return nil
+	}
+	return &commands.ExitCodeError{Code: code}
+}
+
+// unquote 去除变量值两端成对的引号
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// SourceCmd source 命令：在控制台中执行脚本
+type SourceCmd struct{}
+
+func init() {
+	commands.Register(&SourceCmd{})
+}
+
+func (c *SourceCmd) Name() string {
+	return "source"
+}
+
+func (c *SourceCmd) Aliases() []string {
+	return []string{"."}
+}
+
+func (c *SourceCmd) Description() string {
+	return "执行脚本文件中的命令"
+}
+
+// IsReadOnly 脚本中的每条命令仍按查看模式单独检查
+func (c *SourceCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+func (c *SourceCmd) Usage() string {
+	return `source <file> [-e] [--var KEY=VAL]... [args...]
+
+逐行执行脚本文件中的控制台命令（kctl run 的控制台版本）
+
+选项：
+  -e                    任一命令失败时停止
+  --var KEY=VAL         定义变量（可重复）
+  --                    之后的参数都作为位置参数
+
+脚本语法：
+  # 注释                空行和 # 开头的行忽略，行尾 \ 续行
+  NS=kube-system        定义变量，之后以 ${NS} 引用
+  ${1} ${2} ${@}        位置参数；未定义的变量依次查找环境变量，都没有时该行报错
+  $$                    字面的 $（exec 等远程命令中的 $VAR 不展开，原样保留）
+  -sa use ${NS}/x       行首 - 表示忽略该行的失败（即使使用 -e）
+  exit [code]           结束脚本
+
+示例：
+  source recon.kctl
+  source recon.kctl -e 10.0.0.1
+  . ./loot.kctl --var NS=prod`
+}
+
+func (c *SourceCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "-e", Description: "任一命令失败时停止"},
+		{Name: "--var", Arg: "KEY=VAL", Description: "定义变量"},
+	}
+}
+
+func (c *SourceCmd) Execute(sess *session.Session, args []string) error {
+	opts, err := parseScriptArgs(args)
+	if err != nil {
+		return err
+	}
+	return NewExecutor(sess).RunScript(opts)
+}
+
+// parseScriptArgs 解析 source 的参数：第一个非选项参数为脚本文件，其余为位置参数；-- 之后的参数都作为位置参数
+func parseScriptArgs(args []string) (ScriptOptions, error) {
+	opts := ScriptOptions{Vars: make(map[string]string)}
+	positional := false
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case positional:
+			opts.Args = append(opts.Args, arg)
+		case arg == "--":
+			positional = true
+		case arg == "-e":
+			opts.StopOnError = true
+		case arg == "--var":
+			if i+1 >= len(args) {
+				return opts, i18n.Errorf("--var 需要 KEY=VAL")
+			}
+			i++
+			m := scriptAssignRe.FindStringSubmatch(args[i])
+			if m == nil {
+				return opts, i18n.Errorf("无效的变量: %s (需要 KEY=VAL)", args[i])
+			}
+			opts.Vars[m[1]] = m[2]
+		case opts.Path == "":
+			opts.Path = arg
+		default:
+			opts.Args = append(opts.Args, arg)
+		}
+	}
+	if opts.Path == "" {
+		return opts, i18n.Errorf("用法: source <file> [-e] [--var KEY=VAL]... [args...]")
+	}
+	return opts, nil
+}
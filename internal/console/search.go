@@ -0,0 +1,33 @@
+package console
+
+import (
+	"github.com/c-bata/go-prompt"
+)
+
+// historySearch Ctrl+R 反向搜索的状态
+type historySearch struct {
+	query string // 第一次按 Ctrl+R 时输入框中的文本
+	index int    // 上一次匹配的历史下标
+	match string // 上一次填入输入框的命令
+}
+
+// reverseSearch Ctrl+R：用包含当前输入的最近一条历史命令替换输入框，连续按下继续向前查找
+func (c *Console) reverseSearch(buf *prompt.Buffer) {
+	s := &c.search
+	text := buf.Text()
+	if s.match == "" || text != s.match {
+		// 新的搜索
+		s.query, s.index = text, -1
+	}
+
+	index, match := c.session.History.Search(s.query, s.index)
+	if index < 0 {
+		// 没有更早的匹配，保留当前内容
+		return
+	}
+	s.index, s.match = index, match
+
+	buf.CursorRight(len([]rune(buf.Document().TextAfterCursor())))
+	buf.DeleteBeforeCursor(len([]rune(text)))
+	buf.InsertText(match, false, true)
+}
@@ -0,0 +1,94 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/server"
+)
+
+// ServeOptions kctl serve 选项
+type ServeOptions struct {
+	Listen  string // 监听地址，如 127.0.0.1:8080
+	Token   string // API 令牌，为空时随机生成并输出
+	TLSCert string // HTTPS 证书文件，与 TLSKey 同时设置时启用 HTTPS
+	TLSKey  string
+}
+
+// Serve 自动连接后以 REST API 提供扫描数据，直到 Ctrl+C
+// 触发扫描时命令输出被捕获并去除颜色，与 --bridge 相同
+func (c *Console) Serve(opts ServeOptions) error {
+	p := c.session.Printer
+	if (opts.TLSCert == "") != (opts.TLSKey == "") {
+		return i18n.Errorf("--tls-cert 和 --tls-key 需要同时指定")
+	}
+
+	c.autoConnect()
+
+	token := opts.Token
+	if token == "" {
+		var err error
+		if token, err = server.GenerateToken(); err != nil {
+			return i18n.Errorf("生成 API 令牌失败: %w", err)
+		}
+	}
+
+	api := server.New(c.session, token, func(args []string) (string, error) {
+		var err error
+		noColor := color.NoColor
+		color.NoColor = true
+		out := c.capture(func() { err = c.executor.RunArgs(args) })
+		color.NoColor = noColor
+		return out, err
+	})
+	srv := &http.Server{
+		Addr:              opts.Listen,
+		Handler:           api.Handler(),
+		ReadHeaderTimeout: config.DefaultConnectTimeout,
+	}
+
+	scheme := "http"
+	if opts.TLSCert != "" {
+		scheme = "https"
+	}
+	p.Printf("%s REST API listening on %s://%s%s\n", p.Colored(config.ColorGreen, "[+]"), scheme, opts.Listen, server.APIPrefix)
+	if opts.Token == "" {
+		p.Printf("%s API token: %s\n", p.Colored(config.ColorBlue, "[*]"), token)
+	}
+	p.Printf("%s Press Ctrl+C to stop\n", p.Colored(config.ColorGray, "[*]"))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errCh := make(chan error, 1)
+	go func() {
+		if opts.TLSCert != "" {
+			errCh <- srv.ListenAndServeTLS(opts.TLSCert, opts.TLSKey)
+		} else {
+			errCh <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return i18n.Errorf("启动 API 服务失败: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = srv.Shutdown(shutdownCtx)
+	p.Printf("%s REST API stopped\n", p.Colored(config.ColorBlue, "[*]"))
+	return nil
+}
@@ -0,0 +1,461 @@
+package console
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	starjson "go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+
+	"kctl/internal/client/k8s"
+	"kctl/internal/console/commands"
+	"kctl/internal/console/completion"
+	"kctl/internal/i18n"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// starFileOptions 允许顶层 for/if、while、set 和递归，便于编写分析脚本
+var starFileOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+	Recursion:       true,
+}
+
+// StarCmd star 命令：执行 Starlark 脚本
+type StarCmd struct{}
+
+func init() {
+	commands.Register(&StarCmd{})
+}
+
+func (c *StarCmd) Name() string {
+	return "star"
+}
+
+func (c *StarCmd) Aliases() []string {
+	return nil
+}
+
+func (c *StarCmd) Description() string {
+	return "执行 Starlark 脚本（访问 Pod、SA、exec 和 API Server）"
+}
+
+// IsReadOnly 查看模式下脚本只能读取本地数据，kctl.exec / list / can 会被拒绝
+func (c *StarCmd) IsReadOnly(args []string) bool {
+	return true
+}
+
+// IsDestructive 只做分析的脚本不应被安全模式拦截：kctl.exec 经由会话的执行通道，
+// 安全模式下由会话层拒绝；kctl.run 执行的每条命令同样经过执行器的检查
+func (c *StarCmd) IsDestructive(args []string) bool {
+	return false
+}
+
+func (c *StarCmd) Usage() string {
+	return `star <file.star> [args...]
+star -c '<code>' [args...]
+
+执行 Starlark（Python 方言）脚本，脚本中可通过 kctl 模块访问会话数据
+适合 "在镜像匹配 X 的每个 Pod 中执行 Y" 这类需要条件和循环的自动化
+
+kctl 模块：
+  kctl.args                         位置参数列表
+  kctl.target                       当前 Kubelet IP
+  kctl.pods()                       缓存的 Pod 列表（为空时可先 kctl.run("pods")）
+  kctl.sas()                        数据库中的 SA 记录
+  kctl.findings()                   数据库中的检查发现
+  kctl.current_sa()                 当前 SA，未选择时为 None
+  kctl.exec(pod, cmd, namespace="", container="")
+                                    在 Pod 中执行命令，pod 可为 namespace/name 或 kctl.pods() 中的元素
+                                    cmd 为字符串时以 sh -c 执行；返回 .stdout .stderr .exit_code .ok
+  kctl.run(line)                    执行控制台命令，失败时脚本报错
+  kctl.list(kind, namespace="")     通过 API Server 查询资源: nodes, namespaces, serviceaccounts,
+                                    configmaps, services, roles, clusterroles, rolebindings, clusterrolebindings
+  kctl.can(verb, resource, namespace="")
+                                    当前身份是否有权限，如 kctl.can("create", "pods/exec")
+  kctl.finding(severity, title, namespace="", pod="", container="", location="", evidence="", category="")
+                                    保存一条检查发现（可被 export sarif / --fail-on 使用）
+  kctl.match(pattern, s)            正则匹配
+  json.encode(x) / json.decode(s)   JSON
+
+Pod、SA 等记录为 dict，键与 export json 的字段相同，如 pod["Namespace"]、pod["PodName"]、
+pod["Containers"][0]["Image"]、sa["riskLevel"]
+
+以 cluster-admin 身份执行时需要 --confirm
+
+示例：
+  star -c 'print(len(kctl.pods()))'
+  star exec-nginx.star 'nginx:1\.1[0-9]' 'cat /etc/passwd'
+
+  # exec-nginx.star
+  image_re, cmd = kctl.args[0], kctl.args[1]
+  for pod in kctl.pods():
+      if any([kctl.match(image_re, c["Image"]) for c in pod["Containers"]]):
+          r = kctl.exec(pod, cmd)
+          print(pod["Namespace"] + "/" + pod["PodName"], r.exit_code, r.stdout)`
+}
+
+func (c *StarCmd) Flags(args []string) []completion.Flag {
+	return []completion.Flag{
+		{Name: "-c", Arg: "<code>", Description: "执行命令行中的代码"},
+	}
+}
+
+func (c *StarCmd) Execute(sess *session.Session, args []string) error {
+	if len(args) == 0 {
+		return i18n.Errorf("用法: star <file.star> [args...] 或 star -c '<code>' [args...]")
+	}
+
+	var filename string
+	var src any
+	if args[0] == "-c" {
+		if len(args) < 2 {
+			return i18n.Errorf("-c 需要代码")
+		}
+		filename, src, args = "<command>", args[1], args[2:]
+	} else {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return i18n.Errorf("读取脚本失败: %w", err)
+		}
+		filename, src, args = args[0], data, args[1:]
+	}
+
+	// 脚本中的命令不弹出选择器
+	interactive := sess.Interactive
+	sess.Interactive = false
+	defer func() { sess.Interactive = interactive }()
+
+	s := &starSession{sess: sess, name: filepath.Base(filename)}
+	thread := &starlark.Thread{
+		Name:  "star",
+		Print: func(_ *starlark.Thread, msg string) { sess.Printer.Println(msg) },
+	}
+
+	// Ctrl+C 取消脚本
+	ctx := sess.Context()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			thread.Cancel("interrupted")
+		case <-done:
+		}
+	}()
+
+	_, err := starlark.ExecFileOptions(starFileOptions, thread, filename, src, starlark.StringDict{
+		"kctl": s.module(args),
+		"json": starjson.Module,
+	})
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return fmt.Errorf("%s", evalErr.Backtrace())
+	}
+	return err
+}
+
+// starSession 脚本中的 kctl 模块绑定
+type starSession struct {
+	sess *session.Session
+	name string // 脚本文件名，作为检查发现的来源
+}
+
+func (s *starSession) module(args []string) *starlarkstruct.Module {
+	argList := make([]starlark.Value, len(args))
+	for i, arg := range args {
+		argList[i] = starlark.String(arg)
+	}
+
+	return &starlarkstruct.Module{
+		Name: "kctl",
+		Members: starlark.StringDict{
+			"args":       starlark.NewList(argList),
+			"target":     starlark.String(s.sess.Config.KubeletIP),
+			"pods":       starlark.NewBuiltin("pods", s.pods),
+			"sas":        starlark.NewBuiltin("sas", s.sas),
+			"findings":   starlark.NewBuiltin("findings", s.findings),
+			"current_sa": starlark.NewBuiltin("current_sa", s.currentSA),
+			"exec":       starlark.NewBuiltin("exec", s.exec),
+			"run":        starlark.NewBuiltin("run", s.run),
+			"list":       starlark.NewBuiltin("list", s.list),
+			"can":        starlark.NewBuiltin("can", s.can),
+			"finding":    starlark.NewBuiltin("finding", s.finding),
+			"match":      starlark.NewBuiltin("match", s.match),
+		},
+	}
+}
+
+func (s *starSession) pods(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	return toStarlark(thread, s.sess.GetCachedPods())
+}
+
+func (s *starSession) sas(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	records, err := s.sess.SADB.GetAll()
+	if err != nil {
+		return nil, i18n.Errorf("获取 ServiceAccount 失败: %w", err)
+	}
+	return toStarlark(thread, records)
+}
+
+func (s *starSession) findings(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	records, err := s.sess.FindingDB.GetAll()
+	if err != nil {
+		return nil, i18n.Errorf("获取检查发现失败: %w", err)
+	}
+	return toStarlark(thread, records)
+}
+
+func (s *starSession) currentSA(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	sa := s.sess.GetCurrentSA()
+	if sa == nil {
+		return starlark.None, nil
+	}
+	return toStarlark(thread, sa)
+}
+
+func (s *starSession) exec(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var pod, cmd starlark.Value
+	var namespace, container string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "pod", &pod, "cmd", &cmd, "namespace?", &namespace, "container?", &container); err != nil {
+		return nil, err
+	}
+	if err := s.requireCluster(); err != nil {
+		return nil, err
+	}
+
+	name, err := podName(pod)
+	if err != nil {
+		return nil, err
+	}
+	command, err := commandArgs(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := s.sess.ResolvePod(name, namespace, container)
+	if err != nil {
+		return nil, err
+	}
+	executor, err := s.sess.GetExecTransport()
+	if err != nil {
+		return nil, err
+	}
+	result, err := executor.Exec(s.sess.Context(), &types.ExecOptions{
+		Namespace: ref.Namespace,
+		Pod:       ref.Pod,
+		Container: ref.Container,
+		Command:   command,
+		Stdout:    true,
+		Stderr:    true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s/%s: %w", ref.Namespace, ref.Pod, err)
+	}
+
+	return starlarkstruct.FromStringDict(starlark.String("exec_result"), starlark.StringDict{
+		"namespace": starlark.String(ref.Namespace),
+		"pod":       starlark.String(ref.Pod),
+		"container": starlark.String(ref.Container),
+		"stdout":    starlark.String(result.Stdout),
+		"stderr":    starlark.String(result.Stderr),
+		"exit_code": starlark.MakeInt(result.ExitCode),
+		"ok":        starlark.Bool(result.ExitCode == 0 && result.Error == ""),
+	}), nil
+}
+
+func (s *starSession) run(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var line string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "line", &line); err != nil {
+		return nil, err
+	}
+	if err := NewExecutor(s.sess).Run(line); err != nil {
+		return nil, fmt.Errorf("%s: %w", line, err)
+	}
+	return starlark.None, nil
+}
+
+func (s *starSession) list(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var kind, namespace string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "kind", &kind, "namespace?", &namespace); err != nil {
+		return nil, err
+	}
+	client, err := s.k8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := s.sess.Context()
+	var result any
+	switch kind {
+	case "nodes":
+		result, err = client.ListNodes(ctx)
+	case "namespaces":
+		result, err = client.ListNamespaces(ctx)
+	case "serviceaccounts":
+		result, err = client.ListServiceAccounts(ctx, namespace)
+	case "configmaps":
+		result, err = client.ListConfigMaps(ctx, namespace)
+	case "services":
+		result, err = client.ListServices(ctx, namespace)
+	case "roles":
+		result, err = client.ListRoles(ctx, namespace)
+	case "clusterroles":
+		result, err = client.ListClusterRoles(ctx)
+	case "rolebindings":
+		result, err = client.ListRoleBindings(ctx, namespace)
+	case "clusterrolebindings":
+		result, err = client.ListClusterRoleBindings(ctx)
+	default:
+		return nil, i18n.Errorf("不支持的资源类型: %s", kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", kind, err)
+	}
+	return toStarlark(thread, result)
+}
+
+func (s *starSession) can(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var verb, resource, namespace string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "verb", &verb, "resource", &resource, "namespace?", &namespace); err != nil {
+		return nil, err
+	}
+	client, err := s.k8sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &k8s.PermissionRequest{Verb: verb, Resource: resource, Namespace: namespace}
+	if !strings.HasPrefix(resource, "/") {
+		req.Resource, req.Subresource, _ = strings.Cut(resource, "/")
+	}
+	allowed, err := client.CheckPermission(s.sess.Context(), req)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.Bool(allowed), nil
+}
+
+func (s *starSession) finding(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	f := &types.FindingRecord{
+		Source:      "star:" + s.name,
+		CollectedAt: time.Now(),
+		KubeletIP:   s.sess.Config.KubeletIP,
+	}
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs,
+		"severity", &f.Severity, "title", &f.Title,
+		"namespace?", &f.Namespace, "pod?", &f.Pod, "container?", &f.Container,
+		"location?", &f.Location, "evidence?", &f.Evidence, "category?", &f.Category,
+	); err != nil {
+		return nil, err
+	}
+	if s.sess.IsViewer() {
+		return nil, i18n.Errorf("查看模式 (--viewer) 下不能保存检查发现")
+	}
+	f.Severity = strings.ToUpper(f.Severity)
+	if _, err := s.sess.FindingDB.SaveBatch([]*types.FindingRecord{f}); err != nil {
+		return nil, i18n.Errorf("保存失败: %w", err)
+	}
+	return starlark.None, nil
+}
+
+func (s *starSession) match(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var pattern, str string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "pattern", &pattern, "s", &str); err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, i18n.Errorf("无效的正则表达式: %w", err)
+	}
+	return starlark.Bool(re.MatchString(str)), nil
+}
+
+// requireCluster 查看模式下禁止访问集群
+func (s *starSession) requireCluster() error {
+	if s.sess.IsViewer() {
+		return i18n.Errorf("查看模式 (--viewer) 下禁止访问集群")
+	}
+	return nil
+}
+
+// k8sClient 以当前身份创建 API Server 客户端
+func (s *starSession) k8sClient() (k8s.Client, error) {
+	if err := s.requireCluster(); err != nil {
+		return nil, err
+	}
+	return s.sess.GetK8sClient(s.sess.GetActiveToken())
+}
+
+// podName kctl.exec 的 pod 参数：namespace/name 字符串或 kctl.pods() 中的元素
+func podName(v starlark.Value) (string, error) {
+	switch v := v.(type) {
+	case starlark.String:
+		return string(v), nil
+	case *starlark.Dict:
+		ns, _, _ := v.Get(starlark.String("Namespace"))
+		name, _, _ := v.Get(starlark.String("PodName"))
+		nsStr, ok1 := ns.(starlark.String)
+		nameStr, ok2 := name.(starlark.String)
+		if ok1 && ok2 {
+			return string(nsStr) + "/" + string(nameStr), nil
+		}
+	}
+	return "", i18n.Errorf("pod 需要为 namespace/name 或 kctl.pods() 中的元素，得到 %s", v.Type())
+}
+
+// commandArgs kctl.exec 的 cmd 参数：字符串以 sh -c 执行，列表直接作为参数
+func commandArgs(v starlark.Value) ([]string, error) {
+	switch v := v.(type) {
+	case starlark.String:
+		return []string{"sh", "-c", string(v)}, nil
+	case *starlark.List:
+		command := make([]string, v.Len())
+		for i := range command {
+			s, ok := v.Index(i).(starlark.String)
+			if !ok {
+				return nil, i18n.Errorf("cmd 列表中的元素需要为字符串")
+			}
+			command[i] = string(s)
+		}
+		if len(command) > 0 {
+			return command, nil
+		}
+	}
+	return nil, i18n.Errorf("cmd 需要为字符串或非空字符串列表")
+}
+
+// toStarlark 将 Go 值经 JSON 转换为 Starlark 值，键与 export json 相同；nil 切片转换为空列表
+func toStarlark(thread *starlark.Thread, v any) (starlark.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return starlark.NewList(nil), nil
+	}
+	return starlark.Call(thread, starjson.Module.Members["decode"], starlark.Tuple{starlark.String(data)}, nil)
+}
@@ -0,0 +1,109 @@
+package console
+
+import (
+	"bufio"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/internal/session"
+)
+
+// restoreSession 使用 --db 启动时恢复上次退出时保存的会话状态
+// 设置了 --restore 时直接恢复；否则仅在交互终端中询问；返回已恢复的状态，未恢复时返回 nil
+func (c *Console) restoreSession(interactive bool) *session.State {
+	sess := c.session
+	p := sess.Printer
+	if sess.IsViewer() {
+		return nil
+	}
+
+	st, err := sess.LoadState()
+	if err != nil {
+		p.Warning(i18n.Tf("读取保存的会话状态失败: %v", err))
+		return nil
+	}
+	if st == nil {
+		return nil
+	}
+
+	if !c.restore {
+		if !interactive || !term.IsTerminal(int(os.Stdin.Fd())) {
+			return nil
+		}
+		p.Printf("%s Previous session found (saved %s): %s\n",
+			p.Colored(config.ColorBlue, "[*]"), output.RelativeTime(st.SavedAt, time.Now()), st.Summary())
+		p.Printf("%s Restore it? [Y/n] ", p.Colored(config.ColorYellow, "[?]"))
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "" && answer != "y" && answer != "yes" {
+			return nil
+		}
+	}
+
+	if err := sess.RestoreState(st); err != nil {
+		p.Warning(i18n.Tf("恢复会话状态失败: %v", err))
+	}
+	p.Printf("%s Session restored: %s\n", p.Colored(config.ColorGreen, "[+]"), st.Summary())
+	unsaved := st.Unsaved
+	if slices.Contains(unsaved, "token") && interactive && term.IsTerminal(int(os.Stdin.Fd())) {
+		if promptToken(sess) {
+			unsaved = slices.DeleteFunc(slices.Clone(unsaved), func(key string) bool { return key == "token" })
+		}
+	}
+	if len(unsaved) > 0 {
+		p.Warning(i18n.Tf("以下设置包含凭据，未随会话保存，请使用 set 重新设置: %s", strings.Join(unsaved, ", ")))
+	}
+	if pods := len(sess.GetCachedPods()); pods > 0 {
+		p.Printf("%s %d cached pods loaded from database\n", p.Colored(config.ColorBlue, "[*]"), pods)
+	}
+	return st
+}
+
+// promptToken 询问未随会话保存的 Token（不回显），输入为空时跳过
+func promptToken(sess *session.Session) bool {
+	p := sess.Printer
+	p.Printf("%s Token (not saved with the session, empty to skip): ", p.Colored(config.ColorYellow, "[?]"))
+	data, err := term.ReadPassword(int(os.Stdin.Fd()))
+	p.Println()
+	if err != nil {
+		return false
+	}
+	tokenStr := strings.TrimSpace(string(data))
+	if tokenStr == "" {
+		return false
+	}
+	sess.Config.Token = tokenStr
+	return true
+}
+
+// restoreCurrentSA 连接后重新选中恢复的会话中的当前 SA（自动连接会将当前 SA 设为 Token 对应的 SA）
+func (c *Console) restoreCurrentSA(st *session.State) {
+	if st == nil || st.CurrentSA == "" {
+		return
+	}
+	p := c.session.Printer
+	if current := c.session.GetCurrentSA(); current != nil && current.Namespace+"/"+current.Name == st.CurrentSA {
+		return
+	}
+	ok, err := c.session.RestoreCurrentSA(st)
+	if err != nil {
+		p.Warning(i18n.Tf("恢复当前 SA 失败: %v", err))
+		return
+	}
+	if ok {
+		p.Printf("%s Selected: %s\n", p.Colored(config.ColorBlue, "[*]"), st.CurrentSA)
+	}
+}
+
+// saveSession 保存会话状态，供下次以同一 --db 启动时恢复
+func (c *Console) saveSession() {
+	if err := c.session.SaveState(); err != nil {
+		c.session.Printer.Warning(i18n.Tf("保存会话状态失败: %v", err))
+	}
+}
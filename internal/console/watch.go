@@ -0,0 +1,98 @@
+package console
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/console/commands"
+	"kctl/internal/i18n"
+)
+
+// WatchOptions kctl watch 选项
+type WatchOptions struct {
+	Targets  []string      // Kubelet IP 列表，为空时使用会话中的目标
+	Interval time.Duration // 两轮扫描之间的间隔
+	Count    int           // 扫描轮数，0 表示持续运行直到 Ctrl+C
+	Passive  bool          // 使用 sa scan --passive，不读取 Token、不检查权限
+}
+
+// Watch 按间隔重复扫描各目标，每轮与同一目标的上一次扫描比较，输出并推送新增的风险，返回进程退出码
+func (c *Console) Watch(opts WatchOptions) int {
+	p := c.session.Printer
+	if opts.Interval <= 0 {
+		p.Error(i18n.T("--interval 必须大于 0"))
+		return 1
+	}
+	targets := opts.Targets
+	if len(targets) == 0 {
+		if c.session.Config.KubeletIP == "" {
+			p.Error(i18n.T("未检测到 Kubelet IP，请使用 -t 指定目标"))
+			return 1
+		}
+		targets = []string{c.session.Config.KubeletIP}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher := commands.NewPostureWatcher()
+	for round := 1; ; round++ {
+		p.Printf("%s Watch round %d (%s)\n", p.Colored(config.ColorBlue, "[*]"), round, time.Now().Format(time.RFC3339))
+		for _, target := range targets {
+			if ctx.Err() != nil {
+				break
+			}
+			c.watchTarget(watcher, target, opts.Passive)
+		}
+		if ctx.Err() != nil || (opts.Count > 0 && round >= opts.Count) {
+			break
+		}
+
+		next := time.Now().Add(opts.Interval)
+		p.Printf("%s Next round at %s, press Ctrl+C to stop\n\n", p.Colored(config.ColorGray, "[*]"), next.Format(time.RFC3339))
+		select {
+		case <-ctx.Done():
+		case <-time.After(opts.Interval):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	p.Printf("%s Watch stopped\n", p.Colored(config.ColorBlue, "[*]"))
+	return 0
+}
+
+// watchTarget 连接单个目标、执行 sa scan 并与上一次扫描比较
+// 单次扫描的 scan.finished 通知在 watch 中关闭，只推送变化，避免每轮重复通知同样的结果
+func (c *Console) watchTarget(watcher *commands.PostureWatcher, target string, passive bool) {
+	p := c.session.Printer
+	c.session.Config.KubeletIP = target
+	c.session.Disconnect()
+	c.autoConnect()
+	if !c.session.IsConnected {
+		p.Warning(i18n.Tf("跳过目标 %s：未连接", target))
+		return
+	}
+
+	args := []string{"sa", "scan", "--risky", "--quiet"}
+	if passive {
+		args = append(args, "--passive")
+	}
+	notifyURL := c.session.Config.NotifyURL
+	c.session.Config.NotifyURL = ""
+	err := c.executor.RunArgs(args)
+	c.session.Config.NotifyURL = notifyURL
+	if err != nil {
+		p.Error(i18n.Tf("扫描 %s 失败: %v", target, err))
+		return
+	}
+
+	if _, err := watcher.Compare(c.session); err != nil {
+		p.Error(err.Error())
+	}
+	p.Println()
+}
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"database/sql"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// CredentialRepository harvest 收集的凭据仓库
+type CredentialRepository struct {
+	db *DB
+}
+
+// NewCredentialRepository 创建凭据仓库
+func NewCredentialRepository(db *DB) *CredentialRepository {
+	return &CredentialRepository{db: db}
+}
+
+// Save 保存凭据并回填 ID（同一来源的同一身份重复收集时覆盖）
+func (r *CredentialRepository) Save(record *types.CredentialRecord) error {
+	err := r.db.queryRow(`
+		INSERT INTO credentials (
+			kind, identity, groups, source, server, ca_data, token,
+			client_cert, client_key, expires_at, collected_at, kubelet_ip
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(kind, identity, source) DO UPDATE SET
+			groups = excluded.groups,
+			server = excluded.server,
+			ca_data = excluded.ca_data,
+			token = excluded.token,
+			client_cert = excluded.client_cert,
+			client_key = excluded.client_key,
+			expires_at = excluded.expires_at,
+			collected_at = excluded.collected_at,
+			kubelet_ip = excluded.kubelet_ip
+		RETURNING id
+	`,
+		record.Kind, record.Identity, record.Groups, record.Source, record.Server, record.CAData,
+		record.Token, record.ClientCert, record.ClientKey, record.ExpiresAt, record.CollectedAt,
+		record.KubeletIP,
+	).Scan(&record.ID)
+	if err != nil {
+		return i18n.Errorf("保存凭据 %s 失败: %w", record.Identity, err)
+	}
+	return nil
+}
+
+// credentialColumns 查询凭据时的列顺序，与 scanCredential 对应
+const credentialColumns = `id, kind, identity, groups, source, server, ca_data, token,
+	client_cert, client_key, expires_at, collected_at, kubelet_ip`
+
+// scanCredential 读取一行凭据
+func scanCredential(row interface{ Scan(...any) error }) (*types.CredentialRecord, error) {
+	var c types.CredentialRecord
+	err := row.Scan(
+		&c.ID, &c.Kind, &c.Identity, &c.Groups, &c.Source, &c.Server, &c.CAData, &c.Token,
+		&c.ClientCert, &c.ClientKey, &c.ExpiresAt, &c.CollectedAt, &c.KubeletIP,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetAll 获取所有凭据（按 ID 排序）
+func (r *CredentialRepository) GetAll() ([]*types.CredentialRecord, error) {
+	rows, err := r.db.query("SELECT " + credentialColumns + " FROM credentials ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records []*types.CredentialRecord
+	for rows.Next() {
+		c, err := scanCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, c)
+	}
+	return records, rows.Err()
+}
+
+// Get 按 ID 获取凭据
+func (r *CredentialRepository) Get(id int64) (*types.CredentialRecord, error) {
+	c, err := scanCredential(r.db.queryRow("SELECT "+credentialColumns+" FROM credentials WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, i18n.Errorf("凭据不存在: %d", id)
+	}
+	return c, err
+}
+
+// Delete 删除凭据
+func (r *CredentialRepository) Delete(id int64) error {
+	_, err := r.db.exec("DELETE FROM credentials WHERE id = ?", id)
+	return err
+}
+
+// Clear 删除所有凭据
+func (r *CredentialRepository) Clear() error {
+	_, err := r.db.exec("DELETE FROM credentials")
+	return err
+}
+
+// Count 获取总数
+func (r *CredentialRepository) Count() (int, error) {
+	var count int
+	err := r.db.queryRow("SELECT COUNT(*) FROM credentials").Scan(&count)
+	return count, err
+}
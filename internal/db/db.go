@@ -6,26 +6,35 @@ import (
 	"os"
 	"path/filepath"
 
+	_ "github.com/lib/pq"  // PostgreSQL（--db postgres://...）
 	_ "modernc.org/sqlite" // 纯 Go 实现的 SQLite，无需 CGO
 
 	"kctl/config"
+	"kctl/internal/i18n"
 )
 
 // MemoryDBPath 内存数据库标识
 const MemoryDBPath = ":memory:"
 
 // DB 数据库封装
+// 默认使用 SQLite 文件或内存数据库；path 为 postgres:// 连接串时使用 PostgreSQL，
+// 多个操作者可同时读写同一数据库，不受单个 SQLite 文件的写锁限制
 type DB struct {
 	conn     *sql.DB
 	path     string
 	inMemory bool
+	readOnly bool
+	dialect  dialect
 }
 
-// Open 打开数据库
+// Open 打开数据库，path 为文件路径、:memory: 或 postgres:// 连接串
 func Open(path string) (*DB, error) {
 	if path == "" {
 		path = config.DefaultDBPath
 	}
+	if IsPostgresDSN(path) {
+		return openPostgres(path, false)
+	}
 
 	inMemory := path == MemoryDBPath
 
@@ -34,26 +43,63 @@ func Open(path string) (*DB, error) {
 		dir := filepath.Dir(path)
 		if dir != "." && dir != "" {
 			if err := os.MkdirAll(dir, 0755); err != nil {
-				return nil, fmt.Errorf("创建数据库目录失败: %w", err)
+				return nil, i18n.Errorf("创建数据库目录失败: %w", err)
 			}
 		}
 	}
 
 	conn, err := sql.Open("sqlite", path)
 	if err != nil {
-		return nil, fmt.Errorf("打开数据库失败: %w", err)
+		return nil, i18n.Errorf("打开数据库失败: %w", err)
+	}
+	if inMemory {
+		// 每个连接是独立的内存数据库，并发写入（如 exec 操作记录）时不能新建连接
+		conn.SetMaxOpenConns(1)
 	}
 
-	db := &DB{conn: conn, path: path, inMemory: inMemory}
+	db := &DB{conn: conn, path: path, inMemory: inMemory, dialect: sqliteDialect{}}
 
 	if err := db.initSchema(); err != nil {
 		_ = conn.Close()
 		return nil, err
 	}
 
+	// 文件数据库使用 WAL，允许只读副本在写入时并发读取
+	if !inMemory {
+		if _, err := conn.Exec("PRAGMA journal_mode=WAL"); err != nil {
+			_ = conn.Close()
+			return nil, i18n.Errorf("设置数据库日志模式失败: %w", err)
+		}
+	}
+
 	return db, nil
 }
 
+// OpenReadOnly 以只读方式打开已存在的数据库文件或 PostgreSQL 数据库
+func OpenReadOnly(path string) (*DB, error) {
+	if path == "" || path == MemoryDBPath {
+		return nil, i18n.Errorf("只读模式需要数据库文件路径")
+	}
+	if IsPostgresDSN(path) {
+		return openPostgres(path, true)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, i18n.Errorf("打开数据库失败: %w", err)
+	}
+
+	dsn := "file:" + path + "?mode=ro&_pragma=busy_timeout(5000)&_pragma=query_only(1)"
+	conn, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, i18n.Errorf("打开数据库失败: %w", err)
+	}
+	if err := conn.Ping(); err != nil {
+		_ = conn.Close()
+		return nil, i18n.Errorf("打开数据库失败: %w", err)
+	}
+
+	return &DB{conn: conn, path: path, readOnly: true, dialect: sqliteDialect{}}, nil
+}
+
 // OpenMemory 打开内存数据库（无文件落地）
 func OpenMemory() (*DB, error) {
 	return Open(MemoryDBPath)
@@ -69,16 +115,35 @@ func (db *DB) Conn() *sql.DB {
 	return db.conn
 }
 
-// Path 返回数据库路径
+// Path 返回数据库路径（PostgreSQL 为完整连接串，可能包含密码）
 func (db *DB) Path() string {
 	return db.path
 }
 
+// DisplayPath 返回用于显示的数据库路径，隐藏 PostgreSQL 连接串中的密码
+func (db *DB) DisplayPath() string {
+	if db.IsPostgres() {
+		return redactDSN(db.path)
+	}
+	return db.path
+}
+
+// IsPostgres 返回是否使用 PostgreSQL
+func (db *DB) IsPostgres() bool {
+	_, ok := db.dialect.(postgresDialect)
+	return ok
+}
+
 // IsInMemory 返回是否是内存数据库
 func (db *DB) IsInMemory() bool {
 	return db.inMemory
 }
 
+// IsReadOnly 返回是否以只读方式打开
+func (db *DB) IsReadOnly() bool {
+	return db.readOnly
+}
+
 // initSchema 初始化表结构
 func (db *DB) initSchema() error {
 	schema := `
@@ -121,6 +186,9 @@ func (db *DB) initSchema() error {
 		pods TEXT,
 		collected_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 		kubelet_ip TEXT,
+		note TEXT,
+		tags TEXT,
+		scan_id INTEGER,
 		UNIQUE(name, namespace)
 	);
 
@@ -128,13 +196,181 @@ func (db *DB) initSchema() error {
 	CREATE INDEX IF NOT EXISTS idx_sa_risk_level ON service_accounts(risk_level);
 	CREATE INDEX IF NOT EXISTS idx_sa_is_cluster_admin ON service_accounts(is_cluster_admin);
 	CREATE INDEX IF NOT EXISTS idx_sa_collected_at ON service_accounts(collected_at);
+
+	-- sa scan 运行记录
+	CREATE TABLE IF NOT EXISTS scans (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		started_at DATETIME NOT NULL,
+		duration_ms INTEGER,
+		target TEXT,
+		mode TEXT,
+		partial BOOLEAN DEFAULT FALSE,
+		pods INTEGER,
+		sas INTEGER,
+		risky INTEGER,
+		admins INTEGER,
+		sampled INTEGER DEFAULT 0
+	);
+
+	-- 每次扫描的 SA 结果快照（不含 Token，供 diff 比较）
+	CREATE TABLE IF NOT EXISTS scan_results (
+		scan_id INTEGER NOT NULL,
+		name TEXT NOT NULL,
+		namespace TEXT NOT NULL,
+		risk_level TEXT,
+		permissions TEXT,
+		is_cluster_admin BOOLEAN DEFAULT FALSE,
+		security_flags TEXT,
+		pods TEXT,
+		PRIMARY KEY(scan_id, namespace, name)
+	);
+
+	-- Findings 表（凭据搜寻等发现）
+	CREATE TABLE IF NOT EXISTS findings (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source TEXT NOT NULL,
+		severity TEXT NOT NULL,
+		category TEXT,
+		namespace TEXT,
+		pod TEXT,
+		container TEXT,
+		location TEXT,
+		title TEXT,
+		evidence TEXT,
+		collected_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		kubelet_ip TEXT,
+		UNIQUE(source, namespace, pod, container, location)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_findings_severity ON findings(severity);
+	CREATE INDEX IF NOT EXISTS idx_findings_source ON findings(source);
+
+	-- deploy 命令创建的资源（供 deploy --cleanup 清理）
+	CREATE TABLE IF NOT EXISTS deployments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		api_version TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		namespace TEXT,
+		name TEXT NOT NULL,
+		template TEXT,
+		node TEXT,
+		settings TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(api_version, kind, namespace, name)
+	);
+
+	-- harvest 收集的节点凭据（kubeconfig Token、客户端证书）
+	CREATE TABLE IF NOT EXISTS credentials (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		kind TEXT NOT NULL,
+		identity TEXT NOT NULL,
+		groups TEXT,
+		source TEXT NOT NULL,
+		server TEXT,
+		ca_data TEXT,
+		token TEXT,
+		client_cert TEXT,
+		client_key TEXT,
+		expires_at DATETIME,
+		collected_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		kubelet_ip TEXT,
+		UNIQUE(kind, identity, source)
+	);
+
+	-- pscan 从 Pod 内发现的开放端口
+	CREATE TABLE IF NOT EXISTS ports (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		ip TEXT NOT NULL,
+		port INTEGER NOT NULL,
+		service TEXT,
+		source TEXT NOT NULL,
+		scanned_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		kubelet_ip TEXT,
+		UNIQUE(ip, port, source)
+	);
+
+	-- 对集群执行的修改和执行操作（oplog），只追加不删除
+	CREATE TABLE IF NOT EXISTS operations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		time DATETIME NOT NULL,
+		action TEXT NOT NULL,
+		target TEXT NOT NULL,
+		detail TEXT,
+		identity TEXT,
+		via TEXT,
+		command TEXT,
+		result TEXT NOT NULL,
+		error TEXT
+	);
+
+	-- exec --all-pods 的执行结果，同一次批量执行共享 run_id
+	CREATE TABLE IF NOT EXISTS exec_results (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		run_id INTEGER NOT NULL,
+		time DATETIME NOT NULL,
+		node TEXT,
+		namespace TEXT NOT NULL,
+		pod TEXT NOT NULL,
+		container TEXT,
+		command TEXT NOT NULL,
+		stdout TEXT,
+		stderr TEXT,
+		exit_code INTEGER DEFAULT 0,
+		error TEXT
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_exec_results_run ON exec_results(run_id);
+
+	-- Pod 缓存快照（供只读副本浏览）
+	CREATE TABLE IF NOT EXISTS pod_cache (
+		uid TEXT PRIMARY KEY,
+		namespace TEXT NOT NULL,
+		name TEXT NOT NULL,
+		data TEXT NOT NULL,
+		collected_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	-- 会话元数据（如最近扫描时间）
+	CREATE TABLE IF NOT EXISTS meta (
+		key TEXT PRIMARY KEY,
+		value TEXT
+	);
 	`
 
-	_, err := db.conn.Exec(schema)
+	_, err := db.conn.Exec(db.dialect.ddl(schema))
 	if err != nil {
-		return fmt.Errorf("初始化数据库表结构失败: %w", err)
+		return i18n.Errorf("初始化数据库表结构失败: %w", err)
 	}
 
+	return db.addMissingColumns()
+}
+
+// addedColumns 旧版本数据库文件中可能缺少的列
+var addedColumns = []struct {
+	table, column, definition string
+}{
+	{"deployments", "settings", "TEXT"},
+	{"service_accounts", "note", "TEXT"},
+	{"service_accounts", "tags", "TEXT"},
+	{"service_accounts", "scan_id", "INTEGER"},
+	{"scans", "sampled", "INTEGER DEFAULT 0"},
+}
+
+// addMissingColumns 为旧版本数据库文件补充新增的列
+func (db *DB) addMissingColumns() error {
+	for _, c := range addedColumns {
+		exists, err := db.dialect.hasColumn(db.conn, c.table, c.column)
+		if err != nil {
+			return i18n.Errorf("检查 %s.%s 列失败: %w", c.table, c.column, err)
+		}
+		if exists {
+			continue
+		}
+		definition := db.dialect.ddl(c.definition)
+		if _, err := db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", c.table, c.column, definition)); err != nil {
+			return i18n.Errorf("升级 %s 表结构失败: %w", c.table, err)
+		}
+	}
 	return nil
 }
 
@@ -142,3 +378,27 @@ func (db *DB) initSchema() error {
 func DefaultPath() string {
 	return config.DefaultDBPath
 }
+
+// SnapshotTo 将数据库完整复制到新文件（VACUUM INTO），内存数据库和只读数据库同样适用
+func (db *DB) SnapshotTo(path string) error {
+	if db.IsPostgres() {
+		return i18n.Errorf("PostgreSQL 数据库不支持快照到文件，请使用 pg_dump 或 export jsonl")
+	}
+	if _, err := os.Stat(path); err == nil {
+		return i18n.Errorf("文件已存在: %s", path)
+	}
+	conn := db.conn
+	// 只读连接设置了 query_only，VACUUM INTO 会被拒绝，改用不带该限制的只读连接
+	if db.readOnly {
+		roConn, err := sql.Open("sqlite", "file:"+db.path+"?mode=ro&_pragma=busy_timeout(5000)")
+		if err != nil {
+			return i18n.Errorf("打开数据库失败: %w", err)
+		}
+		defer func() { _ = roConn.Close() }()
+		conn = roConn
+	}
+	if _, err := conn.Exec("VACUUM INTO ?", path); err != nil {
+		return i18n.Errorf("复制数据库失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// DeploymentRepository deploy 创建资源的记录仓库
+type DeploymentRepository struct {
+	db *DB
+}
+
+// NewDeploymentRepository 创建部署记录仓库
+func NewDeploymentRepository(db *DB) *DeploymentRepository {
+	return &DeploymentRepository{db: db}
+}
+
+// Save 记录一个已创建的资源（同一资源重复创建时覆盖）
+func (r *DeploymentRepository) Save(record *types.DeploymentRecord) error {
+	_, err := r.db.exec(`
+		INSERT INTO deployments (
+			api_version, kind, namespace, name, template, node, settings, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(api_version, kind, namespace, name) DO UPDATE SET
+			template = excluded.template,
+			node = excluded.node,
+			settings = excluded.settings,
+			created_at = excluded.created_at
+	`,
+		record.Resource.APIVersion, record.Resource.Kind, record.Resource.Namespace,
+		record.Resource.Name, record.Template, record.Node, record.Settings, record.CreatedAt,
+	)
+	if err != nil {
+		return i18n.Errorf("保存部署记录 %s 失败: %w", record.Resource, err)
+	}
+	return nil
+}
+
+// GetAll 获取所有部署记录（按创建时间排序）
+func (r *DeploymentRepository) GetAll() ([]*types.DeploymentRecord, error) {
+	rows, err := r.db.query(`
+		SELECT id, api_version, kind, namespace, name, template, node, COALESCE(settings, ''), created_at
+		FROM deployments ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records []*types.DeploymentRecord
+	for rows.Next() {
+		var d types.DeploymentRecord
+		err := rows.Scan(
+			&d.ID, &d.Resource.APIVersion, &d.Resource.Kind, &d.Resource.Namespace,
+			&d.Resource.Name, &d.Template, &d.Node, &d.Settings, &d.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &d)
+	}
+	return records, rows.Err()
+}
+
+// Delete 删除部署记录
+func (r *DeploymentRepository) Delete(id int64) error {
+	_, err := r.db.exec("DELETE FROM deployments WHERE id = ?", id)
+	return err
+}
+
+// Count 获取总数
+func (r *DeploymentRepository) Count() (int, error) {
+	var count int
+	err := r.db.queryRow("SELECT COUNT(*) FROM deployments").Scan(&count)
+	return count, err
+}
@@ -0,0 +1,225 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"kctl/internal/i18n"
+)
+
+// dialect 数据库方言，屏蔽 SQLite 与 PostgreSQL 之间的 SQL 差异
+// 仓库中的 SQL 按 SQLite 语法书写（? 占位符、SQLite 类型名），执行前由方言转换；
+// 两种数据库都支持的写法（ON CONFLICT ... DO UPDATE、RETURNING）直接使用，不需要转换
+type dialect interface {
+	// driver 返回 database/sql 驱动名
+	driver() string
+	// rebind 将 ? 占位符转换为驱动使用的格式
+	rebind(query string) string
+	// ddl 将建表语句中的类型转换为方言的类型
+	ddl(schema string) string
+	// hasColumn 检查表中是否存在指定列
+	hasColumn(conn *sql.DB, table, column string) (bool, error)
+	// beginReadOnly 开始只读事务，事务中的任何修改都由数据库拒绝；done 回滚事务并释放连接
+	beginReadOnly(ctx context.Context, db *DB) (tx *sql.Tx, done func(), err error)
+}
+
+// sqliteDialect SQLite（默认）
+type sqliteDialect struct{}
+
+func (sqliteDialect) driver() string { return "sqlite" }
+
+func (sqliteDialect) rebind(query string) string { return query }
+
+func (sqliteDialect) ddl(schema string) string { return schema }
+
+func (sqliteDialect) hasColumn(conn *sql.DB, table, column string) (bool, error) {
+	var count int
+	err := conn.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&count)
+	return count > 0, err
+}
+
+// beginReadOnly 文件数据库另外以 mode=ro 打开连接，SQLite 在文件层面拒绝写入；
+// 内存数据库只有一个连接，在该连接上开启 query_only，结束后恢复
+func (sqliteDialect) beginReadOnly(ctx context.Context, db *DB) (*sql.Tx, func(), error) {
+	if db.inMemory {
+		conn, err := db.conn.Conn(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+			_ = conn.Close()
+			return nil, nil, err
+		}
+		reset := func() {
+			_, _ = conn.ExecContext(context.Background(), "PRAGMA query_only = OFF")
+			_ = conn.Close()
+		}
+		tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		if err != nil {
+			reset()
+			return nil, nil, err
+		}
+		return tx, func() { _ = tx.Rollback(); reset() }, nil
+	}
+
+	roConn, err := sql.Open("sqlite", "file:"+db.path+"?mode=ro&_pragma=busy_timeout(5000)&_pragma=query_only(1)")
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := roConn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		_ = roConn.Close()
+		return nil, nil, err
+	}
+	return tx, func() { _ = tx.Rollback(); _ = roConn.Close() }, nil
+}
+
+// postgresDialect PostgreSQL，供多人共用的团队服务器部署
+type postgresDialect struct{}
+
+func (postgresDialect) driver() string { return "postgres" }
+
+// rebind 将 ? 依次替换为 $1、$2 ...，跳过字符串字面量中的 ?
+func (postgresDialect) rebind(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+	var b strings.Builder
+	n, quoted := 0, false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case c == '?' && !quoted:
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// postgresTypes SQLite 类型到 PostgreSQL 类型的映射（按顺序匹配，自增主键优先）
+var postgresTypes = strings.NewReplacer(
+	"INTEGER PRIMARY KEY AUTOINCREMENT", "BIGSERIAL PRIMARY KEY",
+	"INTEGER", "BIGINT",
+	"DATETIME", "TIMESTAMPTZ",
+)
+
+func (postgresDialect) ddl(schema string) string {
+	return postgresTypes.Replace(schema)
+}
+
+func (postgresDialect) hasColumn(conn *sql.DB, table, column string) (bool, error) {
+	var count int
+	err := conn.QueryRow(`
+		SELECT COUNT(*) FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2
+	`, table, column).Scan(&count)
+	return count > 0, err
+}
+
+// beginReadOnly 使用 BEGIN READ ONLY 事务，结束时总是回滚，事务中的 set_config 等修改也随之撤销
+func (postgresDialect) beginReadOnly(ctx context.Context, db *DB) (*sql.Tx, func(), error) {
+	tx, err := db.conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, func() { _ = tx.Rollback() }, nil
+}
+
+// IsPostgresDSN 判断 --db 参数是否为 PostgreSQL 连接串（postgres:// 或 postgresql://）
+func IsPostgresDSN(path string) bool {
+	return strings.HasPrefix(path, "postgres://") || strings.HasPrefix(path, "postgresql://")
+}
+
+// redactDSN 隐藏连接串中的密码，用于显示
+func redactDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return dsn
+	}
+	return u.Redacted()
+}
+
+// openPostgres 连接 PostgreSQL 数据库，readOnly 时由服务端拒绝所有写入
+func openPostgres(dsn string, readOnly bool) (*DB, error) {
+	connDSN := dsn
+	if readOnly {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return nil, i18n.Errorf("解析数据库连接串失败: %w", err)
+		}
+		// lib/pq 将无法识别的参数作为会话参数发送给服务端
+		q := u.Query()
+		q.Set("default_transaction_read_only", "on")
+		u.RawQuery = q.Encode()
+		connDSN = u.String()
+	}
+
+	d := postgresDialect{}
+	conn, err := sql.Open(d.driver(), connDSN)
+	if err != nil {
+		return nil, i18n.Errorf("打开数据库失败: %w", err)
+	}
+	if err := conn.Ping(); err != nil {
+		_ = conn.Close()
+		return nil, i18n.Errorf("连接数据库 %s 失败: %w", redactDSN(dsn), err)
+	}
+
+	db := &DB{conn: conn, path: dsn, readOnly: readOnly, dialect: d}
+	if !readOnly {
+		if err := db.initSchema(); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// exec 执行语句，占位符按方言转换
+func (db *DB) exec(query string, args ...any) (sql.Result, error) {
+	return db.conn.Exec(db.dialect.rebind(query), args...)
+}
+
+// query 执行查询，占位符按方言转换
+func (db *DB) query(query string, args ...any) (*sql.Rows, error) {
+	return db.conn.Query(db.dialect.rebind(query), args...)
+}
+
+// queryRow 执行单行查询，占位符按方言转换
+func (db *DB) queryRow(query string, args ...any) *sql.Row {
+	return db.conn.QueryRow(db.dialect.rebind(query), args...)
+}
+
+// begin 开始事务，事务中的语句同样按方言转换占位符
+func (db *DB) begin() (*dbTx, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &dbTx{Tx: tx, dialect: db.dialect}, nil
+}
+
+// dbTx 按方言转换占位符的事务
+type dbTx struct {
+	*sql.Tx
+	dialect dialect
+}
+
+func (tx *dbTx) Exec(query string, args ...any) (sql.Result, error) {
+	return tx.Tx.Exec(tx.dialect.rebind(query), args...)
+}
+
+func (tx *dbTx) QueryRow(query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRow(tx.dialect.rebind(query), args...)
+}
+
+func (tx *dbTx) Prepare(query string) (*sql.Stmt, error) {
+	return tx.Tx.Prepare(tx.dialect.rebind(query))
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// ExecResultRepository exec --all-pods 执行结果仓库
+type ExecResultRepository struct {
+	db *DB
+}
+
+// NewExecResultRepository 创建执行结果仓库
+func NewExecResultRepository(db *DB) *ExecResultRepository {
+	return &ExecResultRepository{db: db}
+}
+
+// SaveRun 将一次批量执行的结果保存为新的一轮，返回分配的 RunID
+func (r *ExecResultRepository) SaveRun(records []*types.ExecResultRecord) (int64, error) {
+	tx, err := r.db.begin()
+	if err != nil {
+		return 0, i18n.Errorf("开始事务失败: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var runID int64
+	if err := tx.QueryRow("SELECT COALESCE(MAX(run_id), 0) + 1 FROM exec_results").Scan(&runID); err != nil {
+		return 0, i18n.Errorf("分配执行编号失败: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO exec_results (
+			run_id, time, node, namespace, pod, container, command, stdout, stderr, exit_code, error
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`)
+	if err != nil {
+		return 0, i18n.Errorf("准备语句失败: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, record := range records {
+		err := stmt.QueryRow(
+			runID, record.Time, record.Node, record.Namespace, record.Pod, record.Container,
+			record.Command, record.Stdout, record.Stderr, record.ExitCode, record.Error,
+		).Scan(&record.ID)
+		if err != nil {
+			return 0, i18n.Errorf("保存 %s/%s 的执行结果失败: %w", record.Namespace, record.Pod, err)
+		}
+		record.RunID = runID
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, i18n.Errorf("提交事务失败: %w", err)
+	}
+	return runID, nil
+}
+
+// GetAll 获取所有执行结果（按执行编号、节点、命名空间、Pod 排序）
+func (r *ExecResultRepository) GetAll() ([]*types.ExecResultRecord, error) {
+	var records []*types.ExecResultRecord
+	err := r.Each(func(e *types.ExecResultRecord) error {
+		records = append(records, e)
+		return nil
+	})
+	return records, err
+}
+
+// Each 按 GetAll 的顺序逐行读取执行结果，不在内存中保留整个结果集
+// fn 返回错误时停止遍历并返回该错误；fn 中不能再查询数据库（内存数据库只有一个连接）
+func (r *ExecResultRepository) Each(fn func(*types.ExecResultRecord) error) error {
+	rows, err := r.db.query(`
+		SELECT id, run_id, time, COALESCE(node, ''), namespace, pod, COALESCE(container, ''),
+			command, COALESCE(stdout, ''), COALESCE(stderr, ''), exit_code, COALESCE(error, '')
+		FROM exec_results ORDER BY run_id, node, namespace, pod, id
+	`)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var e types.ExecResultRecord
+		err := rows.Scan(
+			&e.ID, &e.RunID, &e.Time, &e.Node, &e.Namespace, &e.Pod, &e.Container,
+			&e.Command, &e.Stdout, &e.Stderr, &e.ExitCode, &e.Error,
+		)
+		if err != nil {
+			return err
+		}
+		if err := fn(&e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Clear 删除所有执行结果
+func (r *ExecResultRepository) Clear() error {
+	_, err := r.db.exec("DELETE FROM exec_results")
+	return err
+}
+
+// Count 获取总数
+func (r *ExecResultRepository) Count() (int, error) {
+	var count int
+	err := r.db.queryRow("SELECT COUNT(*) FROM exec_results").Scan(&count)
+	return count, err
+}
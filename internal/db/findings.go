@@ -0,0 +1,169 @@
+package db
+
+import (
+	"database/sql"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// FindingRepository 发现数据仓库
+type FindingRepository struct {
+	db *DB
+}
+
+// NewFindingRepository 创建发现仓库
+func NewFindingRepository(db *DB) *FindingRepository {
+	return &FindingRepository{db: db}
+}
+
+// SaveBatch 批量保存发现（相同来源/位置的记录会被覆盖）
+func (r *FindingRepository) SaveBatch(records []*types.FindingRecord) (int, error) {
+	tx, err := r.db.begin()
+	if err != nil {
+		return 0, i18n.Errorf("开始事务失败: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO findings (
+			source, severity, category, namespace, pod, container,
+			location, title, evidence, collected_at, kubelet_ip
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(source, namespace, pod, container, location) DO UPDATE SET
+			severity = excluded.severity,
+			category = excluded.category,
+			title = excluded.title,
+			evidence = excluded.evidence,
+			collected_at = excluded.collected_at,
+			kubelet_ip = excluded.kubelet_ip
+	`)
+	if err != nil {
+		return 0, i18n.Errorf("准备语句失败: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	saved := 0
+	for _, record := range records {
+		_, err := stmt.Exec(
+			record.Source, record.Severity, record.Category, record.Namespace,
+			record.Pod, record.Container, record.Location, record.Title,
+			record.Evidence, record.CollectedAt, record.KubeletIP,
+		)
+		if err != nil {
+			return saved, i18n.Errorf("保存发现 %s/%s 失败: %w", record.Namespace, record.Pod, err)
+		}
+		saved++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return saved, i18n.Errorf("提交事务失败: %w", err)
+	}
+
+	return saved, nil
+}
+
+// selectAllFindings 按严重程度排序查询所有发现
+const selectAllFindings = `
+	SELECT id, source, severity, category, namespace, pod, container,
+		   location, title, evidence, collected_at, kubelet_ip
+	FROM findings
+	ORDER BY ` + findingSeverityOrder + `, namespace, pod, location
+`
+
+// GetAll 获取所有发现（按严重程度排序）
+func (r *FindingRepository) GetAll() ([]*types.FindingRecord, error) {
+	return r.query(selectAllFindings)
+}
+
+// Each 按 GetAll 的顺序逐行读取发现，不在内存中保留整个结果集
+// fn 返回错误时停止遍历并返回该错误；fn 中不能再查询数据库（内存数据库只有一个连接）
+func (r *FindingRepository) Each(fn func(*types.FindingRecord) error) error {
+	rows, err := r.db.query(selectAllFindings)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		f, err := scanFinding(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetBySource 按来源获取
+func (r *FindingRepository) GetBySource(source string) ([]*types.FindingRecord, error) {
+	return r.query(`
+		SELECT id, source, severity, category, namespace, pod, container,
+			   location, title, evidence, collected_at, kubelet_ip
+		FROM findings WHERE source = ?
+		ORDER BY `+findingSeverityOrder+`, namespace, pod, location
+	`, source)
+}
+
+// Count 获取总数
+func (r *FindingRepository) Count() (int, error) {
+	var count int
+	err := r.db.queryRow("SELECT COUNT(*) FROM findings").Scan(&count)
+	return count, err
+}
+
+// Clear 清空所有记录
+func (r *FindingRepository) Clear() error {
+	_, err := r.db.exec("DELETE FROM findings")
+	return err
+}
+
+// findingSeverityOrder 严重程度排序表达式
+const findingSeverityOrder = `
+	CASE severity
+		WHEN 'CRITICAL' THEN 0
+		WHEN 'HIGH' THEN 1
+		WHEN 'MEDIUM' THEN 2
+		WHEN 'LOW' THEN 3
+		ELSE 4
+	END`
+
+// query 通用查询方法
+func (r *FindingRepository) query(sql string, args ...interface{}) ([]*types.FindingRecord, error) {
+	rows, err := r.db.query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanFindingRows(rows)
+}
+
+// scanFindingRows 扫描行
+func scanFindingRows(rows *sql.Rows) ([]*types.FindingRecord, error) {
+	var findings []*types.FindingRecord
+	for rows.Next() {
+		f, err := scanFinding(rows)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+// scanFinding 扫描单行
+func scanFinding(rows *sql.Rows) (*types.FindingRecord, error) {
+	var f types.FindingRecord
+	err := rows.Scan(
+		&f.ID, &f.Source, &f.Severity, &f.Category, &f.Namespace,
+		&f.Pod, &f.Container, &f.Location, &f.Title, &f.Evidence,
+		&f.CollectedAt, &f.KubeletIP,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
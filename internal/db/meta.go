@@ -0,0 +1,39 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+
+	"kctl/internal/i18n"
+)
+
+// 元数据键
+const (
+	MetaLastScan     = "last_scan"     // 最近一次 SA 扫描时间 (RFC3339)
+	MetaSessionState = "session_state" // 退出时保存的会话状态 (JSON)
+)
+
+// SetMeta 写入元数据
+func (db *DB) SetMeta(key, value string) error {
+	_, err := db.exec(`
+		INSERT INTO meta (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value
+	`, key, value)
+	if err != nil {
+		return i18n.Errorf("写入元数据失败: %w", err)
+	}
+	return nil
+}
+
+// GetMeta 读取元数据，不存在时返回空字符串
+func (db *DB) GetMeta(key string) (string, error) {
+	var value sql.NullString
+	err := db.queryRow("SELECT value FROM meta WHERE key = ?", key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", i18n.Errorf("读取元数据失败: %w", err)
+	}
+	return value.String, nil
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// OperationRepository 操作审计记录仓库
+type OperationRepository struct {
+	db *DB
+}
+
+// NewOperationRepository 创建操作审计记录仓库
+func NewOperationRepository(db *DB) *OperationRepository {
+	return &OperationRepository{db: db}
+}
+
+// Save 追加一条操作记录
+func (r *OperationRepository) Save(record *types.OperationRecord) error {
+	err := r.db.queryRow(`
+		INSERT INTO operations (
+			time, action, target, detail, identity, via, command, result, error
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`,
+		record.Time, record.Action, record.Target, record.Detail, record.Identity,
+		record.Via, record.Command, record.Result, record.Error,
+	).Scan(&record.ID)
+	if err != nil {
+		return i18n.Errorf("保存操作记录失败: %w", err)
+	}
+	return nil
+}
+
+// GetAll 获取所有操作记录（按时间排序）
+func (r *OperationRepository) GetAll() ([]*types.OperationRecord, error) {
+	rows, err := r.db.query(`
+		SELECT id, time, action, target, COALESCE(detail, ''), COALESCE(identity, ''),
+			COALESCE(via, ''), COALESCE(command, ''), result, COALESCE(error, '')
+		FROM operations ORDER BY time, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records []*types.OperationRecord
+	for rows.Next() {
+		var o types.OperationRecord
+		err := rows.Scan(
+			&o.ID, &o.Time, &o.Action, &o.Target, &o.Detail, &o.Identity,
+			&o.Via, &o.Command, &o.Result, &o.Error,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &o)
+	}
+	return records, rows.Err()
+}
+
+// Count 获取总数
+func (r *OperationRepository) Count() (int, error) {
+	var count int
+	err := r.db.queryRow("SELECT COUNT(*) FROM operations").Scan(&count)
+	return count, err
+}
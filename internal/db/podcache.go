@@ -0,0 +1,94 @@
+package db
+
+import (
+	"encoding/json"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// PodCacheRepository Pod 缓存快照仓库
+// 保存控制台最近一次获取的 Pod 列表，供只读副本浏览
+type PodCacheRepository struct {
+	db *DB
+}
+
+// NewPodCacheRepository 创建 Pod 缓存快照仓库
+func NewPodCacheRepository(db *DB) *PodCacheRepository {
+	return &PodCacheRepository{db: db}
+}
+
+// Replace 用新的 Pod 列表替换快照
+func (r *PodCacheRepository) Replace(pods []types.PodContainerInfo) error {
+	tx, err := r.db.begin()
+	if err != nil {
+		return i18n.Errorf("开始事务失败: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM pod_cache"); err != nil {
+		return i18n.Errorf("清空 Pod 缓存失败: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO pod_cache (uid, namespace, name, data) VALUES (?, ?, ?, ?)
+		ON CONFLICT(uid) DO UPDATE SET namespace = excluded.namespace, name = excluded.name, data = excluded.data
+	`)
+	if err != nil {
+		return i18n.Errorf("准备语句失败: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, pod := range pods {
+		data, err := json.Marshal(pod)
+		if err != nil {
+			return i18n.Errorf("序列化 Pod %s/%s 失败: %w", pod.Namespace, pod.PodName, err)
+		}
+		uid := pod.UID
+		if uid == "" {
+			uid = pod.Namespace + "/" + pod.PodName
+		}
+		if _, err := stmt.Exec(uid, pod.Namespace, pod.PodName, string(data)); err != nil {
+			return i18n.Errorf("保存 Pod %s/%s 失败: %w", pod.Namespace, pod.PodName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return i18n.Errorf("提交事务失败: %w", err)
+	}
+	return nil
+}
+
+// GetAll 获取快照中的所有 Pod
+func (r *PodCacheRepository) GetAll() ([]types.PodContainerInfo, error) {
+	var pods []types.PodContainerInfo
+	err := r.Each(func(pod types.PodContainerInfo) error {
+		pods = append(pods, pod)
+		return nil
+	})
+	return pods, err
+}
+
+// Each 按命名空间、名称顺序逐个读取快照中的 Pod，不在内存中构建完整列表
+func (r *PodCacheRepository) Each(fn func(types.PodContainerInfo) error) error {
+	rows, err := r.db.query("SELECT data FROM pod_cache ORDER BY namespace, name")
+	if err != nil {
+		return i18n.Errorf("查询 Pod 缓存失败: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return i18n.Errorf("扫描行失败: %w", err)
+		}
+		var pod types.PodContainerInfo
+		if err := json.Unmarshal([]byte(data), &pod); err != nil {
+			return i18n.Errorf("解析 Pod 缓存失败: %w", err)
+		}
+		if err := fn(pod); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
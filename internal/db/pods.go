@@ -2,8 +2,8 @@ package db
 
 import (
 	"database/sql"
-	"fmt"
 
+	"kctl/internal/i18n"
 	"kctl/pkg/types"
 )
 
@@ -17,17 +17,32 @@ func NewPodRepository(db *DB) *PodRepository {
 	return &PodRepository{db: db}
 }
 
-// Save 保存单个 Pod
-func (r *PodRepository) Save(record *types.PodRecord) error {
-	query := `
-	INSERT OR REPLACE INTO pods (
+// upsertPod 插入或按 UID 覆盖 Pod
+const upsertPod = `
+	INSERT INTO pods (
 		name, namespace, uid, node_name, pod_ip, host_ip, phase,
 		service_account, creation_timestamp, containers, volumes,
 		security_context, collected_at, kubelet_ip
 	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
+	ON CONFLICT(uid) DO UPDATE SET
+		name = excluded.name,
+		namespace = excluded.namespace,
+		node_name = excluded.node_name,
+		pod_ip = excluded.pod_ip,
+		host_ip = excluded.host_ip,
+		phase = excluded.phase,
+		service_account = excluded.service_account,
+		creation_timestamp = excluded.creation_timestamp,
+		containers = excluded.containers,
+		volumes = excluded.volumes,
+		security_context = excluded.security_context,
+		collected_at = excluded.collected_at,
+		kubelet_ip = excluded.kubelet_ip
+`
 
-	_, err := r.db.conn.Exec(query,
+// Save 保存单个 Pod
+func (r *PodRepository) Save(record *types.PodRecord) error {
+	_, err := r.db.exec(upsertPod,
 		record.Name, record.Namespace, record.UID, record.NodeName,
 		record.PodIP, record.HostIP, record.Phase, record.ServiceAccount,
 		record.CreationTimestamp, record.Containers, record.Volumes,
@@ -39,21 +54,15 @@ func (r *PodRepository) Save(record *types.PodRecord) error {
 
 // SaveBatch 批量保存 Pod
 func (r *PodRepository) SaveBatch(records []*types.PodRecord) (int, error) {
-	tx, err := r.db.conn.Begin()
+	tx, err := r.db.begin()
 	if err != nil {
-		return 0, fmt.Errorf("开始事务失败: %w", err)
+		return 0, i18n.Errorf("开始事务失败: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	stmt, err := tx.Prepare(`
-		INSERT OR REPLACE INTO pods (
-			name, namespace, uid, node_name, pod_ip, host_ip, phase,
-			service_account, creation_timestamp, containers, volumes,
-			security_context, collected_at, kubelet_ip
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`)
+	stmt, err := tx.Prepare(upsertPod)
 	if err != nil {
-		return 0, fmt.Errorf("准备语句失败: %w", err)
+		return 0, i18n.Errorf("准备语句失败: %w", err)
 	}
 	defer func() { _ = stmt.Close() }()
 
@@ -66,13 +75,13 @@ func (r *PodRepository) SaveBatch(records []*types.PodRecord) (int, error) {
 			record.SecurityContext, record.CollectedAt, record.KubeletIP,
 		)
 		if err != nil {
-			return saved, fmt.Errorf("保存 Pod %s/%s 失败: %w", record.Namespace, record.Name, err)
+			return saved, i18n.Errorf("保存 Pod %s/%s 失败: %w", record.Namespace, record.Name, err)
 		}
 		saved++
 	}
 
 	if err := tx.Commit(); err != nil {
-		return saved, fmt.Errorf("提交事务失败: %w", err)
+		return saved, i18n.Errorf("提交事务失败: %w", err)
 	}
 
 	return saved, nil
@@ -148,13 +157,13 @@ func (r *PodRepository) GetWithHostPath() ([]*types.PodRecord, error) {
 // Count 获取总数
 func (r *PodRepository) Count() (int, error) {
 	var count int
-	err := r.db.conn.QueryRow("SELECT COUNT(*) FROM pods").Scan(&count)
+	err := r.db.queryRow("SELECT COUNT(*) FROM pods").Scan(&count)
 	return count, err
 }
 
 // GetNamespaces 获取所有命名空间
 func (r *PodRepository) GetNamespaces() ([]string, error) {
-	rows, err := r.db.conn.Query("SELECT DISTINCT namespace FROM pods ORDER BY namespace")
+	rows, err := r.db.query("SELECT DISTINCT namespace FROM pods ORDER BY namespace")
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +183,7 @@ func (r *PodRepository) GetNamespaces() ([]string, error) {
 
 // GetServiceAccounts 获取所有 ServiceAccount
 func (r *PodRepository) GetServiceAccounts() ([]string, error) {
-	rows, err := r.db.conn.Query("SELECT DISTINCT service_account FROM pods WHERE service_account != '' ORDER BY service_account")
+	rows, err := r.db.query("SELECT DISTINCT service_account FROM pods WHERE service_account != '' ORDER BY service_account")
 	if err != nil {
 		return nil, err
 	}
@@ -194,13 +203,13 @@ func (r *PodRepository) GetServiceAccounts() ([]string, error) {
 
 // Clear 清空所有记录
 func (r *PodRepository) Clear() error {
-	_, err := r.db.conn.Exec("DELETE FROM pods")
+	_, err := r.db.exec("DELETE FROM pods")
 	return err
 }
 
 // query 通用查询方法
 func (r *PodRepository) query(sql string, args ...interface{}) ([]*types.PodRecord, error) {
-	rows, err := r.db.conn.Query(sql, args...)
+	rows, err := r.db.query(sql, args...)
 	if err != nil {
 		return nil, err
 	}
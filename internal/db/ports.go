@@ -0,0 +1,86 @@
+package db
+
+import (
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// PortRepository pscan 发现的开放端口仓库
+type PortRepository struct {
+	db *DB
+}
+
+// NewPortRepository 创建开放端口仓库
+func NewPortRepository(db *DB) *PortRepository {
+	return &PortRepository{db: db}
+}
+
+// SaveBatch 批量保存开放端口（同一 Pod 重复扫描到的同一端口覆盖），返回保存数量
+func (r *PortRepository) SaveBatch(records []*types.PortRecord) (int, error) {
+	tx, err := r.db.begin()
+	if err != nil {
+		return 0, i18n.Errorf("开始事务失败: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO ports (ip, port, service, source, scanned_at, kubelet_ip)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(ip, port, source) DO UPDATE SET
+			service = excluded.service,
+			scanned_at = excluded.scanned_at,
+			kubelet_ip = excluded.kubelet_ip
+	`)
+	if err != nil {
+		return 0, i18n.Errorf("准备语句失败: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	count := 0
+	for _, record := range records {
+		if _, err := stmt.Exec(record.IP, record.Port, record.Service, record.Source, record.ScannedAt, record.KubeletIP); err != nil {
+			return count, i18n.Errorf("保存端口 %s:%d 失败: %w", record.IP, record.Port, err)
+		}
+		count++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, i18n.Errorf("提交事务失败: %w", err)
+	}
+	return count, nil
+}
+
+// GetAll 获取所有开放端口（按 IP、端口排序）
+func (r *PortRepository) GetAll() ([]*types.PortRecord, error) {
+	rows, err := r.db.query(`
+		SELECT id, ip, port, COALESCE(service, ''), source, scanned_at, COALESCE(kubelet_ip, '')
+		FROM ports ORDER BY ip, port, source
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records []*types.PortRecord
+	for rows.Next() {
+		var p types.PortRecord
+		if err := rows.Scan(&p.ID, &p.IP, &p.Port, &p.Service, &p.Source, &p.ScannedAt, &p.KubeletIP); err != nil {
+			return nil, err
+		}
+		records = append(records, &p)
+	}
+	return records, rows.Err()
+}
+
+// Clear 删除所有开放端口
+func (r *PortRepository) Clear() error {
+	_, err := r.db.exec("DELETE FROM ports")
+	return err
+}
+
+// Count 获取总数
+func (r *PortRepository) Count() (int, error) {
+	var count int
+	err := r.db.queryRow("SELECT COUNT(*) FROM ports").Scan(&count)
+	return count, err
+}
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"unicode"
+
+	"kctl/internal/i18n"
+)
+
+// QueryResult 原始 SQL 查询结果
+type QueryResult struct {
+	Columns   []string
+	Rows      [][]any // []byte 已转换为 string，NULL 为 nil
+	Truncated bool    // 结果超过 limit，剩余行未读取
+}
+
+// Query 执行用户输入的原始 SQL（query 命令），最多读取 limit 行（0 表示不限制）
+// write 为 false 时只允许单条 SELECT / WITH / VALUES / EXPLAIN 语句，并在只读事务中执行，
+// 任何修改都由数据库拒绝，事务结束时总是回滚
+func (db *DB) Query(ctx context.Context, query string, write bool, limit int) (*QueryResult, error) {
+	if write && db.readOnly {
+		return nil, i18n.Errorf("数据库以只读方式打开，不能执行写入")
+	}
+
+	var rows *sql.Rows
+	var err error
+	if write {
+		rows, err = db.conn.QueryContext(ctx, query)
+	} else {
+		if err := checkReadOnlyQuery(query, db.IsPostgres()); err != nil {
+			return nil, err
+		}
+		tx, done, txErr := db.dialect.beginReadOnly(ctx, db)
+		if txErr != nil {
+			return nil, i18n.Errorf("开始只读查询失败: %w", txErr)
+		}
+		defer done()
+		// 预编译的语句只能包含一条命令（PostgreSQL 由服务端拒绝多条语句）
+		stmt, prepErr := tx.PrepareContext(ctx, query)
+		if prepErr != nil {
+			return nil, prepErr
+		}
+		defer func() { _ = stmt.Close() }()
+		rows, err = stmt.QueryContext(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := &QueryResult{Columns: columns}
+	for rows.Next() {
+		if limit > 0 && len(result.Rows) == limit {
+			result.Truncated = true
+			break
+		}
+		values := make([]any, len(columns))
+		ptrs := make([]any, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	return result, rows.Err()
+}
+
+// readOnlyKeywords 只读查询允许的起始关键字
+var readOnlyKeywords = []string{"SELECT", "WITH", "VALUES", "EXPLAIN"}
+
+// checkReadOnlyQuery 检查只读查询：只能包含一条语句，且以 readOnlyKeywords 开头，
+// PRAGMA、ATTACH、SET、RESET 等可以关闭只读限制或写入其他文件的语句都不允许；
+// dollarQuotes 为 true 时识别 PostgreSQL 的 $tag$...$tag$ 字符串
+func checkReadOnlyQuery(query string, dollarQuotes bool) error {
+	stmt := skipSQLSpace(query)
+	end := statementEnd(stmt, dollarQuotes)
+	// 语句之后只允许分号、空白和注释
+	rest := stmt[end:]
+	for strings.HasPrefix(rest, ";") {
+		rest = skipSQLSpace(rest[1:])
+	}
+	if rest != "" {
+		return i18n.Errorf("只读查询一次只能执行一条语句")
+	}
+
+	n := strings.IndexFunc(stmt, func(r rune) bool { return !unicode.IsLetter(r) })
+	if n < 0 {
+		n = len(stmt)
+	}
+	if !slices.Contains(readOnlyKeywords, strings.ToUpper(stmt[:n])) {
+		return i18n.Errorf("只读查询只能执行 %s 语句，修改数据库请使用 --write", strings.Join(readOnlyKeywords, " / "))
+	}
+	return nil
+}
+
+// skipSQLSpace 跳过开头的空白、-- 行注释和 /* */ 块注释
+func skipSQLSpace(s string) string {
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		switch {
+		case strings.HasPrefix(s, "--"):
+			i := strings.IndexByte(s, '\n')
+			if i < 0 {
+				return ""
+			}
+			s = s[i+1:]
+		case strings.HasPrefix(s, "/*"):
+			i := strings.Index(s[2:], "*/")
+			if i < 0 {
+				return ""
+			}
+			s = s[i+4:]
+		default:
+			return s
+		}
+	}
+}
+
+// statementEnd 返回第一条语句结束的位置（语句外的第一个分号，没有时为字符串长度）
+// 跳过字符串、带引号的标识符和注释中的分号
+func statementEnd(s string, dollarQuotes bool) int {
+	for i := 0; i < len(s); i++ {
+		var closing string
+		switch c := s[i]; {
+		case c == ';':
+			return i
+		case c == '\'' || c == '"' || c == '`':
+			closing = string(c)
+		case c == '[':
+			closing = "]"
+		case c == '-' && strings.HasPrefix(s[i:], "--"):
+			closing = "\n"
+		case c == '/' && strings.HasPrefix(s[i:], "/*"):
+			closing = "*/"
+			i++
+		case c == '$' && dollarQuotes:
+			if tag := dollarQuoteTag(s[i:]); tag != "" {
+				closing = tag
+				i += len(tag) - 1
+			}
+		}
+		if closing == "" {
+			continue
+		}
+		// 转义的引号（''）视为先结束再开始的两个字符串，不影响结果
+		j := strings.Index(s[i+1:], closing)
+		if j < 0 {
+			return len(s)
+		}
+		i += j + len(closing)
+	}
+	return len(s)
+}
+
+// dollarQuoteTag 返回 s 开头的 PostgreSQL 美元引号标记（如 $$、$body$），不是标记时返回空
+func dollarQuoteTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '$':
+			return s[:i+1]
+		case c == '_' || unicode.IsLetter(rune(c)) || (i > 1 && c >= '0' && c <= '9'):
+		default:
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// ScanRepository sa scan 运行记录仓库
+type ScanRepository struct {
+	db *DB
+}
+
+// NewScanRepository 创建扫描记录仓库
+func NewScanRepository(db *DB) *ScanRepository {
+	return &ScanRepository{db: db}
+}
+
+// Create 记录一次扫描及其 SA 结果快照，返回扫描 ID
+// 快照不保存 Token，只保留 diff 需要的风险、权限和安全标识
+func (r *ScanRepository) Create(scan *types.ScanRecord, records []*types.ServiceAccountRecord) (int64, error) {
+	tx, err := r.db.begin()
+	if err != nil {
+		return 0, i18n.Errorf("开始事务失败: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var id int64
+	err = tx.QueryRow(`
+		INSERT INTO scans (started_at, duration_ms, target, mode, partial, pods, sas, risky, admins, sampled)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`,
+		scan.StartedAt, scan.Duration.Milliseconds(), scan.Target, scan.Mode, scan.Partial,
+		scan.Pods, scan.SAs, scan.Risky, scan.Admins, scan.Sampled,
+	).Scan(&id)
+	if err != nil {
+		return 0, i18n.Errorf("保存扫描记录失败: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO scan_results (
+			scan_id, name, namespace, risk_level, permissions,
+			is_cluster_admin, security_flags, pods
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(scan_id, namespace, name) DO UPDATE SET
+			risk_level = excluded.risk_level,
+			permissions = excluded.permissions,
+			is_cluster_admin = excluded.is_cluster_admin,
+			security_flags = excluded.security_flags,
+			pods = excluded.pods
+	`)
+	if err != nil {
+		return 0, i18n.Errorf("准备语句失败: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, record := range records {
+		_, err := stmt.Exec(
+			id, record.Name, record.Namespace, record.RiskLevel, record.Permissions,
+			record.IsClusterAdmin, record.SecurityFlags, record.Pods,
+		)
+		if err != nil {
+			return 0, i18n.Errorf("保存 SA %s/%s 快照失败: %w", record.Namespace, record.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, i18n.Errorf("提交事务失败: %w", err)
+	}
+
+	scan.ID = id
+	return id, nil
+}
+
+// GetAll 获取所有扫描记录（按时间排序）
+func (r *ScanRepository) GetAll() ([]*types.ScanRecord, error) {
+	rows, err := r.db.query(`
+		SELECT id, started_at, COALESCE(duration_ms, 0), COALESCE(target, ''), COALESCE(mode, ''),
+			   partial, COALESCE(pods, 0), COALESCE(sas, 0), COALESCE(risky, 0), COALESCE(admins, 0),
+			   COALESCE(sampled, 0)
+		FROM scans ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var scans []*types.ScanRecord
+	for rows.Next() {
+		scan, err := scanScanRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		scans = append(scans, scan)
+	}
+	return scans, rows.Err()
+}
+
+// Get 按 ID 获取扫描记录，不存在时返回 nil
+func (r *ScanRepository) Get(id int64) (*types.ScanRecord, error) {
+	row := r.db.queryRow(`
+		SELECT id, started_at, COALESCE(duration_ms, 0), COALESCE(target, ''), COALESCE(mode, ''),
+			   partial, COALESCE(pods, 0), COALESCE(sas, 0), COALESCE(risky, 0), COALESCE(admins, 0),
+			   COALESCE(sampled, 0)
+		FROM scans WHERE id = ?
+	`, id)
+	scan, err := scanScanRow(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return scan, err
+}
+
+// GetResults 获取某次扫描的 SA 结果快照
+func (r *ScanRepository) GetResults(scanID int64) ([]*types.ServiceAccountRecord, error) {
+	rows, err := r.db.query(`
+		SELECT name, namespace, COALESCE(risk_level, ''), COALESCE(permissions, ''),
+			   is_cluster_admin, COALESCE(security_flags, ''), COALESCE(pods, '')
+		FROM scan_results WHERE scan_id = ? ORDER BY namespace, name
+	`, scanID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records []*types.ServiceAccountRecord
+	for rows.Next() {
+		sa := types.ServiceAccountRecord{ScanID: scanID}
+		err := rows.Scan(
+			&sa.Name, &sa.Namespace, &sa.RiskLevel, &sa.Permissions,
+			&sa.IsClusterAdmin, &sa.SecurityFlags, &sa.Pods,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &sa)
+	}
+	return records, rows.Err()
+}
+
+// Count 获取总数
+func (r *ScanRepository) Count() (int, error) {
+	var count int
+	err := r.db.queryRow("SELECT COUNT(*) FROM scans").Scan(&count)
+	return count, err
+}
+
+// scanScanRow 扫描一行扫描记录
+func scanScanRow(row interface{ Scan(...any) error }) (*types.ScanRecord, error) {
+	var scan types.ScanRecord
+	var durationMS int64
+	err := row.Scan(
+		&scan.ID, &scan.StartedAt, &durationMS, &scan.Target, &scan.Mode,
+		&scan.Partial, &scan.Pods, &scan.SAs, &scan.Risky, &scan.Admins, &scan.Sampled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	scan.Duration = time.Duration(durationMS) * time.Millisecond
+	return &scan, nil
+}
+
+// nullableID 未关联记录（ID 为 0）时保存为 NULL
+func nullableID(id int64) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
@@ -2,8 +2,9 @@ package db
 
 import (
 	"database/sql"
-	"fmt"
+	"strings"
 
+	"kctl/internal/i18n"
 	"kctl/pkg/types"
 )
 
@@ -17,22 +18,37 @@ func NewServiceAccountRepository(db *DB) *ServiceAccountRepository {
 	return &ServiceAccountRepository{db: db}
 }
 
-// Save 保存单个 ServiceAccount
-func (r *ServiceAccountRepository) Save(record *types.ServiceAccountRecord) error {
-	query := `
-	INSERT OR REPLACE INTO service_accounts (
+// upsertSA 插入或更新 ServiceAccount，保留已有的备注和标签（重新扫描不丢失）
+const upsertSA = `
+	INSERT INTO service_accounts (
 		name, namespace, token, token_expiration, is_expired,
 		risk_level, permissions, is_cluster_admin, security_flags,
-		pods, collected_at, kubelet_ip
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
+		pods, collected_at, kubelet_ip, scan_id
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(name, namespace) DO UPDATE SET
+		token = excluded.token,
+		token_expiration = excluded.token_expiration,
+		is_expired = excluded.is_expired,
+		risk_level = excluded.risk_level,
+		permissions = excluded.permissions,
+		is_cluster_admin = excluded.is_cluster_admin,
+		security_flags = excluded.security_flags,
+		pods = excluded.pods,
+		collected_at = excluded.collected_at,
+		kubelet_ip = excluded.kubelet_ip,
+		scan_id = excluded.scan_id
+`
 
-	_, err := r.db.conn.Exec(query,
+// Save 保存单个 ServiceAccount
+func (r *ServiceAccountRepository) Save(record *types.ServiceAccountRecord) error {
+	query := upsertSA
+
+	_, err := r.db.exec(query,
 		record.Name, record.Namespace, record.Token,
 		record.TokenExpiration, record.IsExpired,
 		record.RiskLevel, record.Permissions, record.IsClusterAdmin,
 		record.SecurityFlags, record.Pods,
-		record.CollectedAt, record.KubeletIP,
+		record.CollectedAt, record.KubeletIP, nullableID(record.ScanID),
 	)
 
 	return err
@@ -40,21 +56,15 @@ func (r *ServiceAccountRepository) Save(record *types.ServiceAccountRecord) erro
 
 // SaveBatch 批量保存 ServiceAccount
 func (r *ServiceAccountRepository) SaveBatch(records []*types.ServiceAccountRecord) (int, error) {
-	tx, err := r.db.conn.Begin()
+	tx, err := r.db.begin()
 	if err != nil {
-		return 0, fmt.Errorf("开始事务失败: %w", err)
+		return 0, i18n.Errorf("开始事务失败: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	stmt, err := tx.Prepare(`
-		INSERT OR REPLACE INTO service_accounts (
-			name, namespace, token, token_expiration, is_expired,
-			risk_level, permissions, is_cluster_admin, security_flags,
-			pods, collected_at, kubelet_ip
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`)
+	stmt, err := tx.Prepare(upsertSA)
 	if err != nil {
-		return 0, fmt.Errorf("准备语句失败: %w", err)
+		return 0, i18n.Errorf("准备语句失败: %w", err)
 	}
 	defer func() { _ = stmt.Close() }()
 
@@ -65,37 +75,61 @@ func (r *ServiceAccountRepository) SaveBatch(records []*types.ServiceAccountReco
 			record.TokenExpiration, record.IsExpired,
 			record.RiskLevel, record.Permissions, record.IsClusterAdmin,
 			record.SecurityFlags, record.Pods,
-			record.CollectedAt, record.KubeletIP,
+			record.CollectedAt, record.KubeletIP, nullableID(record.ScanID),
 		)
 		if err != nil {
-			return saved, fmt.Errorf("保存 SA %s/%s 失败: %w", record.Namespace, record.Name, err)
+			return saved, i18n.Errorf("保存 SA %s/%s 失败: %w", record.Namespace, record.Name, err)
 		}
 		saved++
 	}
 
 	if err := tx.Commit(); err != nil {
-		return saved, fmt.Errorf("提交事务失败: %w", err)
+		return saved, i18n.Errorf("提交事务失败: %w", err)
 	}
 
 	return saved, nil
 }
 
+// selectAllSAs 按风险等级排序查询所有 ServiceAccount
+const selectAllSAs = `
+	SELECT id, name, namespace, token, token_expiration, is_expired,
+		   risk_level, permissions, is_cluster_admin, security_flags,
+		   pods, collected_at, kubelet_ip, COALESCE(note, ''), COALESCE(tags, ''), COALESCE(scan_id, 0)
+	FROM service_accounts ORDER BY
+		CASE risk_level
+			WHEN 'ADMIN' THEN 0
+			WHEN 'CRITICAL' THEN 1
+			WHEN 'HIGH' THEN 2
+			WHEN 'MEDIUM' THEN 3
+			WHEN 'LOW' THEN 4
+			ELSE 5
+		END, namespace, name
+`
+
 // GetAll 获取所有 ServiceAccount
 func (r *ServiceAccountRepository) GetAll() ([]*types.ServiceAccountRecord, error) {
-	return r.query(`
-		SELECT id, name, namespace, token, token_expiration, is_expired,
-			   risk_level, permissions, is_cluster_admin, security_flags,
-			   pods, collected_at, kubelet_ip
-		FROM service_accounts ORDER BY 
-			CASE risk_level 
-				WHEN 'ADMIN' THEN 0
-				WHEN 'CRITICAL' THEN 1 
-				WHEN 'HIGH' THEN 2 
-				WHEN 'MEDIUM' THEN 3 
-				WHEN 'LOW' THEN 4 
-				ELSE 5 
-			END, namespace, name
-	`)
+	return r.query(selectAllSAs)
+}
+
+// Each 按 GetAll 的顺序逐行读取 ServiceAccount，不在内存中保留整个结果集
+// fn 返回错误时停止遍历并返回该错误；fn 中不能再查询数据库（内存数据库只有一个连接）
+func (r *ServiceAccountRepository) Each(fn func(*types.ServiceAccountRecord) error) error {
+	rows, err := r.db.query(selectAllSAs)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		sa, err := scanSA(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(sa); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
 }
 
 // GetByRiskLevel 按风险等级获取
@@ -103,7 +137,7 @@ func (r *ServiceAccountRepository) GetByRiskLevel(riskLevel string) ([]*types.Se
 	return r.query(`
 		SELECT id, name, namespace, token, token_expiration, is_expired,
 			   risk_level, permissions, is_cluster_admin, security_flags,
-			   pods, collected_at, kubelet_ip
+			   pods, collected_at, kubelet_ip, COALESCE(note, ''), COALESCE(tags, ''), COALESCE(scan_id, 0)
 		FROM service_accounts WHERE risk_level = ? ORDER BY namespace, name
 	`, riskLevel)
 }
@@ -113,7 +147,7 @@ func (r *ServiceAccountRepository) GetClusterAdmins() ([]*types.ServiceAccountRe
 	return r.query(`
 		SELECT id, name, namespace, token, token_expiration, is_expired,
 			   risk_level, permissions, is_cluster_admin, security_flags,
-			   pods, collected_at, kubelet_ip
+			   pods, collected_at, kubelet_ip, COALESCE(note, ''), COALESCE(tags, ''), COALESCE(scan_id, 0)
 		FROM service_accounts WHERE is_cluster_admin = TRUE ORDER BY namespace, name
 	`)
 }
@@ -123,7 +157,7 @@ func (r *ServiceAccountRepository) GetRisky() ([]*types.ServiceAccountRecord, er
 	return r.query(`
 		SELECT id, name, namespace, token, token_expiration, is_expired,
 			   risk_level, permissions, is_cluster_admin, security_flags,
-			   pods, collected_at, kubelet_ip
+			   pods, collected_at, kubelet_ip, COALESCE(note, ''), COALESCE(tags, ''), COALESCE(scan_id, 0)
 		FROM service_accounts 
 		WHERE risk_level IN ('ADMIN', 'CRITICAL', 'HIGH', 'MEDIUM')
 		ORDER BY 
@@ -139,10 +173,10 @@ func (r *ServiceAccountRepository) GetRisky() ([]*types.ServiceAccountRecord, er
 
 // GetByName 按名称和命名空间获取
 func (r *ServiceAccountRepository) GetByName(namespace, name string) (*types.ServiceAccountRecord, error) {
-	row := r.db.conn.QueryRow(`
+	row := r.db.queryRow(`
 		SELECT id, name, namespace, token, token_expiration, is_expired,
 			   risk_level, permissions, is_cluster_admin, security_flags,
-			   pods, collected_at, kubelet_ip
+			   pods, collected_at, kubelet_ip, COALESCE(note, ''), COALESCE(tags, ''), COALESCE(scan_id, 0)
 		FROM service_accounts WHERE namespace = ? AND name = ?
 	`, namespace, name)
 
@@ -152,7 +186,7 @@ func (r *ServiceAccountRepository) GetByName(namespace, name string) (*types.Ser
 		&sa.TokenExpiration, &sa.IsExpired,
 		&sa.RiskLevel, &sa.Permissions, &sa.IsClusterAdmin,
 		&sa.SecurityFlags, &sa.Pods,
-		&sa.CollectedAt, &sa.KubeletIP,
+		&sa.CollectedAt, &sa.KubeletIP, &sa.Note, &sa.Tags, &sa.ScanID,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -169,7 +203,7 @@ func (r *ServiceAccountRepository) GetByNamespace(namespace string) ([]*types.Se
 	return r.query(`
 		SELECT id, name, namespace, token, token_expiration, is_expired,
 			   risk_level, permissions, is_cluster_admin, security_flags,
-			   pods, collected_at, kubelet_ip
+			   pods, collected_at, kubelet_ip, COALESCE(note, ''), COALESCE(tags, ''), COALESCE(scan_id, 0)
 		FROM service_accounts WHERE namespace = ? ORDER BY name
 	`, namespace)
 }
@@ -177,7 +211,7 @@ func (r *ServiceAccountRepository) GetByNamespace(namespace string) ([]*types.Se
 // Count 获取总数
 func (r *ServiceAccountRepository) Count() (int, error) {
 	var count int
-	err := r.db.conn.QueryRow("SELECT COUNT(*) FROM service_accounts").Scan(&count)
+	err := r.db.queryRow("SELECT COUNT(*) FROM service_accounts").Scan(&count)
 	return count, err
 }
 
@@ -185,7 +219,7 @@ func (r *ServiceAccountRepository) Count() (int, error) {
 func (r *ServiceAccountRepository) GetStats() (map[string]int, error) {
 	stats := make(map[string]int)
 
-	rows, err := r.db.conn.Query(`
+	rows, err := r.db.query(`
 		SELECT risk_level, COUNT(*) as count 
 		FROM service_accounts 
 		GROUP BY risk_level
@@ -206,7 +240,7 @@ func (r *ServiceAccountRepository) GetStats() (map[string]int, error) {
 
 	// 获取集群管理员数量
 	var adminCount int
-	err = r.db.conn.QueryRow("SELECT COUNT(*) FROM service_accounts WHERE is_cluster_admin = TRUE").Scan(&adminCount)
+	err = r.db.queryRow("SELECT COUNT(*) FROM service_accounts WHERE is_cluster_admin = TRUE").Scan(&adminCount)
 	if err != nil {
 		return nil, err
 	}
@@ -215,15 +249,37 @@ func (r *ServiceAccountRepository) GetStats() (map[string]int, error) {
 	return stats, nil
 }
 
+// SetNote 设置 SA 的备注，SA 不存在时返回错误
+func (r *ServiceAccountRepository) SetNote(namespace, name, note string) error {
+	return r.update("UPDATE service_accounts SET note = ? WHERE namespace = ? AND name = ?", note, namespace, name)
+}
+
+// SetTags 设置 SA 的标签（逗号分隔保存），SA 不存在时返回错误
+func (r *ServiceAccountRepository) SetTags(namespace, name string, tags []string) error {
+	return r.update("UPDATE service_accounts SET tags = ? WHERE namespace = ? AND name = ?", strings.Join(tags, ","), namespace, name)
+}
+
+// update 更新单个 SA 的列，未匹配任何记录时返回错误
+func (r *ServiceAccountRepository) update(query, value, namespace, name string) error {
+	result, err := r.db.exec(query, value, namespace, name)
+	if err != nil {
+		return i18n.Errorf("更新 SA %s/%s 失败: %w", namespace, name, err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return i18n.Errorf("SA 不存在: %s/%s，请先执行 'sa scan'", namespace, name)
+	}
+	return nil
+}
+
 // Clear 清空所有记录
 func (r *ServiceAccountRepository) Clear() error {
-	_, err := r.db.conn.Exec("DELETE FROM service_accounts")
+	_, err := r.db.exec("DELETE FROM service_accounts")
 	return err
 }
 
 // query 通用查询方法
 func (r *ServiceAccountRepository) query(sql string, args ...interface{}) ([]*types.ServiceAccountRecord, error) {
-	rows, err := r.db.conn.Query(sql, args...)
+	rows, err := r.db.query(sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -236,18 +292,27 @@ func (r *ServiceAccountRepository) query(sql string, args ...interface{}) ([]*ty
 func scanSARows(rows *sql.Rows) ([]*types.ServiceAccountRecord, error) {
 	var sas []*types.ServiceAccountRecord
 	for rows.Next() {
-		var sa types.ServiceAccountRecord
-		err := rows.Scan(
-			&sa.ID, &sa.Name, &sa.Namespace, &sa.Token,
-			&sa.TokenExpiration, &sa.IsExpired,
-			&sa.RiskLevel, &sa.Permissions, &sa.IsClusterAdmin,
-			&sa.SecurityFlags, &sa.Pods,
-			&sa.CollectedAt, &sa.KubeletIP,
-		)
+		sa, err := scanSA(rows)
 		if err != nil {
 			return nil, err
 		}
-		sas = append(sas, &sa)
+		sas = append(sas, sa)
 	}
 	return sas, nil
 }
+
+// scanSA 扫描单行
+func scanSA(rows *sql.Rows) (*types.ServiceAccountRecord, error) {
+	var sa types.ServiceAccountRecord
+	err := rows.Scan(
+		&sa.ID, &sa.Name, &sa.Namespace, &sa.Token,
+		&sa.TokenExpiration, &sa.IsExpired,
+		&sa.RiskLevel, &sa.Permissions, &sa.IsClusterAdmin,
+		&sa.SecurityFlags, &sa.Pods,
+		&sa.CollectedAt, &sa.KubeletIP, &sa.Note, &sa.Tags, &sa.ScanID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &sa, nil
+}
@@ -0,0 +1,78 @@
+package filter
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"kctl/config"
+	"kctl/pkg/types"
+)
+
+// PodRecord 构造 Pod 的过滤记录，risk 为 Pod 所用 SA 的风险等级（未扫描时为空）
+func PodRecord(pod types.PodContainerInfo, risk string) Record {
+	var images, names []string
+	for _, c := range pod.Containers {
+		images = append(images, c.Image)
+		names = append(names, c.Name)
+	}
+	if risk == "" {
+		risk = string(config.RiskNone)
+	}
+
+	return Record{
+		"namespace":  pod.Namespace,
+		"name":       pod.PodName,
+		"risk":       risk,
+		"sa":         pod.ServiceAccount,
+		"status":     pod.Status,
+		"node":       pod.NodeName,
+		"ip":         pod.PodIP,
+		"image":      strings.Join(images, ","),
+		"container":  strings.Join(names, ","),
+		"privileged": strconv.FormatBool(pod.SecurityFlags.Privileged),
+		"hostpath":   strconv.FormatBool(pod.SecurityFlags.HasHostPath),
+		"secret":     strconv.FormatBool(pod.SecurityFlags.HasSecretMount),
+	}.withFlags(pod.SecurityFlags)
+}
+
+// SARecord 构造 ServiceAccount 的过滤记录
+func SARecord(sa *types.ServiceAccountRecord) Record {
+	var perms []types.SAPermission
+	_ = json.Unmarshal([]byte(sa.Permissions), &perms)
+	var pods []types.SAPodInfo
+	_ = json.Unmarshal([]byte(sa.Pods), &pods)
+	var flags types.SASecurityFlags
+	_ = json.Unmarshal([]byte(sa.SecurityFlags), &flags)
+
+	risk := sa.RiskLevel
+	if sa.IsClusterAdmin {
+		risk = string(config.RiskAdmin)
+	}
+
+	return Record{
+		"namespace":  sa.Namespace,
+		"name":       sa.Name,
+		"risk":       risk,
+		"admin":      strconv.FormatBool(sa.IsClusterAdmin),
+		"expired":    strconv.FormatBool(sa.IsExpired),
+		"perms":      strconv.Itoa(len(perms)),
+		"pods":       strconv.Itoa(len(pods)),
+		"privileged": strconv.FormatBool(flags.Privileged),
+		"hostpath":   strconv.FormatBool(flags.HasHostPath),
+		"secret":     strconv.FormatBool(flags.HasSecretMount),
+		"tags":       sa.Tags,
+		"note":       sa.Note,
+	}.withFlags(flags.PodFlags())
+}
+
+// withFlags 添加主机命名空间、capabilities、root 和 seccomp 字段
+func (r Record) withFlags(flags types.SecurityFlags) Record {
+	r["hostnetwork"] = strconv.FormatBool(flags.HostNetwork)
+	r["hostpid"] = strconv.FormatBool(flags.HostPID)
+	r["hostipc"] = strconv.FormatBool(flags.HostIPC)
+	r["caps"] = strings.Join(flags.Capabilities, ",")
+	r["root"] = strconv.FormatBool(flags.RunAsRoot)
+	r["noseccomp"] = strconv.FormatBool(flags.NoSeccomp)
+	return r
+}
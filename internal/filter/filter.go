@@ -0,0 +1,380 @@
+// Package filter 实现 pods / sa / export 共用的过滤表达式
+//
+// 语法示例:
+//
+//	namespace~"^prod" && risk>=HIGH
+//	!(status==Running) || privileged
+//	sa!=default && (hostpath || secret)
+//
+// 比较运算符: == != ~ (正则) !~ >= <= > <
+// 逻辑运算符: && || ! 以及括号；单独的字段名表示布尔为真
+package filter
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+)
+
+// Record 待匹配的记录（字段名 -> 值）
+type Record map[string]string
+
+// Expr 已解析的过滤表达式
+type Expr struct {
+	source string
+	root   node
+}
+
+// String 返回原始表达式
+func (e *Expr) String() string {
+	return e.source
+}
+
+// Match 判断记录是否满足表达式，记录中缺失的字段视为空值
+func (e *Expr) Match(rec Record) bool {
+	return e.root.eval(rec)
+}
+
+// KnownFields 可用于表达式的字段及说明
+var KnownFields = map[string]string{
+	"namespace":   "命名空间",
+	"name":        "Pod / SA 名称",
+	"risk":        "风险等级 (ADMIN > CRITICAL > HIGH > MEDIUM > LOW > NONE)",
+	"sa":          "Pod 使用的 ServiceAccount",
+	"status":      "Pod 状态",
+	"node":        "Pod 所在节点",
+	"ip":          "Pod IP",
+	"image":       "容器镜像（多个以逗号分隔）",
+	"container":   "容器名（多个以逗号分隔）",
+	"privileged":  "特权容器",
+	"hostpath":    "挂载 HostPath",
+	"secret":      "挂载 Secret",
+	"hostnetwork": "使用主机网络",
+	"hostpid":     "使用主机 PID 命名空间",
+	"hostipc":     "使用主机 IPC 命名空间",
+	"caps":        "添加的高危 capabilities（多个以逗号分隔）",
+	"root":        "显式以 root (UID 0) 运行",
+	"noseccomp":   "存在未配置 seccomp 的容器",
+	"admin":       "cluster-admin",
+	"expired":     "Token 已过期",
+	"perms":       "权限数量",
+	"pods":        "关联 Pod 数量",
+	"tags":        "SA 标签（多个以逗号分隔，见 sa tag）",
+	"note":        "SA 备注",
+}
+
+// FieldNames 返回排序后的字段名
+func FieldNames() []string {
+	names := make([]string, 0, len(KnownFields))
+	for name := range KnownFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse 解析过滤表达式
+func Parse(expr string) (*Expr, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, i18n.Errorf("过滤表达式为空")
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, i18n.Errorf("过滤表达式多余内容: %s", p.peek().text)
+	}
+	return &Expr{source: strings.TrimSpace(expr), root: root}, nil
+}
+
+// ==================== 词法分析 ====================
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokString
+	tokOp
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// comparisonOps 比较运算符，按长度从长到短匹配
+var comparisonOps = []string{"==", "!=", "!~", ">=", "<=", "=", "~", ">", "<"}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")"})
+			i++
+		case strings.HasPrefix(s[i:], "&&"):
+			tokens = append(tokens, token{tokAnd, "&&"})
+			i += 2
+		case strings.HasPrefix(s[i:], "||"):
+			tokens = append(tokens, token{tokOr, "||"})
+			i += 2
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, i18n.Errorf("过滤表达式引号未闭合")
+			}
+			tokens = append(tokens, token{tokString, s[i+1 : i+1+end]})
+			i += end + 2
+		default:
+			if op := matchOp(s[i:]); op != "" {
+				tokens = append(tokens, token{tokOp, op})
+				i += len(op)
+				continue
+			}
+			if c == '!' {
+				tokens = append(tokens, token{tokNot, "!"})
+				i++
+				continue
+			}
+			if c == '&' || c == '|' {
+				return nil, i18n.Errorf("无效的运算符 %q，请使用 && 或 ||", string(c))
+			}
+			start := i
+			for i < len(s) && !strings.ContainsRune(" \t()\"'=!~<>&|", rune(s[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokWord, s[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+func matchOp(s string) string {
+	for _, op := range comparisonOps {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// ==================== 语法分析 ====================
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: -1}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch p.peek().kind {
+	case tokNot:
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	case tokLParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokRParen {
+			return nil, i18n.Errorf("过滤表达式缺少 )")
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	t := p.next()
+	if t.kind != tokWord {
+		if t.kind == -1 {
+			return nil, i18n.Errorf("过滤表达式不完整")
+		}
+		return nil, i18n.Errorf("过滤表达式在 %q 处需要字段名", t.text)
+	}
+
+	field := strings.ToLower(t.text)
+	if _, ok := KnownFields[field]; !ok {
+		return nil, i18n.Errorf("未知字段: %s (可用: %s)", t.text, strings.Join(FieldNames(), ", "))
+	}
+
+	if p.peek().kind != tokOp {
+		return truthyNode{field}, nil
+	}
+	op := p.next().text
+
+	v := p.next()
+	if v.kind != tokWord && v.kind != tokString {
+		return nil, i18n.Errorf("过滤表达式 %s%s 缺少比较值", field, op)
+	}
+
+	cmp := compareNode{field: field, op: op, value: v.text}
+	if op == "~" || op == "!~" {
+		re, err := regexp.Compile(v.text)
+		if err != nil {
+			return nil, i18n.Errorf("无效的正则表达式 %q: %w", v.text, err)
+		}
+		cmp.re = re
+	}
+	return cmp, nil
+}
+
+// ==================== 求值 ====================
+
+type node interface {
+	eval(rec Record) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(rec Record) bool { return n.left.eval(rec) && n.right.eval(rec) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(rec Record) bool { return n.left.eval(rec) || n.right.eval(rec) }
+
+type notNode struct{ inner node }
+
+func (n notNode) eval(rec Record) bool { return !n.inner.eval(rec) }
+
+type truthyNode struct{ field string }
+
+func (n truthyNode) eval(rec Record) bool {
+	switch strings.ToLower(rec[n.field]) {
+	case "", "false", "0", "none":
+		return false
+	}
+	return true
+}
+
+type compareNode struct {
+	field string
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+func (n compareNode) eval(rec Record) bool {
+	actual := rec[n.field]
+
+	switch n.op {
+	case "~":
+		return n.re.MatchString(actual)
+	case "!~":
+		return !n.re.MatchString(actual)
+	}
+
+	c := compareValues(actual, n.value)
+	switch n.op {
+	case "==", "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">=":
+		return c >= 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case "<":
+		return c < 0
+	}
+	return false
+}
+
+// compareValues 比较两个值：风险等级按严重程度，数字按数值，其余按字符串（忽略大小写）
+func compareValues(a, b string) int {
+	ra, aok := config.RiskLevelOrder[config.RiskLevel(strings.ToUpper(a))]
+	rb, bok := config.RiskLevelOrder[config.RiskLevel(strings.ToUpper(b))]
+	if aok && bok {
+		// 数字越小越严重
+		return rb - ra
+	}
+
+	fa, aerr := strconv.ParseFloat(a, 64)
+	fb, berr := strconv.ParseFloat(b, 64)
+	if aerr == nil && berr == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -0,0 +1,80 @@
+package filter
+
+import (
+	"regexp"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+)
+
+// filterNameRe 命名过滤器名称格式
+var filterNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Saved 返回配置文件中保存的命名过滤器
+func Saved() (map[string]string, error) {
+	cfg, err := config.LoadUserConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Filters == nil {
+		return map[string]string{}, nil
+	}
+	return cfg.Filters, nil
+}
+
+// Save 校验表达式并保存为命名过滤器（同名覆盖）
+func Save(name, expr string) error {
+	if !filterNameRe.MatchString(name) {
+		return i18n.Errorf("无效的过滤器名称: %s", name)
+	}
+	parsed, err := Parse(expr)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadUserConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Filters == nil {
+		cfg.Filters = make(map[string]string)
+	}
+	cfg.Filters[name] = parsed.String()
+	return config.SaveUserConfig(cfg)
+}
+
+// Delete 删除命名过滤器
+func Delete(name string) error {
+	cfg, err := config.LoadUserConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Filters[name]; !ok {
+		return i18n.Errorf("过滤器不存在: %s", name)
+	}
+	delete(cfg.Filters, name)
+	return config.SaveUserConfig(cfg)
+}
+
+// Resolve 解析 --where 参数：@name 引用命名过滤器，其余按表达式解析
+func Resolve(arg string) (*Expr, error) {
+	name, ok := strings.CutPrefix(arg, "@")
+	if !ok {
+		return Parse(arg)
+	}
+
+	saved, err := Saved()
+	if err != nil {
+		return nil, err
+	}
+	expr, ok := saved[name]
+	if !ok {
+		return nil, i18n.Errorf("过滤器不存在: %s，使用 'filter list' 查看", name)
+	}
+	parsed, err := Parse(expr)
+	if err != nil {
+		return nil, i18n.Errorf("过滤器 %s 无效: %w", name, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,1287 @@
+package i18n
+
+// en 英文消息目录
+var en = map[string]string{
+	"不支持的语言: %s (可用: %s)": "unsupported language: %s (available: %s)",
+
+	// help
+	"未知命令: %s":         "unknown command: %s",
+	"别名":               "Aliases",
+	"用法":               "Usage",
+	"可用命令":             "Available Commands",
+	"输入 '%s' 查看命令详细帮助": "Type '%s' for details on a command",
+	"命令输出可通过管道过滤: %s":  "Filter any command's output with a pipe: %s",
+	"命令输出可写入文件（去除颜色）: %s": "Write any command's output to a file (colors stripped): %s",
+	"连接": "Connection",
+	"扫描": "Scanning",
+	"查询": "Query",
+	"操作": "Operations",
+	"配置": "Configuration",
+	"插件": "Plugins",
+	"其他": "Other",
+	"未知命令: %s，输入 'help' 查看可用命令": "unknown command: %s, type 'help' for available commands",
+
+	// 命令说明
+	"ServiceAccount 相关操作":   "ServiceAccount operations",
+	"为 SA 添加或移除标签":          "Add or remove tags on an SA",
+	"列出 ConfigMap 并搜索其中的凭据": "List ConfigMaps and search them for credentials",
+	"列出 Pod": "List pods",
+	"列出 Service / Endpoints，标出值得关注的目标":            "List Services / Endpoints and flag interesting targets",
+	"列出历次 sa scan 记录":                             "List previous sa scan runs",
+	"列出命名空间及风险汇总":                                 "List namespaces with a risk summary",
+	"列出已加载的插件":                                    "List loaded plugins",
+	"列出已扫描的 ServiceAccount":                       "List scanned ServiceAccounts",
+	"列出集群节点及 Kubelet 版本":                          "List cluster nodes and kubelet versions",
+	"反查 RBAC 绑定、审计 SA 的最小权限":                      "Reverse-lookup RBAC bindings and audit SA least privilege",
+	"发现集群内管理界面并测试匿名访问和默认口令":                       "Find in-cluster admin UIs and test anonymous access and default credentials",
+	"在 Pod 中搜寻凭据":                                 "Hunt for credentials inside pods",
+	"在 Pod 内进行 TCP 端口扫描":                          "TCP port scan from inside a pod",
+	"导入归档或离线 Pod JSON":                            "Import a bundle or offline pod JSON",
+	"导出结果":                                        "Export results",
+	"将 PID 映射到 Pod":                               "Map a PID to its pod",
+	"将后续控制台输出同时写入文件":                              "Mirror subsequent console output to a file",
+	"将结果推送到团队服务器":                                 "Push results to the team server",
+	"执行 Starlark 脚本（访问 Pod、SA、exec 和 API Server）": "Run a Starlark script (pods, SAs, exec and the API server)",
+	"执行命令":                                  "Execute a command",
+	"执行脚本文件中的命令":                            "Run commands from a script file",
+	"扫描所有 Pod 的 SA Token 权限":                "Scan SA token permissions of all pods",
+	"扫描网段发现 Kubelet 节点":                     "Scan a network range for kubelets",
+	"按 CIS Kubernetes Benchmark 检查 Kubelet": "Check the kubelet against the CIS Kubernetes Benchmark",
+	"收集节点 kubeconfig 和客户端证书":                "Harvest node kubeconfigs and client certificates",
+	"显示 Pod 的完整安全配置":                        "Show a pod's full security configuration",
+	"显示帮助信息":                                "Show help information",
+	"显示当前 SA 详情":                            "Show details of the current SA",
+	"显示节点和 Pod 的 CPU / 内存使用":                "Show node and pod CPU / memory usage",
+	"显示配置或状态信息":                             "Show configuration or status",
+	"枚举准入 Webhook 并提示绕过途径":                  "Enumerate admission webhooks and suggest bypasses",
+	"查看对集群执行的操作记录":                          "Show the actions taken against the cluster",
+	"查看 exec --all-pods 的执行结果":              "Show saved exec --all-pods results",
+	"对会话数据库执行 SQL 查询":                       "Run a SQL query against the session database",
+	"查看命令历史":                                "Show command history",
+	"查看或切换运行模式":                             "Show or switch the operating mode",
+	"查看或调整风险评分规则":                           "Show or adjust risk-scoring rules",
+	"检查 API Server 不安全端口、匿名访问和准入插件配置":       "Check the API server for the insecure port, anonymous access and admission plugins",
+	"检查 PSA / PSP，找出可部署特权 Pod 的命名空间":        "Check PSA / PSP for namespaces that admit privileged pods",
+	"检查 etcd 端口暴露和未授权访问":                    "Check etcd port exposure and unauthenticated access",
+	"比较两次 sa scan 的结果":                      "Compare two sa scan runs",
+	"注入临时调试容器":                              "Inject an ephemeral debug container",
+	"测试并采用模拟身份访问 API Server":                "Test and adopt an impersonated identity for the API server",
+	"清除缓存":                                  "Clear the cache",
+	"生成 SA 的 kubeconfig":                    "Generate a kubeconfig for an SA",
+	"端口转发":                                  "Port forwarding",
+	"经由 Pod 出口的 SOCKS5 代理":                  "SOCKS5 proxy that egresses through a pod",
+	"管理命名过滤器":                               "Manage named filters",
+	"管理收集到的节点凭据":                            "Manage harvested node credentials",
+	"管理配置文件中的 profile":                      "Manage profiles in the config file",
+	"获取并审计 Kubelet 配置":                      "Fetch and audit the kubelet configuration",
+	"设置 SA 的备注":                             "Set a note on an SA",
+	"设置配置项":                                 "Set a configuration option",
+	"连接到 Kubelet（可选，命令会自动连接）":               "Connect to the kubelet (optional, commands connect automatically)",
+	"退出控制台":                                 "Exit the console",
+	"选择 ServiceAccount 作为当前身份":              "Select a ServiceAccount as the current identity",
+	"通过 /run API 执行命令":                      "Execute a command via the /run API",
+	"通过 hostPath 挂载浏览节点文件系统":                "Browse the node filesystem through a hostPath mount",
+	"通过特权 hostPID Pod 获取节点 shell":           "Get a node shell through a privileged hostPID pod",
+	"部署提权 Pod / DaemonSet":                  "Deploy a privilege-escalation pod / DaemonSet",
+
+	// set
+	"Kubelet IP 地址":                                       "Kubelet IP address",
+	"Kubelet 端口":                                          "Kubelet port",
+	"Token 字符串":                                           "Token string",
+	"Token 文件路径":                                          "Token file path",
+	"客户端证书文件":                                             "Client certificate file",
+	"客户端私钥文件":                                             "Client private key file",
+	"从 kubeconfig 加载凭据":                                   "Load credentials from a kubeconfig",
+	"切换 kubeconfig 上下文":                                   "Switch kubeconfig context",
+	"kubeconfig 文件 (可附加上下文名)":                             "kubeconfig file (optionally followed by a context)",
+	"kubeconfig 上下文":                                      "kubeconfig context",
+	"API Server 地址":                                       "API server address",
+	"API Server 端口":                                       "API server port",
+	"代理地址 (socks5/http/https)":                            "Proxy address (socks5/http/https)",
+	"SSH 跳板机 (ssh://user@host)":                           "SSH jump host (ssh://user@host)",
+	"关闭 SSH 隧道":                                           "Close the SSH tunnel",
+	"验证服务端 TLS 证书 (on/off)":                               "Verify server TLS certificates (on/off)",
+	"CA 证书文件":                                             "CA certificate file",
+	"固定服务端证书指纹 (SHA256)":                                  "Pin server certificate fingerprints (SHA256)",
+	"按 CA 或固定的指纹验证证书":                                     "Verify against the CA or pinned fingerprints",
+	"不验证证书 (默认)":                                          "Do not verify certificates (default)",
+	"清除 CA 证书":                                            "Clear the CA certificate",
+	"固定当前 Kubelet 的证书":                                    "Pin the current kubelet certificate",
+	"清空固定的指纹":                                             "Clear pinned fingerprints",
+	"请求的 User-Agent":                                      "User-Agent for requests",
+	"附加请求头 (Name: value)":                                 "Extra request header (Name: value)",
+	"Kubelet 健康检查":                                        "Kubelet health probe",
+	"Go 默认值":                                              "Go default",
+	"清空附加请求头":                                             "Clear extra request headers",
+	"无效的 User-Agent: %s":                                  "invalid User-Agent: %s",
+	"无效的请求头: %s (格式: Name: value)":                        "invalid header: %s (format: Name: value)",
+	"请求头 %s 由 kctl 设置，不能自定义":                              "header %s is set by kctl and cannot be customized",
+	"API Server 模拟身份":                                     "API server impersonation identity",
+	"Kubelet 原始响应保存目录":                                    "Directory for raw kubelet responses",
+	"扫描并发数":                                               "Scan concurrency",
+	"共享工作池大小":                                             "Shared worker pool size",
+	"每个 Kubelet 的最大并发数":                                   "Max concurrency per kubelet",
+	"最大请求速率 (如 5/s)":                                      "Maximum request rate (e.g. 5/s)",
+	"请求随机延迟 (如 200-800ms)":                                "Random delay before requests (e.g. 200-800ms)",
+	"请求超时 (如 30s)":                                        "Request timeout (e.g. 30s)",
+	"连接超时 (如 10s)":                                        "Connect timeout (e.g. 10s)",
+	"临时错误的最大重试次数":                                         "Max retries on transient errors",
+	"首次重试间隔 (如 1s)":                                       "Initial retry interval (e.g. 1s)",
+	"交互式 shell 保活间隔 (如 30s)":                              "Interactive shell keepalive interval (e.g. 30s)",
+	"自定义规则文件":                                             "Custom rules file",
+	"高风险结果通知 Webhook":                                     "Webhook notified of high-risk results",
+	"团队服务器地址":                                             "Team server address",
+	"团队服务器 API 令牌":                                        "Team server API token",
+	"exec 默认环境变量":                                         "Default exec environment variables",
+	"exec 执行通道 (auto/websocket/spdy/api/nodes-proxy/run)": "exec transport (auto/websocket/spdy/api/nodes-proxy/run)",
+	"安全模式，禁止修改和执行操作 (on/off)":                             "Safe mode, block actions against the cluster (on/off)",
+	"时间显示方式 (relative/absolute)":                          "Time display (relative/absolute)",
+	"时区 (local/utc)":                                      "Time zone (local/utc)",
+	"长输出分页显示 (on/off)":                                    "Page long output (on/off)",
+	"彩色输出 (on/off)":                                       "Colored output (on/off)",
+	"界面语言 (zh/en)":                                        "Interface language (zh/en)",
+	"日志级别 (debug/info/warn)":                              "Log level (debug/info/warn)",
+	"日志文件":                                                "Log file",
+	"调试日志，包含每个请求":                                         "Debug log including every request",
+	"执行的命令":                                               "Executed commands",
+	"只输出警告 (默认)":                                          "Warnings only (default)",
+	"关闭日志文件":                                              "Stop writing the log file",
+	"自动协商第一个可用通道":                                         "Negotiate the first working transport",
+	"Kubelet /run (旧版，非交互)":                               "Kubelet /run (legacy, non-interactive)",
+	"禁止 exec、deploy 等修改或执行操作":                             "Block exec, deploy and other actions against the cluster",
+	"允许所有操作 (默认)":                                         "Allow all actions (default)",
+	"每秒 5 个请求":                                            "5 requests per second",
+	"每分钟 30 个请求":                                          "30 requests per minute",
+	"不限速 (默认)":                                            "No rate limit (default)",
+	"每个请求前随机等待 200-800ms":                                 "Wait 200-800ms at random before each request",
+	"每个请求前随机等待 1-3s":                                      "Wait 1-3s at random before each request",
+	"不加延迟 (默认)":                                           "No delay (default)",
+	"默认":                                                  "Default",
+	"不重试":                                                 "No retries",
+	"不发送 ping":                                            "Do not send pings",
+	"相对时间 (默认)":                                           "Relative time (default)",
+	"绝对时间":                                                "Absolute time",
+	"本地时区 (默认)":                                           "Local time zone (default)",
+	"超过终端高度时分页显示":                                         "Page output taller than the terminal",
+	"直接输出 (默认)":                                           "Print directly (default)",
+	"彩色输出和 Unicode 符号 (默认)":                               "Colors and Unicode symbols (default)",
+	"纯文本 ASCII 输出":                                        "Plain ASCII output",
+	"可用配置项:":                                              "Available options:",
+	"用法: set <key> <value>":                               "usage: set <key> <value>",
+	"无效的端口号: %s":                                          "invalid port: %s",
+	"读取 Token 文件失败: %w":                                   "failed to read token file: %w",
+	"未加载 kubeconfig，请先使用 'set kubeconfig <path>'":         "no kubeconfig loaded, use 'set kubeconfig <path>' first",
+	"无效的并发数: %s (必须 >= 1)":                                "invalid concurrency: %s (must be >= 1)",
+	"无效的重试次数: %s (必须 >= 0)":                               "invalid retry count: %s (must be >= 0)",
+	"无效的服务器地址: %s (需要 http:// 或 https://)":                "invalid server address: %s (http:// or https:// required)",
+	"无效的执行通道: %s (可用: %s)":                                "invalid exec transport: %s (available: %s)",
+	"无效的时间格式: %s (可用: relative, absolute)":                "invalid time format: %s (available: relative, absolute)",
+	"无效的时区: %s (可用: local, utc)":                          "invalid time zone: %s (available: local, utc)",
+	"无效的取值: %s (可用: on, off)":                             "invalid value: %s (available: on, off)",
+	"未知配置项: %s":                                           "unknown option: %s",
+	"更新 SA 信息失败: %v":                                      "failed to update SA information: %v",
+	"自动重连失败: %v":                                          "automatic reconnect failed: %v",
+	"证书已于 %s 过期":                                          "certificate expired on %s",
+	"请继续设置 %s":                                            "please also set %s",
+	"请设置 target 后执行 'connect'":                            "set target, then run 'connect'",
+	"请设置 token 或 client-cert 后执行 'connect'":               "set token or client-cert, then run 'connect'",
+
+	// 日志
+	"无效的日志级别: %s (可用: %s)": "invalid log level: %s (available: %s)",
+	"打开日志文件失败: %w":         "failed to open log file: %w",
+
+	// 连接
+	"连接成功，但目标可能不是 Kubelet":                                  "connected, but the target may not be a kubelet",
+	"创建会话失败: %w":                                            "failed to create session: %w",
+	"应用 profile %s 失败: %w":                                  "failed to apply profile %s: %w",
+	"--viewer 需要通过 --db 指定数据库文件":                            "--viewer requires a database file via --db",
+	"--fail-on 统计失败: %v":                                    "--fail-on evaluation failed: %v",
+	"未检测到 Kubelet IP，请使用 'set target <ip>' 设置后执行 'connect'": "no kubelet IP detected, use 'set target <ip>' and then 'connect'",
+	"未检测到 Token 或客户端证书，请使用 'set token <token>' 或 'set client-cert <path>' 设置后执行 'connect'": "no token or client certificate detected, use 'set token <token>' or 'set client-cert <path>' and then 'connect'",
+	"自动连接失败: %v":                "auto-connect failed: %v",
+	"请检查配置后手动执行 'connect'":      "check the configuration and run 'connect' manually",
+	"获取客户端失败: %v":               "failed to get client: %v",
+	"连接成功，但无法验证 Kubelet 端口: %v": "connected, but the kubelet port could not be verified: %v",
+	"设置 SA 失败: %v":              "failed to set SA: %v",
+
+	// 执行器
+	"查看模式 (--viewer) 下禁止执行 '%s'":                        "'%s' is not allowed in viewer mode (--viewer)",
+	"安全模式 (safe-mode) 下禁止执行 '%s'":                       "'%s' is not allowed in safe mode (safe-mode)",
+	"读取数据库失败: %w":                                       "failed to read database: %w",
+	"当前 SA 为 cluster-admin，'%s' 可能影响生产环境，确认后添加 %s 重新执行": "the current SA is cluster-admin and '%s' may affect production; re-run with %s to confirm",
+
+	// 管道
+	"按正则过滤行 (-i 忽略大小写, -v 反选, -c 计数, -F 固定字符串)": "Filter lines by regex (-i ignore case, -v invert, -c count, -F fixed string)",
+	"只保留前 n 行 (默认 10)":                         "Keep the first n lines (default 10)",
+	"只保留最后 n 行 (默认 10)":                        "Keep the last n lines (default 10)",
+	"管道语法错误: %s":                               "pipe syntax error: %s",
+	"不支持的管道命令: %s（可用: grep, head, tail）":       "unsupported pipe command: %s (available: grep, head, tail)",
+	"grep: 不支持的选项 -%c（可用: -i, -v, -c, -F, -E）": "grep: unsupported option -%c (available: -i, -v, -c, -F, -E)",
+	"grep: 只支持一个匹配模式，包含空格时请使用引号":               "grep: only one pattern is supported, quote patterns containing spaces",
+	"用法: grep [-i] [-v] [-c] [-F] <pattern>":   "usage: grep [-i] [-v] [-c] [-F] <pattern>",
+	"grep: 无效的正则表达式: %w":                       "grep: invalid regular expression: %w",
+	"用法: %s [-n <lines>]":                      "usage: %s [-n <lines>]",
+	"%s: 无效的行数: %s":                            "%s: invalid line count: %s",
+	"创建管道失败: %w":                               "failed to create pipe: %w",
+	"重定向语法错误: %s（用法: <command> > <file>）":      "redirect syntax error: %s (usage: <command> > <file>)",
+	"写入文件失败: %w":                               "failed to write file: %w",
+
+	// query
+	"语句已执行":    "Statement executed",
+	"查询没有返回结果": "The query returned no rows",
+	"共 %d 行":   "%d row(s)",
+	"仅显示前 %d 行，使用 --limit 显示更多":     "showing the first %d row(s), use --limit to show more",
+	"--limit 需要参数":                  "--limit requires a value",
+	"无效的行数: %s":                     "invalid row count: %s",
+	"用法: query [options] \"<sql>\"": "usage: query [options] \"<sql>\"",
+	"查询失败: %w":                      "query failed: %w",
+
+	// top
+	"--sort 需要指定 cpu 或 mem":      "--sort requires cpu or mem",
+	"无效的排序字段: %s (可用: cpu, mem)": "invalid sort field: %s (available: cpu, mem)",
+	"%s 需要指定数量":                  "%s requires a count",
+	"无效的数量: %s":                  "invalid count: %s",
+	"未知参数: %s":                   "unknown argument: %s",
+	"获取 /stats/summary 失败: %w":   "failed to get /stats/summary: %w",
+	"没有 Pod 资源使用数据":              "No pod resource usage data",
+
+	// export
+	"无法根据文件名识别导出格式: %s（支持 .json、.jsonl、.csv、.sarif、.md、.html，可加 .gz 压缩；.tar.gz 为 bundle），请指定格式": "cannot tell the export format from the file name: %s (supported: .json, .jsonl, .csv, .sarif, .md, .html, optionally .gz-compressed; .tar.gz for bundle), please give the format",
+	"用法: export <json|jsonl|csv|sarif|markdown|html|bundle> [file] 或 export --out <file>":       "usage: export <json|jsonl|csv|sarif|markdown|html|bundle> [file] or export --out <file>",
+	"%s 需要参数":           "%s requires a value",
+	"未知的表: %s (可用: %s)": "unknown table: %s (available: %s)",
+	"请指定导出格式或带扩展名的输出文件 (--out results.json)": "give an export format or an output file with an extension (--out results.json)",
+	"%s 只适用于 csv 格式":     "%s only applies to the csv format",
+	"bundle 不支持 --where": "bundle does not support --where",
+	"没有扫描数据，请先执行 'scan'": "no scan data, run 'scan' first",
+	"不支持的格式: %s (可用: json, jsonl, csv, sarif, markdown, html, bundle)": "unsupported format: %s (available: json, jsonl, csv, sarif, markdown, html, bundle)",
+	"写入 %s 记录失败: %w":             "failed to write %s record: %w",
+	"读取扫描记录失败: %w":               "failed to read scan runs: %w",
+	"导出 ServiceAccount 失败: %w":   "failed to export ServiceAccounts: %w",
+	"导出 Pod 失败: %w":              "failed to export pods: %w",
+	"导出检查发现失败: %w":               "failed to export findings: %w",
+	"导出执行结果失败: %w":               "failed to export exec results: %w",
+	"获取 ServiceAccount 失败: %w":   "failed to get ServiceAccounts: %w",
+	"获取检查发现失败: %w":               "failed to get findings: %w",
+	"序列化 JSON 失败: %w":            "failed to encode JSON: %w",
+	"无效的分隔符: %q（单个字符，制表符写作 tab）": "invalid delimiter: %q (a single character, write tab for a tab)",
+	"findings 表不支持 --where":      "the findings table does not support --where",
+	"生成 CSV 失败: %w":              "failed to generate CSV: %w",
+	"序列化 SARIF 失败: %w":           "failed to encode SARIF: %w",
+	"读取操作记录失败: %w":               "failed to read the operation log: %w",
+	"生成报告失败: %w":                 "failed to generate the report: %w",
+	"文件已存在: %s":                  "file already exists: %s",
+	"创建临时目录失败: %w":               "failed to create a temporary directory: %w",
+
+	// results
+	"无效的条数: %s":    "invalid count: %s",
+	"读取执行结果失败: %w": "failed to read exec results: %w",
+	"没有执行结果，使用 'exec --all-pods -- <command>' 执行后自动保存": "No exec results, they are saved automatically by 'exec --all-pods -- <command>'",
+	"没有匹配的执行结果":                                   "No matching exec results",
+	"用法: results show <id>":                       "usage: results show <id>",
+	"无效的结果 ID: %s":                                "invalid result ID: %s",
+	"执行结果不存在: %d":                                 "exec result not found: %d",
+	"--run 需要参数":                                  "--run requires a value",
+	"用法: results export json [file] [--run <id>]": "usage: results export json [file] [--run <id>]",
+	"不支持的格式: %s (可用: json)":                       "unsupported format: %s (available: json)",
+	"无效的执行轮次: %s":                                 "invalid run: %s",
+
+	// apiserver
+	"--insecure-port 需要指定端口":   "--insecure-port requires a port",
+	"保存 API Server 检查结果失败: %v": "failed to save API server check results: %v",
+	"无效的 API Server 地址: %s":    "invalid API server address: %s",
+	"无效的端口: %s":                "invalid port: %s",
+	"未设置 API Server，请指定 target 或使用 'set api-server <addr>' 设置": "API server is not set, specify a target or use 'set api-server <addr>' to set it",
+	"用法: apiserver audit [target] [--insecure-port <port>]":    "usage: apiserver audit [target] [--insecure-port <port>]",
+
+	// cis
+	"保存 CIS 检查结果失败: %v": "failed to save CIS check results: %v",
+	"用法: cis [kubelet]": "usage: cis [kubelet]",
+
+	// config
+	"profile 不存在: %s":                                                    "profile not found: %s",
+	"resource 不能为空":                                                      "resource must not be empty",
+	"resource/verb 不能包含空白字符":                                             "resource/verb must not contain whitespace",
+	"risk.%s[%d]: group/resource 不能包含 ':'":                               "risk.%s[%d]: group/resource must not contain ':'",
+	"risk.%s[%d]: resource 不能为空":                                         "risk.%s[%d]: resource must not be empty",
+	"risk.%s[%d]: verbs 不能为空":                                            "risk.%s[%d]: verbs must not be empty",
+	"rules[%d]: 无效的 level: %q (可用: admin, dangerous, sensitive, normal)": "rules[%d]: invalid level: %q (available: admin, dangerous, sensitive, normal)",
+	"verb 不能为空":                                                          "verb must not be empty",
+	"写入配置文件失败: %w":                                                       "failed to write config file: %w",
+	"创建配置目录失败: %w":                                                       "failed to create config directory: %w",
+	"序列化配置失败: %w":                                                        "failed to serialize config: %w",
+	"无效的通配模式 %q: %w":                                                     "invalid glob pattern %q: %w",
+	"无法确定用户目录: %w":                                                       "cannot determine the home directory: %w",
+	"解析规则文件失败: %w":                                                       "failed to parse rules file: %w",
+	"解析配置文件 %s 失败: %w":                                                   "failed to parse config file %s: %w",
+	"读取规则文件失败: %w":                                                       "failed to read rules file: %w",
+	"读取配置文件失败: %w":                                                       "failed to read config file: %w",
+	"非资源 URL %s 不能指定 group 或 subresource":                                "non-resource URL %s cannot have a group or subresource",
+
+	// configmaps
+	"保存 findings 失败: %w":                            "failed to save findings: %w",
+	"未设置 API Server，请使用 'set api-server <addr>' 设置": "API server is not set, use 'set api-server <addr>' to set it",
+	"结果已保存到 findings 表，使用 'hunt list' 查看":           "results were saved to the findings table, use 'hunt list' to view them",
+	"获取 ConfigMap 失败: %w":                           "failed to get ConfigMaps: %w",
+
+	// configz
+	"保存配置审计结果失败: %v":     "failed to save configuration audit results: %v",
+	"用法: configz":        "usage: configz",
+	"获取 /configz 失败: %w": "failed to get /configz: %w",
+
+	// connect
+	"无法连接 Kubelet: %v": "cannot connect to the kubelet: %v",
+	"未设置 Kubelet IP，请使用 'set target <ip>' 设置或 'connect <ip>'":                                        "kubelet IP is not set, use 'set target <ip>' or 'connect <ip>'",
+	"未设置 Token 或客户端证书，请使用 'set token <token>'、'set token-file <path>' 或 'set client-cert <path>' 设置": "no token or client certificate is set, use 'set token <token>', 'set token-file <path>' or 'set client-cert <path>'",
+	"连接失败: %w": "connection failed: %w",
+	"连接成功，但无法验证 Kubelet 端口": "connected, but could not verify the kubelet port",
+
+	// creds
+	"无效的凭据 ID: %s":                            "invalid credential ID: %s",
+	"未知子命令: %s (可用: list, show, use, delete)": "unknown subcommand: %s (available: list, show, use, delete)",
+	"未知的凭据类型: %s":                             "unknown credential type: %s",
+	"没有收集到的凭据，使用 'harvest node-creds' 收集":     "No collected credentials, use 'harvest node-creds' to collect them",
+	"用法: creds %s <id>":                       "usage: creds %s <id>",
+
+	// dashboards
+	"--from 需要指定 Pod": "--from requires a pod",
+	"API Server 获取 Service 失败，只从 Pod 缓存识别: %v": "failed to list services from the API server, identifying from the pod cache only: %v",
+	"Pod %s/%s 中没有 curl":                       "pod %s/%s has no curl",
+	"保存管理界面检查结果失败: %v":                         "failed to save dashboard check results: %v",
+	"探测 curl 失败: %w":                           "failed to probe for curl: %w",
+
+	// debug
+	"容器已退出: %s":                         "container exited: %s",
+	"当前 Token 在 %s 中缺少权限: %s":           "the current token lacks permissions in %s: %s",
+	"拉取镜像 %s 失败: %s":                    "failed to pull image %s: %s",
+	"权限预检查失败，继续尝试: %v":                  "permission pre-check failed, trying anyway: %v",
+	"注入临时容器失败: %w":                      "failed to inject ephemeral container: %w",
+	"用法: debug <pod> [--image <image>]": "usage: debug <pod> [--image <image>]",
+	"等待容器启动超时 (%s)":                     "timed out waiting for the container to start (%s)",
+	"获取容器状态失败: %w":                      "failed to get container status: %w",
+	"连接中，若无提示符请按回车；输入 exit 退出":          "connecting, press Enter if no prompt appears; type exit to leave",
+	"连接临时容器失败: %w":                      "failed to attach to the ephemeral container: %w",
+
+	// deploy
+	"custom 模板需要 --file <manifest>":  "the custom template requires --file <manifest>",
+	"创建 %s 失败: %w":                   "failed to create %s: %w",
+	"删除 %s 失败: %v":                   "failed to delete %s: %v",
+	"序列化 %s 失败: %w":                  "failed to serialize %s: %w",
+	"当前 Token 无权创建 %s (%s create)":   "the current token cannot create %s (%s create)",
+	"未知模板: %s，输入 'deploy list' 查看模板": "unknown template: %s, run 'deploy list' to see the templates",
+	"未知选项: %s":                       "unknown option: %s",
+	"没有已部署的资源":                       "No deployed resources",
+	"没有需要清理的资源":                      "nothing to clean up",
+	"生成清单失败: %w":                     "failed to generate manifest: %w",
+	"用法: deploy <hostpath|nsenter|node-shell|custom>，输入 'deploy list' 查看模板": "usage: deploy <hostpath|nsenter|node-shell|custom>, run 'deploy list' to see the templates",
+	"移除记录 %s 失败: %v":     "failed to remove record %s: %v",
+	"记录 %s 失败，需手动清理: %v": "failed to record %s, clean it up manually: %v",
+	"读取清单失败: %w":         "failed to read manifest: %w",
+	"读取部署记录失败: %w":       "failed to read deployment records: %w",
+
+	// describe
+	"-n 需要指定命名空间":                           "-n requires a namespace",
+	"多余的参数: %s":                             "unexpected argument: %s",
+	"用法: describe <pod> [-n <namespace>]":   "usage: describe <pod> [-n <namespace>]",
+	"缓存中没有 Pod: %s/%s":                      "pod not in cache: %s/%s",
+	"缓存中没有 Pod: %s/%s，使用 pods --refresh 更新": "pod not in cache: %s/%s, use pods --refresh to update",
+	"获取 Pod 列表失败: %w":                       "failed to get the pod list: %w",
+
+	// diff
+	"不能将扫描 #%d 与自身比较":                      "cannot compare scan #%d with itself",
+	"扫描 #%d 不存在，见 sa history":              "scan #%d does not exist, see sa history",
+	"无效的扫描 ID: %s":                         "invalid scan ID: %s",
+	"没有扫描记录，请先执行 'sa scan'":                "No scan records, run 'sa scan' first",
+	"用法: diff [<scan1> [<scan2>]]":         "usage: diff [<scan1> [<scan2>]]",
+	"至少需要两次扫描记录才能比较（当前 %d 次），见 sa history": "at least two scan records are needed for a comparison (currently %d), see sa history",
+	"读取扫描 #%d 失败: %w":                      "failed to read scan #%d: %w",
+	"读取扫描 #%d 结果失败: %w":                    "failed to read results of scan #%d: %w",
+
+	// discover
+	"-c 需要指定并发数":                      "-c requires a concurrency",
+	"-p 需要指定端口":                       "-p requires a port",
+	"-t 需要指定超时秒数":                     "-t requires a timeout in seconds",
+	"无效的并发数: %s":                      "invalid concurrency: %s",
+	"无效的超时秒数: %s":                     "invalid timeout in seconds: %s",
+	"没有发现 Kubelet 节点":                 "No kubelet nodes found",
+	"用法: discover <target> [options]": "usage: discover <target> [options]",
+	"解析目标失败: %w":                      "failed to parse target: %w",
+
+	// etcd
+	"--cred 需要指定凭据 ID":   "--cred requires a credential ID",
+	"--limit 需要指定数量":     "--limit requires a number",
+	"Pod 内探测失败: %w":      "in-pod probe failed: %w",
+	"保存 etcd 检查结果失败: %v": "failed to save etcd check results: %v",
+	"凭据 %d 不是客户端证书":      "credential %d is not a client certificate",
+	"凭据 %d: %v":          "credential %d: %v",
+	"没有可检查的目标，请指定 target 或先设置 API Server / 执行 nodes": "nothing to check, specify a target or set the API server / run nodes first",
+	"目标过多: %d 个 IP（最多 %d 个）":                         "too many targets: %d IPs (at most %d)",
+
+	// exec
+	"--all-nodes 需要与 --all-pods 一起使用":        "--all-nodes must be used together with --all-pods",
+	"--all-pods 不支持交互式模式":                    "--all-pods does not support interactive mode",
+	"--all-pods 模式必须指定命令":                    "--all-pods requires a command",
+	"Pod 内没有 tmux 或 screen，断线重连后将启动新的 shell": "pod has neither tmux nor screen, a new shell will be started after reconnecting",
+	"保存执行结果失败: %v":                           "failed to save exec results: %v",
+	"执行命令失败: %s":                             "failed to execute command: %s",
+	"执行命令失败: %w":                             "failed to execute command: %w",
+	"执行已中断，显示部分结果 (%d/%d)":                   "execution interrupted, showing partial results (%d/%d)",
+	"无效的环境变量: %s (格式: KEY=VAL)":              "invalid environment variable: %s (format: KEY=VAL)",
+	"无效的环境变量名: %s":                           "invalid environment variable name: %s",
+	"无法从任何 Kubelet 获取 Pod 列表":                "could not get the pod list from any kubelet",
+	"未找到可用的 shell，请使用 --shell 指定":            "no usable shell found, specify one with --shell",
+	"没有匹配的 Pod":                              "no matching pods",
+	"没有可用的 Kubelet，请先执行 'discover' 发现节点或使用 'set target' 设置目标": "no kubelet available, run 'discover' to find nodes or use 'set target' to set a target",
+	"没有存活的 Pod":                                    "no live pods",
+	"没有缓存的 Pod，请先执行 'pods' 命令":                     "no cached pods, run the 'pods' command first",
+	"用法: exec [pod] -- <command> 或 exec -it [pod]": "usage: exec [pod] -- <command> or exec -it [pod]",
+	"请使用 --from <pod> 指定 Pod 或先使用 'use' 选择一个 SA":   "specify a pod with --from <pod> or select an SA with 'use' first",
+	"请指定 Pod 名称或先使用 'use' 选择一个 SA":                 "specify a pod name or select an SA with 'use' first",
+
+	// failon
+	"无效的风险等级: %s (可用: admin, critical, high, medium, low)": "invalid risk level: %s (available: admin, critical, high, medium, low)",
+
+	// filter
+	"未知子命令: %s (可用: list, save, delete, test, fields)": "unknown subcommand: %s (available: list, save, delete, test, fields)",
+	"没有已保存的过滤器，使用 'filter save <name> <expr>' 创建":      "No saved filters, use 'filter save <name> <expr>' to create one",
+	"用法: filter <list|save|delete|test|fields>":        "usage: filter <list|save|delete|test|fields>",
+	"用法: filter delete <name>":                         "usage: filter delete <name>",
+	"用法: filter save <name> <expr>":                    "usage: filter save <name> <expr>",
+	"用法: filter test <expr|@name>":                     "usage: filter test <expr|@name>",
+
+	// fingerprint
+	"CVE 匹配失败: %v": "CVE matching failed: %v",
+
+	// harvest
+	"%s: 证书或私钥缺失，跳过":                                                          "%s: certificate or private key missing, skipped",
+	"无效的访问方式: %s (可用: hostfs, nsenter)":                                       "invalid access method: %s (available: hostfs, nsenter)",
+	"没有挂载 hostPath 或特权 hostPID 的可用 Pod，可先执行 deploy hostpath 或 deploy nsenter": "no pod with a hostPath mount or privileged hostPID available, run deploy hostpath or deploy nsenter first",
+	"没有收集到凭据": "No credentials collected",
+	"用法: harvest node-creds [--pod <[ns/]name>] [--via <hostfs|nsenter>] [--out <dir>]": "usage: harvest node-creds [--pod <[ns/]name>] [--via <hostfs|nsenter>] [--out <dir>]",
+
+	// history
+	"命令历史不可用":                   "command history is not available",
+	"用法: history search <text>": "usage: history search <text>",
+
+	// hostfs
+	"Pod %s/%s 没有 hostPath 挂载":                             "pod %s/%s has no hostPath mounts",
+	"主机路径 %s 不在 Pod 的 hostPath 挂载范围内 (%s)":                 "host path %s is outside the pod's hostPath mounts (%s)",
+	"保存 %s 失败: %w":                                         "failed to save %s: %w",
+	"创建目录失败: %w":                                           "failed to create directory: %w",
+	"未知子命令: %s (可用: mounts, ls, cat, find, grab)":          "unknown subcommand: %s (available: mounts, ls, cat, find, grab)",
+	"未知目标: %s，输入 'help hostfs' 查看可用目标":                     "unknown target: %s, run 'help hostfs' to see the available targets",
+	"没有缓存的 Pod，请先执行 pods":                                  "no cached pods, run pods first",
+	"用法: hostfs <mounts|ls|cat|find|grab> [path]":          "usage: hostfs <mounts|ls|cat|find|grab> [path]",
+	"用法: hostfs cat <path>":                                "usage: hostfs cat <path>",
+	"缓存中没有 Pod: %s":                                        "pod not in cache: %s",
+	"缓存中没有挂载 hostPath 的 Pod，请先执行 pods":                     "No pods with hostPath mounts in the cache, run pods first",
+	"缓存中没有挂载 hostPath 的 Running Pod，使用 'hostfs mounts' 查看": "no Running pods with hostPath mounts in the cache, use 'hostfs mounts' to view them",
+
+	// hunt
+	"使用 'hunt list --reveal' 查看完整证据": "use 'hunt list --reveal' to see the full evidence",
+	"搜寻已中断，仅保存已完成容器的结果":              "hunt interrupted, only results from completed containers are saved",
+	"没有 findings，请先执行 'hunt'":        "No findings, run 'hunt' first",
+	"没有匹配的 Running 容器":               "no matching Running containers",
+	"清空 findings 失败: %w":             "failed to clear findings: %w",
+	"读取 findings 失败: %w":             "failed to read findings: %w",
+
+	// image
+	"无效的镜像: %s，格式为 <arch>=<image>[,<arch>=<image>...]": "invalid image: %s, expected <arch>=<image>[,<arch>=<image>...]",
+	"无法确定节点 %s 的架构，请先执行 nodes 或只指定一个镜像":                "cannot determine the architecture of node %s, run nodes first or specify a single image",
+	"无法确定节点架构，请先执行 nodes 或只指定一个镜像":                     "cannot determine the node architecture, run nodes first or specify a single image",
+	"无法识别的架构: %s": "unrecognized architecture: %s",
+	"集群节点包含多种架构 (%s)，请使用 --node 指定节点或只指定一个镜像": "cluster nodes have multiple architectures (%s), specify a node with --node or a single image",
+
+	// impersonate
+	"%s 需要指定组":                                      "%s requires a group",
+	"以模拟身份检查权限失败: %w":                               "failed to check permissions as the impersonated identity: %w",
+	"只能指定一个用户: %s":                                  "only one user may be specified: %s",
+	"当前 Token 无权模拟: %s":                             "the current token cannot impersonate: %s",
+	"无效的 SA: %s (格式: namespace/name)":               "invalid SA: %s (format: namespace/name)",
+	"检查 impersonate 权限失败: %w":                       "failed to check the impersonate permission: %w",
+	"用法: impersonate [test] <user> [-g <group>]...": "usage: impersonate [test] <user> [-g <group>]...",
+	"用法: impersonate sa <namespace/name>":           "usage: impersonate sa <namespace/name>",
+
+	// import
+	"%s 需要指定文件路径":                                 "%s requires a file path",
+	"使用 pods、sa list、hunt list --reveal 浏览离线分析结果": "use pods, sa list and hunt list --reveal to browse the offline analysis results",
+	"文件中没有 Pod: %s":                               "no pods in file: %s",
+	"文件已存在: %s，使用 --out 指定其他路径":                   "file already exists: %s, use --out to choose another path",
+	"未知的导入类型: %s (可用: bundle, pods)":              "unknown import type: %s (available: bundle, pods)",
+	"用法: import <bundle|pods> <file>":             "usage: import <bundle|pods> <file>",
+	"用法: import bundle <file> [--out <db>]":       "usage: import bundle <file> [--out <db>]",
+	"用法: import pods <file>":                      "usage: import pods <file>",
+	"读取文件失败: %w":                                  "failed to read file: %w",
+
+	// internal/bundle
+	"不是有效的 kctl 归档: %w":             "not a valid kctl archive: %w",
+	"不是有效的 kctl 归档: format=%q":      "not a valid kctl archive: format=%q",
+	"不是有效的 kctl 归档: 缺少 %s 或 %s":     "not a valid kctl archive: missing %s or %s",
+	"写入 %s 失败: %w":                  "failed to write %s: %w",
+	"写入归档失败: %w":                    "failed to write archive: %w",
+	"创建归档失败: %w":                    "failed to create archive: %w",
+	"创建数据库文件失败: %w":                 "failed to create database file: %w",
+	"序列化 Pod 列表失败: %w":              "failed to serialize pod list: %w",
+	"序列化元数据失败: %w":                  "failed to serialize metadata: %w",
+	"归档版本 %d 高于当前支持的版本 %d，请升级 kctl": "archive version %d is newer than the supported version %d, please upgrade kctl",
+	"打开归档失败: %w":                    "failed to open archive: %w",
+	"解压数据库失败: %w":                   "failed to decompress the database: %w",
+	"解析 %s 失败: %w":                  "failed to parse %s: %w",
+	"读取 %s 失败: %w":                  "failed to read %s: %w",
+	"读取归档失败: %w":                    "failed to read archive: %w",
+
+	// internal/client
+	"CA 证书中没有有效的 PEM 证书":                             "no valid PEM certificate in the CA certificate",
+	"CA 证书文件中没有有效的 PEM 证书: %s":                       "no valid PEM certificate in CA certificate file: %s",
+	"SPDY 连接失败: %w":                                  "SPDY connection failed: %w",
+	"SSH 私钥 %s 已加密，请先用 ssh-add 加入 ssh-agent":         "SSH private key %s is encrypted, add it to ssh-agent with ssh-add first",
+	"SSH 跳板机 %s 握手失败: %w":                            "SSH handshake with jump host %s failed: %w",
+	"TLS 握手失败: %w":                                   "TLS handshake failed: %w",
+	"WebSocket 连接失败: %w":                             "WebSocket connection failed: %w",
+	"不支持的代理协议: %s，仅支持 socks5、socks5h、http 或 https":   "unsupported proxy scheme: %s, only socks5, socks5h, http or https are supported",
+	"不支持的协议: %q":                                     "unsupported protocol: %q",
+	"不支持的隧道协议: %s，仅支持 ssh://user@host[:port]":        "unsupported tunnel scheme: %s, only ssh://user@host[:port] is supported",
+	"交互式会话连接中断: %s（set retries 可开启自动重连）":             "interactive session disconnected: %s (set retries to enable automatic reconnects)",
+	"交互式会话连接中断，重连失败: %w":                             "interactive session disconnected, reconnect failed: %w",
+	"代理 CONNECT %s 失败: %s":                           "proxy CONNECT %s failed: %s",
+	"代理 TLS 握手失败: %w":                                "proxy TLS handshake failed: %w",
+	"代理 URL 缺少主机: %s":                                "proxy URL has no host: %s",
+	"代理 URL 缺少端口: %s":                                "proxy URL has no port: %s",
+	"代理认证失败 (HTTP 407)，请在代理 URL 中提供 user:pass@":      "proxy authentication failed (HTTP 407), provide user:pass@ in the proxy URL",
+	"保存原始响应失败: %w":                                   "failed to save raw response: %w",
+	"写入索引失败: %w":                                     "failed to write index: %w",
+	"创建 %s 流失败: %w":                                  "failed to create %s stream: %w",
+	"创建 SOCKS5 代理拨号器失败: %w":                          "failed to create SOCKS5 proxy dialer: %w",
+	"创建原始响应目录失败: %w":                                 "failed to create raw response directory: %w",
+	"加载客户端证书失败: %w":                                  "failed to load client certificate: %w",
+	"原始响应目录不可写: %w":                                  "raw response directory is not writable: %w",
+	"发送 CONNECT 请求失败: %w":                            "failed to send CONNECT request: %w",
+	"序列化索引失败: %w":                                    "failed to serialize index: %w",
+	"无效的 SHA256 指纹: %s":                              "invalid SHA256 fingerprint: %s",
+	"无效的地址: %s":                                      "invalid address: %s",
+	"无效的延迟范围: %s (示例: 200-800ms, 1-3s)":              "invalid delay range: %s (e.g. 200-800ms, 1-3s)",
+	"无效的时长: %s (示例: 30s, 1m, 500ms)":                 "invalid duration: %s (e.g. 30s, 1m, 500ms)",
+	"无效的速率: %s (示例: 5/s, 30/m)":                      "invalid rate: %s (e.g. 5/s, 30/m)",
+	"无效的速率单位: %s (可用: s, m, h)":                      "invalid rate unit: %s (available: s, m, h)",
+	"服务端未提供证书":                                       "server did not present a certificate",
+	"服务端证书未固定 (%s, sha256 %s)，确认后可用 set pin-cert 添加": "server certificate is not pinned (%s, sha256 %s), add it with set pin-cert after verifying",
+	"未设置 SSH 隧道":                                     "no SSH tunnel is set",
+	"没有可用的 SSH 认证方式：请在 URL 中提供密码、指定私钥文件或启动 ssh-agent": "no SSH authentication method available: provide a password in the URL, specify a private key file or start ssh-agent",
+	"等待 %s 流确认失败: %w":       "failed to wait for the %s stream confirmation: %w",
+	"经由 SSH 隧道连接 %s 失败: %w": "failed to connect to %s through the SSH tunnel: %w",
+	"解析 SSH 私钥 %s 失败: %w":   "failed to parse SSH private key %s: %w",
+	"解析 URL 失败: %w":         "failed to parse URL: %w",
+	"解析代理 URL 失败: %w":       "failed to parse proxy URL: %w",
+	"解析隧道 URL 失败: %w":       "failed to parse tunnel URL: %w",
+	"设置终端 raw 模式失败: %w":     "failed to set the terminal to raw mode: %w",
+	"读取 CA 证书失败: %w":        "failed to read CA certificate: %w",
+	"读取 CONNECT 响应失败: %w":   "failed to read CONNECT response: %w",
+	"读取 SSH 私钥失败: %w":       "failed to read SSH private key: %w",
+	"跳板机主机密钥 %s 与 known_hosts 中的记录不一致，可能存在中间人攻击": "host key of jump host %s does not match known_hosts, possible man-in-the-middle attack",
+	"连接 %s 失败: %w":                            "failed to connect to %s: %w",
+	"连接 SSH 跳板机 %s 失败: %w":                    "failed to connect to SSH jump host %s: %w",
+	"连接代理失败: %w":                              "failed to connect to the proxy: %w",
+	"隧道 URL 需要用户名和主机: ssh://user@host[:port]": "tunnel URL needs a user and host: ssh://user@host[:port]",
+
+	// internal/client/etcd
+	"etcd 拒绝访问":               "etcd denied access",
+	"etcd 返回错误 (HTTP %d): %s": "etcd returned an error (HTTP %d): %s",
+	"创建 HTTP 客户端失败: %w":       "failed to create HTTP client: %w",
+	"创建请求失败: %w":              "failed to create request: %w",
+	"响应不像是 etcd: %s":          "response does not look like etcd: %s",
+	"解析响应失败: %w":              "failed to parse response: %w",
+	"请求 etcd 失败: %w":          "request to etcd failed: %w",
+	"读取响应失败: %w":              "failed to read response: %w",
+
+	// internal/client/k8s
+	"API Server 认证失败：Token 无效: %w":              "API server authentication failed: invalid token: %w",
+	"K8s API Server 返回错误状态: %d":                 "Kubernetes API server returned error status: %d",
+	"K8s API Server 返回错误状态: %d%s":               "Kubernetes API server returned error status: %d%s",
+	"创建 WebSocket 拨号器失败: %w":                    "failed to create WebSocket dialer: %w",
+	"序列化请求失败: %w":                               "failed to serialize request: %w",
+	"当前 Token 无 nodes/proxy 权限: %w":             "the current token has no nodes/proxy permission: %w",
+	"当前 Token 无 pods/exec 或 pods/attach 权限: %w": "the current token has no pods/exec or pods/attach permission: %w",
+	"权限被拒绝：Token 无权访问 %s":                       "permission denied: the token cannot access %s",
+	"认证失败：Token 无效":                             "authentication failed: invalid token",
+	"请求 K8s API Server 失败: %w":                  "request to the Kubernetes API server failed: %w",
+	"资源不存在":                                     "resource not found",
+
+	// internal/client/kubelet
+	"/metrics 中未找到 kubernetes_build_info": "kubernetes_build_info not found in /metrics",
+	"TLS 连接失败: %w":                        "TLS connection failed: %w",
+	"kubelet API 返回错误 (HTTP %d): %s":      "kubelet API returned an error (HTTP %d): %s",
+	"不支持的协议: %s":                          "unsupported protocol: %s",
+	"创建 SPDY 连接失败: %w":                    "failed to create SPDY connection: %w",
+	"升级协议失败: HTTP %d":                     "protocol upgrade failed: HTTP %d",
+	"发送请求失败: %w":                          "failed to send request: %w",
+	"启动本地监听失败: %w":                        "failed to start local listener: %w",
+	"建立 SPDY 连接失败: %w":                    "failed to establish SPDY connection: %w",
+	"未获取到 TLS 证书":                         "no TLS certificate received",
+	"权限被拒绝：Token 无权访问 %s 端点":              "permission denied: the token cannot access the %s endpoint",
+	"监听 %s 失败: %w":                        "failed to listen on %s: %w",
+	"端口响应不像是 Kubelet":                     "port response does not look like a kubelet",
+	"解析 Pod 列表失败: %w":                     "failed to parse the pod list: %w",
+	"认证失败：Token 无效或无权限访问 Kubelet API":     "authentication failed: invalid token or no access to the kubelet API",
+	"请求 Kubelet /run API 失败: %w":          "request to the kubelet /run API failed: %w",
+	"请求 Kubelet API 失败: %w":               "request to the kubelet API failed: %w",
+
+	// internal/console
+	"%s: %d 条命令执行失败，最后一个错误: %w":                                   "%s: %d command(s) failed, last error: %w",
+	"%s:%d 执行失败，已停止 (-e): %w":                                     "%s:%d failed, stopped (-e): %w",
+	"--interval 必须大于 0":                                           "--interval must be greater than 0",
+	"--tls-cert 和 --tls-key 需要同时指定":                               "--tls-cert and --tls-key must be specified together",
+	"--var 需要 KEY=VAL":                                            "--var requires KEY=VAL",
+	"-c 需要代码":                                                     "-c requires code",
+	"cmd 列表中的元素需要为字符串":                                            "elements of the cmd list must be strings",
+	"cmd 需要为字符串或非空字符串列表":                                          "cmd must be a string or a non-empty list of strings",
+	"pod 需要为 namespace/name 或 kctl.pods() 中的元素，得到 %s":             "pod must be namespace/name or an element of kctl.pods(), got %s",
+	"source 嵌套超过 %d 层":                                            "source nested more than %d levels deep",
+	"不支持的资源类型: %s":                                                "unsupported resource type: %s",
+	"以下设置包含凭据，未随会话保存，请使用 set 重新设置: %s":                            "the following settings contain credentials and were not saved with the session, set them again with set: %s",
+	"保存会话状态失败: %v":                                                "failed to save session state: %v",
+	"保存失败: %w":                                                    "save failed: %w",
+	"启动 API 服务失败: %w":                                             "failed to start API server: %w",
+	"恢复会话状态失败: %v":                                                "failed to restore session state: %v",
+	"恢复当前 SA 失败: %v":                                              "failed to restore current SA: %v",
+	"打开脚本失败: %w":                                                  "failed to open script: %w",
+	"扫描 %s 失败: %v":                                                "failed to scan %s: %v",
+	"无效的变量: %s (需要 KEY=VAL)":                                      "invalid variable: %s (KEY=VAL required)",
+	"无效的正则表达式: %w":                                                "invalid regular expression: %w",
+	"无效的环境变量 %s=%s (需要正整数)":                                       "invalid environment variable %s=%s (positive integer required)",
+	"无效的退出码: %s":                                                  "invalid exit code: %s",
+	"未定义的变量: ${%s}":                                               "undefined variable: ${%s}",
+	"未检测到 Kubelet IP，请使用 -t 指定目标":                                 "No kubelet IP detected, specify the target with -t",
+	"查看模式 (--viewer) 下不能保存检查发现":                                   "cannot save findings in viewer mode (--viewer)",
+	"查看模式 (--viewer) 下禁止访问集群":                                     "cluster access is not allowed in viewer mode (--viewer)",
+	"生成 API 令牌失败: %w":                                             "failed to generate API token: %w",
+	"用法: source <file> [-e] [--var KEY=VAL]... [args...]":         "usage: source <file> [-e] [--var KEY=VAL]... [args...]",
+	"用法: star <file.star> [args...] 或 star -c '<code>' [args...]": "usage: star <file.star> [args...] or star -c '<code>' [args...]",
+	"读取保存的会话状态失败: %v":                                             "failed to read the saved session state: %v",
+	"读取脚本失败: %w":                                                  "failed to read script: %w",
+	"跳过目标 %s：未连接":                                                 "skipping target %s: not connected",
+
+	// internal/console/history
+	"写入历史文件失败: %w": "failed to write history file: %w",
+	"创建历史目录失败: %w": "failed to create history directory: %w",
+	"删除历史文件失败: %w": "failed to delete history file: %w",
+	"读取历史文件失败: %w": "failed to read history file: %w",
+
+	// internal/console/picker
+	"已取消选择":       "selection cancelled",
+	"没有匹配 %q 的候选": "no candidates match %q",
+	"没有可选择的候选":    "no candidates to choose from",
+
+	// internal/db
+	"PostgreSQL 数据库不支持快照到文件，请使用 pg_dump 或 export jsonl": "PostgreSQL databases cannot be snapshotted to a file, use pg_dump or export jsonl",
+	"SA 不存在: %s/%s，请先执行 'sa scan'":                      "SA not found: %s/%s, run 'sa scan' first",
+	"保存 %s/%s 的执行结果失败: %w":                              "failed to save the exec result of %s/%s: %w",
+	"保存 Pod %s/%s 失败: %w":                               "failed to save pod %s/%s: %w",
+	"保存 SA %s/%s 失败: %w":                                "failed to save SA %s/%s: %w",
+	"保存 SA %s/%s 快照失败: %w":                              "failed to save snapshot of SA %s/%s: %w",
+	"保存凭据 %s 失败: %w":                                    "failed to save credential %s: %w",
+	"保存发现 %s/%s 失败: %w":                                 "failed to save finding %s/%s: %w",
+	"保存扫描记录失败: %w":                                      "failed to save scan record: %w",
+	"保存操作记录失败: %w":                                      "failed to save operation log: %w",
+	"保存端口 %s:%d 失败: %w":                                 "failed to save port %s:%d: %w",
+	"保存部署记录 %s 失败: %w":                                  "failed to save deployment record %s: %w",
+	"写入元数据失败: %w":                                       "failed to write metadata: %w",
+	"准备语句失败: %w":                                        "failed to prepare statement: %w",
+	"凭据不存在: %d":                                         "credential not found: %d",
+	"分配执行编号失败: %w":                                      "failed to allocate exec run ID: %w",
+	"创建数据库目录失败: %w":                                     "failed to create database directory: %w",
+	"初始化数据库表结构失败: %w":                                   "failed to initialize database schema: %w",
+	"升级 %s 表结构失败: %w":                                   "failed to upgrade the %s table schema: %w",
+	"只读查询一次只能执行一条语句":                                    "read-only queries can only run one statement at a time",
+	"只读查询只能执行 %s 语句，修改数据库请使用 --write":                   "read-only queries can only run %s statements, use --write to modify the database",
+	"只读模式需要数据库文件路径":                                     "read-only mode requires a database file path",
+	"复制数据库失败: %w":                                       "failed to copy database: %w",
+	"序列化 Pod %s/%s 失败: %w":                              "failed to serialize pod %s/%s: %w",
+	"开始事务失败: %w":                                        "failed to begin transaction: %w",
+	"开始只读查询失败: %w":                                      "failed to begin read-only query: %w",
+	"打开数据库失败: %w":                                       "failed to open database: %w",
+	"扫描行失败: %w":                                         "failed to scan row: %w",
+	"提交事务失败: %w":                                        "failed to commit transaction: %w",
+	"数据库以只读方式打开，不能执行写入":                                 "the database is opened read-only, writes are not allowed",
+	"更新 SA %s/%s 失败: %w":                                "failed to update SA %s/%s: %w",
+	"查询 Pod 缓存失败: %w":                                   "failed to query the pod cache: %w",
+	"检查 %s.%s 列失败: %w":                                  "failed to check column %s.%s: %w",
+	"清空 Pod 缓存失败: %w":                                   "failed to clear the pod cache: %w",
+	"解析 Pod 缓存失败: %w":                                   "failed to parse the pod cache: %w",
+	"解析数据库连接串失败: %w":                                    "failed to parse database connection string: %w",
+	"设置数据库日志模式失败: %w":                                   "failed to set the database journal mode: %w",
+	"读取元数据失败: %w":                                       "failed to read metadata: %w",
+	"连接数据库 %s 失败: %w":                                   "failed to connect to database %s: %w",
+
+	// internal/filter
+	"无效的正则表达式 %q: %w":                "invalid regular expression %q: %w",
+	"无效的过滤器名称: %s":                   "invalid filter name: %s",
+	"无效的运算符 %q，请使用 && 或 ||":          "invalid operator %q, use && or ||",
+	"未知字段: %s (可用: %s)":              "unknown field: %s (available: %s)",
+	"过滤器 %s 无效: %w":                  "invalid filter %s: %w",
+	"过滤器不存在: %s":                     "filter not found: %s",
+	"过滤器不存在: %s，使用 'filter list' 查看": "filter not found: %s, use 'filter list' to view filters",
+	"过滤表达式 %s%s 缺少比较值":               "filter expression %s%s is missing a value",
+	"过滤表达式不完整":                       "incomplete filter expression",
+	"过滤表达式为空":                        "filter expression is empty",
+	"过滤表达式在 %q 处需要字段名":               "filter expression needs a field name at %q",
+	"过滤表达式多余内容: %s":                  "unexpected content in filter expression: %s",
+	"过滤表达式引号未闭合":                     "unterminated quote in filter expression",
+	"过滤表达式缺少 )":                      "filter expression is missing )",
+
+	// internal/manifest
+	"DaemonSet 只支持 restartPolicy Always":           "DaemonSets only support restartPolicy Always",
+	"无效的 restartPolicy: %s (可用: %s)":               "invalid restartPolicy: %s (available: %s)",
+	"无效的容忍策略: %s (可用: all, none)":                  "invalid toleration policy: %s (available: all, none)",
+	"清单中没有资源":                                      "no resources in the manifest",
+	"清单第 %d 个文档缺少 apiVersion、kind 或 metadata.name": "document %d of the manifest is missing apiVersion, kind or metadata.name",
+	"解析清单第 %d 个文档失败: %w":                           "failed to parse document %d of the manifest: %w",
+
+	// internal/notify
+	"webhook 返回 %s: %s": "webhook returned %s: %s",
+	"无效的 Webhook 地址: %s (需要 http:// 或 https://)": "invalid webhook URL: %s (http:// or https:// required)",
+
+	// internal/output
+	"分页器 %s 执行失败: %w": "pager %s failed: %w",
+	"打开 tee 文件失败: %w": "failed to open tee file: %w",
+
+	// internal/payload
+	"执行 uname 失败: %s":         "failed to run uname: %s",
+	"执行 uname 失败: %w":         "failed to run uname: %w",
+	"没有适用于 %s 架构的载荷 (可用: %s)": "no payload for the %s architecture (available: %s)",
+
+	// internal/plugin
+	"%s 失败: %w":      "%s failed: %w",
+	"无效的命令名: %s":     "invalid command name: %s",
+	"解析 %s 输出失败: %w": "failed to parse %s output: %w",
+	"解析插件输出失败: %w":   "failed to parse plugin output: %w",
+	"读取插件目录失败: %w":   "failed to read plugin directory: %w",
+
+	// internal/rbac
+	"获取 ClusterRole 失败: %w":        "failed to get ClusterRoles: %w",
+	"获取 ClusterRoleBinding 失败: %w": "failed to get ClusterRoleBindings: %w",
+
+	// internal/security
+	"curl 无输出": "curl produced no output",
+	"连接失败":     "connection failed",
+
+	// internal/server
+	"只读模式 (--viewer) 下不能接收同步":              "cannot receive syncs in read-only mode (--viewer)",
+	"只读模式 (--viewer) 下不能触发扫描":              "cannot trigger scans in read-only mode (--viewer)",
+	"已有扫描正在执行":                             "A scan is already running",
+	"扫描 #%d 不存在":                           "scan #%d does not exist",
+	"无效的请求: %w":                            "invalid request: %w",
+	"服务器返回 %s":                             "server returned %s",
+	"服务器返回 %s: %s":                         "server returned %s: %s",
+	"未认证：需要 Authorization: Bearer <token>": "unauthenticated: Authorization: Bearer <token> required",
+	"读取 SA %s/%s 失败: %w":                   "failed to read SA %s/%s: %w",
+
+	// internal/session
+	"Pod %s 与 -n %s 指定的命名空间不一致":                                  "pod %s does not match the namespace given with -n %s",
+	"Token 中未包含 ServiceAccount 信息":                               "token does not contain ServiceAccount information",
+	"kubeconfig 中没有可用的上下文":                                       "no usable context in kubeconfig",
+	"上下文 %s 的用户 %s 没有 Token 或客户端证书（不支持 exec / auth-provider 插件）": "context %s: user %s has no token or client certificate (exec / auth-provider plugins are not supported)",
+	"上下文 %s 的用户 %s 缺少 client-key":                                "context %s: user %s has no client-key",
+	"上下文不存在: %s (可用: %s)":                                        "context not found: %s (available: %s)",
+	"以 --read-only 启动，安全模式不能关闭":                                  "started with --read-only, safe mode cannot be turned off",
+	"创建 K8s 客户端失败: %v":                                           "failed to create Kubernetes client: %v",
+	"创建 K8s 客户端失败: %w":                                           "failed to create Kubernetes client: %w",
+	"创建 Kubelet 客户端失败: %w":                                       "failed to create kubelet client: %w",
+	"创建内存数据库失败: %w":                                              "failed to create in-memory database: %w",
+	"安全模式 (safe-mode) 下禁止对集群执行修改或执行操作":                           "modifying or executing on the cluster is not allowed in safe mode (safe-mode)",
+	"序列化会话状态失败: %w":                                              "failed to serialize session state: %w",
+	"恢复 SSH 隧道失败: %w":                                            "failed to restore SSH tunnel: %w",
+	"执行通道 %s 不直接连接 Kubelet，多节点执行请使用 'set exec-via auto'":         "exec transport %s does not connect to the kubelet directly, use 'set exec-via auto' for multi-node execution",
+	"文件中未找到 PEM 私钥: %s":                                          "no PEM private key found in file: %s",
+	"文件中未找到 PEM 证书: %s":                                          "no PEM certificate found in file: %s",
+	"无法解析 Token: %w":                                             "cannot parse token: %w",
+	"未知的执行通道: %s":                                                "unknown exec transport: %s",
+	"未设置 API Server，跳过权限检查。使用 'set api-server <addr>' 或 --api-server 参数设置": "API server is not set, skipping the permission check. Set it with 'set api-server <addr>' or --api-server",
+	"未设置 Kubelet IP，请使用 'set target <ip>' 设置":                              "kubelet IP is not set, use 'set target <ip>' to set it",
+	"未设置目标 Kubelet，请先使用 'set target <ip>'":                                 "target kubelet is not set, use 'set target <ip>' first",
+	"检查权限失败: %v":                      "permission check failed: %v",
+	"解析会话状态失败: %w":                    "failed to parse session state: %w",
+	"证书与私钥不匹配: %w":                    "certificate and private key do not match: %w",
+	"读取 certificate-authority 失败: %w": "failed to read certificate-authority: %w",
+	"读取 client-certificate 失败: %w":    "failed to read client-certificate: %w",
+	"读取 client-key 失败: %w":            "failed to read client-key: %w",
+	"读取 kubeconfig 失败: %w":            "failed to read kubeconfig: %w",
+	"读取 tokenFile 失败: %w":             "failed to read tokenFile: %w",
+	"读取私钥文件失败: %w":                    "failed to read private key file: %w",
+	"读取证书文件失败: %w":                    "failed to read certificate file: %w",
+	"重新加载 kubeconfig 失败: %w":          "failed to reload kubeconfig: %w",
+	"重新加载规则文件失败: %w":                  "failed to reload rules file: %w",
+
+	// internal/transport
+	"所有执行通道均失败（输入 'show transports' 查看详情）: %w": "all exec transports failed (run 'show transports' for details): %w",
+	"无法确定 Pod %s/%s 所在节点，请先执行 pods 刷新缓存":       "cannot determine the node of pod %s/%s, run pods to refresh the cache first",
+	"没有可用的执行通道":     "no exec transport available",
+	"该执行通道不支持交互式会话": "this exec transport does not support interactive sessions",
+
+	// internal/vulndb
+	"无法解析版本: %s":    "cannot parse version: %s",
+	"解析内置漏洞库失败: %w": "failed to parse the built-in vulnerability database: %w",
+
+	// mode
+	"无效的模式: %s，可选: kubelet, kubernetes": "invalid mode: %s, choose from: kubelet, kubernetes",
+
+	// namespaces
+	"API Server 获取命名空间失败，从 Pod 缓存和数据库推导: %v": "failed to list namespaces from the API server, deriving them from the pod cache and database: %v",
+	"没有命名空间数据，请先执行 pods 或 sa scan":           "No namespace data, run pods or sa scan first",
+	"获取 Pod 列表失败: %v":                        "failed to get the pod list: %v",
+	"读取 ServiceAccount 失败: %w":               "failed to read ServiceAccount: %w",
+
+	// nodes
+	"API Server 获取节点失败: %v": "failed to list nodes from the API server: %v",
+
+	// nodeshell
+	"没有可用的特权 hostPID Pod":               "no privileged hostPID pod available",
+	"缓存中没有 Pod: %s，请先执行 pods --refresh": "pod not in cache: %s, run pods --refresh first",
+	"节点 %s 上没有可用的特权 hostPID Pod":        "no privileged hostPID pod available on node %s",
+
+	// oplog
+	"不支持的格式: %s (可用: json, csv)":         "unsupported format: %s (available: json, csv)",
+	"没有匹配的操作记录":                          "No matching operation log entries",
+	"用法: oplog export <json|csv> [file]": "usage: oplog export <json|csv> [file]",
+
+	// pick
+	"Pod %s 存在于多个命名空间":                         "pod %s exists in multiple namespaces",
+	"没有缓存的 Running Pod，请先执行 'pods' 或指定 Pod 名称": "no cached Running pods, run 'pods' first or specify a pod name",
+
+	// pid2pod
+	"未找到 PID %d 或该进程不是容器进程":          "PID %d not found or not a container process",
+	"未找到容器进程":                        "No container process found",
+	"此功能仅在 Pod 内可用（需要访问 /proc 文件系统）": "this feature is only available inside a pod (requires access to the /proc filesystem)",
+	"获取进程列表失败: %w":                   "failed to get the process list: %w",
+
+	// pivot
+	"Pod 中没有 %s，可使用 --relay %s":           "pod has no %s, try --relay %s",
+	"Pod 中没有可用的中继工具 (%s)":                 "pod has no usable relay tool (%s)",
+	"已有 pivot 代理在运行，请先执行 'pivot stop' 停止": "A pivot proxy is already running, run 'pivot stop' first",
+	"探测中继工具失败: %s":                        "failed to probe for relay tools: %s",
+	"探测中继工具失败: %w":                        "failed to probe for relay tools: %w",
+	"未知的中继方式: %s (可用: %s)":                "unknown relay method: %s (available: %s)",
+	"没有正在运行的 pivot 代理":                    "no pivot proxy is running",
+	"请指定 Pod 名称，或使用 'pivot stop' 停止当前代理":  "specify a pod name, or use 'pivot stop' to stop the current proxy",
+
+	// pkg/kubeconfig
+	"API Server 地址为空":                           "API server address is empty",
+	"CA 证书不是有效的 PEM 格式":                         "CA certificate is not valid PEM",
+	"kubeconfig 中没有用户":                          "no users in kubeconfig",
+	"token 为空":                                  "token is empty",
+	"未找到 PEM 证书":                                "no PEM certificate found",
+	"用户 %s 的 client-certificate-data 无效: %w":    "invalid client-certificate-data for user %s: %w",
+	"用户 %s 的 client-key-data 无效: %w":            "invalid client-key-data for user %s: %w",
+	"解析 kubeconfig 失败: %w":                      "failed to parse kubeconfig: %w",
+	"解析证书失败: %w":                                "failed to parse certificate: %w",
+	"集群 %s 的 certificate-authority-data 无效: %w": "invalid certificate-authority-data for cluster %s: %w",
+
+	// pkg/network
+	"TCP 连接失败: %w":          "TCP connection failed: %w",
+	"不支持的 SOCKS 版本: %d":     "unsupported SOCKS version: %d",
+	"不支持的 SOCKS5 命令: %d":    "unsupported SOCKS5 command: %d",
+	"不支持的 SOCKS5 地址类型: %d":  "unsupported SOCKS5 address type: %d",
+	"仅支持 IPv4: %s":          "only IPv4 is supported: %s",
+	"十六进制解码失败: %w":          "hex decoding failed: %w",
+	"客户端不支持无认证方式":           "client does not support unauthenticated access",
+	"无效的 CIDR: %s":          "invalid CIDR: %s",
+	"无效的 IP 范围格式: %s":       "invalid IP range format: %s",
+	"无效的十六进制 IP 长度: %s":     "invalid hex IP length: %s",
+	"无效的目标主机: %q":           "invalid target host: %q",
+	"无效的目标格式: %s":           "invalid target format: %s",
+	"无效的目标端口: %d":           "invalid target port: %d",
+	"无效的端口范围: %s":           "invalid port range: %s",
+	"无效的结束 IP: %s":          "invalid end IP: %s",
+	"无效的结束值: %s":            "invalid end value: %s",
+	"无效的结束端口: %s":           "invalid end port: %s",
+	"无效的起始 IP: %s":          "invalid start IP: %s",
+	"无效的起始端口: %s":           "invalid start port: %s",
+	"无法打开路由表文件 %s: %w":      "cannot open route table file %s: %w",
+	"无法验证 Kubelet: %w":      "cannot verify the kubelet: %w",
+	"未找到默认网关":               "no default gateway found",
+	"没有有效的端口":               "no valid ports",
+	"目标不能为空":                "target must not be empty",
+	"端口不能为空":                "port must not be empty",
+	"读取路由表文件失败: %w":         "failed to read route table file: %w",
+	"起始端口不能大于结束端口: %d > %d": "start port cannot be greater than end port: %d > %d",
+
+	// pkg/proc
+	"读取 cgroup 文件失败: %w": "failed to read cgroup file: %w",
+
+	// pkg/token
+	"token 文件为空":              "token file is empty",
+	"无效的 JWT Token 格式":        "invalid JWT token format",
+	"解析 Token claims 失败: %w":  "failed to parse token claims: %w",
+	"解码 Token payload 失败: %w": "failed to decode token payload: %w",
+
+	// plugins
+	"%s: 与已有命令 %s 重名，未加载":         "%s: conflicts with existing command %s, not loaded",
+	"保存插件发现失败: %v":                "failed to save plugin findings: %v",
+	"执行插件 %s 失败: %w":              "failed to run plugin %s: %w",
+	"没有插件，将可执行文件放入 %s 后重新启动 kctl": "No plugins, put executables into %s and restart kctl",
+
+	// pods
+	"没有找到 Pod":    "No pods found",
+	"没有符合条件的 Pod": "No matching pods",
+
+	// podsecurity
+	"命名空间不存在: %s":                   "namespace not found: %s",
+	"检查 %s 中的 create pods 权限失败: %v": "failed to check the create pods permission in %s: %v",
+	"检查 PSP %s 的 use 权限失败: %v":      "failed to check the use permission of PSP %s: %v",
+	"获取 PodSecurityPolicy 失败: %v":   "failed to get PodSecurityPolicies: %v",
+	"获取命名空间失败: %w":                  "failed to get namespaces: %w",
+
+	// portforward
+	"创建停止监听器失败: %w":                          "failed to create stop listener: %w",
+	"已有端口转发在运行，请先执行 'pf stop' 停止":            "A port forward is already running, run 'pf stop' first",
+	"无效的本地端口: %s":                            "invalid local port: %s",
+	"无效的端口映射格式: %s (应为 local:remote)":        "invalid port mapping format: %s (expected local:remote)",
+	"无效的远程端口: %s":                            "invalid remote port: %s",
+	"没有正在运行的端口转发":                            "no port forward is running",
+	"请指定 Pod 名称，或使用 'pf stop' 停止当前转发":        "specify a pod name, or use 'pf stop' to stop the current forward",
+	"请指定端口映射，格式: <local_port>:<remote_port>": "specify a port mapping, format: <local_port>:<remote_port>",
+
+	// profile
+	"Token 不是从文件加载的，未保存；如需保存请使用 'set token-file <path>'":     "token was not loaded from a file and was not saved; use 'set token-file <path>' to save it",
+	"无效的分页设置: %s (可用: on, off)":                              "invalid pager setting: %s (available: on, off)",
+	"未知子命令: %s (可用: list, use, show, save, delete, default)": "unknown subcommand: %s (available: list, use, show, save, delete, default)",
+	"没有 profile，使用 'profile save <name>' 保存当前设置":             "No profiles, use 'profile save <name>' to save the current settings",
+	"用法: profile %s <name>":                                  "usage: profile %s <name>",
+
+	// pscan
+	"%s 需要指定值":                                            "%s requires a value",
+	"Pod %s/%s 中没有 bash 或 nc，无法扫描":                        "pod %s/%s has neither bash nor nc, cannot scan",
+	"保存扫描结果失败: %v":                                        "failed to save scan results: %v",
+	"只能指定一个目标: %s":                                        "only one target may be specified: %s",
+	"扫描 %s 起的 %d 个 IP 失败: %v":                             "failed to scan the IPs from %s (%d addresses): %v",
+	"探测扫描工具失败: %s":                                        "failed to probe for scan tools: %s",
+	"探测扫描工具失败: %w":                                        "failed to probe for scan tools: %w",
+	"无效的扫描方式: %s (可用: bash, nc)":                          "invalid scan method: %s (available: bash, nc)",
+	"没有开放端口记录，请先执行 pscan <target>":                        "No open ports recorded, run pscan <target> first",
+	"清空开放端口记录失败: %w":                                      "failed to clear open port records: %w",
+	"用法: pscan <target> [--ports <ports>] [--from <pod>]": "usage: pscan <target> [--ports <ports>] [--from <pod>]",
+	"目标过多: %d 个 IP（最多 %d 个），请缩小网段":                        "too many targets: %d IPs (at most %d), narrow the range",
+	"读取开放端口失败: %w":                                        "failed to read open ports: %w",
+
+	// rbac
+	"保存 RBAC 审计结果失败: %v":                                  "failed to save RBAC audit results: %v",
+	"数据库中没有 ServiceAccount，请先执行 'sa scan'":                "no ServiceAccounts in the database, run 'sa scan' first",
+	"未知子命令: %s (可用: who-can, admins, analyze)":            "unknown subcommand: %s (available: who-can, admins, analyze)",
+	"用法: rbac admins [--held]":                            "usage: rbac admins [--held]",
+	"用法: rbac analyze [namespace/name] [--all]":           "usage: rbac analyze [namespace/name] [--all]",
+	"用法: rbac who-can <verb> <resource> [-n <namespace>]": "usage: rbac who-can <verb> <resource> [-n <namespace>]",
+	"用法: rbac who-can <verb> <resource> | rbac admins":    "usage: rbac who-can <verb> <resource> | rbac admins",
+
+	// rules
+	"--level 需要参数":                           "--level requires an argument",
+	"无效的等级: %s (可用: critical, high, medium)": "invalid level: %s (available: critical, high, medium)",
+	"未知子命令: %s (可用: list, load, reset)":      "unknown subcommand: %s (available: list, load, reset)",
+	"用法: rules <list|load|reset>":            "usage: rules <list|load|reset>",
+	"用法: rules load <file>":                  "usage: rules load <file>",
+
+	// run
+	"必须指定 --cmd 参数":      "--cmd is required",
+	"无法确定容器名称，请使用 -c 指定": "cannot determine the container name, specify it with -c",
+
+	// sa
+	"%s/%s 没有可用的 Token": "%s/%s has no usable token",
+	"%s/%s 没有备注":        "%s/%s has no note",
+	"%s/%s 没有标签":        "%s/%s has no tags",
+	"%s/%s 的 Token 已过期，kubeconfig 可能无法使用":                      "the token of %s/%s has expired, the kubeconfig may not work",
+	"--ca 需要文件路径":                                              "--ca requires a file path",
+	"--max-per-namespace 需要参数":                                 "--max-per-namespace requires an argument",
+	"--out 需要文件路径":                                             "--out requires a file path",
+	"--sample 需要参数 (如 10%%)":                                   "--sample requires an argument (e.g. 10%%)",
+	"--seed 需要参数":                                              "--seed requires an argument",
+	"使用: KUBECONFIG=%s kubectl auth can-i --list":              "use: KUBECONFIG=%s kubectl auth can-i --list",
+	"保存云检查结果失败: %v":                                            "failed to save cloud check results: %v",
+	"保存扫描记录失败: %v":                                             "failed to save scan record: %v",
+	"写入 kubeconfig 失败: %w":                                     "failed to write kubeconfig: %w",
+	"发送通知失败: %v":                                               "failed to send notification: %v",
+	"扫描已中断，显示部分结果 (%d/%d)":                                     "scan interrupted, showing partial results (%d/%d)",
+	"无效的抽样比例: %s (如 10%% 或 0.1)":                               "invalid sample ratio: %s (e.g. 10%% or 0.1)",
+	"无效的数量: %s (必须 >= 1)":                                      "invalid number: %s (must be >= 1)",
+	"无效的标签: %q":                                                "invalid tag: %q",
+	"无效的随机种子: %s":                                              "invalid random seed: %s",
+	"未找到 ServiceAccount: %s/%s":                                "ServiceAccount not found: %s/%s",
+	"未找到 ServiceAccount: %s，请先执行 'sa scan'":                    "ServiceAccount not found: %s, run 'sa scan' first",
+	"未选择 ServiceAccount，请先使用 'sa use <namespace/name>' 选择":     "no ServiceAccount selected, use 'sa use <namespace/name>' first",
+	"查找 ServiceAccount 失败: %w":                                 "failed to look up ServiceAccount: %w",
+	"格式错误，请使用 namespace/sa-name 格式":                            "invalid format, use namespace/sa-name",
+	"没有可用的 ServiceAccount，请先执行 'sa scan'":                      "no ServiceAccount available, run 'sa scan' first",
+	"没有找到 Running 状态的 Pod":                                     "No Running pods found",
+	"没有找到 ServiceAccount，请先执行 'sa scan'":                       "No ServiceAccounts found, run 'sa scan' first",
+	"没有找到挂载 SA Token 的 Running Pod":                            "No Running pods with a mounted SA token found",
+	"没有符合条件的 ServiceAccount":                                   "No matching ServiceAccounts",
+	"生成 kubeconfig 失败: %w":                                     "failed to generate kubeconfig: %w",
+	"用法: sa kubeconfig <namespace/name>，或先使用 'sa use' 选择 SA":   "usage: sa kubeconfig <namespace/name>, or select an SA with 'sa use' first",
+	"用法: sa note <namespace/name> [\"text\"|--clear]":          "usage: sa note <namespace/name> [\"text\"|--clear]",
+	"用法: sa tag <namespace/name> [tag...] [--remove <tag>...]": "usage: sa tag <namespace/name> [tag...] [--remove <tag>...]",
+	"获取 ServiceAccount 列表失败: %w":                               "failed to get the ServiceAccount list: %w",
+	"该 SA 来自被动扫描，没有 Token，API 请求将使用当前 Kubelet Token":           "this SA comes from a passive scan and has no token, API requests will use the current kubelet token",
+	"请先执行 'sa scan' 扫描 ServiceAccount":                         "run 'sa scan' to scan ServiceAccounts first",
+
+	// services
+	"API Server 获取 Service 失败，改为在 Pod 内发现: %v": "failed to list services from the API server, discovering from inside a pod instead: %v",
+	"执行发现脚本失败: %s":                             "failed to run discovery script: %s",
+	"执行发现脚本失败: %w":                             "failed to run discovery script: %w",
+	"获取 Endpoints 失败，不显示后端 Pod: %v":            "failed to get Endpoints, backend pods are not shown: %v",
+
+	// show
+	"--pod 格式应为 namespace/name: %s":                             "--pod must be namespace/name: %s",
+	"未知选项: %s (可用: options, status, env, kubelets, transports)": "unknown option: %s (available: options, status, env, kubelets, transports)",
+	"用法: show <options|status|env|kubelets|transports>":         "usage: show <options|status|env|kubelets|transports>",
+	"缓存中没有 Running 状态的 Pod，请使用 --pod 指定":                        "no Running pods in the cache, specify one with --pod",
+
+	// sync
+	"--api-token 需要指定令牌": "--api-token requires a token",
+	"同步失败: %w":           "sync failed: %w",
+	"未设置团队服务器令牌，请使用 'set sync-token <token>' 或 --api-token": "team server token is not set, use 'set sync-token <token>' or --api-token",
+	"未设置团队服务器，请使用 'set sync-server <url>' 或 'sync <url>'":   "team server is not set, use 'set sync-server <url>' or 'sync <url>'",
+
+	// tee
+	"tee 已开启 (%s)，先执行 tee off":     "tee is already on (%s), run tee off first",
+	"tee 未开启":                      "tee is not on",
+	"tee 未开启，使用 'tee <file>' 开始记录": "tee is not on, use 'tee <file>' to start recording",
+
+	// webhooks
+	"保存 Webhook 检查结果失败: %v":                       "failed to save webhook check results: %v",
+	"获取 MutatingWebhookConfiguration 失败: %w":      "failed to get MutatingWebhookConfigurations: %w",
+	"获取 ValidatingWebhookConfiguration 失败: %w":    "failed to get ValidatingWebhookConfigurations: %w",
+	"获取命名空间失败，无法核对 namespaceSelector 排除的命名空间: %v": "failed to get namespaces, cannot check the namespaces excluded by namespaceSelector: %v",
+	// 列表汇总、结果与连接错误
+	"权限被拒绝：Token 无权访问 /run 端点":                      "permission denied: the token cannot access the /run endpoint",
+	"Pod 或容器不存在: %s/%s/%s":                          "pod or container not found: %s/%s/%s",
+	"Kubelet API 返回错误 (HTTP %d): %s":                "kubelet API returned an error (HTTP %d): %s",
+	"exec 连接升级失败 (HTTP %d): %s":                     "exec connection upgrade failed (HTTP %d): %s",
+	"无效的请求: %v":                                     "invalid request: %v",
+	"缺少 command 或 args":                             "missing command or args",
+	"未知方法: %s (可用: exec, commands, ping, shutdown)": "unknown method: %s (available: exec, commands, ping, shutdown)",
+	"命令退出码: %d":                                     "command exit code: %d",
+	"获取 Role 失败: %v":                                "failed to get Roles: %v",
+	"获取 RoleBinding 失败: %v":                         "failed to get RoleBindings: %v",
+	"Pod %s 存在于多个命名空间，请使用 -n 或 namespace/name 指定: %s": "pod %s exists in multiple namespaces, use -n or namespace/name: %s",
+	"Pod 没有容器":        "pod has no containers",
+	"Token 为空":        "token is empty",
+	"exec 失败: %v":     "exec failed: %v",
+	"读取 Token 失败: %s": "failed to read token: %s",
+	"解析 Token 失败: %v": "failed to parse token: %v",
+	"下一步: ":           "Next: ",
+	"容器状态: ":          "container state: ",
+	"临时容器无法通过 API 删除，退出主进程后即终止":              "Ephemeral containers cannot be deleted via the API; the container stops when its main process exits",
+	"容器 %s 会保留在 Pod spec 中，删除/重建 Pod 后才会消失:": "Container %s stays in the pod spec until the pod is deleted/recreated:",
+	"(由控制器管理时会自动重建)":                         "(recreated automatically when managed by a controller)",
+	"共 %d 个过滤器":                              "%d filter(s)",
+	"共 %d 条 (%s)":                            "%d entries (%s)",
+	"共 %d 个插件":                               "%d plugin(s)",
+	"加载失败:":                                  "Failed to load:",
+	"共 %d 个 Pod":                             "%d pod(s)",
+	"共 %d 个 profile":                         "%d profile(s)",
+	"共 %d 个 ServiceAccount":                  "%d ServiceAccount(s)",
+	"共 %d 个 Kubelet 节点":                      "%d kubelet node(s)",
+	"使用 'set target <ip>' 选择目标":              "Use 'set target <ip>' to select a target",
+	"可用的 ServiceAccount:":                    "Available ServiceAccounts:",
+	"用法: ":                                   "Usage: ",
+	" (仅 %s 节点)":                             " (%s nodes only)",
+	"，结果只包含 ClusterRoleBinding":              ", results only include ClusterRoleBindings",
+	"%s 下共 %d 个键: %s":                        "%[2]d keys under %[1]s: %[3]s",
+
+	// 命令用法（键为 Usage() 的完整文本）
+	`query [options] "<sql>"
+
+对会话数据库（--db 指定的 SQLite 文件或 PostgreSQL，未指定时为内存数据库）执行 SQL，结果以表格显示
+默认只读：只能执行单条 SELECT / WITH / VALUES / EXPLAIN 语句，并在只读事务中执行，修改由数据库拒绝
+
+表：
+  pods, service_accounts, scans, scan_results, findings, deployments, credentials,
+  ports, operations, exec_results, pod_cache, meta
+
+选项：
+  --limit <n>   最多显示 n 行（默认 500，0 为不限制）
+  --wide        不截断长字段
+  --write       允许修改数据库（查看模式下不可用）
+
+示例：
+  query "SELECT namespace, COUNT(*) AS n FROM pods GROUP BY namespace ORDER BY n DESC"
+  query "SELECT namespace, name, risk_level FROM service_accounts WHERE permissions LIKE '%secrets%'"
+  query --wide "SELECT pod, stdout FROM exec_results WHERE run_id = 3"
+  query --write "DELETE FROM findings WHERE source = 'hunt'"`: `query [options] "<sql>"
+
+Run SQL against the session database (the SQLite file or PostgreSQL given by --db, an in-memory database otherwise) and print the rows as a table
+Read-only by default: only a single SELECT / WITH / VALUES / EXPLAIN statement is accepted and it runs in a read-only transaction, so the database rejects any change
+
+Tables:
+  pods, service_accounts, scans, scan_results, findings, deployments, credentials,
+  ports, operations, exec_results, pod_cache, meta
+
+Options:
+  --limit <n>   Show at most n rows (default 500, 0 for no limit)
+  --wide        Do not truncate long values
+  --write       Allow changes to the database (not available in viewer mode)
+
+Examples:
+  query "SELECT namespace, COUNT(*) AS n FROM pods GROUP BY namespace ORDER BY n DESC"
+  query "SELECT namespace, name, risk_level FROM service_accounts WHERE permissions LIKE '%secrets%'"
+  query --wide "SELECT pod, stdout FROM exec_results WHERE run_id = 3"
+  query --write "DELETE FROM findings WHERE source = 'hunt'"`,
+	`top [options]
+
+从 Kubelet /stats/summary 获取节点和 Pod 的 CPU / 内存使用
+识别出的安全 / 监控代理（Falco、CrowdStrike、Datadog 等）在 AGENT 列标出
+
+选项：
+  --sort <cpu|mem>    排序字段（默认 cpu，从高到低）
+  --asc               从低到高排序，便于挑选空闲的 Pod 执行操作
+  -n <namespace>      按命名空间过滤
+  --limit, -l <n>     只显示前 n 个 Pod
+  --containers, -c    显示每个容器的使用
+
+示例：
+  top                    按 CPU 从高到低列出 Pod
+  top --sort mem -l 10   内存占用最高的 10 个 Pod
+  top --asc              最空闲的 Pod 在前
+  top -n kube-system -c  显示 kube-system 中各容器的使用`: `top [options]
+
+Show node and pod CPU / memory usage from the kubelet /stats/summary endpoint
+Recognized security / monitoring agents (Falco, CrowdStrike, Datadog, ...) are marked in the AGENT column
+
+Options:
+  --sort <cpu|mem>    Sort field (default cpu, highest first)
+  --asc               Sort lowest first, handy for picking idle pods to act from
+  -n <namespace>      Filter by namespace
+  --limit, -l <n>     Show only the first n pods
+  --containers, -c    Show usage per container
+
+Examples:
+  top                    List pods by CPU, highest first
+  top --sort mem -l 10   The 10 pods using the most memory
+  top --asc              Idlest pods first
+  top -n kube-system -c  Per-container usage in kube-system`,
+	`results [list] [options]
+results show <id>
+results export json [file] [--run <id>]
+
+查看 exec --all-pods 保存的执行结果：每个容器的 Pod、所经 Kubelet、命令、标准输出、标准错误、退出码和时间
+每次批量执行保存为一轮（run），结果保存在会话数据库的 exec_results 表中，不会随屏幕输出丢失
+
+选项：
+  --run <id|last>     只显示指定轮次的结果（last 为最近一轮）
+  --pod <text>        只显示 namespace/pod 包含指定文本的结果
+  --node <text>       只显示 Kubelet 包含指定文本的结果
+  --failed            只显示失败的结果（请求失败或退出码非零）
+  --last <n>          只显示最近 n 条
+
+示例：
+  results
+  results --run last --failed
+  results --pod kube-system/
+  results show 42
+  results export json results.json
+  results export json --run 3`: `results [list] [options]
+results show <id>
+results export json [file] [--run <id>]
+
+Browse the results saved by exec --all-pods: pod, kubelet, command, stdout, stderr, exit code and time for every container
+Each batch execution is saved as one run in the exec_results table of the session database, so results are not lost with the scrollback
+
+Options:
+  --run <id|last>     Only show results of the given run (last for the most recent)
+  --pod <text>        Only show results whose namespace/pod contains the text
+  --node <text>       Only show results whose kubelet contains the text
+  --failed            Only show failures (request failed or non-zero exit code)
+  --last <n>          Only show the last n results
+
+Examples:
+  results
+  results --run last --failed
+  results --pod kube-system/
+  results show 42
+  results export json results.json
+  results export json --run 3`,
+	`export <format> [file] [options]
+export --out <file> [options]
+
+导出扫描结果，未指定文件时输出到终端
+指定输出文件时可省略格式，按扩展名识别（.json .jsonl .csv .sarif .md .html，.tar.gz 为 bundle）；
+文件名以 .gz 结尾时使用 gzip 压缩（如 results.json.gz）
+
+格式：
+  json      JSON 格式：SA、Pod、检查发现（findings）、exec --all-pods 执行结果（results）和扫描记录
+  jsonl     JSON Lines：每行一条记录 {"type": ..., "data": ...}，type 为 meta、scan、serviceAccount、pod、
+            finding、execResult，data 与 json 格式中的记录相同；直接从数据库逐行读取写出，
+            不在内存中构建完整结果，适合 Pod 数量很多的集群
+  csv       CSV 格式（RFC 4180 转义），每次导出一张表：sas（默认）、pods 或 findings
+  sarif     SARIF 2.1.0：SA 的风险权限（规则 ID 对应风险查找表，rbac/<key>）、Pod 安全标识（pod/<flag>）
+            和检查发现（finding/<source>/...），可导入 GitHub code scanning / DefectDojo
+  markdown  Markdown 报告：风险概要、问题列表（标注 MITRE ATT&CK 技术）、ATT&CK for Containers 覆盖矩阵
+            和对集群执行的操作记录（oplog）
+  html      同 markdown，单文件 HTML
+  bundle    完整归档：数据库、原始 Pod JSON 和扫描元数据打包为单个 tar.gz
+            （包含 Token 和收集的凭据），在分析机上用 import bundle 打开
+
+选项：
+  --out, -o <file>  输出文件（也可直接写在格式后）
+  --where <expr>    只导出匹配过滤表达式的 SA / Pod（@name 引用已保存的过滤器，见 filter）
+                    指定 --where 时不包含检查发现（json / jsonl 同时不包含执行结果）
+  --sheet <name>    csv 导出的表：sas、pods、findings（默认 sas）
+  --delimiter <c>   csv 分隔符（默认 ,），制表符写作 tab
+  --bom             csv 开头写入 UTF-8 BOM，便于 Excel 正确识别中文
+
+示例：
+  export json
+  export csv
+  export csv pods.csv --sheet pods --bom
+  export csv --sheet findings --delimiter ';'
+  export sarif
+  export markdown
+  export html report.html             写入文件
+  export json --out results.json
+  export jsonl cluster.jsonl.gz       流式导出并压缩
+  export --out results.json.gz        按扩展名识别格式，gzip 压缩
+  export --out findings.sarif
+  export json --where @prod-risky
+  export bundle                       写入 kctl-bundle-<时间>.tar.gz
+  export bundle field.tar.gz`: `export <format> [file] [options]
+export --out <file> [options]
+
+Export scan results, to the terminal when no file is given
+With an output file the format may be omitted and is taken from the extension (.json .jsonl .csv .sarif .md .html, .tar.gz for bundle);
+files ending in .gz are gzip-compressed (e.g. results.json.gz)
+
+Formats:
+  json      JSON: SAs, pods, findings, exec --all-pods results (results) and scan runs
+  jsonl     JSON Lines: one {"type": ..., "data": ...} record per line, type is meta, scan, serviceAccount, pod,
+            finding or execResult and data is the same record as in json; rows are read from the database and
+            written one by one without building the whole result in memory, for clusters with many pods
+  csv       CSV (RFC 4180 quoting), one table per export: sas (default), pods or findings
+  sarif     SARIF 2.1.0: risky SA permissions (rule IDs follow the risk table, rbac/<key>), pod security flags (pod/<flag>)
+            and findings (finding/<source>/...), for GitHub code scanning / DefectDojo
+  markdown  Markdown report: risk overview, issues (tagged with MITRE ATT&CK techniques), ATT&CK for Containers coverage matrix
+            and the operations performed against the cluster (oplog)
+  html      Same as markdown, as a single HTML file
+  bundle    Full archive: database, raw pod JSON and scan metadata in a single tar.gz
+            (includes tokens and collected credentials), open it on the analysis machine with import bundle
+
+Options:
+  --out, -o <file>  Output file (may also follow the format directly)
+  --where <expr>    Only export SAs / pods matching the filter expression (@name refers to a saved filter, see filter)
+                    With --where findings are left out (and exec results too for json / jsonl)
+  --sheet <name>    Table for csv: sas, pods, findings (default sas)
+  --delimiter <c>   csv delimiter (default ,), write tab for a tab
+  --bom             Write a UTF-8 BOM at the start of the csv so Excel detects the encoding
+
+Examples:
+  export json
+  export csv
+  export csv pods.csv --sheet pods --bom
+  export csv --sheet findings --delimiter ';'
+  export sarif
+  export markdown
+  export html report.html             Write to a file
+  export json --out results.json
+  export jsonl cluster.jsonl.gz       Stream and compress
+  export --out results.json.gz        Format from the extension, gzip-compressed
+  export --out findings.sarif
+  export json --where @prod-risky
+  export bundle                       Writes kctl-bundle-<time>.tar.gz
+  export bundle field.tar.gz`,
+}
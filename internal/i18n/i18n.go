@@ -0,0 +1,102 @@
+// Package i18n 控制台消息的多语言支持
+//
+// 消息以中文原文作为 ID：调用方用 T / Errorf 包装原有的中文字符串，
+// 当前语言的目录中有对应翻译时使用翻译，否则原样输出中文，
+// 因此可以逐步为更多消息补充翻译
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// 支持的语言
+const (
+	ZH = "zh" // 中文（默认，消息原文）
+	EN = "en" // English
+)
+
+// Langs 支持的语言列表
+var Langs = []string{ZH, EN}
+
+// catalogs 各语言的消息目录：中文原文 -> 译文
+var catalogs = map[string]map[string]string{
+	EN: en,
+}
+
+var current atomic.Value
+
+func init() {
+	current.Store(ZH)
+}
+
+// SetLang 设置当前语言
+func SetLang(lang string) error {
+	lang = strings.ToLower(lang)
+	if !slices.Contains(Langs, lang) {
+		return Errorf("不支持的语言: %s (可用: %s)", lang, strings.Join(Langs, ", "))
+	}
+	current.Store(lang)
+	return nil
+}
+
+// Lang 返回当前语言
+func Lang() string {
+	return current.Load().(string)
+}
+
+// FromEnv 按 LC_ALL、LC_MESSAGES、LANG 的优先级选择语言
+// 第一个非空变量为中文、C / POSIX 或均未设置时使用中文，其余（如 en_US.UTF-8）使用英文
+func FromEnv() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(value), ZH) {
+			return ZH
+		}
+		if value == "C" || value == "POSIX" || strings.HasPrefix(value, "C.") {
+			// 容器镜像常见的默认值，不代表用户的语言偏好
+			return ZH
+		}
+		return EN
+	}
+	return ZH
+}
+
+// T 返回消息在当前语言下的文本
+func T(msg string) string {
+	if translated, ok := catalogs[Lang()][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Tf 使用当前语言的格式化字符串格式化消息
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Errorf 使用当前语言的格式化字符串创建错误，支持 %w
+func Errorf(format string, args ...any) error {
+	return fmt.Errorf(T(format), args...)
+}
+
+// New 创建错误，Error() 返回当前语言下的文本
+// 用于包级别的哨兵错误：翻译在输出时进行，因此设置语言后同样生效，errors.Is 比较不受影响
+func New(msg string) error {
+	return &message{msg: msg}
+}
+
+// message 按当前语言输出的错误
+type message struct {
+	msg string
+}
+
+func (e *message) Error() string {
+	return T(e.msg)
+}
@@ -0,0 +1,207 @@
+// Package logging 控制台运行日志
+//
+// 日志按级别（debug / info / warn）输出到终端的 stderr，默认只输出 warn；
+// 设置日志文件后，全部级别的日志以 JSON 行追加写入文件，用于排查连接问题和留存操作记录。
+// 所有 HTTP、WebSocket 和 SPDY 请求都以 debug 级别记录
+package logging
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	prefixed "github.com/x-cray/logrus-prefixed-formatter"
+	"golang.org/x/term"
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+)
+
+// 日志级别
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+)
+
+// Levels 支持的日志级别
+var Levels = []string{LevelDebug, LevelInfo, LevelWarn}
+
+// Fields 日志的结构化字段
+type Fields = logrus.Fields
+
+var (
+	mu           sync.Mutex
+	logger       = logrus.New()
+	consoleLevel = logrus.WarnLevel
+	file         *os.File
+	filePath     string
+
+	consoleFormatter = &prefixed.TextFormatter{
+		FullTimestamp:   true,
+		TimestampFormat: "15:04:05",
+		ForceColors:     term.IsTerminal(int(os.Stderr.Fd())),
+	}
+	fileFormatter = &logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano, DisableHTMLEscape: true}
+)
+
+func init() {
+	// 输出由 hook 按目标分别处理：终端按级别过滤，文件记录全部级别
+	logger.SetOutput(io.Discard)
+	logger.AddHook(hook{})
+	logger.SetLevel(consoleLevel)
+}
+
+// hook 将日志写入终端和日志文件
+type hook struct{}
+
+func (hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (hook) Fire(entry *logrus.Entry) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if entry.Level <= consoleLevel {
+		line, err := consoleFormatter.Format(entry)
+		if err != nil {
+			return err
+		}
+		_, _ = output.PlainWriter(os.Stderr).Write(line)
+	}
+	if file != nil {
+		line, err := fileFormatter.Format(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetLevel 设置终端输出的日志级别
+func SetLevel(level string) error {
+	level = strings.ToLower(level)
+	if !slices.Contains(Levels, level) {
+		return i18n.Errorf("无效的日志级别: %s (可用: %s)", level, strings.Join(Levels, ", "))
+	}
+	parsed, _ := logrus.ParseLevel(level)
+
+	mu.Lock()
+	defer mu.Unlock()
+	consoleLevel = parsed
+	updateLevel()
+	return nil
+}
+
+// Level 返回终端输出的日志级别
+func Level() string {
+	mu.Lock()
+	defer mu.Unlock()
+	if consoleLevel == logrus.WarnLevel {
+		return LevelWarn
+	}
+	return consoleLevel.String()
+}
+
+// SetFile 设置日志文件（追加写入），path 为空时关闭日志文件
+func SetFile(path string) error {
+	var f *os.File
+	if path != "" {
+		var err error
+		f, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return i18n.Errorf("打开日志文件失败: %w", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if file != nil {
+		_ = file.Close()
+	}
+	file, filePath = f, path
+	updateLevel()
+	return nil
+}
+
+// File 返回当前的日志文件路径，未设置时返回空字符串
+func File() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return filePath
+}
+
+// updateLevel 设置了日志文件时记录全部级别，否则只生成终端需要输出的级别
+func updateLevel() {
+	if file != nil {
+		logger.SetLevel(logrus.DebugLevel)
+	} else {
+		logger.SetLevel(consoleLevel)
+	}
+}
+
+// enabled 指定级别的日志是否会被输出（终端或文件）
+func enabled(level logrus.Level) bool {
+	return logger.IsLevelEnabled(level)
+}
+
+// Debug 记录调试日志
+func Debug(msg string, fields Fields) {
+	logger.WithFields(fields).Debug(msg)
+}
+
+// Info 记录一般日志
+func Info(msg string, fields Fields) {
+	logger.WithFields(fields).Info(msg)
+}
+
+// Warn 记录警告日志
+func Warn(msg string, fields Fields) {
+	logger.WithFields(fields).Warn(msg)
+}
+
+// Request 记录一次请求：protocol 为 http / websocket / spdy，status 为 0 表示未收到响应
+func Request(protocol, method, rawURL string, status int, start time.Time, err error) {
+	if !enabled(logrus.DebugLevel) {
+		return
+	}
+	fields := Fields{
+		"protocol": protocol,
+		"method":   method,
+		"url":      RedactURL(rawURL),
+		"duration": time.Since(start).Round(time.Millisecond).String(),
+	}
+	if status != 0 {
+		fields["status"] = status
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	Debug(protocol+" request", fields)
+}
+
+// RedactURL 隐藏 URL 中的密码
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
+
+// HostOnly 只保留 URL 的协议和主机，用于路径中包含密钥的地址（如 Slack Webhook）
+func HostOnly(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "<invalid url>"
+	}
+	return fmt.Sprintf("%s://%s/...", u.Scheme, u.Host)
+}
@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"net/http"
+	"time"
+)
+
+// transport 记录经过的每个 HTTP 请求
+type transport struct {
+	next     http.RoundTripper
+	hostOnly bool
+}
+
+// Transport 包装 HTTP Transport，以 debug 级别记录每个请求的方法、URL、状态码和耗时
+// 不记录请求头和请求体，Token 等凭据不会写入日志
+func Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{next: next}
+}
+
+// HostOnlyTransport 与 Transport 相同，但只记录 URL 的主机部分，用于路径中包含密钥的请求
+func HostOnlyTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{next: next, hostOnly: true}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	rawURL := req.URL.String()
+	if t.hostOnly {
+		rawURL = HostOnly(rawURL)
+	}
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	Request("http", req.Method, rawURL, status, start, err)
+	return resp, err
+}
@@ -0,0 +1,176 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Advice 一条存活能力建议
+type Advice struct {
+	OK   bool // true 表示该设置有利于存活
+	Text string
+}
+
+// criticalPriorityClasses 内置的系统关键优先级
+var criticalPriorityClasses = map[string]bool{
+	"system-node-critical":    true,
+	"system-cluster-critical": true,
+}
+
+// podTemplateSpec 返回对象中的 Pod spec（Pod 或工作负载的 spec.template.spec），不含 Pod 时返回 nil
+func podTemplateSpec(obj Object) map[string]interface{} {
+	spec, _ := obj["spec"].(map[string]interface{})
+	if spec == nil {
+		return nil
+	}
+	if kind, _ := obj["kind"].(string); kind == "Pod" {
+		return spec
+	}
+	tmpl, _ := spec["template"].(map[string]interface{})
+	podSpec, _ := tmpl["spec"].(map[string]interface{})
+	return podSpec
+}
+
+// Settings 返回对象中与存活相关的设置摘要，记录到部署记录中；不含 Pod 时返回空字符串
+func Settings(obj Object) string {
+	spec := podTemplateSpec(obj)
+	if spec == nil {
+		return ""
+	}
+	var settings []string
+	if pc, _ := spec["priorityClassName"].(string); pc != "" {
+		settings = append(settings, "priorityClass="+pc)
+	}
+	tolerations := "none"
+	if toleratesAll(spec) {
+		tolerations = TolerateAll
+	} else if t, _ := spec["tolerations"].([]interface{}); len(t) > 0 {
+		tolerations = fmt.Sprintf("%d", len(t))
+	}
+	settings = append(settings, "tolerations="+tolerations, "restartPolicy="+restartPolicy(spec), "qos="+qosClass(spec))
+	return strings.Join(settings, ",")
+}
+
+// Survivability 分析对象在节点压力驱逐、污点驱逐和 descheduler 下的存活能力
+// 不含 Pod 的对象返回 nil
+func Survivability(obj Object) []Advice {
+	spec := podTemplateSpec(obj)
+	if spec == nil {
+		return nil
+	}
+	var advice []Advice
+
+	// 控制器：被驱逐后是否重建
+	switch kind, _ := obj["kind"].(string); kind {
+	case "Pod":
+		advice = append(advice,
+			Advice{false, "裸 Pod 被驱逐或节点故障后不会重建（DaemonSet 会由控制器重建）"},
+			Advice{true, "descheduler 默认不驱逐没有控制器的 Pod"})
+	case "DaemonSet":
+		advice = append(advice, Advice{true, "DaemonSet Pod 被驱逐后由控制器在原节点重建，descheduler 默认不驱逐"})
+	default:
+		advice = append(advice, Advice{true, fmt.Sprintf("%s 管理的 Pod 被驱逐后由控制器重建（可能调度到其他节点）", kind)})
+	}
+
+	// 优先级：节点压力驱逐顺序与抢占
+	switch pc, _ := spec["priorityClassName"].(string); {
+	case criticalPriorityClasses[pc]:
+		advice = append(advice, Advice{true, fmt.Sprintf("priorityClass %s：节点压力时最后被驱逐、不会被抢占，descheduler 默认跳过", pc)})
+	case pc != "":
+		advice = append(advice, Advice{true, fmt.Sprintf("priorityClass %s：驱逐顺序取决于其 value", pc)})
+	default:
+		advice = append(advice, Advice{false, "未设置 priorityClassName，可被高优先级 Pod 抢占（--priority-class system-node-critical，可能受 ResourceQuota 限制）"})
+	}
+
+	// QoS：节点内存 / 磁盘压力时的驱逐顺序
+	switch qos := qosClass(spec); qos {
+	case "Guaranteed":
+		advice = append(advice, Advice{true, "QoS Guaranteed：节点压力时在 BestEffort / Burstable 之后驱逐"})
+	default:
+		advice = append(advice, Advice{false, fmt.Sprintf("QoS %s：节点内存 / 磁盘压力时优先被驱逐", qos)})
+	}
+
+	// 污点：NoExecute 污点驱逐与 cordon/drain
+	if toleratesAll(spec) {
+		advice = append(advice, Advice{true, "容忍所有污点：不受 NoExecute 污点（not-ready、unreachable）驱逐，可调度到控制平面节点"})
+	} else {
+		advice = append(advice, Advice{false, "未容忍所有污点：节点 NotReady / Unreachable 约 300 秒后被驱逐，管理员可通过污点驱离"})
+	}
+
+	// 重启策略：容器退出后是否原地重启
+	if rp := restartPolicy(spec); rp == "Always" {
+		advice = append(advice, Advice{true, "restartPolicy Always：容器被 OOM 或退出后原地重启"})
+	} else {
+		advice = append(advice, Advice{false, fmt.Sprintf("restartPolicy %s：容器退出后不再重启", rp)})
+	}
+
+	// 本地存储：descheduler 默认不驱逐使用 hostPath / emptyDir 的 Pod
+	if hasLocalStorage(spec) {
+		advice = append(advice, Advice{true, "使用 hostPath / emptyDir 本地存储，descheduler 默认不驱逐"})
+	}
+	return advice
+}
+
+// restartPolicy 返回 Pod spec 的 restartPolicy，未设置时为 Always
+func restartPolicy(spec map[string]interface{}) string {
+	if rp, _ := spec["restartPolicy"].(string); rp != "" {
+		return rp
+	}
+	return "Always"
+}
+
+// toleratesAll 是否存在容忍所有污点的条目（operator Exists 且不指定 key）
+func toleratesAll(spec map[string]interface{}) bool {
+	tolerations, _ := spec["tolerations"].([]interface{})
+	for _, t := range tolerations {
+		tol, _ := t.(map[string]interface{})
+		key, _ := tol["key"].(string)
+		op, _ := tol["operator"].(string)
+		if key == "" && op == "Exists" {
+			return true
+		}
+	}
+	return false
+}
+
+// qosClass 按容器资源设置推断 QoS 等级（Guaranteed、Burstable、BestEffort）
+func qosClass(spec map[string]interface{}) string {
+	containers, _ := spec["containers"].([]interface{})
+	hasResources, guaranteed := false, len(containers) > 0
+	for _, c := range containers {
+		container, _ := c.(map[string]interface{})
+		resources, _ := container["resources"].(map[string]interface{})
+		requests, _ := resources["requests"].(map[string]interface{})
+		limits, _ := resources["limits"].(map[string]interface{})
+		if len(requests) > 0 || len(limits) > 0 {
+			hasResources = true
+		}
+		for _, res := range []string{"cpu", "memory"} {
+			limit := fmt.Sprint(limits[res])
+			request, ok := requests[res]
+			if limits[res] == nil || (ok && fmt.Sprint(request) != limit) {
+				guaranteed = false
+			}
+		}
+	}
+	switch {
+	case !hasResources:
+		return "BestEffort"
+	case guaranteed:
+		return "Guaranteed"
+	default:
+		return "Burstable"
+	}
+}
+
+// hasLocalStorage 是否挂载了 hostPath 或 emptyDir 卷
+func hasLocalStorage(spec map[string]interface{}) bool {
+	volumes, _ := spec["volumes"].([]interface{})
+	for _, v := range volumes {
+		volume, _ := v.(map[string]interface{})
+		if volume["hostPath"] != nil || volume["emptyDir"] != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+// Package manifest 提供 deploy 命令使用的内置资源模板和自定义清单解析
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"kctl/internal/client/k8s"
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// Object 一个 Kubernetes 资源对象
+type Object map[string]interface{}
+
+// Ref 返回对象的资源定位信息
+func (o Object) Ref() types.ResourceRef {
+	ref := types.ResourceRef{}
+	ref.APIVersion, _ = o["apiVersion"].(string)
+	ref.Kind, _ = o["kind"].(string)
+	if meta, ok := o["metadata"].(map[string]interface{}); ok {
+		ref.Namespace, _ = meta["namespace"].(string)
+		ref.Name, _ = meta["name"].(string)
+	}
+	return ref
+}
+
+// JSON 序列化为 JSON
+func (o Object) JSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}(o))
+}
+
+// YAML 序列化为 YAML（用于 --dry-run 输出）
+func (o Object) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(map[string]interface{}(o)); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Parse 解析 YAML/JSON 清单（支持 --- 分隔的多文档）
+// 未指定 namespace 的命名空间级对象使用 defaultNamespace
+func Parse(data []byte, defaultNamespace string) ([]Object, error) {
+	var objects []Object
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for i := 1; ; i++ {
+		var obj map[string]interface{}
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, i18n.Errorf("解析清单第 %d 个文档失败: %w", i, err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+
+		o := Object(obj)
+		ref := o.Ref()
+		if ref.APIVersion == "" || ref.Kind == "" || ref.Name == "" {
+			return nil, i18n.Errorf("清单第 %d 个文档缺少 apiVersion、kind 或 metadata.name", i)
+		}
+		if ref.Namespace == "" && defaultNamespace != "" && !k8s.IsClusterScoped(ref.Kind) {
+			o["metadata"].(map[string]interface{})["namespace"] = defaultNamespace
+		}
+		objects = append(objects, o)
+	}
+	if len(objects) == 0 {
+		return nil, i18n.Errorf("清单中没有资源")
+	}
+	return objects, nil
+}
@@ -0,0 +1,215 @@
+package manifest
+
+import (
+	"sort"
+	"strings"
+
+	"kctl/internal/i18n"
+)
+
+// Options 内置模板参数
+type Options struct {
+	Name      string
+	Namespace string
+	Image     string
+	Node      string // 固定调度到指定节点（nodeName），为空时由调度器决定
+
+	// 存活相关设置（见 Survivability）
+	PriorityClass string // priorityClassName，为空时不设置
+	Tolerations   string // all（默认，容忍所有污点）或 none
+	RestartPolicy string // Always（默认）、OnFailure、Never
+}
+
+// 容忍策略
+const (
+	TolerateAll  = "all"
+	TolerateNone = "none"
+)
+
+// restartPolicies 可用的 restartPolicy
+var restartPolicies = []string{"Always", "OnFailure", "Never"}
+
+// Validate 校验存活相关设置，DaemonSet 模板只支持 restartPolicy Always
+func (o *Options) Validate(template string) error {
+	switch o.Tolerations {
+	case "", TolerateAll, TolerateNone:
+	default:
+		return i18n.Errorf("无效的容忍策略: %s (可用: all, none)", o.Tolerations)
+	}
+	if o.RestartPolicy != "" {
+		policy := ""
+		for _, rp := range restartPolicies {
+			if strings.EqualFold(rp, o.RestartPolicy) {
+				policy = rp
+			}
+		}
+		if policy == "" {
+			return i18n.Errorf("无效的 restartPolicy: %s (可用: %s)", o.RestartPolicy, strings.Join(restartPolicies, ", "))
+		}
+		o.RestartPolicy = policy
+	}
+	if template == "node-shell" && o.RestartPolicy != "" && o.RestartPolicy != "Always" {
+		return i18n.Errorf("DaemonSet 只支持 restartPolicy Always")
+	}
+	return nil
+}
+
+// Template 内置部署模板
+type Template struct {
+	Name        string
+	Description string
+	Next        string // 部署后的下一步提示，格式参数依次为 namespace 和 name
+	build       func(opts Options) Object
+}
+
+// Build 根据参数生成资源对象
+func (t Template) Build(opts Options) Object {
+	return t.build(opts)
+}
+
+// DefaultImage 内置模板默认镜像（busybox 自带 chroot/nsenter）
+const DefaultImage = "busybox"
+
+// templates 内置模板
+var templates = map[string]Template{
+	"hostpath": {
+		Name:        "hostpath",
+		Description: "特权 Pod，将节点根目录挂载到 /host",
+		Next:        "exec -n %s %s -it -- chroot /host sh",
+		build:       hostPathPod,
+	},
+	"nsenter": {
+		Name:        "nsenter",
+		Description: "特权 + hostPID Pod，通过 nsenter 进入节点 PID 1 的命名空间",
+		Next:        "exec -n %s %s -it -- nsenter -t 1 -m -u -i -n -p -- sh",
+		build:       nsenterPod,
+	},
+	"node-shell": {
+		Name:        "node-shell",
+		Description: "特权 DaemonSet，在每个节点上运行 hostPID/hostNetwork Pod",
+		Next:        "exec -n %[1]s <%[2]s-xxxxx> -it -- nsenter -t 1 -m -u -i -n -p -- sh",
+		build:       nodeShellDaemonSet,
+	},
+}
+
+// Get 获取内置模板
+func Get(name string) (Template, bool) {
+	t, ok := templates[name]
+	return t, ok
+}
+
+// Templates 返回所有内置模板（按名称排序）
+func Templates() []Template {
+	list := make([]Template, 0, len(templates))
+	for _, t := range templates {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// privilegedContainer 特权容器定义
+func privilegedContainer(opts Options, mounts []interface{}) map[string]interface{} {
+	c := map[string]interface{}{
+		"name":            opts.Name,
+		"image":           opts.Image,
+		"imagePullPolicy": "IfNotPresent",
+		"command":         []interface{}{"sh", "-c", "while true; do sleep 3600; done"},
+		"stdin":           true,
+		"tty":             true,
+		"securityContext": map[string]interface{}{"privileged": true},
+	}
+	if len(mounts) > 0 {
+		c["volumeMounts"] = mounts
+	}
+	return c
+}
+
+// hostRootVolume 节点根目录 hostPath 卷及挂载
+func hostRootVolume() (volume, mount map[string]interface{}) {
+	volume = map[string]interface{}{
+		"name":     "host-root",
+		"hostPath": map[string]interface{}{"path": "/", "type": "Directory"},
+	}
+	mount = map[string]interface{}{"name": "host-root", "mountPath": "/host"}
+	return volume, mount
+}
+
+// tolerateAll 容忍所有污点，允许调度到控制平面节点
+func tolerateAll() []interface{} {
+	return []interface{}{map[string]interface{}{"operator": "Exists"}}
+}
+
+// podSpec 通用 Pod spec
+func podSpec(opts Options, spec map[string]interface{}) map[string]interface{} {
+	spec["restartPolicy"] = "Always"
+	if opts.RestartPolicy != "" {
+		spec["restartPolicy"] = opts.RestartPolicy
+	}
+	if opts.Tolerations != TolerateNone {
+		spec["tolerations"] = tolerateAll()
+	}
+	if opts.PriorityClass != "" {
+		spec["priorityClassName"] = opts.PriorityClass
+	}
+	spec["automountServiceAccountToken"] = false
+	if opts.Node != "" {
+		spec["nodeName"] = opts.Node
+	}
+	return spec
+}
+
+func hostPathPod(opts Options) Object {
+	volume, mount := hostRootVolume()
+	return Object{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": opts.Name, "namespace": opts.Namespace},
+		"spec": podSpec(opts, map[string]interface{}{
+			"containers": []interface{}{privilegedContainer(opts, []interface{}{mount})},
+			"volumes":    []interface{}{volume},
+		}),
+	}
+}
+
+func nsenterPod(opts Options) Object {
+	return Object{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": opts.Name, "namespace": opts.Namespace},
+		"spec": podSpec(opts, map[string]interface{}{
+			"hostPID":     true,
+			"hostIPC":     true,
+			"hostNetwork": true,
+			"containers":  []interface{}{privilegedContainer(opts, nil)},
+		}),
+	}
+}
+
+func nodeShellDaemonSet(opts Options) Object {
+	volume, mount := hostRootVolume()
+	labels := map[string]interface{}{"app": opts.Name}
+	spec := podSpec(opts, map[string]interface{}{
+		"hostPID":     true,
+		"hostNetwork": true,
+		"containers":  []interface{}{privilegedContainer(opts, []interface{}{mount})},
+		"volumes":     []interface{}{volume},
+	})
+	// DaemonSet 由控制器调度，指定节点时改用 nodeSelector
+	if opts.Node != "" {
+		delete(spec, "nodeName")
+		spec["nodeSelector"] = map[string]interface{}{"kubernetes.io/hostname": opts.Node}
+	}
+	return Object{
+		"apiVersion": "apps/v1",
+		"kind":       "DaemonSet",
+		"metadata":   map[string]interface{}{"name": opts.Name, "namespace": opts.Namespace},
+		"spec": map[string]interface{}{
+			"selector": map[string]interface{}{"matchLabels": labels},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": labels},
+				"spec":     spec,
+			},
+		},
+	}
+}
@@ -0,0 +1,154 @@
+// Package notify 扫描结束后将高风险结果摘要推送到 Slack Incoming Webhook 或通用 Webhook
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
+)
+
+// 通用 Webhook 的事件类型
+const (
+	EventScanFinished   = "scan.finished"   // 扫描结束且存在 ADMIN / CRITICAL 结果
+	EventPostureChanged = "posture.changed" // kctl watch 发现与上一轮扫描相比的变化
+)
+
+// MaxTopItems 摘要中最多列出的问题数
+const MaxTopItems = 10
+
+// Item 摘要中的一条问题
+type Item struct {
+	Severity config.RiskLevel `json:"severity"`
+	Object   string           `json:"object"` // 如 kube-system/default
+	Title    string           `json:"title"`
+}
+
+// Summary 一次扫描的结果摘要
+type Summary struct {
+	Event     string                   `json:"event"`
+	ScanID    int64                    `json:"scanId,omitempty"`
+	Since     int64                    `json:"since,omitempty"` // posture.changed：比较的上一次扫描 ID
+	Target    string                   `json:"target"`
+	Mode      string                   `json:"mode"`
+	Partial   bool                     `json:"partial"`
+	StartedAt time.Time                `json:"startedAt"`
+	Counts    map[config.RiskLevel]int `json:"counts"`
+	Top       []Item                   `json:"top"`
+	Changes   []Item                   `json:"changes,omitempty"` // posture.changed：新增的风险
+	Text      string                   `json:"text"`              // 与 Slack 消息相同的纯文本摘要
+}
+
+// ShouldNotify 存在 ADMIN 或 CRITICAL 结果时才推送
+func (s *Summary) ShouldNotify() bool {
+	return s.Counts[config.RiskAdmin] > 0 || s.Counts[config.RiskCritical] > 0
+}
+
+// FormatText 生成纯文本摘要（Slack mrkdwn 兼容）
+func (s *Summary) FormatText() string {
+	if s.Event == EventPostureChanged {
+		return s.formatChanges()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "*kctl scan finished* on `%s` (%s", s.Target, s.Mode)
+	if s.ScanID > 0 {
+		fmt.Fprintf(&b, ", scan #%d", s.ScanID)
+	}
+	if s.Partial {
+		b.WriteString(", partial")
+	}
+	b.WriteString(")\n")
+
+	var counts []string
+	for _, level := range []config.RiskLevel{config.RiskAdmin, config.RiskCritical, config.RiskHigh, config.RiskMedium, config.RiskLow} {
+		if n := s.Counts[level]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, level))
+		}
+	}
+	b.WriteString(strings.Join(counts, ", "))
+
+	for _, item := range s.Top {
+		fmt.Fprintf(&b, "\n• [%s] `%s` %s", item.Severity, item.Object, item.Title)
+	}
+	if total := s.Counts[config.RiskAdmin] + s.Counts[config.RiskCritical]; total > len(s.Top) {
+		fmt.Fprintf(&b, "\n… and %d more", total-len(s.Top))
+	}
+	return b.String()
+}
+
+// formatChanges posture.changed 事件的文本
+func (s *Summary) formatChanges() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*kctl posture changed* on `%s` (scan #%d -> #%d): %d new risk(s)", s.Target, s.Since, s.ScanID, len(s.Changes))
+	for i, item := range s.Changes {
+		if i == MaxTopItems {
+			fmt.Fprintf(&b, "\n… and %d more", len(s.Changes)-MaxTopItems)
+			break
+		}
+		fmt.Fprintf(&b, "\n• [%s] `%s` %s", item.Severity, item.Object, item.Title)
+	}
+	return b.String()
+}
+
+// IsSlack 是否为 Slack Incoming Webhook 地址
+func IsSlack(webhook string) bool {
+	u, err := url.Parse(webhook)
+	return err == nil && strings.EqualFold(u.Hostname(), "hooks.slack.com")
+}
+
+// ValidateURL 检查 Webhook 地址，只允许 http / https
+func ValidateURL(webhook string) error {
+	u, err := url.Parse(webhook)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return i18n.Errorf("无效的 Webhook 地址: %s (需要 http:// 或 https://)", webhook)
+	}
+	return nil
+}
+
+// Send 推送摘要：Slack 地址发送 {"text": ...}，其他地址发送完整的 JSON 摘要
+// Event 为空时视为 scan.finished；Webhook 是操作者自己的服务，不经过会话代理
+func Send(ctx context.Context, webhook string, s *Summary) error {
+	if s.Event == "" {
+		s.Event = EventScanFinished
+	}
+	s.Text = s.FormatText()
+
+	var payload any = s
+	if IsSlack(webhook) {
+		payload = map[string]string{"text": s.Text}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.DefaultHTTPTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Webhook 地址的路径中包含密钥，日志只记录主机
+	httpClient := &http.Client{Transport: logging.HostOnlyTransport(nil)}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return i18n.Errorf("webhook 返回 %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
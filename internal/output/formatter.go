@@ -47,6 +47,24 @@ func (f *Formatter) FormatSecurityFlags(flags types.SecurityFlags) string {
 	if flags.HasSecretMount {
 		parts = append(parts, f.formatSecurityFlag("SecretMount"))
 	}
+	if flags.HostNetwork {
+		parts = append(parts, f.formatSecurityFlag("HostNetwork"))
+	}
+	if flags.HostPID {
+		parts = append(parts, f.formatSecurityFlag("HostPID"))
+	}
+	if flags.HostIPC {
+		parts = append(parts, f.formatSecurityFlag("HostIPC"))
+	}
+	if len(flags.Capabilities) > 0 {
+		parts = append(parts, f.formatSecurityFlag("Capabilities"))
+	}
+	if flags.RunAsRoot {
+		parts = append(parts, f.formatSecurityFlag("RunAsRoot"))
+	}
+	if flags.NoSeccomp {
+		parts = append(parts, f.formatSecurityFlag("NoSeccomp"))
+	}
 
 	return strings.Join(parts, " ")
 }
@@ -117,6 +135,29 @@ func (f *Formatter) FormatDuration(seconds int64) string {
 	return fmt.Sprintf("%dh%dm", seconds/3600, (seconds%3600)/60)
 }
 
+// FormatFlagAbbrevs 格式化表格中使用的安全标识简写（不含 SA Token 挂载）
+// 顺序：PRIV PE HP SEC HNET HPID HIPC CAP ROOT NOSC
+func (f *Formatter) FormatFlagAbbrevs(flags types.SecurityFlags) []string {
+	var result []string
+	add := func(set bool, color config.ColorName, abbrev string) {
+		if set {
+			result = append(result, f.printer.Colored(color, abbrev))
+		}
+	}
+
+	add(flags.Privileged, config.ColorRed, "PRIV")
+	add(flags.AllowPrivilegeEscalation, config.ColorYellow, "PE")
+	add(flags.HasHostPath, config.ColorRed, "HP")
+	add(flags.HasSecretMount, config.ColorYellow, "SEC")
+	add(flags.HostNetwork, config.ColorYellow, "HNET")
+	add(flags.HostPID, config.ColorYellow, "HPID")
+	add(flags.HostIPC, config.ColorYellow, "HIPC")
+	add(len(flags.Capabilities) > 0, config.ColorRed, "CAP")
+	add(flags.RunAsRoot, config.ColorYellow, "ROOT")
+	add(flags.NoSeccomp, config.ColorGray, "NOSC")
+	return result
+}
+
 // FormatRiskFlags 格式化风险标记列表
 func (f *Formatter) FormatRiskFlags(record *types.PodRecord) []string {
 	var flags []string
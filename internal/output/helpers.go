@@ -147,9 +147,10 @@ func getKeyPerms(p Printer, permissions []types.PermissionCheck) string {
 			resource = perm.Resource + "/" + perm.Subresource
 		}
 
-		if _, ok := config.CriticalPermissions[resource]; ok {
+		switch config.ResourceRiskLevel(perm.Group, perm.Resource, perm.Subresource) {
+		case config.RiskCritical:
 			critical = append(critical, resource)
-		} else if _, ok := config.HighPermissions[resource]; ok {
+		case config.RiskHigh:
 			high = append(high, resource)
 		}
 	}
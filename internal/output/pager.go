@@ -0,0 +1,401 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+
+	"kctl/internal/i18n"
+)
+
+// PagerEnv 外部分页器命令（如 less -R），未设置时使用内置分页器
+const PagerEnv = "PAGER"
+
+// Capture 缓存一条命令写入 os.Stdout 的输出
+type Capture struct {
+	w    *os.File
+	buf  bytes.Buffer
+	done chan struct{}
+}
+
+// BeginCapture 开始缓存输出，返回的 Capture.Writer() 用于替代 os.Stdout
+func BeginCapture() (*Capture, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	c := &Capture{w: w, done: make(chan struct{})}
+	go func() {
+		defer close(c.done)
+		_, _ = c.buf.ReadFrom(r)
+		_ = r.Close()
+	}()
+	return c, nil
+}
+
+// Writer 返回管道写端
+func (c *Capture) Writer() *os.File {
+	return c.w
+}
+
+// End 关闭管道并返回缓存的全部输出
+func (c *Capture) End() []byte {
+	_ = c.w.Close()
+	<-c.done
+	return c.buf.Bytes()
+}
+
+// Page 将输出写入终端 out；超过终端高度时通过 $PAGER 或内置分页器显示
+func Page(out, in *os.File, data []byte) error {
+	width, height, err := term.GetSize(int(out.Fd()))
+	if err != nil || !term.IsTerminal(int(in.Fd())) || height < 3 {
+		_, err := out.Write(data)
+		return err
+	}
+
+	rows := screenRows(string(data), width)
+	if len(rows) < height {
+		_, err := out.Write(data)
+		return err
+	}
+
+	if cmd := strings.Fields(os.Getenv(PagerEnv)); len(cmd) > 0 {
+		return externalPager(cmd, out, data)
+	}
+
+	pg := &pager{in: in, out: out, rows: rows, width: width, height: height - 1}
+	return pg.run()
+}
+
+// externalPager 通过外部分页器显示，默认开启 less 的颜色支持
+func externalPager(cmd []string, out *os.File, data []byte) error {
+	c := exec.Command(cmd[0], cmd[1:]...)
+	c.Stdin = bytes.NewReader(data)
+	c.Stdout = out
+	c.Stderr = os.Stderr
+	c.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		c.Env = append(c.Env, "LESS=R")
+	}
+	if err := c.Run(); err != nil {
+		_, _ = out.Write(data)
+		return i18n.Errorf("分页器 %s 执行失败: %w", cmd[0], err)
+	}
+	return nil
+}
+
+// pager 内置分页器
+type pager struct {
+	in, out *os.File
+	rows    []string // 按终端宽度折行后的屏幕行（保留颜色）
+	width   int
+	height  int // 内容区高度，最后一行为状态栏
+	top     int
+	query   string
+	message string
+	pending []string // 已读取未处理的按键
+}
+
+func (pg *pager) run() error {
+	state, err := term.MakeRaw(int(pg.in.Fd()))
+	if err != nil {
+		_, err := pg.out.WriteString(strings.Join(pg.rows, "\r\n"))
+		return err
+	}
+	defer func() { _ = term.Restore(int(pg.in.Fd()), state) }()
+
+	// 使用备用屏幕，退出后恢复控制台原有内容
+	pg.write("\x1b[?1049h\x1b[?25l")
+	defer pg.write("\x1b[?25h\x1b[?1049l")
+
+	for {
+		pg.render()
+		key, err := pg.readKey()
+		if err != nil {
+			return nil
+		}
+		pg.message = ""
+		switch key {
+		case "q", "Q", "\x1b", "\x03":
+			return nil
+		case "j", "\r", "\n", "\x0e", "\x1b[B", "\x1bOB":
+			pg.scroll(1)
+		case "k", "y", "\x10", "\x1b[A", "\x1bOA":
+			pg.scroll(-1)
+		case " ", "f", "\x06", "\x1b[6~":
+			pg.scroll(pg.height)
+		case "b", "\x02", "\x1b[5~":
+			pg.scroll(-pg.height)
+		case "d", "\x04":
+			pg.scroll(pg.height / 2)
+		case "u", "\x15":
+			pg.scroll(-pg.height / 2)
+		case "g", "<", "\x1b[H", "\x1b[1~", "\x1bOH":
+			pg.top = 0
+		case "G", ">", "\x1b[F", "\x1b[4~", "\x1bOF":
+			pg.top = pg.maxTop()
+		case "/", "?":
+			if query, ok := pg.readQuery(key); ok {
+				if query != "" {
+					pg.query = query
+				}
+				pg.search(key == "/")
+			}
+		case "n":
+			pg.search(true)
+		case "N":
+			pg.search(false)
+		case "h":
+			pg.message = "q quit  j/k line  space/b page  d/u half page  g/G top/bottom  / ? search  n/N next/prev match"
+		}
+	}
+}
+
+// readKey 读取一个按键，一次读取到多个按键（粘贴、快速输入）时依次返回
+func (pg *pager) readKey() (string, error) {
+	if len(pg.pending) == 0 {
+		buf := make([]byte, 64)
+		n, err := pg.in.Read(buf)
+		if err != nil {
+			return "", err
+		}
+		pg.pending = splitKeys(buf[:n])
+	}
+	key := pg.pending[0]
+	pg.pending = pg.pending[1:]
+	return key, nil
+}
+
+// splitKeys 将输入拆分为按键：转义序列作为一个按键，其余按字符拆分
+func splitKeys(b []byte) []string {
+	var keys []string
+	for len(b) > 0 {
+		n := 1
+		switch {
+		case b[0] == 0x1b && len(b) > 2 && b[1] == 'O':
+			n = 3
+		case b[0] == 0x1b && len(b) > 1 && b[1] == '[':
+			n = 2
+			for n < len(b) && (b[n] < 0x40 || b[n] > 0x7e) {
+				n++
+			}
+			n = min(n+1, len(b))
+		case b[0] >= utf8.RuneSelf:
+			_, n = utf8.DecodeRune(b)
+		}
+		keys = append(keys, string(b[:n]))
+		b = b[n:]
+	}
+	return keys
+}
+
+func (pg *pager) write(s string) {
+	_, _ = pg.out.WriteString(s)
+}
+
+func (pg *pager) maxTop() int {
+	return max(len(pg.rows)-pg.height, 0)
+}
+
+func (pg *pager) scroll(n int) {
+	pg.top = min(max(pg.top+n, 0), pg.maxTop())
+}
+
+// render 重绘内容区和状态栏，匹配搜索词的部分反色显示
+func (pg *pager) render() {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i := 0; i < pg.height; i++ {
+		if idx := pg.top + i; idx < len(pg.rows) {
+			b.WriteString(pg.highlight(pg.rows[idx]))
+		} else {
+			b.WriteString("\x1b[90m~")
+		}
+		b.WriteString("\x1b[0m\x1b[K\r\n")
+	}
+
+	status := pg.message
+	if status == "" {
+		last := min(pg.top+pg.height, len(pg.rows))
+		status = fmt.Sprintf("lines %d-%d/%d (%d%%)", pg.top+1, last, len(pg.rows), last*100/len(pg.rows))
+		if pg.query != "" {
+			status += "  /" + pg.query
+		}
+		status += "  h help, q quit"
+	}
+	b.WriteString("\x1b[7m" + runewidth.Truncate(status, pg.width, "") + "\x1b[0m\x1b[K")
+	pg.write(b.String())
+}
+
+// highlight 包含搜索词的行去除颜色后反色显示匹配部分
+func (pg *pager) highlight(row string) string {
+	if pg.query == "" {
+		return row
+	}
+	plain := StripANSI(row)
+	start, end := matchIndex(plain, pg.query)
+	if start < 0 {
+		return row
+	}
+	var b strings.Builder
+	for start >= 0 {
+		b.WriteString(plain[:start])
+		b.WriteString("\x1b[7m" + plain[start:end] + "\x1b[27m")
+		plain = plain[end:]
+		start, end = matchIndex(plain, pg.query)
+	}
+	b.WriteString(plain)
+	return b.String()
+}
+
+// readQuery 在状态栏读取搜索词，Esc / Ctrl+C 取消
+func (pg *pager) readQuery(prompt string) (string, bool) {
+	var query []rune
+	for {
+		pg.write(fmt.Sprintf("\x1b[%d;1H\x1b[K%s%s\x1b[?25h", pg.height+1, prompt, string(query)))
+		key, err := pg.readKey()
+		pg.write("\x1b[?25l")
+		if err != nil {
+			return "", false
+		}
+		switch {
+		case key == "\r" || key == "\n":
+			return string(query), true
+		case key == "\x1b" || key == "\x03":
+			return "", false
+		case key == "\x7f" || key == "\b":
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+			}
+		case key[0] >= 0x20 && key[0] != 0x7f && utf8.ValidString(key):
+			query = append(query, []rune(key)...)
+		}
+	}
+}
+
+// search 从当前位置向后（forward）或向前查找搜索词，找到时滚动到该行
+func (pg *pager) search(forward bool) {
+	if pg.query == "" {
+		return
+	}
+	step := 1
+	if !forward {
+		step = -1
+	}
+	for i := pg.top + step; i >= 0 && i < len(pg.rows); i += step {
+		if start, _ := matchIndex(StripANSI(pg.rows[i]), pg.query); start >= 0 {
+			pg.top = min(i, pg.maxTop())
+			if i > pg.maxTop() {
+				// 已在最后一页，匹配行可见
+				pg.message = fmt.Sprintf("match at line %d", i+1)
+			}
+			return
+		}
+	}
+	pg.message = "Pattern not found: " + pg.query
+}
+
+// ansiRe CSI 转义序列（颜色、清除行等）
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// StripANSI 去除 ANSI 转义序列（颜色等）
+func StripANSI(s string) string {
+	return ansiRe.ReplaceAllString(s, "")
+}
+
+// matchIndex 查找搜索词，返回第一处匹配在 s 中的起止位置，未找到时返回 -1
+// 搜索词全为小写时忽略大小写
+func matchIndex(s, query string) (int, int) {
+	if query == "" {
+		return -1, -1
+	}
+	if strings.ToLower(query) != query {
+		if i := strings.Index(s, query); i >= 0 {
+			return i, i + len(query)
+		}
+		return -1, -1
+	}
+	for i := range s {
+		if end := foldPrefix(s[i:], query); end >= 0 {
+			return i, i + end
+		}
+	}
+	return -1, -1
+}
+
+// foldPrefix s 以 query 开头（逐字符忽略大小写）时返回匹配部分的长度，否则返回 -1
+func foldPrefix(s, query string) int {
+	n := 0
+	for _, q := range query {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if size == 0 || unicode.ToLower(r) != q {
+			return -1
+		}
+		n += size
+	}
+	return n
+}
+
+// screenRows 将输出按终端宽度折行，折行时延续当前颜色
+func screenRows(text string, width int) []string {
+	text = strings.TrimRight(text, "\n")
+	var rows []string
+	for _, line := range strings.Split(text, "\n") {
+		// 进度条等原地刷新的行只保留最后一次的内容
+		if i := strings.LastIndex(strings.TrimRight(line, "\r"), "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+		line = strings.TrimRight(line, "\r")
+		rows = append(rows, wrapANSI(strings.ReplaceAll(line, "\t", "        "), width)...)
+	}
+	return rows
+}
+
+// wrapANSI 按显示宽度折行，ANSI 转义序列不计宽度；续行以当前生效的 SGR 序列开头
+func wrapANSI(line string, width int) []string {
+	var rows []string
+	var cur, sgr strings.Builder
+	col := 0
+	for i := 0; i < len(line); {
+		if line[i] == 0x1b && i+1 < len(line) && line[i+1] == '[' {
+			j := i + 2
+			for j < len(line) && (line[j] < 0x40 || line[j] > 0x7e) {
+				j++
+			}
+			if j < len(line) {
+				j++
+			}
+			seq := line[i:j]
+			cur.WriteString(seq)
+			if strings.HasSuffix(seq, "m") {
+				if seq == "\x1b[0m" || seq == "\x1b[m" {
+					sgr.Reset()
+				} else {
+					sgr.WriteString(seq)
+				}
+			}
+			i = j
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		w := runewidth.RuneWidth(r)
+		if col+w > width && col > 0 {
+			rows = append(rows, cur.String())
+			cur.Reset()
+			cur.WriteString(sgr.String())
+			col = 0
+		}
+		cur.WriteRune(r)
+		col += w
+		i += size
+	}
+	return append(rows, cur.String())
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/fatih/color"
+)
+
+// NoColorEnv 设置且非空时使用纯文本输出（https://no-color.org）
+const NoColorEnv = "NO_COLOR"
+
+// plain 纯文本输出：不输出 ANSI 颜色，Unicode 符号和框线替换为 ASCII
+// 输出保存到文件或经过 C2 通道等不支持终端控制字符的环境时使用
+var plain atomic.Bool
+
+// colorDefault 启动时 fatih/color 按终端自动检测的结果，关闭纯文本输出时恢复
+var colorDefault = color.NoColor
+
+// SetPlain 开启或关闭纯文本输出（--no-color / set color off / NO_COLOR）
+func SetPlain(enabled bool) {
+	plain.Store(enabled)
+	color.NoColor = enabled || colorDefault
+}
+
+// Plain 是否为纯文本输出
+func Plain() bool {
+	return plain.Load()
+}
+
+// PlainFromEnv 根据 NO_COLOR 环境变量判断是否使用纯文本输出
+func PlainFromEnv() bool {
+	return os.Getenv(NoColorEnv) != ""
+}
+
+// asciiReplacer 输出中使用的 Unicode 符号和框线对应的 ASCII 字符
+var asciiReplacer = strings.NewReplacer(
+	// 框线
+	"═", "=", "─", "-", "━", "-", "║", "|", "│", "|",
+	"╔", "+", "╗", "+", "╚", "+", "╝", "+", "╠", "+", "╣", "+", "╦", "+", "╩", "+", "╬", "+",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+",
+	"█", "#", "▓", "#", "▒", ":", "░", ".",
+	// 状态和列表符号
+	"✓", "+", "✔", "+", "✗", "x", "✘", "x", "⚠️", "!", "⚠", "!", "ℹ️", "i", "ℹ", "i", "💡", "*",
+	"●", "*", "○", "o", "◆", "*", "★", "*", "▶", ">", "•", "*", "·", ".",
+	"🔴", "(!)", "🟡", "(~)", "🟢", "(ok)",
+	// 箭头和标点
+	"→", "->", "←", "<-", "↑", "^", "↓", "v", "—", "--", "–", "-", "…", "...",
+	"“", "\"", "”", "\"", "‘", "'", "’", "'",
+)
+
+// ToASCII 将 Unicode 符号和框线替换为 ASCII，其余字符（如中文）保持不变
+func ToASCII(s string) string {
+	return asciiReplacer.Replace(s)
+}
+
+// sgrRe ANSI 颜色（SGR）序列；光标和清除行等控制序列保留，用于进度刷新
+var sgrRe = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// plainWriter 纯文本输出开启时去除颜色并替换 Unicode 符号后写入
+type plainWriter struct {
+	w io.Writer
+}
+
+// PlainWriter 包装输出，按当前的纯文本设置处理写入的内容
+func PlainWriter(w io.Writer) io.Writer {
+	if _, ok := w.(*plainWriter); ok {
+		return w
+	}
+	return &plainWriter{w: w}
+}
+
+func (pw *plainWriter) Write(p []byte) (int, error) {
+	if !plain.Load() {
+		return pw.w.Write(p)
+	}
+	s := ToASCII(sgrRe.ReplaceAllString(string(p), ""))
+	if _, err := io.WriteString(pw.w, s); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -67,8 +67,8 @@ func NewPrinter() Printer {
 // NewPrinterWithWriter 创建带自定义输出的打印器
 func NewPrinterWithWriter(out, errOut io.Writer) Printer {
 	p := &printer{
-		out:    out,
-		errOut: errOut,
+		out:    PlainWriter(out),
+		errOut: PlainWriter(errOut),
 		colors: initColors(),
 		width:  config.Layout.DefaultWidth,
 	}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"fmt"
+	"sync"
+
+	"kctl/config"
+)
+
+// ProgressPrinter 进度计数器，在同一行原地刷新
+// 例如: [*] 34/120 pods scanned, 3 ADMIN found
+type ProgressPrinter struct {
+	printer   Printer
+	mu        sync.Mutex
+	total     int
+	completed int
+	unit      string
+	quiet     bool
+	started   bool
+}
+
+// NewProgressPrinter 创建进度计数器，unit 为计数单位描述（如 "pods scanned"）
+func NewProgressPrinter(p Printer, total int, unit string) *ProgressPrinter {
+	return &ProgressPrinter{
+		printer: p,
+		total:   total,
+		unit:    unit,
+	}
+}
+
+// WithQuiet 设置静默模式，静默时不输出任何进度
+func (pp *ProgressPrinter) WithQuiet(quiet bool) *ProgressPrinter {
+	pp.quiet = quiet
+	return pp
+}
+
+// Increment 完成数加一并刷新，extra 为附加统计信息（可为空）
+func (pp *ProgressPrinter) Increment(extra string) {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	pp.completed++
+	pp.render(extra)
+}
+
+// Finish 结束进度显示并换行
+func (pp *ProgressPrinter) Finish() {
+	pp.mu.Lock()
+	defer pp.mu.Unlock()
+
+	if pp.quiet || !pp.started {
+		return
+	}
+	pp.printer.Println()
+	pp.started = false
+}
+
+// render 原地刷新当前行
+func (pp *ProgressPrinter) render(extra string) {
+	if pp.quiet {
+		return
+	}
+	pp.started = true
+
+	line := fmt.Sprintf("%d/%d %s", pp.completed, pp.total, pp.unit)
+	if extra != "" {
+		line += ", " + extra
+	}
+	// \r 回到行首，\033[K 清除行尾残留字符
+	pp.printer.Printf("\r\033[K%s %s", pp.printer.Colored(config.ColorBlue, "[*]"), line)
+}
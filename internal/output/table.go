@@ -20,7 +20,7 @@ type TablePrinter struct {
 // NewTablePrinter 创建表格打印器
 func NewTablePrinter() *TablePrinter {
 	return &TablePrinter{
-		writer: os.Stdout,
+		writer: PlainWriter(os.Stdout),
 		style:  config.DefaultTableStyle,
 	}
 }
@@ -28,7 +28,7 @@ func NewTablePrinter() *TablePrinter {
 // NewTablePrinterWithPrinter 创建带 Printer 的表格打印器
 func NewTablePrinterWithPrinter(p Printer) *TablePrinter {
 	return &TablePrinter{
-		writer:  os.Stdout,
+		writer:  PlainWriter(os.Stdout),
 		style:   config.DefaultTableStyle,
 		printer: p,
 	}
@@ -267,7 +267,40 @@ func (t *TablePrinter) PrintServiceAccounts(sas []SARow, showPerms bool, showTok
 	if showPerms {
 		header = append(header, "PERMISSIONS")
 	}
-	t.PrintSimple(header, t.saRowsToStrings(sas, showPerms, false))
+	rows := t.saRowsToStrings(sas, showPerms, false)
+
+	// 有标签或备注时追加 TAGS / NOTE 列
+	annotated := false
+	for _, sa := range sas {
+		annotated = annotated || sa.Tags != "" || sa.Note != ""
+	}
+	if annotated {
+		header = append(header, "TAGS", "NOTE")
+		for i, sa := range sas {
+			rows[i] = append(rows[i], orDash(sa.Tags), orDash(truncateNote(sa.Note)))
+		}
+	}
+	t.PrintSimple(header, rows)
+}
+
+// maxNoteWidth 表格中备注的最大显示长度（字符）
+const maxNoteWidth = 40
+
+// truncateNote 截断过长的备注
+func truncateNote(note string) string {
+	runes := []rune(note)
+	if len(runes) <= maxNoteWidth {
+		return note
+	}
+	return string(runes[:maxNoteWidth-3]) + "..."
+}
+
+// orDash 空字符串显示为 "-"
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
 }
 
 // printSADetailed 详细格式打印 SA（用于显示 Token）
@@ -276,6 +309,12 @@ func (t *TablePrinter) printSADetailed(sas []SARow, showPerms bool) {
 		fmt.Fprintf(t.writer, "\n[%d] %s  %s/%s\n", i+1, sa.Risk, sa.Namespace, sa.Name)
 		fmt.Fprintf(t.writer, "    Token Status: %s\n", sa.TokenStatus)
 		fmt.Fprintf(t.writer, "    Flags:        %s\n", sa.Flags)
+		if sa.Tags != "" {
+			fmt.Fprintf(t.writer, "    Tags:         %s\n", sa.Tags)
+		}
+		if sa.Note != "" {
+			fmt.Fprintf(t.writer, "    Note:         %s\n", sa.Note)
+		}
 		if showPerms && sa.Permissions != "" && sa.Permissions != "-" {
 			fmt.Fprintf(t.writer, "    Permissions:\n")
 			for _, line := range strings.Split(sa.Permissions, "\n") {
@@ -376,6 +415,8 @@ type SARow struct {
 	Flags       string
 	Permissions string
 	Token       string
+	Tags        string // 逗号分隔的标签
+	Note        string
 }
 
 // MountRow 挂载行数据
@@ -0,0 +1,148 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"kctl/internal/i18n"
+)
+
+// Tee 将控制台输出镜像到文件（去除 ANSI 颜色）
+// 每条命令执行期间由 Begin 返回的管道替代 os.Stdout，输出同时转发到终端和文件
+type Tee struct {
+	mu     sync.Mutex
+	path   string
+	file   *os.File
+	escape int // ANSI 转义序列解析状态，序列可能跨越多次写入
+
+	w    *os.File
+	done chan struct{}
+}
+
+// ANSI 转义序列解析状态
+const (
+	ansiNone = iota
+	ansiEsc  // ESC 之后
+	ansiCSI  // ESC [ ... 直到终止字节
+	ansiOSC  // ESC ] ... 直到 BEL 或 ESC \
+)
+
+// OpenTee 打开 tee 文件，appendMode 为 true 时追加，否则覆盖
+func OpenTee(path string, appendMode bool) (*Tee, error) {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendMode {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(path, flags, 0600)
+	if err != nil {
+		return nil, i18n.Errorf("打开 tee 文件失败: %w", err)
+	}
+	t := &Tee{path: path, file: file}
+	t.write([]byte(fmt.Sprintf("# kctl tee started at %s\n", time.Now().Format(time.RFC3339))))
+	return t, nil
+}
+
+// Path 返回 tee 文件路径
+func (t *Tee) Path() string {
+	return t.path
+}
+
+// Begin 开始镜像一条命令的输出：写入命令行，返回替代 os.Stdout 的管道写端
+// 管道中的输出原样转发到 stdout，去除颜色后写入文件
+func (t *Tee) Begin(stdout *os.File, command string) (*os.File, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	t.write([]byte("\nkctl> " + command + "\n"))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 {
+				_, _ = stdout.Write(buf[:n])
+				t.write(buf[:n])
+			}
+			if err != nil {
+				_ = r.Close()
+				return
+			}
+		}
+	}()
+
+	t.mu.Lock()
+	t.w, t.done = w, done
+	t.mu.Unlock()
+	return w, nil
+}
+
+// End 关闭管道并等待输出全部转发，未在镜像中时不做任何操作
+func (t *Tee) End() {
+	t.mu.Lock()
+	w, done := t.w, t.done
+	t.w, t.done = nil, nil
+	t.mu.Unlock()
+	if w == nil {
+		return
+	}
+	_ = w.Close()
+	<-done
+}
+
+// Close 转发剩余输出并关闭文件，之后的输出不再写入文件
+func (t *Tee) Close() error {
+	t.End()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// write 去除 ANSI 转义序列后写入文件，文件已关闭时丢弃
+func (t *Tee) write(data []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.file == nil {
+		return
+	}
+	plain := make([]byte, 0, len(data))
+	for _, b := range data {
+		switch t.escape {
+		case ansiNone:
+			if b == 0x1b {
+				t.escape = ansiEsc
+			} else {
+				plain = append(plain, b)
+			}
+		case ansiEsc:
+			switch b {
+			case '[':
+				t.escape = ansiCSI
+			case ']':
+				t.escape = ansiOSC
+			default:
+				t.escape = ansiNone
+			}
+		case ansiCSI:
+			if b >= 0x40 && b <= 0x7e {
+				t.escape = ansiNone
+			}
+		case ansiOSC:
+			if b == 0x07 {
+				t.escape = ansiNone
+			} else if b == 0x1b {
+				t.escape = ansiEsc
+			}
+		}
+	}
+	_, _ = t.file.Write(plain)
+}
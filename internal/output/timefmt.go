@@ -0,0 +1,78 @@
+package output
+
+import (
+	"fmt"
+	"time"
+)
+
+// AbsoluteTimeLayout 绝对时间显示格式
+const AbsoluteTimeLayout = "2006-01-02 15:04:05 MST"
+
+// TimeFormatter 统一的时间显示：默认相对时间（"3h ago"），可切换为绝对时间，时区可选 UTC 或本地
+type TimeFormatter struct {
+	Absolute bool // 显示绝对时间
+	UTC      bool // 使用 UTC，否则使用本地时区
+}
+
+// Location 返回显示用时区
+func (f TimeFormatter) Location() *time.Location {
+	if f.UTC {
+		return time.UTC
+	}
+	return time.Local
+}
+
+// In 将时间转换到显示时区
+func (f TimeFormatter) In(t time.Time) time.Time {
+	return t.In(f.Location())
+}
+
+// Format 格式化时间，零值返回 "-"
+func (f TimeFormatter) Format(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	if f.Absolute {
+		return f.In(t).Format(AbsoluteTimeLayout)
+	}
+	return RelativeTime(t, time.Now())
+}
+
+// FormatString 格式化 RFC3339 时间字符串，无法解析时原样返回
+func (f TimeFormatter) FormatString(s string) string {
+	if s == "" {
+		return "-"
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return s
+	}
+	return f.Format(t)
+}
+
+// RelativeTime 返回相对时间描述，如 "3h ago"、"in 5d"
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < 0 {
+		return "in " + ShortDuration(-d)
+	}
+	if d < time.Second {
+		return "just now"
+	}
+	return ShortDuration(d) + " ago"
+}
+
+// ShortDuration 返回简短的时长描述（保留最高的一个单位），如 45s、12m、3h、5d、2y
+func ShortDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%dy", int(d.Hours()/24/365))
+}
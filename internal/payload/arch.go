@@ -0,0 +1,97 @@
+package payload
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// 支持的架构（与 GOARCH 命名一致）
+const (
+	ArchAMD64   = "amd64"
+	ArchARM64   = "arm64"
+	ArchARM     = "arm"
+	Arch386     = "386"
+	ArchPPC64LE = "ppc64le"
+	ArchS390X   = "s390x"
+)
+
+// unameArchMap uname -m 输出到 GOARCH 的映射
+var unameArchMap = map[string]string{
+	"x86_64":  ArchAMD64,
+	"amd64":   ArchAMD64,
+	"aarch64": ArchARM64,
+	"arm64":   ArchARM64,
+	"armv8l":  ArchARM,
+	"armv7l":  ArchARM,
+	"armv6l":  ArchARM,
+	"arm":     ArchARM,
+	"i386":    Arch386,
+	"i686":    Arch386,
+	"386":     Arch386,
+	"ppc64le": ArchPPC64LE,
+	"s390x":   ArchS390X,
+}
+
+// NormalizeArch 将 uname -m 或节点 architecture 字段统一为 GOARCH 命名
+// 无法识别时返回空字符串
+func NormalizeArch(s string) string {
+	return unameArchMap[strings.ToLower(strings.TrimSpace(s))]
+}
+
+// Executor 在容器中执行命令的最小接口
+type Executor interface {
+	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
+}
+
+// DetectArch 通过 uname -m 探测容器所在节点的架构
+func DetectArch(ctx context.Context, kubelet Executor, namespace, pod, container string) (string, error) {
+	result, err := kubelet.Exec(ctx, &types.ExecOptions{
+		Namespace: namespace,
+		Pod:       pod,
+		Container: container,
+		Command:   []string{"uname", "-m"},
+		Stdout:    true,
+		Stderr:    true,
+	})
+	if err != nil {
+		return "", i18n.Errorf("执行 uname 失败: %w", err)
+	}
+	if result.Error != "" {
+		return "", i18n.Errorf("执行 uname 失败: %s", result.Error)
+	}
+
+	raw := strings.TrimSpace(result.Stdout)
+	arch := NormalizeArch(raw)
+	if arch == "" {
+		return "", i18n.Errorf("无法识别的架构: %s", raw)
+	}
+	return arch, nil
+}
+
+// Variants 多架构载荷变体，架构 -> 二进制路径或镜像
+type Variants map[string]string
+
+// Select 根据架构选择载荷变体
+func (v Variants) Select(arch string) (string, error) {
+	if normalized := NormalizeArch(arch); normalized != "" {
+		arch = normalized
+	}
+	if path, ok := v[arch]; ok {
+		return path, nil
+	}
+	return "", i18n.Errorf("没有适用于 %s 架构的载荷 (可用: %s)", arch, strings.Join(v.Arches(), ", "))
+}
+
+// Arches 返回已提供的架构列表
+func (v Variants) Arches() []string {
+	var arches []string
+	for arch := range v {
+		arches = append(arches, arch)
+	}
+	sort.Strings(arches)
+	return arches
+}
@@ -0,0 +1,246 @@
+// Package plugin 外部命令插件：~/.kctl/plugins 中的可执行文件，通过 JSON 与 kctl 交互
+//
+// 协议：
+//   - 发现：kctl 启动时以 --kctl-describe 参数运行插件，插件在标准输出打印 Manifest JSON
+//   - 执行：kctl 以控制台中输入的参数运行插件，标准输入写入 Request JSON 后关闭；
+//     插件的标准输出和标准错误直接输出到控制台，非零退出码作为命令的退出码
+//   - output 为 json 时，插件的标准输出为 Response JSON，其中的 findings 保存到数据库
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// ProtocolVersion Request 的协议版本
+const ProtocolVersion = 1
+
+// DescribeFlag 发现插件时传入的参数
+const DescribeFlag = "--kctl-describe"
+
+// DirEnv 覆盖插件目录的环境变量
+const DirEnv = "KCTL_PLUGINS"
+
+// describeTimeout 插件响应 --kctl-describe 的超时时间
+const describeTimeout = 5 * time.Second
+
+// 插件可请求的会话数据（Manifest.Needs）
+const (
+	NeedPods            = "pods"
+	NeedServiceAccounts = "serviceaccounts"
+	NeedFindings        = "findings"
+)
+
+// OutputJSON 插件标准输出为 Response JSON
+const OutputJSON = "json"
+
+// nameRe 插件命令名格式
+var nameRe = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Manifest 插件对 --kctl-describe 的响应
+type Manifest struct {
+	Name        string   `json:"name"` // 为空时使用文件名（去掉 kctl- 前缀和扩展名）
+	Aliases     []string `json:"aliases,omitempty"`
+	Description string   `json:"description"`
+	Usage       string   `json:"usage,omitempty"`
+	ReadOnly    bool     `json:"readOnly,omitempty"`    // 只读本地数据，查看模式下允许执行
+	Destructive bool     `json:"destructive,omitempty"` // 可能修改目标环境，cluster-admin 身份下需要 --confirm
+	Needs       []string `json:"needs,omitempty"`       // 请求中附带的数据: pods, serviceaccounts, findings
+	Output      string   `json:"output,omitempty"`      // json 时标准输出为 Response
+	Flags       []Flag   `json:"flags,omitempty"`       // 选项补全
+	Complete    string   `json:"complete,omitempty"`    // 位置参数补全: pods, namespaces, serviceaccounts, nodes
+}
+
+// Flag 插件选项的补全信息
+type Flag struct {
+	Name        string   `json:"name"`
+	Short       string   `json:"short,omitempty"`
+	Arg         string   `json:"arg,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Values      []string `json:"values,omitempty"`   // 固定取值
+	Complete    string   `json:"complete,omitempty"` // 同 Manifest.Complete
+}
+
+// Plugin 已发现的插件
+type Plugin struct {
+	Path string
+	Manifest
+}
+
+// Needs 是否请求了指定数据
+func (p *Plugin) Needs(what string) bool {
+	for _, n := range p.Manifest.Needs {
+		if n == what {
+			return true
+		}
+	}
+	return false
+}
+
+// Request 执行插件时写入标准输入的数据
+type Request struct {
+	Version         int                           `json:"version"`
+	Command         string                        `json:"command"`
+	Args            []string                      `json:"args"`
+	Session         SessionInfo                   `json:"session"`
+	Pods            []types.PodContainerInfo      `json:"pods,omitempty"`
+	ServiceAccounts []*types.ServiceAccountRecord `json:"serviceAccounts,omitempty"`
+	Findings        []*types.FindingRecord        `json:"findings,omitempty"`
+}
+
+// SessionInfo 会话的连接信息，插件可用于直接访问 Kubelet / API Server
+type SessionInfo struct {
+	KubeletIP   string     `json:"kubeletIP"`
+	KubeletPort int        `json:"kubeletPort"`
+	APIServer   string     `json:"apiServer"` // 完整地址，如 https://10.0.0.1:6443
+	Token       string     `json:"token,omitempty"`
+	ClientCert  string     `json:"clientCert,omitempty"` // PEM
+	ClientKey   string     `json:"clientKey,omitempty"`  // PEM
+	CACert      string     `json:"caCert,omitempty"`     // PEM
+	Proxy       string     `json:"proxy,omitempty"`
+	Mode        string     `json:"mode"`
+	DBPath      string     `json:"dbPath,omitempty"` // 使用 --db 时的数据库文件
+	CurrentSA   *CurrentSA `json:"currentSA,omitempty"`
+}
+
+// CurrentSA 当前选中的 SA
+type CurrentSA struct {
+	Namespace      string `json:"namespace"`
+	Name           string `json:"name"`
+	Token          string `json:"token,omitempty"`
+	RiskLevel      string `json:"riskLevel,omitempty"`
+	IsClusterAdmin bool   `json:"isClusterAdmin"`
+}
+
+// Response output 为 json 的插件在标准输出打印的结果
+type Response struct {
+	Output   string                 `json:"output"`             // 输出到控制台的文本
+	Findings []*types.FindingRecord `json:"findings,omitempty"` // 保存到数据库，source 为空时使用插件名
+	Error    string                 `json:"error,omitempty"`    // 非空时命令失败
+}
+
+// Dir 返回插件目录：KCTL_PLUGINS，否则为 ~/.kctl/plugins
+func Dir() string {
+	if dir := os.Getenv(DirEnv); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".kctl", "plugins")
+}
+
+// Discover 发现目录中的插件，目录不存在时返回空；单个插件失败不影响其他插件
+func Discover(dir string) ([]*Plugin, []error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{i18n.Errorf("读取插件目录失败: %w", err)}
+	}
+
+	var plugins []*Plugin
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() || info.Mode()&0o111 == 0 {
+			continue
+		}
+		p, err := describe(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.Name(), err))
+			continue
+		}
+		plugins = append(plugins, p)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins, errs
+}
+
+// describe 运行插件的 --kctl-describe 并解析 Manifest
+func describe(path string) (*Plugin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), describeTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, path, DescribeFlag).Output()
+	if err != nil {
+		return nil, i18n.Errorf("%s 失败: %w", DescribeFlag, err)
+	}
+	p := &Plugin{Path: path}
+	if err := json.Unmarshal(out, &p.Manifest); err != nil {
+		return nil, i18n.Errorf("解析 %s 输出失败: %w", DescribeFlag, err)
+	}
+	if p.Name == "" {
+		base := filepath.Base(path)
+		p.Name = strings.TrimPrefix(strings.TrimSuffix(base, filepath.Ext(base)), "kctl-")
+	}
+	if !nameRe.MatchString(p.Name) {
+		return nil, i18n.Errorf("无效的命令名: %s", p.Name)
+	}
+	if p.Description == "" {
+		p.Description = "插件 " + filepath.Base(path)
+	}
+	return p, nil
+}
+
+// Run 执行插件：标准输入写入 req，标准错误写入 stderr
+// output 为 json 时解析标准输出并返回 Response，否则标准输出直接写入 stdout 并返回 nil
+// 插件以非零退出码结束时返回 *exec.ExitError
+func (p *Plugin) Run(ctx context.Context, req *Request, stdout, stderr io.Writer) (*Response, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, p.Path, req.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(), "KCTL_PLUGIN_PROTOCOL="+fmt.Sprint(ProtocolVersion))
+
+	if p.Output != OutputJSON {
+		cmd.Stdout = stdout
+		return nil, cmd.Run()
+	}
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	runErr := cmd.Run()
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return nil, runErr
+	}
+
+	var resp Response
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		// 非 JSON 输出原样显示，便于排查插件错误
+		_, _ = stdout.Write(out.Bytes())
+		if runErr != nil {
+			return nil, runErr
+		}
+		return nil, i18n.Errorf("解析插件输出失败: %w", err)
+	}
+	return &resp, runErr
+}
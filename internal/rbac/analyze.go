@@ -0,0 +1,253 @@
+package rbac
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"kctl/config"
+	"kctl/pkg/types"
+)
+
+// RuleIssue 最小权限审计发现
+type RuleIssue struct {
+	ID             string // 检查标识，如 wildcard、escalate
+	Severity       config.RiskLevel
+	Binding        string // Kind/name
+	Role           string // Kind/name
+	Title          string
+	Detail         string
+	Recommendation string
+}
+
+// Location 返回 检查标识@绑定，同一 SA 的发现互不覆盖
+func (i RuleIssue) Location() string {
+	return i.ID + "@" + i.Binding
+}
+
+// SAAnalysis 单个 SA 的最小权限审计结果
+type SAAnalysis struct {
+	Namespace string
+	Name      string
+	Bindings  []types.RoleBindingInfo // 直接绑定该 SA 的绑定（不含组绑定）
+	Issues    []RuleIssue
+}
+
+// Level 返回最高的发现严重程度，无发现时为 NONE
+func (a *SAAnalysis) Level() config.RiskLevel {
+	if len(a.Issues) == 0 {
+		return config.RiskNone
+	}
+	return a.Issues[0].Severity
+}
+
+// Recommendations 返回去重后的最小权限建议
+func (a *SAAnalysis) Recommendations() []string {
+	var recs []string
+	for _, issue := range a.Issues {
+		if issue.Recommendation != "" && !slices.Contains(recs, issue.Recommendation) {
+			recs = append(recs, issue.Recommendation)
+		}
+	}
+	return recs
+}
+
+// boundRule 绑定到 SA 的一条规则
+type boundRule struct {
+	binding types.RoleBindingInfo
+	role    types.RoleInfo
+	rule    types.PolicyRule
+}
+
+// AnalyzeServiceAccount 审计直接绑定到 SA 的角色：
+// 通配规则、escalate / bind / impersonate 动词、聚合标签滥用、引用不存在的角色，
+// 以及被其他规则完全覆盖的冗余规则，结果按严重程度排序
+func (p *Policy) AnalyzeServiceAccount(namespace, name string) *SAAnalysis {
+	analysis := &SAAnalysis{Namespace: namespace, Name: name}
+	add := func(issue RuleIssue) {
+		analysis.Issues = append(analysis.Issues, issue)
+	}
+
+	var rules []boundRule
+	for _, binding := range p.Bindings {
+		if !p.bindsServiceAccount(binding, namespace, name) {
+			continue
+		}
+		analysis.Bindings = append(analysis.Bindings, binding)
+		bindingRef := binding.Kind + "/" + binding.Name
+		roleRef := binding.RoleRef.Kind + "/" + binding.RoleRef.Name
+
+		role, ok := p.BoundRole(binding)
+		if !ok {
+			add(RuleIssue{
+				ID: "dangling-binding", Severity: config.RiskMedium, Binding: bindingRef, Role: roleRef,
+				Title:          "绑定引用的角色不存在",
+				Detail:         fmt.Sprintf("%s 引用的 %s 不存在：能创建同名角色的主体即可为该 SA 授予任意权限", bindingRef, roleRef),
+				Recommendation: fmt.Sprintf("删除悬空的绑定 %s", bindingRef),
+			})
+			continue
+		}
+		if len(role.AggregationSelectors) > 0 {
+			add(RuleIssue{
+				ID: "aggregated-role", Severity: config.RiskLow, Binding: bindingRef, Role: roleRef,
+				Title:          "绑定了聚合 ClusterRole",
+				Detail:         fmt.Sprintf("%s 聚合带有 %s 标签的 ClusterRole：能创建 ClusterRole 的主体可借此扩大该 SA 的权限", roleRef, formatSelectors(role.AggregationSelectors)),
+				Recommendation: fmt.Sprintf("用只包含所需规则的专用角色替换聚合角色 %s", roleRef),
+			})
+		}
+		for _, rule := range role.Rules {
+			rules = append(rules, boundRule{binding: binding, role: role, rule: rule})
+		}
+	}
+
+	aggregated := p.aggregatedRoles()
+	for i, br := range rules {
+		bindingRef := br.binding.Kind + "/" + br.binding.Name
+		roleRef := br.role.Kind + "/" + br.role.Name
+		issue := func(id string, severity config.RiskLevel, title, detail, rec string) {
+			add(RuleIssue{ID: id, Severity: severity, Binding: bindingRef, Role: roleRef,
+				Title: title, Detail: detail + "（规则: " + FormatRule(br.rule) + "）", Recommendation: rec})
+		}
+		scope := "命名空间 " + br.binding.Namespace
+		if br.binding.Namespace == "" {
+			scope = "整个集群"
+		}
+
+		switch {
+		case isFullWildcard(br.rule):
+			issue("wildcard", config.RiskCritical, "完全通配规则",
+				"verbs、resources、apiGroups 均为 *，在"+scope+"内等同管理员",
+				fmt.Sprintf("将 %s 中的 * 规则替换为实际使用的资源和动词", roleRef))
+		case hasWildcard(br.rule):
+			issue("wildcard", config.RiskMedium, "通配规则",
+				"规则包含 *，会自动覆盖以后新增的资源或动词",
+				fmt.Sprintf("将 %s 中的 * 替换为明确的资源和动词列表", roleRef))
+		}
+
+		if RuleAllows(br.rule, AccessRequest{Verb: "impersonate", Resource: "users"}) ||
+			RuleAllows(br.rule, AccessRequest{Verb: "impersonate", Resource: "groups"}) ||
+			RuleAllows(br.rule, AccessRequest{Verb: "impersonate", Resource: "serviceaccounts"}) {
+			issue("impersonate", config.RiskCritical, "可以模拟其他身份",
+				"impersonate 允许以任意用户 / 组 / SA 身份访问 API Server（如 system:masters）",
+				"移除 impersonate 动词，或用 resourceNames 限定可模拟的身份")
+		}
+		for _, verb := range []string{"escalate", "bind"} {
+			if RuleAllows(br.rule, AccessRequest{Verb: verb, Resource: "roles", Group: "rbac.authorization.k8s.io"}) ||
+				RuleAllows(br.rule, AccessRequest{Verb: verb, Resource: "clusterroles", Group: "rbac.authorization.k8s.io"}) {
+				issue(verb, config.RiskHigh, "可以使用 "+verb+" 动词",
+					verb+" 绕过 RBAC 的提权检查，可创建或绑定超出自身权限的角色",
+					fmt.Sprintf("移除 %s 中的 %s 动词", roleRef, verb))
+			}
+		}
+		if len(aggregated) > 0 && (RuleAllows(br.rule, AccessRequest{Verb: "create", Resource: "clusterroles", Group: "rbac.authorization.k8s.io"}) ||
+			RuleAllows(br.rule, AccessRequest{Verb: "update", Resource: "clusterroles", Group: "rbac.authorization.k8s.io"}) ||
+			RuleAllows(br.rule, AccessRequest{Verb: "patch", Resource: "clusterroles", Group: "rbac.authorization.k8s.io"})) {
+			issue("aggregation-abuse", config.RiskHigh, "可利用聚合标签注入权限",
+				"可创建 / 修改 ClusterRole：添加聚合标签即可向 "+strings.Join(aggregated, ", ")+" 注入规则",
+				fmt.Sprintf("移除 %s 对 clusterroles 的写权限", roleRef))
+		}
+
+		for j, other := range rules {
+			if i != j && ruleCovers(other.rule, br.rule) && (!ruleCovers(br.rule, other.rule) || j < i) {
+				issue("redundant", config.RiskLow, "规则被其他规则覆盖（未生效）",
+					fmt.Sprintf("已被 %s/%s 的规则 %s 完全覆盖", other.role.Kind, other.role.Name, FormatRule(other.rule)),
+					fmt.Sprintf("删除 %s 中的冗余规则", roleRef))
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(analysis.Issues, func(i, j int) bool {
+		return config.RiskLevelOrder[analysis.Issues[i].Severity] < config.RiskLevelOrder[analysis.Issues[j].Severity]
+	})
+	return analysis
+}
+
+// bindsServiceAccount 绑定是否直接指定了 SA（RoleBinding 中省略的 SA 命名空间为绑定所在命名空间）
+func (p *Policy) bindsServiceAccount(binding types.RoleBindingInfo, namespace, name string) bool {
+	for _, subject := range binding.Subjects {
+		if subject.Kind == "ServiceAccount" && subject.Namespace == "" {
+			subject.Namespace = binding.Namespace
+		}
+		if namesServiceAccount(subject, namespace, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// aggregatedRoles 返回聚合 ClusterRole 名称
+func (p *Policy) aggregatedRoles() []string {
+	var names []string
+	for _, role := range p.Roles {
+		if len(role.AggregationSelectors) > 0 {
+			names = append(names, role.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isFullWildcard verbs、resources、apiGroups 均为 *
+func isFullWildcard(rule types.PolicyRule) bool {
+	return slices.Contains(rule.Verbs, "*") && slices.Contains(rule.Resources, "*") && slices.Contains(rule.APIGroups, "*")
+}
+
+// hasWildcard 规则的任一字段包含 *
+func hasWildcard(rule types.PolicyRule) bool {
+	return slices.Contains(rule.Verbs, "*") || slices.Contains(rule.Resources, "*") ||
+		slices.Contains(rule.APIGroups, "*") || slices.Contains(rule.NonResourceURLs, "*")
+}
+
+// ruleCovers 规则 a 是否完全覆盖规则 b（b 允许的访问 a 都允许）
+func ruleCovers(a, b types.PolicyRule) bool {
+	if len(b.NonResourceURLs) > 0 || len(a.NonResourceURLs) > 0 {
+		return false
+	}
+	if len(a.ResourceNames) > 0 && !subsetOf(b.ResourceNames, a.ResourceNames) {
+		return false
+	}
+	return subsetOf(b.Verbs, a.Verbs) && subsetOf(b.APIGroups, a.APIGroups) && subsetOf(b.Resources, a.Resources)
+}
+
+// subsetOf sub 中的每一项都被 set 包含（set 含 * 时包含一切）
+func subsetOf(sub, set []string) bool {
+	if len(sub) == 0 {
+		return len(set) == 0
+	}
+	for _, v := range sub {
+		if !containsOrWildcard(set, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatRule 格式化规则，如 [get,list] pods,secrets (apps)
+func FormatRule(rule types.PolicyRule) string {
+	verbs := "[" + strings.Join(rule.Verbs, ",") + "]"
+	if len(rule.NonResourceURLs) > 0 {
+		return verbs + " " + strings.Join(rule.NonResourceURLs, ",")
+	}
+	s := verbs + " " + strings.Join(rule.Resources, ",")
+	if groups := strings.Join(rule.APIGroups, ","); groups != "" {
+		s += " (" + groups + ")"
+	}
+	if len(rule.ResourceNames) > 0 {
+		s += " names=" + strings.Join(rule.ResourceNames, ",")
+	}
+	return s
+}
+
+// formatSelectors 格式化聚合标签选择器
+func formatSelectors(selectors []map[string]string) string {
+	var labels []string
+	for _, selector := range selectors {
+		for k, v := range selector {
+			labels = append(labels, k+"="+v)
+		}
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, ", ")
+}
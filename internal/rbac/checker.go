@@ -52,24 +52,29 @@ func GetPermissionInfo(p types.PermissionCheck) (config.PermissionLevel, string)
 }
 
 // matchRule 检查权限是否匹配规则
+// 各字段支持 "*" 及 glob 通配（如 group "*.argoproj.io"），非资源 URL 按字面匹配
 func matchRule(p types.PermissionCheck, rule config.PermissionRiskRule) bool {
 	// 资源匹配
-	if rule.Resource != "*" && rule.Resource != p.Resource {
+	if config.IsNonResourceURL(p.Resource) {
+		if rule.Resource != p.Resource {
+			return false
+		}
+	} else if !config.MatchPattern(rule.Resource, p.Resource) {
 		return false
 	}
 
 	// 操作匹配
-	if rule.Verb != "*" && rule.Verb != p.Verb {
+	if !config.MatchPattern(rule.Verb, p.Verb) {
 		return false
 	}
 
 	// API Group 匹配
-	if rule.Group != "*" && rule.Group != p.Group {
+	if !config.MatchPattern(rule.Group, p.Group) {
 		return false
 	}
 
 	// 子资源匹配
-	if rule.Subresource != "*" && rule.Subresource != p.Subresource {
+	if !config.MatchPattern(rule.Subresource, p.Subresource) {
 		return false
 	}
 
@@ -0,0 +1,244 @@
+package rbac
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"kctl/internal/client/k8s"
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// Policy 集群 RBAC 对象快照，用于反查权限（who-can）
+type Policy struct {
+	Roles    map[string]types.RoleInfo // key 见 roleKey
+	Bindings []types.RoleBindingInfo
+	// Warnings 未能读取的命名空间级对象（Token 只能读取集群级对象时结果不完整）
+	Warnings []string
+}
+
+// roleKey Role 的索引键：ClusterRole 为 ClusterRole/<name>，Role 为 Role/<ns>/<name>
+func roleKey(kind, namespace, name string) string {
+	if kind == "ClusterRole" {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// LoadPolicy 通过 API Server 读取 RBAC 对象
+// ClusterRole / ClusterRoleBinding 读取失败时返回错误，Role / RoleBinding 读取失败只记录警告
+func LoadPolicy(ctx context.Context, client k8s.Client) (*Policy, error) {
+	clusterRoles, err := client.ListClusterRoles(ctx)
+	if err != nil {
+		return nil, i18n.Errorf("获取 ClusterRole 失败: %w", err)
+	}
+	clusterBindings, err := client.ListClusterRoleBindings(ctx)
+	if err != nil {
+		return nil, i18n.Errorf("获取 ClusterRoleBinding 失败: %w", err)
+	}
+
+	policy := &Policy{Roles: make(map[string]types.RoleInfo)}
+	for _, role := range clusterRoles {
+		policy.Roles[roleKey(role.Kind, "", role.Name)] = role
+	}
+	policy.Bindings = append(policy.Bindings, clusterBindings...)
+
+	if roles, err := client.ListRoles(ctx, ""); err != nil {
+		policy.Warnings = append(policy.Warnings, i18n.Tf("获取 Role 失败: %v", err))
+	} else {
+		for _, role := range roles {
+			policy.Roles[roleKey(role.Kind, role.Namespace, role.Name)] = role
+		}
+	}
+	if bindings, err := client.ListRoleBindings(ctx, ""); err != nil {
+		policy.Warnings = append(policy.Warnings, i18n.Tf("获取 RoleBinding 失败: %v", err))
+	} else {
+		policy.Bindings = append(policy.Bindings, bindings...)
+	}
+	return policy, nil
+}
+
+// BoundRole 返回绑定引用的角色，角色不存在时 ok 为 false
+func (p *Policy) BoundRole(binding types.RoleBindingInfo) (types.RoleInfo, bool) {
+	role, ok := p.Roles[roleKey(binding.RoleRef.Kind, binding.Namespace, binding.RoleRef.Name)]
+	return role, ok
+}
+
+// AccessRequest 待反查的访问，Resource 以 / 开头时为非资源 URL
+type AccessRequest struct {
+	Verb        string
+	Resource    string
+	Subresource string
+	Group       string
+	Namespace   string // 为空时统计所有作用域
+}
+
+// ParseAccessRequest 解析 kubectl auth can-i 风格的参数，如
+// create pods、create pods/exec、list deployments.apps、get /metrics
+func ParseAccessRequest(verb, resource, namespace string) AccessRequest {
+	req := AccessRequest{Verb: strings.ToLower(verb), Namespace: namespace}
+	if strings.HasPrefix(resource, "/") {
+		req.Resource = resource
+		return req
+	}
+	resource, req.Subresource, _ = strings.Cut(strings.ToLower(resource), "/")
+	req.Resource, req.Group, _ = strings.Cut(resource, ".")
+	return req
+}
+
+// String 返回 verb resource[/subresource][.group] 形式
+func (r AccessRequest) String() string {
+	resource := r.Resource
+	if r.Group != "" {
+		resource += "." + r.Group
+	}
+	if r.Subresource != "" {
+		resource += "/" + r.Subresource
+	}
+	return r.Verb + " " + resource
+}
+
+// Grant 一条授予访问的绑定关系
+type Grant struct {
+	Subject types.Subject
+	Binding types.RoleBindingInfo
+	Role    types.RoleInfo
+	Rule    types.PolicyRule // 第一条匹配的规则
+}
+
+// Scope 返回授权的作用域：ClusterRoleBinding 为 cluster，RoleBinding 为其命名空间
+func (g Grant) Scope() string {
+	if g.Binding.Namespace == "" {
+		return "cluster"
+	}
+	return g.Binding.Namespace
+}
+
+// WhoCan 返回允许执行 req 的所有 (主体, 绑定)
+// req.Namespace 不为空时只计入该命名空间的 RoleBinding 和所有 ClusterRoleBinding
+func (p *Policy) WhoCan(req AccessRequest) []Grant {
+	nonResource := strings.HasPrefix(req.Resource, "/")
+
+	var grants []Grant
+	for _, binding := range p.Bindings {
+		if binding.Namespace != "" {
+			// 非资源 URL 只能通过 ClusterRoleBinding 授予
+			if nonResource || (req.Namespace != "" && binding.Namespace != req.Namespace) {
+				continue
+			}
+		}
+		role, ok := p.BoundRole(binding)
+		if !ok {
+			continue
+		}
+		rule, ok := matchingRule(role.Rules, req)
+		if !ok {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			if subject.Kind == "ServiceAccount" && subject.Namespace == "" {
+				subject.Namespace = binding.Namespace
+			}
+			grants = append(grants, Grant{Subject: subject, Binding: binding, Role: role, Rule: rule})
+		}
+	}
+	return grants
+}
+
+// Admins 返回拥有全部权限（verbs、resources、apiGroups 均为 *）的主体，
+// 通过 ClusterRoleBinding 授予的为集群管理员，通过 RoleBinding 授予的为命名空间管理员
+func (p *Policy) Admins() []Grant {
+	return p.WhoCan(AccessRequest{Verb: "*", Resource: "*", Group: "*"})
+}
+
+// matchingRule 返回第一条允许 req 的规则
+func matchingRule(rules []types.PolicyRule, req AccessRequest) (types.PolicyRule, bool) {
+	for _, rule := range rules {
+		if RuleAllows(rule, req) {
+			return rule, true
+		}
+	}
+	return types.PolicyRule{}, false
+}
+
+// RuleAllows 判断单条规则是否允许 req（不考虑 resourceNames 限制）
+// req 字段为 * 时要求规则中也是 *
+func RuleAllows(rule types.PolicyRule, req AccessRequest) bool {
+	if !containsOrWildcard(rule.Verbs, req.Verb) {
+		return false
+	}
+	if strings.HasPrefix(req.Resource, "/") {
+		for _, u := range rule.NonResourceURLs {
+			if u == "*" || u == req.Resource || (strings.HasSuffix(u, "*") && strings.HasPrefix(req.Resource, strings.TrimSuffix(u, "*"))) {
+				return true
+			}
+		}
+		return false
+	}
+	if !containsOrWildcard(rule.APIGroups, req.Group) {
+		return false
+	}
+	resource := req.Resource
+	if req.Subresource != "" {
+		resource += "/" + req.Subresource
+		// */exec 形式的规则匹配任意资源的该子资源
+		if slices.Contains(rule.Resources, "*/"+req.Subresource) {
+			return true
+		}
+	}
+	return containsOrWildcard(rule.Resources, resource)
+}
+
+// containsOrWildcard 列表中包含 value 或 *
+func containsOrWildcard(list []string, value string) bool {
+	return slices.Contains(list, "*") || slices.Contains(list, value)
+}
+
+// SubjectString 格式化主体，如 sa:kube-system/default、user:admin、group:system:masters
+func SubjectString(s types.Subject) string {
+	switch s.Kind {
+	case "ServiceAccount":
+		return "sa:" + s.Namespace + "/" + s.Name
+	case "Group":
+		return "group:" + s.Name
+	default:
+		return "user:" + s.Name
+	}
+}
+
+// HeldTokens 返回已持有且未过期的 Token 中属于该主体的 SA
+// 组 system:serviceaccounts[:<ns>] 与 system:authenticated 包含对应的 SA
+func HeldTokens(subject types.Subject, sas []*types.ServiceAccountRecord) []*types.ServiceAccountRecord {
+	var held []*types.ServiceAccountRecord
+	for _, sa := range sas {
+		if sa.Token == "" || sa.IsExpired {
+			continue
+		}
+		if subjectIncludes(subject, sa.Namespace, sa.Name) {
+			held = append(held, sa)
+		}
+	}
+	return held
+}
+
+// subjectIncludes 主体是否包含 SA namespace/name
+func subjectIncludes(subject types.Subject, namespace, name string) bool {
+	if subject.Kind == "Group" {
+		return subject.Name == "system:serviceaccounts" ||
+			subject.Name == "system:authenticated" ||
+			subject.Name == "system:serviceaccounts:"+namespace
+	}
+	return namesServiceAccount(subject, namespace, name)
+}
+
+// namesServiceAccount 主体是否直接指定 SA namespace/name（不含组）
+func namesServiceAccount(subject types.Subject, namespace, name string) bool {
+	switch subject.Kind {
+	case "ServiceAccount":
+		return subject.Namespace == namespace && subject.Name == name
+	case "User":
+		return subject.Name == "system:serviceaccount:"+namespace+":"+name
+	}
+	return false
+}
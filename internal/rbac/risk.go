@@ -91,69 +91,26 @@ func CalculateRiskLevel(permissions []types.PermissionCheck) config.RiskLevel {
 		return config.RiskAdmin
 	}
 
-	// 检查 CRITICAL 权限
+	// 按风险查找表取最高等级（CRITICAL > HIGH > MEDIUM）
+	level := config.RiskNone
 	for _, p := range permissions {
 		if !p.Allowed {
 			continue
 		}
 
-		resource := p.Resource
-		if p.Subresource != "" {
-			resource = p.Resource + "/" + p.Subresource
-		}
-
-		if verbs, ok := config.CriticalPermissions[resource]; ok {
-			for _, v := range verbs {
-				if v == p.Verb || v == "*" {
-					return config.RiskCritical
-				}
-			}
-		}
-	}
-
-	// 检查 HIGH 权限
-	for _, p := range permissions {
-		if !p.Allowed {
-			continue
-		}
-
-		resource := p.Resource
-		if p.Subresource != "" {
-			resource = p.Resource + "/" + p.Subresource
-		}
-
-		if verbs, ok := config.HighPermissions[resource]; ok {
-			for _, v := range verbs {
-				if v == p.Verb || v == "*" {
-					return config.RiskHigh
-				}
-			}
+		l := config.PermissionRiskLevel(p.Group, p.Resource, p.Subresource, p.Verb)
+		if l != config.RiskNone && config.RiskLevelOrder[l] < config.RiskLevelOrder[level] {
+			level = l
 		}
 	}
-
-	// 检查 MEDIUM 权限
-	for _, p := range permissions {
-		if !p.Allowed {
-			continue
-		}
-
-		resource := p.Resource
-		if p.Subresource != "" {
-			resource = p.Resource + "/" + p.Subresource
-		}
-
-		if verbs, ok := config.MediumPermissions[resource]; ok {
-			for _, v := range verbs {
-				if v == p.Verb || v == "*" {
-					return config.RiskMedium
-				}
-			}
-		}
+	if level != config.RiskNone {
+		return level
 	}
 
 	// 检查是否有任何允许的权限
+	// 非资源 URL（/api、/healthz 等）默认对所有已认证用户开放，不计入
 	for _, p := range permissions {
-		if p.Allowed {
+		if p.Allowed && !config.IsNonResourceURL(p.Resource) {
 			return config.RiskLow
 		}
 	}
@@ -0,0 +1,122 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/security"
+)
+
+// htmlTemplate HTML 报告模板，样式内联以便单文件分发
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"severityClass": func(level config.RiskLevel) string { return strings.ToLower(string(level)) },
+	"technique": func(id string) security.AttackTechnique {
+		if t, ok := security.FindAttackTechnique(id); ok {
+			return t
+		}
+		return security.AttackTechnique{ID: id}
+	},
+	"join":     strings.Join,
+	"orDash":   orDash,
+	"time":     formatTime,
+	"tactics":  func() []string { return security.AttackTactics },
+	"numTechs": func() int { return len(security.AttackTechniques) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>kctl Security Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+h1, h2 { border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #d0d7de; padding: 4px 8px; text-align: left; vertical-align: top; font-size: 14px; }
+th { background: #f6f8fa; }
+code { font-size: 13px; }
+.sev { font-weight: bold; white-space: nowrap; }
+.admin, .critical { color: #cf222e; }
+.high { color: #bc4c00; }
+.medium { color: #9a6700; }
+.low { color: #57606a; }
+.matrix td { width: 11%; }
+.matrix td.hit { background: #ffebe9; font-weight: bold; }
+.matrix td.miss { color: #8c959f; }
+</style>
+</head>
+<body>
+<h1>kctl Security Report</h1>
+<table>
+<tr><th>Kubelet</th><td>{{orDash .KubeletIP}}</td></tr>
+<tr><th>API Server</th><td>{{orDash .APIServer}}</td></tr>
+<tr><th>Scan time</th><td>{{time .ScanTime}}</td></tr>
+<tr><th>Generated</th><td>{{time .GeneratedAt}}</td></tr>
+</table>
+
+<h2>Summary</h2>
+<table>
+<tr><th>Severity</th><th>Count</th></tr>
+{{- range .Summary}}
+<tr><td class="sev {{severityClass .Severity}}">{{.Severity}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+<p>{{len .Items}} issue(s), {{len .Techniques}} of {{numTechs}} ATT&amp;CK for Containers technique(s) observed.</p>
+
+<h2>Findings</h2>
+{{- if .Items}}
+<table>
+<tr><th>Severity</th><th>Object</th><th>Issue</th><th>Rule</th><th>ATT&amp;CK</th></tr>
+{{- range .Items}}
+<tr>
+<td class="sev {{severityClass .Severity}}">{{.Severity}}</td>
+<td>{{.Kind}} <code>{{.Object}}</code></td>
+<td>{{.Message}}</td>
+<td><code>{{.Rule}}</code></td>
+<td>{{range .Techniques}}{{with technique .}}<a href="{{.AttackURL}}" title="{{.Name}}">{{.ID}}</a> {{end}}{{else}}-{{end}}</td>
+</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No issues found.</p>
+{{- end}}
+
+<h2>ATT&amp;CK Coverage Matrix</h2>
+<p>Techniques of the MITRE ATT&amp;CK for Containers matrix; highlighted cells were observed, with the number of issues in parentheses.</p>
+<table class="matrix">
+<tr>{{range tactics}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Matrix}}
+<tr>{{range .}}{{if .Technique}}<td class="{{if .Count}}hit{{else}}miss{{end}}"><a href="{{.Technique.AttackURL}}">{{.Technique.ID}}</a> {{.Technique.Name}}{{if .Count}} ({{.Count}}){{end}}</td>{{else}}<td></td>{{end}}{{end}}</tr>
+{{- end}}
+</table>
+
+{{- with .Techniques}}
+<h2>Observed Techniques</h2>
+<table>
+<tr><th>Technique</th><th>Tactics</th><th>Issues</th><th>Rules</th></tr>
+{{- range .}}
+<tr><td><a href="{{.AttackURL}}">{{.ID}}</a> {{.Name}}</td><td>{{join .Tactics ", "}}</td><td>{{.Count}}</td><td>{{range .Rules}}<code>{{.}}</code> {{end}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+<h2>Operations</h2>
+{{- if .Operations}}
+<p>Every exec, port-forward, ephemeral container and created or deleted resource, in order.</p>
+<table>
+<tr><th>Time</th><th>Action</th><th>Target</th><th>Detail</th><th>Identity</th><th>Result</th></tr>
+{{- range .Operations}}
+<tr><td>{{time .Time}}</td><td>{{.Action}}</td><td><code>{{.Target}}</code></td><td>{{with .Detail}}<code>{{.}}</code>{{else}}-{{end}}</td><td>{{orDash .Identity}}</td><td>{{.Result}}{{with .Error}}: {{.}}{{end}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No operations against the cluster were recorded.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteHTML 输出单文件 HTML 报告
+func (r *Report) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, r)
+}
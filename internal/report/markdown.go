@@ -0,0 +1,134 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"kctl/internal/security"
+)
+
+// WriteMarkdown 输出 Markdown 报告
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("# kctl Security Report\n\n")
+	b.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Kubelet | %s |\n", mdCell(orDash(r.KubeletIP)))
+	fmt.Fprintf(&b, "| API Server | %s |\n", mdCell(orDash(r.APIServer)))
+	fmt.Fprintf(&b, "| Scan time | %s |\n", formatTime(r.ScanTime))
+	fmt.Fprintf(&b, "| Generated | %s |\n", formatTime(r.GeneratedAt))
+
+	b.WriteString("\n## Summary\n\n")
+	b.WriteString("| Severity | Count |\n|---|---:|\n")
+	for _, s := range r.Summary() {
+		fmt.Fprintf(&b, "| %s | %d |\n", s.Severity, s.Count)
+	}
+	hits := r.Techniques()
+	fmt.Fprintf(&b, "\n%d issue(s), %d of %d ATT&CK for Containers technique(s) observed.\n",
+		len(r.Items), len(hits), len(security.AttackTechniques))
+
+	b.WriteString("\n## Findings\n\n")
+	if len(r.Items) == 0 {
+		b.WriteString("No issues found.\n")
+	} else {
+		b.WriteString("| Severity | Object | Issue | Rule | ATT&CK |\n|---|---|---|---|---|\n")
+		for _, item := range r.Items {
+			var links []string
+			for _, id := range item.Techniques {
+				links = append(links, mdTechnique(id))
+			}
+			fmt.Fprintf(&b, "| %s | %s `%s` | %s | `%s` | %s |\n",
+				item.Severity, item.Kind, mdCode(item.Object), mdCell(item.Message), mdCode(item.Rule),
+				orDash(strings.Join(links, " ")))
+		}
+	}
+
+	b.WriteString("\n## ATT&CK Coverage Matrix\n\n")
+	b.WriteString("Techniques of the MITRE ATT&CK for Containers matrix; **bold** cells were observed, with the number of issues in parentheses.\n\n")
+	b.WriteString("| " + strings.Join(security.AttackTactics, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat("---|", len(security.AttackTactics)) + "\n")
+	for _, row := range r.Matrix() {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			if cell.Technique == nil {
+				continue
+			}
+			text := cell.Technique.ID + " " + cell.Technique.Name
+			if cell.Count > 0 {
+				text = fmt.Sprintf("**%s (%d)**", text, cell.Count)
+			}
+			cells[i] = text
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	if len(hits) > 0 {
+		b.WriteString("\n## Observed Techniques\n\n")
+		b.WriteString("| Technique | Tactics | Issues | Rules |\n|---|---|---:|---|\n")
+		for _, hit := range hits {
+			rules := make([]string, len(hit.Rules))
+			for i, rule := range hit.Rules {
+				rules[i] = "`" + mdCode(rule) + "`"
+			}
+			fmt.Fprintf(&b, "| [%s %s](%s) | %s | %d | %s |\n",
+				hit.ID, hit.Name, hit.AttackURL(), strings.Join(hit.Tactics, ", "), hit.Count, strings.Join(rules, " "))
+		}
+	}
+
+	b.WriteString("\n## Operations\n\n")
+	if len(r.Operations) == 0 {
+		b.WriteString("No operations against the cluster were recorded.\n")
+	} else {
+		b.WriteString("Every exec, port-forward, ephemeral container and created or deleted resource, in order.\n\n")
+		b.WriteString("| Time | Action | Target | Detail | Identity | Result |\n|---|---|---|---|---|---|\n")
+		for _, op := range r.Operations {
+			result := op.Result
+			if op.Error != "" {
+				result += ": " + op.Error
+			}
+			fmt.Fprintf(&b, "| %s | %s | `%s` | %s | %s | %s |\n",
+				formatTime(op.Time), op.Action, mdCode(op.Target), orDash(mdCode(op.Detail)), mdCell(orDash(op.Identity)), mdCell(result))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mdTechnique 技术 ID 的 Markdown 链接，未知 ID 原样返回
+func mdTechnique(id string) string {
+	if t, ok := security.FindAttackTechnique(id); ok {
+		return "[" + id + "](" + t.AttackURL() + ")"
+	}
+	return id
+}
+
+// mdCell 转义表格单元格中的竖线、换行和 HTML 标签
+func mdCell(s string) string {
+	s = strings.NewReplacer("|", `\|`, "<", "&lt;", ">", "&gt;").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// mdCode 行内代码中不解析 HTML 实体，只转义竖线；反引号无法转义，替换为单引号
+func mdCode(s string) string {
+	s = strings.NewReplacer("|", `\|`, "`", "'").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// formatTime 格式化报告时间，零值显示为 -
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// orDash 空字符串显示为 -
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+// Package report 生成 Markdown / HTML 格式的评估报告：风险概要、问题列表和 MITRE ATT&CK for Containers 覆盖矩阵
+package report
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/security"
+	"kctl/pkg/types"
+)
+
+// Item 报告中的一条问题（SA 风险权限、Pod 安全标识或检查发现）
+type Item struct {
+	Rule        string // 规则 ID，如 rbac/pods/exec、pod/Privileged、finding/etcd/pod-reachable
+	Name        string // 规则名称
+	Description string // 规则说明，可为空
+	Severity    config.RiskLevel
+	Tags        []string
+	Kind        string // 对象类型，如 serviceaccount、pod
+	Object      string // 对象，如 kube-system/default
+	Key         string // 区分同一对象上同一规则的多条问题（如文件路径）
+	Message     string
+	Techniques  []string // ATT&CK 技术 ID
+}
+
+// Report 报告内容
+type Report struct {
+	KubeletIP   string
+	APIServer   string
+	ScanTime    time.Time
+	GeneratedAt time.Time
+	Items       []Item
+	Operations  []*types.OperationRecord // 对集群执行的修改和执行操作（oplog）
+}
+
+// SeverityCount 单个风险等级的问题数
+type SeverityCount struct {
+	Severity config.RiskLevel
+	Count    int
+}
+
+// TechniqueHit 报告中出现的技术及对应的问题数和规则
+type TechniqueHit struct {
+	security.AttackTechnique
+	Count int
+	Rules []string
+}
+
+// MatrixCell 覆盖矩阵的一格，Technique 为空表示该列已无更多技术
+type MatrixCell struct {
+	Technique *security.AttackTechnique
+	Count     int
+}
+
+// SortItems 按风险等级、规则和对象排序
+func (r *Report) SortItems() {
+	sort.SliceStable(r.Items, func(i, j int) bool {
+		a, b := r.Items[i], r.Items[j]
+		if a.Severity != b.Severity {
+			return config.RiskLevelOrder[a.Severity] < config.RiskLevelOrder[b.Severity]
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Object < b.Object
+	})
+}
+
+// Summary 按风险等级统计问题数（不含 NONE）
+func (r *Report) Summary() []SeverityCount {
+	counts := make(map[config.RiskLevel]int)
+	for _, item := range r.Items {
+		counts[item.Severity]++
+	}
+	var summary []SeverityCount
+	for _, level := range []config.RiskLevel{config.RiskAdmin, config.RiskCritical, config.RiskHigh, config.RiskMedium, config.RiskLow} {
+		summary = append(summary, SeverityCount{Severity: level, Count: counts[level]})
+	}
+	return summary
+}
+
+// Techniques 报告中出现的技术，按 AttackTechniques 的顺序排列
+func (r *Report) Techniques() []TechniqueHit {
+	counts := r.techniqueCounts()
+	rules := make(map[string][]string)
+	for _, item := range r.Items {
+		for _, id := range item.Techniques {
+			if !slices.Contains(rules[id], item.Rule) {
+				rules[id] = append(rules[id], item.Rule)
+			}
+		}
+	}
+	var hits []TechniqueHit
+	for _, t := range security.AttackTechniques {
+		if counts[t.ID] > 0 {
+			sort.Strings(rules[t.ID])
+			hits = append(hits, TechniqueHit{AttackTechnique: t, Count: counts[t.ID], Rules: rules[t.ID]})
+		}
+	}
+	return hits
+}
+
+// Matrix 覆盖矩阵：每列一个战术，按行返回，列长度不足的位置为空格
+func (r *Report) Matrix() [][]MatrixCell {
+	counts := r.techniqueCounts()
+	columns := make([][]security.AttackTechnique, len(security.AttackTactics))
+	rows := 0
+	for i, tactic := range security.AttackTactics {
+		columns[i] = security.AttackTacticTechniques(tactic)
+		rows = max(rows, len(columns[i]))
+	}
+	matrix := make([][]MatrixCell, rows)
+	for row := range matrix {
+		matrix[row] = make([]MatrixCell, len(columns))
+		for col, techniques := range columns {
+			if row < len(techniques) {
+				t := techniques[row]
+				matrix[row][col] = MatrixCell{Technique: &t, Count: counts[t.ID]}
+			}
+		}
+	}
+	return matrix
+}
+
+// techniqueCounts 每个技术对应的问题数
+func (r *Report) techniqueCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, item := range r.Items {
+		for _, id := range item.Techniques {
+			counts[id]++
+		}
+	}
+	return counts
+}
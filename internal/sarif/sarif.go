@@ -0,0 +1,226 @@
+// Package sarif 将 SA 风险、Pod 错误配置和检查发现转换为 SARIF 2.1.0，供 GitHub code scanning、DefectDojo 等平台导入
+package sarif
+
+import (
+	"sort"
+	"strings"
+
+	"kctl/config"
+)
+
+// 格式常量
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	ToolName = "kctl"
+	ToolURI  = "https://github.com/kinokopio/kctl"
+)
+
+// Log SARIF 文件
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run 一次工具运行
+type Run struct {
+	Tool    Tool              `json:"tool"`
+	Results []Result          `json:"results"`
+	Props   map[string]string `json:"properties,omitempty"`
+}
+
+// Tool 工具信息
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver 工具驱动，包含全部规则
+type Driver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule 检查规则
+type Rule struct {
+	ID                   string         `json:"id"`
+	Name                 string         `json:"name,omitempty"`
+	ShortDescription     Message        `json:"shortDescription"`
+	FullDescription      *Message       `json:"fullDescription,omitempty"`
+	DefaultConfiguration Configuration  `json:"defaultConfiguration"`
+	Properties           RuleProperties `json:"properties"`
+}
+
+// Configuration 规则默认级别
+type Configuration struct {
+	Level string `json:"level"`
+}
+
+// RuleProperties 规则属性，security-severity 用于 GitHub 的严重程度分级
+type RuleProperties struct {
+	Tags             []string `json:"tags,omitempty"`
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+	Precision        string   `json:"precision,omitempty"`
+}
+
+// Message 文本
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result 一条结果
+type Result struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             Message           `json:"message"`
+	Locations           []Location        `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+// Location 结果位置：集群对象没有源文件，使用 k8s:// 形式的虚拟 URI 和逻辑位置
+type Location struct {
+	PhysicalLocation PhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// PhysicalLocation 物理位置
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           Region           `json:"region"`
+}
+
+// ArtifactLocation 对象 URI
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region 行号，code scanning 要求至少有 startLine
+type Region struct {
+	StartLine int `json:"startLine"`
+}
+
+// LogicalLocation 逻辑位置，如 ServiceAccount、Pod
+type LogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// Level 将风险等级转换为 SARIF 级别
+func Level(level config.RiskLevel) string {
+	switch level {
+	case config.RiskAdmin, config.RiskCritical, config.RiskHigh:
+		return "error"
+	case config.RiskMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// SecuritySeverity 风险等级对应的 CVSS 风格分值（GitHub 按 >=9.0 critical、>=7.0 high、>=4.0 medium 分级）
+func SecuritySeverity(level config.RiskLevel) string {
+	switch level {
+	case config.RiskAdmin, config.RiskCritical:
+		return "9.5"
+	case config.RiskHigh:
+		return "8.0"
+	case config.RiskMedium:
+		return "5.5"
+	default:
+		return "3.0"
+	}
+}
+
+// Builder 逐条添加规则和结果，规则按 ID 去重
+type Builder struct {
+	rules   []Rule
+	index   map[string]int
+	levels  map[string]config.RiskLevel
+	results []Result
+	props   map[string]string
+}
+
+// NewBuilder 创建 Builder，props 写入 run.properties（如 kubeletIP、扫描时间）
+func NewBuilder(props map[string]string) *Builder {
+	return &Builder{index: make(map[string]int), levels: make(map[string]config.RiskLevel), props: props}
+}
+
+// AddRule 注册规则；已存在时只在等级更高时提升规则的默认级别
+func (b *Builder) AddRule(id, name, description string, level config.RiskLevel, tags ...string) {
+	if i, ok := b.index[id]; ok {
+		if config.RiskLevelOrder[level] < config.RiskLevelOrder[b.levels[id]] {
+			b.levels[id] = level
+			b.rules[i].DefaultConfiguration.Level = Level(level)
+			b.rules[i].Properties.SecuritySeverity = SecuritySeverity(level)
+		}
+		return
+	}
+	b.index[id] = len(b.rules)
+	b.levels[id] = level
+	rule := Rule{
+		ID:                   id,
+		Name:                 name,
+		ShortDescription:     Message{Text: name},
+		DefaultConfiguration: Configuration{Level: Level(level)},
+		Properties: RuleProperties{
+			Tags:             append([]string{"security", "kubernetes"}, tags...),
+			SecuritySeverity: SecuritySeverity(level),
+			Precision:        "high",
+		},
+	}
+	if description != "" && description != name {
+		rule.FullDescription = &Message{Text: description}
+	}
+	b.rules = append(b.rules, rule)
+}
+
+// AddResult 添加结果，kind 与 object 组成位置（如 serviceaccount、kube-system/default），规则需先注册
+// key 区分同一对象上同一规则的多条结果（如文件路径），参与指纹计算
+func (b *Builder) AddResult(ruleID string, level config.RiskLevel, message, kind, object, key string) {
+	uri := "k8s://" + kind + "/" + strings.TrimPrefix(object, "/")
+	b.results = append(b.results, Result{
+		RuleID:    ruleID,
+		RuleIndex: b.index[ruleID],
+		Level:     Level(level),
+		Message:   Message{Text: message},
+		Locations: []Location{{
+			PhysicalLocation: PhysicalLocation{
+				ArtifactLocation: ArtifactLocation{URI: uri},
+				Region:           Region{StartLine: 1},
+			},
+			LogicalLocations: []LogicalLocation{{Name: object[strings.LastIndex(object, "/")+1:], FullyQualifiedName: object, Kind: kind}},
+		}},
+		// 同一对象的同一规则在多次导出间保持一致，平台据此去重和跟踪修复状态
+		PartialFingerprints: map[string]string{"kctl/v1": ruleID + "|" + kind + "|" + object + "|" + key},
+	})
+}
+
+// Log 生成 SARIF 文件，规则按 ID 排序
+func (b *Builder) Log() *Log {
+	rules := make([]Rule, len(b.rules))
+	copy(rules, b.rules)
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	index := make(map[string]int, len(rules))
+	for i, r := range rules {
+		index[r.ID] = i
+	}
+	results := make([]Result, len(b.results))
+	for i, r := range b.results {
+		r.RuleIndex = index[r.RuleID]
+		results[i] = r
+	}
+
+	return &Log{
+		Version: Version,
+		Schema:  Schema,
+		Runs: []Run{{
+			Tool:    Tool{Driver: Driver{Name: ToolName, InformationURI: ToolURI, Rules: rules}},
+			Results: results,
+			Props:   b.props,
+		}},
+	}
+}
@@ -0,0 +1,52 @@
+package security
+
+import (
+	"strings"
+
+	"kctl/pkg/types"
+)
+
+// securityAgent 安全 / 监控代理的识别特征（匹配 Pod 名或镜像）
+type securityAgent struct {
+	name     string
+	patterns []string
+}
+
+// securityAgents 常见的运行时安全、EDR 和可观测性代理
+// 这些代理会记录 exec、进程和网络活动，操作时应避开其所在节点或留意其告警
+var securityAgents = []securityAgent{
+	{"Falco", []string{"falco"}},
+	{"Sysdig", []string{"sysdig"}},
+	{"CrowdStrike", []string{"falcon-sensor", "crowdstrike"}},
+	{"Prisma Defender", []string{"twistlock", "prisma-cloud", "defender-ds"}},
+	{"Aqua", []string{"aquasec", "aqua-enforcer", "kube-enforcer"}},
+	{"Tetragon", []string{"tetragon"}},
+	{"Tracee", []string{"tracee"}},
+	{"NeuVector", []string{"neuvector"}},
+	{"StackRox", []string{"stackrox", "rhacs"}},
+	{"Wiz", []string{"wiz-sensor", "wiz-kubernetes"}},
+	{"Lacework", []string{"lacework"}},
+	{"SentinelOne", []string{"sentinelone", "s1-agent"}},
+	{"Trend Micro", []string{"trendmicro", "deepsecurity"}},
+	{"Wazuh", []string{"wazuh"}},
+	{"Datadog", []string{"datadog"}},
+	{"Elastic Agent", []string{"elastic-agent", "auditbeat"}},
+}
+
+// DetectSecurityAgent 根据 Pod 名和容器镜像识别安全代理，未识别时返回空
+func DetectSecurityAgent(pod types.PodContainerInfo) string {
+	candidates := []string{strings.ToLower(pod.PodName)}
+	for _, c := range pod.Containers {
+		candidates = append(candidates, strings.ToLower(c.Image))
+	}
+	for _, agent := range securityAgents {
+		for _, pattern := range agent.patterns {
+			for _, s := range candidates {
+				if strings.Contains(s, pattern) {
+					return agent.name
+				}
+			}
+		}
+	}
+	return ""
+}
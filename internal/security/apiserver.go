@@ -0,0 +1,247 @@
+package security
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"kctl/config"
+)
+
+// APIServerFinding API Server 配置审计发现
+type APIServerFinding struct {
+	ID       string // 检查标识，如 insecure-port、anonymous-api
+	Severity config.RiskLevel
+	Title    string
+	Detail   string
+}
+
+// APIServerProbe 不带凭据访问的端点，返回 200 时产生对应发现
+type APIServerProbe struct {
+	ID       string
+	Path     string
+	Severity config.RiskLevel
+	Title    string
+	Detail   string
+}
+
+// APIServerAnonymousProbes 匿名探测的端点，按危害从低到高排列
+// /version、/healthz 默认对 system:unauthenticated 开放（system:public-info-viewer），只说明匿名认证已开启
+var APIServerAnonymousProbes = []APIServerProbe{
+	{ID: "anonymous-auth", Path: "/version", Severity: config.RiskLow, Title: "匿名认证已开启",
+		Detail: "未认证请求以 system:anonymous 身份交由授权模块处理，RBAC 为 system:unauthenticated 授权不当时可直接访问资源"},
+	{ID: "anonymous-discovery", Path: "/api", Severity: config.RiskMedium, Title: "匿名可访问 API 发现",
+		Detail: "system:discovery 默认只授予已认证用户，匿名可访问说明为 system:unauthenticated 额外授权"},
+	{ID: "anonymous-metrics", Path: "/metrics", Severity: config.RiskMedium, Title: "匿名可读取 /metrics",
+		Detail: "指标中包含资源数量、请求路径、准入插件和 etcd 信息"},
+	{ID: "anonymous-pprof", Path: "/debug/pprof/", Severity: config.RiskMedium, Title: "匿名可访问 /debug/pprof",
+		Detail: "--profiling 开启且匿名可访问，可获取堆栈和内存剖析数据，也可发起高开销的 CPU 剖析"},
+	{ID: "anonymous-namespaces", Path: "/api/v1/namespaces", Severity: config.RiskHigh, Title: "匿名可列出命名空间",
+		Detail: "system:anonymous 或 system:unauthenticated 被授予了资源读取权限"},
+	{ID: "anonymous-pods", Path: "/api/v1/pods", Severity: config.RiskHigh, Title: "匿名可列出 Pod",
+		Detail: "无需凭据即可读取全部 Pod 规格（含环境变量中的凭据）"},
+	{ID: "anonymous-secrets", Path: "/api/v1/secrets", Severity: config.RiskCritical, Title: "匿名可列出 Secret",
+		Detail: "无需凭据即可读取全部 Secret（含 SA Token）"},
+}
+
+// DefaultAdmissionPlugins 未通过 --disable-admission-plugins 禁用时默认启用的准入插件（v1.30）
+var DefaultAdmissionPlugins = []string{
+	"NamespaceLifecycle", "LimitRanger", "ServiceAccount", "TaintNodesByCondition", "PodSecurity",
+	"Priority", "DefaultTolerationSeconds", "DefaultStorageClass", "StorageObjectInUseProtection",
+	"PersistentVolumeClaimResize", "RuntimeClass", "CertificateApproval", "CertificateSigning",
+	"ClusterTrustBundleAttest", "CertificateSubjectRestriction", "DefaultIngressClass",
+	"MutatingAdmissionWebhook", "ValidatingAdmissionPolicy", "ValidatingAdmissionWebhook", "ResourceQuota",
+}
+
+// ParseComponentFlags 解析控制平面组件命令行中的 --key=value / --key value 参数
+func ParseComponentFlags(command []string) map[string]string {
+	flags := make(map[string]string)
+	for i := 0; i < len(command); i++ {
+		arg, ok := strings.CutPrefix(command[i], "--")
+		if !ok {
+			continue
+		}
+		if key, value, ok := strings.Cut(arg, "="); ok {
+			flags[key] = value
+			continue
+		}
+		if i+1 < len(command) && !strings.HasPrefix(command[i+1], "-") {
+			flags[arg] = command[i+1]
+			i++
+			continue
+		}
+		flags[arg] = "true"
+	}
+	return flags
+}
+
+// FindComponentCommand 在 PodList JSON（API Server 或 Kubelet /pods）中查找静态 Pod 组件（如 kube-apiserver）的命令行
+// 按 component 标签或容器名匹配，返回 command + args，未找到时返回 nil
+func FindComponentCommand(podList []byte, component string) []string {
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+			Spec struct {
+				Containers []struct {
+					Name    string   `json:"name"`
+					Command []string `json:"command"`
+					Args    []string `json:"args"`
+				} `json:"containers"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(podList, &list); err != nil {
+		return nil
+	}
+	for _, item := range list.Items {
+		for _, c := range item.Spec.Containers {
+			if item.Metadata.Labels["component"] == component || c.Name == component {
+				return append(slices.Clone(c.Command), c.Args...)
+			}
+		}
+	}
+	return nil
+}
+
+// EffectiveAdmissionPlugins 计算启用的准入插件：默认插件 + --enable-admission-plugins - --disable-admission-plugins
+func EffectiveAdmissionPlugins(flags map[string]string) []string {
+	disabled := splitFlagList(flags["disable-admission-plugins"])
+	var plugins []string
+	for _, plugin := range append(slices.Clone(DefaultAdmissionPlugins), splitFlagList(flags["enable-admission-plugins"])...) {
+		if !slices.Contains(disabled, plugin) && !slices.Contains(plugins, plugin) {
+			plugins = append(plugins, plugin)
+		}
+	}
+	sort.Strings(plugins)
+	return plugins
+}
+
+// admissionMetricRe 准入插件耗时指标中的插件名
+var admissionMetricRe = regexp.MustCompile(`^apiserver_admission_controller_admission_duration_seconds_count\{[^}]*name="([^"]+)"`)
+
+// AdmissionPluginsFromMetrics 从 /metrics 中提取处理过请求的准入插件
+// 只包含启动后实际运行过的插件，未处理过请求的插件不会出现
+func AdmissionPluginsFromMetrics(metrics string) []string {
+	var plugins []string
+	scanner := bufio.NewScanner(strings.NewReader(metrics))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if m := admissionMetricRe.FindStringSubmatch(scanner.Text()); m != nil && !slices.Contains(plugins, m[1]) {
+			plugins = append(plugins, m[1])
+		}
+	}
+	sort.Strings(plugins)
+	return plugins
+}
+
+// AuditAPIServerFlags 审计 kube-apiserver 命令行参数，按严重程度排序
+func AuditAPIServerFlags(flags map[string]string) []APIServerFinding {
+	var findings []APIServerFinding
+	add := func(id string, severity config.RiskLevel, title, detail string) {
+		findings = append(findings, APIServerFinding{ID: id, Severity: severity, Title: title, Detail: detail})
+	}
+
+	if port := flags["insecure-port"]; port != "" && port != "0" {
+		add("insecure-port", config.RiskCritical, "启用了不安全端口",
+			fmt.Sprintf("--insecure-port=%s：该端口不经认证和授权，可直接以管理员身份访问", port))
+	}
+	if slices.Contains(splitFlagList(flags["authorization-mode"]), "AlwaysAllow") {
+		add("authorization-mode", config.RiskCritical, "授权模式包含 AlwaysAllow",
+			"--authorization-mode="+flags["authorization-mode"]+"：任何通过认证的身份都拥有全部权限")
+	}
+	if flags["anonymous-auth"] != "false" {
+		add("anonymous-auth-flag", config.RiskLow, "未关闭匿名认证",
+			"--anonymous-auth 未设置为 false，匿名请求的权限完全取决于 RBAC 配置")
+	}
+	if flags["token-auth-file"] != "" {
+		add("token-auth-file", config.RiskMedium, "使用静态 Token 文件认证",
+			"--token-auth-file="+flags["token-auth-file"]+"：静态 Token 不会过期，读取该文件即可获得其中的身份")
+	}
+	if flags["kubelet-certificate-authority"] == "" {
+		add("kubelet-certificate-authority", config.RiskLow, "未校验 Kubelet 服务证书",
+			"未设置 --kubelet-certificate-authority：API Server 访问 Kubelet（exec、logs）时不验证证书，可被中间人")
+	}
+	if flags["audit-log-path"] == "" && flags["audit-webhook-config-file"] == "" {
+		add("audit-log", config.RiskLow, "未开启审计日志",
+			"未设置 --audit-log-path / --audit-webhook-config-file：API 操作不会留下审计记录")
+	}
+	if flags["profiling"] != "false" {
+		add("profiling", config.RiskLow, "未关闭 profiling",
+			"--profiling 默认开启，有权限的身份可通过 /debug/pprof 获取剖析数据")
+	}
+	if flags["encryption-provider-config"] == "" {
+		add("encryption-at-rest", config.RiskLow, "Secret 未静态加密",
+			"未设置 --encryption-provider-config：etcd 中的 Secret 以明文保存，取得 etcd 访问或备份即可读取")
+	}
+
+	sortAPIServerFindings(findings)
+	return findings
+}
+
+// AuditAdmissionPlugins 审计启用的准入插件，observed 为 true 时插件列表来自 /metrics（可能不完整，只报告确定的问题）
+func AuditAdmissionPlugins(plugins []string, observed bool) []APIServerFinding {
+	var findings []APIServerFinding
+	add := func(id string, severity config.RiskLevel, title, detail string) {
+		findings = append(findings, APIServerFinding{ID: id, Severity: severity, Title: title, Detail: detail})
+	}
+	enabled := func(name string) bool {
+		return slices.Contains(plugins, name)
+	}
+
+	if enabled("AlwaysAdmit") {
+		add("admission-always-admit", config.RiskHigh, "启用了 AlwaysAdmit",
+			"AlwaysAdmit 放行所有请求，已废弃")
+	}
+	if observed {
+		sortAPIServerFindings(findings)
+		return findings
+	}
+
+	if !enabled("NodeRestriction") {
+		add("admission-node-restriction", config.RiskHigh, "未启用 NodeRestriction",
+			"Kubelet 凭据可修改其他节点及其上 Pod 的对象和标签，取得一个节点的凭据即可影响整个集群的调度")
+	}
+	if !enabled("PodSecurity") {
+		add("admission-pod-security", config.RiskHigh, "未启用 PodSecurity",
+			"Pod Security Admission 被禁用，命名空间上的 pod-security 标签不起作用，特权 Pod 只受 Webhook 约束")
+	}
+	if !enabled("ServiceAccount") {
+		add("admission-service-account", config.RiskMedium, "未启用 ServiceAccount",
+			"Pod 引用的 ServiceAccount 不再校验，Token 挂载行为不受控")
+	}
+	if !enabled("AlwaysPullImages") {
+		add("admission-always-pull-images", config.RiskLow, "未启用 AlwaysPullImages",
+			"节点上已拉取的私有镜像可被其他命名空间的 Pod 直接使用（imagePullPolicy: IfNotPresent / Never）")
+	}
+	if !enabled("DenyServiceExternalIPs") {
+		add("admission-external-ips", config.RiskLow, "未启用 DenyServiceExternalIPs",
+			"可创建 Service 的身份可设置任意 externalIPs 劫持集群内流量（CVE-2020-8554）")
+	}
+
+	sortAPIServerFindings(findings)
+	return findings
+}
+
+// sortAPIServerFindings 按严重程度排序
+func sortAPIServerFindings(findings []APIServerFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return config.RiskLevelOrder[findings[i].Severity] < config.RiskLevelOrder[findings[j].Severity]
+	})
+}
+
+// splitFlagList 拆分逗号分隔的参数值
+func splitFlagList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
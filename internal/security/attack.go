@@ -0,0 +1,198 @@
+package security
+
+import (
+	"slices"
+	"strings"
+)
+
+// AttackTechnique MITRE ATT&CK for Containers 技术
+type AttackTechnique struct {
+	ID      string
+	Name    string
+	Tactics []string
+}
+
+// AttackURL 技术的 ATT&CK 页面地址，子技术 T1552.007 对应 /T1552/007/
+func (t AttackTechnique) AttackURL() string {
+	return "https://attack.mitre.org/techniques/" + strings.ReplaceAll(t.ID, ".", "/") + "/"
+}
+
+// AttackTactics ATT&CK for Containers 矩阵的战术，按矩阵列顺序排列
+var AttackTactics = []string{
+	"Initial Access",
+	"Execution",
+	"Persistence",
+	"Privilege Escalation",
+	"Defense Evasion",
+	"Credential Access",
+	"Discovery",
+	"Lateral Movement",
+	"Impact",
+}
+
+// AttackTechniques ATT&CK for Containers 矩阵中的技术（v15），覆盖矩阵按此顺序列出
+var AttackTechniques = []AttackTechnique{
+	{ID: "T1190", Name: "Exploit Public-Facing Application", Tactics: []string{"Initial Access"}},
+	{ID: "T1133", Name: "External Remote Services", Tactics: []string{"Initial Access", "Persistence"}},
+	{ID: "T1078", Name: "Valid Accounts", Tactics: []string{"Initial Access", "Persistence", "Privilege Escalation", "Defense Evasion"}},
+	{ID: "T1609", Name: "Container Administration Command", Tactics: []string{"Execution"}},
+	{ID: "T1610", Name: "Deploy Container", Tactics: []string{"Execution", "Defense Evasion"}},
+	{ID: "T1053.007", Name: "Container Orchestration Job", Tactics: []string{"Execution", "Persistence", "Privilege Escalation"}},
+	{ID: "T1204.003", Name: "Malicious Image", Tactics: []string{"Execution"}},
+	{ID: "T1098.006", Name: "Additional Container Cluster Roles", Tactics: []string{"Persistence", "Privilege Escalation"}},
+	{ID: "T1525", Name: "Implant Internal Image", Tactics: []string{"Persistence"}},
+	{ID: "T1611", Name: "Escape to Host", Tactics: []string{"Privilege Escalation"}},
+	{ID: "T1068", Name: "Exploitation for Privilege Escalation", Tactics: []string{"Privilege Escalation"}},
+	{ID: "T1612", Name: "Build Image on Host", Tactics: []string{"Defense Evasion"}},
+	{ID: "T1562.001", Name: "Impair Defenses: Disable or Modify Tools", Tactics: []string{"Defense Evasion"}},
+	{ID: "T1070", Name: "Indicator Removal", Tactics: []string{"Defense Evasion"}},
+	{ID: "T1550.001", Name: "Use Alternate Authentication Material: Application Access Token", Tactics: []string{"Defense Evasion", "Lateral Movement"}},
+	{ID: "T1110", Name: "Brute Force", Tactics: []string{"Credential Access"}},
+	{ID: "T1528", Name: "Steal Application Access Token", Tactics: []string{"Credential Access"}},
+	{ID: "T1552.001", Name: "Unsecured Credentials: Credentials In Files", Tactics: []string{"Credential Access"}},
+	{ID: "T1552.007", Name: "Unsecured Credentials: Container API", Tactics: []string{"Credential Access"}},
+	{ID: "T1613", Name: "Container and Resource Discovery", Tactics: []string{"Discovery"}},
+	{ID: "T1046", Name: "Network Service Discovery", Tactics: []string{"Discovery"}},
+	{ID: "T1069", Name: "Permission Groups Discovery", Tactics: []string{"Discovery"}},
+	{ID: "T1485", Name: "Data Destruction", Tactics: []string{"Impact"}},
+	{ID: "T1499", Name: "Endpoint Denial of Service", Tactics: []string{"Impact"}},
+	{ID: "T1496", Name: "Resource Hijacking", Tactics: []string{"Impact"}},
+}
+
+// FindAttackTechnique 按 ID 查找技术
+func FindAttackTechnique(id string) (AttackTechnique, bool) {
+	for _, t := range AttackTechniques {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return AttackTechnique{}, false
+}
+
+// attackPermissions 风险查找表资源对应的技术（不区分 API 组）
+var attackPermissions = map[string][]string{
+	"*":                      {"T1609", "T1610", "T1552.007", "T1098.006"},
+	"secrets":                {"T1552.007"},
+	"pods":                   {"T1610", "T1611"},
+	"pods/exec":              {"T1609"},
+	"pods/attach":            {"T1609"},
+	"nodes/proxy":            {"T1609"},
+	"clusterroles":           {"T1098.006"},
+	"clusterrolebindings":    {"T1098.006"},
+	"roles":                  {"T1098.006"},
+	"rolebindings":           {"T1098.006"},
+	"serviceaccounts":        {"T1078", "T1550.001"},
+	"serviceaccounts/token":  {"T1528"},
+	"configmaps":             {"T1552.001"},
+	"deployments":            {"T1610"},
+	"daemonsets":             {"T1610"},
+	"jobs":                   {"T1053.007"},
+	"cronjobs":               {"T1053.007"},
+	"pods/log":               {"T1613"},
+	"persistentvolumes":      {"T1611"},
+	"persistentvolumeclaims": {"T1611"},
+	"networkpolicies":        {"T1562.001"},
+	"ingresses":              {"T1133"},
+	"/*":                     {"T1613"},
+	"/logs":                  {"T1613"},
+	"/metrics":               {"T1613"},
+}
+
+// PermissionTechniques 风险查找表键（如 pods/exec、apps:deployments）对应的技术
+func PermissionTechniques(key string) []string {
+	if _, resource, ok := strings.Cut(key, ":"); ok {
+		key = resource
+	}
+	return attackPermissions[key]
+}
+
+// attackSecurityFlags Pod 安全标识对应的技术
+var attackSecurityFlags = map[string][]string{
+	"Privileged":               {"T1611"},
+	"AllowPrivilegeEscalation": {"T1611"},
+	"HostPath":                 {"T1611"},
+	"HostNetwork":              {"T1611"},
+	"HostPID":                  {"T1611"},
+	"HostIPC":                  {"T1611"},
+	"Capabilities":             {"T1611"},
+	"NoSeccomp":                {"T1611"},
+	"RunAsRoot":                {"T1611"},
+	"SecretMount":              {"T1552.007"},
+	"SATokenMount":             {"T1528"},
+}
+
+// SecurityFlagTechniques Pod 安全标识（config.SecurityFlagConfigs 的键）对应的技术
+func SecurityFlagTechniques(name string) []string {
+	return attackSecurityFlags[name]
+}
+
+// attackFindings 检查发现对应的技术，键为 来源/检查标识，未命中时使用 来源/类别，再退回 来源
+var attackFindings = map[string]string{
+	"hunt":                                  "T1552.001",
+	"hunt/secret-mount":                     "T1552.007",
+	"configmaps":                            "T1552.001",
+	"podspec":                               "T1552.001",
+	"cloud":                                 "T1528",
+	"etcd":                                  "T1552.007",
+	"etcd/pod-reachable":                    "T1046",
+	"webhooks":                              "T1562.001",
+	"rbac":                                  "T1098.006",
+	"rbac/impersonate":                      "T1550.001",
+	"rbac/redundant":                        "",
+	"configz":                               "",
+	"configz/read-only-port":                "T1613",
+	"configz/anonymous-auth":                "T1609",
+	"configz/authorization-mode":            "T1609",
+	"configz/webhook-auth":                  "T1609",
+	"cis":                                   "",
+	"cis/4.2.1":                             "T1609",
+	"cis/4.2.2":                             "T1609",
+	"cis/4.2.4":                             "T1613",
+	"apiserver":                             "",
+	"apiserver/insecure-port":               "T1133",
+	"apiserver/authorization-mode":          "T1078",
+	"apiserver/token-auth-file":             "T1552.001",
+	"apiserver/anonymous-auth":              "T1078",
+	"apiserver/anonymous-discovery":         "T1613",
+	"apiserver/anonymous-metrics":           "T1613",
+	"apiserver/anonymous-pprof":             "T1613",
+	"apiserver/anonymous-namespaces":        "T1613",
+	"apiserver/anonymous-pods":              "T1613",
+	"apiserver/anonymous-secrets":           "T1552.007",
+	"apiserver/admission-pod-security":      "T1610",
+	"apiserver/admission-always-admit":      "T1610",
+	"apiserver/admission-node-restriction":  "T1098.006",
+	"dashboards":                            "T1133",
+	"dashboards/dashboard-secrets":          "T1552.007",
+	"dashboards/jenkins-script-console":     "T1609",
+	"dashboards/kubeflow-no-auth":           "T1610",
+	"dashboards/kubeflow-pipelines-no-auth": "T1610",
+}
+
+// FindingTechniques 检查发现对应的技术，check 为检查标识（位置中 @ 之前的部分），可为空
+// 默认口令登录成功的检查统一对应 Valid Accounts
+func FindingTechniques(source, category, check string) []string {
+	if strings.HasSuffix(check, "-default-password") {
+		return []string{"T1078"}
+	}
+	for _, key := range []string{source + "/" + check, source + "/" + category, source} {
+		if id, ok := attackFindings[key]; ok {
+			if id == "" {
+				return nil
+			}
+			return []string{id}
+		}
+	}
+	return nil
+}
+
+// AttackTacticTechniques 返回属于指定战术的技术，保持 AttackTechniques 的顺序
+func AttackTacticTechniques(tactic string) []AttackTechnique {
+	var techniques []AttackTechnique
+	for _, t := range AttackTechniques {
+		if slices.Contains(t.Tactics, tactic) {
+			techniques = append(techniques, t)
+		}
+	}
+	return techniques
+}
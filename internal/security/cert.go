@@ -0,0 +1,96 @@
+package security
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"kctl/config"
+)
+
+// 证书审计阈值
+const (
+	// CertExpiryWarnDays 证书剩余有效期低于该天数时告警
+	CertExpiryWarnDays = 30
+	// CertLongLivedDays 自签名证书有效期超过该天数视为长期证书
+	CertLongLivedDays = 365
+)
+
+// CertFinding 证书审计发现
+type CertFinding struct {
+	Severity config.RiskLevel
+	Title    string
+	Detail   string
+}
+
+// IsSelfSignedCert 检查证书是否自签名
+// Kubelet 未启用 serverTLSBootstrap 时会生成 "<host>-ca@<ts>" CA 签发的证书，也视为自签名
+func IsSelfSignedCert(cert *x509.Certificate) bool {
+	if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return true
+	}
+	return strings.Contains(cert.Issuer.CommonName, "-ca@")
+}
+
+// IsBootstrappedServingCert 检查证书是否由集群 CA 通过 serverTLSBootstrap 签发
+// 此类证书的 Subject 为 system:node:<name>，组织为 system:nodes
+func IsBootstrappedServingCert(cert *x509.Certificate) bool {
+	if !strings.HasPrefix(cert.Subject.CommonName, "system:node:") {
+		return false
+	}
+	for _, org := range cert.Subject.Organization {
+		if org == "system:nodes" {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditServingCert 审计 Kubelet 服务端证书（chain[0] 为叶子证书）
+func AuditServingCert(chain []*x509.Certificate, now time.Time) []CertFinding {
+	if len(chain) == 0 {
+		return nil
+	}
+	cert := chain[0]
+
+	var findings []CertFinding
+
+	remaining := cert.NotAfter.Sub(now)
+	switch {
+	case remaining <= 0:
+		findings = append(findings, CertFinding{
+			Severity: config.RiskLow,
+			Title:    "证书已过期",
+			Detail:   fmt.Sprintf("已于 %s 过期", cert.NotAfter.Format("2006-01-02")),
+		})
+	case remaining < CertExpiryWarnDays*24*time.Hour:
+		findings = append(findings, CertFinding{
+			Severity: config.RiskLow,
+			Title:    "证书即将过期",
+			Detail: fmt.Sprintf("剩余 %d 天 (%s)，可能未启用证书轮换",
+				int(remaining.Hours()/24), cert.NotAfter.Format("2006-01-02")),
+		})
+	}
+
+	selfSigned := IsSelfSignedCert(cert)
+	validity := cert.NotAfter.Sub(cert.NotBefore)
+	if selfSigned && validity > CertLongLivedDays*24*time.Hour {
+		findings = append(findings, CertFinding{
+			Severity: config.RiskLow,
+			Title:    "长期自签名证书",
+			Detail:   fmt.Sprintf("有效期 %d 天，签发者 %s", int(validity.Hours()/24), cert.Issuer.CommonName),
+		})
+	}
+
+	if selfSigned || !IsBootstrappedServingCert(cert) {
+		findings = append(findings, CertFinding{
+			Severity: config.RiskLow,
+			Title:    "serverTLSBootstrap 可能未启用",
+			Detail:   fmt.Sprintf("证书 Subject 为 %q，非集群 CA 签发的 system:node 证书", cert.Subject.CommonName),
+		})
+	}
+
+	return findings
+}
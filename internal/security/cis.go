@@ -0,0 +1,226 @@
+package security
+
+import (
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+
+	"kctl/config"
+	"kctl/pkg/types"
+)
+
+// CISBenchmark 检查项编号对应的基准版本
+const CISBenchmark = "CIS Kubernetes Benchmark v1.7.0"
+
+// CIS 检查结果
+const (
+	CISPass = "PASS"
+	CISFail = "FAIL"
+	CISWarn = "WARN" // 当前位置无法确定，需要人工确认
+)
+
+// CISCheck 一项基准检查的结果
+type CISCheck struct {
+	ID       string // 基准编号，如 4.2.1
+	Title    string
+	Severity config.RiskLevel // 未通过时的风险等级
+	Result   string
+	Evidence string
+}
+
+// KubeletVantage 从当前位置对 Kubelet 的实际探测结果，未探测的字段为零值
+type KubeletVantage struct {
+	AnonymousStatus int               // 不带凭据请求 /pods 的状态码，0 表示未探测或连接失败
+	ReadOnlyPort    int               // 探测的只读端口
+	ReadOnlyOpen    *bool             // 只读端口 /healthz 是否可访问
+	ServingCert     *x509.Certificate // Kubelet 服务端证书
+}
+
+// CISStrongCipherSuites 基准推荐的 TLS 加密套件（含 TLS 1.3 套件）
+var CISStrongCipherSuites = []string{
+	"TLS_AES_128_GCM_SHA256",
+	"TLS_AES_256_GCM_SHA384",
+	"TLS_CHACHA20_POLY1305_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305",
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305",
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+	"TLS_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_RSA_WITH_AES_256_GCM_SHA384",
+}
+
+// CISKubeletBenchmark 按 CIS 基准第 4.2 节检查 Kubelet
+// cfg 为 /configz 返回的配置，读取失败时为 nil；实际探测结果优先于配置，两者都没有时结果为 WARN
+// 4.2.8 hostname-override、4.2.9 event-qps 只能在节点上确认，不在检查范围内
+func CISKubeletBenchmark(cfg *types.KubeletConfiguration, v KubeletVantage) []CISCheck {
+	var checks []CISCheck
+	add := func(id string, severity config.RiskLevel, title, result, evidence string) {
+		checks = append(checks, CISCheck{ID: id, Title: title, Severity: severity, Result: result, Evidence: evidence})
+	}
+	unknown := "无法读取 /configz"
+
+	// 4.2.1 anonymous-auth
+	title := "--anonymous-auth 设置为 false"
+	switch {
+	case v.AnonymousStatus == 401:
+		add("4.2.1", config.RiskMedium, title, CISPass, "不带凭据请求 /pods 返回 401")
+	case v.AnonymousStatus == 200 || v.AnonymousStatus == 403:
+		add("4.2.1", config.RiskMedium, title, CISFail, fmt.Sprintf("不带凭据请求 /pods 返回 %d，匿名认证已开启", v.AnonymousStatus))
+	case cfg != nil && cfg.Authentication.Anonymous.Enabled != nil:
+		result := CISPass
+		if *cfg.Authentication.Anonymous.Enabled {
+			result = CISFail
+		}
+		add("4.2.1", config.RiskMedium, title, result, fmt.Sprintf("authentication.anonymous.enabled=%t", *cfg.Authentication.Anonymous.Enabled))
+	default:
+		add("4.2.1", config.RiskMedium, title, CISWarn, unknown)
+	}
+
+	// 4.2.2 authorization-mode
+	title = "--authorization-mode 不为 AlwaysAllow"
+	switch {
+	case cfg != nil && cfg.Authorization.Mode != "":
+		result := CISPass
+		if strings.EqualFold(cfg.Authorization.Mode, KubeletAuthzAlwaysAllow) {
+			result = CISFail
+		}
+		add("4.2.2", config.RiskCritical, title, result, "authorization.mode="+cfg.Authorization.Mode)
+	case v.AnonymousStatus == 200:
+		add("4.2.2", config.RiskCritical, title, CISFail, "匿名请求 /pods 返回 200（AlwaysAllow，或 RBAC 为匿名用户授予了 nodes/proxy）")
+	case v.AnonymousStatus == 403:
+		add("4.2.2", config.RiskCritical, title, CISPass, "匿名请求被授权模块拒绝 (403)")
+	default:
+		add("4.2.2", config.RiskCritical, title, CISWarn, unknown)
+	}
+
+	// 4.2.3 client-ca-file
+	title = "--client-ca-file 已设置"
+	if cfg == nil {
+		add("4.2.3", config.RiskLow, title, CISWarn, unknown)
+	} else if cfg.Authentication.X509.ClientCAFile == "" {
+		add("4.2.3", config.RiskLow, title, CISFail, "authentication.x509.clientCAFile 为空")
+	} else {
+		add("4.2.3", config.RiskLow, title, CISPass, "authentication.x509.clientCAFile="+cfg.Authentication.X509.ClientCAFile)
+	}
+
+	// 4.2.4 read-only-port
+	title = "--read-only-port 设置为 0"
+	switch {
+	case cfg != nil && cfg.ReadOnlyPort > 0:
+		add("4.2.4", config.RiskMedium, title, CISFail, fmt.Sprintf("readOnlyPort=%d", cfg.ReadOnlyPort))
+	case v.ReadOnlyOpen != nil && *v.ReadOnlyOpen:
+		add("4.2.4", config.RiskMedium, title, CISFail, fmt.Sprintf("只读端口 %d 可不带凭据访问", v.ReadOnlyPort))
+	case cfg != nil:
+		add("4.2.4", config.RiskMedium, title, CISPass, "readOnlyPort=0")
+	case v.ReadOnlyOpen != nil:
+		add("4.2.4", config.RiskMedium, title, CISPass, fmt.Sprintf("只读端口 %d 无法访问", v.ReadOnlyPort))
+	default:
+		add("4.2.4", config.RiskMedium, title, CISWarn, unknown)
+	}
+
+	// 4.2.5 streaming-connection-idle-timeout
+	title = "--streaming-connection-idle-timeout 不为 0"
+	switch {
+	case cfg == nil || cfg.StreamingConnectionIdleTimeout == "":
+		add("4.2.5", config.RiskLow, title, CISWarn, unknown)
+	case strings.Trim(cfg.StreamingConnectionIdleTimeout, "0hms") == "":
+		add("4.2.5", config.RiskLow, title, CISFail, "streamingConnectionIdleTimeout="+cfg.StreamingConnectionIdleTimeout+"（exec / port-forward 连接永不超时）")
+	default:
+		add("4.2.5", config.RiskLow, title, CISPass, "streamingConnectionIdleTimeout="+cfg.StreamingConnectionIdleTimeout)
+	}
+
+	// 4.2.6 protect-kernel-defaults
+	title = "--protect-kernel-defaults 设置为 true"
+	if cfg == nil {
+		add("4.2.6", config.RiskLow, title, CISWarn, unknown)
+	} else {
+		add("4.2.6", config.RiskLow, title, cisBool(cfg.ProtectKernelDefaults), fmt.Sprintf("protectKernelDefaults=%t", cfg.ProtectKernelDefaults))
+	}
+
+	// 4.2.7 make-iptables-util-chains
+	title = "--make-iptables-util-chains 设置为 true"
+	if cfg == nil || cfg.MakeIPTablesUtilChains == nil {
+		add("4.2.7", config.RiskLow, title, CISWarn, unknown)
+	} else {
+		add("4.2.7", config.RiskLow, title, cisBool(*cfg.MakeIPTablesUtilChains), fmt.Sprintf("makeIPTablesUtilChains=%t", *cfg.MakeIPTablesUtilChains))
+	}
+
+	// 4.2.10 tls-cert-file / tls-private-key-file
+	title = "--tls-cert-file 和 --tls-private-key-file 已设置"
+	switch {
+	case cfg != nil && cfg.TLSCertFile != "" && cfg.TLSPrivateKeyFile != "":
+		add("4.2.10", config.RiskLow, title, CISPass, "tlsCertFile="+cfg.TLSCertFile)
+	case (cfg != nil && cfg.ServerTLSBootstrap) || (v.ServingCert != nil && IsBootstrappedServingCert(v.ServingCert)):
+		add("4.2.10", config.RiskLow, title, CISPass, "服务端证书由集群 CA 签发（serverTLSBootstrap）")
+	case v.ServingCert != nil && IsSelfSignedCert(v.ServingCert):
+		add("4.2.10", config.RiskLow, title, CISFail, "使用 Kubelet 自动生成的自签名证书（issuer "+v.ServingCert.Issuer.CommonName+"）")
+	case cfg != nil:
+		add("4.2.10", config.RiskLow, title, CISFail, "tlsCertFile / tlsPrivateKeyFile 为空")
+	default:
+		add("4.2.10", config.RiskLow, title, CISWarn, unknown)
+	}
+
+	// 4.2.11 rotate-certificates
+	title = "--rotate-certificates 不为 false"
+	if cfg == nil {
+		add("4.2.11", config.RiskLow, title, CISWarn, unknown)
+	} else {
+		add("4.2.11", config.RiskLow, title, cisBool(cfg.RotateCertificates), fmt.Sprintf("rotateCertificates=%t", cfg.RotateCertificates))
+	}
+
+	// 4.2.12 RotateKubeletServerCertificate
+	title = "RotateKubeletServerCertificate 已启用"
+	gateDisabled := false
+	if cfg != nil {
+		enabled, ok := cfg.FeatureGates["RotateKubeletServerCertificate"]
+		gateDisabled = ok && !enabled
+	}
+	switch {
+	case gateDisabled:
+		add("4.2.12", config.RiskLow, title, CISFail, "featureGates.RotateKubeletServerCertificate=false")
+	case cfg != nil && cfg.ServerTLSBootstrap:
+		add("4.2.12", config.RiskLow, title, CISPass, "serverTLSBootstrap=true")
+	case v.ServingCert != nil && IsBootstrappedServingCert(v.ServingCert):
+		add("4.2.12", config.RiskLow, title, CISPass, "服务端证书由集群 CA 签发，可自动轮换")
+	case cfg != nil:
+		add("4.2.12", config.RiskLow, title, CISFail, "serverTLSBootstrap=false，服务端证书不会自动轮换")
+	default:
+		add("4.2.12", config.RiskLow, title, CISWarn, unknown)
+	}
+
+	// 4.2.13 tls-cipher-suites
+	title = "只使用强加密套件"
+	switch {
+	case cfg == nil:
+		add("4.2.13", config.RiskLow, title, CISWarn, unknown)
+	case len(cfg.TLSCipherSuites) == 0:
+		add("4.2.13", config.RiskLow, title, CISFail, "未设置 tlsCipherSuites，使用 Go 默认套件")
+	default:
+		var weak []string
+		for _, suite := range cfg.TLSCipherSuites {
+			if !slices.Contains(CISStrongCipherSuites, suite) {
+				weak = append(weak, suite)
+			}
+		}
+		if len(weak) > 0 {
+			add("4.2.13", config.RiskLow, title, CISFail, "弱加密套件: "+strings.Join(weak, ", "))
+		} else {
+			add("4.2.13", config.RiskLow, title, CISPass, fmt.Sprintf("tlsCipherSuites 共 %d 个，均为强加密套件", len(cfg.TLSCipherSuites)))
+		}
+	}
+
+	return checks
+}
+
+// cisBool 布尔配置项为 true 时通过
+func cisBool(ok bool) string {
+	if ok {
+		return CISPass
+	}
+	return CISFail
+}
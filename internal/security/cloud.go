@@ -0,0 +1,446 @@
+package security
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"kctl/config"
+	"kctl/pkg/types"
+)
+
+// CloudProvider 托管 Kubernetes 的云厂商
+type CloudProvider string
+
+const (
+	CloudEKS CloudProvider = "EKS"
+	CloudGKE CloudProvider = "GKE"
+	CloudAKS CloudProvider = "AKS"
+)
+
+// cloudProviders 识别结果票数相同时的优先顺序
+var cloudProviders = []CloudProvider{CloudEKS, CloudGKE, CloudAKS}
+
+// maxCloudFindingPods 每条云发现中列出的 Pod 数量上限
+const maxCloudFindingPods = 5
+
+// CloudSignals 识别云厂商和执行云检查使用的集群信息，缺失的字段跳过对应检查
+type CloudSignals struct {
+	APIServer       string                     // API Server 地址
+	Issuers         []string                   // SA Token 的签发者 (iss)
+	Nodes           []types.NodeInfo           // API Server 返回的节点（含标签和 providerID）
+	Pods            []types.PodContainerInfo   // Kubelet 返回的 Pod
+	ServiceAccounts []types.ServiceAccountInfo // ServiceAccount 对象（含注解），无 list 权限时为空
+}
+
+// CloudDetection 云厂商识别结果
+type CloudDetection struct {
+	Provider CloudProvider // 未识别时为空
+	Evidence []string
+}
+
+// CloudFinding 云厂商相关的审计发现
+type CloudFinding struct {
+	ID       string // 检查标识，如 eks-irsa-pods
+	Severity config.RiskLevel
+	Title    string
+	Detail   string
+}
+
+// cloudHint 字符串特征到云厂商的映射
+type cloudHint struct {
+	provider CloudProvider
+	pattern  string
+}
+
+var (
+	// apiServerHints API Server 域名后缀
+	apiServerHints = []cloudHint{
+		{CloudEKS, ".eks.amazonaws.com"},
+		{CloudAKS, ".azmk8s.io"},
+	}
+	// issuerHints SA Token 签发者中的特征
+	issuerHints = []cloudHint{
+		{CloudEKS, "oidc.eks."},
+		{CloudGKE, "container.googleapis.com/"},
+		{CloudAKS, ".oic.prod-aks.azure.com"},
+		{CloudAKS, ".azmk8s.io"},
+	}
+	// providerIDHints 节点 spec.providerID 前缀
+	providerIDHints = []cloudHint{
+		{CloudEKS, "aws://"},
+		{CloudGKE, "gce://"},
+		{CloudAKS, "azure://"},
+	}
+	// nodeLabelHints 节点标签前缀
+	nodeLabelHints = []cloudHint{
+		{CloudEKS, "eks.amazonaws.com/"},
+		{CloudGKE, "cloud.google.com/gke-"},
+		{CloudAKS, "kubernetes.azure.com/"},
+	}
+	// kubeSystemPodHints kube-system 中托管组件的 Pod 名前缀
+	kubeSystemPodHints = []cloudHint{
+		{CloudEKS, "aws-node-"},
+		{CloudEKS, "eks-pod-identity-agent-"},
+		{CloudGKE, "gke-metadata-server-"},
+		{CloudGKE, "fluentbit-gke-"},
+		{CloudAKS, "azure-ip-masq-agent-"},
+		{CloudAKS, "cloud-node-manager-"},
+	}
+	// imageHints 托管组件的镜像仓库
+	imageHints = []cloudHint{
+		{CloudEKS, ".dkr.ecr."},
+		{CloudGKE, "gke.gcr.io/"},
+		{CloudAKS, "mcr.microsoft.com/aks/"},
+		{CloudAKS, "mcr.microsoft.com/oss/kubernetes/"},
+	}
+)
+
+// 云身份相关的标签、注解和环境变量
+const (
+	eksRoleAnnotation     = "eks.amazonaws.com/role-arn"
+	gkeGSAAnnotation      = "iam.gke.io/gcp-service-account"
+	gkeMetadataLabel      = "iam.gke.io/gke-metadata-server-enabled"
+	azureClientAnnotation = "azure.workload.identity/client-id"
+	azureUseLabel         = "azure.workload.identity/use"
+	aadPodIdentityLabel   = "aadpodidbinding"
+
+	envAWSRoleARN      = "AWS_ROLE_ARN"
+	envAWSPodIdentity  = "AWS_CONTAINER_CREDENTIALS_FULL_URI"
+	envAzureClientID   = "AZURE_CLIENT_ID"
+	envAzureTokenFile  = "AZURE_FEDERATED_TOKEN_FILE"
+	kubeletConfigPath  = "/etc/kubernetes"
+	metadataServerAddr = "169.254.169.254"
+)
+
+// DetectCloud 根据 API Server 地址、Token 签发者、节点和 Pod 特征识别 EKS / GKE / AKS
+// 命中特征最多的云厂商胜出
+func DetectCloud(s CloudSignals) CloudDetection {
+	evidence := make(map[CloudProvider][]string)
+	seen := make(map[string]bool)
+	add := func(provider CloudProvider, text string) {
+		if !seen[text] {
+			seen[text] = true
+			evidence[provider] = append(evidence[provider], text)
+		}
+	}
+
+	host := strings.ToLower(s.APIServer)
+	for _, h := range apiServerHints {
+		if strings.HasSuffix(host, h.pattern) {
+			add(h.provider, "API Server "+s.APIServer)
+		}
+	}
+	for _, iss := range s.Issuers {
+		for _, h := range issuerHints {
+			if strings.Contains(iss, h.pattern) {
+				add(h.provider, "token issuer "+iss)
+			}
+		}
+	}
+	for _, node := range s.Nodes {
+		for _, h := range providerIDHints {
+			if strings.HasPrefix(node.ProviderID, h.pattern) {
+				add(h.provider, "node providerID "+h.pattern)
+			}
+		}
+		for label := range node.Labels {
+			for _, h := range nodeLabelHints {
+				if strings.HasPrefix(label, h.pattern) {
+					add(h.provider, "node label "+h.pattern+"*")
+				}
+			}
+		}
+	}
+	for _, pod := range s.Pods {
+		if pod.Namespace == "kube-system" {
+			for _, h := range kubeSystemPodHints {
+				if strings.HasPrefix(pod.PodName, h.pattern) {
+					add(h.provider, "pod kube-system/"+h.pattern+"*")
+				}
+			}
+		}
+		for _, c := range pod.Containers {
+			for _, h := range imageHints {
+				if strings.Contains(c.Image, h.pattern) {
+					add(h.provider, "image registry "+h.pattern)
+				}
+			}
+		}
+		switch name := pod.NodeName; {
+		case strings.HasSuffix(name, ".compute.internal"), strings.HasSuffix(name, ".ec2.internal"):
+			add(CloudEKS, "node name (EC2 private DNS)")
+		case strings.HasPrefix(name, "gke-"):
+			add(CloudGKE, "node name gke-*")
+		case strings.HasPrefix(name, "aks-"):
+			add(CloudAKS, "node name aks-*")
+		}
+	}
+
+	var result CloudDetection
+	for _, provider := range cloudProviders {
+		if len(evidence[provider]) > len(result.Evidence) {
+			result = CloudDetection{Provider: provider, Evidence: evidence[provider]}
+		}
+	}
+	return result
+}
+
+// AuditCloud 执行云厂商相关的身份检查
+func AuditCloud(provider CloudProvider, s CloudSignals) []CloudFinding {
+	pods := runningPods(s.Pods)
+	switch provider {
+	case CloudEKS:
+		return auditEKS(pods, s.ServiceAccounts)
+	case CloudGKE:
+		return auditGKE(pods, s.Nodes, s.ServiceAccounts)
+	case CloudAKS:
+		return auditAKS(pods, s.ServiceAccounts)
+	}
+	return nil
+}
+
+// auditEKS IRSA / EKS Pod Identity 与节点 IAM 角色暴露
+func auditEKS(pods []types.PodContainerInfo, sas []types.ServiceAccountInfo) []CloudFinding {
+	var findings []CloudFinding
+
+	var irsa, podIdentity, nodeRole, hostNetwork []string
+	for _, pod := range pods {
+		ref := pod.Namespace + "/" + pod.PodName
+		switch {
+		case podEnv(pod, envAWSRoleARN) != "":
+			irsa = append(irsa, ref+" -> "+podEnv(pod, envAWSRoleARN))
+		case podEnv(pod, envAWSPodIdentity) != "":
+			podIdentity = append(podIdentity, ref)
+		default:
+			nodeRole = append(nodeRole, ref)
+		}
+		if pod.SecurityFlags.HostNetwork {
+			hostNetwork = append(hostNetwork, ref)
+		}
+	}
+
+	if roles := annotatedServiceAccounts(sas, eksRoleAnnotation); len(roles) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "eks-irsa-sa",
+			Severity: config.RiskMedium,
+			Title:    fmt.Sprintf("%d 个 SA 通过 IRSA 绑定 IAM 角色", len(roles)),
+			Detail:   summarize(roles),
+		})
+	}
+	if len(irsa) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "eks-irsa-pods",
+			Severity: config.RiskMedium,
+			Title:    fmt.Sprintf("%d 个 Pod 使用 IRSA 角色，可读取 Web Identity Token 换取 AWS 凭据", len(irsa)),
+			Detail:   summarize(irsa),
+		})
+	}
+	if len(podIdentity) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "eks-pod-identity",
+			Severity: config.RiskMedium,
+			Title:    fmt.Sprintf("%d 个 Pod 使用 EKS Pod Identity 获取 AWS 凭据", len(podIdentity)),
+			Detail:   summarize(podIdentity),
+		})
+	}
+	if len(nodeRole) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "eks-node-role",
+			Severity: config.RiskMedium,
+			Title:    fmt.Sprintf("%d 个 Pod 未使用 IRSA，可能通过 IMDS 继承节点 IAM 角色", len(nodeRole)),
+			Detail:   "IMDSv2 hop limit 为 1 时容器网络无法访问，需确认节点角色权限是否过大: " + summarize(nodeRole),
+		})
+	}
+	if len(hostNetwork) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "eks-imds-hostnetwork",
+			Severity: config.RiskHigh,
+			Title:    fmt.Sprintf("%d 个 hostNetwork Pod 可直接访问 IMDS 获取节点 IAM 角色凭据", len(hostNetwork)),
+			Detail:   "hop limit 不限制 hostNetwork Pod: " + summarize(hostNetwork),
+		})
+	}
+	return findings
+}
+
+// auditGKE Workload Identity 与节点 GCP 服务账号暴露
+func auditGKE(pods []types.PodContainerInfo, nodes []types.NodeInfo, sas []types.ServiceAccountInfo) []CloudFinding {
+	var findings []CloudFinding
+
+	enabled, known := gkeWorkloadIdentity(pods, nodes)
+	var all, hostNetwork []string
+	for _, pod := range pods {
+		ref := pod.Namespace + "/" + pod.PodName
+		all = append(all, ref)
+		if pod.SecurityFlags.HostNetwork {
+			hostNetwork = append(hostNetwork, ref)
+		}
+	}
+
+	switch {
+	case known && !enabled && len(all) > 0:
+		findings = append(findings, CloudFinding{
+			ID:       "gke-no-workload-identity",
+			Severity: config.RiskHigh,
+			Title:    "节点池未启用 Workload Identity，所有 Pod 可从元数据服务器获取节点 GCP 服务账号 Token",
+			Detail:   fmt.Sprintf("%d 个 Pod 受影响，需确认节点服务账号（默认 Compute Engine SA 常为 Editor）的权限: %s", len(all), summarize(all)),
+		})
+	case enabled && len(hostNetwork) > 0:
+		findings = append(findings, CloudFinding{
+			ID:       "gke-metadata-bypass",
+			Severity: config.RiskHigh,
+			Title:    fmt.Sprintf("%d 个 hostNetwork Pod 绕过 GKE 元数据服务器，可获取节点 GCP 服务账号 Token", len(hostNetwork)),
+			Detail:   summarize(hostNetwork),
+		})
+	}
+
+	if bindings := annotatedServiceAccounts(sas, gkeGSAAnnotation); len(bindings) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "gke-workload-identity-sa",
+			Severity: config.RiskMedium,
+			Title:    fmt.Sprintf("%d 个 SA 通过 Workload Identity 绑定 GCP 服务账号", len(bindings)),
+			Detail:   summarize(bindings),
+		})
+	}
+	return findings
+}
+
+// gkeWorkloadIdentity 判断节点是否启用 Workload Identity（GKE 元数据服务器）
+// 优先使用节点标签；没有节点信息时根据 gke-metadata-server Pod 是否在当前节点运行判断
+func gkeWorkloadIdentity(pods []types.PodContainerInfo, nodes []types.NodeInfo) (enabled, known bool) {
+	if len(nodes) > 0 {
+		for _, node := range nodes {
+			if node.Labels[gkeMetadataLabel] == "true" {
+				return true, true
+			}
+		}
+		return false, true
+	}
+	for _, pod := range pods {
+		if pod.Namespace == "kube-system" && strings.HasPrefix(pod.PodName, "gke-metadata-server-") {
+			return true, true
+		}
+	}
+	return false, len(pods) > 0
+}
+
+// auditAKS Workload Identity / AAD Pod Identity 与 kubelet 托管身份暴露
+func auditAKS(pods []types.PodContainerInfo, sas []types.ServiceAccountInfo) []CloudFinding {
+	var findings []CloudFinding
+
+	var workloadIdentity, podIdentity, kubeletConfig, imds []string
+	for _, pod := range pods {
+		ref := pod.Namespace + "/" + pod.PodName
+		switch {
+		case pod.Labels[azureUseLabel] == "true" || podEnv(pod, envAzureTokenFile) != "":
+			if id := podEnv(pod, envAzureClientID); id != "" {
+				ref += " -> " + id
+			}
+			workloadIdentity = append(workloadIdentity, ref)
+		case pod.Labels[aadPodIdentityLabel] != "":
+			podIdentity = append(podIdentity, ref+" -> "+pod.Labels[aadPodIdentityLabel])
+		default:
+			imds = append(imds, ref)
+		}
+		if mountsHostPath(pod, kubeletConfigPath) {
+			kubeletConfig = append(kubeletConfig, pod.Namespace+"/"+pod.PodName)
+		}
+	}
+
+	if ids := annotatedServiceAccounts(sas, azureClientAnnotation); len(ids) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "aks-workload-identity-sa",
+			Severity: config.RiskMedium,
+			Title:    fmt.Sprintf("%d 个 SA 通过 Workload Identity 绑定 Azure 托管身份", len(ids)),
+			Detail:   summarize(ids),
+		})
+	}
+	if len(workloadIdentity) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "aks-workload-identity-pods",
+			Severity: config.RiskMedium,
+			Title:    fmt.Sprintf("%d 个 Pod 使用 Azure Workload Identity，可读取联合 Token 换取 Entra ID 凭据", len(workloadIdentity)),
+			Detail:   summarize(workloadIdentity),
+		})
+	}
+	if len(podIdentity) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "aks-pod-identity",
+			Severity: config.RiskMedium,
+			Title:    fmt.Sprintf("%d 个 Pod 使用已弃用的 AAD Pod Identity", len(podIdentity)),
+			Detail:   "NMI 拦截 IMDS 请求，hostNetwork Pod 可绕过: " + summarize(podIdentity),
+		})
+	}
+	if len(kubeletConfig) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "aks-kubelet-config",
+			Severity: config.RiskHigh,
+			Title:    fmt.Sprintf("%d 个 Pod 挂载 %s，可读取 azure.json 中的 kubelet 身份", len(kubeletConfig), kubeletConfigPath),
+			Detail:   summarize(kubeletConfig),
+		})
+	}
+	if len(imds) > 0 {
+		findings = append(findings, CloudFinding{
+			ID:       "aks-kubelet-identity",
+			Severity: config.RiskMedium,
+			Title:    fmt.Sprintf("%d 个 Pod 未使用 Workload Identity，可能通过 IMDS 获取 kubelet 托管身份 Token", len(imds)),
+			Detail:   fmt.Sprintf("需确认 NetworkPolicy 是否阻止 %s 以及 kubelet 身份的角色分配: %s", metadataServerAddr, summarize(imds)),
+		})
+	}
+	return findings
+}
+
+// runningPods 返回 Running 状态的 Pod
+func runningPods(pods []types.PodContainerInfo) []types.PodContainerInfo {
+	var result []types.PodContainerInfo
+	for _, pod := range pods {
+		if pod.Status == "Running" {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+// podEnv 返回 Pod 中任一容器设置的环境变量值
+func podEnv(pod types.PodContainerInfo, name string) string {
+	for _, c := range pod.Containers {
+		if v := c.Env[name]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// mountsHostPath 是否挂载了 path 或其父目录 / 子目录的 hostPath
+func mountsHostPath(pod types.PodContainerInfo, path string) bool {
+	for _, v := range pod.Volumes {
+		if v.Type != "hostPath" {
+			continue
+		}
+		src := strings.TrimSuffix(v.Source, "/")
+		if src == "" || strings.HasPrefix(path, src+"/") || src == path || strings.HasPrefix(src, path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// annotatedServiceAccounts 返回带有指定注解的 SA（ns/name -> 注解值），按名称排序
+func annotatedServiceAccounts(sas []types.ServiceAccountInfo, annotation string) []string {
+	var result []string
+	for _, sa := range sas {
+		if v := sa.Annotations[annotation]; v != "" {
+			result = append(result, sa.Namespace+"/"+sa.Name+" -> "+v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// summarize 列出前几项，其余以数量表示
+func summarize(items []string) string {
+	if len(items) <= maxCloudFindingPods {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s 等 %d 个", strings.Join(items[:maxCloudFindingPods], ", "), len(items))
+}
@@ -0,0 +1,131 @@
+package security
+
+import (
+	"regexp"
+	"strings"
+
+	"kctl/config"
+)
+
+// CredentialMatch 凭据匹配结果
+type CredentialMatch struct {
+	Severity config.RiskLevel
+	Title    string
+	Evidence string // 匹配到的片段（已截断）
+}
+
+// credentialPattern 凭据值匹配规则
+type credentialPattern struct {
+	re       *regexp.Regexp
+	severity config.RiskLevel
+	title    string
+}
+
+// credentialPatterns 按优先级排列的凭据值规则
+var credentialPatterns = []credentialPattern{
+	{regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`), config.RiskHigh, "私钥"},
+	{regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`), config.RiskHigh, "AWS Access Key"},
+	{regexp.MustCompile(`(?i)aws_secret_access_key\s*[:=]\s*\S+`), config.RiskCritical, "AWS Secret Key"},
+	{regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`), config.RiskHigh, "GitHub Token"},
+	{regexp.MustCompile(`\bxox[baprs]-[A-Za-z0-9-]{10,}`), config.RiskMedium, "Slack Token"},
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`), config.RiskHigh, "JWT/Bearer Token"},
+	{regexp.MustCompile(`(?i)\b[a-z][a-z0-9+.-]*://[^/\s:@]+:[^/\s@]+@[^\s]+`), config.RiskMedium, "连接字符串含密码"},
+	{regexp.MustCompile(`(?i)\b(password|passwd|pwd|secret|api_?key|token)\s*[:=]\s*["']?[^\s"',;]{4,}`), config.RiskMedium, "明文密码/密钥"},
+}
+
+// sensitiveNameRe 敏感变量/键名
+var sensitiveNameRe = regexp.MustCompile(`(?i)(password|passwd|secret|token|api_?key|access_?key|private_?key|credential|auth)`)
+
+// numericRe 纯数字值（如 MAX_TOKENS=1000）不视为凭据
+var numericRe = regexp.MustCompile(`^[0-9.]+$`)
+
+// placeholderValues 常见占位值，不视为凭据
+var placeholderValues = map[string]bool{
+	"": true, "true": true, "false": true, "null": true, "none": true,
+	"changeme": true, "xxx": true, "<none>": true, "-": true,
+}
+
+// maxEvidenceLen 证据最大长度
+const maxEvidenceLen = 200
+
+// MatchCredential 检查键值对是否包含凭据，key 为变量名或配置键
+func MatchCredential(key, value string) (*CredentialMatch, bool) {
+	for _, pat := range credentialPatterns {
+		if m := pat.re.FindString(value); m != "" {
+			return &CredentialMatch{
+				Severity: pat.severity,
+				Title:    pat.title,
+				Evidence: truncateEvidence(m),
+			}, true
+		}
+	}
+
+	v := strings.TrimSpace(value)
+	if key != "" && sensitiveNameRe.MatchString(key) && !placeholderValues[strings.ToLower(v)] &&
+		!numericRe.MatchString(v) && !strings.ContainsAny(v, "\n") {
+		severity := config.RiskMedium
+		if strings.Contains(strings.ToUpper(key), "SECRET_ACCESS_KEY") || strings.Contains(strings.ToUpper(key), "PRIVATE_KEY") {
+			severity = config.RiskHigh
+		}
+		return &CredentialMatch{
+			Severity: severity,
+			Title:    "敏感变量: " + key,
+			Evidence: truncateEvidence(key + "=" + v),
+		}, true
+	}
+
+	return nil, false
+}
+
+// MaskSecret 遮盖凭据，保留键名（KEY=、key: 形式）及值的前 4 个字符
+func MaskSecret(s string) string {
+	prefix := ""
+	if i := strings.IndexAny(s, "=:"); i > 0 && i < 64 && !strings.Contains(s[:i], "//") {
+		prefix, s = s[:i+1], s[i+1:]
+	}
+	if len(s) <= 4 {
+		return prefix + strings.Repeat("*", len(s))
+	}
+	return prefix + s[:4] + strings.Repeat("*", min(len(s)-4, 12))
+}
+
+// truncateEvidence 截断证据
+func truncateEvidence(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > maxEvidenceLen {
+		return s[:maxEvidenceLen] + "..."
+	}
+	return s
+}
+
+// CredentialHit 文本中的凭据命中
+type CredentialHit struct {
+	CredentialMatch
+	Line int // 行号（从 1 开始）
+}
+
+// assignmentRe 匹配 key=value / key: value 形式的配置行
+var assignmentRe = regexp.MustCompile(`^\s*["']?([A-Za-z0-9_.-]+)["']?\s*[:=]\s*(.*)$`)
+
+// GrepCredentials 在配置值中搜索凭据，key 为配置键（如 ConfigMap 的 data 键）
+// 多行值（配置文件）逐行检查，单行值同时按键名判断
+func GrepCredentials(key, value string) []CredentialHit {
+	if !strings.Contains(strings.TrimSpace(value), "\n") {
+		if m, ok := MatchCredential(key, value); ok {
+			return []CredentialHit{{CredentialMatch: *m, Line: 1}}
+		}
+		return nil
+	}
+
+	var hits []CredentialHit
+	for i, line := range strings.Split(value, "\n") {
+		name, val := "", line
+		if m := assignmentRe.FindStringSubmatch(line); m != nil {
+			name, val = m[1], m[2]
+		}
+		if m, ok := MatchCredential(name, strings.Trim(strings.TrimSpace(val), `"'`)); ok {
+			hits = append(hits, CredentialHit{CredentialMatch: *m, Line: i + 1})
+		}
+	}
+	return hits
+}
@@ -0,0 +1,253 @@
+package security
+
+import (
+	"bufio"
+	"path"
+	"strconv"
+	"strings"
+
+	"kctl/config"
+)
+
+// HuntHit 凭据搜寻命中
+type HuntHit struct {
+	Category string // file, env, secret-mount
+	Location string // 文件路径或环境变量名
+	Severity config.RiskLevel
+	Title    string
+	Evidence string
+}
+
+// 凭据搜寻输出标记
+const (
+	huntMarkFile   = "@@KCTL FILE "
+	huntMarkSecret = "@@KCTL SECRET "
+	huntMarkEnv    = "@@KCTL ENV"
+	huntMarkEnd    = "@@KCTL END"
+)
+
+// HuntHomeFiles 相对于用户主目录的常见凭据文件
+var HuntHomeFiles = []string{
+	".aws/credentials",
+	".kube/config",
+	".docker/config.json",
+	".ssh/id_rsa",
+	".ssh/id_ed25519",
+	".ssh/id_ecdsa",
+	".npmrc",
+	".git-credentials",
+	".pgpass",
+	".netrc",
+}
+
+// HuntAbsoluteFiles 常见凭据文件的绝对路径
+var HuntAbsoluteFiles = []string{
+	"/etc/kubernetes/admin.conf",
+	"/etc/kubernetes/kubelet.conf",
+	"/var/lib/kubelet/kubeconfig",
+}
+
+// huntReadLimit 单个文件读取上限（字节）
+const huntReadLimit = 4096
+
+// BuildHuntScript 生成在容器内执行的凭据搜寻脚本（POSIX sh）
+// secretDirs 为容器中 Secret 卷的挂载路径
+func BuildHuntScript(secretDirs []string) string {
+	var b strings.Builder
+
+	b.WriteString(`r(){ [ -f "$2" ] && [ -r "$2" ] && [ -s "$2" ] && { echo "$1$2"; head -c ` + strconv.Itoa(huntReadLimit) + ` "$2" 2>/dev/null; echo; echo "` + huntMarkEnd + `"; }; };`)
+
+	// 主目录下的凭据文件
+	b.WriteString(` for h in /root /home/* "${HOME:-/root}"; do for f in`)
+	for _, f := range HuntHomeFiles {
+		b.WriteString(" " + f)
+	}
+	b.WriteString(`; do r "` + huntMarkFile + `" "$h/$f"; done; done;`)
+
+	// 绝对路径
+	for _, f := range HuntAbsoluteFiles {
+		b.WriteString(` r "` + huntMarkFile + `" ` + shellQuote(f) + `;`)
+	}
+
+	// Secret 挂载
+	for _, d := range secretDirs {
+		b.WriteString(` for f in ` + shellQuote(d) + `/*; do r "` + huntMarkSecret + `" "$f"; done;`)
+	}
+
+	// 环境变量
+	b.WriteString(` echo "` + huntMarkEnv + `"; env; echo "` + huntMarkEnd + `"`)
+
+	return b.String()
+}
+
+// ParseHuntOutput 解析凭据搜寻脚本输出
+func ParseHuntOutput(output string) []HuntHit {
+	var hits []HuntHit
+	seen := make(map[string]bool)
+
+	add := func(hit *HuntHit) {
+		if hit == nil || seen[hit.Category+":"+hit.Location] {
+			return
+		}
+		seen[hit.Category+":"+hit.Location] = true
+		hits = append(hits, *hit)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		mode    string // file, secret, env
+		current string
+		content strings.Builder
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, huntMarkFile):
+			mode, current = "file", strings.TrimPrefix(line, huntMarkFile)
+			content.Reset()
+			continue
+		case strings.HasPrefix(line, huntMarkSecret):
+			mode, current = "secret", strings.TrimPrefix(line, huntMarkSecret)
+			content.Reset()
+			continue
+		case line == huntMarkEnv:
+			mode, current = "env", ""
+			continue
+		case line == huntMarkEnd:
+			switch mode {
+			case "file":
+				add(classifyFile(current, content.String()))
+			case "secret":
+				add(classifySecretFile(current, content.String()))
+			}
+			mode = ""
+			continue
+		}
+
+		switch mode {
+		case "file", "secret":
+			content.WriteString(line)
+			content.WriteString("\n")
+		case "env":
+			add(classifyEnv(line))
+		}
+	}
+
+	return hits
+}
+
+// classifyFile 根据文件路径和内容判断凭据类型
+func classifyFile(filePath, content string) *HuntHit {
+	hit := &HuntHit{Category: "file", Location: filePath}
+	lower := strings.ToLower(content)
+
+	switch {
+	case strings.HasSuffix(filePath, ".aws/credentials"):
+		if !strings.Contains(lower, "aws_secret_access_key") {
+			return nil
+		}
+		hit.Severity, hit.Title = config.RiskCritical, "AWS 凭据文件"
+		hit.Evidence = firstMatchingLine(content, "aws_access_key_id")
+
+	case strings.HasSuffix(filePath, ".kube/config"), strings.HasSuffix(filePath, ".conf"),
+		strings.HasSuffix(filePath, "kubeconfig"):
+		if !strings.Contains(content, "clusters:") {
+			return nil
+		}
+		hit.Severity, hit.Title = config.RiskHigh, "kubeconfig 文件"
+		if strings.Contains(content, "token:") || strings.Contains(content, "client-key-data") {
+			hit.Severity, hit.Title = config.RiskCritical, "kubeconfig 含凭据"
+		}
+		hit.Evidence = firstMatchingLine(content, "server:")
+
+	case strings.HasSuffix(filePath, ".docker/config.json"):
+		if !strings.Contains(content, `"auth"`) {
+			return nil
+		}
+		hit.Severity, hit.Title = config.RiskHigh, "Docker 仓库凭据"
+		hit.Evidence = firstMatchingLine(content, `"auth"`)
+
+	case strings.HasPrefix(path.Base(filePath), "id_"):
+		if !strings.Contains(content, "PRIVATE KEY") {
+			return nil
+		}
+		hit.Severity, hit.Title = config.RiskHigh, "SSH 私钥"
+		hit.Evidence = firstMatchingLine(content, "PRIVATE KEY")
+
+	case strings.HasSuffix(filePath, ".npmrc"):
+		if !strings.Contains(content, "_auth") {
+			return nil
+		}
+		hit.Severity, hit.Title = config.RiskHigh, "npm 令牌"
+		hit.Evidence = firstMatchingLine(content, "_auth")
+
+	case strings.HasSuffix(filePath, ".git-credentials"):
+		hit.Severity, hit.Title = config.RiskHigh, "Git 凭据"
+		hit.Evidence = firstMatchingLine(content, "://")
+
+	default:
+		hit.Severity, hit.Title = config.RiskMedium, "凭据文件 "+path.Base(filePath)
+		hit.Evidence = firstMatchingLine(content, "")
+	}
+
+	return hit
+}
+
+// classifySecretFile 判断挂载的 Secret 文件
+func classifySecretFile(filePath, content string) *HuntHit {
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+	hit := &HuntHit{
+		Category: "secret-mount",
+		Location: filePath,
+		Severity: config.RiskMedium,
+		Title:    "挂载的 Secret: " + path.Base(filePath),
+		Evidence: firstMatchingLine(content, ""),
+	}
+	if m, ok := MatchCredential(path.Base(filePath), content); ok {
+		hit.Severity = m.Severity
+		hit.Title = m.Title + " (Secret: " + path.Base(filePath) + ")"
+		hit.Evidence = m.Evidence
+	}
+	return hit
+}
+
+// classifyEnv 判断环境变量
+func classifyEnv(line string) *HuntHit {
+	key, value, ok := strings.Cut(line, "=")
+	if !ok || strings.HasPrefix(key, "KUBERNETES_") {
+		return nil
+	}
+	m, ok := MatchCredential(key, value)
+	if !ok {
+		return nil
+	}
+	return &HuntHit{
+		Category: "env",
+		Location: key,
+		Severity: m.Severity,
+		Title:    m.Title,
+		Evidence: m.Evidence,
+	}
+}
+
+// firstMatchingLine 返回第一个包含子串的非空行（substr 为空时返回第一个非空行）
+func firstMatchingLine(content, substr string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && strings.Contains(line, substr) {
+			return truncateEvidence(line)
+		}
+	}
+	return ""
+}
+
+// shellQuote 单引号转义
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
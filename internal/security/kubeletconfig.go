@@ -0,0 +1,85 @@
+package security
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"kctl/config"
+	"kctl/pkg/types"
+)
+
+// Kubelet 授权模式
+const (
+	KubeletAuthzAlwaysAllow = "AlwaysAllow"
+	KubeletAuthzWebhook     = "Webhook"
+)
+
+// KubeletConfigFinding Kubelet 配置审计发现
+type KubeletConfigFinding struct {
+	ID       string // 检查标识，如 anonymous-auth
+	Severity config.RiskLevel
+	Title    string
+	Detail   string
+}
+
+// AuditKubeletConfig 审计 /configz 返回的 Kubelet 配置，按严重程度排序
+// 未返回的字段视为未知，跳过对应检查
+func AuditKubeletConfig(cfg *types.KubeletConfiguration) []KubeletConfigFinding {
+	if cfg == nil {
+		return nil
+	}
+
+	var findings []KubeletConfigFinding
+	add := func(id string, severity config.RiskLevel, title, detail string) {
+		findings = append(findings, KubeletConfigFinding{ID: id, Severity: severity, Title: title, Detail: detail})
+	}
+
+	anonymous := cfg.Authentication.Anonymous.Enabled != nil && *cfg.Authentication.Anonymous.Enabled
+	alwaysAllow := strings.EqualFold(cfg.Authorization.Mode, KubeletAuthzAlwaysAllow)
+
+	switch {
+	case anonymous && alwaysAllow:
+		add("anonymous-auth", config.RiskCritical, "匿名访问已启用且授权模式为 AlwaysAllow",
+			"anonymous-auth=true，authorization-mode=AlwaysAllow：无需凭据即可访问 /pods、/exec、/run 等全部 Kubelet API")
+	case anonymous:
+		add("anonymous-auth", config.RiskMedium, "匿名访问已启用",
+			fmt.Sprintf("anonymous-auth=true：未认证请求以 system:anonymous 身份交由 %s 授权，RBAC 配置不当时可直接访问 Kubelet API",
+				cfg.Authorization.Mode))
+	}
+
+	if alwaysAllow && !anonymous {
+		add("authorization-mode", config.RiskCritical, "授权模式为 AlwaysAllow",
+			"authorization-mode=AlwaysAllow：任何通过认证的身份（包括无权限的 SA Token）都可在该节点 Pod 中执行命令")
+	}
+
+	if cfg.Authentication.Webhook.Enabled != nil && !*cfg.Authentication.Webhook.Enabled {
+		add("webhook-auth", config.RiskMedium, "Webhook 认证已禁用",
+			"authentication-token-webhook=false：Bearer Token 不经 TokenReview 验证，Kubelet 只接受客户端证书或匿名请求")
+	}
+
+	if cfg.ReadOnlyPort > 0 {
+		add("read-only-port", config.RiskMedium, "只读端口已启用",
+			fmt.Sprintf("read-only-port=%d：无需认证即可读取 /pods（含环境变量中的凭据）、/spec/ 和 /metrics", cfg.ReadOnlyPort))
+	}
+
+	if cfg.Authentication.X509.ClientCAFile == "" {
+		add("client-ca", config.RiskLow, "未配置客户端 CA",
+			"client-ca-file 为空：Kubelet 不验证客户端证书，API Server 无法使用证书访问该节点")
+	}
+
+	if !cfg.ProtectKernelDefaults {
+		add("protect-kernel-defaults", config.RiskLow, "未启用 protectKernelDefaults",
+			"protect-kernel-defaults=false：Kubelet 会修改与预期不符的内核参数，而不是拒绝启动")
+	}
+
+	if !cfg.RotateCertificates {
+		add("rotate-certificates", config.RiskLow, "未启用客户端证书轮换",
+			"rotate-certificates=false：Kubelet 客户端证书过期前不会自动续签")
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return config.RiskLevelOrder[findings[i].Severity] < config.RiskLevelOrder[findings[j].Severity]
+	})
+	return findings
+}
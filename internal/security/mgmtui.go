@@ -0,0 +1,192 @@
+package security
+
+import (
+	"strconv"
+	"strings"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+)
+
+// ManagementUI 集群内管理界面：识别方式和默认 / 弱认证检查
+type ManagementUI struct {
+	Label  string   // 与 config.InterestingServices 的 Label 一致，从 Service 识别
+	Images []string // 容器镜像关键词，从 Pod 识别
+	Ports  []int    // 容器默认端口，从 Pod 识别时使用
+	Checks []UICheck
+}
+
+// UICheck 不带凭据或使用默认口令的 HTTP 请求，Match 返回 true 时产生发现
+type UICheck struct {
+	ID          string
+	Severity    config.RiskLevel
+	Title       string
+	Detail      string
+	Method      string
+	Path        string
+	ContentType string
+	Body        string // 可包含 {{pod}}，替换为后端 Pod 名称
+	Match       func(status int, body string) bool
+}
+
+// UIPodPlaceholder 请求体中的后端 Pod 名称占位符（ArgoCD 旧版本的初始密码为 argocd-server Pod 名称）
+const UIPodPlaceholder = "{{pod}}"
+
+// okContains 状态码为 200 且响应包含全部子串，比较时忽略空白（兼容缩进输出的 JSON）
+func okContains(substrs ...string) func(int, string) bool {
+	return func(status int, body string) bool {
+		if status != 200 {
+			return false
+		}
+		body = stripSpace(body)
+		for _, s := range substrs {
+			if !strings.Contains(body, stripSpace(s)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// stripSpace 去掉所有空白字符
+func stripSpace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// ManagementUIs 支持检查的管理界面
+var ManagementUIs = []ManagementUI{
+	{
+		Label:  "kubernetes-dashboard",
+		Images: []string{"kubernetesui/dashboard", "kubernetes-dashboard"},
+		Ports:  []int{8443, 9090},
+		Checks: []UICheck{
+			{ID: "dashboard-skip-login", Severity: config.RiskHigh, Title: "Dashboard 允许跳过登录",
+				Detail: "--enable-skip-login 开启，跳过登录后以 Dashboard 自身 SA 的权限操作集群",
+				Method: "GET", Path: "/api/v1/login/skippable", Match: okContains(`"skippable":true`)},
+			{ID: "dashboard-secrets", Severity: config.RiskCritical, Title: "无需登录即可通过 Dashboard 读取 Secret",
+				Detail: "Dashboard SA 拥有读取 kube-system Secret 的权限，且未要求登录",
+				Method: "GET", Path: "/api/v1/secret/kube-system", Match: okContains(`"secrets"`)},
+		},
+	},
+	{
+		Label:  "rancher",
+		Images: []string{"rancher/rancher"},
+		Ports:  []int{443, 80},
+		Checks: []UICheck{
+			{ID: "rancher-first-login", Severity: config.RiskHigh, Title: "Rancher 尚未完成首次登录",
+				Detail: "first-login 为 true：管理员密码仍为 bootstrap 密码，首个访问者可设置 admin 密码",
+				Method: "GET", Path: "/v3/settings/first-login", Match: okContains(`"value":"true"`)},
+			{ID: "rancher-default-password", Severity: config.RiskCritical, Title: "Rancher 默认口令 admin/admin",
+				Detail: "使用 admin/admin 登录成功，可管理 Rancher 纳管的全部集群",
+				Method: "POST", Path: "/v3-public/localProviders/local?action=login", ContentType: "application/json",
+				Body: `{"username":"admin","password":"admin","responseType":"json"}`,
+				Match: func(status int, body string) bool {
+					return (status == 200 || status == 201) && strings.Contains(body, `"token"`)
+				}},
+		},
+	},
+	{
+		Label:  "argocd",
+		Images: []string{"argoproj/argocd", "argocd"},
+		Ports:  []int{8080},
+		Checks: []UICheck{
+			{ID: "argocd-anonymous", Severity: config.RiskHigh, Title: "ArgoCD 允许匿名访问",
+				Detail: "users.anonymous.enabled 开启，未登录即可查看应用（含仓库地址和部署参数）",
+				Method: "GET", Path: "/api/v1/applications", Match: okContains(`"items"`)},
+			{ID: "argocd-default-password", Severity: config.RiskCritical, Title: "ArgoCD 默认口令（admin / argocd-server Pod 名称）",
+				Detail: "ArgoCD 1.9 之前 admin 初始密码为 argocd-server Pod 名称且未修改，可部署任意应用",
+				Method: "POST", Path: "/api/v1/session", ContentType: "application/json",
+				Body:  `{"username":"admin","password":"` + UIPodPlaceholder + `"}`,
+				Match: okContains(`"token"`)},
+		},
+	},
+	{
+		Label:  "grafana",
+		Images: []string{"grafana/grafana"},
+		Ports:  []int{3000},
+		Checks: []UICheck{
+			{ID: "grafana-anonymous", Severity: config.RiskMedium, Title: "Grafana 允许匿名访问",
+				Detail: "auth.anonymous 开启，未登录即可查看仪表盘和数据源查询",
+				Method: "GET", Path: "/api/org", Match: okContains(`"name"`)},
+			{ID: "grafana-default-password", Severity: config.RiskCritical, Title: "Grafana 默认口令 admin/admin",
+				Detail: "使用 admin/admin 登录成功，数据源配置中包含数据库和云凭据",
+				Method: "POST", Path: "/login", ContentType: "application/json",
+				Body: `{"user":"admin","password":"admin"}`, Match: okContains("Logged in")},
+		},
+	},
+	{
+		Label:  "kubeflow",
+		Images: []string{"kubeflow", "centraldashboard", "ml-pipeline"},
+		Ports:  []int{8082, 3000},
+		Checks: []UICheck{
+			{ID: "kubeflow-no-auth", Severity: config.RiskHigh, Title: "Kubeflow 中央面板未启用认证",
+				Detail: "未经 Istio / Dex 认证即可访问，可创建 Notebook 在集群内运行任意代码",
+				Method: "GET", Path: "/api/workgroup/env-info", Match: okContains(`"namespaces"`)},
+			{ID: "kubeflow-pipelines-no-auth", Severity: config.RiskHigh, Title: "Kubeflow Pipelines 未启用认证",
+				Detail: "未认证即可列出和运行 Pipeline，Pipeline 步骤以 Pod 形式运行任意镜像",
+				Method: "GET", Path: "/apis/v1beta1/pipelines", Match: okContains(`"pipelines"`)},
+		},
+	},
+	{
+		Label:  "jenkins",
+		Images: []string{"jenkins/jenkins", "jenkins"},
+		Ports:  []int{8080},
+		Checks: []UICheck{
+			{ID: "jenkins-script-console", Severity: config.RiskCritical, Title: "Jenkins Script Console 匿名可访问",
+				Detail: "未登录即可在 Jenkins 控制器上执行任意 Groovy 代码",
+				Method: "GET", Path: "/script", Match: okContains("Script Console")},
+			{ID: "jenkins-anonymous", Severity: config.RiskMedium, Title: "Jenkins 允许匿名读取",
+				Detail: "匿名用户有 Overall/Read 权限，可查看任务配置和构建日志",
+				Method: "GET", Path: "/api/json", Match: okContains(`"jobs"`)},
+		},
+	},
+}
+
+// FindManagementUI 按 InterestingService 标签查找管理界面
+func FindManagementUI(label string) *ManagementUI {
+	for i := range ManagementUIs {
+		if ManagementUIs[i].Label == label {
+			return &ManagementUIs[i]
+		}
+	}
+	return nil
+}
+
+// ManagementUIForImage 按容器镜像识别管理界面
+func ManagementUIForImage(image string) *ManagementUI {
+	image = strings.ToLower(image)
+	for i := range ManagementUIs {
+		for _, keyword := range ManagementUIs[i].Images {
+			if strings.Contains(image, keyword) {
+				return &ManagementUIs[i]
+			}
+		}
+	}
+	return nil
+}
+
+// UICurlCommand 生成在 Pod 内发送请求的 curl 命令，输出响应体后追加一行 KCTL_STATUS <code>
+func UICurlCommand(method, url, contentType, body string, timeout int) string {
+	args := []string{"curl", "-sk", "-m", strconv.Itoa(timeout), "-X", method}
+	if contentType != "" {
+		args = append(args, "-H", shellQuote("Content-Type: "+contentType))
+	}
+	if body != "" {
+		args = append(args, "--data-binary", shellQuote(body))
+	}
+	args = append(args, "-w", shellQuote(`\nKCTL_STATUS %{http_code}`), shellQuote(url))
+	return strings.Join(args, " ")
+}
+
+// ParseUICurl 解析 UICurlCommand 的输出，连接失败（状态码 000）时返回错误
+func ParseUICurl(output string) (int, string, error) {
+	i := strings.LastIndex(output, "KCTL_STATUS ")
+	if i < 0 {
+		return 0, "", i18n.Errorf("curl 无输出")
+	}
+	status, err := strconv.Atoi(strings.TrimSpace(output[i+len("KCTL_STATUS "):]))
+	if err != nil || status == 0 {
+		return 0, "", i18n.Errorf("连接失败")
+	}
+	return status, strings.TrimSuffix(output[:i], "\n"), nil
+}
@@ -64,6 +64,23 @@ func CheckRunAsRoot(containersJSON string) bool {
 	return false
 }
 
+// GetDangerousCapabilities 获取容器添加的高危 capabilities（去重）
+func GetDangerousCapabilities(containersJSON string) []string {
+	var containers []types.ContainerInfo
+	if err := json.Unmarshal([]byte(containersJSON), &containers); err != nil {
+		return nil
+	}
+	var flags types.SecurityFlags
+	for _, c := range containers {
+		for _, capability := range c.Capabilities {
+			if config.IsDangerousCapability(capability) {
+				flags.AddCapability(strings.TrimPrefix(strings.ToUpper(capability), "CAP_"))
+			}
+		}
+	}
+	return flags.Capabilities
+}
+
 // GetSecurityFlags 获取 Pod 的安全风险标记
 func GetSecurityFlags(record *types.PodRecord) types.SecurityFlags {
 	return types.SecurityFlags{
@@ -71,6 +88,8 @@ func GetSecurityFlags(record *types.PodRecord) types.SecurityFlags {
 		AllowPrivilegeEscalation: CheckAllowPrivilegeEscalation(record.Containers),
 		HasHostPath:              CheckHostPath(record.Volumes),
 		HasSecretMount:           CheckSecretMount(record.Volumes),
+		RunAsRoot:                CheckRunAsRoot(record.Containers),
+		Capabilities:             GetDangerousCapabilities(record.Containers),
 	}
 }
 
@@ -90,6 +109,9 @@ func GetRiskFlags(record *types.PodRecord) []string {
 	if CheckSecretMount(record.Volumes) {
 		flags = append(flags, "SEC")
 	}
+	if len(GetDangerousCapabilities(record.Containers)) > 0 {
+		flags = append(flags, "CAP")
+	}
 	if CheckRunAsRoot(record.Containers) {
 		flags = append(flags, "ROOT")
 	}
@@ -97,11 +119,53 @@ func GetRiskFlags(record *types.PodRecord) []string {
 	return flags
 }
 
+// ActiveSecurityFlags 返回已设置的安全标识名称（config.SecurityFlagConfigs 的键，不含 SA Token 挂载）
+// 顺序与表格简写一致：PRIV PE HP SEC HNET HPID HIPC CAP ROOT NOSC
+func ActiveSecurityFlags(flags types.SecurityFlags) []string {
+	var names []string
+	add := func(set bool, name string) {
+		if set {
+			names = append(names, name)
+		}
+	}
+	add(flags.Privileged, "Privileged")
+	add(flags.AllowPrivilegeEscalation, "AllowPrivilegeEscalation")
+	add(flags.HasHostPath, "HostPath")
+	add(flags.HasSecretMount, "SecretMount")
+	add(flags.HostNetwork, "HostNetwork")
+	add(flags.HostPID, "HostPID")
+	add(flags.HostIPC, "HostIPC")
+	add(len(flags.Capabilities) > 0, "Capabilities")
+	add(flags.RunAsRoot, "RunAsRoot")
+	add(flags.NoSeccomp, "NoSeccomp")
+	return names
+}
+
 // IsPodRisky 检查 Pod 是否有风险
 func IsPodRisky(record *types.PodRecord) bool {
 	return CheckPrivileged(record.Containers) ||
 		CheckAllowPrivilegeEscalation(record.Containers) ||
 		CheckHostPath(record.Volumes) ||
 		CheckSecretMount(record.Volumes) ||
-		CheckRunAsRoot(record.Containers)
+		CheckRunAsRoot(record.Containers) ||
+		len(GetDangerousCapabilities(record.Containers)) > 0
+}
+
+// PassiveRiskLevel 只根据 Pod 规格的安全标识估算风险（不读取 Token、不检查 RBAC）
+//
+// 特权 + hostPID/HostPath 可直接逃逸到节点为 CRITICAL；特权、HostPath 或高危 capability 为 HIGH；
+// 权限提升、Secret 挂载、主机命名空间为 MEDIUM；仅挂载 SA Token 为 LOW
+func PassiveRiskLevel(flags types.SecurityFlags) config.RiskLevel {
+	switch {
+	case flags.Privileged && (flags.HostPID || flags.HasHostPath):
+		return config.RiskCritical
+	case flags.Privileged || flags.HasHostPath || len(flags.Capabilities) > 0:
+		return config.RiskHigh
+	case flags.AllowPrivilegeEscalation || flags.HasSecretMount || flags.HostPID || flags.HostNetwork || flags.HostIPC:
+		return config.RiskMedium
+	case flags.HasSATokenMount:
+		return config.RiskLow
+	default:
+		return config.RiskNone
+	}
 }
@@ -0,0 +1,83 @@
+package security
+
+import (
+	"slices"
+	"strings"
+
+	"kctl/config"
+	"kctl/pkg/types"
+)
+
+// Pod Security Admission 级别
+const (
+	PSAPrivileged = "privileged"
+	PSABaseline   = "baseline"
+	PSARestricted = "restricted"
+)
+
+// EffectivePSALevel 返回命名空间生效的 enforce 级别
+// 未设置标签时按 privileged 处理（集群可通过 AdmissionConfiguration 设置默认级别，API 中不可见）
+func EffectivePSALevel(ns types.NamespaceInfo) string {
+	if level := strings.ToLower(ns.PodSecurityLevel("enforce")); level != "" {
+		return level
+	}
+	return PSAPrivileged
+}
+
+// PSAAllowsPrivileged 命名空间的 PSA 是否允许特权 Pod（privileged、hostPID、hostPath 等）
+func PSAAllowsPrivileged(ns types.NamespaceInfo) bool {
+	return EffectivePSALevel(ns) == PSAPrivileged
+}
+
+// PSPEscapeVectors 返回 PodSecurityPolicy 允许的逃逸手段，如 privileged、hostPID、hostPath:/
+func PSPEscapeVectors(psp types.PodSecurityPolicyInfo) []string {
+	var vectors []string
+	if psp.Privileged {
+		vectors = append(vectors, "privileged")
+	}
+	if psp.HostPID {
+		vectors = append(vectors, "hostPID")
+	}
+	if psp.HostNetwork {
+		vectors = append(vectors, "hostNetwork")
+	}
+	if psp.HostIPC {
+		vectors = append(vectors, "hostIPC")
+	}
+	if slices.Contains(psp.Volumes, "*") || slices.Contains(psp.Volumes, "hostPath") {
+		if len(psp.AllowedHostPaths) == 0 {
+			vectors = append(vectors, "hostPath:*")
+		} else {
+			for _, path := range psp.AllowedHostPaths {
+				if !strings.HasSuffix(path, "(ro)") {
+					vectors = append(vectors, "hostPath:"+path)
+				}
+			}
+		}
+	}
+	for _, capability := range psp.AllowedCapabilities {
+		if capability == "*" || config.IsDangerousCapability(capability) {
+			vectors = append(vectors, "cap:"+capability)
+		}
+	}
+	if psp.RunAsUserRule == "RunAsAny" {
+		vectors = append(vectors, "runAsRoot")
+	}
+	return vectors
+}
+
+// PSPAllowsPrivileged PodSecurityPolicy 是否允许特权容器或 hostPID + 可写 hostPath 的逃逸组合
+func PSPAllowsPrivileged(psp types.PodSecurityPolicyInfo) bool {
+	if psp.Privileged {
+		return true
+	}
+	if !psp.HostPID {
+		return false
+	}
+	for _, v := range PSPEscapeVectors(psp) {
+		if strings.HasPrefix(v, "hostPath:") {
+			return true
+		}
+	}
+	return false
+}
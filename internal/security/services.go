@@ -0,0 +1,144 @@
+package security
+
+import (
+	"bufio"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kctl/config"
+	"kctl/pkg/types"
+)
+
+// WellKnownServiceNames 常见管理界面的默认 Service 名称（name.namespace.svc），
+// 无法列出 Service 时在 Pod 内解析这些名称发现跨命名空间的目标
+var WellKnownServiceNames = []string{
+	"kubernetes-dashboard.kubernetes-dashboard.svc",
+	"kubernetes-dashboard.kube-system.svc",
+	"tiller-deploy.kube-system.svc",
+	"argocd-server.argocd.svc",
+	"rancher.cattle-system.svc",
+	"prometheus-server.monitoring.svc",
+	"prometheus-k8s.monitoring.svc",
+	"prometheus-operated.monitoring.svc",
+	"alertmanager-main.monitoring.svc",
+	"grafana.monitoring.svc",
+	"centraldashboard.kubeflow.svc",
+	"ml-pipeline-ui.kubeflow.svc",
+	"jenkins.jenkins.svc",
+	"vault.vault.svc",
+}
+
+// ClassifyService 判断 Service 是否为值得关注的目标，先按名称 / 命名空间关键词，再按端口匹配
+func ClassifyService(svc types.ServiceInfo) *config.InterestingService {
+	name := strings.ToLower(svc.Name)
+	namespace := strings.ToLower(svc.Namespace)
+	for i := range config.InterestingServices {
+		for _, keyword := range config.InterestingServices[i].Keywords {
+			if strings.Contains(name, keyword) {
+				return &config.InterestingServices[i]
+			}
+		}
+	}
+	for i := range config.InterestingServices {
+		for _, keyword := range config.InterestingServices[i].Keywords {
+			if strings.Contains(namespace, keyword) {
+				return &config.InterestingServices[i]
+			}
+		}
+	}
+	for i := range config.InterestingServices {
+		for _, port := range svc.Ports {
+			if slices.Contains(config.InterestingServices[i].Ports, port.Port) {
+				return &config.InterestingServices[i]
+			}
+		}
+	}
+	return nil
+}
+
+// ServiceDiscoveryScript 在 Pod 内执行的发现脚本：输出环境变量，并逐个解析常见 Service 名称
+// 解析成功的行格式为 KCTL_DNS <name> <ip> ...
+func ServiceDiscoveryScript() string {
+	return "env; for n in " + strings.Join(WellKnownServiceNames, " ") +
+		`; do getent hosts "$n" 2>/dev/null | sed "s|^|KCTL_DNS $n |"; done`
+}
+
+// ParseServiceDiscovery 解析 ServiceDiscoveryScript 的输出
+// Kubernetes 为 Pod 注入同命名空间 Service 的 <NAME>_SERVICE_HOST / <NAME>_PORT_<port>_<PROTO> 环境变量
+// （enableServiceLinks 关闭时没有），namespace 为执行命令的 Pod 所在命名空间
+func ParseServiceDiscovery(output, namespace string) []types.ServiceInfo {
+	hosts := make(map[string]string)
+	ports := make(map[string][]types.ServicePort)
+	var dns []types.ServiceInfo
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "KCTL_DNS "); ok {
+			fields := strings.Fields(rest)
+			if len(fields) < 2 {
+				continue
+			}
+			name, ns, _ := strings.Cut(strings.TrimSuffix(fields[0], ".svc"), ".")
+			dns = append(dns, types.ServiceInfo{Namespace: ns, Name: name, ClusterIP: fields[1], Source: "dns"})
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(key, "_SERVICE_HOST"); ok {
+			hosts[prefix] = value
+			continue
+		}
+		// <NAME>_PORT_<port>_<PROTO>=<proto>://<ip>:<port>
+		if i := strings.LastIndex(key, "_PORT_"); i > 0 && strings.Contains(value, "://") {
+			parts := strings.Split(key[i+len("_PORT_"):], "_")
+			if len(parts) != 2 {
+				continue
+			}
+			port, err := strconv.Atoi(parts[0])
+			if err != nil {
+				continue
+			}
+			ports[key[:i]] = append(ports[key[:i]], types.ServicePort{Protocol: parts[1], Port: port, TargetPort: parts[0]})
+		}
+	}
+
+	var services []types.ServiceInfo
+	for prefix, host := range hosts {
+		svcPorts := ports[prefix]
+		sort.Slice(svcPorts, func(i, j int) bool { return svcPorts[i].Port < svcPorts[j].Port })
+		services = append(services, types.ServiceInfo{
+			Namespace: namespace,
+			Name:      strings.ReplaceAll(strings.ToLower(prefix), "_", "-"),
+			ClusterIP: host,
+			Ports:     svcPorts,
+			Source:    "env",
+		})
+	}
+	for _, svc := range dns {
+		if !slices.ContainsFunc(services, func(s types.ServiceInfo) bool {
+			return s.Namespace == svc.Namespace && s.Name == svc.Name
+		}) {
+			services = append(services, svc)
+		}
+	}
+	return services
+}
+
+// PortService 按端口推测服务名称，未知端口返回空字符串
+func PortService(port int) string {
+	if name, ok := config.WellKnownPorts[port]; ok {
+		return name
+	}
+	for _, svc := range config.InterestingServices {
+		if slices.Contains(svc.Ports, port) {
+			return svc.Label
+		}
+	}
+	return ""
+}
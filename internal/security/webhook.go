@@ -0,0 +1,215 @@
+package security
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"kctl/config"
+	"kctl/pkg/types"
+)
+
+// WebhookBypass 准入 Webhook 的绕过途径或配置缺陷
+type WebhookBypass struct {
+	ID       string // 检查标识，如 fail-open、namespace-excluded
+	Severity config.RiskLevel
+	Title    string
+	Detail   string
+}
+
+// SelectorMatches 判断标签是否满足选择器，nil 或空选择器匹配一切
+func SelectorMatches(selector *types.LabelSelector, labels map[string]string) bool {
+	if selector == nil {
+		return true
+	}
+	for k, v := range selector.MatchLabels {
+		if actual, ok := labels[k]; !ok || actual != v {
+			return false
+		}
+	}
+	for _, expr := range selector.MatchExpressions {
+		value, ok := labels[expr.Key]
+		switch expr.Operator {
+		case "In":
+			if !ok || !slices.Contains(expr.Values, value) {
+				return false
+			}
+		case "NotIn":
+			if ok && slices.Contains(expr.Values, value) {
+				return false
+			}
+		case "Exists":
+			if !ok {
+				return false
+			}
+		case "DoesNotExist":
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// FormatSelector 格式化选择器，如 env=prod, kubernetes.io/metadata.name notin (kube-system)
+func FormatSelector(selector *types.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	var parts []string
+	for k, v := range selector.MatchLabels {
+		parts = append(parts, k+"="+v)
+	}
+	sort.Strings(parts)
+	for _, expr := range selector.MatchExpressions {
+		switch expr.Operator {
+		case "Exists":
+			parts = append(parts, expr.Key)
+		case "DoesNotExist":
+			parts = append(parts, "!"+expr.Key)
+		default:
+			parts = append(parts, fmt.Sprintf("%s %s (%s)", expr.Key, strings.ToLower(expr.Operator), strings.Join(expr.Values, ",")))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// WebhookInterceptsPods Webhook 是否拦截 Pod 创建（逃逸 Pod 是否会经过它）
+func WebhookInterceptsPods(w types.WebhookInfo) bool {
+	return webhookMatches(w, "CREATE", "pods")
+}
+
+// webhookMatches Webhook 规则是否拦截核心组资源的指定操作
+func webhookMatches(w types.WebhookInfo, operation, resource string) bool {
+	for _, rule := range w.Rules {
+		if rule.Scope == "Cluster" {
+			continue
+		}
+		if !slices.Contains(rule.Operations, "*") && !slices.Contains(rule.Operations, operation) {
+			continue
+		}
+		if !slices.Contains(rule.APIGroups, "*") && !slices.Contains(rule.APIGroups, "") {
+			continue
+		}
+		base, sub, _ := strings.Cut(resource, "/")
+		for _, r := range rule.Resources {
+			if r == resource || r == "*/*" || (r == "*" && sub == "") || (sub != "" && r == base+"/*") || (sub != "" && r == "*/"+sub) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WebhookExcludedNamespaces 返回 namespaceSelector 不匹配的命名空间
+func WebhookExcludedNamespaces(w types.WebhookInfo, namespaces []types.NamespaceInfo) []string {
+	var excluded []string
+	for _, ns := range namespaces {
+		if !SelectorMatches(w.NamespaceSelector, ns.Labels) {
+			excluded = append(excluded, ns.Name)
+		}
+	}
+	return excluded
+}
+
+// WebhookBypasses 检查 Webhook 的绕过途径：
+// failurePolicy Ignore（Webhook 不可用时放行）、namespaceSelector 排除的命名空间、
+// 可由请求方控制的 objectSelector，以及拦截 Pod 创建但遗漏临时容器子资源
+// namespaces 为空时（无 list namespaces 权限）只根据选择器内容判断
+func WebhookBypasses(w types.WebhookInfo, namespaces []types.NamespaceInfo) []WebhookBypass {
+	var bypasses []WebhookBypass
+	interceptsPods := WebhookInterceptsPods(w)
+
+	if w.FailurePolicy == "Ignore" {
+		severity := config.RiskLow
+		if interceptsPods {
+			severity = config.RiskMedium
+		}
+		bypasses = append(bypasses, WebhookBypass{
+			ID: "fail-open", Severity: severity,
+			Title:  "failurePolicy 为 Ignore（失败放行）",
+			Detail: fmt.Sprintf("Webhook 服务 %s 不可达或超时（%s）时请求直接放行", w.Endpoint, timeoutString(w.TimeoutSeconds)),
+		})
+	}
+
+	if w.NamespaceSelector != nil && (len(w.NamespaceSelector.MatchLabels) > 0 || len(w.NamespaceSelector.MatchExpressions) > 0) {
+		selector := FormatSelector(w.NamespaceSelector)
+		if len(namespaces) > 0 {
+			if excluded := WebhookExcludedNamespaces(w, namespaces); len(excluded) > 0 {
+				severity := config.RiskLow
+				if slices.Contains(excluded, "kube-system") {
+					severity = config.RiskHigh
+				} else if interceptsPods {
+					severity = config.RiskMedium
+				}
+				bypasses = append(bypasses, WebhookBypass{
+					ID: "namespace-excluded", Severity: severity,
+					Title:  "namespaceSelector 排除了命名空间",
+					Detail: fmt.Sprintf("以下命名空间中的请求不经过该 Webhook: %s（选择器: %s）", strings.Join(excluded, ", "), selector),
+				})
+			}
+		} else {
+			bypasses = append(bypasses, WebhookBypass{
+				ID: "namespace-excluded", Severity: config.RiskLow,
+				Title:  "namespaceSelector 可能排除命名空间",
+				Detail: "不匹配选择器的命名空间不经过该 Webhook（选择器: " + selector + "，无法列出命名空间核对）",
+			})
+		}
+		if labelsControllable(w.NamespaceSelector) {
+			bypasses = append(bypasses, WebhookBypass{
+				ID: "namespace-label", Severity: config.RiskMedium,
+				Title:  "namespaceSelector 依赖可修改的命名空间标签",
+				Detail: "能 patch namespaces 或创建命名空间的主体可通过调整标签使命名空间不再匹配（选择器: " + selector + "）",
+			})
+		}
+	}
+
+	if w.ObjectSelector != nil && (len(w.ObjectSelector.MatchLabels) > 0 || len(w.ObjectSelector.MatchExpressions) > 0) {
+		severity := config.RiskMedium
+		if interceptsPods {
+			severity = config.RiskHigh
+		}
+		bypasses = append(bypasses, WebhookBypass{
+			ID: "object-selector", Severity: severity,
+			Title:  "objectSelector 由请求方控制",
+			Detail: "对象标签由创建者决定，创建不匹配 " + FormatSelector(w.ObjectSelector) + " 的对象即可绕过该 Webhook",
+		})
+	}
+
+	if interceptsPods && !webhookMatches(w, "UPDATE", "pods/ephemeralcontainers") {
+		bypasses = append(bypasses, WebhookBypass{
+			ID: "ephemeral-gap", Severity: config.RiskMedium,
+			Title:  "未拦截 pods/ephemeralcontainers",
+			Detail: "拦截了 Pod 创建但未拦截临时容器子资源，可通过 debug 向已有 Pod 注入不受检查的容器",
+		})
+	}
+
+	sort.SliceStable(bypasses, func(i, j int) bool {
+		return config.RiskLevelOrder[bypasses[i].Severity] < config.RiskLevelOrder[bypasses[j].Severity]
+	})
+	return bypasses
+}
+
+// labelsControllable 选择器是否依赖自定义标签（kubernetes.io/metadata.name 由 API Server 维护，不可修改）
+func labelsControllable(selector *types.LabelSelector) bool {
+	for k := range selector.MatchLabels {
+		if k != "kubernetes.io/metadata.name" {
+			return true
+		}
+	}
+	for _, expr := range selector.MatchExpressions {
+		if expr.Key != "kubernetes.io/metadata.name" {
+			return true
+		}
+	}
+	return false
+}
+
+// timeoutString 格式化超时时间，未设置时为默认 10s
+func timeoutString(seconds int) string {
+	if seconds == 0 {
+		seconds = 10
+	}
+	return fmt.Sprintf("timeout %ds", seconds)
+}
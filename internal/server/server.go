@@ -0,0 +1,399 @@
+// Package server 以带认证的 REST API 提供会话中的扫描数据，并允许远程触发扫描（kctl serve），
+// 供仪表盘或团队服务器使用
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// APIPrefix API 路径前缀
+const APIPrefix = "/api/v1"
+
+// Runner 执行一条控制台命令，返回捕获的输出（无颜色）
+type Runner func(args []string) (string, error)
+
+// Server REST API 服务
+type Server struct {
+	sess  *session.Session
+	token string
+	run   Runner
+	mu    sync.Mutex // 命令串行执行
+}
+
+// New 创建服务，token 为客户端需要在 Authorization: Bearer 中携带的令牌
+func New(sess *session.Session, token string, run Runner) *Server {
+	return &Server{sess: sess, token: token, run: run}
+}
+
+// GenerateToken 生成随机 API 令牌
+func GenerateToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Handler 返回带认证的 HTTP 处理器
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+APIPrefix+"/status", s.handleStatus)
+	mux.HandleFunc("GET "+APIPrefix+"/pods", s.handlePods)
+	mux.HandleFunc("GET "+APIPrefix+"/serviceaccounts", s.handleServiceAccounts)
+	mux.HandleFunc("GET "+APIPrefix+"/findings", s.handleFindings)
+	mux.HandleFunc("GET "+APIPrefix+"/scans", s.handleScans)
+	mux.HandleFunc("GET "+APIPrefix+"/scans/{id}", s.handleScan)
+	mux.HandleFunc("POST "+APIPrefix+"/scans", s.handleTriggerScan)
+	mux.HandleFunc("POST "+APIPrefix+"/sync", s.handleSync)
+	return s.authenticate(s.reload(mux))
+}
+
+// reload 查看模式下每次请求前同步数据库中的最新结果（与控制台 --viewer 相同）
+func (s *Server) reload(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.sess.IsViewer() {
+			if err := s.sess.ReloadFromDB(); err != nil {
+				writeError(w, http.StatusInternalServerError, i18n.Errorf("读取数据库失败: %w", err))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticate 校验 Bearer 令牌（常量时间比较）
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="kctl"`)
+			writeError(w, http.StatusUnauthorized, i18n.New("未认证：需要 Authorization: Bearer <token>"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Status 会话状态
+type Status struct {
+	KubeletIP      string     `json:"kubeletIP"`
+	KubeletPort    int        `json:"kubeletPort"`
+	KubeletVersion string     `json:"kubeletVersion,omitempty"`
+	APIServer      string     `json:"apiServer,omitempty"`
+	Connected      bool       `json:"connected"`
+	ReadOnly       bool       `json:"readOnly"` // --viewer：不能触发扫描
+	LastScan       *time.Time `json:"lastScan,omitempty"`
+	Pods           int        `json:"pods"`
+	SAs            int        `json:"serviceAccounts"`
+	Findings       int        `json:"findings"`
+	Scans          int        `json:"scans"`
+	Scanning       bool       `json:"scanning"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	status := Status{
+		KubeletIP:      s.sess.Config.KubeletIP,
+		KubeletPort:    s.sess.Config.KubeletPort,
+		KubeletVersion: s.sess.KubeletVersion,
+		APIServer:      s.sess.Config.APIServer,
+		Connected:      s.sess.IsConnected,
+		ReadOnly:       s.sess.IsViewer(),
+		Pods:           len(s.sess.GetCachedPods()),
+	}
+	if !s.sess.LastScanTime.IsZero() {
+		t := s.sess.LastScanTime
+		status.LastScan = &t
+	}
+	status.SAs, _ = s.sess.SADB.Count()
+	status.Findings, _ = s.sess.FindingDB.Count()
+	status.Scans, _ = s.sess.ScanDB.Count()
+	if s.mu.TryLock() {
+		s.mu.Unlock()
+	} else {
+		status.Scanning = true
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// Pod 缓存中的 Pod
+type Pod struct {
+	Namespace      string              `json:"namespace"`
+	Name           string              `json:"name"`
+	UID            string              `json:"uid"`
+	Status         string              `json:"status"`
+	PodIP          string              `json:"podIP"`
+	HostIP         string              `json:"hostIP"`
+	NodeName       string              `json:"nodeName"`
+	ServiceAccount string              `json:"serviceAccount"`
+	CreatedAt      string              `json:"createdAt"`
+	Containers     []Container         `json:"containers"`
+	SecurityFlags  types.SecurityFlags `json:"securityFlags"`
+}
+
+// Container Pod 中的容器
+type Container struct {
+	Name       string `json:"name"`
+	Image      string `json:"image"`
+	State      string `json:"state"`
+	Privileged bool   `json:"privileged"`
+}
+
+// handlePods 支持 ?namespace= 过滤
+func (s *Server) handlePods(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	pods := []Pod{}
+	for _, pod := range s.sess.GetCachedPods() {
+		if namespace != "" && pod.Namespace != namespace {
+			continue
+		}
+		item := Pod{
+			Namespace:      pod.Namespace,
+			Name:           pod.PodName,
+			UID:            pod.UID,
+			Status:         pod.Status,
+			PodIP:          pod.PodIP,
+			HostIP:         pod.HostIP,
+			NodeName:       pod.NodeName,
+			ServiceAccount: pod.ServiceAccount,
+			CreatedAt:      pod.CreatedAt,
+			Containers:     []Container{},
+			SecurityFlags:  pod.SecurityFlags,
+		}
+		for _, c := range pod.Containers {
+			item.Containers = append(item.Containers, Container{Name: c.Name, Image: c.Image, State: c.State, Privileged: c.Privileged})
+		}
+		pods = append(pods, item)
+	}
+	writeJSON(w, http.StatusOK, pods)
+}
+
+// ServiceAccount 数据库中的 SA，JSON 字段已展开
+type ServiceAccount struct {
+	Namespace       string                `json:"namespace"`
+	Name            string                `json:"name"`
+	RiskLevel       string                `json:"riskLevel"`
+	IsClusterAdmin  bool                  `json:"isClusterAdmin"`
+	Token           string                `json:"token,omitempty"` // 只在 ?tokens=true 时返回
+	TokenExpiration string                `json:"tokenExpiration,omitempty"`
+	IsExpired       bool                  `json:"isExpired"`
+	Permissions     []types.SAPermission  `json:"permissions"` // 被动扫描时为空
+	SecurityFlags   types.SASecurityFlags `json:"securityFlags"`
+	Pods            []types.SAPodInfo     `json:"pods"`
+	Tags            []string              `json:"tags,omitempty"`
+	Note            string                `json:"note,omitempty"`
+	CollectedAt     time.Time             `json:"collectedAt"`
+	KubeletIP       string                `json:"kubeletIP"`
+	ScanID          int64                 `json:"scanId,omitempty"`
+}
+
+// handleServiceAccounts 支持 ?namespace=、?risky=true（ADMIN / CRITICAL / HIGH / MEDIUM）和 ?tokens=true
+func (s *Server) handleServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var records []*types.ServiceAccountRecord
+	var err error
+	if queryBool(q.Get("risky")) {
+		records, err = s.sess.SADB.GetRisky()
+	} else {
+		records, err = s.sess.SADB.GetAll()
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, i18n.Errorf("获取 ServiceAccount 失败: %w", err))
+		return
+	}
+
+	withTokens := queryBool(q.Get("tokens"))
+	sas := []ServiceAccount{}
+	for _, rec := range records {
+		if ns := q.Get("namespace"); ns != "" && rec.Namespace != ns {
+			continue
+		}
+		sa := ServiceAccount{
+			Namespace:       rec.Namespace,
+			Name:            rec.Name,
+			RiskLevel:       rec.RiskLevel,
+			IsClusterAdmin:  rec.IsClusterAdmin,
+			TokenExpiration: rec.TokenExpiration,
+			IsExpired:       rec.IsExpired,
+			Permissions:     []types.SAPermission{},
+			Pods:            []types.SAPodInfo{},
+			Tags:            rec.TagList(),
+			Note:            rec.Note,
+			CollectedAt:     rec.CollectedAt,
+			KubeletIP:       rec.KubeletIP,
+			ScanID:          rec.ScanID,
+		}
+		if withTokens {
+			sa.Token = rec.Token
+		}
+		if rec.IsClusterAdmin {
+			sa.RiskLevel = string(config.RiskAdmin)
+		}
+		_ = json.Unmarshal([]byte(rec.Permissions), &sa.Permissions)
+		_ = json.Unmarshal([]byte(rec.SecurityFlags), &sa.SecurityFlags)
+		_ = json.Unmarshal([]byte(rec.Pods), &sa.Pods)
+		sas = append(sas, sa)
+	}
+	writeJSON(w, http.StatusOK, sas)
+}
+
+// handleFindings 支持 ?source= 和 ?severity= 过滤
+func (s *Server) handleFindings(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	findings, err := s.sess.FindingDB.GetAll()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, i18n.Errorf("获取检查发现失败: %w", err))
+		return
+	}
+	result := []*types.FindingRecord{}
+	for _, f := range findings {
+		if source := q.Get("source"); source != "" && f.Source != source {
+			continue
+		}
+		if severity := q.Get("severity"); severity != "" && !strings.EqualFold(f.Severity, severity) {
+			continue
+		}
+		result = append(result, f)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleScans(w http.ResponseWriter, r *http.Request) {
+	scans, err := s.sess.ScanDB.GetAll()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, i18n.Errorf("读取扫描记录失败: %w", err))
+		return
+	}
+	if scans == nil {
+		scans = []*types.ScanRecord{}
+	}
+	writeJSON(w, http.StatusOK, scans)
+}
+
+// ScanDetail 一次扫描的记录及结果快照
+type ScanDetail struct {
+	*types.ScanRecord
+	Results []ScanResult `json:"results"`
+}
+
+// ScanResult 扫描快照中的一个 SA
+type ScanResult struct {
+	Namespace      string `json:"namespace"`
+	Name           string `json:"name"`
+	RiskLevel      string `json:"riskLevel"`
+	IsClusterAdmin bool   `json:"isClusterAdmin"`
+}
+
+func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, i18n.Errorf("无效的扫描 ID: %s", r.PathValue("id")))
+		return
+	}
+	scan, err := s.sess.ScanDB.Get(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, i18n.Errorf("读取扫描 #%d 失败: %w", id, err))
+		return
+	}
+	if scan == nil {
+		writeError(w, http.StatusNotFound, i18n.Errorf("扫描 #%d 不存在", id))
+		return
+	}
+	records, err := s.sess.ScanDB.GetResults(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, i18n.Errorf("读取扫描 #%d 结果失败: %w", id, err))
+		return
+	}
+	detail := ScanDetail{ScanRecord: scan, Results: []ScanResult{}}
+	for _, rec := range records {
+		level := rec.RiskLevel
+		if rec.IsClusterAdmin {
+			level = string(config.RiskAdmin)
+		}
+		detail.Results = append(detail.Results, ScanResult{Namespace: rec.Namespace, Name: rec.Name, RiskLevel: level, IsClusterAdmin: rec.IsClusterAdmin})
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
+
+// ScanRequest POST /scans 的请求体，可为空
+type ScanRequest struct {
+	Passive bool `json:"passive"` // sa scan --passive：不读取 Token、不检查权限
+}
+
+// ScanResponse POST /scans 的响应
+type ScanResponse struct {
+	Scan   *types.ScanRecord `json:"scan,omitempty"` // 本次扫描记录，没有扫描到 Pod 时为空
+	Output string            `json:"output"`         // 命令输出（无颜色）
+	Error  string            `json:"error,omitempty"`
+}
+
+// handleTriggerScan 同步执行 sa scan，已有命令在执行时返回 409
+func (s *Server) handleTriggerScan(w http.ResponseWriter, r *http.Request) {
+	if s.sess.IsViewer() {
+		writeError(w, http.StatusForbidden, i18n.New("只读模式 (--viewer) 下不能触发扫描"))
+		return
+	}
+	var req ScanRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64*1024)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, i18n.Errorf("无效的请求: %w", err))
+			return
+		}
+	}
+	if !s.mu.TryLock() {
+		writeError(w, http.StatusConflict, i18n.New("已有扫描正在执行"))
+		return
+	}
+	defer s.mu.Unlock()
+
+	before, _ := s.sess.ScanDB.Count()
+	args := []string{"sa", "scan", "--quiet"}
+	if req.Passive {
+		args = append(args, "--passive")
+	}
+	out, err := s.run(args)
+
+	resp := ScanResponse{Output: out}
+	if scans, _ := s.sess.ScanDB.GetAll(); len(scans) > before {
+		resp.Scan = scans[len(scans)-1]
+	}
+	if err != nil {
+		resp.Error = err.Error()
+		writeJSON(w, http.StatusBadGateway, resp)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// queryBool 查询参数是否为 true / 1 / yes
+func queryBool(v string) bool {
+	switch strings.ToLower(v) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,299 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
+	"kctl/internal/session"
+	"kctl/pkg/types"
+)
+
+// syncMaxBody 同步请求体上限
+const syncMaxBody = 256 << 20
+
+// SyncPayload 现场实例推送到团队服务器的数据
+type SyncPayload struct {
+	Operator        string                        `json:"operator"` // 推送者，如 user@host
+	KubeletIP       string                        `json:"kubeletIP"`
+	SentAt          time.Time                     `json:"sentAt"`
+	ServiceAccounts []*types.ServiceAccountRecord `json:"serviceAccounts"`
+	Findings        []*types.FindingRecord        `json:"findings"`
+	Pods            []types.PodContainerInfo      `json:"pods"`
+	Scans           []SyncScan                    `json:"scans"`
+}
+
+// SyncScan 一次扫描及其结果快照，ID 为现场实例中的扫描 ID
+type SyncScan struct {
+	Scan    *types.ScanRecord             `json:"scan"`
+	Results []*types.ServiceAccountRecord `json:"results"`
+}
+
+// SyncResult 合并结果
+type SyncResult struct {
+	SAsAdded     int `json:"serviceAccountsAdded"`
+	SAsUpdated   int `json:"serviceAccountsUpdated"`
+	SAsKept      int `json:"serviceAccountsKept"` // 服务器上的记录同样完整且不早于推送的，未覆盖
+	Findings     int `json:"findings"`
+	Pods         int `json:"pods"`
+	ScansAdded   int `json:"scansAdded"`
+	ScansSkipped int `json:"scansSkipped"` // 已同步过的扫描
+}
+
+// BuildSyncPayload 收集会话中的全部结果；withTokens 为 false 时不推送 SA Token
+func BuildSyncPayload(sess *session.Session, operator string, withTokens bool) (*SyncPayload, error) {
+	payload := &SyncPayload{
+		Operator:  operator,
+		KubeletIP: sess.Config.KubeletIP,
+		SentAt:    time.Now(),
+		Pods:      sess.GetCachedPods(),
+	}
+
+	sas, err := sess.SADB.GetAll()
+	if err != nil {
+		return nil, i18n.Errorf("获取 ServiceAccount 失败: %w", err)
+	}
+	if !withTokens {
+		for _, sa := range sas {
+			sa.Token = ""
+		}
+	}
+	payload.ServiceAccounts = sas
+
+	if payload.Findings, err = sess.FindingDB.GetAll(); err != nil {
+		return nil, i18n.Errorf("获取检查发现失败: %w", err)
+	}
+
+	scans, err := sess.ScanDB.GetAll()
+	if err != nil {
+		return nil, i18n.Errorf("读取扫描记录失败: %w", err)
+	}
+	for _, scan := range scans {
+		results, err := sess.ScanDB.GetResults(scan.ID)
+		if err != nil {
+			return nil, i18n.Errorf("读取扫描 #%d 结果失败: %w", scan.ID, err)
+		}
+		payload.Scans = append(payload.Scans, SyncScan{Scan: scan, Results: results})
+	}
+	return payload, nil
+}
+
+// Merge 将现场实例推送的数据合并到会话数据库：
+//   - 扫描按 目标 + 开始时间 去重，重复推送不会产生重复记录
+//   - SA 按 namespace/name 去重：检查过权限的记录优先于被动扫描的记录，同等情况下保留较新的记录；
+//     服务器上已有的备注和标签保留，服务器上没有时使用推送的
+//   - 检查发现按 来源 + 位置 覆盖（与本地保存相同）
+//   - Pod 按节点替换：推送中出现的节点 (HostIP) 上的旧 Pod 被替换，其他节点的 Pod 保留
+func Merge(sess *session.Session, payload *SyncPayload) (*SyncResult, error) {
+	result := &SyncResult{}
+
+	scanIDs, err := mergeScans(sess, payload.Scans, result)
+	if err != nil {
+		return nil, err
+	}
+	if err := mergeServiceAccounts(sess, payload.ServiceAccounts, scanIDs, result); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Findings) > 0 {
+		n, err := sess.FindingDB.SaveBatch(payload.Findings)
+		if err != nil {
+			return nil, err
+		}
+		result.Findings = n
+	}
+
+	if len(payload.Pods) > 0 {
+		sess.CachePods(mergePods(sess.GetCachedPods(), payload.Pods))
+		result.Pods = len(payload.Pods)
+	}
+	if result.ScansAdded > 0 {
+		sess.MarkScanned()
+	}
+	return result, nil
+}
+
+// mergeScans 保存未同步过的扫描，返回 现场扫描 ID -> 服务器扫描 ID
+func mergeScans(sess *session.Session, scans []SyncScan, result *SyncResult) (map[int64]int64, error) {
+	existing, err := sess.ScanDB.GetAll()
+	if err != nil {
+		return nil, i18n.Errorf("读取扫描记录失败: %w", err)
+	}
+	known := make(map[string]int64, len(existing))
+	for _, s := range existing {
+		known[scanKey(s)] = s.ID
+	}
+
+	ids := make(map[int64]int64)
+	for _, s := range scans {
+		if s.Scan == nil {
+			continue
+		}
+		if id, ok := known[scanKey(s.Scan)]; ok {
+			ids[s.Scan.ID] = id
+			result.ScansSkipped++
+			continue
+		}
+		record := *s.Scan
+		record.ID = 0
+		id, err := sess.ScanDB.Create(&record, s.Results)
+		if err != nil {
+			return nil, err
+		}
+		ids[s.Scan.ID] = id
+		known[scanKey(&record)] = id
+		result.ScansAdded++
+	}
+	return ids, nil
+}
+
+// scanKey 扫描的去重键
+func scanKey(s *types.ScanRecord) string {
+	return s.Target + "@" + s.StartedAt.UTC().Format(time.RFC3339Nano)
+}
+
+// mergeServiceAccounts 按 namespace/name 合并 SA
+func mergeServiceAccounts(sess *session.Session, sas []*types.ServiceAccountRecord, scanIDs map[int64]int64, result *SyncResult) error {
+	for _, incoming := range sas {
+		current, err := sess.SADB.GetByName(incoming.Namespace, incoming.Name)
+		if err != nil {
+			return i18n.Errorf("读取 SA %s/%s 失败: %w", incoming.Namespace, incoming.Name, err)
+		}
+		if current != nil && !preferIncoming(current, incoming) {
+			result.SAsKept++
+			continue
+		}
+
+		record := *incoming
+		record.ScanID = scanIDs[incoming.ScanID]
+		if current != nil && record.Token == "" && current.Token != "" && record.PermissionsChecked() {
+			record.Token, record.TokenExpiration, record.IsExpired = current.Token, current.TokenExpiration, current.IsExpired
+		}
+		if err := sess.SADB.Save(&record); err != nil {
+			return i18n.Errorf("保存 SA %s/%s 失败: %w", record.Namespace, record.Name, err)
+		}
+		if current == nil {
+			result.SAsAdded++
+		} else {
+			result.SAsUpdated++
+		}
+
+		// 备注和标签只补充，不覆盖服务器上已有的
+		if record.Note != "" && (current == nil || current.Note == "") {
+			_ = sess.SADB.SetNote(record.Namespace, record.Name, record.Note)
+		}
+		if record.Tags != "" && (current == nil || current.Tags == "") {
+			_ = sess.SADB.SetTags(record.Namespace, record.Name, record.TagList())
+		}
+	}
+	return nil
+}
+
+// preferIncoming 推送的记录是否比服务器上的更完整或更新
+func preferIncoming(current, incoming *types.ServiceAccountRecord) bool {
+	if current.PermissionsChecked() != incoming.PermissionsChecked() {
+		return incoming.PermissionsChecked()
+	}
+	return incoming.CollectedAt.After(current.CollectedAt)
+}
+
+// mergePods 用推送的 Pod 替换相同节点上的 Pod
+func mergePods(current, incoming []types.PodContainerInfo) []types.PodContainerInfo {
+	nodes := make(map[string]bool)
+	for _, pod := range incoming {
+		nodes[pod.HostIP] = true
+	}
+	merged := make([]types.PodContainerInfo, 0, len(current)+len(incoming))
+	for _, pod := range current {
+		if !nodes[pod.HostIP] {
+			merged = append(merged, pod)
+		}
+	}
+	return append(merged, incoming...)
+}
+
+// handleSync 接收现场实例推送的数据并合并
+func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
+	if s.sess.IsViewer() {
+		writeError(w, http.StatusForbidden, i18n.New("只读模式 (--viewer) 下不能接收同步"))
+		return
+	}
+	var payload SyncPayload
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, syncMaxBody)).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, i18n.Errorf("无效的请求: %w", err))
+		return
+	}
+
+	// 与触发的扫描串行，避免同时写入
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := Merge(s.sess, &payload)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	p := s.sess.Printer
+	p.Printf("%s Sync from %s (%s): %d SAs added, %d updated, %d scans added\n",
+		p.Colored(config.ColorBlue, "[*]"), payload.Operator, payload.KubeletIP, result.SAsAdded, result.SAsUpdated, result.ScansAdded)
+	writeJSON(w, http.StatusOK, result)
+}
+
+// Push 将数据推送到团队服务器的 /api/v1/sync
+// insecure 为 true 时不校验服务器证书（团队服务器使用自签名证书时）
+func Push(ctx context.Context, serverURL, token string, insecure bool, payload *SyncPayload) (*SyncResult, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*config.DefaultHTTPTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(serverURL, "/")+APIPrefix+"/sync", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var transport http.RoundTripper = http.DefaultTransport
+	if insecure {
+		transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+	httpClient := &http.Client{Transport: logging.Transport(transport)}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return nil, i18n.Errorf("服务器返回 %s: %s", resp.Status, apiErr.Error)
+		}
+		return nil, i18n.Errorf("服务器返回 %s", resp.Status)
+	}
+	var result SyncResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, i18n.Errorf("解析响应失败: %w", err)
+	}
+	return &result, nil
+}
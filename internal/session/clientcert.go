@@ -0,0 +1,83 @@
+package session
+
+import (
+	"crypto/tls"
+	"os"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/kubeconfig"
+)
+
+// hasCredentials 是否设置了 Token 或客户端证书
+func (s *Session) hasCredentials() bool {
+	return s.Config.Token != "" || s.Config.ClientCert != ""
+}
+
+// HasCredentials 是否设置了 Token 或客户端证书
+func (s *Session) HasCredentials() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.hasCredentials()
+}
+
+// LoadClientCert 从文件加载客户端证书
+// 文件中同时包含私钥时（如 kubelet-client-current.pem）一并设置私钥
+func (s *Session) LoadClientCert(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return i18n.Errorf("读取证书文件失败: %w", err)
+	}
+	certPEM, keyPEM := kubeconfig.SplitPEM(data)
+	if len(certPEM) == 0 {
+		return i18n.Errorf("文件中未找到 PEM 证书: %s", path)
+	}
+
+	s.Config.ClientCert = string(certPEM)
+	s.Config.ClientCertFile = path
+	if len(keyPEM) > 0 {
+		s.Config.ClientKey = string(keyPEM)
+		s.Config.ClientKeyFile = path
+	}
+	return nil
+}
+
+// LoadClientKey 从文件加载客户端私钥
+func (s *Session) LoadClientKey(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return i18n.Errorf("读取私钥文件失败: %w", err)
+	}
+	_, keyPEM := kubeconfig.SplitPEM(data)
+	if len(keyPEM) == 0 {
+		return i18n.Errorf("文件中未找到 PEM 私钥: %s", path)
+	}
+
+	s.Config.ClientKey = string(keyPEM)
+	s.Config.ClientKeyFile = path
+	return nil
+}
+
+// SetClientCert 直接设置客户端证书和私钥（PEM），source 为来源说明
+func (s *Session) SetClientCert(certPEM, keyPEM, source string) {
+	s.Config.ClientCert = certPEM
+	s.Config.ClientKey = keyPEM
+	s.Config.ClientCertFile = source
+	s.Config.ClientKeyFile = source
+}
+
+// ClearClientCert 清除客户端证书认证
+func (s *Session) ClearClientCert() {
+	s.SetClientCert("", "", "")
+}
+
+// ValidateClientCert 校验证书与私钥是否匹配，返回证书中的身份
+// 尚未同时设置证书和私钥时返回 nil, nil
+func (s *Session) ValidateClientCert() (*kubeconfig.CertInfo, error) {
+	if s.Config.ClientCert == "" || s.Config.ClientKey == "" {
+		return nil, nil
+	}
+	if _, err := tls.X509KeyPair([]byte(s.Config.ClientCert), []byte(s.Config.ClientKey)); err != nil {
+		return nil, i18n.Errorf("证书与私钥不匹配: %w", err)
+	}
+	return kubeconfig.ParseClientCert([]byte(s.Config.ClientCert))
+}
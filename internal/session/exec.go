@@ -0,0 +1,98 @@
+package session
+
+import (
+	"kctl/config"
+	"kctl/internal/i18n"
+	"kctl/internal/transport"
+)
+
+// GetExecTransport 根据 exec-via 配置返回执行通道
+//
+// auto 模式下配置了 Kubelet 时依次协商 websocket → spdy → api → nodes-proxy → run，
+// 未配置 Kubelet 时只协商 api → nodes-proxy
+func (s *Session) GetExecTransport() (transport.ExecTransport, error) {
+	if s.Config.ExecVia != config.ExecViaAuto && s.Config.ExecVia != "" {
+		return s.ExecTransportByName(s.Config.ExecVia)
+	}
+
+	names := []string{config.ExecViaAPI, config.ExecViaNodeProxy}
+	if s.Config.KubeletIP != "" {
+		names = []string{config.ExecViaWebSocket, config.ExecViaSPDY, config.ExecViaAPI, config.ExecViaNodeProxy, config.ExecViaRun}
+	}
+
+	var candidates []transport.ExecTransport
+	for _, name := range names {
+		t, err := s.ExecTransportByName(name)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, t)
+	}
+	return transport.NewAuto(s.Printer, candidates...), nil
+}
+
+// ExecTransportByName 按名称创建单个执行通道（kubelet 为 websocket 的别名）
+func (s *Session) ExecTransportByName(name string) (transport.ExecTransport, error) {
+	switch name {
+	case config.ExecViaWebSocket, config.ExecViaKubelet, config.ExecViaSPDY, config.ExecViaRun:
+		kubelet, err := s.GetKubeletClient()
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case config.ExecViaSPDY:
+			return transport.NewSPDY(kubelet), nil
+		case config.ExecViaRun:
+			return transport.NewRun(kubelet), nil
+		default:
+			return transport.NewWebSocket(kubelet), nil
+		}
+
+	case config.ExecViaAPI, config.ExecViaNodeProxy:
+		k8s, err := s.GetK8sClient(s.GetActiveToken())
+		if err != nil {
+			return nil, err
+		}
+		if name == config.ExecViaAPI {
+			return transport.NewAPI(k8s), nil
+		}
+		return transport.NewNodeProxy(k8s, s.podNode), nil
+
+	default:
+		return nil, i18n.Errorf("未知的执行通道: %s", name)
+	}
+}
+
+// KubeletExecTransport 返回经由指定 Kubelet 的执行通道，使用客户端池中该 Kubelet 的客户端（用于 exec --all-nodes）
+// auto 模式下依次协商 websocket → spdy → run；api 和 nodes-proxy 不直接连接 Kubelet，不能用于多节点执行
+func (s *Session) KubeletExecTransport(ip string, port int) (transport.ExecTransport, error) {
+	switch s.Config.ExecVia {
+	case config.ExecViaAPI, config.ExecViaNodeProxy:
+		return nil, i18n.Errorf("执行通道 %s 不直接连接 Kubelet，多节点执行请使用 'set exec-via auto'", s.Config.ExecVia)
+	}
+
+	kubelet, err := s.KubeletClientFor(ip, port)
+	if err != nil {
+		return nil, err
+	}
+	switch s.Config.ExecVia {
+	case config.ExecViaSPDY:
+		return transport.NewSPDY(kubelet), nil
+	case config.ExecViaRun:
+		return transport.NewRun(kubelet), nil
+	case config.ExecViaWebSocket, config.ExecViaKubelet:
+		return transport.NewWebSocket(kubelet), nil
+	default:
+		return transport.NewAuto(s.Printer, transport.NewWebSocket(kubelet), transport.NewSPDY(kubelet), transport.NewRun(kubelet)), nil
+	}
+}
+
+// podNode 从 Pod 缓存中查找 Pod 所在节点
+func (s *Session) podNode(namespace, pod string) string {
+	for _, p := range s.GetCachedPods() {
+		if p.Namespace == namespace && p.PodName == pod {
+			return p.NodeName
+		}
+	}
+	return ""
+}
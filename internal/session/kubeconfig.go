@@ -0,0 +1,150 @@
+package session
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"kctl/internal/i18n"
+	"kctl/pkg/kubeconfig"
+	"kctl/pkg/token"
+)
+
+// LoadKubeconfig 从 kubeconfig 加载 API Server 地址、CA 和认证信息（Token 或客户端证书）
+// contextName 为空时使用 current-context，未设置 current-context 时使用第一个上下文
+// kubeconfig 中的身份整体替换当前 Token / 客户端证书，返回使用的上下文
+func (s *Session) LoadKubeconfig(path, contextName string) (*kubeconfig.Entry, error) {
+	cfg, err := readKubeconfig(path)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := selectContext(cfg, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	// kubeconfig 中引用的相对路径相对于 kubeconfig 所在目录
+	dir := filepath.Dir(path)
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+
+	tokenStr, tokenFile := entry.Token, ""
+	if tokenStr == "" && entry.TokenFile != "" {
+		tokenFile = resolve(entry.TokenFile)
+		if tokenStr, err = token.Read(tokenFile); err != nil {
+			return nil, i18n.Errorf("读取 tokenFile 失败: %w", err)
+		}
+	}
+	certPEM, err := dataOrFile(entry.ClientCertData, resolve(entry.ClientCertFile))
+	if err != nil {
+		return nil, i18n.Errorf("读取 client-certificate 失败: %w", err)
+	}
+	keyPEM, err := dataOrFile(entry.ClientKeyData, resolve(entry.ClientKeyFile))
+	if err != nil {
+		return nil, i18n.Errorf("读取 client-key 失败: %w", err)
+	}
+	caPEM, err := dataOrFile(entry.CAData, resolve(entry.CAFile))
+	if err != nil {
+		return nil, i18n.Errorf("读取 certificate-authority 失败: %w", err)
+	}
+
+	if tokenStr == "" && len(certPEM) == 0 {
+		return nil, i18n.Errorf("上下文 %s 的用户 %s 没有 Token 或客户端证书（不支持 exec / auth-provider 插件）", entry.Context, entry.User)
+	}
+	if len(certPEM) > 0 && len(keyPEM) == 0 {
+		return nil, i18n.Errorf("上下文 %s 的用户 %s 缺少 client-key", entry.Context, entry.User)
+	}
+
+	if entry.Server != "" {
+		if err := s.SetAPIServerURL(entry.Server); err != nil {
+			return nil, err
+		}
+	}
+	s.Config.Token = tokenStr
+	s.Config.TokenFile = tokenFile
+	if len(certPEM) > 0 {
+		s.SetClientCert(string(certPEM), string(keyPEM), fmt.Sprintf("%s (%s)", path, entry.Context))
+	} else {
+		s.ClearClientCert()
+	}
+	s.Config.CACert = string(caPEM)
+	s.Config.Kubeconfig = path
+	s.Config.KubeContext = entry.Context
+	return entry, nil
+}
+
+// KubeconfigContexts 返回已加载 kubeconfig 中的上下文名称
+func (s *Session) KubeconfigContexts() []string {
+	if s.Config.Kubeconfig == "" {
+		return nil
+	}
+	cfg, err := readKubeconfig(s.Config.Kubeconfig)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(cfg.Entries))
+	for i, e := range cfg.Entries {
+		names[i] = e.Context
+	}
+	return names
+}
+
+// SetAPIServerURL 从 URL（如 https://10.0.0.1:6443）设置 API Server 地址和端口，未指定端口时为 443
+func (s *Session) SetAPIServerURL(server string) error {
+	u, err := url.Parse(server)
+	if err != nil || u.Hostname() == "" {
+		return i18n.Errorf("无效的 API Server 地址: %s", server)
+	}
+	s.Config.APIServer = u.Hostname()
+	s.Config.APIServerPort = 443
+	if port, err := strconv.Atoi(u.Port()); err == nil {
+		s.Config.APIServerPort = port
+	}
+	return nil
+}
+
+// readKubeconfig 读取并解析 kubeconfig 文件
+func readKubeconfig(path string) (*kubeconfig.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取 kubeconfig 失败: %w", err)
+	}
+	return kubeconfig.Parse(data)
+}
+
+// selectContext 按名称选择上下文，名称为空时使用 current-context 或第一个上下文
+func selectContext(cfg *kubeconfig.Config, name string) (*kubeconfig.Entry, error) {
+	if len(cfg.Entries) == 0 {
+		return nil, i18n.Errorf("kubeconfig 中没有可用的上下文")
+	}
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+	if name == "" {
+		return &cfg.Entries[0], nil
+	}
+
+	var names []string
+	for i := range cfg.Entries {
+		if cfg.Entries[i].Context == name {
+			return &cfg.Entries[i], nil
+		}
+		names = append(names, cfg.Entries[i].Context)
+	}
+	return nil, i18n.Errorf("上下文不存在: %s (可用: %s)", name, strings.Join(names, ", "))
+}
+
+// dataOrFile 优先使用内联数据，否则读取文件，两者均为空时返回 nil
+func dataOrFile(data []byte, path string) ([]byte, error) {
+	if len(data) > 0 || path == "" {
+		return data, nil
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,290 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"kctl/internal/client"
+	k8sclient "kctl/internal/client/k8s"
+	kubeletclient "kctl/internal/client/kubelet"
+	"kctl/internal/logging"
+	"kctl/pkg/token"
+	"kctl/pkg/types"
+)
+
+// SetCommand 设置当前执行的控制台命令，操作记录中用于说明触发操作的命令
+// 返回之前的命令，嵌套执行（source、star）结束后用于恢复
+func (s *Session) SetCommand(line string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.command
+	s.command = line
+	return prev
+}
+
+// RecordOperation 记录一次对集群的修改或执行操作，查看模式下不记录
+func (s *Session) RecordOperation(op *types.OperationRecord, err error) {
+	if s.IsViewer() {
+		return
+	}
+	s.mu.RLock()
+	op.Command = s.command
+	s.mu.RUnlock()
+	if op.Time.IsZero() {
+		op.Time = time.Now()
+	}
+	op.Result = types.OpResultOK
+	if err != nil {
+		op.Result = types.OpResultError
+		op.Error = err.Error()
+	}
+
+	logging.Info("operation", logging.Fields{
+		"action": op.Action, "target": op.Target, "detail": op.Detail,
+		"identity": op.Identity, "via": op.Via, "result": op.Result, "error": op.Error,
+	})
+	if saveErr := s.OpDB.Save(op); saveErr != nil {
+		logging.Warn("operation not recorded", logging.Fields{"error": saveErr.Error()})
+	}
+}
+
+// clientIdentity 描述客户端使用的身份：SA Token、其他 Token 或客户端证书 CN
+func clientIdentity(tokenStr string, cfg *client.Config) string {
+	identity := ""
+	switch {
+	case tokenStr != "":
+		if info, err := token.Parse(tokenStr); err == nil && info.ServiceAccount != "" {
+			identity = fmt.Sprintf("system:serviceaccount:%s:%s", info.Namespace, info.ServiceAccount)
+		} else {
+			// 非 SA Token 只记录指纹，不在操作记录中保存凭据
+			sum := sha256.Sum256([]byte(tokenStr))
+			identity = "token sha256:" + hex.EncodeToString(sum[:4])
+		}
+	case len(cfg.ClientCertPEM) > 0:
+		identity = "cert"
+		if block, _ := pem.Decode(cfg.ClientCertPEM); block != nil {
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				identity = "cert " + cert.Subject.CommonName
+			}
+		}
+	}
+	return identity
+}
+
+// execTarget 返回 namespace/pod[/container] 形式的目标
+func execTarget(namespace, pod, container string) string {
+	target := namespace + "/" + pod
+	if container != "" {
+		target += "/" + container
+	}
+	return target
+}
+
+// oplogKubelet 记录 exec、run 和端口转发操作的 Kubelet 客户端，安全模式下拒绝这些操作
+type oplogKubelet struct {
+	kubeletclient.Client
+	sess     *Session
+	via      string
+	identity string
+}
+
+func (s *Session) wrapKubelet(c kubeletclient.Client, cfg *client.Config, ip string, port int) kubeletclient.Client {
+	return &oplogKubelet{
+		Client:   c,
+		sess:     s,
+		via:      fmt.Sprintf("kubelet %s:%d", ip, port),
+		identity: clientIdentity(s.Config.Token, cfg),
+	}
+}
+
+// deny 安全模式下拒绝操作
+func (k *oplogKubelet) deny(action, namespace, pod, container string) error {
+	return k.sess.denyOperation(action, execTarget(namespace, pod, container))
+}
+
+func (k *oplogKubelet) record(action, namespace, pod, container, detail string, err error) {
+	k.sess.RecordOperation(&types.OperationRecord{
+		Action:   action,
+		Target:   execTarget(namespace, pod, container),
+		Detail:   detail,
+		Identity: k.identity,
+		Via:      k.via,
+	}, err)
+}
+
+func (k *oplogKubelet) Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	if err := k.deny(types.OpExec, opts.Namespace, opts.Pod, opts.Container); err != nil {
+		return nil, err
+	}
+	result, err := k.Client.Exec(ctx, opts)
+	k.record(types.OpExec, opts.Namespace, opts.Pod, opts.Container, strings.Join(opts.Command, " "), err)
+	return result, err
+}
+
+func (k *oplogKubelet) ExecInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	if err := k.deny(types.OpExecInteractive, opts.Namespace, opts.Pod, opts.Container); err != nil {
+		return err
+	}
+	err := k.Client.ExecInteractive(ctx, opts)
+	k.record(types.OpExecInteractive, opts.Namespace, opts.Pod, opts.Container, strings.Join(opts.Command, " "), err)
+	return err
+}
+
+func (k *oplogKubelet) ExecSPDY(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	if err := k.deny(types.OpExec, opts.Namespace, opts.Pod, opts.Container); err != nil {
+		return nil, err
+	}
+	result, err := k.Client.ExecSPDY(ctx, opts)
+	k.record(types.OpExec, opts.Namespace, opts.Pod, opts.Container, strings.Join(opts.Command, " "), err)
+	return result, err
+}
+
+func (k *oplogKubelet) ExecSPDYInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	if err := k.deny(types.OpExecInteractive, opts.Namespace, opts.Pod, opts.Container); err != nil {
+		return err
+	}
+	err := k.Client.ExecSPDYInteractive(ctx, opts)
+	k.record(types.OpExecInteractive, opts.Namespace, opts.Pod, opts.Container, strings.Join(opts.Command, " "), err)
+	return err
+}
+
+func (k *oplogKubelet) ExecStream(ctx context.Context, opts *types.ExecOptions) (*client.ExecStream, error) {
+	if err := k.deny(types.OpExec, opts.Namespace, opts.Pod, opts.Container); err != nil {
+		return nil, err
+	}
+	stream, err := k.Client.ExecStream(ctx, opts)
+	k.record(types.OpExec, opts.Namespace, opts.Pod, opts.Container, strings.Join(opts.Command, " "), err)
+	return stream, err
+}
+
+func (k *oplogKubelet) Run(ctx context.Context, opts *types.RunOptions) (*types.RunResult, error) {
+	if err := k.deny(types.OpRun, opts.Namespace, opts.Pod, opts.Container); err != nil {
+		return nil, err
+	}
+	result, err := k.Client.Run(ctx, opts)
+	k.record(types.OpRun, opts.Namespace, opts.Pod, opts.Container, opts.Command, err)
+	return result, err
+}
+
+func (k *oplogKubelet) PortForward(ctx context.Context, opts *types.PortForwardOptions, stopChan <-chan struct{}) error {
+	if err := k.deny(types.OpPortForward, opts.Namespace, opts.Pod, ""); err != nil {
+		return err
+	}
+	var ports []string
+	for _, p := range opts.Ports {
+		ports = append(ports, strconv.Itoa(int(p.Local))+":"+strconv.Itoa(int(p.Remote)))
+	}
+	err := k.Client.PortForward(ctx, opts, stopChan)
+	k.record(types.OpPortForward, opts.Namespace, opts.Pod, "", strings.Join(ports, ","), err)
+	return err
+}
+
+// oplogK8s 记录 exec、临时容器和资源创建/删除操作的 API Server 客户端，安全模式下拒绝这些操作
+type oplogK8s struct {
+	k8sclient.Client
+	sess     *Session
+	via      string
+	identity string
+}
+
+func (s *Session) wrapK8s(c k8sclient.Client, tokenStr string, cfg *client.Config) k8sclient.Client {
+	identity := clientIdentity(tokenStr, cfg)
+	if cfg.ImpersonateUser != "" {
+		identity += " as " + cfg.ImpersonateUser
+	}
+	return &oplogK8s{
+		Client:   c,
+		sess:     s,
+		via:      "api " + s.APIServerURL(),
+		identity: identity,
+	}
+}
+
+func (k *oplogK8s) record(action, target, detail, via string, err error) {
+	k.sess.RecordOperation(&types.OperationRecord{
+		Action:   action,
+		Target:   target,
+		Detail:   detail,
+		Identity: k.identity,
+		Via:      via,
+	}, err)
+}
+
+func (k *oplogK8s) Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	if err := k.sess.denyOperation(types.OpExec, execTarget(opts.Namespace, opts.Pod, opts.Container)); err != nil {
+		return nil, err
+	}
+	result, err := k.Client.Exec(ctx, opts)
+	k.record(types.OpExec, execTarget(opts.Namespace, opts.Pod, opts.Container), strings.Join(opts.Command, " "), k.via, err)
+	return result, err
+}
+
+func (k *oplogK8s) ExecInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	if err := k.sess.denyOperation(types.OpExecInteractive, execTarget(opts.Namespace, opts.Pod, opts.Container)); err != nil {
+		return err
+	}
+	err := k.Client.ExecInteractive(ctx, opts)
+	k.record(types.OpExecInteractive, execTarget(opts.Namespace, opts.Pod, opts.Container), strings.Join(opts.Command, " "), k.via, err)
+	return err
+}
+
+func (k *oplogK8s) NodeProxyExec(ctx context.Context, node string, opts *types.ExecOptions) (*types.ExecResult, error) {
+	if err := k.sess.denyOperation(types.OpExec, execTarget(opts.Namespace, opts.Pod, opts.Container)); err != nil {
+		return nil, err
+	}
+	result, err := k.Client.NodeProxyExec(ctx, node, opts)
+	k.record(types.OpExec, execTarget(opts.Namespace, opts.Pod, opts.Container), strings.Join(opts.Command, " "), k.via+" nodes/"+node+"/proxy", err)
+	return result, err
+}
+
+func (k *oplogK8s) NodeProxyExecInteractive(ctx context.Context, node string, opts *types.ExecOptions) error {
+	if err := k.sess.denyOperation(types.OpExecInteractive, execTarget(opts.Namespace, opts.Pod, opts.Container)); err != nil {
+		return err
+	}
+	err := k.Client.NodeProxyExecInteractive(ctx, node, opts)
+	k.record(types.OpExecInteractive, execTarget(opts.Namespace, opts.Pod, opts.Container), strings.Join(opts.Command, " "), k.via+" nodes/"+node+"/proxy", err)
+	return err
+}
+
+func (k *oplogK8s) AddEphemeralContainer(ctx context.Context, opts *types.EphemeralContainerOptions) error {
+	if err := k.sess.denyOperation(types.OpEphemeral, execTarget(opts.Namespace, opts.Pod, opts.Name)); err != nil {
+		return err
+	}
+	err := k.Client.AddEphemeralContainer(ctx, opts)
+	k.record(types.OpEphemeral, execTarget(opts.Namespace, opts.Pod, opts.Name), opts.Image, k.via, err)
+	return err
+}
+
+func (k *oplogK8s) Attach(ctx context.Context, opts *types.ExecOptions) error {
+	if err := k.sess.denyOperation(types.OpAttach, execTarget(opts.Namespace, opts.Pod, opts.Container)); err != nil {
+		return err
+	}
+	err := k.Client.Attach(ctx, opts)
+	k.record(types.OpAttach, execTarget(opts.Namespace, opts.Pod, opts.Container), "", k.via, err)
+	return err
+}
+
+func (k *oplogK8s) Create(ctx context.Context, ref types.ResourceRef, body []byte) error {
+	if err := k.sess.denyOperation(types.OpCreate, ref.String()); err != nil {
+		return err
+	}
+	err := k.Client.Create(ctx, ref, body)
+	k.record(types.OpCreate, ref.String(), ref.APIVersion, k.via, err)
+	return err
+}
+
+func (k *oplogK8s) Delete(ctx context.Context, ref types.ResourceRef) error {
+	if err := k.sess.denyOperation(types.OpDelete, ref.String()); err != nil {
+		return err
+	}
+	err := k.Client.Delete(ctx, ref)
+	k.record(types.OpDelete, ref.String(), ref.APIVersion, k.via, err)
+	return err
+}
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"strings"
+	"time"
+
+	"kctl/internal/client"
+)
+
+// SetRateLimit 设置发往 Kubelet 和 API Server 的每秒最多请求数，<= 0 时不限速
+// 已创建的客户端共享同一 Pacer，无需重新连接
+func (s *Session) SetRateLimit(perSecond float64) {
+	if perSecond < 0 {
+		perSecond = 0
+	}
+	s.Config.RateLimit = perSecond
+	s.applyPacing()
+}
+
+// SetJitter 设置每个请求前的随机延迟范围，maxDelay 为 0 时关闭
+func (s *Session) SetJitter(minDelay, maxDelay time.Duration) {
+	if maxDelay == 0 {
+		minDelay = 0
+	}
+	s.Config.JitterMin, s.Config.JitterMax = minDelay, maxDelay
+	s.applyPacing()
+}
+
+// IsPaced 是否设置了限速或随机延迟
+func (s *Session) IsPaced() bool {
+	return s.Config.RateLimit > 0 || s.Config.JitterMax > 0
+}
+
+// PacingSummary 描述当前的限速和随机延迟，如 "rate-limit 5/s, jitter 200ms-800ms"，未设置时返回空字符串
+func (s *Session) PacingSummary() string {
+	var parts []string
+	if rate := client.FormatRate(s.Config.RateLimit); rate != "" {
+		parts = append(parts, "rate-limit "+rate)
+	}
+	if jitter := client.FormatJitter(s.Config.JitterMin, s.Config.JitterMax); jitter != "" {
+		parts = append(parts, "jitter "+jitter)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// applyPacing 将会话配置中的限速和随机延迟同步到共享的 Pacer
+func (s *Session) applyPacing() {
+	s.pacer.SetRate(s.Config.RateLimit)
+	s.pacer.SetJitter(s.Config.JitterMin, s.Config.JitterMax)
+}
@@ -0,0 +1,98 @@
+package session
+
+import (
+	"net"
+	"slices"
+	"strconv"
+
+	"kctl/config"
+	kubeletclient "kctl/internal/client/kubelet"
+	"kctl/internal/i18n"
+	"kctl/pkg/limiter"
+)
+
+// KubeletKey 返回 Kubelet 在客户端池和工作池中的键（ip:port）
+func KubeletKey(ip string, port int) string {
+	return net.JoinHostPort(ip, strconv.Itoa(port))
+}
+
+// CurrentKubeletKey 返回当前目标 Kubelet 的键，缓存的 Pod 都运行在该节点上
+func (s *Session) CurrentKubeletKey() string {
+	return KubeletKey(s.Config.KubeletIP, s.Config.KubeletPort)
+}
+
+// KubeletClientFor 返回指定 Kubelet 的客户端（使用会话的凭据和连接设置），按目标缓存，同一 Kubelet 的请求复用连接
+// 连接设置变化（Disconnect / Connect）后池中的客户端重新创建
+func (s *Session) KubeletClientFor(ip string, port int) (kubeletclient.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.hasCredentials() {
+		return nil, i18n.Errorf("未设置 Token 或客户端证书，请使用 'set token <token>'、'set token-file <path>' 或 'set client-cert <path>' 设置")
+	}
+	if port == 0 {
+		port = s.Config.KubeletPort
+	}
+	if s.clientConfig == nil {
+		s.clientConfig = s.newClientConfig()
+	}
+	return s.pooledKubelet(ip, port)
+}
+
+// pooledKubelet 从客户端池获取 Kubelet 客户端，不存在时使用 s.clientConfig 创建，调用方需持有 s.mu
+func (s *Session) pooledKubelet(ip string, port int) (kubeletclient.Client, error) {
+	key := KubeletKey(ip, port)
+	if c, ok := s.kubeletPool[key]; ok {
+		return c, nil
+	}
+
+	cfg := s.clientConfig
+	kubelet, err := kubeletclient.NewClient(ip, port, s.Config.Token, cfg)
+	if err != nil {
+		return nil, i18n.Errorf("创建 Kubelet 客户端失败: %w", err)
+	}
+	c := s.wrapKubelet(kubelet, cfg, ip, port)
+	s.kubeletPool[key] = c
+	return c, nil
+}
+
+// PooledKubelets 返回客户端池中的 Kubelet（ip:port，已排序）
+func (s *Session) PooledKubelets() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.kubeletPool))
+	for key := range s.kubeletPool {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Workers 返回所有批量命令共享的工作池，任务按目标（KubeletKey 或其他端点）获取槽位
+func (s *Session) Workers() *limiter.Pool {
+	return s.workers
+}
+
+// SetWorkers 设置工作池大小及同一目标上的最大并发数，<= 0 时使用默认值
+func (s *Session) SetWorkers(workers, perTarget int) {
+	s.Config.Workers, s.Config.TargetConcurrency = workers, perTarget
+	s.applyWorkers()
+}
+
+// WorkerLimits 返回生效的工作池大小及同一目标上的最大并发数
+func (s *Session) WorkerLimits() (int, int) {
+	workers, perTarget := s.Config.Workers, s.Config.TargetConcurrency
+	if workers <= 0 {
+		workers = config.DefaultWorkers
+	}
+	if perTarget <= 0 {
+		perTarget = config.DefaultTargetConcurrency
+	}
+	return workers, perTarget
+}
+
+// applyWorkers 将会话配置中的工作池设置同步到共享的工作池
+func (s *Session) applyWorkers() {
+	s.workers.SetLimits(s.WorkerLimits())
+}
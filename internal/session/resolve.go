@@ -0,0 +1,74 @@
+package session
+
+import (
+	"sort"
+	"strings"
+
+	"kctl/internal/i18n"
+)
+
+// PodRef 解析后的 Pod 位置
+type PodRef struct {
+	Namespace string
+	Pod       string
+	Container string
+	Cached    bool // Pod 是否在缓存中找到
+}
+
+// AmbiguousPodError 同名 Pod 存在于多个命名空间
+type AmbiguousPodError struct {
+	Name       string
+	Namespaces []string
+}
+
+func (e *AmbiguousPodError) Error() string {
+	candidates := make([]string, len(e.Namespaces))
+	for i, ns := range e.Namespaces {
+		candidates[i] = ns + "/" + e.Name
+	}
+	return i18n.Tf("Pod %s 存在于多个命名空间，请使用 -n 或 namespace/name 指定: %s",
+		e.Name, strings.Join(candidates, ", "))
+}
+
+// ResolvePod 根据 Pod 缓存推断命名空间和容器
+// name 支持 namespace/name 形式；namespace、container 为用户显式指定的值，可为空
+// 未指定命名空间且缓存中有多个同名 Pod 时返回 *AmbiguousPodError
+// 缓存中没有该 Pod 时命名空间默认为 default，容器保持为空
+func (s *Session) ResolvePod(name, namespace, container string) (PodRef, error) {
+	if ns, pod, ok := strings.Cut(name, "/"); ok {
+		if namespace != "" && namespace != ns {
+			return PodRef{}, i18n.Errorf("Pod %s 与 -n %s 指定的命名空间不一致", name, namespace)
+		}
+		namespace, name = ns, pod
+	}
+	ref := PodRef{Namespace: namespace, Pod: name, Container: container}
+
+	var namespaces []string
+	seen := make(map[string]bool)
+	for _, pod := range s.GetCachedPods() {
+		if pod.PodName != name || (namespace != "" && pod.Namespace != namespace) {
+			continue
+		}
+		if seen[pod.Namespace] {
+			continue
+		}
+		seen[pod.Namespace] = true
+		if len(namespaces) == 0 {
+			ref.Namespace = pod.Namespace
+			ref.Cached = true
+			if ref.Container == "" && len(pod.Containers) > 0 {
+				ref.Container = pod.Containers[0].Name
+			}
+		}
+		namespaces = append(namespaces, pod.Namespace)
+	}
+
+	if len(namespaces) > 1 {
+		sort.Strings(namespaces)
+		return PodRef{}, &AmbiguousPodError{Name: name, Namespaces: namespaces}
+	}
+	if ref.Namespace == "" {
+		ref.Namespace = "default"
+	}
+	return ref, nil
+}
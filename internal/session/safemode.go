@@ -0,0 +1,69 @@
+package session
+
+import (
+	"fmt"
+
+	"kctl/internal/i18n"
+	"kctl/internal/logging"
+)
+
+// ErrSafeMode 安全模式下拒绝对集群的修改或执行操作
+var ErrSafeMode = i18n.New("安全模式 (safe-mode) 下禁止对集群执行修改或执行操作")
+
+// SetSafeMode 开启或关闭安全模式，以 --read-only 启动的会话不能关闭
+func (s *Session) SetSafeMode(on bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !on && s.safeModeLocked {
+		return i18n.Errorf("以 --read-only 启动，安全模式不能关闭")
+	}
+	s.safeMode = on
+	return nil
+}
+
+// LockSafeMode 开启安全模式并禁止在会话中关闭（--read-only）
+func (s *Session) LockSafeMode() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.safeMode = true
+	s.safeModeLocked = true
+}
+
+// IsSafeMode 是否处于安全模式
+func (s *Session) IsSafeMode() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.safeMode
+}
+
+// IsSafeModeLocked 安全模式是否由 --read-only 锁定
+func (s *Session) IsSafeModeLocked() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.safeModeLocked
+}
+
+// CheckSafeMode 安全模式下返回 ErrSafeMode，what 描述被拒绝的操作
+// 需要在 Pod 中执行命令的命令可在开始前调用，避免逐个目标失败
+func (s *Session) CheckSafeMode(what string) error {
+	if !s.IsSafeMode() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrSafeMode, what)
+}
+
+// denyOperation 安全模式下拒绝客户端的修改或执行操作，并记录到日志
+func (s *Session) denyOperation(action, target string) error {
+	err := s.CheckSafeMode(action + " " + target)
+	if err != nil {
+		logging.Info("operation blocked", logging.Fields{"action": action, "target": target, "command": s.currentCommand()})
+	}
+	return err
+}
+
+// currentCommand 返回当前执行的控制台命令
+func (s *Session) currentCommand() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.command
+}
@@ -0,0 +1,206 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"kctl/config"
+	"kctl/internal/db"
+	"kctl/internal/i18n"
+	"kctl/pkg/token"
+)
+
+// State 退出时保存到数据库的会话状态，下次以同一 --db 启动时可恢复
+// 数据库可能以 --viewer 打开、被 query 读取或打包进 bundle，因此不保存凭据：
+// Token 和客户端证书在恢复时从 token-file、证书文件或 kubeconfig 重新读取，
+// 带密码的代理 / 隧道地址、请求头、exec 环境变量、通知地址和同步令牌需要重新设置
+type State struct {
+	SavedAt   time.Time     `json:"savedAt"`
+	Config    SessionConfig `json:"config"`
+	Mode      Mode          `json:"mode"`
+	CurrentSA string        `json:"currentSA,omitempty"` // namespace/name
+	Unsaved   []string      `json:"unsaved,omitempty"`   // 因包含凭据未保存的配置项（set 的配置项名）
+}
+
+// Summary 状态的简短描述，如 10.0.0.1:10250, SA kube-system/default
+func (st *State) Summary() string {
+	s := fmt.Sprintf("%s:%d", st.Config.KubeletIP, st.Config.KubeletPort)
+	if st.CurrentSA != "" {
+		s += ", SA " + st.CurrentSA
+	}
+	if st.Config.Profile != "" {
+		s += ", profile " + st.Config.Profile
+	}
+	return s
+}
+
+// SaveState 将当前会话状态保存到数据库；内存数据库、只读数据库或未设置目标时不保存
+func (s *Session) SaveState() error {
+	if s.DB == nil || s.DB.IsInMemory() || s.IsViewer() || s.Config.KubeletIP == "" {
+		return nil
+	}
+
+	cfg, unsaved := withoutSecrets(s.Config)
+	st := State{
+		SavedAt: time.Now(),
+		Config:  cfg,
+		Mode:    s.Mode,
+		Unsaved: unsaved,
+	}
+	if sa := s.GetCurrentSA(); sa != nil {
+		st.CurrentSA = sa.Namespace + "/" + sa.Name
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		return i18n.Errorf("序列化会话状态失败: %w", err)
+	}
+	return s.DB.SetMeta(db.MetaSessionState, string(data))
+}
+
+// withoutSecrets 返回去除凭据的配置副本，以及无法从文件重新读取、需要重新设置的配置项
+func withoutSecrets(cfg SessionConfig) (SessionConfig, []string) {
+	var unsaved []string
+	if cfg.Token != "" && cfg.TokenFile == "" && cfg.Kubeconfig == "" {
+		unsaved = append(unsaved, "token")
+	}
+	if cfg.ClientCert != "" && !isFile(cfg.ClientCertFile) && cfg.Kubeconfig == "" {
+		unsaved = append(unsaved, "client-cert")
+	}
+	cfg.Token, cfg.ClientCert, cfg.ClientKey = "", "", ""
+
+	if hasPassword(cfg.ProxyURL) {
+		cfg.ProxyURL = ""
+		unsaved = append(unsaved, "proxy")
+	}
+	if hasPassword(cfg.TunnelURL) {
+		cfg.TunnelURL, cfg.TunnelKey = "", ""
+		unsaved = append(unsaved, "tunnel")
+	}
+	if len(cfg.Headers) > 0 {
+		cfg.Headers = nil
+		unsaved = append(unsaved, "header")
+	}
+	if len(cfg.Env) > 0 {
+		cfg.Env = nil
+		unsaved = append(unsaved, "env")
+	}
+	if cfg.NotifyURL != "" {
+		cfg.NotifyURL = ""
+		unsaved = append(unsaved, "notify-url")
+	}
+	if cfg.SyncToken != "" {
+		cfg.SyncToken = ""
+		unsaved = append(unsaved, "sync-token")
+	}
+	return cfg, unsaved
+}
+
+// hasPassword URL 中是否带有密码
+func hasPassword(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL != ""
+	}
+	_, ok := u.User.Password()
+	return ok
+}
+
+// isFile 路径是否为存在的文件（客户端证书来源也可能是 kubeconfig 的说明文字）
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return path != "" && err == nil && !info.IsDir()
+}
+
+// reloadCredentials 从 kubeconfig、token-file 和证书文件重新读取未保存的凭据
+func (s *Session) reloadCredentials() error {
+	tokenFile, certFile, keyFile := s.Config.TokenFile, s.Config.ClientCertFile, s.Config.ClientKeyFile
+	if s.Config.Kubeconfig != "" {
+		if _, err := s.LoadKubeconfig(s.Config.Kubeconfig, s.Config.KubeContext); err != nil {
+			return i18n.Errorf("重新加载 kubeconfig 失败: %w", err)
+		}
+	}
+	if tokenFile != "" {
+		tokenStr, err := token.Read(tokenFile)
+		if err != nil {
+			return i18n.Errorf("读取 Token 文件失败: %w", err)
+		}
+		s.Config.Token, s.Config.TokenFile = tokenStr, tokenFile
+	}
+	if isFile(certFile) {
+		if err := s.LoadClientCert(certFile); err != nil {
+			return err
+		}
+	}
+	if isFile(keyFile) && keyFile != certFile {
+		if err := s.LoadClientKey(keyFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadState 读取数据库中保存的会话状态，没有时返回 nil
+func (s *Session) LoadState() (*State, error) {
+	if s.DB == nil || s.DB.IsInMemory() {
+		return nil, nil
+	}
+	data, err := s.DB.GetMeta(db.MetaSessionState)
+	if err != nil || data == "" {
+		return nil, err
+	}
+	var st State
+	if err := json.Unmarshal([]byte(data), &st); err != nil {
+		return nil, i18n.Errorf("解析会话状态失败: %w", err)
+	}
+	return &st, nil
+}
+
+// RestoreState 恢复会话配置、模式和扫描状态，并重新读取凭据文件；当前 SA 在连接后由 RestoreCurrentSA 恢复
+func (s *Session) RestoreState(st *State) error {
+	s.Disconnect()
+	s.Config = st.Config
+	if st.Mode != "" {
+		s.Mode = st.Mode
+	}
+	s.applyPacing()
+	s.applyWorkers()
+	// 凭据读取失败不影响恢复其余状态
+	credErr := s.reloadCredentials()
+	if err := s.tunnel.Configure(s.Config.TunnelURL, s.Config.TunnelKey); err != nil {
+		s.Config.TunnelURL, s.Config.TunnelKey = "", ""
+		return i18n.Errorf("恢复 SSH 隧道失败: %w", err)
+	}
+
+	if s.Config.RulesFile != "" {
+		if _, err := config.LoadRulesFile(s.Config.RulesFile); err != nil {
+			s.Config.RulesFile = ""
+			return i18n.Errorf("重新加载规则文件失败: %w", err)
+		}
+	}
+	if err := s.ReloadFromDB(); err != nil {
+		return err
+	}
+	return credErr
+}
+
+// RestoreCurrentSA 按名称重新选中保存的当前 SA，数据库中不存在时返回 false
+func (s *Session) RestoreCurrentSA(st *State) (bool, error) {
+	if st.CurrentSA == "" {
+		return false, nil
+	}
+	namespace, name, ok := strings.Cut(st.CurrentSA, "/")
+	if !ok {
+		return false, nil
+	}
+	sa, err := s.SADB.GetByName(namespace, name)
+	if err != nil || sa == nil {
+		return false, err
+	}
+	s.SetCurrentSA(sa)
+	return true, nil
+}
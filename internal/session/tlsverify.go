@@ -0,0 +1,73 @@
+package session
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"kctl/internal/client"
+	"kctl/internal/i18n"
+)
+
+// LoadCACert 设置验证服务端证书使用的 CA 证书文件（PEM，可包含多个证书）
+func (s *Session) LoadCACert(path string) error {
+	if _, err := client.LoadCABundle(path); err != nil {
+		return err
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	s.Config.CAFile = path
+	return nil
+}
+
+// PinCert 固定服务端证书的 SHA256 指纹，返回规范化后的指纹；已固定时不重复添加
+func (s *Session) PinCert(fingerprint string) (string, error) {
+	fp, err := client.ParseFingerprint(fingerprint)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(s.Config.PinnedCerts, fp) {
+		s.Config.PinnedCerts = append(s.Config.PinnedCerts, fp)
+	}
+	return fp, nil
+}
+
+// FetchKubeletCert 不验证证书，获取当前 Kubelet 的服务端证书（经由配置的隧道和代理），用于固定指纹
+func (s *Session) FetchKubeletCert(ctx context.Context) (*x509.Certificate, error) {
+	if s.Config.KubeletIP == "" {
+		return nil, i18n.Errorf("未设置目标 Kubelet，请先使用 'set target <ip>'")
+	}
+	addr := net.JoinHostPort(s.Config.KubeletIP, strconv.Itoa(s.Config.KubeletPort))
+	chain, err := client.FetchCertificates(ctx, s.GetClientConfig(), addr)
+	if err != nil {
+		return nil, err
+	}
+	return chain[0], nil
+}
+
+// TLSVerifySummary 描述服务端证书的验证方式，未开启验证时返回空字符串
+func (s *Session) TLSVerifySummary() string {
+	if !s.Config.TLSVerify {
+		return ""
+	}
+	if len(s.Config.PinnedCerts) > 0 {
+		return fmt.Sprintf("%d pinned", len(s.Config.PinnedCerts))
+	}
+	var parts []string
+	if s.Config.CAFile != "" {
+		parts = append(parts, "CA "+s.Config.CAFile)
+	}
+	if s.Config.CACert != "" {
+		parts = append(parts, "kubeconfig CA")
+	}
+	if s.Config.CAFile == "" && s.Config.CACert == "" {
+		parts = append(parts, "system roots")
+	}
+	return strings.Join(parts, ", ")
+}
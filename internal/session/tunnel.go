@@ -0,0 +1,35 @@
+package session
+
+import (
+	"context"
+
+	"kctl/internal/client"
+)
+
+// SetTunnel 设置 SSH 跳板机并立即建立连接，rawURL 为空时关闭隧道
+// 连接失败时保留原有设置；已创建的客户端需重新连接后才会经由新的隧道
+func (s *Session) SetTunnel(ctx context.Context, rawURL, keyFile string) error {
+	if rawURL == "" {
+		s.Config.TunnelURL, s.Config.TunnelKey = "", ""
+		return s.tunnel.Configure("", "")
+	}
+
+	if err := s.tunnel.Configure(rawURL, keyFile); err != nil {
+		return err
+	}
+	if err := s.tunnel.Connect(ctx); err != nil {
+		// 恢复原有隧道（连接在下次使用时建立）
+		_ = s.tunnel.Configure(s.Config.TunnelURL, s.Config.TunnelKey)
+		return err
+	}
+	s.Config.TunnelURL, s.Config.TunnelKey = rawURL, keyFile
+	return nil
+}
+
+// Tunnel 返回会话的 SSH 隧道，未设置时返回 nil
+func (s *Session) Tunnel() *client.SSHTunnel {
+	if s.Config.TunnelURL == "" {
+		return nil
+	}
+	return s.tunnel
+}
@@ -0,0 +1,155 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"kctl/internal/i18n"
+	"kctl/internal/output"
+	"kctl/pkg/types"
+)
+
+// NameAuto 自动协商通道名称
+const NameAuto = "auto"
+
+// negotiator 按顺序尝试候选通道，缓存第一个可用的通道
+type negotiator struct {
+	candidates []ExecTransport
+	printer    output.Printer
+
+	mu     sync.Mutex
+	chosen ExecTransport
+}
+
+// NewAuto 返回自动协商的通道：依次尝试 candidates，失败（连接不可达、握手被拒绝等）时切换到下一个
+// 一旦某个通道执行成功即固定使用该通道
+func NewAuto(printer output.Printer, candidates ...ExecTransport) ExecTransport {
+	return &negotiator{candidates: candidates, printer: printer}
+}
+
+func (n *negotiator) Name() string {
+	if c := n.current(); c != nil {
+		return c.Name()
+	}
+	return NameAuto
+}
+
+func (n *negotiator) Path() string {
+	if c := n.current(); c != nil {
+		return c.Path()
+	}
+	var names []string
+	for _, c := range n.candidates {
+		names = append(names, c.Name())
+	}
+	return strings.Join(names, " → ")
+}
+
+func (n *negotiator) Interactive() bool {
+	for _, c := range n.candidates {
+		if c.Interactive() {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *negotiator) Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	if c := n.current(); c != nil {
+		return c.Exec(ctx, opts)
+	}
+
+	var firstErr error
+	for i, c := range n.candidates {
+		result, err := c.Exec(ctx, opts)
+		if err == nil {
+			n.choose(i)
+			return result, nil
+		}
+		if !ShouldFallback(ctx, err) {
+			return nil, err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, n.exhausted(firstErr)
+}
+
+func (n *negotiator) ExecInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	if c := n.current(); c != nil && c.Interactive() {
+		return c.ExecInteractive(ctx, opts)
+	}
+
+	var firstErr error
+	for i, c := range n.candidates {
+		if !c.Interactive() {
+			continue
+		}
+		err := c.ExecInteractive(ctx, opts)
+		if err == nil {
+			n.choose(i)
+			return nil
+		}
+		if !ShouldFallback(ctx, err) {
+			return err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return n.exhausted(firstErr)
+}
+
+func (n *negotiator) current() ExecTransport {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.chosen
+}
+
+// choose 固定使用第 i 个候选通道，非首选时提示一次
+func (n *negotiator) choose(i int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.chosen != nil {
+		return
+	}
+	n.chosen = n.candidates[i]
+	if i > 0 && n.printer != nil {
+		n.printer.Warning(fmt.Sprintf("%s unavailable, using %s", n.candidates[0].Name(), n.chosen.Path()))
+	}
+}
+
+// exhausted 所有通道均失败
+func (n *negotiator) exhausted(firstErr error) error {
+	if firstErr == nil {
+		return i18n.New("没有可用的执行通道")
+	}
+	return i18n.Errorf("所有执行通道均失败（输入 'show transports' 查看详情）: %w", firstErr)
+}
+
+// ShouldFallback 判断错误是否应切换到下一个通道
+// 用户中断（上下文取消）时不切换
+func ShouldFallback(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	return !errors.Is(err, context.Canceled)
+}
+
+// Probe 在目标 Pod 中执行 true 检测通道是否可用，返回 nil 表示可用
+func Probe(ctx context.Context, t ExecTransport, namespace, pod, container string) error {
+	// 命令退出码非 0 说明已送达容器，同样视为可用
+	_, err := t.Exec(ctx, &types.ExecOptions{
+		Namespace: namespace,
+		Pod:       pod,
+		Container: container,
+		Command:   []string{"true"},
+		Stdout:    true,
+		Stderr:    true,
+	})
+	return err
+}
@@ -0,0 +1,190 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"kctl/internal/client"
+	"kctl/internal/client/k8s"
+	"kctl/internal/client/kubelet"
+	"kctl/internal/i18n"
+	"kctl/pkg/types"
+)
+
+// 通道名称（与 set exec-via 的取值一致）
+const (
+	NameWebSocket = "websocket"
+	NameSPDY      = "spdy"
+	NameNodeProxy = "nodes-proxy"
+	NameAPI       = "api"
+	NameRun       = "run"
+)
+
+// ErrInteractiveUnsupported 通道不支持交互式会话
+var ErrInteractiveUnsupported = i18n.New("该执行通道不支持交互式会话")
+
+// ExecTransport 在容器中执行命令的通道
+type ExecTransport interface {
+	// Name 通道名称
+	Name() string
+	// Path 通道使用的端点
+	Path() string
+	// Interactive 是否支持交互式会话（-it）
+	Interactive() bool
+	Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error)
+	ExecInteractive(ctx context.Context, opts *types.ExecOptions) error
+}
+
+// ==================== Kubelet WebSocket ====================
+
+type websocketTransport struct {
+	kubelet kubelet.Client
+}
+
+// NewWebSocket Kubelet /exec（WebSocket）
+func NewWebSocket(c kubelet.Client) ExecTransport {
+	return &websocketTransport{kubelet: c}
+}
+
+func (t *websocketTransport) Name() string      { return NameWebSocket }
+func (t *websocketTransport) Path() string      { return "kubelet /exec (WebSocket)" }
+func (t *websocketTransport) Interactive() bool { return true }
+
+func (t *websocketTransport) Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	return t.kubelet.Exec(ctx, opts)
+}
+
+func (t *websocketTransport) ExecInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	return t.kubelet.ExecInteractive(ctx, opts)
+}
+
+// ==================== Kubelet SPDY ====================
+
+type spdyTransport struct {
+	kubelet kubelet.Client
+}
+
+// NewSPDY Kubelet /exec（SPDY/3.1）
+func NewSPDY(c kubelet.Client) ExecTransport {
+	return &spdyTransport{kubelet: c}
+}
+
+func (t *spdyTransport) Name() string      { return NameSPDY }
+func (t *spdyTransport) Path() string      { return "kubelet /exec (SPDY/3.1)" }
+func (t *spdyTransport) Interactive() bool { return true }
+
+func (t *spdyTransport) Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	return t.kubelet.ExecSPDY(ctx, opts)
+}
+
+func (t *spdyTransport) ExecInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	return t.kubelet.ExecSPDYInteractive(ctx, opts)
+}
+
+// ==================== API Server nodes/proxy ====================
+
+type nodeProxyTransport struct {
+	k8s         k8s.Client
+	resolveNode func(namespace, pod string) string
+}
+
+// NewNodeProxy API Server nodes/{node}/proxy/exec，resolveNode 根据 Pod 返回所在节点
+func NewNodeProxy(c k8s.Client, resolveNode func(namespace, pod string) string) ExecTransport {
+	return &nodeProxyTransport{k8s: c, resolveNode: resolveNode}
+}
+
+func (t *nodeProxyTransport) Name() string      { return NameNodeProxy }
+func (t *nodeProxyTransport) Path() string      { return "API server nodes/proxy/exec" }
+func (t *nodeProxyTransport) Interactive() bool { return true }
+
+func (t *nodeProxyTransport) node(opts *types.ExecOptions) (string, error) {
+	node := t.resolveNode(opts.Namespace, opts.Pod)
+	if node == "" {
+		return "", i18n.Errorf("无法确定 Pod %s/%s 所在节点，请先执行 pods 刷新缓存", opts.Namespace, opts.Pod)
+	}
+	return node, nil
+}
+
+func (t *nodeProxyTransport) Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	node, err := t.node(opts)
+	if err != nil {
+		return nil, err
+	}
+	return t.k8s.NodeProxyExec(ctx, node, opts)
+}
+
+func (t *nodeProxyTransport) ExecInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	node, err := t.node(opts)
+	if err != nil {
+		return err
+	}
+	return t.k8s.NodeProxyExecInteractive(ctx, node, opts)
+}
+
+// ==================== API Server pods/exec ====================
+
+type apiTransport struct {
+	k8s k8s.Client
+}
+
+// NewAPI API Server pods/exec 子资源
+func NewAPI(c k8s.Client) ExecTransport {
+	return &apiTransport{k8s: c}
+}
+
+func (t *apiTransport) Name() string      { return NameAPI }
+func (t *apiTransport) Path() string      { return "API server pods/exec" }
+func (t *apiTransport) Interactive() bool { return true }
+
+func (t *apiTransport) Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	return t.k8s.Exec(ctx, opts)
+}
+
+func (t *apiTransport) ExecInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	return t.k8s.ExecInteractive(ctx, opts)
+}
+
+// ==================== Kubelet /run ====================
+
+type runTransport struct {
+	kubelet kubelet.Client
+}
+
+// NewRun 旧版 Kubelet /run（单次 POST，无流式输出和退出码）
+func NewRun(c kubelet.Client) ExecTransport {
+	return &runTransport{kubelet: c}
+}
+
+func (t *runTransport) Name() string      { return NameRun }
+func (t *runTransport) Path() string      { return "kubelet /run (legacy)" }
+func (t *runTransport) Interactive() bool { return false }
+
+// Exec 命令以空格拼接（Kubelet 按空格拆分，参数中的空格无法保留）
+func (t *runTransport) Exec(ctx context.Context, opts *types.ExecOptions) (*types.ExecResult, error) {
+	res, err := t.kubelet.Run(ctx, &types.RunOptions{
+		Namespace: opts.Namespace,
+		Pod:       opts.Pod,
+		Container: opts.Container,
+		Command:   strings.Join(opts.Command, " "),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 端点被拒绝或不存在时视为通道不可用，交由协商切换
+	switch res.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusMethodNotAllowed:
+		return nil, &client.ExecDialError{StatusCode: res.StatusCode, Body: res.Error}
+	}
+
+	result := &types.ExecResult{Stdout: res.Output}
+	if res.Error != "" {
+		result.Error = res.Error
+	}
+	return result, nil
+}
+
+func (t *runTransport) ExecInteractive(ctx context.Context, opts *types.ExecOptions) error {
+	return ErrInteractiveUnsupported
+}
@@ -0,0 +1,135 @@
+package vulndb
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"kctl/config"
+	"kctl/internal/i18n"
+)
+
+//go:embed cves.json
+var cvesJSON []byte
+
+// Range 受影响版本区间 [Introduced, Fixed)
+// Introduced 为 "0" 表示更早的所有版本
+type Range struct {
+	Introduced string `json:"introduced"`
+	Fixed      string `json:"fixed"`
+}
+
+// CVE 漏洞条目
+type CVE struct {
+	ID        string           `json:"id"`
+	Severity  config.RiskLevel `json:"severity"`
+	Component string           `json:"component"`
+	Title     string           `json:"title"`
+	OS        string           `json:"os,omitempty"` // 仅影响特定系统（如 windows），为空表示全部
+	Affected  []Range          `json:"affected"`
+}
+
+var (
+	loadOnce sync.Once
+	entries  []CVE
+	loadErr  error
+)
+
+// All 返回内置的全部漏洞条目
+func All() ([]CVE, error) {
+	loadOnce.Do(func() {
+		loadErr = json.Unmarshal(cvesJSON, &entries)
+		if loadErr != nil {
+			loadErr = i18n.Errorf("解析内置漏洞库失败: %w", loadErr)
+		}
+	})
+	return entries, loadErr
+}
+
+// Match 返回影响指定版本的漏洞，按严重程度排序
+func Match(version string) ([]CVE, error) {
+	v, err := ParseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := All()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []CVE
+	for _, cve := range all {
+		if cve.affects(v) {
+			matches = append(matches, cve)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return config.RiskLevelOrder[matches[i].Severity] < config.RiskLevelOrder[matches[j].Severity]
+	})
+	return matches, nil
+}
+
+// affects 判断版本是否落在任一受影响区间内
+func (c CVE) affects(v Version) bool {
+	for _, r := range c.Affected {
+		fixed, err := ParseVersion(r.Fixed)
+		if err != nil {
+			continue
+		}
+		introduced := Version{}
+		if r.Introduced != "0" && r.Introduced != "" {
+			if introduced, err = ParseVersion(r.Introduced); err != nil {
+				continue
+			}
+		}
+		if !v.Less(introduced) && v.Less(fixed) {
+			return true
+		}
+	}
+	return false
+}
+
+// Version 语义化版本（仅比较 major.minor.patch）
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// versionRe 匹配 v1.28.2、1.27.3+k3s1、v1.26.5-eks-abc 等格式
+var versionRe = regexp.MustCompile(`v?(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// ParseVersion 解析 Kubernetes 版本字符串
+func ParseVersion(s string) (Version, error) {
+	m := versionRe.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, i18n.Errorf("无法解析版本: %s", s)
+	}
+	var v Version
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		v.Patch, _ = strconv.Atoi(m[3])
+	}
+	return v, nil
+}
+
+// Less 判断 v 是否小于 o
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// String 返回版本字符串
+func (v Version) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
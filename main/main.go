@@ -2,8 +2,12 @@ package main
 
 import (
 	"kctl/cmd"
+	_ "kctl/cmd/analyze" // analyze 命令
 	_ "kctl/cmd/console" // console 命令
+	_ "kctl/cmd/run"     // run 命令
+	_ "kctl/cmd/serve"   // serve 命令
 	_ "kctl/cmd/version" // import sub command as module
+	_ "kctl/cmd/watch"   // watch 命令
 )
 
 func init() {
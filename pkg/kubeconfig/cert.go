@@ -0,0 +1,58 @@
+package kubeconfig
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"kctl/internal/i18n"
+)
+
+// CertInfo 客户端证书中的认证身份
+type CertInfo struct {
+	CommonName string    // 用户名，如 system:node:worker-1
+	Groups     []string  // 组（Organization），如 system:nodes
+	NotAfter   time.Time // 过期时间
+}
+
+// ParseClientCert 解析 PEM 中的第一个证书
+func ParseClientCert(data []byte) (*CertInfo, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, i18n.Errorf("未找到 PEM 证书")
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, i18n.Errorf("解析证书失败: %w", err)
+		}
+		return &CertInfo{
+			CommonName: cert.Subject.CommonName,
+			Groups:     cert.Subject.Organization,
+			NotAfter:   cert.NotAfter,
+		}, nil
+	}
+}
+
+// SplitPEM 将证书和私钥合并的 PEM（如 kubelet-client-current.pem）拆分为证书和私钥
+func SplitPEM(data []byte) (certPEM, keyPEM []byte) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certPEM, keyPEM
+		}
+		encoded := pem.EncodeToMemory(block)
+		switch {
+		case block.Type == "CERTIFICATE":
+			certPEM = append(certPEM, encoded...)
+		case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			keyPEM = append(keyPEM, encoded...)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Package kubeconfig 生成 kubectl 可直接使用的 kubeconfig
+package kubeconfig
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/pem"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"kctl/internal/i18n"
+)
+
+// Options kubeconfig 生成参数
+type Options struct {
+	Server    string // API Server 地址
+	Token     string // Bearer Token
+	Namespace string // 默认命名空间
+	User      string // 用户名（如 system:serviceaccount:ns:name）
+	CAData    []byte // PEM 格式 CA 证书，为空时跳过 TLS 校验
+}
+
+type file struct {
+	APIVersion     string         `yaml:"apiVersion"`
+	Kind           string         `yaml:"kind"`
+	Clusters       []namedCluster `yaml:"clusters"`
+	Contexts       []namedContext `yaml:"contexts"`
+	CurrentContext string         `yaml:"current-context"`
+	Users          []namedUser    `yaml:"users"`
+}
+
+type namedCluster struct {
+	Name    string      `yaml:"name"`
+	Cluster clusterSpec `yaml:"cluster"`
+}
+
+type clusterSpec struct {
+	Server                   string `yaml:"server"`
+	CertificateAuthority     string `yaml:"certificate-authority,omitempty"`
+	CertificateAuthorityData string `yaml:"certificate-authority-data,omitempty"`
+	InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify,omitempty"`
+}
+
+type namedContext struct {
+	Name    string      `yaml:"name"`
+	Context contextSpec `yaml:"context"`
+}
+
+type contextSpec struct {
+	Cluster   string `yaml:"cluster"`
+	User      string `yaml:"user"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+type namedUser struct {
+	Name string   `yaml:"name"`
+	User userSpec `yaml:"user"`
+}
+
+type userSpec struct {
+	Token                 string `yaml:"token"`
+	TokenFile             string `yaml:"tokenFile,omitempty"`
+	ClientCertificate     string `yaml:"client-certificate,omitempty"`
+	ClientCertificateData string `yaml:"client-certificate-data,omitempty"`
+	ClientKey             string `yaml:"client-key,omitempty"`
+	ClientKeyData         string `yaml:"client-key-data,omitempty"`
+}
+
+// nameRe kubeconfig 名称中允许的字符
+var nameRe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Build 生成 kubeconfig YAML
+func Build(opts Options) ([]byte, error) {
+	if opts.Server == "" {
+		return nil, i18n.Errorf("API Server 地址为空")
+	}
+	if opts.Token == "" {
+		return nil, i18n.Errorf("token 为空")
+	}
+	caData := ""
+	if len(opts.CAData) > 0 {
+		if block, _ := pem.Decode(opts.CAData); block == nil {
+			return nil, i18n.Errorf("CA 证书不是有效的 PEM 格式")
+		}
+		caData = base64.StdEncoding.EncodeToString(opts.CAData)
+	}
+
+	clusterName := "kctl"
+	userName := "kctl"
+	if opts.User != "" {
+		userName = nameRe.ReplaceAllString(strings.TrimPrefix(opts.User, "system:serviceaccount:"), "-")
+	}
+	contextName := userName + "@" + clusterName
+
+	kc := file{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []namedCluster{{
+			Name: clusterName,
+			Cluster: clusterSpec{
+				Server:                   opts.Server,
+				CertificateAuthorityData: caData,
+				InsecureSkipTLSVerify:    len(opts.CAData) == 0,
+			},
+		}},
+		Contexts: []namedContext{{
+			Name: contextName,
+			Context: contextSpec{
+				Cluster:   clusterName,
+				User:      userName,
+				Namespace: opts.Namespace,
+			},
+		}},
+		CurrentContext: contextName,
+		Users: []namedUser{{
+			Name: userName,
+			User: userSpec{Token: opts.Token},
+		}},
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(kc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
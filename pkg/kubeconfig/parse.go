@@ -0,0 +1,110 @@
+package kubeconfig
+
+import (
+	"encoding/base64"
+
+	"gopkg.in/yaml.v3"
+
+	"kctl/internal/i18n"
+)
+
+// Entry kubeconfig 中一个上下文解析出的连接信息
+// *Data 字段为解码后的 PEM 内容，*File 字段为 kubeconfig 中引用的文件路径（未读取）
+type Entry struct {
+	Context   string
+	Cluster   string
+	User      string
+	Namespace string
+
+	Server   string
+	CAData   []byte
+	CAFile   string
+	Insecure bool
+
+	Token          string
+	TokenFile      string
+	ClientCertData []byte
+	ClientCertFile string
+	ClientKeyData  []byte
+	ClientKeyFile  string
+}
+
+// Config 解析后的 kubeconfig
+type Config struct {
+	CurrentContext string
+	Entries        []Entry
+}
+
+// Parse 解析 kubeconfig，每个上下文生成一个 Entry
+// 没有上下文时按用户逐个生成（使用第一个集群），便于处理手工拼接的 kubeconfig
+func Parse(data []byte) (*Config, error) {
+	var kc file
+	if err := yaml.Unmarshal(data, &kc); err != nil {
+		return nil, i18n.Errorf("解析 kubeconfig 失败: %w", err)
+	}
+	if len(kc.Users) == 0 {
+		return nil, i18n.Errorf("kubeconfig 中没有用户")
+	}
+
+	clusters := make(map[string]clusterSpec)
+	for _, c := range kc.Clusters {
+		clusters[c.Name] = c.Cluster
+	}
+	users := make(map[string]userSpec)
+	for _, u := range kc.Users {
+		users[u.Name] = u.User
+	}
+
+	contexts := kc.Contexts
+	if len(contexts) == 0 {
+		cluster := ""
+		if len(kc.Clusters) > 0 {
+			cluster = kc.Clusters[0].Name
+		}
+		for _, u := range kc.Users {
+			contexts = append(contexts, namedContext{Name: u.Name, Context: contextSpec{Cluster: cluster, User: u.Name}})
+		}
+	}
+
+	cfg := &Config{CurrentContext: kc.CurrentContext}
+	for _, ctx := range contexts {
+		user, ok := users[ctx.Context.User]
+		if !ok {
+			continue
+		}
+		cluster := clusters[ctx.Context.Cluster]
+		entry := Entry{
+			Context:        ctx.Name,
+			Cluster:        ctx.Context.Cluster,
+			User:           ctx.Context.User,
+			Namespace:      ctx.Context.Namespace,
+			Server:         cluster.Server,
+			CAFile:         cluster.CertificateAuthority,
+			Insecure:       cluster.InsecureSkipTLSVerify,
+			Token:          user.Token,
+			TokenFile:      user.TokenFile,
+			ClientCertFile: user.ClientCertificate,
+			ClientKeyFile:  user.ClientKey,
+		}
+		var err error
+		if entry.CAData, err = decodeData(cluster.CertificateAuthorityData); err != nil {
+			return nil, i18n.Errorf("集群 %s 的 certificate-authority-data 无效: %w", ctx.Context.Cluster, err)
+		}
+		if entry.ClientCertData, err = decodeData(user.ClientCertificateData); err != nil {
+			return nil, i18n.Errorf("用户 %s 的 client-certificate-data 无效: %w", ctx.Context.User, err)
+		}
+		if entry.ClientKeyData, err = decodeData(user.ClientKeyData); err != nil {
+			return nil, i18n.Errorf("用户 %s 的 client-key-data 无效: %w", ctx.Context.User, err)
+		}
+		cfg.Entries = append(cfg.Entries, entry)
+	}
+	return cfg, nil
+}
+
+// decodeData 解码 base64 编码的 *-data 字段
+func decodeData(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
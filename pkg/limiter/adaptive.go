@@ -0,0 +1,93 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+)
+
+// Adaptive 自适应并发控制器（AIMD）
+// 请求失败（错误/超时）时并发上限减半，连续成功达到当前上限次数后并发上限加一
+type Adaptive struct {
+	mu       sync.Mutex
+	limit    int
+	min      int
+	max      int
+	inflight int
+	streak   int
+	notify   chan struct{}
+}
+
+// NewAdaptive 创建自适应并发控制器，max 为并发上限
+// 初始并发为上限的一半，最小并发为 1
+func NewAdaptive(max int) *Adaptive {
+	if max <= 0 {
+		max = 1
+	}
+	initial := max / 2
+	if initial < 1 {
+		initial = 1
+	}
+	return &Adaptive{
+		limit:  initial,
+		min:    1,
+		max:    max,
+		notify: make(chan struct{}),
+	}
+}
+
+// Acquire 获取一个并发槽位，ctx 取消时返回错误
+func (a *Adaptive) Acquire(ctx context.Context) error {
+	for {
+		a.mu.Lock()
+		if a.inflight < a.limit {
+			a.inflight++
+			a.mu.Unlock()
+			return nil
+		}
+		wait := a.notify
+		a.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release 释放并发槽位，并根据本次请求是否成功调整并发上限
+func (a *Adaptive) Release(success bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.inflight--
+	if success {
+		a.streak++
+		if a.streak >= a.limit && a.limit < a.max {
+			a.limit++
+			a.streak = 0
+		}
+	} else {
+		a.streak = 0
+		a.limit /= 2
+		if a.limit < a.min {
+			a.limit = a.min
+		}
+	}
+
+	// 唤醒所有等待者重新竞争槽位
+	close(a.notify)
+	a.notify = make(chan struct{})
+}
+
+// Limit 返回当前并发上限
+func (a *Adaptive) Limit() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.limit
+}
+
+// Max 返回并发上限的最大值
+func (a *Adaptive) Max() int {
+	return a.max
+}
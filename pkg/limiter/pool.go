@@ -0,0 +1,87 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+)
+
+// Pool 会话级工作池：限制同时进行的任务总数，以及同一目标（如某个 Kubelet）上同时进行的任务数
+// 多个命令共享同一个 Pool，修改上限后立即生效；上限 <= 0 表示不限制
+type Pool struct {
+	mu       sync.Mutex
+	size     int
+	perKey   int
+	inflight int
+	keys     map[string]int
+	notify   chan struct{}
+}
+
+// NewPool 创建工作池，size 为全局并发上限，perKey 为每个目标的并发上限
+func NewPool(size, perKey int) *Pool {
+	return &Pool{
+		size:   size,
+		perKey: perKey,
+		keys:   make(map[string]int),
+		notify: make(chan struct{}),
+	}
+}
+
+// SetLimits 修改全局及每个目标的并发上限，已获取的槽位不受影响
+func (p *Pool) SetLimits(size, perKey int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.size, p.perKey = size, perKey
+	p.wake()
+}
+
+// Limits 返回全局及每个目标的并发上限
+func (p *Pool) Limits() (int, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.size, p.perKey
+}
+
+// InFlight 返回正在进行的任务数
+func (p *Pool) InFlight() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.inflight
+}
+
+// Acquire 为目标 key 获取一个槽位，全局或该目标的并发已满时等待，ctx 取消时返回错误
+func (p *Pool) Acquire(ctx context.Context, key string) error {
+	for {
+		p.mu.Lock()
+		if (p.size <= 0 || p.inflight < p.size) && (p.perKey <= 0 || p.keys[key] < p.perKey) {
+			p.inflight++
+			p.keys[key]++
+			p.mu.Unlock()
+			return nil
+		}
+		wait := p.notify
+		p.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release 释放目标 key 的一个槽位
+func (p *Pool) Release(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inflight--
+	if p.keys[key]--; p.keys[key] <= 0 {
+		delete(p.keys, key)
+	}
+	p.wake()
+}
+
+// wake 唤醒所有等待者重新竞争槽位，调用方需持有 p.mu
+func (p *Pool) wake() {
+	close(p.notify)
+	p.notify = make(chan struct{})
+}
@@ -0,0 +1,128 @@
+package network
+
+import (
+	"net"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"kctl/internal/i18n"
+)
+
+// Pod 内中继方式（pivot）：每个 SOCKS5 连接在 Pod 中启动一个中继，将 stdin / stdout 接到目标地址
+const (
+	PivotSocat  = "socat"   // socat - TCP:host:port
+	PivotNC     = "nc"      // nc host port
+	PivotBash   = "bash"    // bash /dev/tcp，需要 cat
+	PivotPython = "python3" // python3 socket
+)
+
+// PivotRelays 可用的中继方式，按自动选择的优先顺序排列
+var PivotRelays = []string{PivotSocat, PivotNC, PivotBash, PivotPython}
+
+// PivotToolsScript 探测 Pod 内可用中继工具的脚本，输出 command -v 的结果
+const PivotToolsScript = "command -v socat; command -v nc; command -v bash; command -v cat; command -v python3; true"
+
+// pivotBashRelay 后台把 stdin 写到目标（非交互 shell 的后台命令默认从 /dev/null 读取，需显式 <&0），
+// 前台把目标的数据写到 stdout，目标关闭连接后结束后台的 cat
+const pivotBashRelay = `exec 3<>"/dev/tcp/$1/$2" || exit 1; cat <&0 >&3 & cat <&3; kill $! 2>/dev/null`
+
+// pivotPythonRelay 转发 stdin 到目标并把目标的数据写到 stdout，目标关闭连接后退出
+const pivotPythonRelay = `import os,socket,sys,threading
+s=socket.create_connection((sys.argv[1],int(sys.argv[2])))
+def up():
+    while True:
+        d=os.read(0,65536)
+        if not d:
+            break
+        s.sendall(d)
+    s.shutdown(socket.SHUT_WR)
+threading.Thread(target=up,daemon=True).start()
+out=sys.stdout.buffer
+while True:
+    d=s.recv(65536)
+    if not d:
+        break
+    out.write(d)
+    out.flush()`
+
+// pivotHostPattern 允许作为中继参数的域名，不能以 - 开头以免被当作选项
+var pivotHostPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+
+// PivotTools Pod 内可用的中继工具
+type PivotTools struct {
+	Socat  bool
+	NC     bool
+	Bash   bool
+	Cat    bool
+	Python bool
+}
+
+// ParsePivotTools 解析 PivotToolsScript 的输出
+func ParsePivotTools(output string) PivotTools {
+	var tools PivotTools
+	for _, line := range strings.Split(output, "\n") {
+		switch path.Base(strings.TrimSpace(line)) {
+		case "socat":
+			tools.Socat = true
+		case "nc":
+			tools.NC = true
+		case "bash":
+			tools.Bash = true
+		case "cat":
+			tools.Cat = true
+		case "python3":
+			tools.Python = true
+		}
+	}
+	return tools
+}
+
+// Has 中继方式在 Pod 内是否可用
+func (t PivotTools) Has(relay string) bool {
+	switch relay {
+	case PivotSocat:
+		return t.Socat
+	case PivotNC:
+		return t.NC
+	case PivotBash:
+		return t.Bash && t.Cat
+	case PivotPython:
+		return t.Python
+	}
+	return false
+}
+
+// Relay 按 PivotRelays 的顺序选择中继方式，没有可用工具时返回空字符串
+func (t PivotTools) Relay() string {
+	for _, relay := range PivotRelays {
+		if t.Has(relay) {
+			return relay
+		}
+	}
+	return ""
+}
+
+// PivotRelayCommand 生成在 Pod 内连接 host:port 的中继命令，目标地址作为独立参数传入，不经过 shell 解析
+func PivotRelayCommand(relay, host string, port int) ([]string, error) {
+	if net.ParseIP(host) == nil && !pivotHostPattern.MatchString(host) {
+		return nil, i18n.Errorf("无效的目标主机: %q", host)
+	}
+	if port <= 0 || port > 65535 {
+		return nil, i18n.Errorf("无效的目标端口: %d", port)
+	}
+	p := strconv.Itoa(port)
+
+	switch relay {
+	case PivotSocat:
+		return []string{"socat", "-", "TCP:" + net.JoinHostPort(host, p)}, nil
+	case PivotNC:
+		return []string{"nc", host, p}, nil
+	case PivotBash:
+		return []string{"bash", "-c", pivotBashRelay, "pivot", host, p}, nil
+	case PivotPython:
+		return []string{"python3", "-c", pivotPythonRelay, host, p}, nil
+	}
+	return nil, i18n.Errorf("未知的中继方式: %s (可用: %s)", relay, strings.Join(PivotRelays, ", "))
+}
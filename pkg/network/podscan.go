@@ -0,0 +1,107 @@
+package network
+
+import (
+	"bufio"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Pod 内扫描方式
+const (
+	PodScanBash = "bash" // bash /dev/tcp 连接
+	PodScanNC   = "nc"   // nc -z 连接
+)
+
+// PodScanToolsScript 探测 Pod 内可用扫描工具的脚本，输出 command -v 的结果
+const PodScanToolsScript = "command -v bash; command -v nc; command -v timeout; true"
+
+// PodScanTools Pod 内可用的扫描工具
+type PodScanTools struct {
+	Bash    bool
+	NC      bool
+	Timeout bool // coreutils / busybox timeout，可为每个连接单独限时
+}
+
+// ParsePodScanTools 解析 PodScanToolsScript 的输出
+func ParsePodScanTools(output string) PodScanTools {
+	var tools PodScanTools
+	for _, line := range strings.Split(output, "\n") {
+		switch path.Base(strings.TrimSpace(line)) {
+		case "bash":
+			tools.Bash = true
+		case "nc":
+			tools.NC = true
+		case "timeout":
+			tools.Timeout = true
+		}
+	}
+	return tools
+}
+
+// Method 选择扫描方式，优先 bash /dev/tcp，没有可用工具时返回空字符串
+func (t PodScanTools) Method() string {
+	switch {
+	case t.Bash:
+		return PodScanBash
+	case t.NC:
+		return PodScanNC
+	}
+	return ""
+}
+
+// PodScanOptions Pod 内扫描配置
+type PodScanOptions struct {
+	Method      string
+	Targets     []string
+	Ports       []int
+	Concurrency int  // 每批并发连接数
+	Timeout     int  // 单个连接超时（秒）
+	HasTimeout  bool // Pod 内有 timeout 命令
+}
+
+// PodScanScript 生成在 Pod 内执行的 TCP connect 扫描脚本（sh -c 执行），开放端口输出 OPEN <ip> <port>
+// 每批启动 Concurrency 个后台连接后等待；bash 方式没有 timeout 命令时，每批固定等待 Timeout 秒后结束未完成的连接
+func PodScanScript(opts PodScanOptions) string {
+	ports := make([]string, len(opts.Ports))
+	for i, port := range opts.Ports {
+		ports[i] = strconv.Itoa(port)
+	}
+
+	var probe, flush string
+	switch opts.Method {
+	case PodScanNC:
+		probe = fmt.Sprintf(`nc -z -w %d "$ip" "$p" >/dev/null 2>&1 && echo "OPEN $ip $p" &`, opts.Timeout)
+		flush = "wait"
+	default:
+		if opts.HasTimeout {
+			probe = fmt.Sprintf(`timeout %d bash -c "echo >/dev/tcp/$ip/$p" >/dev/null 2>&1 && echo "OPEN $ip $p" &`, opts.Timeout)
+			flush = "wait"
+		} else {
+			probe = `bash -c "echo >/dev/tcp/$ip/$p" >/dev/null 2>&1 && echo "OPEN $ip $p" &`
+			flush = fmt.Sprintf("sleep %d; kill $(jobs -p) 2>/dev/null; wait", opts.Timeout)
+		}
+	}
+
+	return fmt.Sprintf(`i=0; for ip in %s; do for p in %s; do %s i=$((i+1)); if [ $((i %% %d)) -eq 0 ]; then %s; fi; done; done; %s`,
+		strings.Join(opts.Targets, " "), strings.Join(ports, " "), probe, opts.Concurrency, flush, flush)
+}
+
+// ParsePodScan 解析 PodScanScript 的输出，返回开放端口
+func ParsePodScan(output string) []ScanResult {
+	var results []ScanResult
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[0] != "OPEN" {
+			continue
+		}
+		port, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+		results = append(results, ScanResult{IP: fields[1], Port: port, Open: true})
+	}
+	return results
+}
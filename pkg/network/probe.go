@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"kctl/config"
+	"kctl/internal/i18n"
 	"kctl/pkg/types"
 )
 
@@ -32,8 +33,8 @@ func ProbePort(ip string, port int, timeout time.Duration) *types.ProbeResult {
 }
 
 // ValidateKubeletPort 验证指定端口是否为有效的 Kubelet 端口
-// 通过访问 /healthz 或 /pods 端点来验证
-func ValidateKubeletPort(ip string, port int, token string, timeout time.Duration) *types.ProbeResult {
+// 通过访问 /healthz 或 /pods 端点来验证，headers 附加到每个请求（如自定义 User-Agent），可为 nil
+func ValidateKubeletPort(ip string, port int, token string, timeout time.Duration, headers http.Header) *types.ProbeResult {
 	result := &types.ProbeResult{
 		IP:   ip,
 		Port: port,
@@ -44,7 +45,7 @@ func ValidateKubeletPort(ip string, port int, token string, timeout time.Duratio
 	conn, err := net.DialTimeout("tcp", address, timeout)
 	if err != nil {
 		result.Reachable = false
-		result.Error = fmt.Errorf("TCP 连接失败: %w", err)
+		result.Error = i18n.Errorf("TCP 连接失败: %w", err)
 		return result
 	}
 	_ = conn.Close()
@@ -63,9 +64,10 @@ func ValidateKubeletPort(ip string, port int, token string, timeout time.Duratio
 	req, err := http.NewRequest("GET", healthzURL, nil)
 	if err != nil {
 		result.IsKubelet = false
-		result.Error = fmt.Errorf("创建请求失败: %w", err)
+		result.Error = i18n.Errorf("创建请求失败: %w", err)
 		return result
 	}
+	setHeaders(req.Header, headers)
 
 	resp, err := client.Do(req)
 	if err == nil {
@@ -84,10 +86,11 @@ func ValidateKubeletPort(ip string, port int, token string, timeout time.Duratio
 		req, err = http.NewRequest("GET", podsURL, nil)
 		if err != nil {
 			result.IsKubelet = false
-			result.Error = fmt.Errorf("创建请求失败: %w", err)
+			result.Error = i18n.Errorf("创建请求失败: %w", err)
 			return result
 		}
 
+		setHeaders(req.Header, headers)
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 		resp, err = client.Do(req)
@@ -107,14 +110,21 @@ func ValidateKubeletPort(ip string, port int, token string, timeout time.Duratio
 	// 无法确认是 Kubelet
 	result.IsKubelet = false
 	if err != nil {
-		result.Error = fmt.Errorf("无法验证 Kubelet: %w", err)
+		result.Error = i18n.Errorf("无法验证 Kubelet: %w", err)
 	} else {
-		result.Error = fmt.Errorf("端口响应不像是 Kubelet")
+		result.Error = i18n.Errorf("端口响应不像是 Kubelet")
 	}
 
 	return result
 }
 
+// setHeaders 将 headers 复制到请求头
+func setHeaders(dst, headers http.Header) {
+	for name, values := range headers {
+		dst[name] = values
+	}
+}
+
 // DefaultProbeTimeout 返回默认探测超时时间
 func DefaultProbeTimeout() time.Duration {
 	return config.DefaultProbeTimeout
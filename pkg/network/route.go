@@ -3,12 +3,12 @@ package network
 import (
 	"bufio"
 	"encoding/hex"
-	"fmt"
 	"net"
 	"os"
 	"strings"
 
 	"kctl/config"
+	"kctl/internal/i18n"
 	"kctl/pkg/types"
 )
 
@@ -16,7 +16,7 @@ import (
 func GetDefaultGateway() (string, error) {
 	file, err := os.Open(config.ProcNetRoute)
 	if err != nil {
-		return "", fmt.Errorf("无法打开路由表文件 %s: %w", config.ProcNetRoute, err)
+		return "", i18n.Errorf("无法打开路由表文件 %s: %w", config.ProcNetRoute, err)
 	}
 	defer func() { _ = file.Close() }()
 
@@ -48,23 +48,23 @@ func GetDefaultGateway() (string, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return "", fmt.Errorf("读取路由表文件失败: %w", err)
+		return "", i18n.Errorf("读取路由表文件失败: %w", err)
 	}
 
-	return "", fmt.Errorf("未找到默认网关")
+	return "", i18n.Errorf("未找到默认网关")
 }
 
 // hexToIP 将十六进制字符串转换为 IP 地址
 // Linux 路由表中的 IP 是小端序的十六进制
 func hexToIP(hexStr string) (string, error) {
 	if len(hexStr) != 8 {
-		return "", fmt.Errorf("无效的十六进制 IP 长度: %s", hexStr)
+		return "", i18n.Errorf("无效的十六进制 IP 长度: %s", hexStr)
 	}
 
 	// 解码十六进制
 	bytes, err := hex.DecodeString(hexStr)
 	if err != nil {
-		return "", fmt.Errorf("十六进制解码失败: %w", err)
+		return "", i18n.Errorf("十六进制解码失败: %w", err)
 	}
 
 	// Linux 使用小端序，需要反转字节顺序
@@ -77,7 +77,7 @@ func hexToIP(hexStr string) (string, error) {
 func GetAllRoutes() ([]types.RouteEntry, error) {
 	file, err := os.Open(config.ProcNetRoute)
 	if err != nil {
-		return nil, fmt.Errorf("无法打开路由表文件 %s: %w", config.ProcNetRoute, err)
+		return nil, i18n.Errorf("无法打开路由表文件 %s: %w", config.ProcNetRoute, err)
 	}
 	defer func() { _ = file.Close() }()
 
@@ -109,7 +109,7 @@ func GetAllRoutes() ([]types.RouteEntry, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("读取路由表文件失败: %w", err)
+		return nil, i18n.Errorf("读取路由表文件失败: %w", err)
 	}
 
 	return routes, nil
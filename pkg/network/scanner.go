@@ -8,6 +8,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"kctl/internal/i18n"
 )
 
 // ScanOptions 扫描配置
@@ -151,7 +153,7 @@ func (s *Scanner) probePort(ctx context.Context, ip string, port int) ScanResult
 func ParseTargets(target string) ([]string, error) {
 	target = strings.TrimSpace(target)
 	if target == "" {
-		return nil, fmt.Errorf("目标不能为空")
+		return nil, i18n.Errorf("目标不能为空")
 	}
 
 	// CIDR 格式
@@ -169,14 +171,14 @@ func ParseTargets(target string) ([]string, error) {
 		return []string{target}, nil
 	}
 
-	return nil, fmt.Errorf("无效的目标格式: %s", target)
+	return nil, i18n.Errorf("无效的目标格式: %s", target)
 }
 
 // parseCIDR 解析 CIDR 格式
 func parseCIDR(cidr string) ([]string, error) {
 	_, ipNet, err := net.ParseCIDR(cidr)
 	if err != nil {
-		return nil, fmt.Errorf("无效的 CIDR: %s", cidr)
+		return nil, i18n.Errorf("无效的 CIDR: %s", cidr)
 	}
 
 	var ips []string
@@ -196,16 +198,16 @@ func parseCIDR(cidr string) ([]string, error) {
 func parseIPRange(rangeStr string) ([]string, error) {
 	parts := strings.Split(rangeStr, "-")
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("无效的 IP 范围格式: %s", rangeStr)
+		return nil, i18n.Errorf("无效的 IP 范围格式: %s", rangeStr)
 	}
 
 	startIP := net.ParseIP(strings.TrimSpace(parts[0]))
 	if startIP == nil {
-		return nil, fmt.Errorf("无效的起始 IP: %s", parts[0])
+		return nil, i18n.Errorf("无效的起始 IP: %s", parts[0])
 	}
 	startIP = startIP.To4()
 	if startIP == nil {
-		return nil, fmt.Errorf("仅支持 IPv4: %s", parts[0])
+		return nil, i18n.Errorf("仅支持 IPv4: %s", parts[0])
 	}
 
 	endPart := strings.TrimSpace(parts[1])
@@ -216,14 +218,14 @@ func parseIPRange(rangeStr string) ([]string, error) {
 		// 完整 IP: 192.168.1.1-192.168.1.100
 		endIP = net.ParseIP(endPart)
 		if endIP == nil {
-			return nil, fmt.Errorf("无效的结束 IP: %s", endPart)
+			return nil, i18n.Errorf("无效的结束 IP: %s", endPart)
 		}
 		endIP = endIP.To4()
 	} else {
 		// 只有最后一段: 192.168.1.1-100
 		endNum, err := strconv.Atoi(endPart)
 		if err != nil || endNum < 0 || endNum > 255 {
-			return nil, fmt.Errorf("无效的结束值: %s", endPart)
+			return nil, i18n.Errorf("无效的结束值: %s", endPart)
 		}
 		endIP = make(net.IP, 4)
 		copy(endIP, startIP)
@@ -247,7 +249,7 @@ func parseIPRange(rangeStr string) ([]string, error) {
 func ParsePorts(portStr string) ([]int, error) {
 	portStr = strings.TrimSpace(portStr)
 	if portStr == "" {
-		return nil, fmt.Errorf("端口不能为空")
+		return nil, i18n.Errorf("端口不能为空")
 	}
 
 	var ports []int
@@ -277,7 +279,7 @@ func ParsePorts(portStr string) ([]int, error) {
 			// 单个端口
 			p, err := strconv.Atoi(part)
 			if err != nil || p < 1 || p > 65535 {
-				return nil, fmt.Errorf("无效的端口: %s", part)
+				return nil, i18n.Errorf("无效的端口: %s", part)
 			}
 			if !seen[p] {
 				seen[p] = true
@@ -287,7 +289,7 @@ func ParsePorts(portStr string) ([]int, error) {
 	}
 
 	if len(ports) == 0 {
-		return nil, fmt.Errorf("没有有效的端口")
+		return nil, i18n.Errorf("没有有效的端口")
 	}
 
 	return ports, nil
@@ -297,21 +299,21 @@ func ParsePorts(portStr string) ([]int, error) {
 func parsePortRange(rangeStr string) ([]int, error) {
 	parts := strings.Split(rangeStr, "-")
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("无效的端口范围: %s", rangeStr)
+		return nil, i18n.Errorf("无效的端口范围: %s", rangeStr)
 	}
 
 	start, err := strconv.Atoi(strings.TrimSpace(parts[0]))
 	if err != nil || start < 1 || start > 65535 {
-		return nil, fmt.Errorf("无效的起始端口: %s", parts[0])
+		return nil, i18n.Errorf("无效的起始端口: %s", parts[0])
 	}
 
 	end, err := strconv.Atoi(strings.TrimSpace(parts[1]))
 	if err != nil || end < 1 || end > 65535 {
-		return nil, fmt.Errorf("无效的结束端口: %s", parts[1])
+		return nil, i18n.Errorf("无效的结束端口: %s", parts[1])
 	}
 
 	if start > end {
-		return nil, fmt.Errorf("起始端口不能大于结束端口: %d > %d", start, end)
+		return nil, i18n.Errorf("起始端口不能大于结束端口: %d > %d", start, end)
 	}
 
 	var ports []int
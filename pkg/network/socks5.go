@@ -0,0 +1,107 @@
+package network
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+
+	"kctl/internal/i18n"
+)
+
+// SOCKS5 应答码（RFC 1928）
+const (
+	SOCKS5Succeeded           byte = 0x00
+	SOCKS5GeneralFailure      byte = 0x01
+	SOCKS5HostUnreachable     byte = 0x04
+	SOCKS5CommandNotSupported byte = 0x07
+	SOCKS5AddrNotSupported    byte = 0x08
+)
+
+const (
+	socks5Version    = 0x05
+	socks5NoAuth     = 0x00
+	socks5NoAccepted = 0xff
+	socks5Connect    = 0x01
+	socks5AddrIPv4   = 0x01
+	socks5AddrDomain = 0x03
+	socks5AddrIPv6   = 0x04
+)
+
+// SOCKS5Accept 完成 SOCKS5 服务端握手（无认证，只支持 CONNECT），返回客户端请求的目标地址
+// 握手成功后调用方需用 SOCKS5Reply 返回连接结果；不支持的请求已向客户端回复错误
+func SOCKS5Accept(conn net.Conn) (string, int, error) {
+	// 版本和认证方式协商
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", 0, err
+	}
+	if header[0] != socks5Version {
+		return "", 0, i18n.Errorf("不支持的 SOCKS 版本: %d", header[0])
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return "", 0, err
+	}
+	noAuth := false
+	for _, m := range methods {
+		if m == socks5NoAuth {
+			noAuth = true
+		}
+	}
+	if !noAuth {
+		_, _ = conn.Write([]byte{socks5Version, socks5NoAccepted})
+		return "", 0, i18n.Errorf("客户端不支持无认证方式")
+	}
+	if _, err := conn.Write([]byte{socks5Version, socks5NoAuth}); err != nil {
+		return "", 0, err
+	}
+
+	// 请求：VER CMD RSV ATYP DST.ADDR DST.PORT
+	req := make([]byte, 4)
+	if _, err := io.ReadFull(conn, req); err != nil {
+		return "", 0, err
+	}
+	if req[1] != socks5Connect {
+		_ = SOCKS5Reply(conn, SOCKS5CommandNotSupported)
+		return "", 0, i18n.Errorf("不支持的 SOCKS5 命令: %d", req[1])
+	}
+
+	var host string
+	switch req[3] {
+	case socks5AddrIPv4, socks5AddrIPv6:
+		size := net.IPv4len
+		if req[3] == socks5AddrIPv6 {
+			size = net.IPv6len
+		}
+		ip := make([]byte, size)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return "", 0, err
+		}
+		host = net.IP(ip).String()
+	case socks5AddrDomain:
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return "", 0, err
+		}
+		domain := make([]byte, size[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return "", 0, err
+		}
+		host = string(domain)
+	default:
+		_ = SOCKS5Reply(conn, SOCKS5AddrNotSupported)
+		return "", 0, i18n.Errorf("不支持的 SOCKS5 地址类型: %d", req[3])
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", 0, err
+	}
+	return host, int(binary.BigEndian.Uint16(port)), nil
+}
+
+// SOCKS5Reply 向客户端返回 CONNECT 结果，绑定地址固定为 0.0.0.0:0
+func SOCKS5Reply(conn net.Conn, code byte) error {
+	_, err := conn.Write([]byte{socks5Version, code, 0x00, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
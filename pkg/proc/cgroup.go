@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"kctl/internal/i18n"
 )
 
 // ContainerInfo 容器信息
@@ -19,7 +21,7 @@ func GetContainerInfo(pid int) (*ContainerInfo, error) {
 	cgroupPath := fmt.Sprintf("/proc/%d/cgroup", pid)
 	data, err := os.ReadFile(cgroupPath)
 	if err != nil {
-		return nil, fmt.Errorf("读取 cgroup 文件失败: %w", err)
+		return nil, i18n.Errorf("读取 cgroup 文件失败: %w", err)
 	}
 
 	return parseCgroupV1(string(data))
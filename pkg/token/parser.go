@@ -3,12 +3,12 @@ package token
 import (
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"kctl/config"
+	"kctl/internal/i18n"
 	"kctl/pkg/types"
 )
 
@@ -20,12 +20,12 @@ func Read(path string) (string, error) {
 
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("读取 Token 文件失败: %w", err)
+		return "", i18n.Errorf("读取 Token 文件失败: %w", err)
 	}
 
 	token := strings.TrimSpace(string(data))
 	if token == "" {
-		return "", fmt.Errorf("token 文件为空")
+		return "", i18n.Errorf("token 文件为空")
 	}
 
 	return token, nil
@@ -35,7 +35,7 @@ func Read(path string) (string, error) {
 func Parse(token string) (*types.TokenInfo, error) {
 	parts := strings.Split(token, ".")
 	if len(parts) != 3 {
-		return nil, fmt.Errorf("无效的 JWT Token 格式")
+		return nil, i18n.Errorf("无效的 JWT Token 格式")
 	}
 
 	// 解码 payload（第二部分）
@@ -44,13 +44,13 @@ func Parse(token string) (*types.TokenInfo, error) {
 		// 尝试标准 base64 解码
 		payload, err = base64.StdEncoding.DecodeString(parts[1])
 		if err != nil {
-			return nil, fmt.Errorf("解码 Token payload 失败: %w", err)
+			return nil, i18n.Errorf("解码 Token payload 失败: %w", err)
 		}
 	}
 
 	var claims map[string]interface{}
 	if err := json.Unmarshal(payload, &claims); err != nil {
-		return nil, fmt.Errorf("解析 Token claims 失败: %w", err)
+		return nil, i18n.Errorf("解析 Token claims 失败: %w", err)
 	}
 
 	info := &types.TokenInfo{}
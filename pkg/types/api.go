@@ -1,6 +1,9 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // ==================== Kubelet API 响应类型 ====================
 
@@ -10,53 +13,123 @@ type KubeletPodsResponse struct {
 	APIVersion string `json:"apiVersion"`
 	Items      []struct {
 		Metadata struct {
-			Name              string `json:"name"`
-			Namespace         string `json:"namespace"`
-			UID               string `json:"uid"`
-			CreationTimestamp string `json:"creationTimestamp"`
+			Name              string            `json:"name"`
+			Namespace         string            `json:"namespace"`
+			UID               string            `json:"uid"`
+			CreationTimestamp string            `json:"creationTimestamp"`
+			Labels            map[string]string `json:"labels"`
+			Annotations       map[string]string `json:"annotations"`
 		} `json:"metadata"`
 		Spec struct {
-			NodeName       string `json:"nodeName"`
-			ServiceAccount string `json:"serviceAccountName"`
-			Containers     []struct {
-				Name            string           `json:"name"`
-				Image           string           `json:"image"`
-				SecurityContext *SecurityContext `json:"securityContext"`
-				VolumeMounts    []VolumeMount    `json:"volumeMounts"`
-			} `json:"containers"`
-			Volumes []Volume `json:"volumes"`
+			NodeName            string              `json:"nodeName"`
+			ServiceAccount      string              `json:"serviceAccountName"`
+			HostPID             bool                `json:"hostPID"`
+			HostNetwork         bool                `json:"hostNetwork"`
+			HostIPC             bool                `json:"hostIPC"`
+			SecurityContext     *PodSecurityContext `json:"securityContext"`
+			Tolerations         []Toleration        `json:"tolerations"`
+			Containers          []KubeletContainer  `json:"containers"`
+			InitContainers      []KubeletContainer  `json:"initContainers"`
+			EphemeralContainers []KubeletContainer  `json:"ephemeralContainers"`
+			Volumes             []Volume            `json:"volumes"`
 		} `json:"spec"`
 		Status struct {
-			Phase             string `json:"phase"`
-			PodIP             string `json:"podIP"`
-			HostIP            string `json:"hostIP"`
-			ContainerStatuses []struct {
-				Name        string `json:"name"`
-				ContainerID string `json:"containerID"`
-				Ready       bool   `json:"ready"`
-				State       struct {
-					Running *struct {
-						StartedAt string `json:"startedAt"`
-					} `json:"running"`
-					Waiting *struct {
-						Reason  string `json:"reason"`
-						Message string `json:"message"`
-					} `json:"waiting"`
-					Terminated *struct {
-						Reason   string `json:"reason"`
-						ExitCode int    `json:"exitCode"`
-					} `json:"terminated"`
-				} `json:"state"`
-			} `json:"containerStatuses"`
+			Phase                      string                   `json:"phase"`
+			PodIP                      string                   `json:"podIP"`
+			HostIP                     string                   `json:"hostIP"`
+			ContainerStatuses          []KubeletContainerStatus `json:"containerStatuses"`
+			InitContainerStatuses      []KubeletContainerStatus `json:"initContainerStatuses"`
+			EphemeralContainerStatuses []KubeletContainerStatus `json:"ephemeralContainerStatuses"`
 		} `json:"status"`
 	} `json:"items"`
 }
 
+// KubeletContainer /pods 响应中的容器规格（普通、init 和临时容器共用）
+type KubeletContainer struct {
+	Name                string           `json:"name"`
+	Image               string           `json:"image"`
+	Env                 []EnvVar         `json:"env"`
+	SecurityContext     *SecurityContext `json:"securityContext"`
+	VolumeMounts        []VolumeMount    `json:"volumeMounts"`
+	RestartPolicy       string           `json:"restartPolicy"`       // init 容器为 Always 时是 sidecar
+	TargetContainerName string           `json:"targetContainerName"` // 临时容器共享进程命名空间的目标容器
+}
+
+// KubeletContainerStatus /pods 响应中的容器状态
+type KubeletContainerStatus struct {
+	Name        string `json:"name"`
+	ContainerID string `json:"containerID"`
+	Ready       bool   `json:"ready"`
+	State       struct {
+		Running *struct {
+			StartedAt string `json:"startedAt"`
+		} `json:"running"`
+		Waiting *struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"waiting"`
+		Terminated *struct {
+			Reason   string `json:"reason"`
+			ExitCode int    `json:"exitCode"`
+		} `json:"terminated"`
+	} `json:"state"`
+}
+
 // SecurityContext 容器安全上下文
 type SecurityContext struct {
-	Privileged               *bool `json:"privileged"`
-	AllowPrivilegeEscalation *bool `json:"allowPrivilegeEscalation"`
-	RunAsRoot                bool  `json:"runAsNonRoot"` // 注意：这是 runAsNonRoot，取反表示可能以 root 运行
+	Privileged               *bool            `json:"privileged"`
+	AllowPrivilegeEscalation *bool            `json:"allowPrivilegeEscalation"`
+	RunAsRoot                bool             `json:"runAsNonRoot"` // 注意：这是 runAsNonRoot，取反表示可能以 root 运行
+	RunAsUser                *int64           `json:"runAsUser"`
+	RunAsGroup               *int64           `json:"runAsGroup"`
+	ReadOnlyRootFilesystem   *bool            `json:"readOnlyRootFilesystem"`
+	Capabilities             *Capabilities    `json:"capabilities"`
+	SeccompProfile           *SecurityProfile `json:"seccompProfile"`
+	AppArmorProfile          *SecurityProfile `json:"appArmorProfile"`
+}
+
+// Capabilities 容器 Linux capabilities
+type Capabilities struct {
+	Add  []string `json:"add"`
+	Drop []string `json:"drop"`
+}
+
+// SecurityProfile seccomp / AppArmor 配置（type 为 RuntimeDefault、Unconfined 或 Localhost）
+type SecurityProfile struct {
+	Type             string `json:"type"`
+	LocalhostProfile string `json:"localhostProfile,omitempty"`
+}
+
+// String 格式化为 RuntimeDefault、Unconfined 或 Localhost/<profile>
+func (p *SecurityProfile) String() string {
+	if p == nil {
+		return ""
+	}
+	if p.LocalhostProfile != "" {
+		return p.Type + "/" + p.LocalhostProfile
+	}
+	return p.Type
+}
+
+// Sysctl Pod 级内核参数
+type Sysctl struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Toleration Pod 污点容忍
+type Toleration struct {
+	Key               string `json:"key,omitempty"`
+	Operator          string `json:"operator,omitempty"`
+	Value             string `json:"value,omitempty"`
+	Effect            string `json:"effect,omitempty"`
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
+}
+
+// EnvVar 容器环境变量（valueFrom 引用的变量 Value 为空）
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // VolumeMount 卷挂载信息
@@ -76,6 +149,17 @@ type Volume struct {
 	Secret *struct {
 		SecretName string `json:"secretName"`
 	} `json:"secret"`
+	ConfigMap             *ConfigMapVol `json:"configMap"`
+	EmptyDir              *EmptyDirVol  `json:"emptyDir"`
+	Projected             *ProjectedVol `json:"projected"`
+	PersistentVolumeClaim *PVCVol       `json:"persistentVolumeClaim"`
+	DownwardAPI           *struct{}     `json:"downwardAPI"`
+}
+
+// PVCVol PersistentVolumeClaim 卷
+type PVCVol struct {
+	ClaimName string `json:"claimName"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
 }
 
 // ==================== 完整 Pod 响应结构（用于解析）====================
@@ -102,11 +186,13 @@ type PodMetadata struct {
 
 // PodSpec Pod 规格
 type PodSpec struct {
-	NodeName           string              `json:"nodeName"`
-	ServiceAccountName string              `json:"serviceAccountName"`
-	Containers         []ContainerSpec     `json:"containers"`
-	Volumes            []VolumeSpec        `json:"volumes"`
-	SecurityContext    *PodSecurityContext `json:"securityContext,omitempty"`
+	NodeName            string              `json:"nodeName"`
+	ServiceAccountName  string              `json:"serviceAccountName"`
+	Containers          []ContainerSpec     `json:"containers"`
+	InitContainers      []ContainerSpec     `json:"initContainers,omitempty"`
+	EphemeralContainers []ContainerSpec     `json:"ephemeralContainers,omitempty"`
+	Volumes             []VolumeSpec        `json:"volumes"`
+	SecurityContext     *PodSecurityContext `json:"securityContext,omitempty"`
 }
 
 // ContainerSpec 容器规格
@@ -175,19 +261,26 @@ type SATokenSource struct {
 
 // PodSecurityContext Pod 安全上下文
 type PodSecurityContext struct {
-	RunAsUser    *int64 `json:"runAsUser,omitempty"`
-	RunAsGroup   *int64 `json:"runAsGroup,omitempty"`
-	RunAsNonRoot *bool  `json:"runAsNonRoot,omitempty"`
+	RunAsUser          *int64           `json:"runAsUser,omitempty"`
+	RunAsGroup         *int64           `json:"runAsGroup,omitempty"`
+	RunAsNonRoot       *bool            `json:"runAsNonRoot,omitempty"`
+	FSGroup            *int64           `json:"fsGroup,omitempty"`
+	SupplementalGroups []int64          `json:"supplementalGroups,omitempty"`
+	SeccompProfile     *SecurityProfile `json:"seccompProfile,omitempty"`
+	AppArmorProfile    *SecurityProfile `json:"appArmorProfile,omitempty"`
+	Sysctls            []Sysctl         `json:"sysctls,omitempty"`
 }
 
 // ContainerSecurityContext 容器安全上下文
 type ContainerSecurityContext struct {
-	RunAsUser                *int64 `json:"runAsUser,omitempty"`
-	RunAsGroup               *int64 `json:"runAsGroup,omitempty"`
-	Privileged               *bool  `json:"privileged,omitempty"`
-	AllowPrivilegeEscalation *bool  `json:"allowPrivilegeEscalation,omitempty"`
-	ReadOnlyRootFilesystem   *bool  `json:"readOnlyRootFilesystem,omitempty"`
-	RunAsNonRoot             *bool  `json:"runAsNonRoot,omitempty"`
+	RunAsUser                *int64           `json:"runAsUser,omitempty"`
+	RunAsGroup               *int64           `json:"runAsGroup,omitempty"`
+	Privileged               *bool            `json:"privileged,omitempty"`
+	AllowPrivilegeEscalation *bool            `json:"allowPrivilegeEscalation,omitempty"`
+	ReadOnlyRootFilesystem   *bool            `json:"readOnlyRootFilesystem,omitempty"`
+	RunAsNonRoot             *bool            `json:"runAsNonRoot,omitempty"`
+	Capabilities             *Capabilities    `json:"capabilities,omitempty"`
+	SeccompProfile           *SecurityProfile `json:"seccompProfile,omitempty"`
 }
 
 // PodStatus Pod 状态
@@ -216,17 +309,30 @@ type ExecOptions struct {
 
 // ExecResult 表示 exec 执行结果
 type ExecResult struct {
-	Stdout string
-	Stderr string
-	Error  string
+	Stdout   string
+	Stderr   string
+	Error    string
+	ExitCode int // 远程命令退出码，0 表示成功
 }
 
 // ExecStatus 表示 Kubernetes exec API 的状态响应
 type ExecStatus struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
+	Status  string             `json:"status"`
+	Message string             `json:"message"`
+	Reason  string             `json:"reason"`
+	Code    int                `json:"code"`
+	Details *ExecStatusDetails `json:"details,omitempty"`
+}
+
+// ExecStatusDetails 表示 exec 状态的详细信息
+type ExecStatusDetails struct {
+	Causes []ExecStatusCause `json:"causes,omitempty"`
+}
+
+// ExecStatusCause 表示 exec 状态的原因（ExitCode 原因携带退出码）
+type ExecStatusCause struct {
 	Reason  string `json:"reason"`
-	Code    int    `json:"code"`
+	Message string `json:"message"`
 }
 
 // ==================== Run 相关类型 ====================
@@ -241,8 +347,9 @@ type RunOptions struct {
 
 // RunResult 表示 run 执行结果
 type RunResult struct {
-	Output string
-	Error  string
+	Output     string
+	Error      string
+	StatusCode int // Kubelet 返回的 HTTP 状态码
 }
 
 // ==================== PortForward 相关类型 ====================
@@ -285,6 +392,217 @@ type KubeletNode struct {
 	DiscoveredAt time.Time
 }
 
+// ==================== 集群节点类型 ====================
+
+// NodeListResponse 表示 API Server /api/v1/nodes 的响应结构
+type NodeListResponse struct {
+	Items []struct {
+		Metadata struct {
+			Name   string            `json:"name"`
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Spec struct {
+			ProviderID string `json:"providerID"`
+		} `json:"spec"`
+		Status struct {
+			Addresses []struct {
+				Type    string `json:"type"`
+				Address string `json:"address"`
+			} `json:"addresses"`
+			DaemonEndpoints struct {
+				KubeletEndpoint struct {
+					Port int `json:"Port"`
+				} `json:"kubeletEndpoint"`
+			} `json:"daemonEndpoints"`
+			NodeInfo struct {
+				KubeletVersion          string `json:"kubeletVersion"`
+				OSImage                 string `json:"osImage"`
+				KernelVersion           string `json:"kernelVersion"`
+				ContainerRuntimeVersion string `json:"containerRuntimeVersion"`
+				Architecture            string `json:"architecture"`
+				OperatingSystem         string `json:"operatingSystem"`
+			} `json:"nodeInfo"`
+			Conditions []struct {
+				Type   string `json:"type"`
+				Status string `json:"status"`
+			} `json:"conditions"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// NodeInfo 表示集群节点信息
+type NodeInfo struct {
+	Name             string
+	InternalIP       string
+	KubeletPort      int
+	KubeletVersion   string
+	OSImage          string
+	KernelVersion    string
+	ContainerRuntime string
+	Architecture     string
+	Ready            bool
+	Source           string            // 信息来源: apiserver 或 kubelet
+	ProviderID       string            // spec.providerID，如 aws:///us-east-1a/i-0abc
+	Labels           map[string]string // 节点标签（仅 apiserver 来源）
+}
+
+// KubeletSpec 表示 Kubelet /spec/ 的响应（cAdvisor MachineInfo 子集）
+type KubeletSpec struct {
+	NumCores       int    `json:"num_cores"`
+	MemoryCapacity uint64 `json:"memory_capacity"`
+	MachineID      string `json:"machine_id"`
+	SystemUUID     string `json:"system_uuid"`
+	BootID         string `json:"boot_id"`
+}
+
+// KubeletStatsSummary 表示 Kubelet /stats/summary 的响应（仅 CPU / 内存）
+type KubeletStatsSummary struct {
+	Node NodeStats  `json:"node"`
+	Pods []PodStats `json:"pods"`
+}
+
+// NodeStats 节点资源使用
+type NodeStats struct {
+	NodeName string       `json:"nodeName"`
+	CPU      *CPUStats    `json:"cpu,omitempty"`
+	Memory   *MemoryStats `json:"memory,omitempty"`
+}
+
+// PodStats Pod 资源使用
+type PodStats struct {
+	PodRef struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+		UID       string `json:"uid"`
+	} `json:"podRef"`
+	CPU        *CPUStats        `json:"cpu,omitempty"`
+	Memory     *MemoryStats     `json:"memory,omitempty"`
+	Containers []ContainerStats `json:"containers,omitempty"`
+}
+
+// ContainerStats 容器资源使用
+type ContainerStats struct {
+	Name   string       `json:"name"`
+	CPU    *CPUStats    `json:"cpu,omitempty"`
+	Memory *MemoryStats `json:"memory,omitempty"`
+}
+
+// CPUStats CPU 使用（纳核）
+type CPUStats struct {
+	UsageNanoCores *uint64 `json:"usageNanoCores,omitempty"`
+}
+
+// MemoryStats 内存使用（字节）
+type MemoryStats struct {
+	WorkingSetBytes *uint64 `json:"workingSetBytes,omitempty"`
+	AvailableBytes  *uint64 `json:"availableBytes,omitempty"`
+}
+
+// MilliCores 返回 CPU 使用的毫核数，未上报时返回 0
+func (s *CPUStats) MilliCores() uint64 {
+	if s == nil || s.UsageNanoCores == nil {
+		return 0
+	}
+	return *s.UsageNanoCores / 1e6
+}
+
+// WorkingSet 返回工作集内存字节数，未上报时返回 0
+func (s *MemoryStats) WorkingSet() uint64 {
+	if s == nil || s.WorkingSetBytes == nil {
+		return 0
+	}
+	return *s.WorkingSetBytes
+}
+
+// KubeletConfigzResponse 表示 Kubelet /configz 的响应
+type KubeletConfigzResponse struct {
+	KubeletConfig KubeletConfiguration `json:"kubeletconfig"`
+}
+
+// KubeletConfiguration 表示 KubeletConfiguration 中与安全相关的字段子集
+type KubeletConfiguration struct {
+	Authentication struct {
+		Anonymous struct {
+			Enabled *bool `json:"enabled"`
+		} `json:"anonymous"`
+		Webhook struct {
+			Enabled *bool `json:"enabled"`
+		} `json:"webhook"`
+		X509 struct {
+			ClientCAFile string `json:"clientCAFile"`
+		} `json:"x509"`
+	} `json:"authentication"`
+	Authorization struct {
+		Mode string `json:"mode"`
+	} `json:"authorization"`
+	ReadOnlyPort                   int             `json:"readOnlyPort"`
+	StaticPodPath                  string          `json:"staticPodPath"`
+	ProtectKernelDefaults          bool            `json:"protectKernelDefaults"`
+	RotateCertificates             bool            `json:"rotateCertificates"`
+	ServerTLSBootstrap             bool            `json:"serverTLSBootstrap"`
+	StreamingConnectionIdleTimeout string          `json:"streamingConnectionIdleTimeout"`
+	MakeIPTablesUtilChains         *bool           `json:"makeIPTablesUtilChains"`
+	TLSCertFile                    string          `json:"tlsCertFile"`
+	TLSPrivateKeyFile              string          `json:"tlsPrivateKeyFile"`
+	TLSCipherSuites                []string        `json:"tlsCipherSuites"`
+	FeatureGates                   map[string]bool `json:"featureGates"`
+}
+
+// ConfigMapListResponse 表示 API Server /api/v1/configmaps 的响应结构
+type ConfigMapListResponse struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Data map[string]string `json:"data"`
+	} `json:"items"`
+}
+
+// ServiceAccountListResponse 表示 API Server /api/v1/serviceaccounts 的响应结构
+type ServiceAccountListResponse struct {
+	Items []struct {
+		Metadata struct {
+			Name        string            `json:"name"`
+			Namespace   string            `json:"namespace"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	} `json:"items"`
+}
+
+// ServiceAccountInfo 表示 ServiceAccount 对象信息
+type ServiceAccountInfo struct {
+	Name        string
+	Namespace   string
+	Annotations map[string]string
+}
+
+// ConfigMapInfo 表示 ConfigMap 信息
+type ConfigMapInfo struct {
+	Name      string
+	Namespace string
+	Data      map[string]string
+}
+
+// ==================== 临时容器类型 ====================
+
+// EphemeralContainerOptions 注入临时容器的参数
+type EphemeralContainerOptions struct {
+	Namespace       string
+	Pod             string
+	Name            string   // 临时容器名称
+	Image           string   // 镜像
+	Command         []string // 启动命令，为空时使用镜像默认命令
+	TargetContainer string   // 共享进程命名空间的目标容器
+}
+
+// ContainerStatus 容器（含临时容器）运行状态
+type ContainerStatus struct {
+	Name   string
+	State  string // Waiting, Running, Terminated
+	Reason string
+}
+
 // ==================== 路由相关类型 ====================
 
 // RouteEntry 表示路由表中的一条记录
@@ -295,3 +613,281 @@ type RouteEntry struct {
 	Flags       string
 	Mask        string
 }
+
+// PolicyRule RBAC 规则
+type PolicyRule struct {
+	Verbs           []string `json:"verbs"`
+	APIGroups       []string `json:"apiGroups,omitempty"`
+	Resources       []string `json:"resources,omitempty"`
+	ResourceNames   []string `json:"resourceNames,omitempty"`
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// RoleListResponse 表示 /apis/rbac.authorization.k8s.io/v1/{roles,clusterroles} 的响应结构
+type RoleListResponse struct {
+	Items []struct {
+		Metadata struct {
+			Name      string            `json:"name"`
+			Namespace string            `json:"namespace"`
+			Labels    map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Rules           []PolicyRule `json:"rules"`
+		AggregationRule *struct {
+			ClusterRoleSelectors []struct {
+				MatchLabels map[string]string `json:"matchLabels"`
+			} `json:"clusterRoleSelectors"`
+		} `json:"aggregationRule"`
+	} `json:"items"`
+}
+
+// RoleInfo 表示 Role 或 ClusterRole（Namespace 为空）
+type RoleInfo struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Labels    map[string]string
+	Rules     []PolicyRule
+	// AggregationSelectors 聚合 ClusterRole 的标签选择器，匹配标签的 ClusterRole 规则会被合并进来
+	AggregationSelectors []map[string]string
+}
+
+// RoleRef 绑定引用的角色
+type RoleRef struct {
+	Kind string `json:"kind"` // Role 或 ClusterRole
+	Name string `json:"name"`
+}
+
+// Subject 绑定的主体
+type Subject struct {
+	Kind      string `json:"kind"` // User、Group 或 ServiceAccount
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// RoleBindingListResponse 表示 /apis/rbac.authorization.k8s.io/v1/{rolebindings,clusterrolebindings} 的响应结构
+type RoleBindingListResponse struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		RoleRef  RoleRef   `json:"roleRef"`
+		Subjects []Subject `json:"subjects"`
+	} `json:"items"`
+}
+
+// RoleBindingInfo 表示 RoleBinding 或 ClusterRoleBinding（Namespace 为空）
+type RoleBindingInfo struct {
+	Kind      string
+	Name      string
+	Namespace string
+	RoleRef   RoleRef
+	Subjects  []Subject
+}
+
+// NamespaceListResponse 表示 API Server /api/v1/namespaces 的响应结构
+type NamespaceListResponse struct {
+	Items []struct {
+		Metadata struct {
+			Name   string            `json:"name"`
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Status struct {
+			Phase string `json:"phase"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// NamespaceInfo 表示 Namespace 对象信息
+type NamespaceInfo struct {
+	Name   string
+	Phase  string
+	Labels map[string]string
+}
+
+// PodSecurityLabelPrefix Pod Security Admission 命名空间标签前缀
+const PodSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+// PodSecurityLevel 返回 Pod Security Admission 指定模式（enforce、audit、warn）的级别，未设置时为空
+func (n NamespaceInfo) PodSecurityLevel(mode string) string {
+	return n.Labels[PodSecurityLabelPrefix+mode]
+}
+
+// PodSecurityPolicyListResponse 表示 /apis/policy/v1beta1/podsecuritypolicies 的响应结构（Kubernetes 1.25 起已移除）
+type PodSecurityPolicyListResponse struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Spec struct {
+			Privileged          bool     `json:"privileged"`
+			HostPID             bool     `json:"hostPID"`
+			HostIPC             bool     `json:"hostIPC"`
+			HostNetwork         bool     `json:"hostNetwork"`
+			Volumes             []string `json:"volumes"`
+			AllowedCapabilities []string `json:"allowedCapabilities"`
+			AllowedHostPaths    []struct {
+				PathPrefix string `json:"pathPrefix"`
+				ReadOnly   bool   `json:"readOnly"`
+			} `json:"allowedHostPaths"`
+			RunAsUser struct {
+				Rule string `json:"rule"`
+			} `json:"runAsUser"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// PodSecurityPolicyInfo 表示 PodSecurityPolicy 中与逃逸相关的字段
+type PodSecurityPolicyInfo struct {
+	Name                string
+	Privileged          bool
+	HostPID             bool
+	HostIPC             bool
+	HostNetwork         bool
+	Volumes             []string
+	AllowedCapabilities []string
+	AllowedHostPaths    []string // pathPrefix，只读的带 (ro) 后缀
+	RunAsUserRule       string
+}
+
+// LabelSelector 标签选择器（matchLabels 与 matchExpressions 同时满足才匹配）
+type LabelSelector struct {
+	MatchLabels      map[string]string          `json:"matchLabels,omitempty"`
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+}
+
+// LabelSelectorRequirement 标签选择器表达式
+type LabelSelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"` // In、NotIn、Exists、DoesNotExist
+	Values   []string `json:"values,omitempty"`
+}
+
+// WebhookRule 准入 Webhook 拦截的操作和资源
+type WebhookRule struct {
+	Operations  []string `json:"operations"`
+	APIGroups   []string `json:"apiGroups"`
+	APIVersions []string `json:"apiVersions"`
+	Resources   []string `json:"resources"`
+	Scope       string   `json:"scope,omitempty"` // Cluster、Namespaced 或 *
+}
+
+// WebhookConfigurationListResponse 表示
+// /apis/admissionregistration.k8s.io/v1/{mutating,validating}webhookconfigurations 的响应结构
+type WebhookConfigurationListResponse struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Webhooks []struct {
+			Name              string         `json:"name"`
+			FailurePolicy     string         `json:"failurePolicy"`
+			TimeoutSeconds    int            `json:"timeoutSeconds"`
+			NamespaceSelector *LabelSelector `json:"namespaceSelector"`
+			ObjectSelector    *LabelSelector `json:"objectSelector"`
+			Rules             []WebhookRule  `json:"rules"`
+			ClientConfig      struct {
+				URL     string `json:"url"`
+				Service *struct {
+					Namespace string `json:"namespace"`
+					Name      string `json:"name"`
+					Path      string `json:"path"`
+					Port      int    `json:"port"`
+				} `json:"service"`
+			} `json:"clientConfig"`
+		} `json:"webhooks"`
+	} `json:"items"`
+}
+
+// WebhookInfo 表示 Mutating / ValidatingWebhookConfiguration 中的单个 Webhook
+type WebhookInfo struct {
+	Kind              string // Mutating 或 Validating
+	Configuration     string // 所属 WebhookConfiguration 名称
+	Name              string
+	FailurePolicy     string // Fail 或 Ignore，未设置时为 Fail（admissionregistration/v1 默认值）
+	TimeoutSeconds    int
+	NamespaceSelector *LabelSelector
+	ObjectSelector    *LabelSelector
+	Rules             []WebhookRule
+	Endpoint          string // service ns/name:port/path 或 URL
+}
+
+// ServicePort Service 端口
+type ServicePort struct {
+	Name       string
+	Protocol   string
+	Port       int
+	TargetPort string // 后端容器端口号或端口名
+	NodePort   int
+}
+
+// ServiceListResponse 表示 /api/v1/services 的响应结构
+type ServiceListResponse struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			Type         string   `json:"type"`
+			ClusterIP    string   `json:"clusterIP"`
+			ExternalIPs  []string `json:"externalIPs"`
+			ExternalName string   `json:"externalName"`
+			Ports        []struct {
+				Name       string          `json:"name"`
+				Protocol   string          `json:"protocol"`
+				Port       int             `json:"port"`
+				TargetPort json.RawMessage `json:"targetPort"` // 整数或字符串
+				NodePort   int             `json:"nodePort"`
+			} `json:"ports"`
+			Selector map[string]string `json:"selector"`
+		} `json:"spec"`
+		Status struct {
+			LoadBalancer struct {
+				Ingress []struct {
+					IP       string `json:"ip"`
+					Hostname string `json:"hostname"`
+				} `json:"ingress"`
+			} `json:"loadBalancer"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// ServiceInfo 表示 Service 对象信息
+type ServiceInfo struct {
+	Namespace    string
+	Name         string
+	Type         string // ClusterIP、NodePort、LoadBalancer、ExternalName
+	ClusterIP    string
+	ExternalIPs  []string // externalIPs 及 LoadBalancer 入口
+	ExternalName string
+	Ports        []ServicePort
+	Selector     map[string]string
+	Endpoints    []ServiceEndpoint // 就绪的后端（来自 Endpoints 对象）
+	Source       string            // 信息来源: apiserver、env 或 dns
+}
+
+// ServiceEndpoint Service 的后端地址
+type ServiceEndpoint struct {
+	IP  string
+	Pod string // 后端 Pod 名称（targetRef 不是 Pod 时为空）
+}
+
+// EndpointsListResponse 表示 /api/v1/endpoints 的响应结构
+type EndpointsListResponse struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Subsets []struct {
+			Addresses []struct {
+				IP        string `json:"ip"`
+				TargetRef *struct {
+					Kind string `json:"kind"`
+					Name string `json:"name"`
+				} `json:"targetRef"`
+			} `json:"addresses"`
+		} `json:"subsets"`
+	} `json:"items"`
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// ==================== 凭据（harvest）相关类型 ====================
+
+// 凭据类型
+const (
+	CredentialToken      = "token"       // Bearer Token（含 bootstrap token）
+	CredentialClientCert = "client-cert" // 客户端证书 + 私钥
+)
+
+// CredentialRecord 从节点等位置收集的非 ServiceAccount 凭据
+type CredentialRecord struct {
+	ID          int64     `json:"id"`
+	Kind        string    `json:"kind"`       // token, client-cert
+	Identity    string    `json:"identity"`   // 认证身份，如 system:node:worker-1、kubernetes-admin
+	Groups      string    `json:"groups"`     // 逗号分隔的组（证书 O 字段）
+	Source      string    `json:"source"`     // 来源，如 worker-1:/etc/kubernetes/kubelet.conf
+	Server      string    `json:"server"`     // 凭据所属 kubeconfig 中的 API Server 地址
+	CAData      string    `json:"caData"`     // PEM 格式 CA 证书
+	Token       string    `json:"token"`      // kind 为 token 时
+	ClientCert  string    `json:"clientCert"` // kind 为 client-cert 时，PEM
+	ClientKey   string    `json:"clientKey"`  // kind 为 client-cert 时，PEM
+	ExpiresAt   time.Time `json:"expiresAt"`  // 证书过期时间，未知时为零值
+	CollectedAt time.Time `json:"collectedAt"`
+	KubeletIP   string    `json:"kubeletIP"`
+}
+
+// IsAdmin 凭据是否属于集群管理员组
+func (r *CredentialRecord) IsAdmin() bool {
+	for _, g := range strings.Split(r.Groups, ",") {
+		if g == "system:masters" || g == "kubeadm:cluster-admins" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package types
+
+import "time"
+
+// ==================== 部署（deploy）相关类型 ====================
+
+// ResourceRef 定位 API Server 中的一个资源
+type ResourceRef struct {
+	APIVersion string `json:"apiVersion"` // v1, apps/v1 等
+	Kind       string `json:"kind"`       // Pod, DaemonSet 等
+	Namespace  string `json:"namespace"`  // 集群级资源为空
+	Name       string `json:"name"`
+}
+
+// String 返回 Kind/namespace/name 形式的描述
+func (r ResourceRef) String() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// DeploymentRecord 表示由 deploy 命令创建并记录在数据库中的资源
+type DeploymentRecord struct {
+	ID        int64       `json:"id"`
+	Resource  ResourceRef `json:"resource"`
+	Template  string      `json:"template"` // 使用的模板: hostpath, nsenter, node-shell, custom
+	Node      string      `json:"node"`     // 指定的节点（可为空）
+	Settings  string      `json:"settings"` // 存活相关设置，如 priorityClass=...,tolerations=all,restartPolicy=Always
+	CreatedAt time.Time   `json:"createdAt"`
+}
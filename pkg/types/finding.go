@@ -0,0 +1,21 @@
+package types
+
+import "time"
+
+// ==================== 发现（Findings）相关类型 ====================
+
+// FindingRecord 表示存储在数据库中的一条发现（如凭据泄露）
+type FindingRecord struct {
+	ID          int64     `json:"id"`
+	Source      string    `json:"source"`    // 来源命令: hunt, configmaps 等
+	Severity    string    `json:"severity"`  // 严重程度: CRITICAL, HIGH, MEDIUM, LOW
+	Category    string    `json:"category"`  // 类别: file, env, secret-mount, configmap
+	Namespace   string    `json:"namespace"` // 命名空间
+	Pod         string    `json:"pod"`       // Pod 名称（ConfigMap 来源时为 ConfigMap 名称，rbac 来源时为 SA 名称，webhooks 来源时为 WebhookConfiguration 名称）
+	Container   string    `json:"container"` // 容器名称
+	Location    string    `json:"location"`  // 位置: 文件路径、环境变量名、ConfigMap 键
+	Title       string    `json:"title"`     // 描述
+	Evidence    string    `json:"evidence"`  // 证据片段（已截断）
+	CollectedAt time.Time `json:"collectedAt"`
+	KubeletIP   string    `json:"kubeletIP"`
+}
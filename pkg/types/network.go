@@ -0,0 +1,16 @@
+package types
+
+import "time"
+
+// ==================== 网络侦察相关类型 ====================
+
+// PortRecord 表示 pscan 从 Pod 内发现的开放端口
+type PortRecord struct {
+	ID        int64     `json:"id"`
+	IP        string    `json:"ip"`
+	Port      int       `json:"port"`
+	Service   string    `json:"service"` // 按端口推测的服务，如 redis、kubelet
+	Source    string    `json:"source"`  // 执行扫描的 Pod: namespace/name
+	ScannedAt time.Time `json:"scannedAt"`
+	KubeletIP string    `json:"kubeletIP"`
+}
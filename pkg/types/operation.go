@@ -0,0 +1,65 @@
+package types
+
+import "time"
+
+// ==================== 操作审计（oplog）相关类型 ====================
+
+// 操作类型
+const (
+	OpExec            = "exec"             // 在容器中执行命令
+	OpExecInteractive = "exec-interactive" // 交互式 shell
+	OpRun             = "run"              // Kubelet /run
+	OpPortForward     = "port-forward"     // 端口转发
+	OpAttach          = "attach"           // 附加到容器
+	OpEphemeral       = "ephemeral"        // 注入临时容器
+	OpCreate          = "create"           // 创建资源
+	OpDelete          = "delete"           // 删除资源
+)
+
+// OperationRecord 对集群执行的一次修改或执行操作
+type OperationRecord struct {
+	ID       int64     `json:"id"`
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`   // 操作类型，如 exec、create
+	Target   string    `json:"target"`   // 目标，如 namespace/pod/container、Kind/namespace/name
+	Detail   string    `json:"detail"`   // 执行的命令、端口或镜像等
+	Identity string    `json:"identity"` // 使用的身份，如 system:serviceaccount:ns:name
+	Via      string    `json:"via"`      // 请求的端点，如 kubelet 10.0.0.1:10250
+	Command  string    `json:"command"`  // 触发该操作的控制台命令
+	Result   string    `json:"result"`   // ok 或 error
+	Error    string    `json:"error,omitempty"`
+}
+
+// 操作结果
+const (
+	OpResultOK    = "ok"
+	OpResultError = "error"
+)
+
+// Failed 操作是否失败
+func (r *OperationRecord) Failed() bool {
+	return r.Result == OpResultError
+}
+
+// ==================== 批量执行结果相关类型 ====================
+
+// ExecResultRecord exec --all-pods 在单个容器中的执行结果，同一次批量执行的结果共享 RunID
+type ExecResultRecord struct {
+	ID        int64     `json:"id"`
+	RunID     int64     `json:"runId"`
+	Time      time.Time `json:"time"`
+	Node      string    `json:"node"` // 执行所经的 Kubelet（ip:port）
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	Container string    `json:"container"`
+	Command   string    `json:"command"`
+	Stdout    string    `json:"stdout"`
+	Stderr    string    `json:"stderr"`
+	ExitCode  int       `json:"exitCode"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Failed 执行是否失败（请求失败或命令返回非零退出码）
+func (r *ExecResultRecord) Failed() bool {
+	return r.Error != ""
+}
@@ -1,6 +1,9 @@
 package types
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // ==================== Pod 相关类型 ====================
 
@@ -34,9 +37,15 @@ type PodContainerInfo struct {
 	NodeName       string
 	ServiceAccount string
 	CreatedAt      string
+	Labels         map[string]string
 	Containers     []ContainerDetail
-	Volumes        []VolumeDetail
-	SecurityFlags  SecurityFlags
+	// InitContainers、EphemeralContainers 不参与 exec 等操作的容器选择，但计入安全标识
+	InitContainers      []ContainerDetail `json:",omitempty"`
+	EphemeralContainers []ContainerDetail `json:",omitempty"`
+	Volumes             []VolumeDetail
+	SecurityFlags       SecurityFlags
+	PodSecurity         *PodSecurityContext // Pod 级安全上下文（seccomp、AppArmor、sysctls 等）
+	Tolerations         []Toleration
 }
 
 // ContainerDetail 容器详细信息
@@ -49,7 +58,18 @@ type ContainerDetail struct {
 	StartedAt    string
 	VolumeMounts []VolumeMountDetail
 	Privileged   bool
-	AllowPE      bool // AllowPrivilegeEscalation
+	AllowPE      bool              // AllowPrivilegeEscalation
+	Env          map[string]string // 直接设置值的环境变量（不含 valueFrom）
+	RunAsUser    *int64
+	RunAsGroup   *int64
+	RunAsNonRoot *bool
+	ReadOnlyRoot bool     // ReadOnlyRootFilesystem
+	CapAdd       []string // 添加的 capabilities
+	CapDrop      []string // 移除的 capabilities
+	Seccomp      string   // 容器级 seccomp 配置，为空时继承 Pod 级配置
+	AppArmor     string   // 容器级 AppArmor 配置（含旧版注解），为空时继承 Pod 级配置
+	Sidecar      bool     `json:",omitempty"` // restartPolicy 为 Always 的 init 容器（原生 sidecar）
+	Target       string   `json:",omitempty"` // 临时容器共享进程命名空间的目标容器
 }
 
 // VolumeMountDetail 卷挂载详情
@@ -64,8 +84,8 @@ type VolumeMountDetail struct {
 // VolumeDetail 卷详情
 type VolumeDetail struct {
 	Name   string
-	Type   string // hostPath, secret, configMap, emptyDir, projected
-	Source string // hostPath 路径或 secret/configMap 名称
+	Type   string // hostPath, secret, configMap, emptyDir, projected, persistentVolumeClaim, downwardAPI
+	Source string // hostPath 路径、secret/configMap/PVC 名称或 projected 来源
 }
 
 // PodInfo 表示从 Kubelet API 获取的 Pod 基本信息
@@ -82,13 +102,16 @@ type PodInfo struct {
 // ContainerInfo 存储容器的安全相关信息
 type ContainerInfo struct {
 	Name                     string   `json:"name"`
+	Kind                     string   `json:"kind,omitempty"` // 空为普通容器，init 或 ephemeral
 	Image                    string   `json:"image"`
 	RunAsUser                *int64   `json:"runAsUser,omitempty"`
 	RunAsGroup               *int64   `json:"runAsGroup,omitempty"`
 	Privileged               bool     `json:"privileged"`
 	AllowPrivilegeEscalation bool     `json:"allowPrivilegeEscalation"`
 	ReadOnlyRootFilesystem   bool     `json:"readOnlyRootFilesystem"`
-	VolumeMounts             []string `json:"volumeMounts"` // 挂载路径列表
+	VolumeMounts             []string `json:"volumeMounts"`             // 挂载路径列表
+	Capabilities             []string `json:"capabilities,omitempty"`   // 添加的 capabilities
+	SeccompProfile           string   `json:"seccompProfile,omitempty"` // 容器级 seccomp 配置
 }
 
 // ContainerSecurityInfo 容器安全信息（详细）
@@ -135,6 +158,20 @@ type SecurityFlags struct {
 	HasHostPath              bool `json:"hasHostPath"`              // 挂载了 HostPath
 	HasSecretMount           bool `json:"hasSecretMount"`           // 挂载了 Secret
 	HasSATokenMount          bool `json:"hasSATokenMount"`          // 挂载了 ServiceAccount Token
+	HostPID                  bool `json:"hostPID"`                  // 共享主机 PID 命名空间
+	HostNetwork              bool `json:"hostNetwork"`              // 共享主机网络命名空间
+	HostIPC                  bool `json:"hostIPC"`                  // 共享主机 IPC 命名空间
+	RunAsRoot                bool `json:"runAsRoot"`                // 显式以 root (UID 0) 运行
+	NoSeccomp                bool `json:"noSeccomp"`                // 存在未配置 seccomp 或为 Unconfined 的容器
+
+	Capabilities []string `json:"capabilities,omitempty"` // 添加的高危 capabilities（如 SYS_ADMIN、NET_ADMIN）
+}
+
+// AddCapability 记录高危 capability（去重）
+func (f *SecurityFlags) AddCapability(capability string) {
+	if !slices.Contains(f.Capabilities, capability) {
+		f.Capabilities = append(f.Capabilities, capability)
+	}
 }
 
 // ==================== Pod 安全摘要 ====================
@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+// ==================== 扫描历史相关类型 ====================
+
+// ScanRecord 表示一次 sa scan 的运行记录
+type ScanRecord struct {
+	ID        int64         `json:"id"`
+	StartedAt time.Time     `json:"startedAt"`
+	Duration  time.Duration `json:"duration"`
+	Target    string        `json:"target"`  // Kubelet 地址
+	Mode      string        `json:"mode"`    // active, passive
+	Partial   bool          `json:"partial"` // 采样或被中断，结果不完整
+	Pods      int           `json:"pods"`    // 扫描的 Pod 数量
+	SAs       int           `json:"sas"`     // 发现的 SA 数量
+	Risky     int           `json:"risky"`   // CRITICAL / HIGH / MEDIUM 数量
+	Admins    int           `json:"admins"`  // 集群管理员数量
+	Sampled   int           `json:"sampled"` // 抽样扫描的样本大小（Pod 数），0 表示未抽样
+}
+
+// 扫描模式
+const (
+	ScanModeActive  = "active"
+	ScanModePassive = "passive"
+)
@@ -1,6 +1,10 @@
 package types
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
 // ==================== ServiceAccount 相关类型 ====================
 
@@ -19,6 +23,22 @@ type ServiceAccountRecord struct {
 	Pods            string    `json:"pods"`            // JSON 格式的关联 Pod 列表
 	CollectedAt     time.Time `json:"collectedAt"`     // 收集时间
 	KubeletIP       string    `json:"kubeletIP"`       // 收集来源 Kubelet IP
+	Note            string    `json:"note"`            // 备注（sa note）
+	Tags            string    `json:"tags"`            // 逗号分隔的标签（sa tag）
+	ScanID          int64     `json:"scanId"`          // 最近一次发现该 SA 的扫描（sa history）
+}
+
+// TagList 返回标签列表
+func (r *ServiceAccountRecord) TagList() []string {
+	if r.Tags == "" {
+		return nil
+	}
+	return strings.Split(r.Tags, ",")
+}
+
+// PermissionsChecked 是否检查过权限（sa scan --passive 的记录不读取 Token，permissions 为空）
+func (r *ServiceAccountRecord) PermissionsChecked() bool {
+	return r.Permissions != ""
 }
 
 // SAPermission 存储单个权限信息
@@ -30,13 +50,55 @@ type SAPermission struct {
 	Allowed     bool   `json:"allowed"`
 }
 
-// SASecurityFlags 存储安全标识
+// SASecurityFlags 存储安全标识（使用该 SA 的所有 Pod 的标识合并）
 type SASecurityFlags struct {
-	Privileged               bool `json:"privileged"`
-	AllowPrivilegeEscalation bool `json:"allowPrivilegeEscalation"`
-	HasHostPath              bool `json:"hasHostPath"`
-	HasSecretMount           bool `json:"hasSecretMount"`
-	HasSATokenMount          bool `json:"hasSATokenMount"`
+	Privileged               bool     `json:"privileged"`
+	AllowPrivilegeEscalation bool     `json:"allowPrivilegeEscalation"`
+	HasHostPath              bool     `json:"hasHostPath"`
+	HasSecretMount           bool     `json:"hasSecretMount"`
+	HasSATokenMount          bool     `json:"hasSATokenMount"`
+	HostPID                  bool     `json:"hostPID,omitempty"`
+	HostNetwork              bool     `json:"hostNetwork,omitempty"`
+	HostIPC                  bool     `json:"hostIPC,omitempty"`
+	RunAsRoot                bool     `json:"runAsRoot,omitempty"`
+	NoSeccomp                bool     `json:"noSeccomp,omitempty"`
+	Capabilities             []string `json:"capabilities,omitempty"`
+}
+
+// Merge 合并一个 Pod 的安全标识
+func (f *SASecurityFlags) Merge(flags SecurityFlags) {
+	f.Privileged = f.Privileged || flags.Privileged
+	f.AllowPrivilegeEscalation = f.AllowPrivilegeEscalation || flags.AllowPrivilegeEscalation
+	f.HasHostPath = f.HasHostPath || flags.HasHostPath
+	f.HasSecretMount = f.HasSecretMount || flags.HasSecretMount
+	f.HasSATokenMount = f.HasSATokenMount || flags.HasSATokenMount
+	f.HostPID = f.HostPID || flags.HostPID
+	f.HostNetwork = f.HostNetwork || flags.HostNetwork
+	f.HostIPC = f.HostIPC || flags.HostIPC
+	f.RunAsRoot = f.RunAsRoot || flags.RunAsRoot
+	f.NoSeccomp = f.NoSeccomp || flags.NoSeccomp
+	for _, c := range flags.Capabilities {
+		if !slices.Contains(f.Capabilities, c) {
+			f.Capabilities = append(f.Capabilities, c)
+		}
+	}
+}
+
+// PodFlags 转换为 Pod 安全标识，便于统一显示
+func (f SASecurityFlags) PodFlags() SecurityFlags {
+	return SecurityFlags{
+		Privileged:               f.Privileged,
+		AllowPrivilegeEscalation: f.AllowPrivilegeEscalation,
+		HasHostPath:              f.HasHostPath,
+		HasSecretMount:           f.HasSecretMount,
+		HasSATokenMount:          f.HasSATokenMount,
+		HostPID:                  f.HostPID,
+		HostNetwork:              f.HostNetwork,
+		HostIPC:                  f.HostIPC,
+		RunAsRoot:                f.RunAsRoot,
+		NoSeccomp:                f.NoSeccomp,
+		Capabilities:             f.Capabilities,
+	}
 }
 
 // SAPodInfo 存储关联的 Pod 信息
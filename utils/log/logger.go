@@ -3,6 +3,7 @@ package log
 import (
 	log "github.com/sirupsen/logrus"
 	prefixed "github.com/x-cray/logrus-prefixed-formatter"
+	"kctl/internal/output"
 	"os"
 )
 
@@ -50,6 +51,7 @@ func logFormat() log.Formatter {
 	formatter := new(prefixed.TextFormatter)
 	formatter.FullTimestamp = true
 	formatter.TimestampFormat = "2006-01-02 15:04:05"
+	formatter.DisableColors = output.Plain()
 	formatter.SetColorScheme(&prefixed.ColorScheme{
 		PrefixStyle:    "blue+b",
 		TimestampStyle: "white+h",